@@ -0,0 +1,9 @@
+package treacherest
+
+import _ "embed"
+
+// AttributionText is the licence and attribution notice shown on /about. It
+// is always embedded, even when the card assets are left out.
+//
+//go:embed static/ATTRIBUTION.txt
+var AttributionText string
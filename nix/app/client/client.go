@@ -0,0 +1,265 @@
+// Package client talks to a treacherest server the way a browser does, so Go
+// integrations and tools can create and join rooms, start games, search cards
+// and follow a room's event stream without hand-rolling the HTTP calls.
+//
+// A Client is one seat: like a browser, it keeps the session and player
+// cookies the server sets and sends them back. Use one Client per player.
+// It only depends on the standard library.
+//
+// Creating and joining rooms are bot-checked forms, and the client makes no
+// attempt to pass for a browser: against a server with bot checks on, they
+// fail with a 403 StatusError. Run integrations against a server with
+// server.botChecks off.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// StatusError is returned for a response the call didn't expect
+type StatusError struct {
+	StatusCode int
+	Body       string // the start of the response body, usually the server's message
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("treacherest: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// maxErrorBody bounds how much of an unexpected response ends up in a StatusError
+const maxErrorBody = 512
+
+// Client is one browser-like seat on a treacherest server
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+}
+
+// New returns a client for the server at baseURL, e.g. "http://localhost:8080".
+// httpClient may be nil; its cookie jar and redirect policy are replaced
+// either way, since the client relies on both.
+func New(baseURL string, httpClient *http.Client) (*Client, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("treacherest: base URL %q needs a scheme and host", baseURL)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	hc := &http.Client{}
+	if httpClient != nil {
+		*hc = *httpClient
+	}
+	hc.Jar = jar
+	// The room code is read from where the server sends the browser next
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	return &Client{baseURL: base, http: hc}, nil
+}
+
+// RoomOptions are the create and join form fields
+type RoomOptions struct {
+	PlayerName string // empty for a generated name
+	PIN        string // optional 4-digit seat PIN
+	HostOnly   bool   // create only: run the room without playing
+	RulesMode  string // create only: "treachery" (default) or "coup"
+}
+
+// CreateRoom creates a room with this client as its Room Operator and
+// returns the room code
+func (c *Client) CreateRoom(ctx context.Context, opts RoomOptions) (string, error) {
+	form := url.Values{"playerName": {opts.PlayerName}, "rulesMode": {opts.RulesMode}}
+	if form.Get("rulesMode") == "" {
+		form.Set("rulesMode", "treachery")
+	}
+	if opts.PIN != "" {
+		form.Set("pin", opts.PIN)
+	}
+	if opts.HostOnly {
+		form.Set("hostOnly", "true")
+	}
+
+	location, err := c.postForRedirect(ctx, "/room/new", form)
+	if err != nil {
+		return "", err
+	}
+	code := strings.TrimPrefix(strings.TrimPrefix(location, "/room/"), "/host/")
+	if code == "" || code == location {
+		return "", fmt.Errorf("treacherest: create room redirected to %q", location)
+	}
+	return code, nil
+}
+
+// JoinRoom takes a seat in the room's lobby
+func (c *Client) JoinRoom(ctx context.Context, roomCode string, opts RoomOptions) error {
+	form := url.Values{"room_code": {roomCode}, "player_name": {opts.PlayerName}}
+	if opts.PIN != "" {
+		form.Set("pin", opts.PIN)
+	}
+	_, err := c.postForRedirect(ctx, "/join-room", form)
+	return err
+}
+
+// StartError is a start the server refused, e.g. by a player who isn't the
+// Room Operator or in a room without enough players
+type StartError struct {
+	Message string
+}
+
+func (e *StartError) Error() string {
+	return "treacherest: game not started: " + e.Message
+}
+
+// StartGame starts the room's game; only the Room Operator may
+func (c *Client) StartGame(ctx context.Context, roomCode string) error {
+	// Starting as a lobby page skips the redirect script meant for browsers;
+	// the room stream carries the seat into the game
+	resp, err := c.do(ctx, http.MethodPost, "/room/"+url.PathEscape(roomCode)+"/start?view=lobby", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := expectStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	// A refused start answers with the page's error patches
+	return &StartError{Message: startErrorMessage(NewStream(resp.Body))}
+}
+
+// startErrorMessage reads the reason from a refused start's patches: the
+// startError signal when it's sent, else the error fragment's text
+func startErrorMessage(stream *Stream) string {
+	message := "start refused"
+	for {
+		event, err := stream.Next()
+		if err != nil {
+			return message
+		}
+		text := event.Text()
+		if raw, ok := strings.CutPrefix(text, "signals "); ok {
+			var signals struct {
+				StartError string `json:"startError"`
+			}
+			if json.Unmarshal([]byte(raw), &signals) == nil && signals.StartError != "" {
+				return signals.StartError
+			}
+		}
+		if _, rest, ok := strings.Cut(text, "<span>"); ok {
+			if span, _, ok := strings.Cut(rest, "</span>"); ok {
+				message = strings.TrimSpace(span)
+			}
+		}
+	}
+}
+
+// LeaveRoom gives up this client's seat
+func (c *Client) LeaveRoom(ctx context.Context, roomCode string) error {
+	resp, err := c.do(ctx, http.MethodPost, "/room/"+url.PathEscape(roomCode)+"/leave", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return expectStatus(resp, http.StatusOK, http.StatusNoContent, http.StatusSeeOther)
+}
+
+// Card is one card search result
+type Card struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Anchor   string `json:"anchor"`
+	RoleType string `json:"roleType"`
+	Text     string `json:"text"`
+	Score    int    `json:"score"`
+}
+
+// SearchCards searches card names and rules text, best match first. limit
+// of 0 uses the server's default.
+func (c *Client) SearchCards(ctx context.Context, query string, limit int) ([]Card, error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var body struct {
+		Results []Card `json:"results"`
+	}
+	if err := c.getJSON(ctx, "/api/v1/cards/search?"+params.Encode(), &body); err != nil {
+		return nil, err
+	}
+	return body.Results, nil
+}
+
+// getJSON decodes the JSON response of a GET into v
+func (c *Client) getJSON(ctx context.Context, path string, v any) error {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := expectStatus(resp, http.StatusOK); err != nil {
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// postForRedirect posts a form the server answers with a redirect, and
+// returns where it sends the browser
+func (c *Client) postForRedirect(ctx context.Context, path string, form url.Values) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, path, form)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := expectStatus(resp, http.StatusSeeOther); err != nil {
+		return "", err
+	}
+	return resp.Header.Get("Location"), nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, form url.Values) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return c.http.Do(req)
+}
+
+// expectStatus returns a StatusError unless resp has one of the statuses
+func expectStatus(resp *http.Response, statuses ...int) error {
+	for _, status := range statuses {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+// IsStatus reports whether err is a StatusError with the given status
+func IsStatus(err error, status int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == status
+}
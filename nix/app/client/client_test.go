@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/handlers"
+	"treacherest/internal/store"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Server.BotChecks = false
+	cards := &game.CardService{
+		Leaders:   []*game.Card{{ID: 1, Name: "The Queen", Types: game.CardTypes{Subtype: "Leader"}, Base64Image: "data:image/jpeg;base64,test"}},
+		Guardians: []*game.Card{{ID: 2, Name: "The Bodyguard", Types: game.CardTypes{Subtype: "Guardian"}, Base64Image: "data:image/jpeg;base64,test"}},
+		Assassins: []*game.Card{{ID: 3, Name: "The Ninja", Types: game.CardTypes{Subtype: "Assassin"}, Base64Image: "data:image/jpeg;base64,test"}},
+		Traitors:  []*game.Card{{ID: 4, Name: "The Puppet Master", Types: game.CardTypes{Subtype: "Traitor"}, Base64Image: "data:image/jpeg;base64,test"}},
+	}
+	s := store.NewMemoryStore(cfg)
+	s.SetCardService(cards)
+	h := handlers.New(s, cards, cfg, nil)
+	server := httptest.NewServer(handlers.SetupRouter(h, cfg, &handlers.RouterOptions{DisableRateLimiting: true, DisableRequestLogger: true}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientFollowsARoomIntoTheGame(t *testing.T) {
+	server := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	operator, err := New(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := operator.CreateRoom(ctx, RoomOptions{PlayerName: "Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player, _ := New(server.URL, nil)
+	if err := player.JoinRoom(ctx, code, RoomOptions{PlayerName: "Bob", PIN: "1234"}); err != nil {
+		t.Fatal(err)
+	}
+	stranger, _ := New(server.URL, nil)
+	if err := stranger.JoinRoom(ctx, code, RoomOptions{PlayerName: "Carol", PIN: "12"}); !IsStatus(err, http.StatusBadRequest) {
+		t.Fatalf("expected a bad PIN to be refused with a StatusError, got %v", err)
+	}
+	stranger.JoinRoom(ctx, code, RoomOptions{PlayerName: "Carol"})
+
+	stream, err := player.OpenRoomStream(ctx, code, "lobby")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	// The lobby stream sends its validation signals on connect
+	if event, err := stream.Next(); err != nil || !strings.HasPrefix(event.Text(), "signals") {
+		t.Fatalf("expected the lobby signals first, got %+v, %v", event, err)
+	}
+
+	var refused *StartError
+	if err := player.StartGame(ctx, code); !errors.As(err, &refused) || !strings.Contains(refused.Message, "room operator") {
+		t.Errorf("expected a player to be refused the start, got %v", err)
+	}
+	if err := operator.StartGame(ctx, code); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		event, err := stream.Next()
+		if err != nil {
+			t.Fatalf("stream ended before the game body arrived: %v", err)
+		}
+		if event.Type == "datastar-patch-elements" && strings.Contains(event.Text(), `id="game-container"`) {
+			break
+		}
+	}
+}
+
+func TestClientSearchesCards(t *testing.T) {
+	server := newTestServer(t)
+	c, _ := New(server.URL+"/", nil)
+
+	cards, err := c.SearchCards(context.Background(), "ninja", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cards) == 0 || cards[0].Name != "The Ninja" {
+		t.Fatalf("expected The Ninja first, got %+v", cards)
+	}
+	if _, err := c.SearchCards(context.Background(), strings.Repeat("a", 500), 0); !IsStatus(err, http.StatusBadRequest) {
+		t.Errorf("expected an overlong query to come back as a 400, got %v", err)
+	}
+}
+
+func TestStreamParsesEvents(t *testing.T) {
+	raw := ": keepalive\n\n" +
+		"event: datastar-patch-signals\nid: 7\ndata: signals {\"a\":1}\n\n" +
+		"data: first\ndata:second\n\n"
+	stream := NewStream(io.NopCloser(strings.NewReader(raw)))
+
+	event, err := stream.Next()
+	if err != nil || event.Type != "datastar-patch-signals" || event.ID != "7" || event.Text() != `signals {"a":1}` {
+		t.Fatalf("unexpected first event %+v, %v", event, err)
+	}
+	event, err = stream.Next()
+	if err != nil || event.Type != "message" || event.Text() != "first\nsecond" {
+		t.Fatalf("unexpected second event %+v, %v", event, err)
+	}
+	if _, err := stream.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF at the end, got %v", err)
+	}
+}
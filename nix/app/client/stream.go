@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Event is one server-sent event. The server's events are Datastar patches,
+// so Data holds lines such as "elements <div ...>" or "signals {...}".
+type Event struct {
+	Type string   // e.g. "datastar-patch-elements"; "message" when unnamed
+	ID   string   // set on events a reconnect can resume from
+	Data []string // one entry per data line
+}
+
+// Text returns the event's data lines joined with newlines
+func (e Event) Text() string {
+	return strings.Join(e.Data, "\n")
+}
+
+// Stream is an open event stream
+type Stream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// maxEventLine bounds one line of a stream; patched page bodies can be large
+const maxEventLine = 4 << 20
+
+// OpenRoomStream opens the room's stream as a player page would, following
+// the seat from lobby into game. view may be "" or "lobby" for a player, or
+// "host" for the Operator Dashboard.
+func (c *Client) OpenRoomStream(ctx context.Context, roomCode, view string) (*Stream, error) {
+	path := "/sse/room/" + url.PathEscape(roomCode)
+	if view != "" {
+		path += "?view=" + url.QueryEscape(view)
+	}
+	return c.OpenStream(ctx, path)
+}
+
+// OpenStream opens any of the server's event streams by path. Cancel ctx or
+// call Close to hang up.
+func (c *Client) OpenStream(ctx context.Context, path string) (*Stream, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL.String()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := expectStatus(resp, http.StatusOK); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return NewStream(resp.Body), nil
+}
+
+// NewStream reads events from r, e.g. a response body opened elsewhere
+func NewStream(r io.ReadCloser) *Stream {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxEventLine)
+	return &Stream{body: r, scanner: scanner}
+}
+
+// Next blocks until the next event arrives. It returns io.EOF once the
+// server ends the stream.
+func (s *Stream) Next() (Event, error) {
+	var event Event
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		if line == "" {
+			if event.Type == "" && event.Data == nil && event.ID == "" {
+				continue // keepalive or stray blank line
+			}
+			if event.Type == "" {
+				event.Type = "message"
+			}
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Type = value
+		case "data":
+			event.Data = append(event.Data, value)
+		case "id":
+			event.ID = value
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return Event{}, err
+	}
+	return Event{}, io.EOF
+}
+
+// Close hangs up the stream
+func (s *Stream) Close() error {
+	return s.body.Close()
+}
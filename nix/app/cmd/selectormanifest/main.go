@@ -0,0 +1,158 @@
+// Command selectormanifest scans the templ sources and writes the per-page
+// selector manifest used to validate outgoing Datastar element patches.
+//
+// Run it with go generate from internal/handlers after changing element ids.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// page is one streaming page type and the templates that render it
+type page struct {
+	Const string   // PageType constant in package handlers
+	Roots []string // package.Template entry points
+}
+
+var pages = []page{
+	{"PageLobby", []string{"pages.LobbyPage", "pages.LobbyPageWithDebug"}},
+	{"PageGame", []string{"pages.GamePage", "pages.GamePageWithDebug"}},
+	{"PageHost", []string{"pages.HostDashboardLobby", "pages.HostDashboardCountdownPage", "pages.HostDashboardPlayingPage", "pages.HostDashboardEndedPage"}},
+	{"PageOverlay", []string{"pages.OverlayPage"}},
+	{"PageWatch", []string{"pages.WatchPage", "pages.SpectatePage"}},
+}
+
+var (
+	templDecl   = regexp.MustCompile(`^templ\s+(\w+)\s*\(`)
+	goDecl      = regexp.MustCompile(`^(func|type|var|const)\s`)
+	literalID   = regexp.MustCompile(`(?:^|\s)id="([^"{}]+)"`)
+	templateUse = regexp.MustCompile(`@(?:(\w+)\.)?(\w+)\(`)
+)
+
+// template is one templ block: the literal ids it renders and the templates it calls
+type template struct {
+	ids   []string
+	calls []string
+}
+
+// scan parses every .templ file under viewsDir into templates keyed by package.Name
+func scan(viewsDir string) (map[string]*template, error) {
+	templates := make(map[string]*template)
+	err := filepath.WalkDir(viewsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".templ") {
+			return err
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pkg := filepath.Base(filepath.Dir(path))
+
+		var current *template
+		for _, line := range strings.Split(string(src), "\n") {
+			if m := templDecl.FindStringSubmatch(line); m != nil {
+				current = &template{}
+				templates[pkg+"."+m[1]] = current
+			} else if goDecl.MatchString(line) {
+				current = nil
+			}
+			if current == nil {
+				continue
+			}
+			for _, m := range literalID.FindAllStringSubmatch(line, -1) {
+				current.ids = append(current.ids, m[1])
+			}
+			for _, m := range templateUse.FindAllStringSubmatch(line, -1) {
+				callee := m[1]
+				if callee == "" {
+					callee = pkg
+				}
+				current.calls = append(current.calls, callee+"."+m[2])
+			}
+		}
+		return nil
+	})
+	return templates, err
+}
+
+// reachableIDs collects the ids rendered by roots and everything they call
+func reachableIDs(templates map[string]*template, roots []string) []string {
+	seen := make(map[string]bool)
+	ids := make(map[string]bool)
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		tmpl, ok := templates[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		for _, id := range tmpl.ids {
+			ids[id] = true
+		}
+		queue = append(queue, tmpl.calls...)
+	}
+
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// generate renders the manifest as Go source for package handlers
+func generate(viewsDir string) ([]byte, error) {
+	templates, err := scan(viewsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pages {
+		for _, root := range p.Roots {
+			if _, ok := templates[root]; !ok {
+				return nil, fmt.Errorf("root template %s not found", root)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/selectormanifest; DO NOT EDIT.\n\npackage handlers\n\n")
+	buf.WriteString("// pageSelectors lists the literal element ids each streaming page can render\n")
+	buf.WriteString("var pageSelectors = map[PageType]map[string]bool{\n")
+	for _, p := range pages {
+		fmt.Fprintf(&buf, "%s: {\n", p.Const)
+		for _, id := range reachableIDs(templates, p.Roots) {
+			fmt.Fprintf(&buf, "%q: true,\n", id)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	// go generate runs in internal/handlers; allow an explicit module root otherwise
+	root := "../.."
+	if len(os.Args) > 1 {
+		root = os.Args[1]
+	}
+
+	src, err := generate(filepath.Join(root, "internal", "views"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	out := filepath.Join(root, "internal", "handlers", "selector_manifest_gen.go")
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %s", out)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestManifestIsUpToDate(t *testing.T) {
+	want, err := generate("../../internal/views")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	got, err := os.ReadFile("../../internal/handlers/selector_manifest_gen.go")
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("selector manifest is stale; run go generate ./internal/handlers")
+	}
+}
+
+func TestReachableIDsFollowsCalls(t *testing.T) {
+	templates := map[string]*template{
+		"pages.Page":       {ids: []string{"page"}, calls: []string{"components.Card", "pages.Page"}},
+		"components.Card":  {ids: []string{"card"}},
+		"components.Other": {ids: []string{"other"}},
+	}
+	got := reachableIDs(templates, []string{"pages.Page"})
+	if len(got) != 2 || got[0] != "card" || got[1] != "page" {
+		t.Fatalf("expected [card page], got %v", got)
+	}
+}
@@ -3,26 +3,31 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"log"
-	"net"
-	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
-	"treacherest"
+
+	"treacherest/internal/app"
 	"treacherest/internal/config"
-	"treacherest/internal/game"
-	"treacherest/internal/handlers"
-	"treacherest/internal/store"
 )
 
 func main() {
+	cardSet := flag.String("card-set", "", `card data to serve: "embedded" or "sandbox" (overrides CARD_SET)`)
+	flag.Parse()
+
 	// Load server configuration
 	cfg, err := config.LoadConfig("")
 	if err != nil {
 		log.Fatal("Failed to load configuration: ", err)
 	}
+	if *cardSet != "" {
+		cfg.Server.CardSet = *cardSet
+		if err := cfg.Validate(); err != nil {
+			log.Fatal("Invalid -card-set: ", err)
+		}
+	}
 	log.Printf("Loaded configuration: max players per room = %d", cfg.Server.MaxPlayersPerRoom)
 
 	// Debug mode - dump config and enable verbose logging
@@ -36,94 +41,64 @@ func main() {
 		log.Printf("DEBUG: Debug mode enabled - verbose logging active")
 	}
 
-	// Create CardService with fail-fast initialization using embedded resources
-	cardService, err := game.NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
-	if err != nil {
-		log.Fatal("Failed to initialize card service: ", err)
-	}
-	if err := game.LoadCoupRoleImages(treacherest.CoupRoleImagesFS); err != nil {
-		log.Fatal("Failed to initialize Coup role images: ", err)
-	}
-
-	// Create BackupService for game state backup/restore
-	backupService, err := game.NewBackupService(
-		cfg.Server.BackupEncryptionKey,
-		cfg.Server.BackupEncryptionEnabled,
-	)
+	a, err := app.New(cfg)
 	if err != nil {
-		log.Fatal("Failed to initialize backup service: ", err)
-	}
-	if backupService.IsEnabled() {
-		log.Printf("Backup service initialized with encryption enabled")
-	} else {
-		log.Printf("Backup service initialized in DEBUG mode (encryption disabled)")
+		log.Fatal("Failed to initialize server: ", err)
 	}
-
-	// Create store and handler with configuration
-	s := store.NewMemoryStore(cfg)
-	s.SetCardService(cardService)
-	h := handlers.New(s, cardService, cfg, backupService)
-
-	// Use the unified router setup
-	r := handlers.SetupRouter(h, cfg, nil)
-
-	// Start server with production configuration
-	addr := cfg.Server.Host + ":" + cfg.Server.Port
+	a.CaptureRoomLogs()
+	a.RedactLogs()
 
 	serverCtx, stopServer := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stopServer()
 
-	// Create custom server with production settings
-	server := newHTTPServer(addr, r, cfg, serverCtx)
+	if err := a.Start(serverCtx); err != nil {
+		log.Fatal("Server failed to start:", err)
+	}
 
-	// Start server in goroutine
+	// SIGUSR1 drains SSE clients ahead of a deploy without stopping the server
+	drainSignal := make(chan os.Signal, 1)
+	signal.Notify(drainSignal, syscall.SIGUSR1)
+	defer signal.Stop(drainSignal)
 	go func() {
-		log.Printf("Starting server on %s", addr)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("Server failed to start:", err)
+		for range drainSignal {
+			log.Println("Draining SSE connections...")
+			drainCtx, cancel := context.WithTimeout(serverCtx, cfg.Server.DrainTimeout)
+			if err := a.Drain(drainCtx); err != nil {
+				log.Printf("Drain incomplete: %v", err)
+			} else {
+				log.Println("Drain complete")
+			}
+			cancel()
 		}
 	}()
 
-	// Wait for interrupt signal to gracefully shutdown the server.
-	<-serverCtx.Done()
-	stopServer()
-
-	log.Println("Shutting down server...")
-
-	// Create shutdown context with timeout
-	shutdownTimeout := cfg.Server.ShutdownTimeout
-	if shutdownTimeout <= 0 {
-		shutdownTimeout = 30 * time.Second
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-	defer cancel()
-
-	// Attempt graceful shutdown
-	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Graceful shutdown timed out: %v", err)
-		if closeErr := server.Close(); closeErr != nil {
-			log.Fatal("Server forced shutdown failed:", closeErr)
+	// SIGHUP re-reads the config; live rooms it would invalidate keep theirs
+	reloadSignal := make(chan os.Signal, 1)
+	signal.Notify(reloadSignal, syscall.SIGHUP)
+	defer signal.Stop(reloadSignal)
+	go func() {
+		for range reloadSignal {
+			next, err := config.LoadConfig("")
+			if err != nil {
+				log.Printf("Config reload failed, keeping the current config: %v", err)
+				continue
+			}
+			a.ReloadConfig(next)
 		}
-		log.Println("Server forced to stop")
-		return
-	}
-
-	log.Println("Server gracefully stopped")
-}
+	}()
 
-func newHTTPServer(addr string, handler http.Handler, cfg *config.ServerConfig, baseCtx context.Context) *http.Server {
-	if baseCtx == nil {
-		baseCtx = context.Background()
+	// Wait for interrupt signal (or a serve failure) to shut the server down
+	select {
+	case <-serverCtx.Done():
+	case err := <-a.Err():
+		if err != nil {
+			log.Fatal("Server failed:", err)
+		}
 	}
+	stopServer()
 
-	return &http.Server{
-		Addr:         addr,
-		Handler:      handler,
-		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
-		IdleTimeout:  cfg.Server.IdleTimeout, // 0 for SSE support
-		BaseContext: func(net.Listener) context.Context {
-			return baseCtx
-		},
+	log.Println("Shutting down server...")
+	if err := a.Shutdown(context.Background()); err != nil {
+		log.Fatal("Server forced shutdown failed:", err)
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
+	"treacherest/internal/views/components"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -41,6 +43,11 @@ func TestSetupServer(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
 			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.method == "POST" {
+				// As the create form's script would, to pass the bot checks
+				req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(components.BotElapsedField+"=5000"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
 			w := httptest.NewRecorder()
 
 			handler.ServeHTTP(w, req)
@@ -1,3 +1,5 @@
+//go:build !nocardassets
+
 package treacherest
 
 import (
@@ -5,16 +7,15 @@ import (
 	_ "embed"
 )
 
+// CardAssetsEmbedded reports whether the card JSON and images are compiled in.
+// Build with -tags nocardassets to leave them out and run on the sandbox card set.
+const CardAssetsEmbedded = true
+
 // Embed the treachery cards JSON file
 //
 //go:embed static/treachery-cards.json
 var TreacheryCardsJSON []byte
 
-// Embed all card images
-//
-//go:embed static/images/cards/*.jpg
-var CardImagesFS embed.FS
-
 // Embed optional Coup role images
 //
 //go:embed static/images/coup/*
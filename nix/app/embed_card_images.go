@@ -0,0 +1,14 @@
+//go:build !nocardassets && !nocardimages
+
+package treacherest
+
+import "embed"
+
+// CardImagesEmbedded reports whether the card artwork is compiled in. Build
+// with -tags nocardimages to ship card text without the artwork.
+const CardImagesEmbedded = true
+
+// Embed all card images
+//
+//go:embed static/images/cards/*.jpg
+var CardImagesFS embed.FS
@@ -0,0 +1,15 @@
+//go:build nocardassets
+
+package treacherest
+
+import "embed"
+
+// CardAssetsEmbedded is false when built without the card asset bundle; the
+// server then always uses the sandbox card set
+const CardAssetsEmbedded = false
+
+// TreacheryCardsJSON is empty without the card asset bundle
+var TreacheryCardsJSON []byte
+
+// CoupRoleImagesFS is empty without the card asset bundle; Coup roles fall back to text
+var CoupRoleImagesFS embed.FS
@@ -0,0 +1,12 @@
+//go:build nocardassets || nocardimages
+
+package treacherest
+
+import "embed"
+
+// CardImagesEmbedded is false when built without the card artwork; cards are
+// then served text-only
+const CardImagesEmbedded = false
+
+// CardImagesFS is empty without the card artwork
+var CardImagesFS embed.FS
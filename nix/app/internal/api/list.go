@@ -0,0 +1,200 @@
+// Package api holds the rules every /api/v1 list endpoint shares: how many
+// items a page holds, how a client asks for the next page, which filters a
+// list accepts and the order items come back in. Endpoints describe their
+// list once with a List and get the same query parameters and response shape
+// as every other list.
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query parameters every list understands; anything else must be one of the
+// list's filters
+const (
+	ParamLimit  = "limit"
+	ParamCursor = "cursor"
+)
+
+var (
+	ErrInvalidLimit  = errors.New("limit must be a positive number")
+	ErrInvalidCursor = errors.New("cursor is not valid for this list")
+)
+
+// List describes one list endpoint
+type List[T any] struct {
+	DefaultLimit int
+	MaxLimit     int
+
+	// Key orders the list and must be unique per item, so pages neither
+	// repeat nor skip items when others are added or removed between requests.
+	// Keys compare as strings; build them with SortKey.
+	Key func(T) string
+
+	// Filters are the query parameters that narrow the list, each matching
+	// an item against the parameter's value
+	Filters map[string]func(item T, value string) bool
+}
+
+// Params is a parsed list request
+type Params struct {
+	Limit   int
+	After   string            // key of the last item on the previous page; "" for the first page
+	Filters map[string]string // only the list's own filters, with non-empty values
+}
+
+// Page is the response body of a list endpoint
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor fetches the following page; it is left out on the last one
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Parse reads limit, cursor and the list's filters from query. Unknown
+// parameters are an error, so a mistyped filter isn't silently ignored.
+func (l List[T]) Parse(query url.Values) (Params, error) {
+	params := Params{Limit: l.DefaultLimit, Filters: make(map[string]string)}
+
+	for name, values := range query {
+		value := strings.TrimSpace(values[len(values)-1])
+		switch name {
+		case ParamLimit:
+			limit, err := ParseLimit(value, l.DefaultLimit, l.MaxLimit)
+			if err != nil {
+				return Params{}, err
+			}
+			params.Limit = limit
+		case ParamCursor:
+			if value == "" {
+				continue
+			}
+			after, err := decodeCursor(value)
+			if err != nil {
+				return Params{}, ErrInvalidCursor
+			}
+			params.After = after
+		default:
+			if _, ok := l.Filters[name]; !ok {
+				return Params{}, fmt.Errorf("unknown parameter %q", name)
+			}
+			if value != "" {
+				params.Filters[name] = value
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// ParseLimit reads a limit parameter: fallback when it's empty, at most
+// maxLimit when that is set
+func ParseLimit(raw string, fallback, maxLimit int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, ErrInvalidLimit
+	}
+	if maxLimit > 0 {
+		n = min(n, maxLimit)
+	}
+	return n, nil
+}
+
+// Page filters items, orders them by key and returns the page params asks
+// for. items is not modified.
+func (l List[T]) Page(items []T, params Params) Page[T] {
+	type keyed struct {
+		key  string
+		item T
+	}
+	matched := make([]keyed, 0, len(items))
+	for _, item := range items {
+		if l.matches(item, params.Filters) {
+			matched = append(matched, keyed{key: l.Key(item), item: item})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].key < matched[j].key })
+
+	start := 0
+	if params.After != "" {
+		start = sort.Search(len(matched), func(i int) bool { return matched[i].key > params.After })
+	}
+	end := len(matched)
+	if params.Limit > 0 {
+		end = min(start+params.Limit, len(matched))
+	}
+
+	page := Page[T]{Items: make([]T, 0, end-start)}
+	for _, entry := range matched[start:end] {
+		page.Items = append(page.Items, entry.item)
+	}
+	if end < len(matched) {
+		page.NextCursor = encodeCursor(matched[end-1].key)
+	}
+	return page
+}
+
+func (l List[T]) matches(item T, filters map[string]string) bool {
+	for name, value := range filters {
+		if !l.Filters[name](item, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// SortKey joins parts into one key that orders by each part in turn. Numbers
+// are zero-padded so they order numerically.
+func SortKey(parts ...any) string {
+	fields := make([]string, len(parts))
+	for i, part := range parts {
+		switch v := part.(type) {
+		case int:
+			fields[i] = fmt.Sprintf("%020d", uint64(v)^(1<<63))
+		case int64:
+			fields[i] = fmt.Sprintf("%020d", uint64(v)^(1<<63))
+		case string:
+			fields[i] = v
+		default:
+			fields[i] = fmt.Sprint(v)
+		}
+	}
+	// The separator sorts below every printable character, so a field sorts
+	// before a longer one it is a prefix of
+	return strings.Join(fields, "\x00")
+}
+
+// Cursors are opaque to clients; today they carry the last key seen
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return string(key), nil
+}
+
+// Equal is a filter that matches a field case-insensitively
+func Equal[T any](field func(T) string) func(T, string) bool {
+	return func(item T, value string) bool {
+		return strings.EqualFold(field(item), value)
+	}
+}
+
+// Contains is a filter that matches a field containing the value, ignoring case
+func Contains[T any](field func(T) string) func(T, string) bool {
+	return func(item T, value string) bool {
+		return strings.Contains(strings.ToLower(field(item)), strings.ToLower(value))
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+)
+
+type testRoom struct {
+	Code    string
+	State   string
+	Players int
+}
+
+var testRooms = List[testRoom]{
+	DefaultLimit: 2,
+	MaxLimit:     3,
+	Key:          func(r testRoom) string { return SortKey(r.Players, r.Code) },
+	Filters: map[string]func(testRoom, string) bool{
+		"state": Equal(func(r testRoom) string { return r.State }),
+		"code":  Contains(func(r testRoom) string { return r.Code }),
+	},
+}
+
+func codes(page Page[testRoom]) []string {
+	out := make([]string, len(page.Items))
+	for i, room := range page.Items {
+		out[i] = room.Code
+	}
+	return out
+}
+
+func TestListPagesInStableOrder(t *testing.T) {
+	rooms := []testRoom{
+		{"DDDD", "lobby", 10},
+		{"AAAA", "lobby", 2},
+		{"CCCC", "playing", 2},
+		{"BBBB", "lobby", 9},
+		{"EEEE", "lobby", -1},
+	}
+
+	params, err := testRooms.Parse(url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seen []string
+	for pages := 0; ; pages++ {
+		if pages > len(rooms) {
+			t.Fatal("paging never reached the last page")
+		}
+		page := testRooms.Page(rooms, params)
+		seen = append(seen, codes(page)...)
+		if page.NextCursor == "" {
+			break
+		}
+		// A room added ahead of the cursor between requests doesn't shift
+		// the pages still to come
+		rooms = append(rooms, testRoom{"0000", "lobby", -5})
+		if params, err = testRooms.Parse(url.Values{"cursor": {page.NextCursor}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"EEEE", "AAAA", "CCCC", "BBBB", "DDDD"}
+	if len(seen) != len(want) {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, seen)
+		}
+	}
+}
+
+func TestListParseFiltersAndLimits(t *testing.T) {
+	rooms := []testRoom{{"ABCD", "lobby", 1}, {"ABXY", "playing", 2}, {"ZZZZ", "Lobby", 3}}
+
+	params, err := testRooms.Parse(url.Values{"state": {"LOBBY"}, "limit": {"50"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.Limit != 3 {
+		t.Errorf("expected the limit to be capped at 3, got %d", params.Limit)
+	}
+	if got := codes(testRooms.Page(rooms, params)); len(got) != 2 || got[0] != "ABCD" || got[1] != "ZZZZ" {
+		t.Errorf("expected the lobby rooms, got %v", got)
+	}
+
+	params, _ = testRooms.Parse(url.Values{"code": {"ab"}, "state": {"playing"}})
+	if got := codes(testRooms.Page(rooms, params)); len(got) != 1 || got[0] != "ABXY" {
+		t.Errorf("expected filters to combine, got %v", got)
+	}
+
+	for _, bad := range []url.Values{
+		{"limit": {"0"}},
+		{"limit": {"x"}},
+		{"cursor": {"!!"}},
+		{"sort": {"code"}},
+	} {
+		if _, err := testRooms.Parse(bad); err == nil {
+			t.Errorf("expected %v to be refused", bad)
+		}
+	}
+}
+
+func TestSortKeyOrdersNumbersNumerically(t *testing.T) {
+	ordered := []string{SortKey(-3, "b"), SortKey(0, "a"), SortKey(2, ""), SortKey(2, "a"), SortKey(10, "a")}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1] >= ordered[i] {
+			t.Errorf("expected key %d to sort before key %d", i-1, i)
+		}
+	}
+}
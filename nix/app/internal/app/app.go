@@ -0,0 +1,409 @@
+// Package app wires configuration, card data, the store and handlers into a
+// runnable server, so cmd/server and tests or tooling can embed the same app.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"treacherest"
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/handlers"
+	"treacherest/internal/mail"
+	"treacherest/internal/privacy"
+	"treacherest/internal/roomlog"
+	"treacherest/internal/secrets"
+	"treacherest/internal/store"
+	_ "treacherest/internal/store/sqlite" // registers the sqlite:// driver
+)
+
+// defaultShutdownTimeout bounds graceful shutdown when the config leaves it unset
+const defaultShutdownTimeout = 30 * time.Second
+
+// App is a fully wired Treacherest server
+type App struct {
+	cfg     *config.ServerConfig
+	store   store.RoomStore
+	handler *handlers.Handler
+	router  http.Handler
+
+	server   *http.Server
+	listener net.Listener
+	serveErr chan error
+}
+
+// New builds the app from cfg using the card set it selects: the embedded
+// card data, or generated sandbox cards when cardSet is "sandbox" or the
+// binary was built without the asset bundle
+func New(cfg *config.ServerConfig) (*App, error) {
+	cardService, err := loadCards(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Server.TextOnlyCards {
+		if err := game.LoadCoupRoleImages(treacherest.CoupRoleImagesFS); err != nil {
+			return nil, fmt.Errorf("initialize Coup role images: %w", err)
+		}
+	}
+	return NewWithCards(cfg, cardService)
+}
+
+// loadCards builds the card service for cfg.Server.CardSet, without artwork
+// in text-only mode or when the binary was built without it
+func loadCards(cfg *config.ServerConfig) (*game.CardService, error) {
+	var cardService *game.CardService
+	if cfg.Server.CardSet == "sandbox" || !treacherest.CardAssetsEmbedded {
+		if cfg.Server.CardSet != "sandbox" {
+			log.Printf("Built without card assets (nocardassets); using the sandbox card set")
+		}
+		log.Printf("Using sandbox card set: %d placeholder cards per role type", sandboxCardsPerType(cfg))
+		cardService = game.NewSandboxCardService(sandboxCardsPerType(cfg))
+	} else {
+		var images fs.FS
+		if treacherest.CardImagesEmbedded && !cfg.Server.TextOnlyCards {
+			images = treacherest.CardImagesFS
+		}
+		var err error
+		cardService, err = game.NewCardService(treacherest.TreacheryCardsJSON, images)
+		if err != nil {
+			return nil, fmt.Errorf("initialize card service (set CARD_SET=sandbox to run without card assets): %w", err)
+		}
+	}
+
+	if dir := cfg.Server.CardTranslationsDir; dir != "" {
+		if err := loadCardTranslations(cardService, os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("load card translations from %s: %w", dir, err)
+		}
+		log.Printf("Card text available in: %s", strings.Join(cardService.Locales(), ", "))
+	}
+
+	if cfg.Server.TextOnlyCards {
+		cardService.DropImages()
+	}
+	if !cardService.Info().Images {
+		log.Printf("Serving cards text-only, without artwork")
+	}
+	return cardService, nil
+}
+
+// loadCardTranslations overlays each <locale>.json in translations on the
+// cards' English text
+func loadCardTranslations(cardService *game.CardService, translations fs.FS) error {
+	files, err := fs.Glob(translations, "*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		data, err := fs.ReadFile(translations, name)
+		if err != nil {
+			return err
+		}
+		if err := cardService.AddTranslations(strings.TrimSuffix(name, ".json"), data); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// sandboxCardsPerType returns the configured sandbox card count, or the default when unset
+func sandboxCardsPerType(cfg *config.ServerConfig) int {
+	if cfg.Server.SandboxCardsPerType > 0 {
+		return cfg.Server.SandboxCardsPerType
+	}
+	return game.DefaultSandboxCardsPerType
+}
+
+// NewWithCards builds the app with a caller-supplied card service, e.g. a small fixture set in tests
+func NewWithCards(cfg *config.ServerConfig, cardService *game.CardService) (*App, error) {
+	compat, err := game.CheckCardCompatibility(cfg, cardService)
+	if err != nil {
+		return nil, fmt.Errorf("card set does not fit the configured roles: %w", err)
+	}
+	for subtype, count := range compat.Unused {
+		log.Printf("⚠️ %d %s card(s) are never dealt: no role in roles.available has category %q", count, subtype, subtype)
+	}
+
+	provider, err := secrets.NewProvider(secrets.Settings{
+		Provider:  cfg.Server.SecretsProvider,
+		Dir:       cfg.Server.SecretsDir,
+		VaultAddr: cfg.Server.VaultAddr,
+		VaultPath: cfg.Server.VaultPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialize secrets provider: %w", err)
+	}
+	resolved, err := resolveSecrets(cfg, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	backupService, err := game.NewBackupService(
+		cfg.Server.BackupEncryptionKey,
+		cfg.Server.BackupEncryptionEnabled,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initialize backup service: %w", err)
+	}
+	if backupService.IsEnabled() {
+		log.Printf("Backup service initialized with encryption enabled")
+	} else {
+		log.Printf("Backup service initialized in DEBUG mode (encryption disabled)")
+	}
+
+	s, err := store.Open(cfg.Server.StoreDSN, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("initialize room store: %w", err)
+	}
+	s, faults, err := withChaos(s)
+	if err != nil {
+		return nil, err
+	}
+	s.SetCardService(cardService)
+	h := handlers.New(s, cardService, cfg, backupService)
+	if faults != nil {
+		h.SetEventFaults(faults)
+	}
+	if resolved.sessionKeys != nil {
+		h.SetSessionKeys(resolved.sessionKeys)
+	}
+	h.SetAdminToken(resolved.adminToken)
+	for id, token := range resolved.tenantAdminTokens {
+		h.SetTenantAdminToken(id, token)
+	}
+	if cfg.Server.SMTPAddr != "" {
+		h.SetMailer(mail.SMTP{
+			Addr:     cfg.Server.SMTPAddr,
+			From:     cfg.Server.SMTPFrom,
+			Username: cfg.Server.SMTPUsername,
+			Password: resolved.smtpPassword,
+		})
+		log.Printf("Email invites sent through %s", cfg.Server.SMTPAddr)
+	}
+	h.SetAttribution(treacherest.AttributionText)
+
+	return &App{
+		cfg:     cfg,
+		store:   s,
+		handler: h,
+		router:  handlers.BuildRouter(h),
+	}, nil
+}
+
+// resolvedSecrets holds the secrets the handlers need
+type resolvedSecrets struct {
+	sessionKeys *secrets.Keyring // nil leaves cookies unsigned
+	adminToken  string           // empty disables the admin endpoints
+
+	tenantAdminTokens map[string]string // by tenant ID, for those that have one
+	smtpPassword      string
+}
+
+// resolveSecrets loads the session cookie keys, admin tokens, SMTP password and
+// backup key from the provider. A backup key from the provider replaces any plaintext config value.
+func resolveSecrets(cfg *config.ServerConfig, provider secrets.Provider) (resolvedSecrets, error) {
+	sessionKeys, err := secrets.LoadKeyring(provider, secrets.CookieKeys)
+	if err != nil {
+		return resolvedSecrets{}, fmt.Errorf("resolve cookie keys: %w", err)
+	}
+	if sessionKeys != nil {
+		log.Printf("Session cookies signed (%d key(s) from %s secrets)", sessionKeys.Len(), provider.Name())
+	} else {
+		log.Printf("Session cookies unsigned: set %s to enable signing", secrets.EnvVar(secrets.CookieKeys))
+	}
+
+	adminToken, _, err := provider.Lookup(secrets.AdminToken)
+	if err != nil {
+		return resolvedSecrets{}, fmt.Errorf("resolve admin token: %w", err)
+	}
+
+	tenantAdminTokens := make(map[string]string)
+	for _, t := range cfg.Tenants {
+		token, ok, err := provider.Lookup(secrets.TenantAdminToken(t.ID))
+		if err != nil {
+			return resolvedSecrets{}, fmt.Errorf("resolve tenant %s admin token: %w", t.ID, err)
+		}
+		if ok {
+			tenantAdminTokens[t.ID] = token
+		}
+	}
+
+	smtpPassword, _, err := provider.Lookup(secrets.SMTPPassword)
+	if err != nil {
+		return resolvedSecrets{}, fmt.Errorf("resolve SMTP password: %w", err)
+	}
+
+	backupKey, ok, err := provider.Lookup(secrets.BackupEncryptionKey)
+	if err != nil {
+		return resolvedSecrets{}, fmt.Errorf("resolve backup encryption key: %w", err)
+	}
+	if ok {
+		cfg.Server.BackupEncryptionKey = backupKey
+	} else if cfg.Server.BackupEncryptionKey != "" {
+		log.Printf("⚠️ backupEncryptionKey is set in plaintext config; move it to the %s secret", secrets.BackupEncryptionKey)
+	}
+
+	return resolvedSecrets{sessionKeys: sessionKeys, adminToken: adminToken, tenantAdminTokens: tenantAdminTokens, smtpPassword: smtpPassword}, nil
+}
+
+// Router returns the app's HTTP handler
+func (a *App) Router() http.Handler {
+	return a.router
+}
+
+// CaptureRoomLogs routes the standard logger through a per-room capture so
+// a Room Operator's problem report carries the room's recent log lines
+func (a *App) CaptureRoomLogs() {
+	capture := roomlog.New(log.Writer(), 0, 0)
+	log.SetOutput(capture)
+	a.handler.SetRoomLogs(capture)
+}
+
+// RedactLogs puts privacy logging in front of the standard logger when the
+// config asks for it, so neither the log nor captured room logs name players.
+// Call it after CaptureRoomLogs.
+func (a *App) RedactLogs() {
+	if !a.cfg.Server.PrivacyLogging {
+		return
+	}
+	redactor := privacy.NewRedactor(log.Writer(), 0)
+	log.SetOutput(redactor)
+	a.handler.SetRedactor(redactor)
+	log.Printf("Privacy logging on: player names and IDs are logged as hashes")
+}
+
+// ReloadConfig applies the room and role settings of next, pinning live rooms
+// it would invalidate to their current config (see Handler.ReloadConfig)
+func (a *App) ReloadConfig(next *config.ServerConfig) int {
+	return a.handler.ReloadConfig(next)
+}
+
+// Store returns the app's room store
+func (a *App) Store() store.RoomStore {
+	return a.store
+}
+
+// Addr returns the address the server is listening on, or the configured address before Start
+func (a *App) Addr() string {
+	if a.listener != nil {
+		return a.listener.Addr().String()
+	}
+	return a.cfg.Server.Host + ":" + a.cfg.Server.Port
+}
+
+// Start binds the configured address and serves in the background. Request
+// contexts derive from ctx, so cancelling it ends long-lived SSE streams.
+// Listen errors are returned directly; later serve errors arrive on Err.
+func (a *App) Start(ctx context.Context) error {
+	if a.server != nil {
+		return errors.New("app already started")
+	}
+
+	listener, err := net.Listen("tcp", a.Addr())
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", a.Addr(), err)
+	}
+	a.listener = listener
+	a.server = NewHTTPServer(listener.Addr().String(), a.router, a.cfg, ctx)
+	a.serveErr = make(chan error, 1)
+	go a.handler.RunHistoryRetention(ctx)
+
+	go func() {
+		log.Printf("Starting server on %s", listener.Addr())
+		if err := a.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.serveErr <- err
+		}
+		close(a.serveErr)
+	}()
+	return nil
+}
+
+// Err delivers a serve failure after Start; it is closed once the server stops
+func (a *App) Err() <-chan error {
+	return a.serveErr
+}
+
+// Drain moves SSE clients off this instance: readiness turns unavailable, new
+// streams are refused and open ones are told to reconnect. It waits until every
+// stream has closed or ctx is done; the server keeps serving other requests.
+func (a *App) Drain(ctx context.Context) error {
+	return a.handler.Drain(ctx)
+}
+
+// Shutdown drains SSE clients for up to DrainTimeout, stops accepting
+// connections and waits for active requests until ctx is done, then forces
+// remaining connections closed
+func (a *App) Shutdown(ctx context.Context) error {
+	if a.server == nil {
+		return nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := a.cfg.Server.ShutdownTimeout
+		if timeout <= 0 {
+			timeout = defaultShutdownTimeout
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if a.cfg.Server.DrainTimeout > 0 {
+		drainCtx, cancel := context.WithTimeout(ctx, a.cfg.Server.DrainTimeout)
+		if err := a.Drain(drainCtx); err != nil {
+			log.Printf("Drain incomplete: %v", err)
+		}
+		cancel()
+	}
+
+	if err := a.server.Shutdown(ctx); err != nil {
+		log.Printf("Graceful shutdown timed out: %v", err)
+		if closeErr := a.server.Close(); closeErr != nil {
+			return fmt.Errorf("forced shutdown: %w", closeErr)
+		}
+		log.Println("Server forced to stop")
+		a.closeStore()
+		return nil
+	}
+
+	log.Println("Server gracefully stopped")
+	a.closeStore()
+	return nil
+}
+
+// closeStore closes a store that holds a resource, like an SQLite database
+func (a *App) closeStore() {
+	if closer, ok := a.store.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Printf("Closing the room store failed: %v", err)
+		}
+	}
+}
+
+// NewHTTPServer creates the HTTP server with the configured timeouts. Request
+// contexts derive from baseCtx so SSE handlers observe process shutdown.
+func NewHTTPServer(addr string, handler http.Handler, cfg *config.ServerConfig, baseCtx context.Context) *http.Server {
+	if baseCtx == nil {
+		baseCtx = context.Background()
+	}
+
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout, // 0 for SSE support
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+}
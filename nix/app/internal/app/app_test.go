@@ -0,0 +1,332 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store/sqlite"
+)
+
+func testCards() *game.CardService {
+	return &game.CardService{
+		Leaders:   []*game.Card{{ID: 1, Name: "Test Leader", Types: game.CardTypes{Subtype: "Leader"}}},
+		Guardians: []*game.Card{{ID: 2, Name: "Test Guardian", Types: game.CardTypes{Subtype: "Guardian"}}},
+		Assassins: []*game.Card{{ID: 3, Name: "Test Assassin", Types: game.CardTypes{Subtype: "Assassin"}}},
+		Traitors:  []*game.Card{{ID: 4, Name: "Test Traitor", Types: game.CardTypes{Subtype: "Traitor"}}},
+	}
+}
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = "0"
+
+	a, err := NewWithCards(cfg, testCards())
+	if err != nil {
+		t.Fatalf("NewWithCards: %v", err)
+	}
+	return a
+}
+
+func TestRouterServesProductionRoutes(t *testing.T) {
+	a := newTestApp(t)
+
+	w := httptest.NewRecorder()
+	a.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 from /health/ready, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	a.Router().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/room/NOPE1", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown room, got %d", w.Code)
+	}
+}
+
+func TestStartServesUntilShutdown(t *testing.T) {
+	a := newTestApp(t)
+
+	if err := a.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := a.Start(context.Background()); err == nil {
+		t.Error("expected second Start to fail")
+	}
+
+	resp, err := http.Get("http://" + a.Addr() + "/health/live")
+	if err != nil {
+		t.Fatalf("GET /health/live: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := a.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	select {
+	case err, ok := <-a.Err():
+		if ok && err != nil {
+			t.Fatalf("unexpected serve error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop after Shutdown")
+	}
+
+	if _, err := http.Get("http://" + a.Addr() + "/health/live"); err == nil {
+		t.Error("expected requests to fail after Shutdown")
+	}
+}
+
+func TestStartReportsListenErrors(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+
+	a := newTestApp(t)
+	host, port, _ := net.SplitHostPort(busy.Addr().String())
+	a.cfg.Server.Host, a.cfg.Server.Port = host, port
+
+	if err := a.Start(context.Background()); err == nil {
+		a.Shutdown(context.Background())
+		t.Fatal("expected Start to fail on a busy port")
+	}
+}
+
+func TestShutdownBeforeStartIsNoop(t *testing.T) {
+	if err := newTestApp(t).Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestHTTPServerBaseContextCancelsActiveRequests(t *testing.T) {
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	requestDone := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("expected response writer to support flushing")
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if _, err := w.Write([]byte("data: started\n\n")); err != nil {
+			t.Errorf("write SSE prelude: %v", err)
+			return
+		}
+		flusher.Flush()
+
+		<-r.Context().Done()
+		close(requestDone)
+	})
+
+	cfg := &config.ServerConfig{
+		Server: config.ServerSettings{
+			ReadTimeout:  time.Second,
+			WriteTimeout: time.Minute,
+			IdleTimeout:  0,
+		},
+	}
+	server := NewHTTPServer("127.0.0.1:0", handler, cfg, baseCtx)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	listener := newOneShotListener(serverConn)
+
+	serveDone := make(chan error, 1)
+	go func() {
+		serveDone <- server.Serve(listener)
+	}()
+
+	if _, err := clientConn.Write([]byte("GET / HTTP/1.1\r\nHost: treacherest.test\r\n\r\n")); err != nil {
+		t.Fatalf("write request: %v", err)
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(clientConn), nil)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	defer resp.Body.Close()
+
+	cancelBase()
+
+	select {
+	case <-requestDone:
+	case <-time.After(time.Second):
+		t.Fatal("active request context was not canceled")
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		t.Fatalf("close response body: %v", err)
+	}
+	if err := clientConn.Close(); err != nil {
+		t.Fatalf("close client connection: %v", err)
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), time.Second)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("shutdown should finish after active request context cancellation: %v", err)
+	}
+
+	select {
+	case err := <-serveDone:
+		if err != nil && err != http.ErrServerClosed {
+			t.Fatalf("serve returned unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop after shutdown")
+	}
+}
+
+type oneShotListener struct {
+	conn   chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newOneShotListener(conn net.Conn) *oneShotListener {
+	l := &oneShotListener{
+		conn:   make(chan net.Conn, 1),
+		closed: make(chan struct{}),
+	}
+	l.conn <- conn
+	return l
+}
+
+func (l *oneShotListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conn:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *oneShotListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *oneShotListener) Addr() net.Addr {
+	return pipeAddr("treacherest-test")
+}
+
+type pipeAddr string
+
+func (a pipeAddr) Network() string { return "pipe" }
+
+func (a pipeAddr) String() string { return string(a) }
+
+func TestNewUsesSandboxCardSet(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = "0"
+	cfg.Server.CardSet = "sandbox"
+	cfg.Server.SandboxCardsPerType = 2
+
+	cards, err := loadCards(cfg)
+	if err != nil {
+		t.Fatalf("loadCards: %v", err)
+	}
+	if len(cards.GetAllCards()) != 8 || cards.Leaders[0].Name != "Sandbox Leader 1" {
+		t.Fatalf("expected 2 sandbox cards per role type, got %d cards", len(cards.GetAllCards()))
+	}
+
+	if _, err := New(cfg); err != nil {
+		t.Fatalf("New with sandbox cards: %v", err)
+	}
+}
+
+func TestNewTextOnlyCards(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Server.CardSet = "sandbox"
+	cfg.Server.TextOnlyCards = true
+
+	cards, err := loadCards(cfg)
+	if err != nil {
+		t.Fatalf("loadCards: %v", err)
+	}
+	if cards.Info().Images {
+		t.Error("expected text-only cards")
+	}
+	for _, card := range cards.GetAllCards() {
+		if card.GetImageBase64() != "" {
+			t.Fatalf("expected %s to have no image", card.Name)
+		}
+	}
+}
+
+func TestNewLoadsCardTranslations(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"1": {"name": "Sandbox-Anführer 1"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Server.CardSet = "sandbox"
+	cfg.Server.CardTranslationsDir = dir
+
+	cards, err := loadCards(cfg)
+	if err != nil {
+		t.Fatalf("loadCards: %v", err)
+	}
+	if !cards.HasLocale("de") || cards.Leaders[0].In("de").Name != "Sandbox-Anführer 1" {
+		t.Errorf("expected German card text from %s", dir)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "fr.json"), []byte(`{"999": {"name": "Inconnu"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCards(cfg); err == nil {
+		t.Error("expected text for cards outside the set to fail startup")
+	}
+}
+
+func TestNewOpensTheConfiguredStore(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Server.StoreDSN = "nosuch://"
+	if _, err := NewWithCards(cfg, testCards()); err == nil || !strings.Contains(err.Error(), `unknown store driver "nosuch"`) {
+		t.Fatalf("expected an unknown store driver refused, got %v", err)
+	}
+
+	cfg.Server.StoreDSN = "sqlite://" + filepath.Join(t.TempDir(), "rooms.db")
+	a, err := NewWithCards(cfg, testCards())
+	if err != nil {
+		t.Fatalf("NewWithCards with sqlite: %v", err)
+	}
+	defer a.closeStore()
+	if _, ok := a.Store().(*sqlite.Store); !ok {
+		t.Errorf("expected the sqlite store, got %T", a.Store())
+	}
+}
+
+func TestNewRefusesRolesTheCardsCannotFill(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Roles.Available["jester"] = config.RoleDefinition{DisplayName: "Jester", Category: "Jester", MaxCount: 1}
+
+	_, err := NewWithCards(cfg, testCards())
+	if err == nil || !strings.Contains(err.Error(), "roles.available.jester.category") {
+		t.Fatalf("expected the jester role refused with its field path, got %v", err)
+	}
+}
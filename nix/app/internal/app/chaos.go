@@ -0,0 +1,30 @@
+//go:build chaos
+
+package app
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"treacherest/internal/chaos"
+	"treacherest/internal/handlers"
+	"treacherest/internal/store"
+)
+
+// withChaos wraps the store and event bus in the faults the CHAOS_* variables
+// ask for. Only chaos builds (-tags chaos) have it; see internal/chaos.
+func withChaos(s store.RoomStore) (store.RoomStore, handlers.EventFaults, error) {
+	settings, err := chaos.FromEnv()
+	if err != nil {
+		return nil, nil, fmt.Errorf("chaos settings: %w", err)
+	}
+	if !settings.Enabled() {
+		log.Printf("Chaos build: no CHAOS_* faults set, running normally")
+		return s, nil, nil
+	}
+	log.Printf("⚠️ Chaos mode: injecting faults (%s)", settings)
+	in := chaos.New(settings)
+	faults := func(handlers.Event) (time.Duration, bool) { return in.Event() }
+	return chaos.WrapStore(s, in), faults, nil
+}
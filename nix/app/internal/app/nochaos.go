@@ -0,0 +1,13 @@
+//go:build !chaos
+
+package app
+
+import (
+	"treacherest/internal/handlers"
+	"treacherest/internal/store"
+)
+
+// withChaos leaves the store and event bus alone outside chaos builds
+func withChaos(s store.RoomStore) (store.RoomStore, handlers.EventFaults, error) {
+	return s, nil, nil
+}
@@ -0,0 +1,132 @@
+// Package chaos injects faults — late and dropped events, transient store
+// errors — so the server's recovery paths (Last-Event-ID replay, resync,
+// retries) get exercised on purpose rather than only on flaky Wi-Fi. The
+// server only wires it in when built with -tags chaos.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrInjected is wrapped by every error the chaos store makes up
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Settings say how much chaos to cause. The zero value causes none.
+type Settings struct {
+	Seed           int64         // seeds the fault sequence, so a failing run can be repeated
+	MaxDelay       time.Duration // events are held back up to this long
+	DropRate       float64       // share of events never delivered, 0 to 1
+	StoreErrorRate float64       // share of store calls that fail, 0 to 1
+}
+
+// Enabled reports whether s causes any faults
+func (s Settings) Enabled() bool {
+	return s.MaxDelay > 0 || s.DropRate > 0 || s.StoreErrorRate > 0
+}
+
+// String describes s for the startup log
+func (s Settings) String() string {
+	return fmt.Sprintf("seed=%d max-delay=%s drop-rate=%.2f store-error-rate=%.2f",
+		s.Seed, s.MaxDelay, s.DropRate, s.StoreErrorRate)
+}
+
+// FromEnv reads CHAOS_SEED, CHAOS_MAX_DELAY, CHAOS_DROP_RATE and
+// CHAOS_STORE_ERROR_RATE. Without a seed, one is picked from the clock.
+func FromEnv() (Settings, error) {
+	s := Settings{Seed: time.Now().UnixNano()}
+	if v := os.Getenv("CHAOS_SEED"); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return Settings{}, fmt.Errorf("CHAOS_SEED: %w", err)
+		}
+		s.Seed = seed
+	}
+	if v := os.Getenv("CHAOS_MAX_DELAY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return Settings{}, fmt.Errorf("CHAOS_MAX_DELAY: invalid duration %q", v)
+		}
+		s.MaxDelay = d
+	}
+	var err error
+	if s.DropRate, err = rateFromEnv("CHAOS_DROP_RATE"); err != nil {
+		return Settings{}, err
+	}
+	if s.StoreErrorRate, err = rateFromEnv("CHAOS_STORE_ERROR_RATE"); err != nil {
+		return Settings{}, err
+	}
+	return s, nil
+}
+
+func rateFromEnv(name string) (float64, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(v, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("%s: want a rate between 0 and 1, got %q", name, v)
+	}
+	return rate, nil
+}
+
+// Injector decides which calls fail. It is safe for concurrent use; with the
+// same seed and the same order of calls it makes the same decisions.
+type Injector struct {
+	settings Settings
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// New returns an Injector causing the faults s describes
+func New(s Settings) *Injector {
+	return &Injector{settings: s, rng: rand.New(rand.NewSource(s.Seed))}
+}
+
+// Settings returns the settings the Injector is causing faults with
+func (in *Injector) Settings() Settings {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.settings
+}
+
+// Set switches the Injector to the faults s describes and reseeds it, so a
+// test can set a table up calmly and bring the chaos in afterwards
+func (in *Injector) Set(s Settings) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.settings = s
+	in.rng = rand.New(rand.NewSource(s.Seed))
+}
+
+// Event decides the fate of one event delivery: how long to hold it back,
+// or whether to drop it altogether
+func (in *Injector) Event() (delay time.Duration, drop bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.settings.DropRate > 0 && in.rng.Float64() < in.settings.DropRate {
+		return 0, true
+	}
+	if in.settings.MaxDelay > 0 {
+		delay = time.Duration(in.rng.Int63n(int64(in.settings.MaxDelay) + 1))
+	}
+	return delay, false
+}
+
+// Fail returns an error wrapping ErrInjected for a share of calls to op, and
+// nil for the rest
+func (in *Injector) Fail(op string) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.settings.StoreErrorRate > 0 && in.rng.Float64() < in.settings.StoreErrorRate {
+		return fmt.Errorf("%s: %w", op, ErrInjected)
+	}
+	return nil
+}
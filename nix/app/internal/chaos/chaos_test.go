@@ -0,0 +1,104 @@
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+	"treacherest/internal/store/storetest"
+)
+
+func TestSameSeedSameFaults(t *testing.T) {
+	settings := Settings{Seed: 42, MaxDelay: 100 * time.Millisecond, DropRate: 0.3, StoreErrorRate: 0.3}
+	a, b := New(settings), New(settings)
+	for i := 0; i < 50; i++ {
+		delayA, dropA := a.Event()
+		delayB, dropB := b.Event()
+		if delayA != delayB || dropA != dropB {
+			t.Fatalf("call %d: expected the same event fault, got %s/%v and %s/%v", i, delayA, dropA, delayB, dropB)
+		}
+		if (a.Fail("op") == nil) != (b.Fail("op") == nil) {
+			t.Fatalf("call %d: expected the same store fault", i)
+		}
+		if delayA > settings.MaxDelay {
+			t.Fatalf("call %d: delay %s beyond the %s maximum", i, delayA, settings.MaxDelay)
+		}
+	}
+}
+
+func TestZeroSettingsCauseNoFaults(t *testing.T) {
+	in := New(Settings{})
+	for i := 0; i < 50; i++ {
+		if delay, drop := in.Event(); delay != 0 || drop {
+			t.Fatalf("expected no event faults, got %s/%v", delay, drop)
+		}
+		if err := in.Fail("op"); err != nil {
+			t.Fatalf("expected no store faults, got %v", err)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("CHAOS_SEED", "7")
+	t.Setenv("CHAOS_MAX_DELAY", "250ms")
+	t.Setenv("CHAOS_DROP_RATE", "0.1")
+	t.Setenv("CHAOS_STORE_ERROR_RATE", "0.05")
+	got, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Settings{Seed: 7, MaxDelay: 250 * time.Millisecond, DropRate: 0.1, StoreErrorRate: 0.05}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	t.Setenv("CHAOS_DROP_RATE", "1.5")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("expected a drop rate above 1 to be refused")
+	}
+}
+
+func TestStorePassesConformanceWithoutFaults(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.RoomStore {
+		return WrapStore(store.NewMemoryStore(config.DefaultConfig()), New(Settings{}))
+	})
+}
+
+func TestFailedAppendLeavesRoomUntouched(t *testing.T) {
+	s := WrapStore(store.NewMemoryStore(config.DefaultConfig()), New(Settings{StoreErrorRate: 1}))
+	room, err := s.Unwrap().CreateRoom()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "session")))
+	if !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected an injected error, got %v", err)
+	}
+	if len(room.Players) != 0 {
+		t.Fatalf("expected the room untouched, got %d players", len(room.Players))
+	}
+	if _, err := s.GetRoom(room.Code); !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected GetRoom to fail too, got %v", err)
+	}
+}
+
+func TestSetSwitchesFaultsOnAndOff(t *testing.T) {
+	in := New(Settings{})
+	if err := in.Fail("op"); err != nil {
+		t.Fatalf("expected no store faults yet, got %v", err)
+	}
+
+	in.Set(Settings{StoreErrorRate: 1})
+	if err := in.Fail("op"); !errors.Is(err, ErrInjected) {
+		t.Fatalf("expected an injected error once switched on, got %v", err)
+	}
+
+	in.Set(Settings{})
+	if err := in.Fail("op"); err != nil {
+		t.Fatalf("expected no store faults once switched off, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package chaos
+
+import (
+	"io"
+
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+)
+
+// Store wraps a RoomStore so a share of the calls that can fail do, before
+// reaching the wrapped store. A failed Append leaves the room untouched, as
+// a real store failure would.
+type Store struct {
+	store.RoomStore
+	in *Injector
+}
+
+var _ store.RoomStore = (*Store)(nil)
+
+// WrapStore returns s with in's store errors injected
+func WrapStore(s store.RoomStore, in *Injector) *Store {
+	return &Store{RoomStore: s, in: in}
+}
+
+// Unwrap returns the wrapped store
+func (s *Store) Unwrap() store.RoomStore {
+	return s.RoomStore
+}
+
+// CreateRoom creates a lobby unless an error is injected
+func (s *Store) CreateRoom() (*game.Room, error) {
+	if err := s.in.Fail("create room"); err != nil {
+		return nil, err
+	}
+	return s.RoomStore.CreateRoom()
+}
+
+// GetRoom returns the live room unless an error is injected
+func (s *Store) GetRoom(code string) (*game.Room, error) {
+	if err := s.in.Fail("get room " + code); err != nil {
+		return nil, err
+	}
+	return s.RoomStore.GetRoom(code)
+}
+
+// UpdateRoom saves the room unless an error is injected
+func (s *Store) UpdateRoom(room *game.Room) error {
+	if err := s.in.Fail("update room " + room.Code); err != nil {
+		return err
+	}
+	return s.RoomStore.UpdateRoom(room)
+}
+
+// Append applies and records e unless an error is injected
+func (s *Store) Append(room *game.Room, e game.RoomEvent) error {
+	if err := s.in.Fail("append " + string(e.Kind) + " to room " + room.Code); err != nil {
+		return err
+	}
+	return s.RoomStore.Append(room, e)
+}
+
+// Events returns the room's journal unless an error is injected
+func (s *Store) Events(code string, since int) ([]game.RoomEvent, error) {
+	if err := s.in.Fail("events of room " + code); err != nil {
+		return nil, err
+	}
+	return s.RoomStore.Events(code, since)
+}
+
+// Close closes the wrapped store when it holds a resource
+func (s *Store) Close() error {
+	if closer, ok := s.RoomStore.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
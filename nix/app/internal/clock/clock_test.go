@@ -0,0 +1,68 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFakeAfterFuncRunsInDeadlineOrder(t *testing.T) {
+	fake := NewFake(start)
+
+	var fired []string
+	fake.AfterFunc(3*time.Second, func() { fired = append(fired, "late") })
+	fake.AfterFunc(time.Second, func() {
+		fired = append(fired, "early")
+		if got := fake.Since(start); got != time.Second {
+			t.Errorf("expected the clock to read 1s inside the call, got %v", got)
+		}
+	})
+	cancelled := fake.AfterFunc(2*time.Second, func() { fired = append(fired, "cancelled") })
+	if !cancelled.Stop() {
+		t.Error("expected Stop to cancel a pending call")
+	}
+
+	fake.Advance(5 * time.Second)
+	if len(fired) != 2 || fired[0] != "early" || fired[1] != "late" {
+		t.Errorf("expected [early late], got %v", fired)
+	}
+	if got := fake.Since(start); got != 5*time.Second {
+		t.Errorf("expected the clock to read 5s, got %v", got)
+	}
+}
+
+func TestFakeTickerDropsUnreadTicks(t *testing.T) {
+	fake := NewFake(start)
+	ticker := fake.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	fake.Advance(3 * time.Second)
+	if got := <-ticker.C(); !got.Equal(start.Add(time.Second)) {
+		t.Errorf("expected the first tick at 1s, got %v", got.Sub(start))
+	}
+	select {
+	case tick := <-ticker.C():
+		t.Errorf("expected later ticks to be dropped, got one at %v", tick.Sub(start))
+	default:
+	}
+}
+
+func TestFakeBlockUntilWaitsForWaiters(t *testing.T) {
+	fake := NewFake(start)
+
+	ready := make(chan struct{})
+	go func() {
+		fake.BlockUntil(1)
+		close(ready)
+	}()
+
+	select {
+	case <-ready:
+		t.Fatal("BlockUntil returned with nothing waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fake.NewTicker(time.Second)
+	<-ready
+}
@@ -0,0 +1,154 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock that only moves when Advance is called. Tickers tick and
+// AfterFunc calls run, in deadline order, as Advance passes them.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{} // closed and replaced whenever waiters changes
+}
+
+// fakeWaiter is a pending ticker (period > 0) or AfterFunc call
+type fakeWaiter struct {
+	fake   *Fake
+	at     time.Time
+	period time.Duration
+	c      chan time.Time
+	f      func()
+}
+
+// NewFake returns a Fake clock reading start
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start, changed: make(chan struct{})}
+}
+
+// Now returns the fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// NewTicker returns a ticker that ticks every d of fake time. Like
+// time.Ticker, ticks are dropped while the previous one is unread.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{fake: f, period: d, c: make(chan time.Time, 1)}
+	f.add(w, d)
+	return fakeTicker{w}
+}
+
+// AfterFunc calls fn once d of fake time has passed. Unlike time.AfterFunc,
+// fn runs on the goroutine calling Advance.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	w := &fakeWaiter{fake: f, f: fn}
+	f.add(w, d)
+	return fakeTimer{w}
+}
+
+// Advance moves the clock forward by d, firing everything due on the way
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	end := f.now.Add(d)
+	for {
+		w := f.nextDue(end)
+		if w == nil {
+			break
+		}
+		f.now = w.at
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			select {
+			case w.c <- f.now:
+			default:
+			}
+			continue
+		}
+
+		f.remove(w)
+		f.mu.Unlock()
+		w.f()
+		f.mu.Lock()
+	}
+	f.now = end
+	f.mu.Unlock()
+}
+
+// BlockUntil waits until at least n tickers and timers are pending, so a test
+// advances the clock only once the code under test is waiting on it
+func (f *Fake) BlockUntil(n int) {
+	for {
+		f.mu.Lock()
+		pending, changed := len(f.waiters), f.changed
+		f.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+func (f *Fake) add(w *fakeWaiter, d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.at = f.now.Add(d)
+	f.waiters = append(f.waiters, w)
+	f.notify()
+}
+
+// remove drops w, reporting whether it was still pending; f.mu must be held
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, pending := range f.waiters {
+		if pending == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			f.notify()
+			return true
+		}
+	}
+	return false
+}
+
+// nextDue returns the earliest waiter due by end; f.mu must be held
+func (f *Fake) nextDue(end time.Time) *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range f.waiters {
+		if !w.at.After(end) && (next == nil || w.at.Before(next.at)) {
+			next = w
+		}
+	}
+	return next
+}
+
+// notify wakes BlockUntil callers; f.mu must be held
+func (f *Fake) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (w *fakeWaiter) stop() bool {
+	w.fake.mu.Lock()
+	defer w.fake.mu.Unlock()
+	return w.fake.remove(w)
+}
+
+type fakeTicker struct{ w *fakeWaiter }
+
+func (t fakeTicker) C() <-chan time.Time { return t.w.c }
+func (t fakeTicker) Stop()               { t.w.stop() }
+
+type fakeTimer struct{ w *fakeWaiter }
+
+func (t fakeTimer) Stop() bool { return t.w.stop() }
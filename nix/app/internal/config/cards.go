@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateCards checks the configured roles against the card set, given the
+// number of cards it can deal per subtype and the IDs of its packs. A role
+// whose category no card has would be skipped without a word when roles are
+// dealt, and an unknown default pack would quietly enable nothing, so both
+// are reported here, with their field paths, before the server starts.
+func (c *ServerConfig) ValidateCards(dealable map[string]int, packs []string) error {
+	subtypes := make([]string, 0, len(dealable))
+	for _, subtype := range sortedKeys(dealable) {
+		if dealable[subtype] > 0 {
+			subtypes = append(subtypes, subtype)
+		}
+	}
+
+	problems := &ValidationError{}
+	for _, name := range sortedKeys(c.Roles.Available) {
+		category := c.Roles.Available[name].Category
+		if dealable[category] > 0 {
+			continue
+		}
+		problems.Errors = append(problems.Errors, FieldError{
+			Path:       "roles.available." + name + ".category",
+			Message:    fmt.Sprintf("no card in the card set has subtype %q (the cards have %s)", category, strings.Join(subtypes, ", ")),
+			Suggestion: closestMatch(category, subtypes),
+		})
+	}
+
+	known := make(map[string]bool, len(packs))
+	for _, pack := range packs {
+		known[pack] = true
+	}
+	for i, pack := range c.Server.CardPacks {
+		if !known[pack] {
+			problems.Errors = append(problems.Errors, FieldError{
+				Path:       fmt.Sprintf("server.cardPacks.%d", i),
+				Message:    fmt.Sprintf("no card pack %q (the cards have %s)", pack, strings.Join(packs, ", ")),
+				Suggestion: closestMatch(pack, packs),
+			})
+		}
+	}
+	return problems.err()
+}
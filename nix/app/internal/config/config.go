@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"sort"
 	"time"
 )
 
@@ -10,17 +11,23 @@ import (
 
 // ServerConfig represents the server configuration
 type ServerConfig struct {
-	Server ServerSettings `yaml:"server"`
-	Roles  RolesConfig    `yaml:"roles"`
+	Server  ServerSettings `yaml:"server"`
+	Roles   RolesConfig    `yaml:"roles"`
+	Tenants []TenantConfig `yaml:"tenants"` // communities sharing the instance, see TenantConfig
 }
 
 // ServerSettings contains server-wide settings
 type ServerSettings struct {
-	MaxPlayersPerRoom int           `yaml:"maxPlayersPerRoom"`
-	MinPlayersPerRoom int           `yaml:"minPlayersPerRoom"`
-	DefaultGameSize   int           `yaml:"defaultGameSize"`
-	RoomCodeLength    int           `yaml:"roomCodeLength"`
-	RoomTimeout       time.Duration `yaml:"roomTimeout"`
+	MaxPlayersPerRoom int           `yaml:"maxPlayersPerRoom" envconfig:"MAX_PLAYERS_PER_ROOM"`
+	MinPlayersPerRoom int           `yaml:"minPlayersPerRoom" envconfig:"MIN_PLAYERS_PER_ROOM"`
+	DefaultGameSize   int           `yaml:"defaultGameSize" envconfig:"DEFAULT_GAME_SIZE"`
+	RoomCodeLength    int           `yaml:"roomCodeLength" envconfig:"ROOM_CODE_LENGTH"`
+	RoomTimeout       time.Duration `yaml:"roomTimeout" envconfig:"ROOM_TIMEOUT"`
+
+	// Lobbies with more active players than this switch to large-room mode:
+	// the roster shows names and counts only, updated with signals instead of
+	// a re-render per player on every join (0 disables)
+	LargeRoomThreshold int `yaml:"largeRoomThreshold" envconfig:"LARGE_ROOM_THRESHOLD" default:"12"`
 
 	// Server settings
 	Port            string        `yaml:"port" envconfig:"PORT" required:"true"`
@@ -29,16 +36,60 @@ type ServerSettings struct {
 	WriteTimeout    time.Duration `yaml:"writeTimeout" envconfig:"WRITE_TIMEOUT" default:"15s"`
 	IdleTimeout     time.Duration `yaml:"idleTimeout" envconfig:"IDLE_TIMEOUT" default:"0s"` // 0 for SSE support
 	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
-	RequestTimeout  time.Duration `yaml:"requestTimeout"` // Timeout for regular HTTP requests (middleware)
-	SSETimeout      time.Duration `yaml:"sseTimeout"`     // Timeout for SSE connections (0 = no timeout)
+	DrainTimeout    time.Duration `yaml:"drainTimeout" envconfig:"DRAIN_TIMEOUT" default:"10s"` // How long shutdown waits for SSE clients to move (0 skips draining)
+	RequestTimeout  time.Duration `yaml:"requestTimeout" envconfig:"REQUEST_TIMEOUT"`           // Timeout for regular HTTP requests (middleware)
+	SSETimeout      time.Duration `yaml:"sseTimeout" envconfig:"SSE_TIMEOUT"`                   // Timeout for SSE connections (0 = no timeout)
+
+	// SSE streams send a keepalive every SSEKeepalive; rooms whose clients
+	// drop or reconnect often get a shorter interval, never below SSEKeepaliveFloor
+	SSEKeepalive      time.Duration `yaml:"sseKeepalive" envconfig:"SSE_KEEPALIVE" default:"15s"`
+	SSEKeepaliveFloor time.Duration `yaml:"sseKeepaliveFloor" envconfig:"SSE_KEEPALIVE_FLOOR" default:"5s"`
+
+	// A write to an SSE stream that the client hasn't taken within
+	// SSEWriteTimeout ends the stream as a disconnect, so a stalled peer can't
+	// hold it open forever (0 disables)
+	SSEWriteTimeout time.Duration `yaml:"sseWriteTimeout" envconfig:"SSE_WRITE_TIMEOUT" default:"10s"`
 
 	// Rate limiting (using golang.org/x/time/rate)
 	RateLimit      float64 `yaml:"rateLimit" envconfig:"RATE_LIMIT" default:"10"`            // requests per second
 	RateLimitBurst int     `yaml:"rateLimitBurst" envconfig:"RATE_LIMIT_BURST" default:"20"` // burst size
 
+	// Room setup changes (the /room/{code}/config/ endpoints) are limited per
+	// room as well, more strictly: each one re-renders every page in the room
+	ConfigRateLimit      float64 `yaml:"configRateLimit" envconfig:"CONFIG_RATE_LIMIT" default:"5"`             // requests per second per room
+	ConfigRateLimitBurst int     `yaml:"configRateLimitBurst" envconfig:"CONFIG_RATE_LIMIT_BURST" default:"10"` // burst size
+
+	// Room creation quotas per client IP and server-wide, counted over
+	// RoomCreationWindow; 0 turns a quota off
+	RoomCreationPerIP  int           `yaml:"roomCreationPerIp" envconfig:"ROOM_CREATION_PER_IP" default:"20"`
+	RoomCreationGlobal int           `yaml:"roomCreationGlobal" envconfig:"ROOM_CREATION_GLOBAL" default:"1000"`
+	RoomCreationWindow time.Duration `yaml:"roomCreationWindow" envconfig:"ROOM_CREATION_WINDOW" default:"1h"`
+
+	// Reverse proxies, as IP addresses or CIDR ranges, whose X-Forwarded-For
+	// names the client that rate limits count and whose X-Forwarded-Host picks
+	// the tenant a request is for; from any other peer both headers are
+	// ignored and the connection's own address and Host count
+	TrustedProxies []string `yaml:"trustedProxies" envconfig:"TRUSTED_PROXIES"`
+
+	// Bot checks on the create and join forms: a honeypot field, headless
+	// User-Agents, and posts sent sooner than BotMinSubmitTime after the form
+	// loaded or without the form's timing send the client to a verification
+	// page instead
+	BotChecks        bool          `yaml:"botChecks" envconfig:"BOT_CHECKS" default:"true"`
+	BotMinSubmitTime time.Duration `yaml:"botMinSubmitTime" envconfig:"BOT_MIN_SUBMIT_TIME" default:"1s"`
+
+	// Security headers. The Content-Security-Policy is generated to allow what
+	// the pages load; ContentSecurityPolicy replaces it, minus frame-ancestors.
+	// Pages can't be framed, except overlays by OverlayFrameAncestors, e.g.
+	// "https://streamer.example" for streaming tools that embed them.
+	ContentSecurityPolicy string        `yaml:"contentSecurityPolicy" envconfig:"CONTENT_SECURITY_POLICY"`
+	OverlayFrameAncestors string        `yaml:"overlayFrameAncestors" envconfig:"OVERLAY_FRAME_ANCESTORS" default:"'none'"`
+	HSTSMaxAge            time.Duration `yaml:"hstsMaxAge" envconfig:"HSTS_MAX_AGE" default:"4320h"` // sent over TLS only; 0 disables
+
 	// Request limits
-	MaxRequestSize    int64 `yaml:"maxRequestSize" envconfig:"MAX_REQUEST_SIZE" default:"1048576"` // 1MB
-	MaxSSEConnections int   `yaml:"maxSSEConnections" envconfig:"MAX_SSE_CONNECTIONS" default:"1000"`
+	MaxRequestSize           int64 `yaml:"maxRequestSize" envconfig:"MAX_REQUEST_SIZE" default:"1048576"`                   // 1MB
+	MaxSSEConnections        int   `yaml:"maxSSEConnections" envconfig:"MAX_SSE_CONNECTIONS" default:"1000"`                // open streams server-wide, 0 for no cap
+	MaxSSEConnectionsPerRoom int   `yaml:"maxSSEConnectionsPerRoom" envconfig:"MAX_SSE_CONNECTIONS_PER_ROOM" default:"100"` // open streams in one room, spectators included
 
 	// Monitoring
 	EnableMetrics bool   `yaml:"enableMetrics" envconfig:"ENABLE_METRICS" default:"false"`
@@ -46,18 +97,80 @@ type ServerSettings struct {
 	LogLevel      string `yaml:"logLevel" envconfig:"LOG_LEVEL" default:"info"`
 	LogFormat     string `yaml:"logFormat" envconfig:"LOG_FORMAT" default:"text"`
 
+	// Privacy logging writes player IDs and sessions to the log as salted
+	// hashes and player names as their player's hash. Room history and
+	// analytics older than HistoryRetention are dropped (0 keeps them as long
+	// as the room).
+	PrivacyLogging   bool          `yaml:"privacyLogging" envconfig:"PRIVACY_LOGGING" default:"false"`
+	HistoryRetention time.Duration `yaml:"historyRetention" envconfig:"HISTORY_RETENTION" default:"0s"`
+
 	// State backup (for Cloud Run instance recovery)
 	BackupEncryptionKey     string `yaml:"backupEncryptionKey" envconfig:"BACKUP_ENCRYPTION_KEY"` // 32-byte hex string (64 chars)
 	BackupEncryptionEnabled bool   `yaml:"backupEncryptionEnabled" envconfig:"BACKUP_ENCRYPTION_ENABLED" default:"true"`
 
+	// Secrets (cookie keys, backup key) are resolved at startup, never read from this file
+	SecretsProvider string `yaml:"secretsProvider" envconfig:"SECRETS_PROVIDER" default:"env"` // env, file or vault
+	SecretsDir      string `yaml:"secretsDir" envconfig:"SECRETS_DIR"`                         // file provider: one file per secret
+	VaultAddr       string `yaml:"vaultAddr" envconfig:"VAULT_ADDR"`
+	VaultPath       string `yaml:"vaultPath" envconfig:"VAULT_SECRET_PATH"` // KV v2 path, e.g. secret/data/treacherest
+
+	// Room store: the DSN's scheme picks a registered driver (see store.Open).
+	// "memory://" keeps rooms in this process only; "sqlite:///path/rooms.db"
+	// keeps them in an SQLite database across restarts.
+	StoreDSN string `yaml:"storeDSN" envconfig:"STORE_DSN" default:"memory://"`
+
+	// Email invites: the SMTP server that sends hosts' one-time join links
+	// (empty hides the invite form). Its password is the smtp_password secret.
+	SMTPAddr            string `yaml:"smtpAddr" envconfig:"SMTP_ADDR"` // host:port
+	SMTPFrom            string `yaml:"smtpFrom" envconfig:"SMTP_FROM"`
+	SMTPUsername        string `yaml:"smtpUsername" envconfig:"SMTP_USERNAME"`
+	InviteEmailsPerHour int    `yaml:"inviteEmailsPerHour" envconfig:"INVITE_EMAILS_PER_HOUR" default:"20"` // per room (0 lifts the limit)
+
+	// Read-only GraphQL API for dashboards at /api/graphql. Rooms need the
+	// admin token (a tenant's sees its own); hidden roles need the server's.
+	GraphQLEnabled bool `yaml:"graphqlEnabled" envconfig:"GRAPHQL_ENABLED" default:"false"`
+
+	// Maintenance mode is saved to this file, when set, so it survives restarts
+	MaintenanceStateFile string `yaml:"maintenanceStateFile" envconfig:"MAINTENANCE_STATE_FILE"`
+
+	// Card data: "embedded" uses the bundled Treachery cards, "sandbox" generates
+	// placeholder cards so the server runs without the asset bundle
+	CardSet             string `yaml:"cardSet" envconfig:"CARD_SET" default:"embedded"`
+	SandboxCardsPerType int    `yaml:"sandboxCardsPerType" envconfig:"SANDBOX_CARDS_PER_TYPE" default:"5"`
+
+	// Card packs new rooms start with enabled, by pack ID (empty enables every
+	// pack); hosts can still toggle the others on in the room config
+	CardPacks []string `yaml:"cardPacks" envconfig:"CARD_PACKS"`
+
+	// Render role names and rules without card artwork, for deployments that can't ship it
+	TextOnlyCards bool `yaml:"textOnlyCards" envconfig:"TEXT_ONLY_CARDS" default:"false"`
+
+	// Directory of card text translations, one <locale>.json per language
+	// mapping card IDs to their name, text, flavor and rulings
+	CardTranslationsDir string `yaml:"cardTranslationsDir" envconfig:"CARD_TRANSLATIONS_DIR"`
+
 	// Debug mode (enables debug panel on game pages and debug endpoints)
 	DebugModeEnabled bool `yaml:"debugModeEnabled" envconfig:"DEBUG_MODE_ENABLED" default:"false"`
+
+	// Accessibility audit (development: logs a11y problems in rendered pages and SSE fragments)
+	A11yAuditEnabled bool `yaml:"a11yAuditEnabled" envconfig:"A11Y_AUDIT_ENABLED" default:"false"`
+
+	// Drop SSE patches whose selector the viewer's page never renders (development only logs them)
+	StrictSelectors bool `yaml:"strictSelectors" envconfig:"STRICT_SELECTORS" default:"false"`
 }
 
 // RolesConfig contains role definitions and presets
 type RolesConfig struct {
 	Available map[string]RoleDefinition `yaml:"available"`
 	Presets   map[string]Preset         `yaml:"presets"`
+	Knowledge []KnowledgeRule           `yaml:"knowledge"`
+}
+
+// KnowledgeRule grants players of one role private knowledge of who holds another role,
+// e.g. {viewer: guardian, sees: guardian} lets Guardians know each other
+type KnowledgeRule struct {
+	Viewer string `yaml:"viewer"` // Role key (from Available) that receives the knowledge
+	Sees   string `yaml:"sees"`   // Role key whose holders are revealed to the viewer
 }
 
 // RoleDefinition defines a single role type
@@ -69,11 +182,16 @@ type RoleDefinition struct {
 	AlwaysRevealed bool   `yaml:"alwaysRevealed"`
 }
 
-// Preset defines a named role distribution preset
+// Preset defines a named role distribution preset. A preset that extends
+// another starts from its parent's distributions and replaces the player
+// counts it lists (see resolvePresets). Unlisted player counts are filled
+// as Interpolation says (see DistributionFor).
 type Preset struct {
 	Name          string                 `yaml:"name"`
 	Description   string                 `yaml:"description"`
+	Extends       string                 `yaml:"extends"`
 	Distributions map[int]map[string]int `yaml:"distributions"`
+	Interpolation Interpolation          `yaml:"interpolation"`
 }
 
 // DefaultConfig returns a default configuration
@@ -86,6 +204,8 @@ func DefaultConfig() *ServerConfig {
 			RoomCodeLength:    5,
 			RoomTimeout:       24 * time.Hour,
 
+			LargeRoomThreshold: 12,
+
 			// Server defaults
 			Port:            "", // Must be set via env
 			Host:            "", // Must be set via env
@@ -93,20 +213,54 @@ func DefaultConfig() *ServerConfig {
 			WriteTimeout:    10 * time.Minute, // 10 minutes for SSE support
 			IdleTimeout:     0,                // 0 for SSE support
 			ShutdownTimeout: 30 * time.Second,
+			DrainTimeout:    10 * time.Second,
+
+			// SSE keepalive defaults
+			SSEKeepalive:      15 * time.Second,
+			SSEKeepaliveFloor: 5 * time.Second,
+			SSEWriteTimeout:   10 * time.Second,
 
 			// Rate limiting defaults
 			RateLimit:      10, // 10 requests per second
 			RateLimitBurst: 20,
 
+			// Per-room config mutation limits
+			ConfigRateLimit:      5,
+			ConfigRateLimitBurst: 10,
+
+			// Room creation quotas
+			RoomCreationPerIP:  20,
+			RoomCreationGlobal: 1000,
+			RoomCreationWindow: time.Hour,
+
+			// Bot checks
+			BotChecks:        true,
+			BotMinSubmitTime: time.Second,
+
+			// Security headers
+			OverlayFrameAncestors: "'none'",
+			HSTSMaxAge:            180 * 24 * time.Hour,
+
 			// Request limits
-			MaxRequestSize:    10485760, // 10MB
-			MaxSSEConnections: 1000,
+			MaxRequestSize:           10485760, // 10MB
+			MaxSSEConnections:        1000,
+			MaxSSEConnectionsPerRoom: 100,
 
 			// Monitoring defaults
 			EnableMetrics: false,
 			MetricsPort:   "", // Must be set if metrics enabled
 			LogLevel:      "info",
 			LogFormat:     "text",
+
+			// Room store defaults
+			StoreDSN: "memory://",
+
+			// Email invite defaults
+			InviteEmailsPerHour: 20,
+
+			// Card data defaults
+			CardSet:             "embedded",
+			SandboxCardsPerType: 5,
 		},
 		Roles: RolesConfig{
 			Available: map[string]RoleDefinition{
@@ -156,32 +310,121 @@ func DefaultConfig() *ServerConfig {
 	}
 }
 
-// Validate checks if the configuration is valid
+// Validate checks the configuration and reports every problem it finds as a
+// *ValidationError with YAML field paths. It also clamps DefaultGameSize into range.
 func (c *ServerConfig) Validate() error {
+	problems := &ValidationError{}
+
 	// Required fields
 	if c.Server.Port == "" {
-		return fmt.Errorf("PORT environment variable must be set")
+		problems.add("server.port", "must be set (PORT environment variable)")
 	}
 	if c.Server.Host == "" {
-		return fmt.Errorf("HOST environment variable must be set")
+		problems.add("server.host", "must be set (HOST environment variable)")
 	}
 
 	// If metrics are enabled, port must be set
 	if c.Server.EnableMetrics && c.Server.MetricsPort == "" {
-		return fmt.Errorf("METRICS_PORT must be set when ENABLE_METRICS is true")
+		problems.add("server.metricsPort", "must be set when enableMetrics is true (METRICS_PORT)")
 	}
 
 	if c.Server.MaxPlayersPerRoom < 1 {
-		return fmt.Errorf("maxPlayersPerRoom must be at least 1")
+		problems.add("server.maxPlayersPerRoom", "must be at least 1")
 	}
 	if c.Server.MinPlayersPerRoom < 1 {
-		return fmt.Errorf("minPlayersPerRoom must be at least 1")
+		problems.add("server.minPlayersPerRoom", "must be at least 1")
 	}
 	if c.Server.MinPlayersPerRoom > c.Server.MaxPlayersPerRoom {
-		return fmt.Errorf("minPlayersPerRoom cannot be greater than maxPlayersPerRoom")
+		problems.add("server.minPlayersPerRoom", "cannot be greater than maxPlayersPerRoom (%d)", c.Server.MaxPlayersPerRoom)
 	}
 	if c.Server.RoomCodeLength < 3 {
-		return fmt.Errorf("roomCodeLength must be at least 3")
+		problems.add("server.roomCodeLength", "must be at least 3")
+	}
+	switch c.Server.SecretsProvider {
+	case "", "env", "file", "vault":
+	default:
+		problems.addUnknown("server.secretsProvider", "secrets provider", c.Server.SecretsProvider, []string{"env", "file", "vault"})
+	}
+
+	switch c.Server.LogLevel {
+	case "", "debug", "info", "warn", "error":
+	default:
+		problems.addUnknown("server.logLevel", "log level", c.Server.LogLevel, []string{"debug", "info", "warn", "error"})
+	}
+	switch c.Server.LogFormat {
+	case "", "text", "json":
+	default:
+		problems.addUnknown("server.logFormat", "log format", c.Server.LogFormat, []string{"text", "json"})
+	}
+
+	switch c.Server.CardSet {
+	case "", "embedded", "sandbox":
+	default:
+		problems.addUnknown("server.cardSet", "card set", c.Server.CardSet, []string{"embedded", "sandbox"})
+	}
+	if c.Server.SandboxCardsPerType < 0 {
+		problems.add("server.sandboxCardsPerType", "cannot be negative")
+	}
+	if c.Server.RateLimit < 0 {
+		problems.add("server.rateLimit", "cannot be negative")
+	}
+	if c.Server.RateLimitBurst < 0 {
+		problems.add("server.rateLimitBurst", "cannot be negative")
+	}
+	if c.Server.ConfigRateLimit < 0 {
+		problems.add("server.configRateLimit", "cannot be negative")
+	}
+	if c.Server.ConfigRateLimitBurst < 0 {
+		problems.add("server.configRateLimitBurst", "cannot be negative")
+	}
+	for i, entry := range c.Server.TrustedProxies {
+		if _, err := parseTrustedProxy(entry); err != nil {
+			problems.add(fmt.Sprintf("server.trustedProxies.%d", i), "%q is not an IP address or CIDR range", entry)
+		}
+	}
+	if c.Server.MaxSSEConnections < 0 {
+		problems.add("server.maxSSEConnections", "cannot be negative")
+	}
+	if c.Server.MaxSSEConnectionsPerRoom < 0 {
+		problems.add("server.maxSSEConnectionsPerRoom", "cannot be negative")
+	}
+	if c.Server.RoomCreationPerIP < 0 {
+		problems.add("server.roomCreationPerIp", "cannot be negative")
+	}
+	if c.Server.RoomCreationGlobal < 0 {
+		problems.add("server.roomCreationGlobal", "cannot be negative")
+	}
+	if (c.Server.RoomCreationPerIP > 0 || c.Server.RoomCreationGlobal > 0) && c.Server.RoomCreationWindow <= 0 {
+		problems.add("server.roomCreationWindow", "must be positive when a room creation quota is set")
+	}
+	if c.Server.SSEKeepalive < 0 {
+		problems.add("server.sseKeepalive", "cannot be negative")
+	}
+	if c.Server.SSEKeepaliveFloor < 0 {
+		problems.add("server.sseKeepaliveFloor", "cannot be negative")
+	} else if c.Server.SSEKeepalive > 0 && c.Server.SSEKeepaliveFloor > c.Server.SSEKeepalive {
+		problems.add("server.sseKeepaliveFloor", "cannot be longer than server.sseKeepalive")
+	}
+	if c.Server.SSEWriteTimeout < 0 {
+		problems.add("server.sseWriteTimeout", "cannot be negative")
+	}
+	if c.Server.BotMinSubmitTime < 0 {
+		problems.add("server.botMinSubmitTime", "cannot be negative")
+	}
+	if c.Server.HSTSMaxAge < 0 {
+		problems.add("server.hstsMaxAge", "cannot be negative")
+	}
+	if c.Server.LargeRoomThreshold < 0 {
+		problems.add("server.largeRoomThreshold", "cannot be negative")
+	}
+	if c.Server.HistoryRetention < 0 {
+		problems.add("server.historyRetention", "cannot be negative")
+	}
+	if c.Server.SMTPAddr != "" && c.Server.SMTPFrom == "" {
+		problems.add("server.smtpFrom", "must be set when smtpAddr is (SMTP_FROM)")
+	}
+	if c.Server.InviteEmailsPerHour < 0 {
+		problems.add("server.inviteEmailsPerHour", "cannot be negative")
 	}
 
 	// Validate and fix DefaultGameSize
@@ -196,36 +439,81 @@ func (c *ServerConfig) Validate() error {
 	}
 
 	// Validate roles
+	roleNames := sortedKeys(c.Roles.Available)
 	hasLeader := false
-	for name, role := range c.Roles.Available {
+	for _, name := range roleNames {
+		role := c.Roles.Available[name]
 		if role.MinCount > role.MaxCount {
-			return fmt.Errorf("role %s: minCount cannot be greater than maxCount", name)
+			problems.add("roles.available."+name+".minCount", "cannot be greater than maxCount (%d)", role.MaxCount)
 		}
 		if role.Category == "Leader" {
 			hasLeader = true
 		}
 	}
 	if !hasLeader {
-		return fmt.Errorf("at least one Leader role must be defined")
+		problems.add("roles.available", "at least one Leader role must be defined")
 	}
 
-	// Validate presets
-	for presetName, preset := range c.Roles.Presets {
-		for playerCount, distribution := range preset.Distributions {
+	// Validate knowledge rules
+	for i, rule := range c.Roles.Knowledge {
+		path := fmt.Sprintf("roles.knowledge.%d", i)
+		if _, exists := c.Roles.Available[rule.Viewer]; !exists {
+			problems.addUnknown(path+".viewer", "viewer role "+rule.Viewer, rule.Viewer, roleNames)
+		}
+		if _, exists := c.Roles.Available[rule.Sees]; !exists {
+			problems.addUnknown(path+".sees", "seen role "+rule.Sees, rule.Sees, roleNames)
+		}
+	}
+
+	// Validate presets, once inheritance has filled in their distributions
+	c.resolvePresets(problems)
+	for _, presetName := range sortedKeys(c.Roles.Presets) {
+		preset := c.Roles.Presets[presetName]
+
+		interpolationPath := "roles.presets." + presetName + ".interpolation"
+		switch preset.Interpolation.Method {
+		case "", InterpolateScale, InterpolatePad:
+		default:
+			problems.addUnknown(interpolationPath+".method", "interpolation method", preset.Interpolation.Method, interpolationMethods)
+		}
+		for i, roleName := range preset.Interpolation.Fixed {
+			if _, exists := c.Roles.Available[roleName]; !exists {
+				problems.addUnknown(fmt.Sprintf("%s.fixed.%d", interpolationPath, i), "role", roleName, roleNames)
+			}
+		}
+		for i, roleName := range preset.Interpolation.Priority {
+			if _, exists := c.Roles.Available[roleName]; !exists {
+				problems.addUnknown(fmt.Sprintf("%s.priority.%d", interpolationPath, i), "role", roleName, roleNames)
+			}
+		}
+
+		playerCounts := make([]int, 0, len(preset.Distributions))
+		for playerCount := range preset.Distributions {
+			playerCounts = append(playerCounts, playerCount)
+		}
+		sort.Ints(playerCounts)
+
+		for _, playerCount := range playerCounts {
+			path := fmt.Sprintf("roles.presets.%s.distributions.%d", presetName, playerCount)
 			if playerCount < 1 || playerCount > c.Server.MaxPlayersPerRoom {
-				return fmt.Errorf("preset %s: invalid player count %d", presetName, playerCount)
+				problems.add(path, "invalid player count, must be between 1 and %d", c.Server.MaxPlayersPerRoom)
 			}
 
 			// Check that all roles in distribution exist
-			for roleName := range distribution {
+			distribution := preset.Distributions[playerCount]
+			for _, roleName := range sortedKeys(distribution) {
 				if _, exists := c.Roles.Available[roleName]; !exists {
-					return fmt.Errorf("preset %s: unknown role %s", presetName, roleName)
+					problems.addUnknown(path+"."+roleName, "role", roleName, roleNames)
+				} else if distribution[roleName] < 0 {
+					problems.add(path+"."+roleName, "count cannot be negative")
 				}
 			}
 		}
 	}
 
-	return nil
+	c.validateTenants(problems)
+
+	return problems.err()
 }
 
 // GetPreset returns a preset by name
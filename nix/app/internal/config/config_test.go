@@ -150,7 +150,7 @@ func TestConfigValidation(t *testing.T) {
 				},
 			},
 			wantError: true,
-			errorMsg:  "maxPlayersPerRoom must be at least 1",
+			errorMsg:  "server.maxPlayersPerRoom: must be at least 1",
 		},
 		{
 			name: "MinGreaterThanMax",
@@ -169,7 +169,7 @@ func TestConfigValidation(t *testing.T) {
 				},
 			},
 			wantError: true,
-			errorMsg:  "minPlayersPerRoom cannot be greater than maxPlayersPerRoom",
+			errorMsg:  "server.minPlayersPerRoom: cannot be greater than maxPlayersPerRoom",
 		},
 		{
 			name: "NoLeaderRole",
@@ -188,7 +188,7 @@ func TestConfigValidation(t *testing.T) {
 				},
 			},
 			wantError: true,
-			errorMsg:  "at least one Leader role must be defined",
+			errorMsg:  "roles.available: at least one Leader role must be defined",
 		},
 		{
 			name: "InvalidRoleMinMax",
@@ -211,7 +211,7 @@ func TestConfigValidation(t *testing.T) {
 				},
 			},
 			wantError: true,
-			errorMsg:  "minCount cannot be greater than maxCount",
+			errorMsg:  "minCount: cannot be greater than maxCount",
 		},
 		{
 			name: "UnknownRoleInPreset",
@@ -239,6 +239,26 @@ func TestConfigValidation(t *testing.T) {
 			wantError: true,
 			errorMsg:  "unknown role",
 		},
+		{
+			name: "UnknownKnowledgeRole",
+			config: &ServerConfig{
+				Server: ServerSettings{
+					Host:              "localhost",
+					Port:              "8080",
+					MaxPlayersPerRoom: 20,
+					MinPlayersPerRoom: 1,
+					RoomCodeLength:    5,
+				},
+				Roles: RolesConfig{
+					Available: map[string]RoleDefinition{
+						"leader": {Category: "Leader"},
+					},
+					Knowledge: []KnowledgeRule{{Viewer: "guardian", Sees: "leader"}},
+				},
+			},
+			wantError: true,
+			errorMsg:  "unknown viewer role",
+		},
 	}
 
 	for _, tt := range tests {
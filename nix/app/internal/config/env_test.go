@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEverySettingHasEnvBinding keeps every server setting overridable from the environment
+func TestEverySettingHasEnvBinding(t *testing.T) {
+	settings := reflect.TypeOf(ServerSettings{})
+	for i := 0; i < settings.NumField(); i++ {
+		field := settings.Field(i)
+		if field.Tag.Get("envconfig") == "" {
+			t.Errorf("ServerSettings.%s has no envconfig tag", field.Name)
+		}
+	}
+
+	if got := len(EnvBindings()); got != settings.NumField() {
+		t.Errorf("expected %d bindings, got %d", settings.NumField(), got)
+	}
+}
+
+func writeTestConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "server.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigEnvOverridesEveryKind(t *testing.T) {
+	t.Setenv("PORT", "9000")
+	t.Setenv("HOST", "127.0.0.1")
+	t.Setenv("ROOM_TIMEOUT", "2h")
+	t.Setenv("MAX_PLAYERS_PER_ROOM", "12")
+	t.Setenv("RATE_LIMIT", "2.5")
+	t.Setenv("DEBUG_MODE_ENABLED", "true")
+	t.Setenv("SSE_TIMEOUT", "90s")
+
+	cfg, err := LoadConfig(writeTestConfig(t, "server:\n  maxPlayersPerRoom: 8\n"))
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	if cfg.Server.Port != "9000" || cfg.Server.Host != "127.0.0.1" {
+		t.Errorf("expected host/port from env, got %s:%s", cfg.Server.Host, cfg.Server.Port)
+	}
+	if cfg.Server.RoomTimeout != 2*time.Hour {
+		t.Errorf("expected roomTimeout 2h, got %v", cfg.Server.RoomTimeout)
+	}
+	if cfg.Server.MaxPlayersPerRoom != 12 {
+		t.Errorf("expected env to beat config file for maxPlayersPerRoom, got %d", cfg.Server.MaxPlayersPerRoom)
+	}
+	if cfg.Server.RateLimit != 2.5 {
+		t.Errorf("expected rateLimit 2.5, got %v", cfg.Server.RateLimit)
+	}
+	if !cfg.Server.DebugModeEnabled {
+		t.Error("expected debugModeEnabled from env")
+	}
+	if cfg.Server.SSETimeout != 90*time.Second {
+		t.Errorf("expected sseTimeout 90s, got %v", cfg.Server.SSETimeout)
+	}
+}
+
+func TestLoadConfigEnvPrecedence(t *testing.T) {
+	t.Setenv("PORT", "8080")
+	t.Setenv("HOST", "localhost")
+	path := writeTestConfig(t, "server:\n  roomCodeLength: 6\n")
+
+	t.Run("config file beats defaults", func(t *testing.T) {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Server.RoomCodeLength != 6 {
+			t.Errorf("expected 6, got %d", cfg.Server.RoomCodeLength)
+		}
+	})
+
+	t.Run("legacy name beats config file", func(t *testing.T) {
+		t.Setenv("SERVER_ROOMCODELENGTH", "7")
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Server.RoomCodeLength != 7 {
+			t.Errorf("expected 7, got %d", cfg.Server.RoomCodeLength)
+		}
+	})
+
+	t.Run("prefixed name beats legacy name", func(t *testing.T) {
+		t.Setenv("SERVER_ROOMCODELENGTH", "7")
+		t.Setenv("TREACHEREST_SERVER_ROOM_CODE_LENGTH", "8")
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Server.RoomCodeLength != 8 {
+			t.Errorf("expected 8, got %d", cfg.Server.RoomCodeLength)
+		}
+	})
+
+	t.Run("short name beats prefixed name", func(t *testing.T) {
+		t.Setenv("TREACHEREST_SERVER_ROOM_CODE_LENGTH", "8")
+		t.Setenv("ROOM_CODE_LENGTH", "9")
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Server.RoomCodeLength != 9 {
+			t.Errorf("expected 9, got %d", cfg.Server.RoomCodeLength)
+		}
+	})
+}
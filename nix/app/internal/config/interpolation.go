@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Interpolation methods
+const (
+	// InterpolateScale scales the nearest listed distribution to the player
+	// count; it is the default
+	InterpolateScale = "scale"
+	// InterpolatePad takes the nearest listed distribution and adds or removes
+	// Guardians until it fits, the behaviour before interpolation was configurable
+	InterpolatePad = "pad"
+)
+
+var (
+	defaultFixedRoles    = []string{"leader"}
+	defaultRolePriority  = []string{"guardian", "assassin", "traitor", "leader"}
+	interpolationMethods = []string{InterpolateScale, InterpolatePad}
+)
+
+// Interpolation configures how a preset fills a player count its
+// distributions do not list
+type Interpolation struct {
+	Method   string   `yaml:"method"`   // "scale" (default) or "pad"
+	Fixed    []string `yaml:"fixed"`    // roles kept at their listed count while the rest scale; default leader
+	Priority []string `yaml:"priority"` // order that breaks rounding ties; default guardian, assassin, traitor, leader
+}
+
+// DistributionFor returns the role counts for playerCount. A listed player
+// count is returned as written. Otherwise the nearest listed count, the
+// smaller on a tie, is the anchor and the configured method fills the gap.
+//
+// Scaling works like this:
+//  1. Fixed roles keep the anchor's counts, unless they alone would exceed
+//     playerCount, in which case every role scales.
+//  2. Each other role gets a quota of the remaining seats in proportion to
+//     its share of the anchor's non-fixed roles, and the whole part of it.
+//  3. Seats left over by rounding down go one each to the roles with the
+//     largest fractional quota; Priority, then role name, breaks ties.
+//
+// So the counts always sum to playerCount and keep the anchor's mix as
+// closely as whole seats allow.
+func (p Preset) DistributionFor(playerCount int) (map[string]int, error) {
+	if playerCount < 1 {
+		return nil, fmt.Errorf("player count must be at least 1, got %d", playerCount)
+	}
+	if len(p.Distributions) == 0 {
+		return nil, fmt.Errorf("preset has no distributions")
+	}
+	if listed, ok := p.Distributions[playerCount]; ok {
+		return copyDistribution(listed), nil
+	}
+
+	anchor := p.Distributions[p.nearestPlayerCount(playerCount)]
+	if p.Interpolation.Method == InterpolatePad {
+		return padDistribution(anchor, playerCount), nil
+	}
+	return p.scaleDistribution(anchor, playerCount), nil
+}
+
+// nearestPlayerCount returns the listed player count closest to playerCount,
+// preferring the smaller on a tie
+func (p Preset) nearestPlayerCount(playerCount int) int {
+	counts := make([]int, 0, len(p.Distributions))
+	for count := range p.Distributions {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+
+	nearest := counts[0]
+	for _, count := range counts[1:] {
+		if abs(count-playerCount) < abs(nearest-playerCount) {
+			nearest = count
+		}
+	}
+	return nearest
+}
+
+func (p Preset) scaleDistribution(anchor map[string]int, playerCount int) map[string]int {
+	fixed := p.Interpolation.Fixed
+	if fixed == nil {
+		fixed = defaultFixedRoles
+	}
+	isFixed := make(map[string]bool, len(fixed))
+	fixedSeats := 0
+	for _, role := range fixed {
+		if !isFixed[role] {
+			isFixed[role] = true
+			fixedSeats += anchor[role]
+		}
+	}
+	if fixedSeats > playerCount {
+		isFixed = map[string]bool{}
+		fixedSeats = 0
+	}
+
+	result := make(map[string]int, len(anchor))
+	scaledAnchorSeats := 0
+	for role, count := range anchor {
+		if isFixed[role] {
+			result[role] = count
+		} else {
+			scaledAnchorSeats += count
+		}
+	}
+	seats := playerCount - fixedSeats
+	if scaledAnchorSeats == 0 {
+		// Nothing to scale; the fixed roles keep their counts
+		return result
+	}
+
+	type share struct {
+		role      string
+		remainder int // numerator of the fractional quota over scaledAnchorSeats
+	}
+	var shares []share
+	assigned := 0
+	for role, count := range anchor {
+		if isFixed[role] {
+			continue
+		}
+		quota := count * seats
+		result[role] = quota / scaledAnchorSeats
+		assigned += result[role]
+		shares = append(shares, share{role: role, remainder: quota % scaledAnchorSeats})
+	}
+
+	rank := p.priorityRank()
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].remainder != shares[j].remainder {
+			return shares[i].remainder > shares[j].remainder
+		}
+		if rank(shares[i].role) != rank(shares[j].role) {
+			return rank(shares[i].role) < rank(shares[j].role)
+		}
+		return shares[i].role < shares[j].role
+	})
+	for i := 0; assigned < seats; i++ {
+		result[shares[i%len(shares)].role]++
+		assigned++
+	}
+	for role, count := range result {
+		if count == 0 {
+			delete(result, role)
+		}
+	}
+	return result
+}
+
+// priorityRank orders roles for tie-breaks; unlisted roles come last
+func (p Preset) priorityRank() func(string) int {
+	priority := p.Interpolation.Priority
+	if priority == nil {
+		priority = defaultRolePriority
+	}
+	ranks := make(map[string]int, len(priority))
+	for i, role := range priority {
+		if _, seen := ranks[role]; !seen {
+			ranks[role] = i
+		}
+	}
+	return func(role string) int {
+		if rank, ok := ranks[role]; ok {
+			return rank
+		}
+		return len(priority)
+	}
+}
+
+// padDistribution fills with Guardians, or removes them down to one
+func padDistribution(anchor map[string]int, playerCount int) map[string]int {
+	result := copyDistribution(anchor)
+	total := 0
+	for _, count := range result {
+		total += count
+	}
+	if total < playerCount {
+		result["guardian"] += playerCount - total
+	}
+	for total > playerCount && result["guardian"] > 1 {
+		result["guardian"]--
+		total--
+	}
+	return result
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
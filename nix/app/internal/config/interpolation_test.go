@@ -0,0 +1,149 @@
+package config
+
+import "testing"
+
+// distributionRow is a player count and the leader, guardian, assassin and
+// traitor counts expected for it
+type distributionRow [5]int
+
+func assertDistributions(t *testing.T, preset Preset, rows []distributionRow) {
+	t.Helper()
+	for _, row := range rows {
+		got, err := preset.DistributionFor(row[0])
+		if err != nil {
+			t.Fatalf("%d players: %v", row[0], err)
+		}
+		want := map[string]int{"leader": row[1], "guardian": row[2], "assassin": row[3], "traitor": row[4]}
+		for role, count := range want {
+			if got[role] != count {
+				t.Errorf("%d players: got %v, want %v", row[0], got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestDistributionForStandardPreset(t *testing.T) {
+	// 1-8 are listed; 9-20 scale the 8-player table with the Leader fixed
+	assertDistributions(t, DefaultConfig().Roles.Presets["standard"], []distributionRow{
+		{1, 1, 0, 0, 0},
+		{2, 1, 0, 0, 1},
+		{3, 1, 1, 0, 1},
+		{4, 1, 2, 0, 1},
+		{5, 1, 2, 1, 1},
+		{6, 1, 2, 2, 1},
+		{7, 1, 3, 2, 1},
+		{8, 1, 3, 2, 2},
+		{9, 1, 4, 2, 2},
+		{10, 1, 4, 3, 2},
+		{11, 1, 4, 3, 3},
+		{12, 1, 5, 3, 3},
+		{13, 1, 5, 4, 3},
+		{14, 1, 5, 4, 4},
+		{15, 1, 6, 4, 4},
+		{16, 1, 7, 4, 4},
+		{17, 1, 7, 5, 4},
+		{18, 1, 7, 5, 5},
+		{19, 1, 8, 5, 5},
+		{20, 1, 8, 6, 5},
+	})
+}
+
+func sparsePreset() Preset {
+	return Preset{Distributions: map[int]map[string]int{
+		5:  {"leader": 1, "guardian": 2, "assassin": 1, "traitor": 1},
+		10: {"leader": 1, "guardian": 4, "assassin": 3, "traitor": 2},
+	}}
+}
+
+func TestDistributionForSparsePresetScales(t *testing.T) {
+	// 1-7 anchor on 5 players, 8-20 on 10; ties in rounding go to the
+	// Guardian, then the Assassin, then the Traitor
+	assertDistributions(t, sparsePreset(), []distributionRow{
+		{1, 1, 0, 0, 0},
+		{2, 1, 1, 0, 0},
+		{3, 1, 1, 1, 0},
+		{4, 1, 1, 1, 1},
+		{5, 1, 2, 1, 1},
+		{6, 1, 3, 1, 1},
+		{7, 1, 3, 2, 1},
+		{8, 1, 3, 2, 2},
+		{9, 1, 3, 3, 2},
+		{10, 1, 4, 3, 2},
+		{11, 1, 5, 3, 2},
+		{12, 1, 5, 4, 2},
+		{13, 1, 5, 4, 3},
+		{14, 1, 6, 4, 3},
+		{15, 1, 6, 5, 3},
+		{16, 1, 7, 5, 3},
+		{17, 1, 7, 5, 4},
+		{18, 1, 7, 6, 4},
+		{19, 1, 8, 6, 4},
+		{20, 1, 9, 6, 4},
+	})
+}
+
+func TestDistributionForSparsePresetPads(t *testing.T) {
+	// Padding only ever changes Guardians, so small tables keep one of each role
+	preset := sparsePreset()
+	preset.Interpolation.Method = InterpolatePad
+	assertDistributions(t, preset, []distributionRow{
+		{1, 1, 1, 1, 1},
+		{2, 1, 1, 1, 1},
+		{3, 1, 1, 1, 1},
+		{4, 1, 1, 1, 1},
+		{5, 1, 2, 1, 1},
+		{6, 1, 3, 1, 1},
+		{7, 1, 4, 1, 1},
+		{8, 1, 2, 3, 2},
+		{9, 1, 3, 3, 2},
+		{10, 1, 4, 3, 2},
+		{11, 1, 5, 3, 2},
+		{12, 1, 6, 3, 2},
+		{13, 1, 7, 3, 2},
+		{14, 1, 8, 3, 2},
+		{15, 1, 9, 3, 2},
+		{16, 1, 10, 3, 2},
+		{17, 1, 11, 3, 2},
+		{18, 1, 12, 3, 2},
+		{19, 1, 13, 3, 2},
+		{20, 1, 14, 3, 2},
+	})
+}
+
+func TestDistributionForCustomFixedAndPriority(t *testing.T) {
+	preset := sparsePreset()
+	preset.Interpolation = Interpolation{Fixed: []string{"leader", "traitor"}, Priority: []string{"assassin", "guardian"}}
+
+	// 12 players from the 10-player table: 9 scaled seats over 4 Guardians
+	// and 3 Assassins give 5.14 and 3.86, so the Assassin takes the spare seat
+	// by remainder, not priority
+	assertDistributions(t, preset, []distributionRow{{12, 1, 5, 4, 2}})
+
+	// 8 players: 5 scaled seats give 2.86 and 2.14
+	assertDistributions(t, preset, []distributionRow{{8, 1, 3, 2, 2}})
+}
+
+func TestDistributionForRejectsEmptyTables(t *testing.T) {
+	if _, err := sparsePreset().DistributionFor(0); err == nil {
+		t.Error("expected an error for zero players")
+	}
+	if _, err := (Preset{}).DistributionFor(5); err == nil {
+		t.Error("expected an error for a preset without distributions")
+	}
+}
+
+func TestValidateRejectsUnknownInterpolation(t *testing.T) {
+	cfg := validBaseConfig()
+	preset := cfg.Roles.Presets["standard"]
+	preset.Interpolation = Interpolation{Method: "scal", Priority: []string{"gaurdian"}}
+	cfg.Roles.Presets["standard"] = preset
+
+	got := cfg.Validate().Error()
+	want := "2 configuration problems:" +
+		"\n  - roles.presets.standard.interpolation.method: unknown interpolation method, did you mean scale?" +
+		"\n  - roles.presets.standard.interpolation.priority.0: unknown role, did you mean guardian?"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
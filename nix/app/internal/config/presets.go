@@ -0,0 +1,91 @@
+package config
+
+import "strings"
+
+// Preset resolution states
+const (
+	presetUnresolved = iota
+	presetResolving
+	presetResolved
+	presetBroken
+)
+
+// resolvePresets folds each preset's extends chain into its distributions,
+// so the rest of the server only ever sees complete presets. A child keeps
+// the player counts it lists and inherits the rest, along with its parent's
+// description and interpolation when it sets none. Unknown parents and cycles are recorded in
+// problems and leave the presets involved as written.
+func (c *ServerConfig) resolvePresets(problems *ValidationError) {
+	states := make(map[string]int, len(c.Roles.Presets))
+	for _, name := range sortedKeys(c.Roles.Presets) {
+		c.resolvePreset(name, nil, states, problems)
+	}
+}
+
+// resolvePreset resolves name, whose dependants so far are chain, and reports
+// whether it resolved
+func (c *ServerConfig) resolvePreset(name string, chain []string, states map[string]int, problems *ValidationError) bool {
+	switch states[name] {
+	case presetResolved:
+		return true
+	case presetBroken:
+		return false
+	case presetResolving:
+		cycle := chain
+		for i, dependant := range chain {
+			if dependant == name {
+				cycle = chain[i:]
+				break
+			}
+		}
+		problems.add("roles.presets."+cycle[0]+".extends", "inheritance cycle %s", strings.Join(append(cycle, name), " -> "))
+		for _, dependant := range cycle {
+			states[dependant] = presetBroken
+		}
+		return false
+	}
+
+	preset := c.Roles.Presets[name]
+	if preset.Extends == "" {
+		states[name] = presetResolved
+		return true
+	}
+	if _, exists := c.Roles.Presets[preset.Extends]; !exists {
+		problems.addUnknown("roles.presets."+name+".extends", "preset", preset.Extends, sortedKeys(c.Roles.Presets))
+		states[name] = presetBroken
+		return false
+	}
+
+	states[name] = presetResolving
+	if !c.resolvePreset(preset.Extends, append(chain, name), states, problems) {
+		states[name] = presetBroken
+		return false
+	}
+
+	parent := c.Roles.Presets[preset.Extends]
+	distributions := make(map[int]map[string]int, len(parent.Distributions)+len(preset.Distributions))
+	for playerCount, distribution := range parent.Distributions {
+		distributions[playerCount] = copyDistribution(distribution)
+	}
+	for playerCount, distribution := range preset.Distributions {
+		distributions[playerCount] = copyDistribution(distribution)
+	}
+	preset.Distributions = distributions
+	if preset.Description == "" {
+		preset.Description = parent.Description
+	}
+	if preset.Interpolation.Method == "" && preset.Interpolation.Fixed == nil && preset.Interpolation.Priority == nil {
+		preset.Interpolation = parent.Interpolation
+	}
+	c.Roles.Presets[name] = preset
+	states[name] = presetResolved
+	return true
+}
+
+func copyDistribution(distribution map[string]int) map[string]int {
+	copied := make(map[string]int, len(distribution))
+	for role, count := range distribution {
+		copied[role] = count
+	}
+	return copied
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed rules.yaml
+var rulesYAML []byte
+
+// RulesReference is the rules pages' content: each rules mode and every role
+// type's win condition, reveal rules and tips (see rules.yaml)
+type RulesReference struct {
+	Modes []RulesMode `yaml:"modes"`
+	Roles []RoleRules `yaml:"roles"`
+}
+
+// RulesMode introduces one rules mode's roles
+type RulesMode struct {
+	ID      string `yaml:"id"` // a game.RulesMode
+	Name    string `yaml:"name"`
+	Summary string `yaml:"summary"`
+}
+
+// RoleRules is one role type's rules page
+type RoleRules struct {
+	Type         string   `yaml:"type"` // the card subtype, e.g. "Blue Knight"
+	Mode         string   `yaml:"mode"`
+	Summary      string   `yaml:"summary"`
+	WinCondition string   `yaml:"winCondition"`
+	Reveal       string   `yaml:"reveal"`
+	Tips         []string `yaml:"tips"`
+}
+
+// Slug is the role's path under /rules, e.g. blue-knight
+func (r RoleRules) Slug() string {
+	return RulesSlug(r.Type)
+}
+
+// RulesSlug returns the /rules path segment for a role type
+func RulesSlug(roleType string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(roleType)), " ", "-")
+}
+
+// Role returns the rules of the role type with slug
+func (r *RulesReference) Role(slug string) (RoleRules, bool) {
+	for _, role := range r.Roles {
+		if role.Slug() == slug {
+			return role, true
+		}
+	}
+	return RoleRules{}, false
+}
+
+// RolesFor returns the role types of a rules mode, in page order
+func (r *RulesReference) RolesFor(mode string) []RoleRules {
+	var roles []RoleRules
+	for _, role := range r.Roles {
+		if role.Mode == mode {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+var (
+	rulesOnce sync.Once
+	rules     *RulesReference
+	rulesErr  error
+)
+
+// Rules returns the rules reference compiled into the server, parsed once
+func Rules() (*RulesReference, error) {
+	rulesOnce.Do(func() {
+		rules, rulesErr = parseRules(rulesYAML)
+	})
+	return rules, rulesErr
+}
+
+func parseRules(data []byte) (*RulesReference, error) {
+	var ref RulesReference
+	if err := yaml.Unmarshal(data, &ref); err != nil {
+		return nil, fmt.Errorf("parse rules reference: %w", err)
+	}
+
+	modes := make(map[string]bool, len(ref.Modes))
+	for _, mode := range ref.Modes {
+		modes[mode.ID] = true
+	}
+	slugs := make(map[string]bool, len(ref.Roles))
+	for i, role := range ref.Roles {
+		switch {
+		case role.Type == "" || role.WinCondition == "":
+			return nil, fmt.Errorf("rules reference: roles.%d needs a type and a winCondition", i)
+		case !modes[role.Mode]:
+			return nil, fmt.Errorf("rules reference: %s has unknown mode %q", role.Type, role.Mode)
+		case slugs[role.Slug()]:
+			return nil, fmt.Errorf("rules reference: %s is listed twice", role.Type)
+		}
+		slugs[role.Slug()] = true
+	}
+	return &ref, nil
+}
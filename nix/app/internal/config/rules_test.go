@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRulesReferenceParses(t *testing.T) {
+	ref, err := Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range ref.Modes {
+		if len(ref.RolesFor(mode.ID)) == 0 {
+			t.Errorf("mode %s lists no roles", mode.ID)
+		}
+	}
+	if role, ok := ref.Role("wasteland-knight"); !ok || role.Type != "Wasteland Knight" {
+		t.Errorf("expected the Wasteland Knight by slug, got %+v", role)
+	}
+}
+
+func TestParseRulesRejectsBadEntries(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown mode": "modes: [{id: treachery}]\nroles: [{type: Leader, mode: coup, winCondition: win}]",
+		"duplicate":    "modes: [{id: treachery}]\nroles: [{type: Leader, mode: treachery, winCondition: a}, {type: leader, mode: treachery, winCondition: b}]",
+		"no win":       "modes: [{id: treachery}]\nroles: [{type: Leader, mode: treachery}]",
+	} {
+		if _, err := parseRules([]byte(data)); err == nil || !strings.Contains(err.Error(), "rules reference") {
+			t.Errorf("%s: expected a rules reference error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// TenantConfig scopes part of the instance to one community. Its rooms are
+// reachable only through its hosts or its /t/{id} path, it offers only its
+// presets, and it has its own rate limits and admin token. Zero limits keep
+// the server's.
+type TenantConfig struct {
+	ID    string   `yaml:"id"`    // lowercase letters, digits and dashes; used in /t/{id}, metrics and logs
+	Hosts []string `yaml:"hosts"` // hostnames served as this tenant, e.g. spikes.example.com; none enters by path only
+
+	// Role presets offered to the tenant's rooms (empty offers them all); the
+	// first is the one new rooms start with when the server's isn't offered
+	Presets []string `yaml:"presets"`
+
+	RateLimit          float64 `yaml:"rateLimit"`
+	RateLimitBurst     int     `yaml:"rateLimitBurst"`
+	RoomCreationPerIP  int     `yaml:"roomCreationPerIp"`
+	RoomCreationGlobal int     `yaml:"roomCreationGlobal"`
+}
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ForTenant returns the config governing a tenant's rooms: this one with the
+// presets the tenant doesn't offer removed and its own limits in place
+func (c *ServerConfig) ForTenant(t TenantConfig) *ServerConfig {
+	scoped := *c
+	if len(t.Presets) > 0 {
+		scoped.Roles.Presets = make(map[string]Preset, len(t.Presets))
+		for _, name := range t.Presets {
+			if preset, ok := c.Roles.Presets[name]; ok {
+				scoped.Roles.Presets[name] = preset
+			}
+		}
+	}
+	if t.RateLimit > 0 {
+		scoped.Server.RateLimit = t.RateLimit
+	}
+	if t.RateLimitBurst > 0 {
+		scoped.Server.RateLimitBurst = t.RateLimitBurst
+	}
+	if t.RoomCreationPerIP > 0 {
+		scoped.Server.RoomCreationPerIP = t.RoomCreationPerIP
+	}
+	if t.RoomCreationGlobal > 0 {
+		scoped.Server.RoomCreationGlobal = t.RoomCreationGlobal
+	}
+	return &scoped
+}
+
+func (c *ServerConfig) validateTenants(problems *ValidationError) {
+	presetNames := sortedKeys(c.Roles.Presets)
+	ids := make(map[string]bool)
+	hosts := make(map[string]string)
+
+	for i, t := range c.Tenants {
+		path := fmt.Sprintf("tenants.%d", i)
+		switch {
+		case !tenantIDPattern.MatchString(t.ID):
+			problems.add(path+".id", "must be lowercase letters, digits and dashes")
+		case ids[t.ID]:
+			problems.add(path+".id", "%s is already used by another tenant", t.ID)
+		}
+		ids[t.ID] = true
+
+		for j, host := range t.Hosts {
+			host = strings.ToLower(host)
+			if host == "" || strings.ContainsAny(host, ":/ ") {
+				problems.add(fmt.Sprintf("%s.hosts.%d", path, j), "must be a bare hostname")
+			} else if other, taken := hosts[host]; taken {
+				problems.add(fmt.Sprintf("%s.hosts.%d", path, j), "%s is already served as tenant %s", host, other)
+			}
+			hosts[host] = t.ID
+		}
+		for j, name := range t.Presets {
+			if _, ok := c.Roles.Presets[name]; !ok {
+				problems.addUnknown(fmt.Sprintf("%s.presets.%d", path, j), "preset", name, presetNames)
+			}
+		}
+
+		if t.RateLimit < 0 {
+			problems.add(path+".rateLimit", "cannot be negative")
+		}
+		if t.RateLimitBurst < 0 {
+			problems.add(path+".rateLimitBurst", "cannot be negative")
+		}
+		if t.RoomCreationPerIP < 0 {
+			problems.add(path+".roomCreationPerIp", "cannot be negative")
+		}
+		if t.RoomCreationGlobal < 0 {
+			problems.add(path+".roomCreationGlobal", "cannot be negative")
+		}
+	}
+}
+
+// TrustedProxyRanges returns TrustedProxies as address ranges, a bare address
+// as a range of one. Entries Validate rejects are left out.
+func (s ServerSettings) TrustedProxyRanges() []netip.Prefix {
+	ranges := make([]netip.Prefix, 0, len(s.TrustedProxies))
+	for _, entry := range s.TrustedProxies {
+		if prefix, err := parseTrustedProxy(entry); err == nil {
+			ranges = append(ranges, prefix)
+		}
+	}
+	return ranges
+}
+
+func parseTrustedProxy(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		return prefix.Masked(), err
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateChecksTenants(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Tenants = []TenantConfig{
+		{ID: "spikes", Hosts: []string{"spikes.example.com"}, Presets: []string{"standrd"}},
+		{ID: "Spikes Club", Hosts: []string{"SPIKES.example.com", "club.example.com:8080"}, RateLimit: -1},
+		{ID: "spikes"},
+	}
+
+	got := cfg.Validate().Error()
+	want := "6 configuration problems:" +
+		"\n  - tenants.0.presets.0: unknown preset, did you mean standard?" +
+		"\n  - tenants.1.id: must be lowercase letters, digits and dashes" +
+		"\n  - tenants.1.hosts.0: spikes.example.com is already served as tenant spikes" +
+		"\n  - tenants.1.hosts.1: must be a bare hostname" +
+		"\n  - tenants.1.rateLimit: cannot be negative" +
+		"\n  - tenants.2.id: spikes is already used by another tenant"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTrustedProxies(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.TrustedProxies = []string{"10.0.0.0/8", "::ffff:192.0.2.7", "proxy.internal"}
+
+	want := `server.trustedProxies.2: "proxy.internal" is not an IP address or CIDR range`
+	if err := cfg.Validate(); err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+	ranges := cfg.Server.TrustedProxyRanges()
+	if len(ranges) != 2 || ranges[0].String() != "10.0.0.0/8" || ranges[1].String() != "192.0.2.7/32" {
+		t.Errorf("expected the two valid entries as ranges, got %v", ranges)
+	}
+}
+
+func TestForTenantScopesPresetsAndLimits(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Roles.Presets["duel"] = cfg.Roles.Presets["standard"]
+
+	scoped := cfg.ForTenant(TenantConfig{ID: "spikes", Presets: []string{"duel"}, RateLimit: 2, RoomCreationPerIP: 3})
+	if _, ok := scoped.GetPreset("standard"); ok || len(scoped.Roles.Presets) != 1 {
+		t.Errorf("expected only the tenant's preset, got %d presets", len(scoped.Roles.Presets))
+	}
+	if scoped.Server.RateLimit != 2 || scoped.Server.RoomCreationPerIP != 3 || scoped.Server.RateLimitBurst != cfg.Server.RateLimitBurst {
+		t.Errorf("expected the tenant's limits over the server's, got %+v", scoped.Server)
+	}
+	if len(cfg.Roles.Presets) != 2 || cfg.Server.RateLimit == 2 {
+		t.Error("expected the server config left alone")
+	}
+
+	if all := cfg.ForTenant(TenantConfig{ID: "club"}); len(all.Roles.Presets) != 2 {
+		t.Errorf("expected a tenant without presets offered them all, got %d", len(all.Roles.Presets))
+	}
+}
+
+func TestLoadConfigReadsTenants(t *testing.T) {
+	t.Setenv("PORT", "8080")
+	t.Setenv("HOST", "localhost")
+	t.Setenv("CONFIG_PATH", "")
+
+	path := filepath.Join(t.TempDir(), "server.yaml")
+	yaml := "tenants:\n" +
+		"  - id: spikes\n" +
+		"    hosts: [spikes.example.com]\n" +
+		"    presets: [standard]\n" +
+		"    roomCreationPerIp: 4\n" +
+		"  - id: club\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Tenants) != 2 {
+		t.Fatalf("expected both tenants, got %+v", cfg.Tenants)
+	}
+	spikes := cfg.Tenants[0]
+	if spikes.ID != "spikes" || len(spikes.Hosts) != 1 || spikes.Presets[0] != "standard" || spikes.RoomCreationPerIP != 4 {
+		t.Errorf("expected tenant spikes as written, got %+v", spikes)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldError is a single configuration problem located by its YAML field path
+type FieldError struct {
+	Path       string // e.g. roles.presets.standard.distributions.5.assasin
+	Message    string
+	Suggestion string // closest valid value, if any
+}
+
+func (e FieldError) Error() string {
+	msg := e.Path + ": " + e.Message
+	if e.Suggestion != "" {
+		msg += ", did you mean " + e.Suggestion + "?"
+	}
+	return msg
+}
+
+// ValidationError reports every problem Validate found, not just the first
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d configuration problems:", len(e.Errors))
+	for _, fe := range e.Errors {
+		b.WriteString("\n  - ")
+		b.WriteString(fe.Error())
+	}
+	return b.String()
+}
+
+func (e *ValidationError) add(path, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// addUnknown records an unknown name, suggesting the closest candidate
+func (e *ValidationError) addUnknown(path, what, got string, candidates []string) {
+	e.Errors = append(e.Errors, FieldError{
+		Path:       path,
+		Message:    "unknown " + what,
+		Suggestion: closestMatch(got, candidates),
+	})
+}
+
+// err returns nil when nothing was recorded
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// closestMatch returns the candidate within a small edit distance of got, or "".
+// Ties go to the alphabetically first candidate so reports are stable.
+func closestMatch(got string, candidates []string) string {
+	maxDistance := len(got) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for _, candidate := range sortedCopy(candidates) {
+		d := editDistance(strings.ToLower(got), strings.ToLower(candidate))
+		if d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func sortedCopy(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
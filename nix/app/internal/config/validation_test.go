@@ -0,0 +1,341 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validBaseConfig() *ServerConfig {
+	cfg := DefaultConfig()
+	cfg.Server.Host = "localhost"
+	cfg.Server.Port = "8080"
+	return cfg
+}
+
+func TestValidateReportsFieldPathWithSuggestion(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Roles.Available["assassin"] = RoleDefinition{Category: "Assassin", MaxCount: 3}
+	cfg.Roles.Presets = map[string]Preset{
+		"standard": {Distributions: map[int]map[string]int{5: {"assasin": 1}}},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := "roles.presets.standard.distributions.5.assasin: unknown role, did you mean assassin?"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidateListsEveryProblem(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.Port = ""
+	cfg.Server.Host = ""
+	cfg.Server.RoomCodeLength = 1
+	cfg.Roles.Knowledge = []KnowledgeRule{{Viewer: "nobody", Sees: "leader"}}
+
+	err := cfg.Validate()
+	var problems *ValidationError
+	if !errors.As(err, &problems) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+
+	paths := make([]string, len(problems.Errors))
+	for i, fe := range problems.Errors {
+		paths[i] = fe.Path
+	}
+	want := []string{"server.port", "server.host", "server.roomCodeLength", "roles.knowledge.0.viewer"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "4 configuration problems:") {
+		t.Errorf("expected a count header, got %q", msg)
+	}
+	if strings.Count(msg, "\n  - ") != 4 {
+		t.Errorf("expected one line per problem, got %q", msg)
+	}
+}
+
+func TestValidateReportsPresetsInStableOrder(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Roles.Presets = map[string]Preset{
+		"zeta":  {Distributions: map[int]map[string]int{3: {"b": 1, "a": 1}}},
+		"alpha": {Distributions: map[int]map[string]int{9: {"x": 1}, 4: {"y": 1}}},
+	}
+
+	for i := 0; i < 5; i++ {
+		got := cfg.Validate().Error()
+		want := "4 configuration problems:" +
+			"\n  - roles.presets.alpha.distributions.4.y: unknown role" +
+			"\n  - roles.presets.alpha.distributions.9.x: unknown role" +
+			"\n  - roles.presets.zeta.distributions.3.a: unknown role" +
+			"\n  - roles.presets.zeta.distributions.3.b: unknown role"
+		if got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"leader", "guardian", "assassin", "traitor"}
+
+	tests := map[string]string{
+		"assasin":  "assassin",
+		"Gaurdian": "guardian",
+		"traiter":  "traitor",
+		"wizard":   "",
+		"":         "",
+	}
+	for got, want := range tests {
+		if match := closestMatch(got, candidates); match != want {
+			t.Errorf("closestMatch(%q) = %q, want %q", got, match, want)
+		}
+	}
+}
+
+func TestLoadConfigListsMissingPortAndHost(t *testing.T) {
+	t.Setenv("PORT", "")
+	t.Setenv("HOST", "")
+	t.Setenv("CONFIG_PATH", "")
+
+	path := filepath.Join(t.TempDir(), "server.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  roomCodeLength: 5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"server.port: must be set", "server.host: must be set"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestValidateRejectsUnknownSecretsProvider(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.SecretsProvider = "vualt"
+
+	err := cfg.Validate()
+	want := "server.secretsProvider: unknown secrets provider, did you mean vault?"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func TestValidateRejectsUnknownCardSet(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.CardSet = "sandbx"
+
+	err := cfg.Validate()
+	want := "server.cardSet: unknown card set, did you mean sandbox?"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func TestValidateRejectsUnknownLogSettings(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.LogLevel = "debg"
+	cfg.Server.LogFormat = "jsn"
+
+	err := cfg.Validate()
+	for _, want := range []string{
+		"server.logLevel: unknown log level, did you mean debug?",
+		"server.logFormat: unknown log format, did you mean json?",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestValidateRejectsNegativeRateLimits(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.RateLimit = -1
+	cfg.Server.ConfigRateLimitBurst = -1
+
+	err := cfg.Validate()
+	for _, want := range []string{"server.rateLimit: cannot be negative", "server.configRateLimitBurst: cannot be negative"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
+
+func TestValidateRejectsNegativeLargeRoomThreshold(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.LargeRoomThreshold = -1
+
+	err := cfg.Validate()
+	want := "server.largeRoomThreshold: cannot be negative"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func TestValidateRejectsNegativeHistoryRetention(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.HistoryRetention = -time.Hour
+
+	err := cfg.Validate()
+	want := "server.historyRetention: cannot be negative"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func TestValidateRequiresSMTPFrom(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.SMTPAddr = "smtp.example.com:587"
+	cfg.Server.InviteEmailsPerHour = -1
+
+	got := cfg.Validate().Error()
+	want := "2 configuration problems:" +
+		"\n  - server.smtpFrom: must be set when smtpAddr is (SMTP_FROM)" +
+		"\n  - server.inviteEmailsPerHour: cannot be negative"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateRejectsRoomQuotaWithoutWindow(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.RoomCreationWindow = 0
+
+	err := cfg.Validate()
+	want := "server.roomCreationWindow: must be positive when a room creation quota is set"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+
+	cfg.Server.RoomCreationPerIP = 0
+	cfg.Server.RoomCreationGlobal = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("quotas turned off need no window, got %v", err)
+	}
+}
+
+func TestValidateRejectsKeepaliveFloorAboveInterval(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.SSEKeepalive = 10 * time.Second
+	cfg.Server.SSEKeepaliveFloor = 20 * time.Second
+
+	err := cfg.Validate()
+	want := "server.sseKeepaliveFloor: cannot be longer than server.sseKeepalive"
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+func TestLoadConfigResolvesPresetExtends(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "server.yaml")
+	yamlContent := `
+roles:
+  available:
+    leader: {displayName: Leader, category: Leader, minCount: 1, maxCount: 1}
+    guardian: {displayName: Guardian, category: Guardian, maxCount: 5}
+    traitor: {displayName: Traitor, category: Traitor, maxCount: 2}
+  presets:
+    standard:
+      description: "The usual table"
+      distributions:
+        3: {leader: 1, guardian: 2}
+        4: {leader: 1, guardian: 3}
+    spicy:
+      extends: standard
+      distributions:
+        4: {leader: 1, guardian: 2, traitor: 1}
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("HOST", "localhost")
+	t.Setenv("PORT", "8080")
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	spicy, _ := cfg.GetPreset("spicy")
+	if spicy.Distributions[3]["guardian"] != 2 {
+		t.Errorf("expected the 3-player table inherited, got %v", spicy.Distributions[3])
+	}
+	if spicy.Distributions[4]["traitor"] != 1 || spicy.Distributions[4]["guardian"] != 2 {
+		t.Errorf("expected the 4-player override, got %v", spicy.Distributions[4])
+	}
+	if spicy.Description != "The usual table" {
+		t.Errorf("expected the parent's description, got %q", spicy.Description)
+	}
+	if standard, _ := cfg.GetPreset("standard"); standard.Distributions[4]["guardian"] != 3 {
+		t.Errorf("expected the parent left untouched, got %v", standard.Distributions[4])
+	}
+}
+
+func TestValidateRejectsBrokenPresetExtends(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Roles.Presets = map[string]Preset{
+		"alpha":    {Extends: "beta"},
+		"beta":     {Extends: "alpha"},
+		"orphan":   {Extends: "standrd"},
+		"standard": {Distributions: map[int]map[string]int{3: {"leader": 1}}},
+	}
+
+	got := cfg.Validate().Error()
+	want := "2 configuration problems:" +
+		"\n  - roles.presets.alpha.extends: inheritance cycle alpha -> beta -> alpha" +
+		"\n  - roles.presets.orphan.extends: unknown preset, did you mean standard?"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateCardsNamesRolesNoCardCanFill(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Roles.Available["guardian"] = RoleDefinition{Category: "Guardain", MaxCount: 3}
+	dealable := map[string]int{"Leader": 2, "Guardian": 3, "Assassin": 2, "Traitor": 2, "Jester": 0}
+
+	err := cfg.ValidateCards(dealable, nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 {
+		t.Fatalf("expected one problem, got %v", err)
+	}
+	want := `roles.available.guardian.category: no card in the card set has subtype "Guardain" (the cards have Assassin, Guardian, Leader, Traitor), did you mean Guardian?`
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+
+	cfg.Roles.Available["guardian"] = RoleDefinition{Category: "Guardian", MaxCount: 3}
+	if err := cfg.ValidateCards(dealable, nil); err != nil {
+		t.Errorf("expected the default roles to fit, got %v", err)
+	}
+}
+
+func TestValidateCardsNamesUnknownCardPacks(t *testing.T) {
+	cfg := validBaseConfig()
+	cfg.Server.CardPacks = []string{"base", "comunity"}
+	dealable := map[string]int{"Leader": 2, "Guardian": 3, "Assassin": 2, "Traitor": 2}
+
+	err := cfg.ValidateCards(dealable, []string{"base", "community"})
+	want := `server.cardPacks.1: no card pack "comunity" (the cards have base, community), did you mean community?`
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+
+	cfg.Server.CardPacks = []string{"community"}
+	if err := cfg.ValidateCards(dealable, []string{"base", "community"}); err != nil {
+		t.Errorf("expected a known pack to pass, got %v", err)
+	}
+}
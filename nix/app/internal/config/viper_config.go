@@ -3,13 +3,14 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/spf13/viper"
 )
 
 // LoadConfig loads configuration using Viper
-// Priority order: Environment variables > Config file > Defaults
+// Priority order: Environment variables > Config file > Defaults (see EnvBindings)
 func LoadConfig(configPath string) (*ServerConfig, error) {
 	v := viper.New()
 
@@ -29,23 +30,13 @@ func LoadConfig(configPath string) (*ServerConfig, error) {
 		v.SetConfigFile("/app/server.yaml")
 	}
 
-	// Enable environment variable binding
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
-	// Bind specific environment variables
-	// These allow both TREACHEREST_SERVER_PORT and PORT to work
-	v.BindEnv("server.port", "PORT")
-	v.BindEnv("server.host", "HOST")
-	v.BindEnv("server.loglevel", "LOG_LEVEL")
-	v.BindEnv("server.logformat", "LOG_FORMAT")
-	v.BindEnv("server.ratelimit", "RATE_LIMIT")
-	v.BindEnv("server.ratelimitburst", "RATE_LIMIT_BURST")
-	v.BindEnv("server.maxrequestsize", "MAX_REQUEST_SIZE")
-	v.BindEnv("server.maxsseconnections", "MAX_SSE_CONNECTIONS")
-	v.BindEnv("server.shutdowntimeout", "SHUTDOWN_TIMEOUT")
-	v.BindEnv("server.enablemetrics", "ENABLE_METRICS")
-	v.BindEnv("server.metricsport", "METRICS_PORT")
+	// Bind every server setting to the environment (see EnvBindings for precedence)
+	for _, b := range EnvBindings() {
+		args := append([]string{b.Key}, b.EnvVars...)
+		if err := v.BindEnv(args...); err != nil {
+			return nil, fmt.Errorf("bind env for %s: %w", b.Key, err)
+		}
+	}
 
 	// Set defaults for safe settings
 	v.SetDefault("server.maxplayersperroom", 20)
@@ -53,20 +44,32 @@ func LoadConfig(configPath string) (*ServerConfig, error) {
 	v.SetDefault("server.defaultgamesize", 5)
 	v.SetDefault("server.roomcodelength", 5)
 	v.SetDefault("server.roomtimeout", "24h")
+	v.SetDefault("server.largeroomthreshold", 12)
 
 	// Timeout defaults
 	v.SetDefault("server.readtimeout", "30s")
 	v.SetDefault("server.writetimeout", "10m") // 10 minutes for SSE support
 	v.SetDefault("server.idletimeout", "0s")   // 0 for SSE support
 	v.SetDefault("server.shutdowntimeout", "0s")
+	v.SetDefault("server.draintimeout", "10s")
 
 	// Request timeout for middleware (separate from server timeouts)
 	v.SetDefault("server.requesttimeout", "60s") // Default 60s for regular requests
 	v.SetDefault("server.ssetimeout", "24h")     // 24 hours for SSE connections (or 0 to disable)
+	v.SetDefault("server.ssekeepalive", "15s")
+	v.SetDefault("server.ssekeepalivefloor", "5s")
+	v.SetDefault("server.ssewritetimeout", "10s")
 
 	// Rate limiting defaults
 	v.SetDefault("server.ratelimit", 10.0)
 	v.SetDefault("server.ratelimitburst", 20)
+	v.SetDefault("server.roomcreationperip", 20)
+	v.SetDefault("server.roomcreationglobal", 1000)
+	v.SetDefault("server.roomcreationwindow", "1h")
+	v.SetDefault("server.botchecks", true)
+	v.SetDefault("server.botminsubmittime", "1s")
+	v.SetDefault("server.overlayframeancestors", "'none'")
+	v.SetDefault("server.hstsmaxage", "4320h")
 
 	// Request limits
 	v.SetDefault("server.maxrequestsize", 10485760) // 10MB
@@ -76,6 +79,18 @@ func LoadConfig(configPath string) (*ServerConfig, error) {
 	v.SetDefault("server.enablemetrics", false)
 	v.SetDefault("server.loglevel", "info")
 	v.SetDefault("server.logformat", "text")
+	v.SetDefault("server.privacylogging", false)
+	v.SetDefault("server.historyretention", "0s")
+
+	// Room store defaults
+	v.SetDefault("server.storedsn", "memory://")
+
+	// Email invite defaults
+	v.SetDefault("server.inviteemailsperhour", 20)
+
+	// Card data defaults
+	v.SetDefault("server.cardset", "embedded")
+	v.SetDefault("server.sandboxcardspertype", 5)
 
 	// Try to read config file (it's optional)
 	if err := v.ReadInConfig(); err != nil {
@@ -101,20 +116,12 @@ func LoadConfig(configPath string) (*ServerConfig, error) {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
 
-	// Validate required fields
-	if v.GetString("server.port") == "" {
-		return nil, fmt.Errorf("PORT environment variable must be set")
-	}
-	if v.GetString("server.host") == "" {
-		return nil, fmt.Errorf("HOST environment variable must be set")
-	}
-
 	// Load default roles if not in config file
 	if len(cfg.Roles.Available) == 0 {
 		cfg.Roles = DefaultConfig().Roles
 	}
 
-	// Additional validation
+	// Validate everything at once so startup lists all problems, including missing PORT/HOST
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -145,3 +152,42 @@ func InitViper() *viper.Viper {
 
 	return v
 }
+
+// EnvPrefix namespaces the long-form environment variable names
+const EnvPrefix = "TREACHEREST"
+
+// EnvBinding maps a config key to the environment variables that can set it
+type EnvBinding struct {
+	Key     string   // viper key, e.g. server.readtimeout
+	EnvVars []string // checked in order; the first non-empty one wins
+}
+
+// EnvBindings derives an environment binding for every scalar ServerSettings
+// field from its envconfig tag. Precedence, highest first:
+//
+//  1. the short name from the envconfig tag, e.g. READ_TIMEOUT
+//  2. the prefixed name, e.g. TREACHEREST_SERVER_READ_TIMEOUT
+//  3. the legacy automatic name, e.g. SERVER_READTIMEOUT
+//  4. the config file
+//  5. the defaults set in LoadConfig
+func EnvBindings() []EnvBinding {
+	settings := reflect.TypeOf(ServerSettings{})
+	bindings := make([]EnvBinding, 0, settings.NumField())
+	for i := 0; i < settings.NumField(); i++ {
+		field := settings.Field(i)
+		name := field.Tag.Get("envconfig")
+		if name == "" {
+			continue
+		}
+		key := "server." + strings.ToLower(field.Name)
+		bindings = append(bindings, EnvBinding{
+			Key: key,
+			EnvVars: []string{
+				name,
+				EnvPrefix + "_SERVER_" + name,
+				strings.ToUpper(strings.ReplaceAll(key, ".", "_")),
+			},
+		})
+	}
+	return bindings
+}
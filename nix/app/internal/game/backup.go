@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"treacherest/internal/clock"
 )
 
 // Backup-related errors
@@ -42,6 +44,7 @@ type StateBackup struct {
 type BackupService struct {
 	encryptionKey     []byte
 	encryptionEnabled bool
+	clock             clock.Clock
 }
 
 // NewBackupService creates a new backup service
@@ -69,9 +72,15 @@ func NewBackupService(hexKey string, enabled bool) (*BackupService, error) {
 	return &BackupService{
 		encryptionKey:     key,
 		encryptionEnabled: enabled,
+		clock:             clock.Real(),
 	}, nil
 }
 
+// SetClock replaces the clock that stamps backups and ages them on restore
+func (s *BackupService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
 // CreateBackup serializes and optionally encrypts room state
 func (s *BackupService) CreateBackup(room *Room) (string, error) {
 	if room == nil {
@@ -80,7 +89,7 @@ func (s *BackupService) CreateBackup(room *Room) (string, error) {
 
 	backup := StateBackup{
 		Version:   BackupVersion,
-		Timestamp: time.Now(),
+		Timestamp: s.clock.Now(),
 		RoomCode:  room.Code,
 		Room:      room,
 	}
@@ -138,7 +147,7 @@ func (s *BackupService) RestoreBackup(data string, expectedRoomCode string) (*Ro
 	}
 
 	// Validate timestamp - reject if too old
-	if time.Since(backup.Timestamp) > BackupMaxAge {
+	if s.clock.Since(backup.Timestamp) > BackupMaxAge {
 		return nil, ErrBackupExpired
 	}
 
@@ -2,8 +2,11 @@ package game
 
 import (
 	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
+
+	"treacherest/internal/clock"
 )
 
 // Generate a valid 32-byte test key (64 hex chars)
@@ -267,16 +270,28 @@ func TestBackupService_RestoreBackup(t *testing.T) {
 }
 
 func TestBackupService_ExpiredBackup(t *testing.T) {
-	// This test verifies that backups older than BackupMaxAge are rejected
-	// We can't easily test this without modifying the backup timestamp,
-	// so we test the validation logic indirectly
-
-	t.Run("rejects backup older than max age", func(t *testing.T) {
-		// Skip if we can't easily modify the timestamp
-		// The actual validation happens during RestoreBackup
-		// and checks time.Since(backup.Timestamp) > BackupMaxAge
-		t.Skip("timestamp validation tested implicitly through integration tests")
-	})
+	service, err := NewBackupService(testEncryptionKey(), true)
+	if err != nil {
+		t.Fatalf("NewBackupService failed: %v", err)
+	}
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	service.SetClock(fake)
+
+	room := &Room{Code: "TEST1", State: StatePlaying, Players: make(map[string]*Player)}
+	backup, err := service.CreateBackup(room)
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	fake.Advance(BackupMaxAge)
+	if _, err := service.RestoreBackup(backup, room.Code); err != nil {
+		t.Errorf("expected a backup exactly BackupMaxAge old to restore, got %v", err)
+	}
+
+	fake.Advance(time.Second)
+	if _, err := service.RestoreBackup(backup, room.Code); !errors.Is(err, ErrBackupExpired) {
+		t.Errorf("expected ErrBackupExpired, got %v", err)
+	}
 }
 
 func TestEncryptDecryptRoundtrip(t *testing.T) {
@@ -30,62 +30,44 @@ type CardTypes struct {
 
 // Card represents a MTG Treachery card
 type Card struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	NameAnchor  string    `json:"name_anchor"`
-	URI         string    `json:"uri"`
-	Cost        string    `json:"cost"`
-	CMC         int       `json:"cmc"`
-	Color       string    `json:"color"`
-	Type        string    `json:"type"`
-	Types       CardTypes `json:"types"`
-	Rarity      string    `json:"rarity"`
-	Text        string    `json:"text"`
-	Flavor      string    `json:"flavor"`
-	Artist      string    `json:"artist"`
-	Rulings     []string  `json:"rulings"`
-	ImagePath   string    `json:"-"` // Local image path, not from JSON
-	Base64Image string    `json:"-"` // Base64-encoded image data URI
+	ID          int                 `json:"id"`
+	Name        string              `json:"name"`
+	NameAnchor  string              `json:"name_anchor"`
+	URI         string              `json:"uri"`
+	Cost        string              `json:"cost"`
+	CMC         int                 `json:"cmc"`
+	Color       string              `json:"color"`
+	Type        string              `json:"type"`
+	Types       CardTypes           `json:"types"`
+	Rarity      string              `json:"rarity"`
+	Text        string              `json:"text"`
+	Flavor      string              `json:"flavor"`
+	Artist      string              `json:"artist"`
+	Rulings     []string            `json:"rulings"`
+	Pack        string              `json:"pack,omitempty"`      // pack ID; empty means the collection's first pack
+	Localized   map[string]CardText `json:"localized,omitempty"` // by locale, see In
+	PackName    string              `json:"-"`                   // pack display name, set when the card is loaded
+	ImagePath   string              `json:"-"`                   // Local image path, not from JSON
+	Base64Image string              `json:"-"`                   // Base64-encoded image data URI
 }
 
 // CardCollection represents the full JSON structure
 type CardCollection struct {
-	GameVariant string  `json:"game_variant"`
-	APIAuthor   string  `json:"api_author"`
-	APIVersion  float64 `json:"api_version"`
-	SetName     string  `json:"set_name"`
-	SetCode     string  `json:"set_code"`
-	SetLang     string  `json:"set_lang"`
-	CardsCount  int     `json:"cards_count"`
-	Cards       []Card  `json:"cards"`
-}
-
-// GetRoleType returns the RoleType equivalent for a card's subtype
+	GameVariant string     `json:"game_variant"`
+	APIAuthor   string     `json:"api_author"`
+	APIVersion  float64    `json:"api_version"`
+	SetName     string     `json:"set_name"`
+	SetCode     string     `json:"set_code"`
+	SetLang     string     `json:"set_lang"`
+	CardsCount  int        `json:"cards_count"`
+	Packs       []CardPack `json:"packs,omitempty"` // in display order; empty puts every card in one base pack
+	Cards       []Card     `json:"cards"`
+}
+
+// GetRoleType returns the RoleType equivalent for a card's subtype. Every
+// subtype is a role type, so a card set can bring types config then deals.
 func (c *Card) GetRoleType() RoleType {
-	switch c.Types.Subtype {
-	case "Leader":
-		return RoleLeader
-	case "Guardian":
-		return RoleGuardian
-	case "Assassin":
-		return RoleAssassin
-	case "Traitor":
-		return RoleTraitor
-	case "King":
-		return RoleKing
-	case "Blue Knight":
-		return RoleBlueKnight
-	case "Black Knight":
-		return RoleBlackKnight
-	case "Red Knight":
-		return RoleRedKnight
-	case "Green Knight":
-		return RoleGreenKnight
-	case "Wasteland Knight":
-		return RoleWasteland
-	default:
-		return ""
-	}
+	return RoleType(c.Types.Subtype)
 }
 
 // GetWinCondition returns a simplified win condition based on card type
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"sort"
+
+	"treacherest/internal/config"
+)
+
+// CardCompatibility reports how the configured roles and the loaded cards
+// line up, for the startup check and /health/ready?details=1
+type CardCompatibility struct {
+	Roles    []RoleCards    `json:"roles"`
+	Unused   map[string]int `json:"unusedSubtypes,omitempty"` // card subtypes no role deals, with their card counts
+	Problems []string       `json:"problems,omitempty"`
+}
+
+// RoleCards is one configured role and how many cards can fill it
+type RoleCards struct {
+	Role     string `json:"role"`
+	Category string `json:"category"`
+	Cards    int    `json:"cards"`
+}
+
+// DealableSubtypes counts the cards roles can be dealt from, by subtype
+func (cs *CardService) DealableSubtypes() map[string]int {
+	dealable := make(map[string]int, len(standardRoleTypes))
+	for _, roleType := range standardRoleTypes {
+		dealable[string(roleType)] = 0
+	}
+	for _, roleType := range cs.RoleTypes() {
+		dealable[string(roleType)] = len(cs.CardsOfType(roleType))
+	}
+	return dealable
+}
+
+// CheckCardCompatibility compares cfg's roles with the cards in cs. The
+// error, when there is one, is a *config.ValidationError naming every role
+// no card can fill and every default pack the cards don't have; cards no
+// role deals are only reported as unused.
+func CheckCardCompatibility(cfg *config.ServerConfig, cs *CardService) (CardCompatibility, error) {
+	dealable := cs.DealableSubtypes()
+	report := CardCompatibility{Roles: make([]RoleCards, 0, len(cfg.Roles.Available))}
+
+	categories := make(map[string]bool)
+	for name, role := range cfg.Roles.Available {
+		categories[role.Category] = true
+		report.Roles = append(report.Roles, RoleCards{Role: name, Category: role.Category, Cards: dealable[role.Category]})
+	}
+	sort.Slice(report.Roles, func(i, j int) bool { return report.Roles[i].Role < report.Roles[j].Role })
+
+	for _, card := range cs.GetAllCards() {
+		subtype := card.Types.Subtype
+		if categories[subtype] {
+			continue
+		}
+		if report.Unused == nil {
+			report.Unused = make(map[string]int)
+		}
+		report.Unused[subtype]++
+	}
+
+	err := cfg.ValidateCards(dealable, cs.PackIDs())
+	if verr, ok := err.(*config.ValidationError); ok {
+		for _, fe := range verr.Errors {
+			report.Problems = append(report.Problems, fe.Error())
+		}
+	}
+	return report, err
+}
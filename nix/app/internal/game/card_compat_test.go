@@ -0,0 +1,35 @@
+package game
+
+import (
+	"testing"
+
+	"treacherest/internal/config"
+)
+
+func TestCheckCardCompatibility(t *testing.T) {
+	cs := NewSandboxCardService(2)
+	cfg := config.DefaultConfig()
+
+	report, err := CheckCardCompatibility(cfg, cs)
+	if err != nil {
+		t.Fatalf("expected the default roles to fit the sandbox cards, got %v", err)
+	}
+	if len(report.Roles) != len(cfg.Roles.Available) || len(report.Problems) != 0 || len(report.Unused) != 0 {
+		t.Fatalf("expected every role filled and no card unused, got %+v", report)
+	}
+	for _, role := range report.Roles {
+		if role.Cards != 2 {
+			t.Errorf("expected 2 cards for %s, got %d", role.Role, role.Cards)
+		}
+	}
+
+	delete(cfg.Roles.Available, "traitor")
+	cfg.Roles.Available["jester"] = config.RoleDefinition{Category: "Jester", MaxCount: 1}
+	report, err = CheckCardCompatibility(cfg, cs)
+	if err == nil {
+		t.Fatal("expected a role without cards to be refused")
+	}
+	if len(report.Problems) != 1 || report.Unused["Traitor"] != 2 {
+		t.Errorf("expected the jester problem and 2 unused Traitor cards, got %+v", report)
+	}
+}
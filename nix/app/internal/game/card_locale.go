@@ -0,0 +1,163 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCardLocale is the locale card data is written in; every other
+// locale falls back to it for text it doesn't translate
+const DefaultCardLocale = "en"
+
+var ErrUnknownCardLocale = errors.New("no card text for that language")
+
+var cardLocalePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// CardText is a card's player-facing text in one locale. Empty fields fall
+// back to the English card.
+type CardText struct {
+	Name    string   `json:"name,omitempty"`
+	Text    string   `json:"text,omitempty"`
+	Flavor  string   `json:"flavor,omitempty"`
+	Rulings []string `json:"rulings,omitempty"`
+}
+
+// NormalizeCardLocale lowercases a locale tag such as "pt-BR" and reports
+// whether it is well formed
+func NormalizeCardLocale(locale string) (string, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	return locale, cardLocalePattern.MatchString(locale)
+}
+
+// In returns the card with its text in locale, falling back from a regional
+// locale to its language and from there to English. Names used as keys, such
+// as in a role config's enabled cards, stay on the English card.
+func (c *Card) In(locale string) *Card {
+	text, ok := c.Localized[locale]
+	if !ok {
+		if base, _, regional := strings.Cut(locale, "-"); regional {
+			text, ok = c.Localized[base]
+		}
+	}
+	if !ok {
+		return c
+	}
+
+	localized := *c
+	if text.Name != "" {
+		localized.Name = text.Name
+	}
+	if text.Text != "" {
+		localized.Text = text.Text
+	}
+	if text.Flavor != "" {
+		localized.Flavor = text.Flavor
+	}
+	if len(text.Rulings) > 0 {
+		localized.Rulings = text.Rulings
+	}
+	return &localized
+}
+
+// AddTranslations overlays card text in locale, from JSON mapping card IDs to
+// CardText, on the loaded cards. Entries for unknown cards are an error, so a
+// translation made for another card set isn't loaded by mistake.
+func (cs *CardService) AddTranslations(locale string, data []byte) error {
+	locale, ok := NormalizeCardLocale(locale)
+	if !ok || locale == DefaultCardLocale {
+		return fmt.Errorf("%w: %q", ErrUnknownCardLocale, locale)
+	}
+	var texts map[string]CardText
+	if err := json.Unmarshal(data, &texts); err != nil {
+		return fmt.Errorf("failed to parse %s card text: %w", locale, err)
+	}
+
+	byID := make(map[int]*Card, len(cs.allCards))
+	for i := range cs.allCards {
+		byID[cs.allCards[i].ID] = &cs.allCards[i]
+	}
+	for key, text := range texts {
+		id, err := strconv.Atoi(key)
+		card := byID[id]
+		if err != nil || card == nil {
+			return fmt.Errorf("%s card text for unknown card %q", locale, key)
+		}
+		if card.Localized == nil {
+			card.Localized = make(map[string]CardText)
+		}
+		card.Localized[locale] = text
+	}
+	return nil
+}
+
+// Locales returns the locales the cards have text in, English first
+func (cs *CardService) Locales() []string {
+	seen := make(map[string]bool)
+	for i := range cs.allCards {
+		for locale := range cs.allCards[i].Localized {
+			seen[locale] = true
+		}
+	}
+	delete(seen, DefaultCardLocale)
+	locales := make([]string, 0, len(seen))
+	for locale := range seen {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return append([]string{DefaultCardLocale}, locales...)
+}
+
+// HasLocale reports whether any card has text in locale; English always does
+func (cs *CardService) HasLocale(locale string) bool {
+	for _, known := range cs.Locales() {
+		if known == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCardByID returns the card with id, or nil
+func (cs *CardService) GetCardByID(id int) *Card {
+	for i := range cs.allCards {
+		if cs.allCards[i].ID == id {
+			return &cs.allCards[i]
+		}
+	}
+	return nil
+}
+
+// cardLocaleNames are the names languages go by in their own tongue, for
+// the card language picker
+var cardLocaleNames = map[string]string{
+	"de": "Deutsch",
+	"en": "English",
+	"es": "Español",
+	"fr": "Français",
+	"it": "Italiano",
+	"ja": "日本語",
+	"nl": "Nederlands",
+	"pl": "Polski",
+	"pt": "Português",
+	"ru": "Русский",
+	"zh": "中文",
+}
+
+// CardLocaleLabel names locale for the card language picker, e.g. "Deutsch"
+// or "Português (BR)", falling back to the tag itself
+func CardLocaleLabel(locale string) string {
+	base, region, regional := strings.Cut(locale, "-")
+	name, ok := cardLocaleNames[base]
+	if !ok {
+		return locale
+	}
+	if regional {
+		return name + " (" + strings.ToUpper(region) + ")"
+	}
+	return name
+}
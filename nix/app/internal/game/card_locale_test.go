@@ -0,0 +1,47 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCardTranslationsFallBackToEnglish(t *testing.T) {
+	cs, err := NewCardService([]byte(packedCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.AddTranslations("de", []byte(`{"1": {"name": "Der König", "text": "Du bist der König."}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	king := cs.Leaders[0]
+	if german := king.In("de-AT"); german.Name != "Der König" || german.Text != "Du bist der König." || german.ID != king.ID {
+		t.Errorf("expected a regional locale to fall back to German, got %q", german.Name)
+	}
+	if king.Name != "The King" || king.In("fr") != king {
+		t.Error("expected the English card unchanged and untranslated locales to show it")
+	}
+	if bodyguard := cs.GetCardByID(2); bodyguard.In("de").Name != "The Bodyguard" {
+		t.Error("expected an untranslated card to keep its English name")
+	}
+
+	if locales := cs.Locales(); len(locales) != 2 || locales[0] != DefaultCardLocale || locales[1] != "de" {
+		t.Errorf("expected English then German, got %v", locales)
+	}
+	if !cs.HasLocale("de") || cs.HasLocale("fr") {
+		t.Error("expected only the translated locale reported")
+	}
+}
+
+func TestAddTranslationsRejectsOtherCardSets(t *testing.T) {
+	cs, err := NewCardService([]byte(packedCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.AddTranslations("de", []byte(`{"99": {"name": "Der Fremde"}}`)); err == nil {
+		t.Error("expected text for an unknown card to be refused")
+	}
+	if err := cs.AddTranslations("en", []byte(`{}`)); !errors.Is(err, ErrUnknownCardLocale) {
+		t.Errorf("expected English overlays to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package game
+
+// BasePackID is the pack cards belong to when the card data lists no packs
+const BasePackID = "base"
+
+// CardPack is a named group of cards in the card data, e.g. the base set or
+// a community pack. Hosts enable and disable a pack's cards together.
+type CardPack struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Cards int    `json:"-"` // counted when the cards are loaded
+}
+
+// indexPacks files every card under its pack. Cards without one join the
+// first listed pack, or a base pack named after the set when none are
+// listed; a pack a card names but the list doesn't is added under its ID.
+func (cs *CardService) indexPacks(listed []CardPack, setName string) {
+	cs.packs = make([]CardPack, 0, len(listed)+1)
+	cs.packCards = make(map[string][]*Card)
+	positions := make(map[string]int)
+	addPack := func(pack CardPack) {
+		if _, ok := positions[pack.ID]; ok {
+			return
+		}
+		if pack.Name == "" {
+			pack.Name = pack.ID
+		}
+		positions[pack.ID] = len(cs.packs)
+		cs.packs = append(cs.packs, CardPack{ID: pack.ID, Name: pack.Name})
+	}
+
+	for _, pack := range listed {
+		if pack.ID != "" {
+			addPack(pack)
+		}
+	}
+	defaultPack := BasePackID
+	if len(cs.packs) > 0 {
+		defaultPack = cs.packs[0].ID
+	}
+
+	for i := range cs.allCards {
+		card := &cs.allCards[i]
+		if card.Pack == "" {
+			card.Pack = defaultPack
+		}
+		if card.Pack == BasePackID && len(listed) == 0 {
+			addPack(CardPack{ID: BasePackID, Name: setName})
+		} else {
+			addPack(CardPack{ID: card.Pack})
+		}
+		pack := &cs.packs[positions[card.Pack]]
+		pack.Cards++
+		card.PackName = pack.Name
+		cs.packCards[card.Pack] = append(cs.packCards[card.Pack], card)
+	}
+}
+
+// Packs lists the card packs in display order, with their card counts
+func (cs *CardService) Packs() []CardPack {
+	packs := make([]CardPack, len(cs.packs))
+	copy(packs, cs.packs)
+	return packs
+}
+
+// PackIDs lists the pack IDs in display order
+func (cs *CardService) PackIDs() []string {
+	ids := make([]string, len(cs.packs))
+	for i, pack := range cs.packs {
+		ids[i] = pack.ID
+	}
+	return ids
+}
+
+// HasPack reports whether the card data has a pack with this ID
+func (cs *CardService) HasPack(id string) bool {
+	_, ok := cs.packCards[id]
+	return ok
+}
+
+// CardsInPack returns the cards of one pack
+func (cs *CardService) CardsInPack(id string) []*Card {
+	return cs.packCards[id]
+}
+
+// InPacks reports whether card belongs to one of packs; no packs means all
+func (card *Card) InPacks(packs []string) bool {
+	if len(packs) == 0 {
+		return true
+	}
+	for _, pack := range packs {
+		if card.Pack == pack {
+			return true
+		}
+	}
+	return false
+}
+
+// PackCardsEnabled counts how many of a pack's cards c deals, out of the
+// pack's cards of the role types c configures
+func (c *RoleConfiguration) PackCardsEnabled(cs *CardService, packID string) (enabled, total int) {
+	for _, card := range cs.CardsInPack(packID) {
+		typeConfig := c.RoleTypes[string(card.GetRoleType())]
+		if typeConfig == nil {
+			continue
+		}
+		total++
+		if typeConfig.EnabledCards[card.Name] {
+			enabled++
+		}
+	}
+	return enabled, total
+}
+
+// SetPackEnabled enables or disables every card of a pack in the role types
+// c configures
+func (c *RoleConfiguration) SetPackEnabled(cs *CardService, packID string, enabled bool) {
+	for _, card := range cs.CardsInPack(packID) {
+		typeConfig := c.RoleTypes[string(card.GetRoleType())]
+		if typeConfig == nil {
+			continue
+		}
+		if typeConfig.EnabledCards == nil {
+			typeConfig.EnabledCards = make(map[string]bool)
+		}
+		typeConfig.EnabledCards[card.Name] = enabled
+	}
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"treacherest/internal/config"
+)
+
+const packedCardsJSON = `{
+	"set_name": "Treachery",
+	"packs": [{"id": "base", "name": "Base Set"}, {"id": "community", "name": "Community Pack"}],
+	"cards": [
+		{"id": 1, "name": "The King", "types": {"supertype": "Identity", "subtype": "Leader"}},
+		{"id": 2, "name": "The Bodyguard", "types": {"supertype": "Identity", "subtype": "Guardian"}},
+		{"id": 3, "name": "The Usurper", "pack": "community", "types": {"supertype": "Identity", "subtype": "Leader"}},
+		{"id": 4, "name": "The Lookout", "pack": "community", "types": {"supertype": "Identity", "subtype": "Guardian"}}
+	]
+}`
+
+func TestCardServiceIndexesPacks(t *testing.T) {
+	cs, err := NewCardService([]byte(packedCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packs := cs.Packs()
+	if len(packs) != 2 || packs[0].ID != "base" || packs[0].Cards != 2 || packs[1].Name != "Community Pack" || packs[1].Cards != 2 {
+		t.Fatalf("expected the base and community packs with 2 cards each, got %+v", packs)
+	}
+	if king := cs.Leaders[0]; king.Pack != "base" || king.PackName != "Base Set" {
+		t.Errorf("expected a card without a pack to join the first pack, got %q (%q)", king.Pack, king.PackName)
+	}
+	if len(cs.CardsInPack("community")) != 2 || cs.HasPack("expansion") {
+		t.Error("expected the community pack indexed and no other")
+	}
+
+	single := NewSandboxCardService(2)
+	if packs := single.Packs(); len(packs) != 1 || packs[0].ID != BasePackID || packs[0].Name != "Sandbox" {
+		t.Errorf("expected one base pack named after the set, got %+v", packs)
+	}
+}
+
+func TestDefaultPacksAndPackToggle(t *testing.T) {
+	cs, err := NewCardService([]byte(packedCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Server.CardPacks = []string{"base"}
+	service := NewRoleConfigService(cfg)
+	service.SetCardService(cs)
+
+	roleConfig := service.CreateDefaultConfiguration()
+	if enabled, total := roleConfig.PackCardsEnabled(cs, "community"); enabled != 0 || total != 2 {
+		t.Errorf("expected the community pack off by default, got %d of %d", enabled, total)
+	}
+	if enabled, total := roleConfig.PackCardsEnabled(cs, "base"); enabled != 2 || total != 2 {
+		t.Errorf("expected the base pack on by default, got %d of %d", enabled, total)
+	}
+
+	roleConfig.SetPackEnabled(cs, "community", true)
+	if !roleConfig.RoleTypes["Leader"].EnabledCards["The Usurper"] || !roleConfig.RoleTypes["Guardian"].EnabledCards["The Lookout"] {
+		t.Error("expected enabling the pack to enable each of its cards")
+	}
+	roleConfig.SetPackEnabled(cs, "base", false)
+	if enabled, _ := roleConfig.PackCardsEnabled(cs, "base"); enabled != 0 {
+		t.Errorf("expected the base pack off, got %d enabled", enabled)
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Search scores, highest first. A query term scores by its best match.
+const (
+	searchScoreExactName   = 100
+	searchScoreNamePrefix  = 80
+	searchScoreNameWord    = 70
+	searchScoreNameContain = 60
+	searchScoreNameFuzzy   = 40
+	searchScoreText        = 20
+)
+
+// CardSearchResult is a card matching a search query
+type CardSearchResult struct {
+	Card  *Card
+	Score int
+}
+
+// cardSearchEntry holds a card's normalized name and rules text
+type cardSearchEntry struct {
+	card *Card
+	name string
+	text string
+}
+
+// buildSearchIndex precomputes the normalized name and text of every role card
+func (cs *CardService) buildSearchIndex() {
+	cs.searchIndex = make([]cardSearchEntry, 0)
+	for _, roleType := range cs.RoleTypes() {
+		for _, card := range cs.CardsOfType(roleType) {
+			cs.searchIndex = append(cs.searchIndex, cardSearchEntry{
+				card: card,
+				name: normalizeSearchText(card.Name),
+				text: normalizeSearchText(card.Text),
+			})
+		}
+	}
+}
+
+// Search returns up to limit cards matching query, best first. Every query
+// word must match the card's name or rules text; names match loosely, so
+// "augr" finds The Augur. A limit of 0 or less returns every match.
+func (cs *CardService) Search(query string, limit int) []CardSearchResult {
+	terms := strings.Fields(normalizeSearchText(query))
+	results := make([]CardSearchResult, 0)
+	if len(terms) == 0 {
+		return results
+	}
+
+	cs.searchOnce.Do(func() {
+		if cs.searchIndex == nil {
+			cs.buildSearchIndex()
+		}
+	})
+	phrase := strings.Join(terms, " ")
+	for _, entry := range cs.searchIndex {
+		score := 0
+		if entry.name == phrase {
+			score = searchScoreExactName * len(terms)
+		} else {
+			for _, term := range terms {
+				termScore := entry.score(term)
+				if termScore == 0 {
+					score = 0
+					break
+				}
+				score += termScore
+			}
+		}
+		if score > 0 {
+			results = append(results, CardSearchResult{Card: entry.card, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Card.Name < results[j].Card.Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// score rates how well one normalized query term matches the card
+func (e cardSearchEntry) score(term string) int {
+	switch {
+	case strings.HasPrefix(e.name, term) || strings.HasPrefix(strings.TrimPrefix(e.name, "the "), term):
+		return searchScoreNamePrefix
+	case strings.HasPrefix(e.name, term+" ") || strings.Contains(e.name, " "+term):
+		return searchScoreNameWord
+	case strings.Contains(e.name, term):
+		return searchScoreNameContain
+	case isSubsequence(term, e.name):
+		return searchScoreNameFuzzy
+	case strings.Contains(e.text, term):
+		return searchScoreText
+	}
+	return 0
+}
+
+// isSubsequence reports whether every rune of term appears in s in order
+func isSubsequence(term, s string) bool {
+	remaining := []rune(term)
+	for _, r := range s {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
+// normalizeSearchText lowercases s, folds ligatures the card names use and
+// turns punctuation into spaces
+func normalizeSearchText(s string) string {
+	s = strings.NewReplacer("Æ", "ae", "æ", "ae", "’", "'").Replace(s)
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+		} else if !space && r != '\'' {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
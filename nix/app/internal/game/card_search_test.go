@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+func searchNames(results []CardSearchResult) []string {
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.Card.Name
+	}
+	return names
+}
+
+func TestCardService_Search(t *testing.T) {
+	service := createMockCardService()
+	service.Traitors[0].Text = "When a player is eliminated, you may unveil."
+	service.Guardians[0].Name = "The Ætherist"
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"The Spy", []string{"The Spy"}},
+		{"spy", []string{"The Spy"}},
+		{"SHAD", []string{"The Shadow"}},
+		{"prtctr", []string{"The Protector"}},
+		{"aetherist", []string{"The Ætherist"}},
+		{"eliminated", []string{"The Cultist"}},
+		{"cultist unveil", []string{"The Cultist"}},
+		{"spy unveil", []string{}},
+		{"  ", []string{}},
+		{"zzz", []string{}},
+	}
+	for _, tt := range tests {
+		got := searchNames(service.Search(tt.query, 0))
+		if len(got) != len(tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestCardService_SearchRanksNamesAboveText(t *testing.T) {
+	service := createMockCardService()
+	service.Leaders[0].Text = "Protect the knight at all costs."
+
+	results := service.Search("knight", 0)
+	names := searchNames(results)
+	if len(names) != 2 || names[0] != "The Knight" || names[1] != "The Usurper" {
+		t.Fatalf("expected the name match before the text match, got %v", names)
+	}
+	if results[0].Score <= results[1].Score {
+		t.Errorf("expected a higher score for the name match, got %d and %d", results[0].Score, results[1].Score)
+	}
+
+	if got := service.Search("the", 3); len(got) != 3 {
+		t.Errorf("expected the limit to cap results at 3, got %d", len(got))
+	}
+}
@@ -1,25 +1,56 @@
 package game
 
 import (
-	"embed"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"math/rand"
 	"net/http"
+	"sort"
+	"strconv"
+	"sync"
 )
 
-// CardService manages the loaded cards and provides methods to access them
+// CardService manages the loaded cards and provides methods to access them.
+// Role cards are grouped by subtype: the four Treachery types in their own
+// fields, any other subtype in others, so a card set can bring new role
+// types that config then deals (see CardsOfType).
 type CardService struct {
 	Leaders   []*Card
 	Guardians []*Card
 	Assassins []*Card
 	Traitors  []*Card
+	others    map[RoleType][]*Card
 	allCards  []Card
+	info      CardSetInfo
+
+	// packs are the card packs in display order; packCards indexes their cards
+	packs     []CardPack
+	packCards map[string][]*Card
+
+	// searchIndex is built once, at load for constructed services and on the
+	// first Search for literal ones
+	searchIndex []cardSearchEntry
+	searchOnce  sync.Once
+}
+
+// CardSetInfo describes the loaded card set for the /about page
+type CardSetInfo struct {
+	Name      string
+	Code      string
+	Version   string // card data API version
+	Authors   string
+	Language  string
+	CardCount int
+	Artists   []string // sorted, without duplicates
+	Sandbox   bool     // generated placeholder cards, see NewSandboxCardService
+	Images    bool     // false when cards are served text-only
 }
 
-// NewCardService creates a new CardService by loading cards from embedded data
-func NewCardService(jsonData []byte, imagesFS embed.FS) (*CardService, error) {
+// NewCardService creates a new CardService by loading cards from embedded
+// data. A nil imagesFS loads the cards without artwork, for text-only mode.
+func NewCardService(jsonData []byte, imagesFS fs.FS) (*CardService, error) {
 	// Parse the embedded JSON data
 	var collection CardCollection
 	if err := json.Unmarshal(jsonData, &collection); err != nil {
@@ -32,44 +63,128 @@ func NewCardService(jsonData []byte, imagesFS embed.FS) (*CardService, error) {
 		Assassins: make([]*Card, 0),
 		Traitors:  make([]*Card, 0),
 		allCards:  collection.Cards,
+		info: CardSetInfo{
+			Name:      collection.SetName,
+			Code:      collection.SetCode,
+			Version:   strconv.FormatFloat(collection.APIVersion, 'f', -1, 64),
+			Authors:   collection.APIAuthor,
+			Language:  collection.SetLang,
+			CardCount: len(collection.Cards),
+			Images:    imagesFS != nil,
+		},
 	}
 
 	// Categorize cards by subtype and load images
 	for i := range collection.Cards {
 		card := &collection.Cards[i]
 
-		// Load and encode the image from embedded filesystem
-		imagePath := fmt.Sprintf("static/images/cards/%d.jpg", card.ID)
-		imageData, err := imagesFS.ReadFile(imagePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read embedded image for card %d (%s): %w", card.ID, card.Name, err)
-		}
+		if imagesFS != nil {
+			// Load and encode the image from embedded filesystem
+			imagePath := fmt.Sprintf("static/images/cards/%d.jpg", card.ID)
+			imageData, err := fs.ReadFile(imagesFS, imagePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read embedded image for card %d (%s): %w", card.ID, card.Name, err)
+			}
+
+			// Detect MIME type
+			mimeType := http.DetectContentType(imageData)
 
-		// Detect MIME type
-		mimeType := http.DetectContentType(imageData)
-
-		// Create base64 data URI
-		base64Data := base64.StdEncoding.EncodeToString(imageData)
-		card.Base64Image = fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-
-		// Keep image path for backward compatibility
-		card.ImagePath = fmt.Sprintf("/static/images/cards/%d.jpg", card.ID)
-
-		switch card.Types.Subtype {
-		case "Leader":
-			service.Leaders = append(service.Leaders, card)
-		case "Guardian":
-			service.Guardians = append(service.Guardians, card)
-		case "Assassin":
-			service.Assassins = append(service.Assassins, card)
-		case "Traitor":
-			service.Traitors = append(service.Traitors, card)
+			// Create base64 data URI
+			base64Data := base64.StdEncoding.EncodeToString(imageData)
+			card.Base64Image = fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
+
+			// Keep image path for backward compatibility
+			card.ImagePath = fmt.Sprintf("/static/images/cards/%d.jpg", card.ID)
 		}
+
+		service.addRoleCard(card)
 	}
+	service.indexPacks(collection.Packs, collection.SetName)
+	service.info.Artists = cardArtists(collection.Cards)
+	service.buildSearchIndex()
 
 	return service, nil
 }
 
+// addRoleCard files card with the other cards of its subtype
+func (cs *CardService) addRoleCard(card *Card) {
+	switch roleType := card.GetRoleType(); roleType {
+	case RoleLeader:
+		cs.Leaders = append(cs.Leaders, card)
+	case RoleGuardian:
+		cs.Guardians = append(cs.Guardians, card)
+	case RoleAssassin:
+		cs.Assassins = append(cs.Assassins, card)
+	case RoleTraitor:
+		cs.Traitors = append(cs.Traitors, card)
+	case "":
+		// not a role card
+	default:
+		if cs.others == nil {
+			cs.others = make(map[RoleType][]*Card)
+		}
+		cs.others[roleType] = append(cs.others[roleType], card)
+	}
+}
+
+// CardsOfType returns the cards a role of type roleType is dealt from
+func (cs *CardService) CardsOfType(roleType RoleType) []*Card {
+	switch roleType {
+	case RoleLeader:
+		return cs.Leaders
+	case RoleGuardian:
+		return cs.Guardians
+	case RoleAssassin:
+		return cs.Assassins
+	case RoleTraitor:
+		return cs.Traitors
+	default:
+		return cs.others[roleType]
+	}
+}
+
+// RoleTypes lists the role types the card set has cards for, in deal order
+func (cs *CardService) RoleTypes() []RoleType {
+	types := make([]RoleType, 0, len(standardRoleTypes)+len(cs.others))
+	for _, roleType := range standardRoleTypes {
+		if len(cs.CardsOfType(roleType)) > 0 {
+			types = append(types, roleType)
+		}
+	}
+	for roleType := range cs.others {
+		types = append(types, roleType)
+	}
+	return OrderRoleTypes(types)
+}
+
+// cardArtists returns the distinct artists credited on cards, sorted
+func cardArtists(cards []Card) []string {
+	seen := make(map[string]bool)
+	artists := make([]string, 0)
+	for _, card := range cards {
+		if card.Artist != "" && !seen[card.Artist] {
+			seen[card.Artist] = true
+			artists = append(artists, card.Artist)
+		}
+	}
+	sort.Strings(artists)
+	return artists
+}
+
+// Info describes the loaded card set
+func (cs *CardService) Info() CardSetInfo {
+	return cs.info
+}
+
+// DropImages removes the artwork from every card so they render text-only
+func (cs *CardService) DropImages() {
+	for i := range cs.allCards {
+		cs.allCards[i].Base64Image = ""
+		cs.allCards[i].ImagePath = ""
+	}
+	cs.info.Images = false
+}
+
 // GetRandomLeader returns a random Leader card
 func (cs *CardService) GetRandomLeader() *Card {
 	if len(cs.Leaders) == 0 {
@@ -114,18 +229,8 @@ func (cs *CardService) GetAllCards() []*Card {
 // GetRandomCards returns a specified number of random cards from a category
 // ensuring no duplicates
 func (cs *CardService) GetRandomCards(cardType RoleType, count int) []*Card {
-	var pool []*Card
-
-	switch cardType {
-	case RoleLeader:
-		pool = cs.Leaders
-	case RoleGuardian:
-		pool = cs.Guardians
-	case RoleAssassin:
-		pool = cs.Assassins
-	case RoleTraitor:
-		pool = cs.Traitors
-	default:
+	pool := cs.CardsOfType(cardType)
+	if len(pool) == 0 {
 		return nil
 	}
 
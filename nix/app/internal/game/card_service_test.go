@@ -4,9 +4,11 @@ import (
 	"strings"
 	"testing"
 	"treacherest"
+	"treacherest/internal/testhelpers"
 )
 
 func TestNewCardService(t *testing.T) {
+	testhelpers.RequireCardAssets(t)
 	service, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -55,6 +57,7 @@ func TestNewCardService(t *testing.T) {
 }
 
 func TestCardService_GetRandomCards(t *testing.T) {
+	testhelpers.RequireCardAssets(t)
 	service, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -106,6 +109,7 @@ func TestCardService_GetRandomCards(t *testing.T) {
 }
 
 func TestCardService_GetRandomSingleCards(t *testing.T) {
+	testhelpers.RequireCardAssets(t)
 	service, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -139,7 +143,8 @@ func TestCard_GetRoleType(t *testing.T) {
 		{"Guardian card", "Guardian", RoleGuardian},
 		{"Assassin card", "Assassin", RoleAssassin},
 		{"Traitor card", "Traitor", RoleTraitor},
-		{"Unknown card", "Unknown", ""},
+		{"Card set role type", "Jester", RoleType("Jester")},
+		{"No subtype", "", ""},
 	}
 
 	for _, tt := range tests {
@@ -254,6 +259,7 @@ func TestCard_GreenWinConditionUsesActiveInquisitionAmnesty(t *testing.T) {
 }
 
 func TestCardService_Base64Images(t *testing.T) {
+	testhelpers.RequireCardAssets(t)
 	service, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -294,6 +300,7 @@ func TestCardService_Base64Images(t *testing.T) {
 
 func TestCardService_EmbeddedAssets(t *testing.T) {
 	// This test verifies that embedded assets are loaded correctly
+	testhelpers.RequireCardAssets(t)
 	service, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService with embedded assets: %v", err)
@@ -319,3 +326,35 @@ func TestCardService_EmbeddedAssets(t *testing.T) {
 	// Since files are embedded at compile time, they are guaranteed to exist
 	t.Logf("CardService successfully loaded %d cards from embedded assets", len(allCards))
 }
+
+func TestNewCardServiceWithoutImages(t *testing.T) {
+	if !treacherest.CardAssetsEmbedded {
+		t.Skip("card data not embedded (built with -tags nocardassets)")
+	}
+	service, err := NewCardService(treacherest.TreacheryCardsJSON, nil)
+	if err != nil {
+		t.Fatalf("Failed to create CardService: %v", err)
+	}
+
+	info := service.Info()
+	if info.Images || info.CardCount != len(service.GetAllCards()) || info.Name == "" || len(info.Artists) == 0 {
+		t.Errorf("unexpected card set info: %+v", info)
+	}
+	for _, card := range service.GetAllCards() {
+		if card.Base64Image != "" {
+			t.Fatalf("expected %s to have no image", card.Name)
+		}
+	}
+}
+
+func TestCardService_DropImages(t *testing.T) {
+	service := NewSandboxCardService(1)
+	service.DropImages()
+
+	if service.Info().Images {
+		t.Error("expected Info to report no images")
+	}
+	if image := service.Leaders[0].GetImageBase64(); image != "" {
+		t.Errorf("expected the Leader image to be dropped, got %q", image)
+	}
+}
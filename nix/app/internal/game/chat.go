@@ -0,0 +1,67 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxChatLength bounds one chat message, in characters.
+	MaxChatLength = 280
+	// ChatHistoryLimit is how many messages a room keeps; older ones drop off.
+	ChatHistoryLimit = 50
+)
+
+var (
+	ErrChatEmpty   = errors.New("chat message is empty")
+	ErrChatTooLong = errors.New("chat message is too long")
+)
+
+// ChatMessage is one line of a room's table chat
+type ChatMessage struct {
+	Seq      int       `json:"seq"`
+	PlayerID string    `json:"playerId"`
+	Name     string    `json:"name"`
+	Text     string    `json:"text"`
+	At       time.Time `json:"at"`
+}
+
+// PostChat appends a message from playerID to the room's chat, dropping the
+// oldest message once ChatHistoryLimit are kept.
+func (r *Room) PostChat(playerID, text string, at time.Time) (ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ChatMessage{}, ErrChatEmpty
+	}
+	if utf8.RuneCountInString(text) > MaxChatLength {
+		return ChatMessage{}, ErrChatTooLong
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	player, ok := r.Players[playerID]
+	if !ok {
+		return ChatMessage{}, ErrPlayerNotFound
+	}
+	seq := 1
+	if n := len(r.Chat); n > 0 {
+		seq = r.Chat[n-1].Seq + 1
+	}
+	msg := ChatMessage{Seq: seq, PlayerID: playerID, Name: player.Name, Text: text, At: at}
+	r.Chat = append(r.Chat, msg)
+	if over := len(r.Chat) - ChatHistoryLimit; over > 0 {
+		r.Chat = append(r.Chat[:0:0], r.Chat[over:]...)
+	}
+	return msg, nil
+}
+
+// ChatMessages returns a copy of the room's kept chat, oldest first.
+func (r *Room) ChatMessages() []ChatMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]ChatMessage(nil), r.Chat...)
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPostChatKeepsTheLatestMessages(t *testing.T) {
+	room := &Room{Code: "CHAT1", Players: map[string]*Player{"p1": {ID: "p1", Name: "Alice"}}}
+	at := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= ChatHistoryLimit+5; i++ {
+		if _, err := room.PostChat("p1", fmt.Sprintf("message %d", i), at); err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+	}
+
+	chat := room.ChatMessages()
+	if len(chat) != ChatHistoryLimit {
+		t.Fatalf("expected %d kept messages, got %d", ChatHistoryLimit, len(chat))
+	}
+	if chat[0].Text != "message 6" || chat[len(chat)-1].Seq != ChatHistoryLimit+5 {
+		t.Errorf("expected the oldest messages to drop off, got first %q and last seq %d", chat[0].Text, chat[len(chat)-1].Seq)
+	}
+	if chat[0].Name != "Alice" {
+		t.Errorf("expected the author's name on the message, got %q", chat[0].Name)
+	}
+}
+
+func TestPostChatRejectsBadMessages(t *testing.T) {
+	room := &Room{Code: "CHAT2", Players: map[string]*Player{"p1": {ID: "p1", Name: "Alice"}}}
+	now := time.Now()
+
+	if _, err := room.PostChat("p1", "   ", now); !errors.Is(err, ErrChatEmpty) {
+		t.Errorf("expected ErrChatEmpty, got %v", err)
+	}
+	if _, err := room.PostChat("p1", strings.Repeat("x", MaxChatLength+1), now); !errors.Is(err, ErrChatTooLong) {
+		t.Errorf("expected ErrChatTooLong, got %v", err)
+	}
+	if _, err := room.PostChat("ghost", "hello", now); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	}
+	if len(room.ChatMessages()) != 0 {
+		t.Error("rejected messages should not be kept")
+	}
+}
@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxListedCardChanges is how many card changes of one role type are listed
+// by name before they are summed up instead
+const maxListedCardChanges = 3
+
+// Clone returns a deep copy of c
+func (c *RoleConfiguration) Clone() *RoleConfiguration {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	clone.RoleTypes = make(map[string]*RoleTypeConfig, len(c.RoleTypes))
+	for name, typeConfig := range c.RoleTypes {
+		if typeConfig == nil {
+			clone.RoleTypes[name] = nil
+			continue
+		}
+		enabled := make(map[string]bool, len(typeConfig.EnabledCards))
+		for card, on := range typeConfig.EnabledCards {
+			enabled[card] = on
+		}
+		clone.RoleTypes[name] = &RoleTypeConfig{Count: typeConfig.Count, EnabledCards: enabled}
+	}
+	return &clone
+}
+
+// DiffRoleConfigs describes what changed from before to after, one line per
+// change, e.g. "Guardians 2→3" or "Assassin card 'The Ninja' disabled"
+func DiffRoleConfigs(before, after *RoleConfiguration) []string {
+	if before == nil || after == nil {
+		return nil
+	}
+	var changes []string
+	if before.PresetName != after.PresetName {
+		changes = append(changes, fmt.Sprintf("Preset %s→%s", before.PresetName, after.PresetName))
+	}
+	if before.MaxPlayers != after.MaxPlayers {
+		changes = append(changes, fmt.Sprintf("Players %d→%d", before.MaxPlayers, after.MaxPlayers))
+	}
+
+	seen := make(map[RoleType]bool)
+	var roleTypes []RoleType
+	for _, config := range []*RoleConfiguration{before, after} {
+		for name := range config.RoleTypes {
+			if !seen[RoleType(name)] {
+				seen[RoleType(name)] = true
+				roleTypes = append(roleTypes, RoleType(name))
+			}
+		}
+	}
+	for _, roleType := range OrderRoleTypes(roleTypes) {
+		was, now := before.RoleTypes[string(roleType)], after.RoleTypes[string(roleType)]
+		if count, newCount := roleTypeCount(was), roleTypeCount(now); count != newCount {
+			changes = append(changes, fmt.Sprintf("%ss %d→%d", roleType, count, newCount))
+		}
+		changes = append(changes, diffEnabledCards(roleType, was, now)...)
+	}
+
+	changes = appendSettingChange(changes, before.AllowLeaderlessGame, after.AllowLeaderlessGame, "Leaderless games allowed", "Leaderless games no longer allowed")
+	changes = appendSettingChange(changes, before.HideRoleDistribution, after.HideRoleDistribution, "Role distribution hidden", "Role distribution shown")
+	changes = appendSettingChange(changes, before.FullyRandomRoles, after.FullyRandomRoles, "Fully random roles on", "Fully random roles off")
+	if before.RebalanceStrategy != after.RebalanceStrategy {
+		changes = append(changes, fmt.Sprintf("Rebalancing %s→%s", rebalanceLabel(before.RebalanceStrategy), rebalanceLabel(after.RebalanceStrategy)))
+	}
+	return changes
+}
+
+// diffEnabledCards lists the cards of roleType enabled or disabled between
+// two configs, or sums them up when there are too many to list
+func diffEnabledCards(roleType RoleType, before, after *RoleTypeConfig) []string {
+	var enabled, disabled []string
+	names := make(map[string]bool)
+	for _, typeConfig := range []*RoleTypeConfig{before, after} {
+		if typeConfig != nil {
+			for name := range typeConfig.EnabledCards {
+				names[name] = true
+			}
+		}
+	}
+	for name := range names {
+		was, now := cardEnabled(before, name), cardEnabled(after, name)
+		switch {
+		case now && !was:
+			enabled = append(enabled, name)
+		case was && !now:
+			disabled = append(disabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	sort.Strings(disabled)
+
+	if len(enabled)+len(disabled) > maxListedCardChanges {
+		return []string{fmt.Sprintf("%s cards: %d enabled, %d disabled", roleType, len(enabled), len(disabled))}
+	}
+	var changes []string
+	for _, name := range enabled {
+		changes = append(changes, fmt.Sprintf("%s card '%s' enabled", roleType, name))
+	}
+	for _, name := range disabled {
+		changes = append(changes, fmt.Sprintf("%s card '%s' disabled", roleType, name))
+	}
+	return changes
+}
+
+func roleTypeCount(typeConfig *RoleTypeConfig) int {
+	if typeConfig == nil {
+		return 0
+	}
+	return typeConfig.Count
+}
+
+func cardEnabled(typeConfig *RoleTypeConfig, name string) bool {
+	return typeConfig != nil && typeConfig.EnabledCards[name]
+}
+
+func appendSettingChange(changes []string, before, after bool, on, off string) []string {
+	switch {
+	case after && !before:
+		return append(changes, on)
+	case before && !after:
+		return append(changes, off)
+	}
+	return changes
+}
+
+// rebalanceLabel names a rebalance strategy for a diff line
+func rebalanceLabel(name string) string {
+	if strategy, ok := RebalanceStrategyByName(name); ok {
+		return strategy.Label()
+	}
+	return "Keep role counts"
+}
+
+// KeepConfigBaseline remembers the role config as it was before a preset
+// switch, so the Room Operator sees what the switch and any later edits
+// changed until they dismiss it. Later switches keep the first baseline.
+func (r *Room) KeepConfigBaseline(before *RoleConfiguration) {
+	if r.PreviousRoleConfig == nil {
+		r.PreviousRoleConfig = before
+	}
+}
+
+// ConfigChanges describes what changed since the baseline, if there is one
+func (r *Room) ConfigChanges() []string {
+	return DiffRoleConfigs(r.PreviousRoleConfig, r.RoleConfig)
+}
+
+// DismissConfigChanges forgets the baseline
+func (r *Room) DismissConfigChanges() {
+	r.PreviousRoleConfig = nil
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRoleConfigs(t *testing.T) {
+	before := &RoleConfiguration{
+		PresetName: "standard",
+		MaxPlayers: 5,
+		RoleTypes: map[string]*RoleTypeConfig{
+			"Leader":   {Count: 1, EnabledCards: map[string]bool{"The King": true}},
+			"Guardian": {Count: 2, EnabledCards: map[string]bool{"The Bodyguard": true}},
+			"Assassin": {Count: 1, EnabledCards: map[string]bool{"The Ninja": true, "The Sniper": true}},
+		},
+	}
+	after := before.Clone()
+	after.PresetName = "custom"
+	after.RoleTypes["Guardian"].Count = 3
+	after.RoleTypes["Assassin"].EnabledCards["The Ninja"] = false
+	after.AllowLeaderlessGame = true
+
+	if before.RoleTypes["Guardian"].Count != 2 || !before.RoleTypes["Assassin"].EnabledCards["The Ninja"] {
+		t.Fatal("expected Clone to copy the role types, not share them")
+	}
+	want := []string{
+		"Preset standard→custom",
+		"Guardians 2→3",
+		"Assassin card 'The Ninja' disabled",
+		"Leaderless games allowed",
+	}
+	if got := DiffRoleConfigs(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := DiffRoleConfigs(before, before.Clone()); len(got) != 0 {
+		t.Errorf("expected no changes between copies, got %q", got)
+	}
+
+	after.RoleTypes["Traitor"] = &RoleTypeConfig{Count: 1, EnabledCards: map[string]bool{"A": true, "B": true, "C": true, "D": true}}
+	got := DiffRoleConfigs(before, after)
+	if last := got[len(got)-2]; last != "Traitor cards: 4 enabled, 0 disabled" {
+		t.Errorf("expected many card changes summed up, got %q", got)
+	}
+}
+
+func TestConfigBaselineKeepsTheFirstSwitch(t *testing.T) {
+	room := &Room{RoleConfig: &RoleConfiguration{PresetName: "custom", RoleTypes: map[string]*RoleTypeConfig{}}}
+	first := room.RoleConfig.Clone()
+	room.KeepConfigBaseline(first)
+	room.RoleConfig = &RoleConfiguration{PresetName: "standard", RoleTypes: map[string]*RoleTypeConfig{}}
+	room.KeepConfigBaseline(room.RoleConfig.Clone())
+
+	if changes := room.ConfigChanges(); len(changes) != 1 || changes[0] != "Preset custom→standard" {
+		t.Errorf("expected the changes since the first switch, got %q", changes)
+	}
+	room.DismissConfigChanges()
+	if changes := room.ConfigChanges(); changes != nil {
+		t.Errorf("expected no changes once dismissed, got %q", changes)
+	}
+}
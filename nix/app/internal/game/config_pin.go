@@ -0,0 +1,54 @@
+package game
+
+import (
+	"fmt"
+
+	"treacherest/internal/config"
+)
+
+// Config returns the server config that governs the room: the one it pinned
+// during a config reload, or current
+func (r *Room) Config(current *config.ServerConfig) *config.ServerConfig {
+	if r.PinnedConfig != nil {
+		return r.PinnedConfig
+	}
+	return current
+}
+
+// ConfigConflicts lists why next would invalidate the room's current setup,
+// e.g. a smaller player cap than the players already seated or a removed
+// preset the role configuration still points at
+func (r *Room) ConfigConflicts(next *config.ServerConfig) []string {
+	var conflicts []string
+
+	if seated := r.GetActivePlayerCount(); seated > next.Server.MaxPlayersPerRoom {
+		conflicts = append(conflicts, fmt.Sprintf("the player limit dropped to %d but %d players are seated", next.Server.MaxPlayersPerRoom, seated))
+	} else if r.State != StateLobby && r.MaxPlayers > next.Server.MaxPlayersPerRoom {
+		conflicts = append(conflicts, fmt.Sprintf("the player limit dropped to %d mid-game", next.Server.MaxPlayersPerRoom))
+	}
+
+	if r.RoleConfig != nil && r.RoleConfig.PresetName != "" && r.RoleConfig.PresetName != "custom" {
+		if _, ok := next.GetPreset(r.RoleConfig.PresetName); !ok {
+			conflicts = append(conflicts, fmt.Sprintf("the %q preset was removed", r.RoleConfig.PresetName))
+		}
+	}
+	return conflicts
+}
+
+// PinConfig keeps the room on captured for the rest of its life and records
+// the notice shown to its host
+func (r *Room) PinConfig(captured *config.ServerConfig, notice string) {
+	r.PinnedConfig = captured
+	r.ConfigNotice = notice
+}
+
+// FitConfig clamps a lobby's player caps to next, for rooms whose setup
+// next leaves valid
+func (r *Room) FitConfig(next *config.ServerConfig) {
+	if r.MaxPlayers > next.Server.MaxPlayersPerRoom {
+		r.MaxPlayers = next.Server.MaxPlayersPerRoom
+	}
+	if r.RoleConfig != nil && r.RoleConfig.MaxPlayers > next.Server.MaxPlayersPerRoom {
+		r.RoleConfig.MaxPlayers = next.Server.MaxPlayersPerRoom
+	}
+}
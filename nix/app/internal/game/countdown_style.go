@@ -0,0 +1,64 @@
+package game
+
+import "errors"
+
+// CountdownSeconds is how long the countdown start ritual runs
+const CountdownSeconds = 5
+
+// CountdownStyle is how a player's page presents the countdown before roles
+// are revealed. Each player picks their own; the zero value is numeric.
+type CountdownStyle string
+
+const (
+	CountdownNumeric  CountdownStyle = "numeric"  // The big ticking number
+	CountdownProgress CountdownStyle = "progress" // A bar that fills as the reveal nears
+	CountdownText     CountdownStyle = "text"     // A sentence announced to screen readers each second
+)
+
+// CountdownStyles lists the countdown presentations in picker order
+var CountdownStyles = []CountdownStyle{CountdownNumeric, CountdownProgress, CountdownText}
+
+var ErrInvalidCountdownStyle = errors.New("invalid countdown style")
+
+// Label names the style in the picker
+func (s CountdownStyle) Label() string {
+	switch s {
+	case CountdownProgress:
+		return "Progress bar"
+	case CountdownText:
+		return "Spoken text"
+	default:
+		return "Number"
+	}
+}
+
+// ParseCountdownStyle returns the style named s
+func ParseCountdownStyle(s string) (CountdownStyle, error) {
+	for _, style := range CountdownStyles {
+		if string(style) == s {
+			return style, nil
+		}
+	}
+	return "", ErrInvalidCountdownStyle
+}
+
+// CountdownPresentation is the player's countdown style, numeric when unset
+func (p *Player) CountdownPresentation() CountdownStyle {
+	if p == nil || p.CountdownStyle == "" {
+		return CountdownNumeric
+	}
+	return p.CountdownStyle
+}
+
+// SetPlayerCountdownStyle records how a player wants the countdown shown
+func (r *Room) SetPlayerCountdownStyle(playerID string, style CountdownStyle) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	player, ok := r.Players[playerID]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	player.CountdownStyle = style
+	return nil
+}
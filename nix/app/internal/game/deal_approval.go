@@ -0,0 +1,43 @@
+package game
+
+import "errors"
+
+var (
+	ErrNoDealPending = errors.New("no deal is waiting for approval")
+	ErrDealOutdated  = errors.New("someone joined after the deal; deal again to include them")
+)
+
+// OperatorPlays reports whether the Room Operator holds a seat in the game
+// rather than only hosting it
+func (r *Room) OperatorPlays() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, player := range r.Players {
+		if r.OperatorSessionID != "" && player.SessionID == r.OperatorSessionID {
+			return !player.IsHost
+		}
+	}
+	return false
+}
+
+// DealApprovalOn reports whether a started game waits for the Room Operator
+// to approve the deal. Seeing the deal means seeing every role, so only an
+// operator who isn't playing can turn it on.
+func (r *Room) DealApprovalOn() bool {
+	return r.StartRitual.ApproveDeal && !r.OperatorPlays()
+}
+
+// DealOutdated reports whether a player joined after the pending deal and so
+// has no role
+func (r *Room) DealOutdated() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, player := range r.Players {
+		if !player.IsHost && player.Role == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -7,4 +7,5 @@ var (
 	ErrGameAlreadyStarted = errors.New("game has already started")
 	ErrNotEnoughPlayers   = errors.New("not enough players to start")
 	ErrDuplicateName      = errors.New("a player with that name already exists in the room")
+	ErrPlayerNotFound     = errors.New("player not found")
 )
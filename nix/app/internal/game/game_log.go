@@ -0,0 +1,65 @@
+package game
+
+import (
+	"time"
+
+	"treacherest/internal/privacy"
+)
+
+// maxLogEntries bounds the per-room game log kept in memory and in backups
+const maxLogEntries = 200
+
+// LogEntry is one public line in a room's game log.
+type LogEntry struct {
+	At      time.Time
+	Message string
+}
+
+// AppendLog records a public event in the room's game log.
+func (r *Room) AppendLog(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Log = append(r.Log, LogEntry{At: time.Now(), Message: message})
+	if len(r.Log) > maxLogEntries {
+		r.Log = r.Log[len(r.Log)-maxLogEntries:]
+	}
+}
+
+// GetLog returns a copy of the room's game log, oldest first.
+func (r *Room) GetLog() []LogEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]LogEntry, len(r.Log))
+	copy(entries, r.Log)
+	return entries
+}
+
+// PruneLog drops game log entries from before cutoff, for history retention,
+// returning how many it dropped.
+func (r *Room) PruneLog(cutoff time.Time) int {
+	return r.filterLog(func(e LogEntry) bool { return !e.At.Before(cutoff) })
+}
+
+// PurgeLog drops game log entries that mention any of terms as a whole
+// token, e.g. a player who asked to be forgotten, returning how many it
+// dropped.
+func (r *Room) PurgeLog(terms ...string) int {
+	return r.filterLog(func(e LogEntry) bool { return !privacy.MentionsAny(e.Message, terms...) })
+}
+
+func (r *Room) filterLog(keep func(LogEntry) bool) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.Log[:0]
+	for _, e := range r.Log {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+	dropped := len(r.Log) - len(kept)
+	r.Log = kept
+	return dropped
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"crypto/subtle"
+	"errors"
+	"sort"
+)
+
+var (
+	ErrRecoveryCodeInvalid    = errors.New("that recovery code is not valid for this room")
+	ErrOperatorTransferTarget = errors.New("control can only pass to another player seated in the room")
+)
+
+// EnsureCreatorToken returns the room's host recovery token, creating it on first use.
+func (r *Room) EnsureCreatorToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.CreatorToken == "" {
+		r.CreatorToken = newPublicToken()
+	}
+	return r.CreatorToken
+}
+
+// RecoverOperator moves Room Operator authority to sessionID when token is
+// the room's creator token. The player seated by the old operator session
+// moves with it and is returned; it is nil if that player has left. A seat
+// protected by a PIN only moves when pin matches, so the recovery code alone,
+// shown on the host dashboard, can't claim it.
+func (r *Room) RecoverOperator(token, pin, sessionID string) (*Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.CreatorToken == "" || token == "" || sessionID == "" {
+		return nil, ErrRecoveryCodeInvalid
+	}
+	if subtle.ConstantTimeCompare([]byte(r.CreatorToken), []byte(token)) != 1 {
+		return nil, ErrRecoveryCodeInvalid
+	}
+
+	var operator *Player
+	if r.OperatorSessionID != "" {
+		for _, player := range r.Players {
+			if player.SessionID == r.OperatorSessionID {
+				operator = player
+				break
+			}
+		}
+	}
+	if operator != nil {
+		if !operator.seatPINMatches(pin) {
+			return nil, ErrSeatPINMismatch
+		}
+		operator.SessionID = sessionID
+	}
+	r.OperatorSessionID = sessionID
+	return operator, nil
+}
+
+// TransferOperator moves Room Operator authority to the seated player
+// playerID, who keeps their seat and role. The recovery code is cleared, so
+// the previous operator's copy can't take control back; the new operator's
+// dashboard shows a fresh one. A deal waiting for approval is called off and
+// the room goes back to setup, as the new operator's dashboard would show a
+// player everyone's role.
+func (r *Room) TransferOperator(playerID string) (*Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := r.Players[playerID]
+	if !canTakeOperator(target) || target.SessionID == r.OperatorSessionID {
+		return nil, ErrOperatorTransferTarget
+	}
+	if r.DealPending {
+		r.clearDeal()
+	}
+	r.OperatorSessionID = target.SessionID
+	r.CreatorToken = ""
+	return target, nil
+}
+
+// OperatorFallback returns the earliest-joined player who could take over
+// from an absent Room Operator, or nil when no one could
+func (r *Room) OperatorFallback() *Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var candidates []*Player
+	for _, player := range r.Players {
+		if canTakeOperator(player) && player.SessionID != r.OperatorSessionID {
+			candidates = append(candidates, player)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].JoinedAt.Before(candidates[j].JoinedAt)
+	})
+	return candidates[0]
+}
+
+// canTakeOperator reports whether player is a real, playing seat that
+// Room Operator authority can move to
+func canTakeOperator(player *Player) bool {
+	return player != nil && !player.IsHost && !player.IsDebug && player.SessionID != ""
+}
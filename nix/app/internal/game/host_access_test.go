@@ -0,0 +1,138 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoom_RecoverOperator(t *testing.T) {
+	room := &Room{Code: "HOST1", Players: make(map[string]*Player)}
+	host := NewPlayer("host", "Host", "old-session")
+	host.IsHost = true
+	room.Players[host.ID] = host
+	room.OperatorSessionID = host.SessionID
+
+	if _, err := room.RecoverOperator("", "", "new-session"); err != ErrRecoveryCodeInvalid {
+		t.Fatal("empty token should never recover operator access")
+	}
+
+	token := room.EnsureCreatorToken()
+	if again := room.EnsureCreatorToken(); again != token {
+		t.Fatalf("EnsureCreatorToken should be stable, got %q then %q", token, again)
+	}
+	if _, err := room.RecoverOperator(token[:31]+"x", "", "new-session"); err != ErrRecoveryCodeInvalid {
+		t.Fatal("altered token should not recover operator access")
+	}
+	if !room.IsOperatorSession("old-session") {
+		t.Fatal("a failed recovery should leave the operator session alone")
+	}
+
+	player, err := room.RecoverOperator(token, "", "new-session")
+	if err != nil || player != host {
+		t.Fatalf("expected recovery to return the host, got %v, %v", player, err)
+	}
+	if !room.IsOperatorSession("new-session") || room.IsOperatorSession("old-session") {
+		t.Error("expected operator access to move to the new session")
+	}
+	if host.SessionID != "new-session" {
+		t.Errorf("expected the host to move to the new session, got %q", host.SessionID)
+	}
+}
+
+func TestRoom_RecoverOperatorNeedsSeatPIN(t *testing.T) {
+	room := &Room{Code: "HOST2", Players: make(map[string]*Player)}
+	host := NewPlayer("host", "Host", "old-session")
+	if err := host.SetSeatPIN("12a4"); err != ErrSeatPINInvalid {
+		t.Fatalf("expected a non-numeric PIN to be refused, got %v", err)
+	}
+	if err := host.SetSeatPIN("1234"); err != nil {
+		t.Fatal(err)
+	}
+	if host.SeatPINHash == "" || host.SeatPINHash == "1234" {
+		t.Fatalf("expected only a hash of the PIN to be kept, got %q", host.SeatPINHash)
+	}
+	room.Players[host.ID] = host
+	room.OperatorSessionID = host.SessionID
+	token := room.EnsureCreatorToken()
+
+	for _, pin := range []string{"", "4321"} {
+		if _, err := room.RecoverOperator(token, pin, "new-session"); err != ErrSeatPINMismatch {
+			t.Fatalf("expected PIN %q to be refused, got %v", pin, err)
+		}
+	}
+	if !room.IsOperatorSession("old-session") || host.SessionID != "old-session" {
+		t.Fatal("a refused PIN should leave the seat where it was")
+	}
+
+	if player, err := room.RecoverOperator(token, "1234", "new-session"); err != nil || player != host {
+		t.Fatalf("expected the right PIN to move the seat, got %v, %v", player, err)
+	}
+	if host.SessionID != "new-session" {
+		t.Errorf("expected the host to move to the new session, got %q", host.SessionID)
+	}
+}
+
+func TestRoom_ReclaimSeat(t *testing.T) {
+	room := &Room{Code: "SEATS", Players: make(map[string]*Player), OperatorSessionID: "host-session"}
+	host := NewPlayer("host", "Host", "host-session")
+	host.SetSeatPIN("1111")
+	alice := NewPlayer("p1", "Alice", "old-session")
+	alice.SetSeatPIN("1234")
+	bob := NewPlayer("p2", "Bob", "bob-session")
+	for _, p := range []*Player{host, alice, bob} {
+		room.Players[p.ID] = p
+	}
+
+	for _, tc := range []struct {
+		name, pin, session string
+		err                error
+	}{
+		{"Carol", "1234", "new-session", ErrPlayerNotFound},
+		{"Bob", "", "new-session", ErrSeatNotProtected},
+		{"Host", "1111", "new-session", ErrSeatIsOperators},
+		{"alice", "4321", "new-session", ErrSeatPINMismatch},
+		{"Alice", "1234", "bob-session", ErrAlreadySeated},
+	} {
+		if _, err := room.ReclaimSeat(tc.name, tc.pin, tc.session); err != tc.err {
+			t.Errorf("reclaim %s with %q: expected %v, got %v", tc.name, tc.pin, tc.err, err)
+		}
+	}
+	if alice.SessionID != "old-session" {
+		t.Fatal("a refused reclaim should leave the seat where it was")
+	}
+
+	if player, err := room.ReclaimSeat("alice", "1234", "new-session"); err != nil || player != alice {
+		t.Fatalf("expected the right PIN to move the seat, got %v, %v", player, err)
+	}
+	if alice.SessionID != "new-session" {
+		t.Errorf("expected Alice to move to the new session, got %q", alice.SessionID)
+	}
+}
+
+func TestRoom_OperatorFallbackSkipsHostsAndDebugSeats(t *testing.T) {
+	room := &Room{Code: "HOST2", Players: make(map[string]*Player)}
+	host := NewPlayer("host", "Host", "host-session")
+	host.IsHost = true
+	debug := NewPlayer("debug", "Debug", "debug-session")
+	debug.IsDebug = true
+	early := NewPlayer("early", "Early", "early-session")
+	late := NewPlayer("late", "Late", "late-session")
+	late.JoinedAt = early.JoinedAt.Add(time.Minute)
+	for _, p := range []*Player{host, debug, early, late} {
+		room.Players[p.ID] = p
+	}
+	room.OperatorSessionID = host.SessionID
+
+	if next := room.OperatorFallback(); next != early {
+		t.Fatalf("expected the earliest-joined player, got %v", next)
+	}
+	if _, err := room.TransferOperator(debug.ID); err != ErrOperatorTransferTarget {
+		t.Fatalf("expected a debug seat to be refused, got %v", err)
+	}
+	if _, err := room.TransferOperator(late.ID); err != nil || !room.IsOperatorSession(late.SessionID) {
+		t.Fatalf("expected control to pass to Late, got %v", err)
+	}
+	if next := room.OperatorFallback(); next != early {
+		t.Fatalf("expected the operator's own seat to be skipped, got %v", next)
+	}
+}
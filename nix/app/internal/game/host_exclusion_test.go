@@ -3,6 +3,7 @@ package game
 import (
 	"testing"
 	"treacherest"
+	"treacherest/internal/testhelpers"
 )
 
 func TestHostExclusion(t *testing.T) {
@@ -15,6 +16,7 @@ func TestHostExclusion(t *testing.T) {
 		}
 
 		// Create card service
+		testhelpers.RequireCardAssets(t)
 		cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 		if err != nil {
 			t.Fatalf("Failed to create card service: %v", err)
@@ -125,6 +127,7 @@ func TestHostExclusion(t *testing.T) {
 			{ID: "host", Name: "Host", IsHost: true},
 		}
 
+		testhelpers.RequireCardAssets(t)
 		cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 		if err != nil {
 			t.Fatalf("Failed to create card service: %v", err)
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// Invite is a one-time join link emailed to one address. It is pending until
+// a player joins through it.
+type Invite struct {
+	Token    string
+	Email    string
+	SentAt   time.Time
+	PlayerID string // who joined through it; empty while pending
+	JoinedAt time.Time
+}
+
+// Joined reports whether someone has joined through the invite
+func (i Invite) Joined() bool {
+	return i.PlayerID != ""
+}
+
+// NewInvite issues an invite for email, sent at now. It counts for the room
+// once added, so an invite that couldn't be sent is simply dropped.
+func NewInvite(email string, now time.Time) Invite {
+	return Invite{Token: newPublicToken(), Email: email, SentAt: now}
+}
+
+// AddInvite records a sent invite
+func (r *Room) AddInvite(invite Invite) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Invites = append(r.Invites, invite)
+}
+
+// FindInvite returns the invite behind token
+func (r *Room) FindInvite(token string) (Invite, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i := r.inviteIndex(token); i >= 0 {
+		return r.Invites[i], true
+	}
+	return Invite{}, false
+}
+
+// AcceptInvite records that playerID joined through the invite behind token.
+// It reports false when there is no such invite or it was already used.
+func (r *Room) AcceptInvite(token, playerID string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.inviteIndex(token)
+	if i < 0 || r.Invites[i].Joined() {
+		return false
+	}
+	r.Invites[i].PlayerID = playerID
+	r.Invites[i].JoinedAt = now
+	return true
+}
+
+// InvitesSentSince counts the invites sent at or after since
+func (r *Room) InvitesSentSince(since time.Time) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, invite := range r.Invites {
+		if !invite.SentAt.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetInvites returns a copy of the room's invites, oldest first
+func (r *Room) GetInvites() []Invite {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invites := make([]Invite, len(r.Invites))
+	copy(invites, r.Invites)
+	return invites
+}
+
+func (r *Room) inviteIndex(token string) int {
+	if token == "" {
+		return -1
+	}
+	for i, invite := range r.Invites {
+		if subtle.ConstantTimeCompare([]byte(invite.Token), []byte(token)) == 1 {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoom_Invites(t *testing.T) {
+	room := &Room{Code: "INVIT", Players: make(map[string]*Player)}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	first := NewInvite("alice@example.com", start)
+	second := NewInvite("bob@example.com", start.Add(time.Hour))
+	room.AddInvite(first)
+	room.AddInvite(second)
+	if first.Token == second.Token || first.Token == "" {
+		t.Fatal("invites should have distinct tokens")
+	}
+	if got, ok := room.FindInvite(second.Token); !ok || got.Email != "bob@example.com" {
+		t.Errorf("expected Bob's invite behind his token, got %+v", got)
+	}
+	if _, ok := room.FindInvite(""); ok {
+		t.Error("empty token should never find an invite")
+	}
+
+	if !room.AcceptInvite(first.Token, "p1", start.Add(time.Minute)) {
+		t.Fatal("expected a pending invite accepted")
+	}
+	if room.AcceptInvite(first.Token, "p2", start.Add(2*time.Minute)) {
+		t.Error("expected an invite usable only once")
+	}
+	if got, _ := room.FindInvite(first.Token); !got.Joined() || got.PlayerID != "p1" {
+		t.Errorf("expected the first joiner recorded, got %+v", got)
+	}
+
+	if n := room.InvitesSentSince(start.Add(time.Minute)); n != 1 {
+		t.Errorf("expected one invite sent in the window, got %d", n)
+	}
+	if invites := room.GetInvites(); len(invites) != 2 || invites[1].Joined() {
+		t.Errorf("expected both invites with Bob's pending, got %+v", invites)
+	}
+}
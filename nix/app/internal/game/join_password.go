@@ -0,0 +1,152 @@
+package game
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"unicode/utf8"
+)
+
+// MaxJoinPasswordLength caps a room's join password, in characters
+const MaxJoinPasswordLength = 64
+
+// maxJoinTokens caps how many unused QR join tokens a room keeps; the oldest
+// go first, since only the QR code on screen now needs to work
+const maxJoinTokens = 20
+
+var (
+	ErrJoinPasswordTooLong  = errors.New("room password must be at most 64 characters")
+	ErrJoinPasswordRequired = errors.New("this room needs its password to join")
+	ErrJoinPasswordMismatch = errors.New("wrong room password")
+)
+
+// SetJoinPassword makes joining the room take password; "" opens it to
+// anyone with the code. Only a hash salted with the room code is kept.
+func (r *Room) SetJoinPassword(password string) error {
+	if utf8.RuneCountInString(password) > MaxJoinPasswordLength {
+		return ErrJoinPasswordTooLong
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.JoinTokens = nil
+	if password == "" {
+		r.JoinPasswordHash = ""
+		return nil
+	}
+	r.JoinPasswordHash = hashJoinPassword(r.Code, password)
+	return nil
+}
+
+// HasJoinPassword reports whether joining the room takes a password
+func (r *Room) HasJoinPassword() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.JoinPasswordHash != ""
+}
+
+// JoinPasswordMatches reports whether password lets a newcomer join; a room
+// without a password lets anyone in
+func (r *Room) JoinPasswordMatches(password string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.JoinPasswordHash == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(r.JoinPasswordHash), []byte(hashJoinPassword(r.Code, password))) == 1
+}
+
+// SpectatePass returns what a browser that gave the room's password keeps to
+// watch it without a seat, "" for a room without a password. It is derived
+// from the password, so changing that voids every pass.
+func (r *Room) SpectatePass() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.JoinPasswordHash == "" {
+		return ""
+	}
+	return spectatePassFor(r.JoinPasswordHash)
+}
+
+// SpectatePassMatches reports whether pass lets a browser watch the room; a
+// room without a password can be watched by anyone
+func (r *Room) SpectatePassMatches(pass string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.JoinPasswordHash == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(spectatePassFor(r.JoinPasswordHash)), []byte(pass)) == 1
+}
+
+// IssueJoinToken returns a token that lets one newcomer join without the
+// password, for the room's QR code
+func (r *Room) IssueJoinToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token := newPublicToken()
+	r.JoinTokens = append(r.JoinTokens, token)
+	if len(r.JoinTokens) > maxJoinTokens {
+		r.JoinTokens = r.JoinTokens[len(r.JoinTokens)-maxJoinTokens:]
+	}
+	return token
+}
+
+// HasJoinToken reports whether token is an unused join token of the room
+func (r *Room) HasJoinToken(token string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.joinTokenIndex(token) >= 0
+}
+
+// UseJoinToken spends token, reporting whether it was an unused join token
+// of the room
+func (r *Room) UseJoinToken(token string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.joinTokenIndex(token)
+	if i < 0 {
+		return false
+	}
+	r.JoinTokens = append(r.JoinTokens[:i], r.JoinTokens[i+1:]...)
+	r.JoinTokensUsed++
+	return true
+}
+
+// UsedJoinTokens counts the join tokens spent so far
+func (r *Room) UsedJoinTokens() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.JoinTokensUsed
+}
+
+func (r *Room) joinTokenIndex(token string) int {
+	if token == "" {
+		return -1
+	}
+	for i, t := range r.JoinTokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return i
+		}
+	}
+	return -1
+}
+
+func hashJoinPassword(roomCode, password string) string {
+	sum := sha256.Sum256([]byte(roomCode + ":" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+func spectatePassFor(joinPasswordHash string) string {
+	sum := sha256.Sum256([]byte("spectate:" + joinPasswordHash))
+	return hex.EncodeToString(sum[:])
+}
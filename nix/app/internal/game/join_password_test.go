@@ -0,0 +1,83 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJoinPassword(t *testing.T) {
+	room := &Room{Code: "PRIV1"}
+	if room.HasJoinPassword() || !room.JoinPasswordMatches("") {
+		t.Fatal("expected a new room to be open to anyone")
+	}
+
+	if err := room.SetJoinPassword(strings.Repeat("x", MaxJoinPasswordLength+1)); !errors.Is(err, ErrJoinPasswordTooLong) {
+		t.Errorf("expected an overlong password refused, got %v", err)
+	}
+	if err := room.SetJoinPassword("swordfish"); err != nil {
+		t.Fatal(err)
+	}
+	if !room.HasJoinPassword() || strings.Contains(room.JoinPasswordHash, "swordfish") {
+		t.Error("expected only a hash of the password kept")
+	}
+	if !room.JoinPasswordMatches("swordfish") || room.JoinPasswordMatches("Swordfish") || room.JoinPasswordMatches("") {
+		t.Error("expected only the exact password to match")
+	}
+
+	room.SetJoinPassword("")
+	if room.HasJoinPassword() {
+		t.Error("expected clearing the password to open the room")
+	}
+}
+
+func TestJoinTokensAreOneTime(t *testing.T) {
+	room := &Room{Code: "PRIV1"}
+	room.SetJoinPassword("swordfish")
+
+	token := room.IssueJoinToken()
+	if !room.HasJoinToken(token) || room.HasJoinToken("") || room.HasJoinToken("forged") {
+		t.Fatal("expected only the issued token to be live")
+	}
+	if !room.UseJoinToken(token) || room.UseJoinToken(token) {
+		t.Error("expected the token to let exactly one player in")
+	}
+	if room.UsedJoinTokens() != 1 {
+		t.Errorf("expected one spent token counted, got %d", room.UsedJoinTokens())
+	}
+
+	first := room.IssueJoinToken()
+	for i := 0; i < maxJoinTokens; i++ {
+		room.IssueJoinToken()
+	}
+	if room.HasJoinToken(first) || len(room.JoinTokens) != maxJoinTokens {
+		t.Errorf("expected the oldest unused token dropped past %d, got %d", maxJoinTokens, len(room.JoinTokens))
+	}
+
+	room.SetJoinPassword("marlin")
+	if len(room.JoinTokens) != 0 {
+		t.Error("expected a new password to void the tokens out there")
+	}
+}
+
+func TestJoinPasswordSurvivesABackup(t *testing.T) {
+	service, _ := NewBackupService(testEncryptionKey(), true)
+	room := &Room{Code: "PRIV1", State: StateLobby, Players: map[string]*Player{}}
+	room.SetJoinPassword("swordfish")
+	room.IssueJoinToken()
+
+	backup, err := service.CreateBackup(room)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := service.RestoreBackup(backup, room.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.JoinPasswordMatches("") || !restored.JoinPasswordMatches("swordfish") {
+		t.Error("expected the restored room still private")
+	}
+	if len(restored.JoinTokens) != 0 {
+		t.Error("expected QR join tokens left out of the backup")
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+import "errors"
+
+var (
+	ErrRemoveTarget = errors.New("only another player seated in the lobby can be removed")
+	ErrBanned       = errors.New("you were removed from this room")
+)
+
+// RemovalTarget returns the seated player playerID for the Room Operator to
+// remove from the lobby: anyone but the operator themselves
+func (r *Room) RemovalTarget(playerID string) (*Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.State != StateLobby {
+		return nil, ErrGameAlreadyStarted
+	}
+	target := r.Players[playerID]
+	if target == nil || (target.SessionID != "" && target.SessionID == r.OperatorSessionID) {
+		return nil, ErrRemoveTarget
+	}
+	return target, nil
+}
+
+// Ban keeps sessionID from joining the room again
+func (r *Room) Ban(sessionID string) {
+	if sessionID == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.BannedSessions == nil {
+		r.BannedSessions = make(map[string]bool)
+	}
+	r.BannedSessions[sessionID] = true
+}
+
+// IsBanned reports whether the Room Operator banned sessionID from the room
+func (r *Room) IsBanned(sessionID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.BannedSessions[sessionID]
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRemovalTargetAndBan(t *testing.T) {
+	room := &Room{State: StateLobby, Players: map[string]*Player{}, MaxPlayers: 8}
+	room.AddPlayer(NewPlayer("op", "Operator", "operator-session"))
+	room.AddPlayer(NewPlayer("p1", "Alice", "s1"))
+	room.OperatorSessionID = "operator-session"
+
+	if _, err := room.RemovalTarget("op"); !errors.Is(err, ErrRemoveTarget) {
+		t.Errorf("expected the Room Operator not to be removable, got %v", err)
+	}
+	if _, err := room.RemovalTarget("nobody"); !errors.Is(err, ErrRemoveTarget) {
+		t.Errorf("expected an unknown player refused, got %v", err)
+	}
+	if target, err := room.RemovalTarget("p1"); err != nil || target.Name != "Alice" {
+		t.Fatalf("expected Alice removable, got %v", err)
+	}
+
+	room.Ban("s1")
+	room.Ban("")
+	if !room.IsBanned("s1") || room.IsBanned("") || room.IsBanned("operator-session") {
+		t.Error("expected only Alice's session banned")
+	}
+
+	room.State = StatePlaying
+	if _, err := room.RemovalTarget("p1"); !errors.Is(err, ErrGameAlreadyStarted) {
+		t.Errorf("expected removal refused once the game started, got %v", err)
+	}
+}
+
+func TestBanSurvivesABackup(t *testing.T) {
+	service, _ := NewBackupService(testEncryptionKey(), true)
+	room := &Room{Code: "BAN01", State: StateLobby, Players: map[string]*Player{}, MaxPlayers: 8}
+	room.Ban("s1")
+
+	backup, err := service.CreateBackup(room)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := service.RestoreBackup(backup, room.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restored.IsBanned("s1") {
+		t.Error("expected the ban restored with the room")
+	}
+}
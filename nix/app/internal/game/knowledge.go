@@ -0,0 +1,79 @@
+package game
+
+import (
+	"sort"
+	"treacherest/internal/config"
+)
+
+// KnownInfo is one fact a player privately learned when roles were dealt.
+type KnownInfo struct {
+	PlayerID   string
+	PlayerName string
+	RoleType   RoleType
+}
+
+// ApplyRoleKnowledge computes each player's KnownInfo from the configured knowledge rules.
+// It must run after roles are assigned; any previously dealt knowledge is replaced.
+func ApplyRoleKnowledge(players []*Player, cfg *config.ServerConfig) {
+	for _, p := range players {
+		p.KnownInfo = nil
+	}
+	if cfg == nil || len(cfg.Roles.Knowledge) == 0 {
+		return
+	}
+
+	playersByRole := make(map[RoleType][]*Player)
+	for _, p := range players {
+		if p.IsHost || p.Role == nil {
+			continue
+		}
+		playersByRole[p.Role.GetRoleType()] = append(playersByRole[p.Role.GetRoleType()], p)
+	}
+	for roleType := range playersByRole {
+		sort.Slice(playersByRole[roleType], func(i, j int) bool {
+			return playersByRole[roleType][i].Name < playersByRole[roleType][j].Name
+		})
+	}
+
+	for _, rule := range cfg.Roles.Knowledge {
+		viewerRole, ok := knowledgeRoleType(cfg, rule.Viewer)
+		if !ok {
+			continue
+		}
+		seenRole, ok := knowledgeRoleType(cfg, rule.Sees)
+		if !ok {
+			continue
+		}
+
+		for _, viewer := range playersByRole[viewerRole] {
+			for _, seen := range playersByRole[seenRole] {
+				if seen.ID == viewer.ID || viewer.knows(seen.ID) {
+					continue
+				}
+				viewer.KnownInfo = append(viewer.KnownInfo, KnownInfo{
+					PlayerID:   seen.ID,
+					PlayerName: seen.Name,
+					RoleType:   seenRole,
+				})
+			}
+		}
+	}
+}
+
+// knowledgeRoleType resolves a configured role key (e.g. "guardian") to its RoleType.
+func knowledgeRoleType(cfg *config.ServerConfig, roleKey string) (RoleType, bool) {
+	def, ok := cfg.GetRoleDefinition(roleKey)
+	if !ok {
+		return "", false
+	}
+	return RoleType(def.Category), true
+}
+
+func (p *Player) knows(playerID string) bool {
+	for _, info := range p.KnownInfo {
+		if info.PlayerID == playerID {
+			return true
+		}
+	}
+	return false
+}
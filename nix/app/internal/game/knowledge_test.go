@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+	"treacherest/internal/config"
+)
+
+func knowledgeTestPlayers() []*Player {
+	mk := func(id, name, subtype string) *Player {
+		p := NewPlayer(id, name, "s-"+id)
+		p.Role = &Card{Name: name + " card", Types: CardTypes{Subtype: subtype}}
+		return p
+	}
+	return []*Player{
+		mk("l", "Lena", "Leader"),
+		mk("g1", "Gus", "Guardian"),
+		mk("g2", "Gia", "Guardian"),
+		mk("t", "Tom", "Traitor"),
+		mk("a", "Ann", "Assassin"),
+	}
+}
+
+func TestApplyRoleKnowledge(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Roles.Knowledge = []config.KnowledgeRule{
+		{Viewer: "guardian", Sees: "guardian"},
+		{Viewer: "traitor", Sees: "leader"},
+	}
+	players := knowledgeTestPlayers()
+	byID := make(map[string]*Player)
+	for _, p := range players {
+		byID[p.ID] = p
+	}
+
+	ApplyRoleKnowledge(players, cfg)
+
+	if got := byID["g1"].KnownInfo; len(got) != 1 || got[0].PlayerID != "g2" || got[0].RoleType != RoleGuardian {
+		t.Errorf("Gus should know only Gia as a Guardian, got %+v", got)
+	}
+	if got := byID["g2"].KnownInfo; len(got) != 1 || got[0].PlayerID != "g1" {
+		t.Errorf("Gia should know only Gus, got %+v", got)
+	}
+	if got := byID["t"].KnownInfo; len(got) != 1 || got[0].PlayerID != "l" || got[0].RoleType != RoleLeader {
+		t.Errorf("Traitor should know the Leader, got %+v", got)
+	}
+	for _, id := range []string{"l", "a"} {
+		if len(byID[id].KnownInfo) != 0 {
+			t.Errorf("%s should not learn anything, got %+v", id, byID[id].KnownInfo)
+		}
+	}
+}
+
+func TestApplyRoleKnowledge_NoRulesClearsKnowledge(t *testing.T) {
+	players := knowledgeTestPlayers()
+	players[0].KnownInfo = []KnownInfo{{PlayerID: "stale"}}
+
+	ApplyRoleKnowledge(players, config.DefaultConfig())
+
+	for _, p := range players {
+		if len(p.KnownInfo) != 0 {
+			t.Errorf("expected no knowledge without rules, %s has %+v", p.Name, p.KnownInfo)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package game
+
+import "fmt"
+
+// LeaderPolicy is the single rule for how many Leaders a room's role
+// configuration may hold. A room needs at least one Leader unless leaderless
+// games are enabled, and at most MaxCount, which comes from the Leader role
+// definitions: more than one only when they allow a multi-leader game.
+type LeaderPolicy struct {
+	Leaderless bool // AllowLeaderlessGame: zero Leaders is allowed
+	MinCount   int  // Leaders the definitions require; at least 1 unless leaderless
+	MaxCount   int  // most Leaders allowed; 0 when unbounded
+}
+
+// NewLeaderPolicy builds the policy for config from the Leader bounds of the
+// role definitions, e.g. RoleConfigService.RoleCountBounds(config, "Leader")
+func NewLeaderPolicy(config *RoleConfiguration, bounds RoleCountBounds) LeaderPolicy {
+	return LeaderPolicy{
+		Leaderless: config != nil && config.AllowLeaderlessGame,
+		MinCount:   bounds.Min,
+		MaxCount:   bounds.Max,
+	}
+}
+
+// LeaderPolicy returns the leader policy for config under this service's role definitions
+func (s *RoleConfigService) LeaderPolicy(config *RoleConfiguration) LeaderPolicy {
+	return NewLeaderPolicy(config, s.RoleCountBounds(config, "Leader"))
+}
+
+// LeaderCount returns the configured number of Leaders
+func (c *RoleConfiguration) LeaderCount() int {
+	if c == nil {
+		return 0
+	}
+	if leader, ok := c.RoleTypes["Leader"]; ok && leader != nil {
+		return leader.Count
+	}
+	return 0
+}
+
+// Min returns the fewest Leaders allowed
+func (p LeaderPolicy) Min() int {
+	if p.Leaderless {
+		return 0
+	}
+	if p.MinCount > 1 {
+		return p.MinCount
+	}
+	return 1
+}
+
+// MultiLeader reports whether more than one Leader may be configured
+func (p LeaderPolicy) MultiLeader() bool {
+	return p.MaxCount != 1
+}
+
+// Missing reports whether count is below the required minimum
+func (p LeaderPolicy) Missing(count int) bool {
+	return count < p.Min()
+}
+
+// TooMany reports whether count exceeds the allowed maximum
+func (p LeaderPolicy) TooMany(count int) bool {
+	return p.MaxCount > 0 && count > p.MaxCount
+}
+
+// RunsLeaderless reports whether a game with count Leaders runs without a
+// revealed Leader, so every role stays hidden
+func (p LeaderPolicy) RunsLeaderless(count int) bool {
+	return p.Leaderless && count == 0
+}
+
+// Check returns an error when count breaks the policy
+func (p LeaderPolicy) Check(count int) error {
+	if p.Missing(count) {
+		if p.Min() > 1 {
+			return fmt.Errorf("must have at least %d leaders, got %d", p.Min(), count)
+		}
+		return fmt.Errorf("must have a leader role")
+	}
+	if p.TooMany(count) {
+		noun := "leader"
+		if p.MaxCount != 1 {
+			noun = "leaders"
+		}
+		return fmt.Errorf("cannot have more than %d %s, got %d", p.MaxCount, noun, count)
+	}
+	return nil
+}
+
+// Normalize clamps count into the allowed range
+func (p LeaderPolicy) Normalize(count int) int {
+	if count < p.Min() {
+		return p.Min()
+	}
+	if p.TooMany(count) {
+		return p.MaxCount
+	}
+	return count
+}
+
+// Rebalances reports whether automatic rebalancing may adjust a Leader count
+// of count. Only multi-leader games with more than one Leader take part, so
+// rebalancing never adds the first extra Leader or removes the last one.
+func (p LeaderPolicy) Rebalances(count int) bool {
+	return p.MultiLeader() && count > 1
+}
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"treacherest/internal/config"
+)
+
+func TestLeaderPolicy(t *testing.T) {
+	single := LeaderPolicy{MinCount: 1, MaxCount: 1}
+	singleLeaderless := LeaderPolicy{Leaderless: true, MinCount: 1, MaxCount: 1}
+	multi := LeaderPolicy{MinCount: 1, MaxCount: 3}
+	multiLeaderless := LeaderPolicy{Leaderless: true, MinCount: 1, MaxCount: 3}
+	unbounded := LeaderPolicy{}
+
+	tests := []struct {
+		name           string
+		policy         LeaderPolicy
+		count          int
+		wantErr        string
+		runsLeaderless bool
+		normalized     int
+		rebalances     bool
+	}{
+		{"single leader, none configured", single, 0, "must have a leader role", false, 1, false},
+		{"single leader, one configured", single, 1, "", false, 1, false},
+		{"single leader, two configured", single, 2, "cannot have more than 1 leader, got 2", false, 1, false},
+		{"leaderless, none configured", singleLeaderless, 0, "", true, 0, false},
+		{"leaderless, one configured", singleLeaderless, 1, "", false, 1, false},
+		{"leaderless, two configured", singleLeaderless, 2, "cannot have more than 1 leader, got 2", false, 1, false},
+		{"multi-leader, none configured", multi, 0, "must have a leader role", false, 1, false},
+		{"multi-leader, one configured", multi, 1, "", false, 1, false},
+		{"multi-leader, two configured", multi, 2, "", false, 2, true},
+		{"multi-leader, four configured", multi, 4, "cannot have more than 3 leaders, got 4", false, 3, true},
+		{"multi-leader leaderless, none configured", multiLeaderless, 0, "", true, 0, false},
+		{"multi-leader leaderless, three configured", multiLeaderless, 3, "", false, 3, true},
+		{"unbounded, none configured", unbounded, 0, "must have a leader role", false, 1, false},
+		{"unbounded, five configured", unbounded, 5, "", false, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.count)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.runsLeaderless, tt.policy.RunsLeaderless(tt.count))
+			assert.Equal(t, tt.normalized, tt.policy.Normalize(tt.count))
+			assert.Equal(t, tt.rebalances, tt.policy.Rebalances(tt.count))
+		})
+	}
+}
+
+func TestLeaderPolicy_DefinitionMinimum(t *testing.T) {
+	policy := LeaderPolicy{MinCount: 2, MaxCount: 3}
+	assert.Equal(t, 2, policy.Min())
+	assert.EqualError(t, policy.Check(1), "must have at least 2 leaders, got 1")
+	assert.Equal(t, 2, policy.Normalize(0))
+
+	policy.Leaderless = true
+	assert.Equal(t, 0, policy.Min())
+	assert.NoError(t, policy.Check(0))
+}
+
+func TestRoleConfigService_LeaderPolicy(t *testing.T) {
+	cfg := config.DefaultConfig()
+	service := NewRoleConfigService(cfg)
+
+	policy := service.LeaderPolicy(&RoleConfiguration{})
+	assert.Equal(t, LeaderPolicy{MinCount: 1, MaxCount: 1}, policy)
+	assert.False(t, policy.MultiLeader())
+
+	policy = service.LeaderPolicy(&RoleConfiguration{AllowLeaderlessGame: true})
+	assert.True(t, policy.Leaderless)
+	assert.Equal(t, 0, policy.Min())
+
+	leader := cfg.Roles.Available["leader"]
+	leader.MaxCount = 2
+	cfg.Roles.Available["leader"] = leader
+	policy = service.LeaderPolicy(&RoleConfiguration{})
+	assert.True(t, policy.MultiLeader())
+	assert.NoError(t, policy.Check(2))
+}
@@ -0,0 +1,28 @@
+package game
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// MaxNotesLength bounds a player's private scratchpad, in characters.
+const MaxNotesLength = 4000
+
+var ErrNotesTooLong = errors.New("notes are too long")
+
+// SetPlayerNotes replaces a player's private scratchpad.
+func (r *Room) SetPlayerNotes(playerID, notes string) error {
+	if utf8.RuneCountInString(notes) > MaxNotesLength {
+		return ErrNotesTooLong
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	player, ok := r.Players[playerID]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	player.Notes = notes
+	return nil
+}
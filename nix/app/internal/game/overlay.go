@@ -0,0 +1,36 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// newPublicToken generates an unguessable token for cookie-less, read-only room access.
+func newPublicToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// EnsureOverlayToken returns the room's streaming overlay token, creating it on first use.
+func (r *Room) EnsureOverlayToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.OverlayToken == "" {
+		r.OverlayToken = newPublicToken()
+	}
+	return r.OverlayToken
+}
+
+// IsOverlayToken reports whether token grants read-only overlay access to the room.
+func (r *Room) IsOverlayToken(token string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.OverlayToken == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(r.OverlayToken), []byte(token)) == 1
+}
@@ -0,0 +1,25 @@
+package game
+
+import "testing"
+
+func TestRoom_OverlayToken(t *testing.T) {
+	room := &Room{Code: "OVR01", Players: make(map[string]*Player)}
+
+	if room.IsOverlayToken("") {
+		t.Fatal("empty token should never grant overlay access")
+	}
+
+	token := room.EnsureOverlayToken()
+	if len(token) != 32 {
+		t.Fatalf("expected 32 hex chars, got %q", token)
+	}
+	if again := room.EnsureOverlayToken(); again != token {
+		t.Fatalf("EnsureOverlayToken should be stable, got %q then %q", token, again)
+	}
+	if !room.IsOverlayToken(token) {
+		t.Error("issued token should grant overlay access")
+	}
+	if room.IsOverlayToken(token[:31] + "x") {
+		t.Error("altered token should not grant overlay access")
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Phase is one half of a day/night cycle used by extended variants.
+type Phase string
+
+const (
+	PhaseDay   Phase = "day"   // Discussion: table talk, votes and eliminations
+	PhaseNight Phase = "night" // Actions: reveals and role abilities
+)
+
+// PhaseAction is a category of player action that a phase may allow.
+type PhaseAction string
+
+const (
+	PhaseActionReveal    PhaseAction = "reveal"
+	PhaseActionAbility   PhaseAction = "ability"
+	PhaseActionEliminate PhaseAction = "eliminate"
+	PhaseActionVote      PhaseAction = "vote"
+)
+
+var (
+	ErrPhasesDisabled = errors.New("day/night phases are not enabled for this room")
+	ErrPhaseForbidden = errors.New("that action is not allowed during the current phase")
+)
+
+// phaseAllowedActions lists what each phase permits when phases are enabled.
+var phaseAllowedActions = map[Phase]map[PhaseAction]bool{
+	PhaseDay: {
+		PhaseActionEliminate: true,
+		PhaseActionVote:      true,
+	},
+	PhaseNight: {
+		PhaseActionReveal:  true,
+		PhaseActionAbility: true,
+	},
+}
+
+// PhaseSettings is the pre-start configuration for the optional phase engine.
+type PhaseSettings struct {
+	Enabled  bool
+	Duration time.Duration // Length of each phase; 0 means the Room Operator advances manually
+}
+
+// PhaseState tracks the live phase once a phased game is playing.
+type PhaseState struct {
+	Current   Phase
+	Round     int
+	StartedAt time.Time
+}
+
+// Label renders the phase for display, e.g. "Day 2".
+func (p PhaseState) Label() string {
+	if p.Current == PhaseNight {
+		return fmt.Sprintf("Night %d", p.Round)
+	}
+	return fmt.Sprintf("Day %d", p.Round)
+}
+
+// StartPhases begins the first day if the room has phases enabled.
+func (r *Room) StartPhases(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.PhaseSettings.Enabled {
+		r.Phase = nil
+		return
+	}
+	r.Phase = &PhaseState{Current: PhaseDay, Round: 1, StartedAt: now}
+}
+
+// AdvancePhase moves day to night, or night to the next day.
+func (r *Room) AdvancePhase(now time.Time) (PhaseState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Phase == nil {
+		return PhaseState{}, ErrPhasesDisabled
+	}
+
+	next := PhaseState{Current: PhaseNight, Round: r.Phase.Round, StartedAt: now}
+	if r.Phase.Current == PhaseNight {
+		next = PhaseState{Current: PhaseDay, Round: r.Phase.Round + 1, StartedAt: now}
+	}
+	r.Phase = &next
+	return next, nil
+}
+
+// CurrentPhase returns the live phase, if the room is running phases.
+func (r *Room) CurrentPhase() (PhaseState, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.Phase == nil {
+		return PhaseState{}, false
+	}
+	return *r.Phase, true
+}
+
+// PhaseAllows reports whether action is permitted right now.
+// Rooms without phases allow everything.
+func (r *Room) PhaseAllows(action PhaseAction) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.Phase == nil {
+		return true
+	}
+	return phaseAllowedActions[r.Phase.Current][action]
+}
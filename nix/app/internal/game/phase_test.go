@@ -0,0 +1,60 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoom_PhaseCycle(t *testing.T) {
+	room := &Room{Code: "PHASE", Players: make(map[string]*Player)}
+	now := time.Now()
+
+	room.StartPhases(now)
+	if _, ok := room.CurrentPhase(); ok {
+		t.Fatal("phases should stay off unless enabled")
+	}
+	if !room.PhaseAllows(PhaseActionReveal) || !room.PhaseAllows(PhaseActionEliminate) {
+		t.Fatal("rooms without phases should allow every action")
+	}
+	if _, err := room.AdvancePhase(now); err != ErrPhasesDisabled {
+		t.Fatalf("expected ErrPhasesDisabled, got %v", err)
+	}
+
+	room.PhaseSettings.Enabled = true
+	room.StartPhases(now)
+
+	expected := []string{"Day 1", "Night 1", "Day 2", "Night 2"}
+	phase, _ := room.CurrentPhase()
+	if phase.Label() != expected[0] {
+		t.Fatalf("expected %s, got %s", expected[0], phase.Label())
+	}
+	for _, want := range expected[1:] {
+		next, err := room.AdvancePhase(now)
+		if err != nil {
+			t.Fatalf("advance: %v", err)
+		}
+		if next.Label() != want {
+			t.Fatalf("expected %s, got %s", want, next.Label())
+		}
+	}
+}
+
+func TestRoom_PhaseAllowedActions(t *testing.T) {
+	room := &Room{Code: "PHASE", Players: make(map[string]*Player), PhaseSettings: PhaseSettings{Enabled: true}}
+	room.StartPhases(time.Now())
+
+	if room.PhaseAllows(PhaseActionAbility) {
+		t.Error("abilities should wait for night")
+	}
+	if !room.PhaseAllows(PhaseActionEliminate) || !room.PhaseAllows(PhaseActionVote) {
+		t.Error("day should allow eliminations and votes")
+	}
+
+	room.AdvancePhase(time.Now())
+	if !room.PhaseAllows(PhaseActionAbility) || !room.PhaseAllows(PhaseActionReveal) {
+		t.Error("night should allow abilities and reveals")
+	}
+	if room.PhaseAllows(PhaseActionVote) {
+		t.Error("night should not allow votes")
+	}
+}
@@ -13,6 +13,7 @@ type Player struct {
 	RoleRevealed bool
 	JoinedAt     time.Time
 	SessionID    string // Used for reconnection
+	SeatPINHash  string // Hash of the optional PIN that guards moving this seat to another session
 	IsHost       bool   // Indicates if the player is the host who created the room but doesn't participate
 	IsDebug      bool   // Indicates a synthetic Debug Mode player seat
 
@@ -23,6 +24,15 @@ type Player struct {
 	// Elimination
 	IsEliminated bool      // Player has been eliminated from the game
 	EliminatedAt time.Time // When elimination occurred
+
+	// Private ally knowledge dealt with the role (see ApplyRoleKnowledge)
+	KnownInfo []KnownInfo
+
+	// Private scratchpad, only ever rendered for this player
+	Notes string
+
+	// How this player's page shows the countdown (see CountdownPresentation)
+	CountdownStyle CountdownStyle
 }
 
 // NewPlayer creates a new player
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"errors"
+	"sort"
+
+	"treacherest/internal/config"
+)
+
+var (
+	ErrNoOpenPoll      = errors.New("there is no open poll")
+	ErrPollAlreadyOpen = errors.New("a poll is already open")
+	ErrPollUndecided   = errors.New("the poll has no single winner yet")
+)
+
+// PresetPollOptions lists the presets a lobby poll can choose between for the room's rules mode.
+func PresetPollOptions(room *Room, cfg *config.ServerConfig) []VoteOption {
+	if room.RulesMode == RulesModeCoup {
+		presets := CoupPresetOptions()
+		options := make([]VoteOption, 0, len(presets))
+		for _, preset := range presets {
+			options = append(options, VoteOption{ID: string(preset), Label: CoupPresetLabel(preset)})
+		}
+		return options
+	}
+
+	if cfg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Roles.Presets))
+	for name := range cfg.Roles.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	options := make([]VoteOption, 0, len(names))
+	for _, name := range names {
+		label := cfg.Roles.Presets[name].Name
+		if label == "" {
+			label = name
+		}
+		options = append(options, VoteOption{ID: name, Label: label})
+	}
+	return options
+}
+
+// OpenPoll starts an anonymous lobby poll. Tallies are always live; voters are never shown.
+func (r *Room) OpenPoll(question string, options []VoteOption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Poll != nil {
+		return ErrPollAlreadyOpen
+	}
+	poll, err := NewVote(question, options, true)
+	if err != nil {
+		return err
+	}
+	r.Poll = poll
+	return nil
+}
+
+// CastPollVote records a ballot in the room's open poll.
+func (r *Room) CastPollVote(voterID, optionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Poll == nil {
+		return ErrNoOpenPoll
+	}
+	return r.Poll.Cast(voterID, optionID)
+}
+
+// PollWinner returns the leading option when exactly one option leads the poll.
+func (r *Room) PollWinner() (VoteOption, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.Poll == nil {
+		return VoteOption{}, ErrNoOpenPoll
+	}
+	result := r.Poll.Result()
+	if len(result.Winners) != 1 {
+		return VoteOption{}, ErrPollUndecided
+	}
+	return result.Winners[0], nil
+}
+
+// ClosePoll discards the room's open poll.
+func (r *Room) ClosePoll() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Poll == nil {
+		return ErrNoOpenPoll
+	}
+	r.Poll = nil
+	return nil
+}
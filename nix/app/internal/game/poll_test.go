@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"treacherest/internal/config"
+)
+
+func TestPresetPollOptionsFollowRulesMode(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Roles.Presets["chaos"] = config.Preset{}
+
+	room := &Room{Code: "POLL1", Players: make(map[string]*Player)}
+	options := PresetPollOptions(room, cfg)
+	if len(options) != 2 || options[0].ID != "chaos" || options[1].Label != "Standard" {
+		t.Fatalf("unexpected Treachery options %+v", options)
+	}
+
+	room.RulesMode = RulesModeCoup
+	options = PresetPollOptions(room, cfg)
+	if len(options) != len(CoupPresetOptions()) {
+		t.Fatalf("expected one option per Coup preset, got %+v", options)
+	}
+}
+
+func TestRoomPollWinner(t *testing.T) {
+	room := &Room{Code: "POLL2", Players: make(map[string]*Player)}
+	if _, err := room.PollWinner(); !errors.Is(err, ErrNoOpenPoll) {
+		t.Fatalf("expected ErrNoOpenPoll, got %v", err)
+	}
+
+	options := []VoteOption{{ID: "standard", Label: "Standard"}, {ID: "chaos", Label: "Chaos"}}
+	if err := room.OpenPoll("Which preset tonight?", options); err != nil {
+		t.Fatalf("open poll: %v", err)
+	}
+	if !room.Poll.PublicTally {
+		t.Fatal("poll tallies should always be live")
+	}
+	if _, err := room.PollWinner(); !errors.Is(err, ErrPollUndecided) {
+		t.Fatalf("expected ErrPollUndecided without ballots, got %v", err)
+	}
+
+	room.CastPollVote("p1", "chaos")
+	winner, err := room.PollWinner()
+	if err != nil || winner.ID != "chaos" {
+		t.Fatalf("expected chaos to win, got %+v, %v", winner, err)
+	}
+	if err := room.ClosePoll(); err != nil || room.Poll != nil {
+		t.Fatalf("expected the poll to close, got %v", err)
+	}
+}
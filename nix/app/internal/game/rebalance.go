@@ -0,0 +1,251 @@
+package game
+
+// Rebalance strategy names, as stored in RoleConfiguration.RebalanceStrategy
+const (
+	RebalanceEvenSpread    = "even-spread"
+	RebalancePreserveRatio = "preserve-ratio"
+	RebalanceGuardianFirst = "guardian-first"
+)
+
+// rebalanceOrder lists roles in the order that breaks rebalancing ties:
+// Guardians, Assassins and Traitors, then any types the config adds,
+// alphabetically, and Leaders last
+func rebalanceOrder(counts map[string]int) []string {
+	types := make([]RoleType, 0, len(counts))
+	for role := range counts {
+		types = append(types, RoleType(role))
+	}
+	roles := make([]string, 0, len(types))
+	leader := false
+	for _, roleType := range OrderRoleTypes(types) {
+		if roleType == RoleLeader {
+			leader = true
+			continue
+		}
+		roles = append(roles, string(roleType))
+	}
+	if leader {
+		roles = append(roles, string(RoleLeader))
+	}
+	return roles
+}
+
+// RebalanceStrategy decides which roles a custom role configuration gains or
+// loses when the Room Operator changes the game size
+type RebalanceStrategy interface {
+	// Name is the identifier stored on the room
+	Name() string
+	// Label is the name shown to the Room Operator
+	Label() string
+	// Rebalance returns counts adjusted one role at a time toward target,
+	// staying within limits. Only role types present in counts change, and the
+	// result sums to target whenever limits allow it.
+	Rebalance(counts map[string]int, target int, limits RebalanceLimits) map[string]int
+}
+
+// RebalanceLimits are the hard limits a rebalance must respect
+type RebalanceLimits struct {
+	Bounds  map[string]RoleCountBounds // per role type; a missing type is unbounded
+	Leaders LeaderPolicy
+}
+
+// canAdd reports whether role may go from count to count+1
+func (l RebalanceLimits) canAdd(role string, count int) bool {
+	if !l.Bounds[role].CanIncrement(count) {
+		return false
+	}
+	if role == "Leader" {
+		return l.Leaders.Rebalances(count) && !l.Leaders.TooMany(count+1)
+	}
+	return true
+}
+
+// canRemove reports whether role may go from count to count-1
+func (l RebalanceLimits) canRemove(role string, count int) bool {
+	if count <= 0 || !l.Bounds[role].CanDecrement(count) {
+		return false
+	}
+	if role == "Leader" {
+		return l.Leaders.Rebalances(count)
+	}
+	return true
+}
+
+// RebalanceStrategies returns every strategy in display order
+func RebalanceStrategies() []RebalanceStrategy {
+	return []RebalanceStrategy{evenSpread{}, preserveRatio{}, guardianFirst{}}
+}
+
+// RebalanceStrategyByName returns the strategy called name
+func RebalanceStrategyByName(name string) (RebalanceStrategy, bool) {
+	for _, strategy := range RebalanceStrategies() {
+		if strategy.Name() == name {
+			return strategy, true
+		}
+	}
+	return nil, false
+}
+
+// rebalancePicker chooses the role to add to (add) or remove from among
+// candidates, all of which the limits allow
+type rebalancePicker func(counts map[string]int, candidates []string, add bool) string
+
+// stepRebalance moves counts toward target one role at a time using pick.
+// Removing the last Assassin is avoided while any other role can shrink.
+func stepRebalance(counts map[string]int, target int, limits RebalanceLimits, pick rebalancePicker) map[string]int {
+	result := make(map[string]int, len(counts))
+	total := 0
+	for role, count := range counts {
+		result[role] = count
+		total += count
+	}
+
+	order := rebalanceOrder(result)
+	for total != target {
+		add := total < target
+		var candidates, preferred []string
+		for _, role := range order {
+			count := result[role]
+			if add && limits.canAdd(role, count) || !add && limits.canRemove(role, count) {
+				candidates = append(candidates, role)
+				if add || role != "Assassin" || count > 1 {
+					preferred = append(preferred, role)
+				}
+			}
+		}
+		if len(preferred) > 0 {
+			candidates = preferred
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		role := pick(result, candidates, add)
+		if add {
+			result[role]++
+			total++
+		} else {
+			result[role]--
+			total--
+		}
+	}
+	return result
+}
+
+// evenSpread grows the smallest role and shrinks the largest, keeping the
+// counts as level as possible
+type evenSpread struct{}
+
+func (evenSpread) Name() string  { return RebalanceEvenSpread }
+func (evenSpread) Label() string { return "Even spread" }
+
+func (evenSpread) Rebalance(counts map[string]int, target int, limits RebalanceLimits) map[string]int {
+	return stepRebalance(counts, target, limits, pickEvenSpread)
+}
+
+// pickEvenSpread returns the candidate with the fewest (add) or most (remove)
+// roles, taking the first in rebalanceOrder on a tie
+func pickEvenSpread(counts map[string]int, candidates []string, add bool) string {
+	best := candidates[0]
+	for _, role := range candidates[1:] {
+		if add && counts[role] < counts[best] || !add && counts[role] > counts[best] {
+			best = role
+		}
+	}
+	return best
+}
+
+// preserveRatio keeps each role's share of the starting configuration
+type preserveRatio struct{}
+
+func (preserveRatio) Name() string  { return RebalancePreserveRatio }
+func (preserveRatio) Label() string { return "Preserve ratio" }
+
+func (preserveRatio) Rebalance(counts map[string]int, target int, limits RebalanceLimits) map[string]int {
+	start := make(map[string]int, len(counts))
+	startTotal := 0
+	for role, count := range counts {
+		start[role] = count
+		startTotal += count
+	}
+	if startTotal == 0 {
+		return stepRebalance(counts, target, limits, pickEvenSpread)
+	}
+
+	return stepRebalance(counts, target, limits, func(current map[string]int, candidates []string, add bool) string {
+		total := 0
+		for _, count := range current {
+			total += count
+		}
+		if add {
+			total++
+		} else {
+			total--
+		}
+
+		// Pick the role furthest below (add) or above (remove) its share
+		best, bestGap := "", 0.0
+		for _, role := range candidates {
+			share := float64(start[role]) * float64(total) / float64(startTotal)
+			gap := share - float64(current[role])
+			if !add {
+				gap = -gap
+			}
+			if best == "" || gap > bestGap {
+				best, bestGap = role, gap
+			}
+		}
+		return best
+	})
+}
+
+// guardianFirst puts every change on the Guardians while they have room, then
+// falls back to an even spread
+type guardianFirst struct{}
+
+func (guardianFirst) Name() string  { return RebalanceGuardianFirst }
+func (guardianFirst) Label() string { return "Guardians first" }
+
+func (guardianFirst) Rebalance(counts map[string]int, target int, limits RebalanceLimits) map[string]int {
+	return stepRebalance(counts, target, limits, func(current map[string]int, candidates []string, add bool) string {
+		for _, role := range candidates {
+			if role == "Guardian" {
+				return role
+			}
+		}
+		return pickEvenSpread(current, candidates, add)
+	})
+}
+
+// RebalanceLimits returns the limits rebalancing config must stay within
+func (s *RoleConfigService) RebalanceLimits(config *RoleConfiguration) RebalanceLimits {
+	limits := RebalanceLimits{
+		Bounds:  make(map[string]RoleCountBounds),
+		Leaders: s.LeaderPolicy(config),
+	}
+	limits.Bounds[string(RoleLeader)] = s.RoleCountBounds(config, string(RoleLeader))
+	for _, role := range s.nonLeaderCategories(config) {
+		limits.Bounds[role] = s.RoleCountBounds(config, role)
+	}
+	return limits
+}
+
+// Rebalance returns config's role counts adjusted to target by the room's
+// rebalance strategy. ok is false when the room keeps its explicit counts.
+func (s *RoleConfigService) Rebalance(config *RoleConfiguration, target int) (counts map[string]int, ok bool) {
+	if config == nil {
+		return nil, false
+	}
+	strategy, ok := RebalanceStrategyByName(config.RebalanceStrategy)
+	if !ok {
+		return nil, false
+	}
+
+	counts = make(map[string]int, len(config.RoleTypes))
+	for role, typeConfig := range config.RoleTypes {
+		if typeConfig != nil {
+			counts[role] = typeConfig.Count
+		}
+	}
+	return strategy.Rebalance(counts, target, s.RebalanceLimits(config)), true
+}
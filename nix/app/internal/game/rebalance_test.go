@@ -0,0 +1,117 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"treacherest/internal/config"
+)
+
+func rebalanceTotal(counts map[string]int) int {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+func TestRebalanceStrategies_SumToTarget(t *testing.T) {
+	service := NewRoleConfigService(config.DefaultConfig())
+	rng := rand.New(rand.NewSource(1))
+
+	for _, strategy := range RebalanceStrategies() {
+		for _, leaderless := range []bool{false, true} {
+			roleConfig := &RoleConfiguration{AllowLeaderlessGame: leaderless}
+			limits := service.RebalanceLimits(roleConfig)
+
+			for i := 0; i < 500; i++ {
+				counts := map[string]int{
+					"Leader":   1,
+					"Guardian": rng.Intn(6),
+					"Assassin": rng.Intn(6),
+					"Traitor":  rng.Intn(6),
+				}
+				if leaderless && rng.Intn(2) == 0 {
+					counts["Leader"] = 0
+				}
+				// Leaders never move, and the others each hold 0 to 10
+				target := counts["Leader"] + rng.Intn(31)
+
+				result := strategy.Rebalance(counts, target, limits)
+				if !assert.Equal(t, target, rebalanceTotal(result), "%s: %v to %d gave %v", strategy.Name(), counts, target, result) {
+					return
+				}
+				assert.Equal(t, counts["Leader"], result["Leader"], "%s must not change a single Leader", strategy.Name())
+				for role, count := range result {
+					bounds := limits.Bounds[role]
+					assert.True(t, count >= 0 && (bounds.Max == 0 || count <= bounds.Max), "%s: %s out of bounds in %v", strategy.Name(), role, result)
+				}
+			}
+		}
+	}
+}
+
+func TestRebalanceStrategies_Unbounded(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	limits := RebalanceLimits{Leaders: LeaderPolicy{MinCount: 1}}
+
+	for _, strategy := range RebalanceStrategies() {
+		for i := 0; i < 500; i++ {
+			counts := map[string]int{
+				"Leader":   1 + rng.Intn(3),
+				"Guardian": rng.Intn(10),
+				"Assassin": rng.Intn(10),
+				"Traitor":  rng.Intn(10),
+			}
+			target := 1 + rng.Intn(60)
+			result := strategy.Rebalance(counts, target, limits)
+			assert.Equal(t, target, rebalanceTotal(result), "%s: %v to %d gave %v", strategy.Name(), counts, target, result)
+			assert.GreaterOrEqual(t, result["Leader"], 1, "%s removed the last Leader", strategy.Name())
+		}
+	}
+}
+
+func TestRebalanceStrategies_Choices(t *testing.T) {
+	service := NewRoleConfigService(config.DefaultConfig())
+	limits := service.RebalanceLimits(&RoleConfiguration{})
+	counts := map[string]int{"Leader": 1, "Guardian": 4, "Assassin": 2, "Traitor": 1}
+
+	tests := []struct {
+		strategy string
+		target   int
+		want     map[string]int
+	}{
+		{RebalanceEvenSpread, 10, map[string]int{"Leader": 1, "Guardian": 4, "Assassin": 3, "Traitor": 2}},
+		{RebalanceEvenSpread, 6, map[string]int{"Leader": 1, "Guardian": 2, "Assassin": 2, "Traitor": 1}},
+		{RebalancePreserveRatio, 15, map[string]int{"Leader": 1, "Guardian": 8, "Assassin": 4, "Traitor": 2}},
+		{RebalanceGuardianFirst, 10, map[string]int{"Leader": 1, "Guardian": 6, "Assassin": 2, "Traitor": 1}},
+		{RebalanceGuardianFirst, 3, map[string]int{"Leader": 1, "Guardian": 0, "Assassin": 1, "Traitor": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			strategy, ok := RebalanceStrategyByName(tt.strategy)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, strategy.Rebalance(counts, tt.target, limits))
+		})
+	}
+}
+
+func TestRoleConfigService_Rebalance(t *testing.T) {
+	service := NewRoleConfigService(config.DefaultConfig())
+	roleConfig := &RoleConfiguration{
+		RoleTypes: map[string]*RoleTypeConfig{
+			"Leader":   {Count: 1},
+			"Guardian": {Count: 2},
+		},
+	}
+
+	_, ok := service.Rebalance(roleConfig, 5)
+	assert.False(t, ok, "rooms without a strategy keep their counts")
+
+	roleConfig.RebalanceStrategy = RebalanceEvenSpread
+	counts, ok := service.Rebalance(roleConfig, 5)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int{"Leader": 1, "Guardian": 4}, counts, "only configured role types change")
+}
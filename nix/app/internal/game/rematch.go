@@ -0,0 +1,38 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoGameToRestart is returned when a room that never got past the
+// countdown is asked for a rematch
+var ErrNoGameToRestart = errors.New("only a game in play or over can be restarted")
+
+// restart takes a room whose game is in play or over back to the lobby for
+// a rematch. Players keep their seats and the room keeps its setup; roles,
+// eliminations, notes and everything else the last game left behind are
+// cleared. The caller holds r.mu.
+func (r *Room) restart() error {
+	if r.State != StatePlaying && r.State != StateEnded {
+		return ErrNoGameToRestart
+	}
+	r.clearDeal()
+	r.DebugStartMode = DebugStartModeNone
+	r.CoupInquisition = nil
+	r.CoupKingFallen = false
+	r.CoupGreenEligibleBeforeKingFall = false
+	r.CoupWin = nil
+	r.Phase = nil
+	r.Vote = nil
+	r.LastVoteResult = nil
+	r.Log = nil
+	r.RoleImageLoads = nil
+	r.PreviousRoleConfig = nil
+	for _, player := range r.Players {
+		player.IsEliminated = false
+		player.EliminatedAt = time.Time{}
+		player.Notes = ""
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package game
+
+import "testing"
+
+func TestRoom_ApplyGameRestarted(t *testing.T) {
+	room := &Room{Code: "AGAIN", State: StateCountdown, Players: make(map[string]*Player), RoleConfig: &RoleConfiguration{PresetName: "standard"}}
+	alice := NewPlayer("p1", "Alice", "s1")
+	room.Players[alice.ID] = alice
+
+	if err := room.Apply(GameRestartedEvent()); err != ErrNoGameToRestart {
+		t.Fatalf("expected a countdown to refuse a restart, got %v", err)
+	}
+
+	room.State = StatePlaying
+	room.LeaderRevealed = true
+	room.Log = []LogEntry{{}}
+	alice.Role = &Card{Name: "Test Leader"}
+	alice.RoleRevealed = true
+	alice.Notes = "Bob is lying"
+	alice.MarkEliminated()
+	room.State = StateEnded
+
+	if err := room.Apply(GameRestartedEvent()); err != nil {
+		t.Fatalf("expected an ended game to restart, got %v", err)
+	}
+	if room.State != StateLobby || room.LeaderRevealed || room.Log != nil {
+		t.Fatalf("expected the room back in the lobby with the game cleared, got %s", room.State)
+	}
+	if room.Players["p1"] != alice || alice.Role != nil || alice.RoleRevealed || alice.IsEliminated || alice.Notes != "" {
+		t.Errorf("expected Alice seated with nothing left of the last game, got %+v", alice)
+	}
+	if room.RoleConfig == nil || room.RoleConfig.PresetName != "standard" {
+		t.Errorf("expected the setup kept, got %+v", room.RoleConfig)
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 	"treacherest"
+	"treacherest/internal/testhelpers"
 )
 
 func TestRoleAssignmentOrder(t *testing.T) {
 	// Create a card service
+	testhelpers.RequireCardAssets(t)
 	cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	require.NoError(t, err)
 
@@ -58,36 +58,14 @@ func (s *RoleConfigService) CreateFromPreset(presetName string, maxPlayers int)
 		}
 	}
 
-	// Enable all cards for each type
-	if s.cardService != nil {
-		for _, card := range s.cardService.Leaders {
-			if roleConfig.RoleTypes["Leader"] != nil {
-				roleConfig.RoleTypes["Leader"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Guardians {
-			if roleConfig.RoleTypes["Guardian"] != nil {
-				roleConfig.RoleTypes["Guardian"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Assassins {
-			if roleConfig.RoleTypes["Assassin"] != nil {
-				roleConfig.RoleTypes["Assassin"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Traitors {
-			if roleConfig.RoleTypes["Traitor"] != nil {
-				roleConfig.RoleTypes["Traitor"].EnabledCards[card.Name] = true
-			}
-		}
-	}
+	s.enableDefaultCards(roleConfig)
 
 	// Set counts based on the preset's closest distribution
 	if dist, exists := preset.Distributions[maxPlayers]; exists {
 		for role, count := range dist {
 			if roleDef, ok := s.config.Roles.Available[role]; ok {
 				if roleConfig.RoleTypes[roleDef.Category] != nil {
-					roleConfig.RoleTypes[roleDef.Category].Count = count
+					roleConfig.RoleTypes[roleDef.Category].Count += count
 				}
 			}
 		}
@@ -96,7 +74,22 @@ func (s *RoleConfigService) CreateFromPreset(presetName string, maxPlayers int)
 	return roleConfig, nil
 }
 
-// CreateDefaultConfiguration creates a new role configuration with all cards enabled
+// enableDefaultCards enables the cards of each of roleConfig's role types
+// that are in the default packs (server.cardPacks, every pack when unset)
+func (s *RoleConfigService) enableDefaultCards(roleConfig *RoleConfiguration) {
+	if s.cardService == nil {
+		return
+	}
+	for category, typeConfig := range roleConfig.RoleTypes {
+		for _, card := range s.cardService.CardsOfType(RoleType(category)) {
+			if card.InPacks(s.config.Server.CardPacks) {
+				typeConfig.EnabledCards[card.Name] = true
+			}
+		}
+	}
+}
+
+// CreateDefaultConfiguration creates a new role configuration with the default packs' cards enabled
 func (s *RoleConfigService) CreateDefaultConfiguration() *RoleConfiguration {
 	roleConfig := &RoleConfiguration{
 		PresetName: "custom",
@@ -115,29 +108,7 @@ func (s *RoleConfigService) CreateDefaultConfiguration() *RoleConfiguration {
 		}
 	}
 
-	// Enable all cards for each type
-	if s.cardService != nil {
-		for _, card := range s.cardService.Leaders {
-			if roleConfig.RoleTypes["Leader"] != nil {
-				roleConfig.RoleTypes["Leader"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Guardians {
-			if roleConfig.RoleTypes["Guardian"] != nil {
-				roleConfig.RoleTypes["Guardian"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Assassins {
-			if roleConfig.RoleTypes["Assassin"] != nil {
-				roleConfig.RoleTypes["Assassin"].EnabledCards[card.Name] = true
-			}
-		}
-		for _, card := range s.cardService.Traitors {
-			if roleConfig.RoleTypes["Traitor"] != nil {
-				roleConfig.RoleTypes["Traitor"].EnabledCards[card.Name] = true
-			}
-		}
-	}
+	s.enableDefaultCards(roleConfig)
 
 	return roleConfig
 }
@@ -151,72 +122,14 @@ func (s *RoleConfigService) GetDistributionForPlayerCount(config *RoleConfigurat
 			return nil, fmt.Errorf("preset '%s' not found", config.PresetName)
 		}
 
-		// Look for exact player count match
-		if dist, ok := preset.Distributions[playerCount]; ok {
-			// Convert string map to RoleType map
-			result := make(map[RoleType]int)
-			for role, count := range dist {
-				// Map lowercase role names to RoleType constants
-				switch role {
-				case "leader":
-					result[RoleLeader] = count
-				case "guardian":
-					result[RoleGuardian] = count
-				case "assassin":
-					result[RoleAssassin] = count
-				case "traitor":
-					result[RoleTraitor] = count
-				}
-			}
-			return result, nil
-		}
-
-		// If no exact match, find closest distribution
-		closestCount := 0
-		closestDiff := playerCount
-		for count := range preset.Distributions {
-			diff := abs(playerCount - count)
-			if diff < closestDiff {
-				closestDiff = diff
-				closestCount = count
-			}
-		}
-
-		if closestCount > 0 {
-			dist := preset.Distributions[closestCount]
-			result := make(map[RoleType]int)
-
-			// Start with the base distribution
-			totalRoles := 0
-			for role, count := range dist {
-				// Map lowercase role names to RoleType constants
-				switch role {
-				case "leader":
-					result[RoleLeader] = count
-				case "guardian":
-					result[RoleGuardian] = count
-				case "assassin":
-					result[RoleAssassin] = count
-				case "traitor":
-					result[RoleTraitor] = count
-				}
-				totalRoles += count
-			}
-
-			// Adjust for player count difference
-			if totalRoles < playerCount {
-				// Add more guardians to fill the gap
-				result[RoleGuardian] += playerCount - totalRoles
-			} else if totalRoles > playerCount {
-				// Remove roles starting with non-essential ones
-				// This is a simplified approach - in production, you'd want more sophisticated logic
-				for totalRoles > playerCount && result[RoleGuardian] > 1 {
-					result[RoleGuardian]--
-					totalRoles--
-				}
+		// Listed player counts come back as written; others are interpolated
+		// as the preset configures (see config.Preset.DistributionFor)
+		if len(preset.Distributions) > 0 {
+			dist, err := preset.DistributionFor(playerCount)
+			if err != nil {
+				return nil, err
 			}
-
-			return result, nil
+			return PresetRoleTypeCounts(s.config, dist), nil
 		}
 	}
 
@@ -224,17 +137,9 @@ func (s *RoleConfigService) GetDistributionForPlayerCount(config *RoleConfigurat
 	result := make(map[RoleType]int)
 	totalRoles := 0
 
-	// Map category names to RoleType constants
-	categoryToRoleType := map[string]RoleType{
-		"Leader":   RoleLeader,
-		"Guardian": RoleGuardian,
-		"Assassin": RoleAssassin,
-		"Traitor":  RoleTraitor,
-	}
-
 	for category, typeConfig := range config.RoleTypes {
-		if roleType, ok := categoryToRoleType[category]; ok && typeConfig.Count > 0 {
-			result[roleType] = typeConfig.Count
+		if typeConfig.Count > 0 {
+			result[RoleType(category)] = typeConfig.Count
 			totalRoles += typeConfig.Count
 		}
 	}
@@ -251,10 +156,10 @@ func (s *RoleConfigService) GetDistributionForPlayerCount(config *RoleConfigurat
 	}
 
 	// For presets, we still adjust to match player count
-	// Ensure we have at least one leader (unless leaderless games are allowed)
-	if result[RoleLeader] == 0 && !config.AllowLeaderlessGame {
-		result[RoleLeader] = 1
-		totalRoles++
+	// Ensure we have the leaders the leader policy requires
+	if leaders := s.LeaderPolicy(config).Normalize(result[RoleLeader]); leaders > result[RoleLeader] {
+		totalRoles += leaders - result[RoleLeader]
+		result[RoleLeader] = leaders
 	}
 
 	// Adjust for player count mismatch (only for presets)
@@ -270,14 +175,84 @@ func (s *RoleConfigService) GetDistributionForPlayerCount(config *RoleConfigurat
 	return result, nil
 }
 
+// RoleCountBounds is how many of a role type a room may configure. Max is 0
+// when the type has no upper limit.
+type RoleCountBounds struct {
+	Min int
+	Max int
+}
+
+// CanIncrement reports whether one more than count stays within the bounds
+func (b RoleCountBounds) CanIncrement(count int) bool {
+	return b.Max == 0 || count < b.Max
+}
+
+// CanDecrement reports whether one fewer than count stays within the bounds
+func (b RoleCountBounds) CanDecrement(count int) bool {
+	return count > 0 && count > b.Min
+}
+
+// RoleCountBounds adds up the MinCount and MaxCount of every role definition
+// in category. A definition without a MaxCount leaves the category unbounded,
+// and leaderless games drop the Leader minimum.
+func (s *RoleConfigService) RoleCountBounds(config *RoleConfiguration, category string) RoleCountBounds {
+	var bounds RoleCountBounds
+	unbounded := false
+	found := false
+	for _, def := range s.config.Roles.Available {
+		if def.Category != category {
+			continue
+		}
+		found = true
+		bounds.Min += def.MinCount
+		bounds.Max += def.MaxCount
+		if def.MaxCount == 0 {
+			unbounded = true
+		}
+	}
+	if !found || unbounded {
+		bounds.Max = 0
+	}
+	if category == "Leader" && config != nil && config.AllowLeaderlessGame {
+		bounds.Min = 0
+	}
+	return bounds
+}
+
+// nonLeaderCategories lists the role types other than Leader that config
+// has or the server's roles define, in deal order
+func (s *RoleConfigService) nonLeaderCategories(config *RoleConfiguration) []string {
+	seen := map[RoleType]bool{RoleLeader: true}
+	var types []RoleType
+	add := func(roleType RoleType) {
+		if roleType != "" && !seen[roleType] {
+			seen[roleType] = true
+			types = append(types, roleType)
+		}
+	}
+	for _, roleType := range standardRoleTypes {
+		add(roleType)
+	}
+	for _, def := range s.config.Roles.Available {
+		add(RoleType(def.Category))
+	}
+	for _, roleType := range ConfiguredRoleTypes(config) {
+		add(roleType)
+	}
+
+	categories := make([]string, 0, len(types))
+	for _, roleType := range OrderRoleTypes(types) {
+		categories = append(categories, string(roleType))
+	}
+	return categories
+}
+
 // ValidateConfiguration validates a role configuration
 func (s *RoleConfigService) ValidateConfiguration(config *RoleConfiguration) error {
 	if config == nil {
 		return fmt.Errorf("configuration is nil")
 	}
 
-	// Check that we have at least one leader
-	hasLeader := false
 	totalRoles := 0
 
 	for category, typeConfig := range config.RoleTypes {
@@ -299,17 +274,25 @@ func (s *RoleConfigService) ValidateConfiguration(config *RoleConfiguration) err
 		}
 
 		totalRoles += typeConfig.Count
+	}
 
-		if category == "Leader" {
-			hasLeader = typeConfig.Count > 0
-			if typeConfig.Count > 1 {
-				return fmt.Errorf("cannot have more than 1 leader, got %d", typeConfig.Count)
-			}
-		}
+	if err := s.LeaderPolicy(config).Check(config.LeaderCount()); err != nil {
+		return err
 	}
 
-	if !hasLeader && !config.AllowLeaderlessGame {
-		return fmt.Errorf("must have a leader role")
+	// Validate the other role types against their configured minimum and maximum
+	for _, category := range s.nonLeaderCategories(config) {
+		count := 0
+		if typeConfig, ok := config.RoleTypes[category]; ok {
+			count = typeConfig.Count
+		}
+		bounds := s.RoleCountBounds(config, category)
+		if count < bounds.Min {
+			return fmt.Errorf("%s: need at least %d, got %d", category, bounds.Min, count)
+		}
+		if bounds.Max > 0 && count > bounds.Max {
+			return fmt.Errorf("%s: at most %d allowed, got %d", category, bounds.Max, count)
+		}
 	}
 
 	// Validate player bounds
@@ -225,11 +225,11 @@ func TestRoleConfigService_GetDistributionForPlayerCount(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:        "player count outside all ranges uses closest",
+			name:        "player count outside all ranges scales the closest",
 			playerCount: 7,
 			wantRoles: map[RoleType]int{
 				RoleLeader:   1,
-				RoleGuardian: 4, // 2 + 2 extra to fill
+				RoleGuardian: 4, // the 6-player table scaled to 6 non-Leader seats
 				RoleAssassin: 1,
 				RoleTraitor:  1,
 			},
@@ -480,3 +480,35 @@ func TestRoleConfigService_CreateFromPreset_InitializesRoleCounts(t *testing.T)
 // 		t.Errorf("expected guardian count to be 1 (default), got %d", count)
 // 	}
 // }
+
+func TestRoleConfigService_RoleCountBounds(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Roles.Available["traitor"] = config.RoleDefinition{Category: "Traitor", MinCount: 1, MaxCount: 2}
+	service := NewRoleConfigService(cfg)
+
+	roleConfig := &RoleConfiguration{
+		MinPlayers: 1,
+		MaxPlayers: 5,
+		RoleTypes: map[string]*RoleTypeConfig{
+			"Leader":  {Count: 1, EnabledCards: map[string]bool{"L1": true}},
+			"Traitor": {Count: 3, EnabledCards: map[string]bool{"T1": true, "T2": true, "T3": true}},
+		},
+	}
+
+	if bounds := service.RoleCountBounds(roleConfig, "Traitor"); bounds != (RoleCountBounds{Min: 1, Max: 2}) {
+		t.Fatalf("unexpected Traitor bounds %+v", bounds)
+	}
+	if err := service.ValidateConfiguration(roleConfig); err == nil || err.Error() != "Traitor: at most 2 allowed, got 3" {
+		t.Errorf("expected the Traitor maximum to fail validation, got %v", err)
+	}
+
+	roleConfig.RoleTypes["Traitor"].Count = 0
+	if err := service.ValidateConfiguration(roleConfig); err == nil || err.Error() != "Traitor: need at least 1, got 0" {
+		t.Errorf("expected the Traitor minimum to fail validation, got %v", err)
+	}
+
+	roleConfig.AllowLeaderlessGame = true
+	if bounds := service.RoleCountBounds(roleConfig, "Leader"); !bounds.CanDecrement(1) || bounds.CanIncrement(1) {
+		t.Errorf("expected a leaderless game to allow 0 or 1 Leaders, got %+v", bounds)
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"sort"
+	"strings"
+
+	"treacherest/internal/config"
+)
+
+// standardRoleTypes are the Treachery role types in deal order. Leaders come
+// first so a short deal never leaves the table without one.
+var standardRoleTypes = []RoleType{RoleLeader, RoleGuardian, RoleAssassin, RoleTraitor}
+
+// OrderRoleTypes sorts types into deal order: the standard Treachery types
+// first, then any types the config and card set add, alphabetically
+func OrderRoleTypes(types []RoleType) []RoleType {
+	rank := func(roleType RoleType) int {
+		for i, standard := range standardRoleTypes {
+			if roleType == standard {
+				return i
+			}
+		}
+		return len(standardRoleTypes)
+	}
+	ordered := append([]RoleType(nil), types...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, rj := rank(ordered[i]), rank(ordered[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return ordered[i] < ordered[j]
+	})
+	return ordered
+}
+
+// ConfiguredRoleTypes lists the role types a room configuration has, in deal order
+func ConfiguredRoleTypes(config *RoleConfiguration) []RoleType {
+	if config == nil {
+		return nil
+	}
+	types := make([]RoleType, 0, len(config.RoleTypes))
+	for name := range config.RoleTypes {
+		types = append(types, RoleType(name))
+	}
+	return OrderRoleTypes(types)
+}
+
+// PresetRoleTypeCounts turns a preset distribution, keyed by role, into
+// counts per role type; roles that share a type add up
+func PresetRoleTypeCounts(cfg *config.ServerConfig, dist map[string]int) map[RoleType]int {
+	counts := make(map[RoleType]int)
+	for role, count := range dist {
+		if roleType, ok := presetRoleType(cfg, role); ok {
+			counts[roleType] += count
+		}
+	}
+	return counts
+}
+
+// presetRoleType returns the role type a preset distribution's role key
+// deals: a standard type of the same name, e.g. "guardian", or else the
+// category of the role the key names in cfg.Roles.Available
+func presetRoleType(cfg *config.ServerConfig, roleKey string) (RoleType, bool) {
+	for _, roleType := range standardRoleTypes {
+		if strings.EqualFold(string(roleType), roleKey) {
+			return roleType, true
+		}
+	}
+	if def, ok := cfg.Roles.Available[roleKey]; ok && def.Category != "" {
+		return RoleType(def.Category), true
+	}
+	return "", false
+}
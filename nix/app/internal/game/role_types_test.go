@@ -0,0 +1,82 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"treacherest/internal/config"
+)
+
+func TestOrderRoleTypesPutsStandardTypesFirst(t *testing.T) {
+	got := OrderRoleTypes([]RoleType{"Jester", RoleTraitor, "Cultist", RoleLeader, RoleGuardian})
+	want := []RoleType{RoleLeader, RoleGuardian, RoleTraitor, "Cultist", "Jester"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+// A fifth role type needs only a card subtype and a role definition
+func TestCustomRoleTypeDealsFromConfigAndCards(t *testing.T) {
+	collection := CardCollection{Cards: []Card{
+		{ID: 1, Name: "The King", Types: CardTypes{Subtype: "Leader"}},
+		{ID: 2, Name: "The Knight", Types: CardTypes{Subtype: "Guardian"}},
+		{ID: 3, Name: "The Fool", Types: CardTypes{Subtype: "Jester"}},
+		{ID: 4, Name: "The Clown", Types: CardTypes{Subtype: "Jester"}},
+	}}
+	data, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := NewCardService(data, nil)
+	if err != nil {
+		t.Fatalf("load cards: %v", err)
+	}
+	if got := cs.RoleTypes(); len(got) != 3 || got[2] != "Jester" {
+		t.Fatalf("expected the Jester cards grouped after the standard types, got %v", got)
+	}
+
+	cfg := config.DefaultConfig()
+	delete(cfg.Roles.Available, "assassin")
+	delete(cfg.Roles.Available, "traitor")
+	cfg.Roles.Available["jester"] = config.RoleDefinition{DisplayName: "Jester", Category: "Jester", MinCount: 1, MaxCount: 1}
+	cfg.Roles.Presets["fools"] = config.Preset{Name: "Fools", Distributions: map[int]map[string]int{
+		3: {"leader": 1, "guardian": 1, "jester": 1},
+	}}
+	if _, err := CheckCardCompatibility(cfg, cs); err != nil {
+		t.Fatalf("expected the Jester role to fit the cards, got %v", err)
+	}
+
+	service := NewRoleConfigService(cfg)
+	service.SetCardService(cs)
+	roleConfig, err := service.CreateFromPreset("fools", 3)
+	if err != nil {
+		t.Fatalf("create from preset: %v", err)
+	}
+	jester := roleConfig.RoleTypes["Jester"]
+	if jester == nil || jester.Count != 1 || !jester.EnabledCards["The Fool"] || !jester.EnabledCards["The Clown"] {
+		t.Fatalf("expected one Jester with both cards enabled, got %+v", jester)
+	}
+
+	players := []*Player{{ID: "p1", Name: "A"}, {ID: "p2", Name: "B"}, {ID: "p3", Name: "C"}}
+	AssignRolesWithConfig(players, cs, roleConfig, service)
+	dealt := make(map[RoleType]int)
+	for _, p := range players {
+		if p.Role == nil {
+			t.Fatalf("%s was dealt no role", p.Name)
+		}
+		dealt[p.Role.GetRoleType()]++
+	}
+	if dealt["Jester"] != 1 || dealt[RoleLeader] != 1 || dealt[RoleGuardian] != 1 {
+		t.Errorf("expected one Leader, Guardian and Jester, got %v", dealt)
+	}
+
+	roleConfig.PresetName = "custom"
+	roleConfig.MinPlayers = cfg.Server.MinPlayersPerRoom
+	roleConfig.MaxPlayers = 3
+	jester.Count = 2
+	if err := service.ValidateConfiguration(roleConfig); err == nil {
+		t.Error("expected the Jester's maxCount to be enforced")
+	}
+}
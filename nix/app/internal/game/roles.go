@@ -87,21 +87,8 @@ func AssignRolesWithConfig(players []*Player, cardService *CardService, roleConf
 	playerIndex := 0
 	usedCards := make(map[*Card]bool)
 
-	// Map for getting cards by type
-	categoryToCards := map[RoleType][]*Card{
-		RoleLeader:   cardService.Leaders,
-		RoleGuardian: cardService.Guardians,
-		RoleAssassin: cardService.Assassins,
-		RoleTraitor:  cardService.Traitors,
-	}
-
-	// Create ordered list of role types to ensure consistent assignment order
-	// Leaders should always be assigned first when not allowing leaderless games
-	roleOrder := []RoleType{RoleLeader, RoleGuardian, RoleAssassin, RoleTraitor}
-
-	// If allowing leaderless games, process in any order
-	// Otherwise, ensure leaders are assigned first
-	for _, roleType := range roleOrder {
+	// Deal in a consistent order, Leaders first (see OrderRoleTypes)
+	for _, roleType := range distributionOrder(roleDistribution) {
 		neededCount, exists := roleDistribution[roleType]
 		if !exists || neededCount == 0 {
 			continue
@@ -118,7 +105,7 @@ func AssignRolesWithConfig(players []*Player, cardService *CardService, roleConf
 
 		// Filter cards to only include enabled ones
 		availableCards := make([]*Card, 0)
-		for _, card := range categoryToCards[roleType] {
+		for _, card := range cardService.CardsOfType(roleType) {
 			if enabledCardNames == nil || enabledCardNames[card.Name] {
 				availableCards = append(availableCards, card)
 			}
@@ -374,6 +361,12 @@ func handleFullyRandomDistribution(shuffled []*Player, cardService *CardService,
 		RoleAssassin: 2, // Medium frequency
 		RoleTraitor:  1, // Less common
 	}
+	// Role types the config adds beyond these are as common as Traitors
+	for _, roleType := range ConfiguredRoleTypes(roleConfig) {
+		if _, standard := roleWeights[roleType]; !standard && len(cardService.CardsOfType(roleType)) > 0 {
+			roleWeights[roleType] = 1
+		}
+	}
 
 	// Build weighted pool
 	weightedPool := []RoleType{}
@@ -409,19 +402,8 @@ func handleFullyRandomDistribution(shuffled []*Player, cardService *CardService,
 
 // assignRolesFromDistribution is a helper that assigns roles based on a distribution map
 func assignRolesFromDistribution(shuffled []*Player, cardService *CardService, roleDistribution map[RoleType]int, roleConfig *RoleConfiguration) {
-	// Map role types to card categories
-	categoryToCards := map[RoleType][]*Card{
-		RoleLeader:   cardService.Leaders,
-		RoleGuardian: cardService.Guardians,
-		RoleAssassin: cardService.Assassins,
-		RoleTraitor:  cardService.Traitors,
-	}
-
-	// Create ordered list of role types
-	roleOrder := []RoleType{RoleLeader, RoleGuardian, RoleAssassin, RoleTraitor}
-
 	playerIndex := 0
-	for _, roleType := range roleOrder {
+	for _, roleType := range distributionOrder(roleDistribution) {
 		neededCount, exists := roleDistribution[roleType]
 		if !exists || neededCount == 0 {
 			continue
@@ -437,7 +419,7 @@ func assignRolesFromDistribution(shuffled []*Player, cardService *CardService, r
 
 		// Filter cards to only include enabled ones
 		availableCards := make([]*Card, 0)
-		for _, card := range categoryToCards[roleType] {
+		for _, card := range cardService.CardsOfType(roleType) {
 			if enabledCardNames == nil || enabledCardNames[card.Name] {
 				availableCards = append(availableCards, card)
 			}
@@ -445,7 +427,10 @@ func assignRolesFromDistribution(shuffled []*Player, cardService *CardService, r
 
 		// If no available cards for this role type, use all cards
 		if len(availableCards) == 0 {
-			availableCards = categoryToCards[roleType]
+			availableCards = cardService.CardsOfType(roleType)
+		}
+		if len(availableCards) == 0 {
+			continue
 		}
 
 		// Shuffle available cards
@@ -474,3 +459,12 @@ func assignRolesFromDistribution(shuffled []*Player, cardService *CardService, r
 		}
 	}
 }
+
+// distributionOrder lists the role types dist deals, in deal order
+func distributionOrder(dist map[RoleType]int) []RoleType {
+	types := make([]RoleType, 0, len(dist))
+	for roleType := range dist {
+		types = append(types, roleType)
+	}
+	return OrderRoleTypes(types)
+}
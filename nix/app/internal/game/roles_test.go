@@ -4,6 +4,7 @@ import (
 	"testing"
 	"treacherest"
 	"treacherest/internal/config"
+	"treacherest/internal/testhelpers"
 )
 
 func TestAssignRoles(t *testing.T) {
@@ -24,6 +25,7 @@ func TestAssignRoles(t *testing.T) {
 	}
 
 	// Create CardService for testing
+	testhelpers.RequireCardAssets(t)
 	cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -165,6 +167,7 @@ func TestGetRoleDistribution(t *testing.T) {
 
 func TestAssignRoles_NoDuplicateCards(t *testing.T) {
 	// Create CardService for testing
+	testhelpers.RequireCardAssets(t)
 	cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -205,6 +208,7 @@ func TestAssignRoles_NoDuplicateCards(t *testing.T) {
 
 func TestAssignRoles_CorrectRoleTypes(t *testing.T) {
 	// Create CardService for testing
+	testhelpers.RequireCardAssets(t)
 	cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -438,6 +442,7 @@ func TestHiddenDistribution(t *testing.T) {
 
 func TestAssignRoles_InitialFaceState(t *testing.T) {
 	// Create CardService for testing
+	testhelpers.RequireCardAssets(t)
 	cardService, err := NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create CardService: %v", err)
@@ -6,9 +6,16 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"treacherest/internal/config"
 	"treacherest/internal/game/ability"
 )
 
+// CardListView is the page of a role type's cards shown in the role config
+type CardListView struct {
+	Page  int    // 1-based
+	Query string // card search filter; empty lists every card
+}
+
 // GameState represents the current state of a game
 type GameState string
 
@@ -42,6 +49,7 @@ type RoleConfiguration struct {
 	AllowLeaderlessGame  bool                       `json:"allowLeaderlessGame"`  // Allow games without a leader role
 	HideRoleDistribution bool                       `json:"hideRoleDistribution"` // Hide role count distribution from players
 	FullyRandomRoles     bool                       `json:"fullyRandomRoles"`     // Completely randomize role distribution
+	RebalanceStrategy    string                     `json:"rebalanceStrategy"`    // How custom counts follow the game size; empty keeps them as set
 	RoleTypes            map[string]*RoleTypeConfig `json:"roleTypes"`            // Role type configurations
 }
 
@@ -55,11 +63,15 @@ type ValidationState struct {
 	AutoScaleDetails  string    `json:"autoScaleDetails"`  // Details about auto-scaling
 	RequiredRoles     int       `json:"requiredRoles"`     // Players needing roles
 	ConfiguredRoles   int       `json:"configuredRoles"`   // Currently configured roles
+
+	// Issues is the start checklist: one entry per check, passing or not
+	Issues []ValidationIssue `json:"issues"`
 }
 
 // Room represents a game room
 type Room struct {
 	Code                            string
+	Tenant                          string `json:",omitempty"` // ID of the tenant the room belongs to; empty for the server's own
 	State                           GameState
 	RulesMode                       RulesMode
 	CoupPreset                      CoupPreset
@@ -80,16 +92,90 @@ type Room struct {
 	DebugViewedPlayerID             string
 	DebugStartMode                  DebugStartMode
 
+	// Read-only streaming overlay and spectator access
+	OverlayToken string
+	WatchLinks   []WatchLink
+
+	// Emailed join links and who they went to. The addresses belong to people
+	// who may never join, so they are never serialized: not saved with the
+	// room and not in the state backups every player's browser keeps.
+	Invites []Invite `json:"-"`
+
+	// CreatorToken lets the room's creator recover Room Operator access from
+	// another browser. It is a credential on its own, so like Invites it is
+	// never serialized and a restart ends it.
+	CreatorToken string `json:"-"`
+
+	// Sessions the Room Operator banned from rejoining (see kick.go). Saved
+	// with the room, like the players' own sessions, so a ban outlives a
+	// restart or a restored backup. That puts them in every player's state
+	// backup too, encrypted with the server's backup key like the rest.
+	BannedSessions map[string]bool `json:"bannedSessions,omitempty"`
+
+	// Private rooms take a password to join, or one of the one-time tokens
+	// their QR code carries (see join_password.go). The hash, salted with the
+	// room code, is saved with the room so it stays private after a restart
+	// or a restored backup, and so is in every player's encrypted state
+	// backup; the tokens are short-lived and a fresh QR code issues more.
+	JoinPasswordHash string   `json:"joinPasswordHash,omitempty"`
+	JoinTokens       []string `json:"-"`
+	JoinTokensUsed   int      // tells the QR code on screen to fetch a fresh token
+
 	MaxPlayers int
 	CreatedAt  time.Time
 	StartedAt  time.Time
+	StartedBy  string // name of the player who started the game; empty when unknown
+
+	// Server config captured when a reload would have invalidated the room,
+	// and the notice its host sees about it (see config_pin.go)
+	PinnedConfig *config.ServerConfig `json:"-"`
+	ConfigNotice string               `json:"-"`
+
+	// The role config before the last preset switch, for the changes the
+	// Room Operator sees until they dismiss them (see config_diff.go)
+	PreviousRoleConfig *RoleConfiguration `json:"-"`
 
 	// Countdown state
 	CountdownRemaining int
 
+	// RevealAt is the server time every player's role appears at once after
+	// the countdown; zero once it has passed, or when the start ritual shows
+	// roles right away
+	RevealAt time.Time
+
+	// How the countdown state ends, and who has confirmed a confirm start
+	StartRitual        StartRitualSettings
+	StartConfirmations map[string]bool
+
+	// DealPending is set while dealt roles wait in the lobby for the Room
+	// Operator to approve them or deal again
+	DealPending bool
+
 	// Game state
 	LeaderRevealed bool
 
+	// Role cards are watermarked images served from per-player token URLs
+	// rather than inline data, so leaked screenshots carry the player's name,
+	// and how often each player's role image was loaded
+	ScreenshotDeterrence bool
+	RoleImageSecret      string
+	RoleImageLoads       map[string]int `json:"-"`
+
+	// Optional day/night phase engine
+	PhaseSettings PhaseSettings
+	Phase         *PhaseState
+
+	// Voting and the public game log
+	Vote           *Vote
+	LastVoteResult *VoteResult
+	Log            []LogEntry
+
+	// Anonymous lobby poll, e.g. "which preset tonight?"
+	Poll *Vote
+
+	// Table chat, the last ChatHistoryLimit messages (see chat.go)
+	Chat []ChatMessage
+
 	// Role configuration
 	RoleConfig *RoleConfiguration
 
@@ -97,13 +183,37 @@ type Room struct {
 	ValidationVersion int64     `json:"-"`
 	LastValidatedAt   time.Time `json:"-"`
 
+	// Language the role config and card pages show card text in; empty is
+	// English (see Card.In)
+	CardLocale string
+
+	// Card list page the role config shows per role type, so re-renders keep
+	// lazily loaded cards; role types never expanded are absent
+	CardListViews map[string]CardListView `json:"-"`
+
 	// Ability system components
 	CardPool           *CardPool
 	RoleOptionsManager *RoleOptionsManager
 
 	mu sync.RWMutex
+
+	// state serializes handlers, which read and write the fields above
+	// directly; mu only guards the methods below
+	state sync.RWMutex
 }
 
+// Lock holds the room for a handler's read-modify-write of its fields
+func (r *Room) Lock() { r.state.Lock() }
+
+// Unlock releases Lock
+func (r *Room) Unlock() { r.state.Unlock() }
+
+// RLock holds the room for rendering, keeping handlers out until RUnlock
+func (r *Room) RLock() { r.state.RLock() }
+
+// RUnlock releases RLock
+func (r *Room) RUnlock() { r.state.RUnlock() }
+
 // IsOperatorSession reports whether a browser session has Room Operator authority.
 func (r *Room) IsOperatorSession(sessionID string) bool {
 	r.mu.RLock()
@@ -252,16 +362,12 @@ func (r *Room) CanStart() bool {
 	if r.RoleConfig != nil {
 		// Count total configured roles
 		totalRoles := 0
-		hasLeader := false
-
-		for roleType, typeConfig := range r.RoleConfig.RoleTypes {
+		for _, typeConfig := range r.RoleConfig.RoleTypes {
 			if typeConfig.Count > 0 {
 				totalRoles += typeConfig.Count
-				if roleType == "Leader" {
-					hasLeader = true
-				}
 			}
 		}
+		leaders := NewLeaderPolicy(r.RoleConfig, RoleCountBounds{})
 
 		// Check if we have enough roles for all players
 		if totalRoles < activePlayerCount {
@@ -269,7 +375,7 @@ func (r *Room) CanStart() bool {
 		}
 
 		// Check if we need a leader
-		if !hasLeader && !r.RoleConfig.AllowLeaderlessGame {
+		if leaders.Missing(r.RoleConfig.LeaderCount()) {
 			return false
 		}
 	}
@@ -304,16 +410,12 @@ func (r *Room) GetStartValidationError() string {
 	if r.RoleConfig != nil {
 		// Count total configured roles
 		totalRoles := 0
-		hasLeader := false
-
-		for roleType, typeConfig := range r.RoleConfig.RoleTypes {
+		for _, typeConfig := range r.RoleConfig.RoleTypes {
 			if typeConfig.Count > 0 {
 				totalRoles += typeConfig.Count
-				if roleType == "Leader" {
-					hasLeader = true
-				}
 			}
 		}
+		leaders := NewLeaderPolicy(r.RoleConfig, RoleCountBounds{})
 
 		// Check if we have enough roles for all players
 		if totalRoles < activePlayerCount {
@@ -321,7 +423,7 @@ func (r *Room) GetStartValidationError() string {
 		}
 
 		// Check if we need a leader
-		if !hasLeader && !r.RoleConfig.AllowLeaderlessGame {
+		if leaders.Missing(r.RoleConfig.LeaderCount()) {
 			return "Leader role is required (or enable leaderless games)"
 		}
 	}
@@ -373,7 +475,6 @@ func (r *Room) ValidateRoleConfig() error {
 
 	// Count total roles and validate each type
 	totalRoles := 0
-	hasLeader := false
 
 	for roleType, config := range r.RoleConfig.RoleTypes {
 		// Count enabled cards for this type
@@ -390,15 +491,11 @@ func (r *Room) ValidateRoleConfig() error {
 		}
 
 		totalRoles += config.Count
-
-		if roleType == "Leader" && config.Count > 0 {
-			hasLeader = true
-		}
 	}
 
-	// Must have exactly one leader unless leaderless game is allowed
-	if !hasLeader && !r.RoleConfig.AllowLeaderlessGame {
-		return fmt.Errorf("must have exactly one leader role")
+	// Must have a leader unless leaderless game is allowed
+	if err := NewLeaderPolicy(r.RoleConfig, RoleCountBounds{}).Check(r.RoleConfig.LeaderCount()); err != nil {
+		return err
 	}
 
 	// For now, we allow flexible role counts
@@ -502,29 +599,31 @@ func (r *Room) GetValidationState(roleService *RoleConfigService) ValidationStat
 	if activeCount < 1 {
 		state.CanStart = false
 		state.ValidationMessage = "Need at least 1 player to start"
+		state.addIssue(ValidationCheckPlayers, ValidationBlocking, "player-list", state.ValidationMessage)
 		return state
 	}
+	state.addIssue(ValidationCheckPlayers, ValidationOK, "player-list", playerCountText(activeCount)+" joined")
 
 	// Must be in lobby state
 	if r.State != StateLobby {
 		state.CanStart = false
 		state.ValidationMessage = "Game is not in lobby state"
+		state.addIssue(ValidationCheckLobby, ValidationBlocking, "", state.ValidationMessage)
 		return state
 	}
 
 	// Check role configuration
 	if r.RoleConfig != nil {
 		totalRoles := 0
-		hasLeader := false
-
-		for roleType, config := range r.RoleConfig.RoleTypes {
+		for _, config := range r.RoleConfig.RoleTypes {
 			if config.Count > 0 {
 				totalRoles += config.Count
-				if roleType == "Leader" {
-					hasLeader = true
-				}
 			}
 		}
+		leaders := NewLeaderPolicy(r.RoleConfig, RoleCountBounds{})
+		if roleService != nil {
+			leaders = roleService.LeaderPolicy(r.RoleConfig)
+		}
 
 		state.ConfiguredRoles = totalRoles
 		state.RequiredRoles = activeCount
@@ -540,9 +639,11 @@ func (r *Room) GetValidationState(roleService *RoleConfigService) ValidationStat
 				if canScale {
 					state.CanStart = true
 					state.ValidationMessage = fmt.Sprintf("Will auto-scale roles from %d to %d players", totalRoles, activeCount)
+					state.addIssue(ValidationCheckRoles, ValidationWarning, "treachery-role-counts", state.ValidationMessage)
 				} else {
 					state.CanStart = false
 					state.ValidationMessage = fmt.Sprintf("Not enough roles configured (%d) for %d players. %s", totalRoles, activeCount, details)
+					state.addIssue(ValidationCheckRoles, ValidationBlocking, "treachery-role-counts", state.ValidationMessage)
 				}
 			} else {
 				state.CanStart = false
@@ -550,15 +651,56 @@ func (r *Room) GetValidationState(roleService *RoleConfigService) ValidationStat
 				if r.RoleConfig.PresetName == "custom" {
 					state.AutoScaleDetails = "Custom configurations do not support auto-scaling"
 				}
+				state.addIssue(ValidationCheckRoles, ValidationBlocking, "treachery-role-counts", state.ValidationMessage)
 			}
+		} else {
+			state.addIssue(ValidationCheckRoles, ValidationOK, "treachery-role-counts", fmt.Sprintf("Enough roles for %s", playerCountText(activeCount)))
 		}
 
-		// Check leader requirement
-		if !hasLeader && !r.RoleConfig.AllowLeaderlessGame && state.CanStart {
-			state.CanStart = false
-			state.ValidationMessage = "Leader role is required (or enable leaderless games)"
+		// Check leader requirement. The checklist lists a missing Leader
+		// even when too few roles already block the start.
+		switch {
+		case leaders.Missing(r.RoleConfig.LeaderCount()):
+			if state.CanStart {
+				state.CanStart = false
+				state.ValidationMessage = "Leader role is required (or enable leaderless games)"
+			}
+			state.addIssue(ValidationCheckLeader, ValidationBlocking, "allow-leaderless", "Leader role is required (or enable leaderless games)")
+		case r.RoleConfig.LeaderCount() == 0:
+			state.addIssue(ValidationCheckLeader, ValidationWarning, "allow-leaderless", "Leaderless game")
+		default:
+			state.addIssue(ValidationCheckLeader, ValidationOK, "role-row-Leader", "Leader role configured")
 		}
 	}
 
 	return state
 }
+
+// WithoutRoleCounts returns the state as players may see it while the Room
+// Operator hides the role distribution: role counts and auto-scale details
+// are dropped and role setup messages become neutral
+func (s ValidationState) WithoutRoleCounts() ValidationState {
+	if s.RequiredRoles == 0 && s.ConfiguredRoles == 0 {
+		// Validation stopped before checking roles
+		return s
+	}
+	s.RequiredRoles = 0
+	s.ConfiguredRoles = 0
+	s.AutoScaleDetails = ""
+	if s.CanStart {
+		s.ValidationMessage = ""
+	} else {
+		s.ValidationMessage = "Waiting for the Room Operator to finish role setup"
+	}
+	issues := make([]ValidationIssue, 0, len(s.Issues))
+	for _, issue := range s.Issues {
+		if issue.Check != ValidationCheckRoles && issue.Check != ValidationCheckLeader {
+			issues = append(issues, issue)
+		}
+	}
+	if !s.CanStart {
+		issues = append(issues, ValidationIssue{Check: ValidationCheckRoles, Severity: ValidationBlocking, Message: s.ValidationMessage})
+	}
+	s.Issues = issues
+	return s
+}
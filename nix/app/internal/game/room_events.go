@@ -0,0 +1,157 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrUnknownRoomEvent = errors.New("unknown room event")
+
+// RoomEventKind names a change to a room's state
+type RoomEventKind string
+
+const (
+	RoomEventPlayerJoined  RoomEventKind = "player_joined"
+	RoomEventPlayerLeft    RoomEventKind = "player_left"
+	RoomEventPlayerBanned  RoomEventKind = "player_banned"
+	RoomEventConfigChanged RoomEventKind = "config_changed"
+	RoomEventGameStarted   RoomEventKind = "game_started"
+	RoomEventRoleRevealed  RoomEventKind = "role_revealed"
+	RoomEventGameRestarted RoomEventKind = "game_restarted"
+)
+
+// RoomEvent is one entry in a room's append-only event log. The store applies
+// each event to the live room as it records it, so replaying a room's events
+// over its last snapshot rebuilds the room. Events carry outcomes rather than
+// intentions: a start carries the roles that were dealt, not a request to
+// deal, so a replay ends up with the same roles.
+type RoomEvent struct {
+	Seq  int           `json:"seq"`
+	At   time.Time     `json:"at"`
+	Kind RoomEventKind `json:"kind"`
+
+	Player     *Player              `json:"player,omitempty"`     // joined
+	PlayerID   string               `json:"playerId,omitempty"`   // left, banned, revealed
+	SessionID  string               `json:"sessionId,omitempty"`  // banned
+	RoleConfig *RoleConfiguration   `json:"roleConfig,omitempty"` // config changed
+	Deal       map[string]DealtRole `json:"deal,omitempty"`       // started, by player ID
+	State      GameState            `json:"state,omitempty"`      // started
+	StartedAt  time.Time            `json:"startedAt,omitempty"`  // started
+	StartedBy  string               `json:"startedBy,omitempty"`  // started
+	Revealed   bool                 `json:"revealed,omitempty"`   // revealed
+	FaceUp     bool                 `json:"faceUp,omitempty"`     // revealed
+}
+
+// DealtRole is what a start dealt one player, including whether the role
+// was dealt revealed, as leaders' are
+type DealtRole struct {
+	Role      *Card       `json:"role,omitempty"`
+	KnownInfo []KnownInfo `json:"knownInfo,omitempty"`
+	Revealed  bool        `json:"revealed,omitempty"`
+	FaceUp    bool        `json:"faceUp,omitempty"`
+}
+
+// PlayerJoinedEvent seats player in the room
+func PlayerJoinedEvent(player *Player) RoomEvent {
+	return RoomEvent{Kind: RoomEventPlayerJoined, Player: player}
+}
+
+// PlayerLeftEvent gives up a player's seat
+func PlayerLeftEvent(playerID string) RoomEvent {
+	return RoomEvent{Kind: RoomEventPlayerLeft, PlayerID: playerID}
+}
+
+// PlayerBannedEvent gives up a player's seat and keeps their session from
+// joining again
+func PlayerBannedEvent(player *Player) RoomEvent {
+	return RoomEvent{Kind: RoomEventPlayerBanned, PlayerID: player.ID, SessionID: player.SessionID}
+}
+
+// ConfigChangedEvent records the room's role configuration as it now stands
+func ConfigChangedEvent(config *RoleConfiguration) RoomEvent {
+	return RoomEvent{Kind: RoomEventConfigChanged, RoleConfig: config}
+}
+
+// GameStartedEvent records a started room: its state, who started it and the
+// role each player was dealt
+func GameStartedEvent(r *Room) RoomEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deal := make(map[string]DealtRole, len(r.Players))
+	for id, player := range r.Players {
+		if player.Role != nil {
+			deal[id] = DealtRole{Role: player.Role, KnownInfo: player.KnownInfo, Revealed: player.RoleRevealed, FaceUp: player.FaceUp}
+		}
+	}
+	return RoomEvent{Kind: RoomEventGameStarted, Deal: deal, State: r.State, StartedAt: r.StartedAt, StartedBy: r.StartedBy}
+}
+
+// RoleRevealedEvent sets whether a player's role is revealed and face up
+func RoleRevealedEvent(playerID string, revealed, faceUp bool) RoomEvent {
+	return RoomEvent{Kind: RoomEventRoleRevealed, PlayerID: playerID, Revealed: revealed, FaceUp: faceUp}
+}
+
+// GameRestartedEvent takes a game in play or over back to the lobby for a
+// rematch, see restart
+func GameRestartedEvent() RoomEvent {
+	return RoomEvent{Kind: RoomEventGameRestarted}
+}
+
+// Apply makes the change e records. It fails, changing nothing, when the
+// change isn't possible in the room as it is: a join into a full room or
+// under a taken name, or an event about a player who isn't seated.
+func (r *Room) Apply(e RoomEvent) error {
+	switch e.Kind {
+	case RoomEventPlayerJoined:
+		if e.Player == nil {
+			return fmt.Errorf("%w: %s without a player", ErrUnknownRoomEvent, e.Kind)
+		}
+		return r.AddPlayer(e.Player)
+	case RoomEventPlayerLeft:
+		r.RemovePlayer(e.PlayerID)
+		return nil
+	case RoomEventPlayerBanned:
+		r.RemovePlayer(e.PlayerID)
+		r.Ban(e.SessionID)
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch e.Kind {
+	case RoomEventConfigChanged:
+		r.RoleConfig = e.RoleConfig
+	case RoomEventGameStarted:
+		for id := range e.Deal {
+			if _, ok := r.Players[id]; !ok {
+				return fmt.Errorf("%w: dealt to %s", ErrPlayerNotFound, id)
+			}
+		}
+		for id, player := range r.Players {
+			if dealt, ok := e.Deal[id]; ok {
+				player.Role, player.KnownInfo = dealt.Role, dealt.KnownInfo
+				player.RoleRevealed, player.FaceUp = dealt.Revealed, dealt.FaceUp
+			} else {
+				player.Role, player.KnownInfo = nil, nil
+			}
+		}
+		r.State = e.State
+		r.StartedAt = e.StartedAt
+		r.StartedBy = e.StartedBy
+	case RoomEventRoleRevealed:
+		player, ok := r.Players[e.PlayerID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrPlayerNotFound, e.PlayerID)
+		}
+		player.RoleRevealed = e.Revealed
+		player.FaceUp = e.FaceUp
+	case RoomEventGameRestarted:
+		return r.restart()
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownRoomEvent, e.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApplyRoomEvents(t *testing.T) {
+	room := &Room{Code: "EVNTS", State: StateLobby, Players: make(map[string]*Player), MaxPlayers: 2}
+	alice := NewPlayer("p1", "Alice", "s1")
+	bob := NewPlayer("p2", "Bob", "s2")
+	for _, e := range []RoomEvent{PlayerJoinedEvent(alice), PlayerJoinedEvent(bob)} {
+		if err := room.Apply(e); err != nil {
+			t.Fatalf("apply %s: %v", e.Kind, err)
+		}
+	}
+
+	if err := room.Apply(PlayerJoinedEvent(NewPlayer("p3", "alice", "s3"))); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("expected a taken name refused, got %v", err)
+	}
+	if err := room.Apply(PlayerJoinedEvent(NewPlayer("p3", "Carol", "s3"))); !errors.Is(err, ErrRoomFull) {
+		t.Errorf("expected a join into a full room refused, got %v", err)
+	}
+
+	config := &RoleConfiguration{PresetName: "custom", MaxPlayers: 2}
+	if err := room.Apply(ConfigChangedEvent(config)); err != nil || room.RoleConfig != config {
+		t.Errorf("expected the configuration replaced, got %v", err)
+	}
+
+	cards := createMockCardService()
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	start := RoomEvent{
+		Kind:      RoomEventGameStarted,
+		Deal:      map[string]DealtRole{"p1": {Role: cards.Leaders[0], Revealed: true, FaceUp: true}, "p2": {Role: cards.Assassins[0], KnownInfo: []KnownInfo{{PlayerID: "p1"}}}},
+		State:     StateCountdown,
+		StartedAt: startedAt,
+		StartedBy: "Alice",
+	}
+	if err := room.Apply(start); err != nil {
+		t.Fatalf("apply start: %v", err)
+	}
+	if room.State != StateCountdown || !room.StartedAt.Equal(startedAt) || room.StartedBy != "Alice" {
+		t.Errorf("expected the start recorded, got %s at %v by %q", room.State, room.StartedAt, room.StartedBy)
+	}
+	if alice.Role != cards.Leaders[0] || !alice.RoleRevealed || bob.Role != cards.Assassins[0] || len(bob.KnownInfo) != 1 {
+		t.Errorf("expected the dealt roles, got %v and %v", alice.Role, bob.Role)
+	}
+
+	if err := room.Apply(RoleRevealedEvent("p2", true, true)); err != nil || !bob.RoleRevealed || !bob.FaceUp {
+		t.Errorf("expected Bob's role revealed, got %v", err)
+	}
+	if err := room.Apply(RoleRevealedEvent("gone", true, true)); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("expected a reveal for an unseated player refused, got %v", err)
+	}
+
+	if err := room.Apply(PlayerLeftEvent("p2")); err != nil || room.GetPlayer("p2") != nil {
+		t.Errorf("expected Bob's seat given up, got %v", err)
+	}
+	if err := room.Apply(PlayerBannedEvent(alice)); err != nil || room.GetPlayer("p1") != nil || !room.IsBanned("s1") {
+		t.Errorf("expected Alice unseated and banned, got %v", err)
+	}
+	if err := room.Apply(RoomEvent{Kind: "shuffled"}); !errors.Is(err, ErrUnknownRoomEvent) {
+		t.Errorf("expected an unknown event refused, got %v", err)
+	}
+}
+
+func TestGameStartedEventCarriesTheDeal(t *testing.T) {
+	room := &Room{Code: "DEALT", State: StateCountdown, StartedBy: "Alice", Players: make(map[string]*Player), MaxPlayers: 4}
+	alice := NewPlayer("p1", "Alice", "s1")
+	alice.Role = createMockCardService().Guardians[0]
+	host := NewPlayer("op", "Operator", "s0")
+	host.IsHost = true
+	room.AddPlayer(alice)
+	room.AddPlayer(host)
+
+	e := GameStartedEvent(room)
+	if e.State != StateCountdown || e.StartedBy != "Alice" {
+		t.Errorf("expected the room's start recorded, got %+v", e)
+	}
+	if len(e.Deal) != 1 || e.Deal["p1"].Role != alice.Role {
+		t.Errorf("expected only Alice's role in the deal, got %+v", e.Deal)
+	}
+}
@@ -385,3 +385,39 @@ func findSubstring(s, substr string) int {
 	}
 	return -1
 }
+
+func TestValidationStateWithoutRoleCounts(t *testing.T) {
+	room := &Room{
+		Code:    "HIDE1",
+		State:   StateLobby,
+		Players: make(map[string]*Player),
+		RoleConfig: &RoleConfiguration{
+			PresetName: "custom",
+			RoleTypes: map[string]*RoleTypeConfig{
+				"Leader":   {Count: 1},
+				"Assassin": {Count: 1},
+			},
+		},
+	}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		room.Players[id] = NewPlayer(id, id, "session-"+id)
+	}
+
+	state := room.GetValidationState(nil)
+	if state.CanStart || state.ConfiguredRoles != 2 {
+		t.Fatalf("expected a blocked start with 2 configured roles, got %+v", state)
+	}
+
+	redacted := state.WithoutRoleCounts()
+	if redacted.RequiredRoles != 0 || redacted.ConfiguredRoles != 0 || redacted.AutoScaleDetails != "" {
+		t.Errorf("expected role counts to be dropped, got %+v", redacted)
+	}
+	if redacted.CanStart || redacted.ValidationMessage != "Waiting for the Room Operator to finish role setup" {
+		t.Errorf("expected a neutral blocked message, got %+v", redacted)
+	}
+
+	empty := (&Room{Code: "HIDE2", State: StateLobby, Players: make(map[string]*Player)}).GetValidationState(nil)
+	if got := empty.WithoutRoleCounts(); got.ValidationMessage != "Need at least 1 player to start" {
+		t.Errorf("expected messages without role info to be kept, got %q", got.ValidationMessage)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// DefaultSandboxCardsPerType is the sandbox card count per role type when none is configured
+const DefaultSandboxCardsPerType = 5
+
+// sandboxRoleTypes lists the Treachery role types in card ID order, with the
+// colour the real set prints them in
+var sandboxRoleTypes = []struct {
+	subtype string
+	color   string
+	fill    string // SVG background for the generated image
+}{
+	{"Leader", "multicolor", "#b8860b"},
+	{"Guardian", "blue", "#1e4d8c"},
+	{"Assassin", "red", "#8b1a1a"},
+	{"Traitor", "black", "#2b2b2b"},
+}
+
+// NewSandboxCardService builds a CardService of generated placeholder cards,
+// perType for each Treachery role type. It needs no embedded card data, so
+// contributors and CI without the asset bundle can still run the server.
+func NewSandboxCardService(perType int) *CardService {
+	if perType < 1 {
+		perType = DefaultSandboxCardsPerType
+	}
+
+	cards := make([]Card, 0, perType*len(sandboxRoleTypes))
+	for _, roleType := range sandboxRoleTypes {
+		for n := 1; n <= perType; n++ {
+			name := fmt.Sprintf("Sandbox %s %d", roleType.subtype, n)
+			cards = append(cards, Card{
+				ID:          len(cards) + 1,
+				Name:        name,
+				NameAnchor:  fmt.Sprintf("sandbox-%s-%d", strings.ToLower(roleType.subtype), n),
+				Color:       roleType.color,
+				Type:        "Identity — " + roleType.subtype,
+				Types:       CardTypes{Supertype: "Identity", Subtype: roleType.subtype},
+				Rarity:      "U",
+				Text:        fmt.Sprintf("Placeholder %s identity for sandbox mode. It has no abilities.", roleType.subtype),
+				Base64Image: sandboxCardImage(name, roleType.fill),
+			})
+		}
+	}
+
+	service := &CardService{
+		Leaders:   make([]*Card, 0, perType),
+		Guardians: make([]*Card, 0, perType),
+		Assassins: make([]*Card, 0, perType),
+		Traitors:  make([]*Card, 0, perType),
+		allCards:  cards,
+		info: CardSetInfo{
+			Name:      "Sandbox",
+			Code:      "SANDBOX",
+			Language:  "EN",
+			CardCount: len(cards),
+			Sandbox:   true,
+			Images:    true,
+		},
+	}
+	for i := range service.allCards {
+		service.addRoleCard(&service.allCards[i])
+	}
+	service.indexPacks(nil, service.info.Name)
+	service.buildSearchIndex()
+	return service
+}
+
+// sandboxCardImage returns an SVG data URI showing the card name on a role-coloured card
+func sandboxCardImage(name, fill string) string {
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="488" height="680" viewBox="0 0 488 680">`+
+		`<rect width="488" height="680" rx="24" fill="%s"/>`+
+		`<text x="244" y="340" fill="#fff" font-family="sans-serif" font-size="32" text-anchor="middle">%s</text>`+
+		`</svg>`, fill, html.EscapeString(name))
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSandboxCardService(t *testing.T) {
+	service := NewSandboxCardService(3)
+
+	for roleType, cards := range map[string][]*Card{
+		"Leader":   service.Leaders,
+		"Guardian": service.Guardians,
+		"Assassin": service.Assassins,
+		"Traitor":  service.Traitors,
+	} {
+		if len(cards) != 3 {
+			t.Errorf("expected 3 %s cards, got %d", roleType, len(cards))
+		}
+		for _, card := range cards {
+			if card.Types.Subtype != roleType {
+				t.Errorf("%s listed as %s", card.Name, roleType)
+			}
+			if !strings.HasPrefix(card.Base64Image, "data:image/svg+xml;base64,") {
+				t.Errorf("%s has no image: %q", card.Name, card.Base64Image)
+			}
+		}
+	}
+
+	all := service.GetAllCards()
+	if len(all) != 12 {
+		t.Fatalf("expected 12 cards, got %d", len(all))
+	}
+	seenIDs := make(map[int]bool)
+	seenAnchors := make(map[string]bool)
+	for _, card := range all {
+		if seenIDs[card.ID] || seenAnchors[card.NameAnchor] {
+			t.Errorf("duplicate card %d %q", card.ID, card.NameAnchor)
+		}
+		seenIDs[card.ID] = true
+		seenAnchors[card.NameAnchor] = true
+	}
+	if service.Leaders[0] != all[0] {
+		t.Error("expected role lists to share cards with GetAllCards")
+	}
+}
+
+func TestNewSandboxCardServiceDefaultsCount(t *testing.T) {
+	service := NewSandboxCardService(0)
+	if len(service.Traitors) != DefaultSandboxCardsPerType {
+		t.Errorf("expected %d Traitors, got %d", DefaultSandboxCardsPerType, len(service.Traitors))
+	}
+	if len(service.GetRandomCards(RoleTraitor, 2)) != 2 {
+		t.Error("expected sandbox cards to be drawable")
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+)
+
+// CountRoleImageLoad records one load of a player's role image and returns
+// how many times that player has loaded this card's image, this one included
+func (r *Room) CountRoleImageLoad(playerID string, cardID int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.RoleImageLoads == nil {
+		r.RoleImageLoads = make(map[string]int)
+	}
+	key := playerID + ":" + strconv.Itoa(cardID)
+	r.RoleImageLoads[key]++
+	return r.RoleImageLoads[key]
+}
+
+// EnsureRoleImageSecret creates the key role image tokens are derived from, on first use.
+func (r *Room) EnsureRoleImageSecret() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.RoleImageSecret == "" {
+		r.RoleImageSecret = newPublicToken()
+	}
+}
+
+// RoleImageToken returns the token in playerID's role image URL, or "" before
+// the room has a role image secret. Tokens are derived rather than stored, so
+// players who join later get one too.
+func (r *Room) RoleImageToken(playerID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.roleImageTokenLocked(playerID)
+}
+
+func (r *Room) roleImageTokenLocked(playerID string) string {
+	if r.RoleImageSecret == "" || playerID == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(r.RoleImageSecret))
+	mac.Write([]byte(playerID))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// RoleImagePlayer returns the player whose role image token this is, or nil.
+func (r *Room) RoleImagePlayer(token string) *Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if token == "" {
+		return nil
+	}
+	for id, player := range r.Players {
+		if subtle.ConstantTimeCompare([]byte(r.roleImageTokenLocked(id)), []byte(token)) == 1 {
+			return player
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrSeatPINInvalid   = errors.New("PIN must be exactly 4 digits")
+	ErrSeatPINMismatch  = errors.New("that seat is protected by a PIN")
+	ErrSeatNotProtected = errors.New("only a seat protected by a PIN can be moved to another device")
+	ErrSeatIsOperators  = errors.New("the Room Operator's seat moves with the recovery code")
+	ErrAlreadySeated    = errors.New("this browser already has a seat in the room")
+)
+
+// ValidSeatPIN reports whether pin is a usable seat PIN: exactly four digits.
+func ValidSeatPIN(pin string) bool {
+	if len(pin) != 4 {
+		return false
+	}
+	for _, ch := range pin {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSeatPIN protects the player's seat with pin; "" leaves it unprotected.
+// Only a hash salted with the player ID is kept.
+func (p *Player) SetSeatPIN(pin string) error {
+	if pin == "" {
+		p.SeatPINHash = ""
+		return nil
+	}
+	if !ValidSeatPIN(pin) {
+		return ErrSeatPINInvalid
+	}
+	p.SeatPINHash = hashSeatPIN(p.ID, pin)
+	return nil
+}
+
+// HasSeatPIN reports whether the player's seat can move to another session,
+// with its PIN, see ReclaimSeat and RecoverOperator.
+func (p *Player) HasSeatPIN() bool {
+	return p.SeatPINHash != ""
+}
+
+// seatPINMatches reports whether pin unlocks the player's seat; a seat
+// without a PIN is unlocked by anything
+func (p *Player) seatPINMatches(pin string) bool {
+	if p.SeatPINHash == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(p.SeatPINHash), []byte(hashSeatPIN(p.ID, pin))) == 1
+}
+
+// ReclaimSeat moves the seated player named name to sessionID, e.g. a phone
+// that replaced a player's laptop mid-game, and returns them. Only a seat
+// protected by a PIN moves, and only when pin matches, so knowing a player's
+// name is never enough. The Room Operator's seat moves with RecoverOperator,
+// which also takes the recovery code.
+func (r *Room) ReclaimSeat(name, pin, sessionID string) (*Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var player *Player
+	for _, p := range r.Players {
+		if p.SessionID == sessionID {
+			return nil, ErrAlreadySeated
+		}
+		if strings.EqualFold(p.Name, name) {
+			player = p
+		}
+	}
+	switch {
+	case player == nil:
+		return nil, ErrPlayerNotFound
+	case r.OperatorSessionID != "" && player.SessionID == r.OperatorSessionID:
+		return nil, ErrSeatIsOperators
+	case !player.HasSeatPIN():
+		return nil, ErrSeatNotProtected
+	case !player.seatPINMatches(pin):
+		return nil, ErrSeatPINMismatch
+	}
+	player.SessionID = sessionID
+	return player, nil
+}
+
+func hashSeatPIN(playerID, pin string) string {
+	sum := sha256.Sum256([]byte(playerID + ":" + pin))
+	return hex.EncodeToString(sum[:])
+}
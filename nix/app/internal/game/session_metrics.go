@@ -0,0 +1,50 @@
+package game
+
+import "time"
+
+// SessionMetrics sums up a room's last game for its Room Operator, so a group
+// can tune its setup: how long the lobby took, how long until someone
+// revealed, and how much the setup changed on the way.
+type SessionMetrics struct {
+	LobbyTime         time.Duration // from the lobby opening to the start
+	TimeToFirstReveal time.Duration // from the start to the first reveal
+	Revealed          bool          // whether anyone revealed at all
+	ConfigChanges     int           // role configuration changes in the lobby
+	Reconnects        int           // stream reconnects browsers reported
+}
+
+// LastGameMetrics derives the metrics of room's last game from its journal.
+// A journal compacted past the start still gives the lobby and reveal times
+// from the room itself, with changes counted from what is left. The caller
+// holds the room's lock.
+func LastGameMetrics(room *Room, events []RoomEvent) SessionMetrics {
+	var m SessionMetrics
+	lobbyOpened := room.CreatedAt
+	var firstReveal time.Time
+	for _, e := range events {
+		switch e.Kind {
+		case RoomEventGameRestarted:
+			if e.At.Before(room.StartedAt) {
+				lobbyOpened = e.At
+				m.ConfigChanges = 0
+			}
+		case RoomEventConfigChanged:
+			if e.At.Before(room.StartedAt) {
+				m.ConfigChanges++
+			}
+		case RoomEventRoleRevealed:
+			if e.Revealed && !e.At.Before(room.StartedAt) && firstReveal.IsZero() {
+				firstReveal = e.At
+			}
+		}
+	}
+
+	if !room.StartedAt.IsZero() && !lobbyOpened.IsZero() {
+		m.LobbyTime = room.StartedAt.Sub(lobbyOpened)
+	}
+	if !firstReveal.IsZero() {
+		m.Revealed = true
+		m.TimeToFirstReveal = firstReveal.Sub(room.StartedAt)
+	}
+	return m
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastGameMetrics(t *testing.T) {
+	created := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	room := &Room{CreatedAt: created, StartedAt: created.Add(12 * time.Minute)}
+	events := []RoomEvent{
+		{Kind: RoomEventConfigChanged, At: created.Add(time.Minute)},
+		{Kind: RoomEventGameRestarted, At: created.Add(2 * time.Minute)},
+		{Kind: RoomEventConfigChanged, At: created.Add(3 * time.Minute)},
+		{Kind: RoomEventConfigChanged, At: created.Add(4 * time.Minute)},
+		{Kind: RoomEventRoleRevealed, At: created.Add(13 * time.Minute), Revealed: false},
+		{Kind: RoomEventRoleRevealed, At: created.Add(15 * time.Minute), Revealed: true},
+		{Kind: RoomEventRoleRevealed, At: created.Add(16 * time.Minute), Revealed: true},
+	}
+
+	m := LastGameMetrics(room, events)
+	if m.LobbyTime != 10*time.Minute {
+		t.Errorf("expected the lobby timed from the last restart, got %v", m.LobbyTime)
+	}
+	if m.ConfigChanges != 2 {
+		t.Errorf("expected only the last lobby's changes, got %d", m.ConfigChanges)
+	}
+	if !m.Revealed || m.TimeToFirstReveal != 3*time.Minute {
+		t.Errorf("expected the first reveal 3m in, got %v revealed=%v", m.TimeToFirstReveal, m.Revealed)
+	}
+
+	if m := LastGameMetrics(room, nil); m.LobbyTime != 12*time.Minute || m.Revealed {
+		t.Errorf("expected a compacted journal to fall back on the room, got %+v", m)
+	}
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"errors"
+	"time"
+
+	"treacherest/internal/game/ability"
+)
+
+// StartRitual is how a started game moves from the countdown state to playing.
+type StartRitual string
+
+const (
+	StartRitualCountdown StartRitual = ""        // Fixed countdown, then roles are revealed
+	StartRitualConfirm   StartRitual = "confirm" // Every player taps once they have seen their role
+)
+
+// DefaultStartConfirmTimeout is how long a confirm start waits for stragglers
+// before the game begins anyway.
+const DefaultStartConfirmTimeout = 90 * time.Second
+
+var (
+	ErrStartConfirmNotPending = errors.New("the game is not waiting for start confirmations")
+	ErrNotCountingDown        = errors.New("roles can only be dealt again before the game begins")
+)
+
+// StartRitualSettings is the pre-start configuration for the start ritual.
+type StartRitualSettings struct {
+	Ritual      StartRitual
+	Timeout     time.Duration // Confirm start fallback; 0 uses DefaultStartConfirmTimeout
+	ApproveDeal bool          // hold the dealt roles for the Room Operator's approval, see DealApprovalOn
+}
+
+// ConfirmTimeout is how long a confirm start waits before playing anyway.
+func (s StartRitualSettings) ConfirmTimeout() time.Duration {
+	if s.Timeout <= 0 {
+		return DefaultStartConfirmTimeout
+	}
+	return s.Timeout
+}
+
+// AwaitingStartConfirmations reports whether the room is in a confirm start
+// that has not moved to playing yet.
+func (r *Room) AwaitingStartConfirmations() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.State == StateCountdown && r.StartRitual.Ritual == StartRitualConfirm
+}
+
+// BeginStartConfirmations clears the checklist for a new confirm start.
+func (r *Room) BeginStartConfirmations() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.StartConfirmations = make(map[string]bool)
+}
+
+// ConfirmStart records that playerID has seen their role and reports whether
+// every active player has now confirmed.
+func (r *Room) ConfirmStart(playerID string) (bool, error) {
+	if !r.AwaitingStartConfirmations() {
+		return false, ErrStartConfirmNotPending
+	}
+
+	r.mu.Lock()
+	if r.StartConfirmations == nil {
+		r.StartConfirmations = make(map[string]bool)
+	}
+	r.StartConfirmations[playerID] = true
+	r.mu.Unlock()
+
+	confirmed, total := r.StartConfirmationProgress()
+	return confirmed == total, nil
+}
+
+// HasConfirmedStart reports whether playerID tapped through the confirm start.
+func (r *Room) HasConfirmedStart(playerID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.StartConfirmations[playerID]
+}
+
+// StartConfirmationProgress counts active players who have confirmed.
+func (r *Room) StartConfirmationProgress() (confirmed, total int) {
+	for _, player := range r.GetActivePlayers() {
+		total++
+		if r.HasConfirmedStart(player.ID) {
+			confirmed++
+		}
+	}
+	return confirmed, total
+}
+
+// CancelStart takes a room in the countdown state back to the lobby and wipes
+// the dealt roles, so the Room Operator can fix the setup and deal again.
+// Players keep their seats.
+func (r *Room) CancelStart() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.State != StateCountdown {
+		return ErrNotCountingDown
+	}
+	r.clearDeal()
+	return nil
+}
+
+// clearDeal takes the room back to the lobby and wipes the dealt roles.
+// The caller holds r.mu.
+func (r *Room) clearDeal() {
+	r.State = StateLobby
+	r.StartedAt = time.Time{}
+	r.StartedBy = ""
+	r.CountdownRemaining = 0
+	r.RevealAt = time.Time{}
+	r.StartConfirmations = nil
+	r.DealPending = false
+	r.LeaderRevealed = false
+	for _, player := range r.Players {
+		player.Role = nil
+		player.RoleRevealed = false
+		player.FaceUp = true
+		player.KnownInfo = nil
+		player.AbilityState = ability.NewAbilityState()
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoom_ConfirmStart(t *testing.T) {
+	room := &Room{Code: "READY", State: StateCountdown, Players: make(map[string]*Player)}
+	host := NewPlayer("host", "Host", "s0")
+	host.IsHost = true
+	room.Players[host.ID] = host
+	for _, id := range []string{"p1", "p2"} {
+		room.Players[id] = NewPlayer(id, id, "s-"+id)
+	}
+
+	if _, err := room.ConfirmStart("p1"); err != ErrStartConfirmNotPending {
+		t.Fatalf("expected a countdown start to refuse confirmations, got %v", err)
+	}
+
+	room.StartRitual.Ritual = StartRitualConfirm
+	room.BeginStartConfirmations()
+	if all, err := room.ConfirmStart("p1"); err != nil || all {
+		t.Fatalf("expected one of two confirmations to keep waiting, got %v %v", all, err)
+	}
+	if all, _ := room.ConfirmStart("p1"); all {
+		t.Fatal("expected a repeated tap to count once")
+	}
+	if all, _ := room.ConfirmStart("p2"); !all {
+		t.Fatal("expected every active player confirming to complete the start; the host does not play")
+	}
+
+	if got := (StartRitualSettings{}).ConfirmTimeout(); got != DefaultStartConfirmTimeout {
+		t.Errorf("expected the default timeout, got %s", got)
+	}
+	if got := (StartRitualSettings{Timeout: time.Minute}).ConfirmTimeout(); got != time.Minute {
+		t.Errorf("expected the configured timeout, got %s", got)
+	}
+}
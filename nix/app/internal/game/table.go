@@ -0,0 +1,167 @@
+package game
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"treacherest/internal/config"
+)
+
+// TablePhase is where a pass-the-phone table is in its deal
+type TablePhase string
+
+const (
+	TableSeating TablePhase = "seating" // players add their names on the shared device
+	TablePassing TablePhase = "passing" // the device is passed to the current player
+	TableShowing TablePhase = "showing" // the current player's role is on screen
+	TableDone    TablePhase = "done"    // everyone has seen their role
+)
+
+var (
+	ErrTableNotSeating  = errors.New("roles have already been dealt at this table")
+	ErrTableFull        = errors.New("this table is full")
+	ErrTableTooFew      = errors.New("not enough players to deal")
+	ErrTableWrongPhase  = errors.New("that isn't possible at this point of the deal")
+	ErrTableNoCardDecks = errors.New("no cards are available to deal")
+)
+
+// Table is a pass-the-phone game for groups playing in person: one shared
+// device seats everyone, deals the roles and shows each player theirs in
+// turn, hiding it again before the device is passed on. A table has no
+// room, stream or per-player session; it lives only on the device that
+// started it.
+type Table struct {
+	ID        string
+	CreatedAt time.Time
+
+	mu      sync.Mutex
+	players []*Player
+	phase   TablePhase
+	turn    int // index of the player whose role is next, or on screen
+}
+
+// NewTable returns an empty table ready to seat players
+func NewTable(now time.Time) *Table {
+	return &Table{ID: newPublicToken(), CreatedAt: now, phase: TableSeating}
+}
+
+// Phase returns where the table is in its deal
+func (t *Table) Phase() TablePhase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.phase
+}
+
+// PlayerNames returns the seated players' names in seating order
+func (t *Table) PlayerNames() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	names := make([]string, len(t.players))
+	for i, player := range t.players {
+		names[i] = player.Name
+	}
+	return names
+}
+
+// Seat adds a player while the table is seating, up to maxPlayers
+func (t *Table) Seat(id, name string, maxPlayers int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TableSeating {
+		return ErrTableNotSeating
+	}
+	if len(t.players) >= maxPlayers {
+		return ErrTableFull
+	}
+	t.players = append(t.players, NewPlayer(id, name, ""))
+	return nil
+}
+
+// Deal assigns roles the same way a room without a role configuration does
+// and passes the device to the first player seated
+func (t *Table) Deal(cardService *CardService, cfg *config.ServerConfig) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TableSeating {
+		return ErrTableNotSeating
+	}
+	if len(t.players) < max(cfg.Server.MinPlayersPerRoom, 1) {
+		return ErrTableTooFew
+	}
+	if cardService == nil {
+		return ErrTableNoCardDecks
+	}
+
+	AssignRoles(t.players, cardService)
+	ApplyRoleKnowledge(t.players, cfg)
+	for _, player := range t.players {
+		// Roles are only ever shown to their owner, one at a time
+		player.FaceUp = false
+	}
+	t.phase = TablePassing
+	t.turn = 0
+	return nil
+}
+
+// Current returns the player the device should be with, or nil while
+// seating and once everyone has seen their role
+func (t *Table) Current() *Player {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TablePassing && t.phase != TableShowing {
+		return nil
+	}
+	return t.players[t.turn]
+}
+
+// Reveal shows the current player's role
+func (t *Table) Reveal() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TablePassing {
+		return ErrTableWrongPhase
+	}
+	t.phase = TableShowing
+	return nil
+}
+
+// Hide takes the current player's role off screen and passes the device on,
+// finishing the deal after the last player
+func (t *Table) Hide() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TableShowing {
+		return ErrTableWrongPhase
+	}
+	t.turn++
+	if t.turn == len(t.players) {
+		t.phase = TableDone
+	} else {
+		t.phase = TablePassing
+	}
+	return nil
+}
+
+// Leader returns the player dealt the Leader, who plays face up in Treachery,
+// once the deal is done; nil before then or in a leaderless deal
+func (t *Table) Leader() *Player {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.phase != TableDone {
+		return nil
+	}
+	for _, player := range t.players {
+		if player.Role != nil && player.Role.GetRoleType() == RoleLeader {
+			return player
+		}
+	}
+	return nil
+}
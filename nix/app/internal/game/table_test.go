@@ -0,0 +1,58 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+)
+
+func TestTableDealsAndPassesInTurn(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Server.MinPlayersPerRoom = 4
+	table := NewTable(time.Now())
+
+	for i, name := range []string{"Ann", "Ben", "Cat"} {
+		if err := table.Seat(string(rune('a'+i)), name, 4); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.Deal(createMockCardService(), cfg); err != ErrTableTooFew {
+		t.Fatalf("expected three players to be too few, got %v", err)
+	}
+	table.Seat("d", "Dan", 4)
+	if err := table.Seat("e", "Eve", 4); err != ErrTableFull {
+		t.Fatalf("expected a fifth seat to be refused, got %v", err)
+	}
+	if err := table.Deal(createMockCardService(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Seat("e", "Eve", 8); err != ErrTableNotSeating {
+		t.Fatalf("expected seating to close once dealt, got %v", err)
+	}
+
+	for _, name := range []string{"Ann", "Ben", "Cat", "Dan"} {
+		current := table.Current()
+		if current == nil || current.Name != name || table.Phase() != TablePassing {
+			t.Fatalf("expected the device to pass to %s, got %+v in %s", name, current, table.Phase())
+		}
+		if current.Role == nil {
+			t.Fatalf("expected %s to be dealt a role", name)
+		}
+		if err := table.Hide(); err != ErrTableWrongPhase {
+			t.Fatalf("expected hiding a role not yet shown to be refused, got %v", err)
+		}
+		table.Reveal()
+		if table.Phase() != TableShowing || table.Leader() != nil {
+			t.Fatal("expected the role on screen and no public Leader yet")
+		}
+		table.Hide()
+	}
+
+	if table.Phase() != TableDone || table.Current() != nil {
+		t.Fatalf("expected the deal to be done, got %s", table.Phase())
+	}
+	if leader := table.Leader(); leader == nil || leader.Role.GetRoleType() != RoleLeader {
+		t.Fatalf("expected the Leader to be public once everyone has seen their role, got %+v", leader)
+	}
+}
@@ -0,0 +1,42 @@
+package game
+
+import "fmt"
+
+// ValidationSeverity is how a start check affects starting the game
+type ValidationSeverity string
+
+const (
+	ValidationOK       ValidationSeverity = "ok"       // The check passes
+	ValidationWarning  ValidationSeverity = "warning"  // The game can start, but not quite as configured
+	ValidationBlocking ValidationSeverity = "blocking" // The game cannot start until this is fixed
+)
+
+// Start checks reported by GetValidationState
+const (
+	ValidationCheckPlayers = "players"
+	ValidationCheckLobby   = "lobby"
+	ValidationCheckRoles   = "roles"
+	ValidationCheckLeader  = "leader"
+)
+
+// ValidationIssue is one line of the start checklist. Section is the id of
+// the page element where the Room Operator fixes it, empty when there is
+// nothing to link to.
+type ValidationIssue struct {
+	Check    string             `json:"check"`
+	Severity ValidationSeverity `json:"severity"`
+	Message  string             `json:"message"`
+	Section  string             `json:"section,omitempty"`
+}
+
+func (s *ValidationState) addIssue(check string, severity ValidationSeverity, section, message string) {
+	s.Issues = append(s.Issues, ValidationIssue{Check: check, Severity: severity, Message: message, Section: section})
+}
+
+// playerCountText is "1 player" or "N players"
+func playerCountText(count int) string {
+	if count == 1 {
+		return "1 player"
+	}
+	return fmt.Sprintf("%d players", count)
+}
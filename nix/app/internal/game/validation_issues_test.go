@@ -0,0 +1,104 @@
+package game
+
+import (
+	"testing"
+	"treacherest/internal/config"
+)
+
+func issueFor(t *testing.T, state ValidationState, check string) ValidationIssue {
+	t.Helper()
+	for _, issue := range state.Issues {
+		if issue.Check == check {
+			return issue
+		}
+	}
+	t.Fatalf("expected a %q issue, got %+v", check, state.Issues)
+	return ValidationIssue{}
+}
+
+func checklistRoom(leaders, guardians int, leaderless bool) *Room {
+	room := &Room{
+		Code:    "LIST1",
+		State:   StateLobby,
+		Players: make(map[string]*Player),
+		RoleConfig: &RoleConfiguration{
+			PresetName:          "custom",
+			AllowLeaderlessGame: leaderless,
+			RoleTypes: map[string]*RoleTypeConfig{
+				"Leader":   {Count: leaders},
+				"Guardian": {Count: guardians},
+			},
+		},
+	}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		room.Players[id] = NewPlayer(id, id, "session-"+id)
+	}
+	return room
+}
+
+func TestValidationIssues(t *testing.T) {
+	roleService := NewRoleConfigService(config.DefaultConfig())
+
+	t.Run("lists every passing check", func(t *testing.T) {
+		state := checklistRoom(1, 2, false).GetValidationState(roleService)
+		if !state.CanStart {
+			t.Fatalf("expected the room to start, got %q", state.ValidationMessage)
+		}
+		for _, check := range []string{ValidationCheckPlayers, ValidationCheckRoles, ValidationCheckLeader} {
+			if issue := issueFor(t, state, check); issue.Severity != ValidationOK {
+				t.Errorf("expected %s to pass, got %+v", check, issue)
+			}
+		}
+		if got := issueFor(t, state, ValidationCheckPlayers).Message; got != "3 players joined" {
+			t.Errorf("unexpected players message %q", got)
+		}
+	})
+
+	t.Run("reports a missing Leader alongside too few roles", func(t *testing.T) {
+		state := checklistRoom(0, 1, false).GetValidationState(roleService)
+		if state.CanStart {
+			t.Fatal("expected the start to be blocked")
+		}
+		roles := issueFor(t, state, ValidationCheckRoles)
+		if roles.Severity != ValidationBlocking || roles.Section != "treachery-role-counts" {
+			t.Errorf("expected blocking roles issue linking to the role counts, got %+v", roles)
+		}
+		leader := issueFor(t, state, ValidationCheckLeader)
+		if leader.Severity != ValidationBlocking || leader.Section != "allow-leaderless" {
+			t.Errorf("expected blocking leader issue linking to the leaderless toggle, got %+v", leader)
+		}
+		if state.ValidationMessage != roles.Message {
+			t.Errorf("expected the message to stay the first blocking issue, got %q", state.ValidationMessage)
+		}
+	})
+
+	t.Run("warns about a leaderless game", func(t *testing.T) {
+		state := checklistRoom(0, 3, true).GetValidationState(roleService)
+		if !state.CanStart {
+			t.Fatalf("expected a leaderless game to start, got %q", state.ValidationMessage)
+		}
+		if leader := issueFor(t, state, ValidationCheckLeader); leader.Severity != ValidationWarning || leader.Message != "Leaderless game" {
+			t.Errorf("expected a leaderless warning, got %+v", leader)
+		}
+	})
+
+	t.Run("blocks an empty room", func(t *testing.T) {
+		state := (&Room{Code: "LIST2", State: StateLobby, Players: make(map[string]*Player)}).GetValidationState(roleService)
+		if len(state.Issues) != 1 || state.Issues[0].Severity != ValidationBlocking || state.Issues[0].Check != ValidationCheckPlayers {
+			t.Errorf("expected a single blocking players issue, got %+v", state.Issues)
+		}
+	})
+
+	t.Run("hides role checks without role counts", func(t *testing.T) {
+		redacted := checklistRoom(0, 1, false).GetValidationState(roleService).WithoutRoleCounts()
+		roles := issueFor(t, redacted, ValidationCheckRoles)
+		if roles.Message != "Waiting for the Room Operator to finish role setup" || roles.Section != "" {
+			t.Errorf("expected a neutral roles issue, got %+v", roles)
+		}
+		for _, issue := range redacted.Issues {
+			if issue.Check == ValidationCheckLeader {
+				t.Errorf("expected the leader check to be hidden, got %+v", issue)
+			}
+		}
+	})
+}
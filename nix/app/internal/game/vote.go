@@ -0,0 +1,215 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoOpenVote        = errors.New("there is no open vote")
+	ErrVoteAlreadyOpen   = errors.New("a vote is already open")
+	ErrInvalidVoteOption = errors.New("that is not an option in this vote")
+	ErrVoteChangeUsed    = errors.New("you have already changed your vote once")
+	ErrVoteNeedsOptions  = errors.New("a vote needs at least two options")
+)
+
+// VoteOption is one choice on a ballot.
+type VoteOption struct {
+	ID    string
+	Label string
+}
+
+// Vote is a generic ballot: one vote per voter, changeable once, tallied on close.
+// Vote methods are not synchronized; Room wrappers hold the room lock.
+type Vote struct {
+	Question    string
+	Options     []VoteOption
+	Ballots     map[string]string // voter ID -> option ID
+	Changed     map[string]bool   // voters who have used their one change
+	PublicTally bool              // Show live tallies to voters, not just the Room Operator
+	OpenedAt    time.Time
+}
+
+// VoteTally is the count for one option.
+type VoteTally struct {
+	Option VoteOption
+	Count  int
+}
+
+// VoteResult summarizes a closed vote.
+type VoteResult struct {
+	Question string
+	Tallies  []VoteTally
+	Winners  []VoteOption // More than one winner means a tie
+	Ballots  int
+}
+
+// NewVote creates an open vote. Options must be distinct and at least two.
+func NewVote(question string, options []VoteOption, publicTally bool) (*Vote, error) {
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option.ID == "" || seen[option.ID] {
+			return nil, ErrInvalidVoteOption
+		}
+		seen[option.ID] = true
+	}
+	if len(options) < 2 {
+		return nil, ErrVoteNeedsOptions
+	}
+
+	return &Vote{
+		Question:    question,
+		Options:     options,
+		Ballots:     make(map[string]string),
+		Changed:     make(map[string]bool),
+		PublicTally: publicTally,
+		OpenedAt:    time.Now(),
+	}, nil
+}
+
+// Cast records a ballot. A voter may change their choice exactly once.
+func (v *Vote) Cast(voterID, optionID string) error {
+	if !v.hasOption(optionID) {
+		return ErrInvalidVoteOption
+	}
+
+	previous, voted := v.Ballots[voterID]
+	switch {
+	case !voted:
+		v.Ballots[voterID] = optionID
+	case previous == optionID:
+		// Re-casting the same choice is a no-op
+	case v.Changed[voterID]:
+		return ErrVoteChangeUsed
+	default:
+		v.Ballots[voterID] = optionID
+		v.Changed[voterID] = true
+	}
+	return nil
+}
+
+// Choice returns the option a voter picked, if any.
+func (v *Vote) Choice(voterID string) (string, bool) {
+	optionID, ok := v.Ballots[voterID]
+	return optionID, ok
+}
+
+// CanChange reports whether a voter may still change their ballot.
+func (v *Vote) CanChange(voterID string) bool {
+	return !v.Changed[voterID]
+}
+
+// Result tallies the ballots in option order.
+func (v *Vote) Result() VoteResult {
+	counts := make(map[string]int, len(v.Options))
+	for _, optionID := range v.Ballots {
+		counts[optionID]++
+	}
+
+	result := VoteResult{Question: v.Question, Ballots: len(v.Ballots)}
+	best := 0
+	for _, option := range v.Options {
+		count := counts[option.ID]
+		result.Tallies = append(result.Tallies, VoteTally{Option: option, Count: count})
+		if count > best {
+			best = count
+		}
+	}
+	if best > 0 {
+		for _, tally := range result.Tallies {
+			if tally.Count == best {
+				result.Winners = append(result.Winners, tally.Option)
+			}
+		}
+	}
+	return result
+}
+
+// Summary renders the result as a single game log line.
+func (res VoteResult) Summary() string {
+	parts := make([]string, 0, len(res.Tallies))
+	tallies := make([]VoteTally, len(res.Tallies))
+	copy(tallies, res.Tallies)
+	sort.SliceStable(tallies, func(i, j int) bool { return tallies[i].Count > tallies[j].Count })
+	for _, tally := range tallies {
+		parts = append(parts, fmt.Sprintf("%s %d", tally.Option.Label, tally.Count))
+	}
+
+	outcome := "no votes cast"
+	switch len(res.Winners) {
+	case 0:
+	case 1:
+		outcome = res.Winners[0].Label
+	default:
+		labels := make([]string, len(res.Winners))
+		for i, winner := range res.Winners {
+			labels[i] = winner.Label
+		}
+		outcome = "tie between " + strings.Join(labels, " and ")
+	}
+	return fmt.Sprintf("Vote %q closed: %s (%s)", res.Question, outcome, strings.Join(parts, ", "))
+}
+
+func (v *Vote) hasOption(optionID string) bool {
+	for _, option := range v.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayerVoteOptions builds one option per living player, e.g. for an exile vote.
+func PlayerVoteOptions(players []*Player) []VoteOption {
+	options := make([]VoteOption, 0, len(players))
+	for _, p := range players {
+		options = append(options, VoteOption{ID: p.ID, Label: p.Name})
+	}
+	return options
+}
+
+// OpenVote starts the room's vote.
+func (r *Room) OpenVote(question string, options []VoteOption, publicTally bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Vote != nil {
+		return ErrVoteAlreadyOpen
+	}
+	vote, err := NewVote(question, options, publicTally)
+	if err != nil {
+		return err
+	}
+	r.Vote = vote
+	return nil
+}
+
+// CastVote records a ballot in the room's open vote.
+func (r *Room) CastVote(voterID, optionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Vote == nil {
+		return ErrNoOpenVote
+	}
+	return r.Vote.Cast(voterID, optionID)
+}
+
+// CloseVote ends the open vote and writes its result to the game log.
+func (r *Room) CloseVote() (VoteResult, error) {
+	r.mu.Lock()
+	if r.Vote == nil {
+		r.mu.Unlock()
+		return VoteResult{}, ErrNoOpenVote
+	}
+	result := r.Vote.Result()
+	r.Vote = nil
+	r.LastVoteResult = &result
+	r.mu.Unlock()
+
+	r.AppendLog(result.Summary())
+	return result, nil
+}
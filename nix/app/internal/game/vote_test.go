@@ -0,0 +1,96 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func voteOptions() []VoteOption {
+	return []VoteOption{{ID: "a", Label: "Alice"}, {ID: "b", Label: "Bob"}, {ID: "c", Label: "Carol"}}
+}
+
+func TestNewVoteValidatesOptions(t *testing.T) {
+	if _, err := NewVote("Exile", voteOptions()[:1], false); !errors.Is(err, ErrVoteNeedsOptions) {
+		t.Fatalf("expected ErrVoteNeedsOptions, got %v", err)
+	}
+	dup := []VoteOption{{ID: "a", Label: "A"}, {ID: "a", Label: "Again"}}
+	if _, err := NewVote("Exile", dup, false); !errors.Is(err, ErrInvalidVoteOption) {
+		t.Fatalf("expected ErrInvalidVoteOption, got %v", err)
+	}
+}
+
+func TestVoteAllowsOneChange(t *testing.T) {
+	vote, err := NewVote("Exile", voteOptions(), false)
+	if err != nil {
+		t.Fatalf("new vote: %v", err)
+	}
+
+	if err := vote.Cast("p1", "a"); err != nil {
+		t.Fatalf("first cast: %v", err)
+	}
+	if err := vote.Cast("p1", "a"); err != nil {
+		t.Fatalf("re-casting the same option should be a no-op: %v", err)
+	}
+	if !vote.CanChange("p1") {
+		t.Fatal("re-casting the same option should not use up the change")
+	}
+	if err := vote.Cast("p1", "b"); err != nil {
+		t.Fatalf("first change: %v", err)
+	}
+	if err := vote.Cast("p1", "c"); !errors.Is(err, ErrVoteChangeUsed) {
+		t.Fatalf("expected ErrVoteChangeUsed, got %v", err)
+	}
+	if choice, _ := vote.Choice("p1"); choice != "b" {
+		t.Fatalf("expected choice b, got %q", choice)
+	}
+	if err := vote.Cast("p2", "zzz"); !errors.Is(err, ErrInvalidVoteOption) {
+		t.Fatalf("expected ErrInvalidVoteOption, got %v", err)
+	}
+}
+
+func TestVoteResultWinnersAndTies(t *testing.T) {
+	vote, _ := NewVote("Exile", voteOptions(), false)
+	if res := vote.Result(); len(res.Winners) != 0 || !strings.Contains(res.Summary(), "no votes cast") {
+		t.Fatalf("expected no winner without ballots, got %+v", res)
+	}
+
+	vote.Cast("p1", "a")
+	vote.Cast("p2", "b")
+	res := vote.Result()
+	if len(res.Winners) != 2 || !strings.Contains(res.Summary(), "tie between Alice and Bob") {
+		t.Fatalf("expected a tie, got %q", res.Summary())
+	}
+
+	vote.Cast("p3", "b")
+	res = vote.Result()
+	if len(res.Winners) != 1 || res.Winners[0].ID != "b" || res.Ballots != 3 {
+		t.Fatalf("expected Bob to win, got %+v", res)
+	}
+}
+
+func TestRoomCloseVoteWritesLog(t *testing.T) {
+	room := &Room{Code: "TEST1", Players: make(map[string]*Player)}
+
+	if _, err := room.CloseVote(); !errors.Is(err, ErrNoOpenVote) {
+		t.Fatalf("expected ErrNoOpenVote, got %v", err)
+	}
+	if err := room.OpenVote("Exile", voteOptions(), false); err != nil {
+		t.Fatalf("open vote: %v", err)
+	}
+	if err := room.OpenVote("Again", voteOptions(), false); !errors.Is(err, ErrVoteAlreadyOpen) {
+		t.Fatalf("expected ErrVoteAlreadyOpen, got %v", err)
+	}
+	room.CastVote("p1", "c")
+
+	if _, err := room.CloseVote(); err != nil {
+		t.Fatalf("close vote: %v", err)
+	}
+	if room.Vote != nil || room.LastVoteResult == nil {
+		t.Fatal("expected the vote to be closed with a stored result")
+	}
+	entries := room.GetLog()
+	if len(entries) != 1 || !strings.Contains(entries[0].Message, "Carol") {
+		t.Fatalf("expected the result in the game log, got %+v", entries)
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// WatchLink is a revocable, read-only share link for remote spectators.
+type WatchLink struct {
+	Token     string
+	CreatedAt time.Time
+}
+
+// CreateWatchLink issues a new share link for the room.
+func (r *Room) CreateWatchLink() WatchLink {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	link := WatchLink{Token: newPublicToken(), CreatedAt: time.Now()}
+	r.WatchLinks = append(r.WatchLinks, link)
+	return link
+}
+
+// RevokeWatchLink removes a share link. It reports whether the link existed.
+func (r *Room) RevokeWatchLink(token string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, link := range r.WatchLinks {
+		if link.Token == token {
+			r.WatchLinks = append(r.WatchLinks[:i], r.WatchLinks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasWatchLink reports whether token is a live share link for the room.
+func (r *Room) HasWatchLink(token string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if token == "" {
+		return false
+	}
+	for _, link := range r.WatchLinks {
+		if subtle.ConstantTimeCompare([]byte(link.Token), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWatchLinks returns a copy of the room's share links, oldest first.
+func (r *Room) GetWatchLinks() []WatchLink {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	links := make([]WatchLink, len(r.WatchLinks))
+	copy(links, r.WatchLinks)
+	return links
+}
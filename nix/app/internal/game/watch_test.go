@@ -0,0 +1,34 @@
+package game
+
+import "testing"
+
+func TestRoom_WatchLinks(t *testing.T) {
+	room := &Room{Code: "WATCH", Players: make(map[string]*Player)}
+
+	first := room.CreateWatchLink()
+	second := room.CreateWatchLink()
+	if first.Token == second.Token {
+		t.Fatal("watch links should have distinct tokens")
+	}
+	if !room.HasWatchLink(first.Token) || !room.HasWatchLink(second.Token) {
+		t.Fatal("issued watch links should be valid")
+	}
+	if room.HasWatchLink("") {
+		t.Error("empty token should never be valid")
+	}
+
+	if !room.RevokeWatchLink(first.Token) {
+		t.Fatal("expected revoke to find the link")
+	}
+	if room.HasWatchLink(first.Token) {
+		t.Error("revoked link should no longer be valid")
+	}
+	if room.RevokeWatchLink(first.Token) {
+		t.Error("revoking twice should report the link as missing")
+	}
+
+	links := room.GetWatchLinks()
+	if len(links) != 1 || links[0].Token != second.Token {
+		t.Fatalf("expected only the second link to remain, got %+v", links)
+	}
+}
@@ -0,0 +1,259 @@
+// Package graphql executes read-only GraphQL queries against a schema built
+// from Go resolvers. It covers what dashboards send: query operations with
+// fields, aliases, arguments, variables and __typename. Fragments,
+// directives, mutations and introspection are refused with an error.
+//
+// A schema is a tree of Objects. Each Field resolves its value from the
+// parent's; a Guard runs first and hides the field, with an error at its
+// path, from callers it refuses, so auth is decided per field.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// maxDepth bounds how deeply a query can nest, so one request can't walk
+// every room's every player's every event
+const maxDepth = 8
+
+// Object is a GraphQL object type
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is one field of an Object. Type is the object type its value (or
+// each element of its list value) has, nil for scalars.
+type Field struct {
+	Type    *Object
+	Resolve func(ctx context.Context, source any, args Args) (any, error)
+	Guard   func(ctx context.Context, source any) error
+}
+
+// Args holds a field's arguments, variables already substituted
+type Args map[string]any
+
+// String returns the named argument as a string, empty when it's absent or
+// not a string
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns the named argument as an int, def when it's absent or not a
+// whole number. JSON variables arrive as float64.
+func (a Args) Int(name string, def int) int {
+	switch v := a[name].(type) {
+	case int:
+		return v
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	}
+	return def
+}
+
+// Schema is the set of types a query runs against
+type Schema struct {
+	Query *Object
+}
+
+// Request is a GraphQL request as it's posted over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is a query's result. Data is absent when the request couldn't
+// run at all.
+type Response struct {
+	Data   any     `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error is one problem the query met; Path locates the field it nulled
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Execute runs req's operation against the schema
+func (s *Schema) Execute(ctx context.Context, req Request) Response {
+	ops, err := parseDocument(req.Query)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	op, err := pickOperation(ops, req.OperationName)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	variables := make(map[string]any, len(op.defaults)+len(req.Variables))
+	for name, v := range op.defaults {
+		variables[name] = v
+	}
+	for name, v := range req.Variables {
+		variables[name] = v
+	}
+
+	e := &executor{ctx: ctx, variables: variables}
+	data := e.object(s.Query, nil, op.selection, nil)
+	return Response{Data: data, Errors: e.errors}
+}
+
+func pickOperation(ops []operation, name string) (operation, error) {
+	if name == "" {
+		if len(ops) > 1 {
+			return operation{}, fmt.Errorf("operationName is required with more than one operation")
+		}
+		return ops[0], nil
+	}
+	for _, op := range ops {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return operation{}, fmt.Errorf("unknown operation %q", name)
+}
+
+type executor struct {
+	ctx       context.Context
+	variables map[string]any
+	errors    []Error
+}
+
+func (e *executor) fail(path []any, format string, args ...any) {
+	e.errors = append(e.errors, Error{Message: fmt.Sprintf(format, args...), Path: append([]any(nil), path...)})
+}
+
+// object resolves the selection on source, an instance of t
+func (e *executor) object(t *Object, source any, set []selection, path []any) *fields {
+	result := &fields{}
+	for _, sel := range set {
+		fieldPath := append(path[:len(path):len(path)], sel.alias)
+		if sel.name == "__typename" {
+			result.set(sel.alias, t.Name)
+			continue
+		}
+		result.set(sel.alias, e.field(t, source, sel, fieldPath))
+	}
+	return result
+}
+
+func (e *executor) field(t *Object, source any, sel selection, path []any) any {
+	f, ok := t.Fields[sel.name]
+	if !ok {
+		e.fail(path, "%s has no field %q", t.Name, sel.name)
+		return nil
+	}
+	switch {
+	case f.Type != nil && len(sel.selection) == 0:
+		e.fail(path, "%s.%s needs a selection of subfields", t.Name, sel.name)
+		return nil
+	case f.Type == nil && len(sel.selection) > 0:
+		e.fail(path, "%s.%s has no subfields", t.Name, sel.name)
+		return nil
+	case f.Type != nil && depth(path) > maxDepth:
+		e.fail(path, "the query nests deeper than %d levels", maxDepth)
+		return nil
+	}
+
+	if f.Guard != nil {
+		if err := f.Guard(e.ctx, source); err != nil {
+			e.fail(path, "%s", err)
+			return nil
+		}
+	}
+	args := make(Args, len(sel.args))
+	for name, v := range sel.args {
+		if v.variable == "" {
+			args[name] = v.literal
+		} else if value, ok := e.variables[v.variable]; ok {
+			args[name] = value
+		}
+	}
+	value, err := f.Resolve(e.ctx, source, args)
+	if err != nil {
+		e.fail(path, "%s", err)
+		return nil
+	}
+	if f.Type == nil || isNil(value) {
+		return value
+	}
+
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice {
+		return e.object(f.Type, value, sel.selection, path)
+	}
+	items := make([]any, list.Len())
+	for i := range items {
+		items[i] = e.object(f.Type, list.Index(i).Interface(), sel.selection, append(path[:len(path):len(path)], i))
+	}
+	return items
+}
+
+// depth counts the fields, not the list indexes, on path
+func depth(path []any) int {
+	n := 0
+	for _, p := range path {
+		if _, ok := p.(string); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// fields is an object's result, encoded with its fields in query order as
+// the spec asks
+type fields struct {
+	keys   []string
+	values map[string]any
+}
+
+func (f *fields) set(key string, v any) {
+	if f.values == nil {
+		f.values = make(map[string]any)
+	}
+	if _, ok := f.values[key]; !ok {
+		f.keys = append(f.keys, key)
+	}
+	f.values[key] = v
+}
+
+func (f *fields) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range f.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteByte(':')
+		v, err := json.Marshal(f.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
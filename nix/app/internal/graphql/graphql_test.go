@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type adminKey struct{}
+
+type testPlayer struct {
+	Name   string
+	Secret string
+}
+
+func testSchema() *Schema {
+	player := &Object{Name: "Player", Fields: map[string]*Field{
+		"name": {Resolve: func(_ context.Context, source any, _ Args) (any, error) {
+			return source.(testPlayer).Name, nil
+		}},
+		"secret": {
+			Guard: func(ctx context.Context, _ any) error {
+				if ctx.Value(adminKey{}) == nil {
+					return errors.New("secret is for admins")
+				}
+				return nil
+			},
+			Resolve: func(_ context.Context, source any, _ Args) (any, error) {
+				return source.(testPlayer).Secret, nil
+			},
+		},
+	}}
+	players := []testPlayer{{"Alice", "leader"}, {"Bob", "traitor"}}
+	return &Schema{Query: &Object{Name: "Query", Fields: map[string]*Field{
+		"players": {Type: player, Resolve: func(_ context.Context, _ any, args Args) (any, error) {
+			return players[:args.Int("first", len(players))], nil
+		}},
+		"player": {Type: player, Resolve: func(_ context.Context, _ any, args Args) (any, error) {
+			for _, p := range players {
+				if p.Name == args.String("name") {
+					return p, nil
+				}
+			}
+			return nil, nil
+		}},
+	}}}
+}
+
+func run(t *testing.T, ctx context.Context, req Request) string {
+	t.Helper()
+	out, err := json.Marshal(testSchema().Execute(ctx, req))
+	if err != nil {
+		t.Fatalf("failed to encode the response: %v", err)
+	}
+	return string(out)
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "fields in query order",
+			req:  Request{Query: `{ players { name __typename } }`},
+			want: `{"data":{"players":[{"name":"Alice","__typename":"Player"},{"name":"Bob","__typename":"Player"}]}}`,
+		},
+		{
+			name: "aliases and arguments",
+			req:  Request{Query: `query { first: players(first: 1) { name } bob: player(name: "Bob") { name } }`},
+			want: `{"data":{"first":[{"name":"Alice"}],"bob":{"name":"Bob"}}}`,
+		},
+		{
+			name: "variables and their defaults",
+			req: Request{
+				Query:     `query Pick($name: String!, $first: Int = 1) { player(name: $name) { name } players(first: $first) { name } }`,
+				Variables: map[string]any{"name": "Alice"},
+			},
+			want: `{"data":{"player":{"name":"Alice"},"players":[{"name":"Alice"}]}}`,
+		},
+		{
+			name: "a missing object is null",
+			req:  Request{Query: `{ player(name: "Mallory") { name } }`},
+			want: `{"data":{"player":null}}`,
+		},
+		{
+			name: "a guarded field is nulled with an error at its path",
+			req:  Request{Query: `{ players(first: 1) { name secret } }`},
+			want: `{"data":{"players":[{"name":"Alice","secret":null}]},"errors":[{"message":"secret is for admins","path":["players",0,"secret"]}]}`,
+		},
+		{
+			name: "unknown fields",
+			req:  Request{Query: `{ rooms { code } }`},
+			want: `{"data":{"rooms":null},"errors":[{"message":"Query has no field \"rooms\"","path":["rooms"]}]}`,
+		},
+		{
+			name: "objects need subfields",
+			req:  Request{Query: `{ players }`},
+			want: `{"data":{"players":null},"errors":[{"message":"Query.players needs a selection of subfields","path":["players"]}]}`,
+		},
+		{
+			name: "the operation is picked by name",
+			req:  Request{Query: `query A { player(name: "Alice") { name } } query B { player(name: "Bob") { name } }`, OperationName: "B"},
+			want: `{"data":{"player":{"name":"Bob"}}}`,
+		},
+		{
+			name: "mutations are refused",
+			req:  Request{Query: `mutation { players { name } }`},
+			want: `{"errors":[{"message":"syntax error at 0: only queries are supported"}]}`,
+		},
+		{
+			name: "fragments are refused",
+			req:  Request{Query: `{ players { ...f } }`},
+			want: `{"errors":[{"message":"syntax error at 12: fragments are not supported"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(t, context.Background(), tt.req); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteGuardSeesContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), adminKey{}, true)
+	got := run(t, ctx, Request{Query: `{ player(name: "Bob") { secret } }`})
+	if want := `{"data":{"player":{"secret":"traitor"}}}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestExecuteLimitsDepth(t *testing.T) {
+	self := &Object{Name: "Node"}
+	self.Fields = map[string]*Field{
+		"next": {Type: self, Resolve: func(context.Context, any, Args) (any, error) { return struct{}{}, nil }},
+		"id":   {Resolve: func(context.Context, any, Args) (any, error) { return 1, nil }},
+	}
+	schema := &Schema{Query: &Object{Name: "Query", Fields: map[string]*Field{"node": self.Fields["next"]}}}
+
+	query := "{ node { " + strings.Repeat("next { ", maxDepth) + "id" + strings.Repeat(" }", maxDepth+1) + " }"
+	resp := schema.Execute(context.Background(), Request{Query: query})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "nests deeper") {
+		t.Errorf("expected the query refused for its depth, got %+v", resp.Errors)
+	}
+}
@@ -0,0 +1,452 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// operation is one parsed query
+type operation struct {
+	name      string
+	defaults  map[string]any // variable defaults
+	selection []selection
+}
+
+// selection is one field asked for, with its own sub-selection for objects
+type selection struct {
+	alias     string
+	name      string
+	args      map[string]value
+	selection []selection
+}
+
+// value is an argument literal, or a variable to look up at execution
+type value struct {
+	literal  any
+	variable string
+}
+
+// parseDocument parses the subset of GraphQL this package executes: query
+// operations with fields, aliases, arguments and variables
+func parseDocument(source string) ([]operation, error) {
+	p := &parser{src: source}
+	p.next()
+
+	var ops []operation
+	for p.tok.kind != tokEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("the document has no operations")
+	}
+	return ops, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func (p *parser) fail(format string, args ...any) error {
+	if p.err == nil {
+		p.err = fmt.Errorf("syntax error at %d: %s", p.tok.pos, fmt.Sprintf(format, args...))
+	}
+	return p.err
+}
+
+// next moves to the next token, skipping whitespace, commas and comments
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' || c == 0xEF || c == 0xBB || c == 0xBF {
+			p.pos++
+		} else if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		} else {
+			break
+		}
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok = token{kind: tokPunct, text: "...", pos: start}
+	case strings.IndexByte("{}()[]:$!=@", c) >= 0:
+		p.pos++
+		p.tok = token{kind: tokPunct, text: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokName, text: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		p.scanNumber(start)
+	case c == '"':
+		p.scanString(start)
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.tok = token{kind: tokPunct, text: string(r), pos: start}
+		p.fail("unexpected character %q", r)
+		p.pos = len(p.src)
+	}
+}
+
+func (p *parser) scanNumber(start int) {
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	kind := tokInt
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case isDigit(c):
+		case c == '.' || c == 'e' || c == 'E':
+			kind = tokFloat
+		case (c == '+' || c == '-') && kind == tokFloat:
+		default:
+			p.tok = token{kind: kind, text: p.src[start:p.pos], pos: start}
+			return
+		}
+		p.pos++
+	}
+	p.tok = token{kind: kind, text: p.src[start:p.pos], pos: start}
+}
+
+func (p *parser) scanString(start int) {
+	var b strings.Builder
+	p.pos++
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			p.tok = token{kind: tokString, text: b.String(), pos: start}
+			return
+		case '\n':
+			p.tok = token{kind: tokString, pos: start}
+			p.fail("unterminated string")
+			return
+		case '\\':
+			if p.pos+1 >= len(p.src) {
+				break
+			}
+			p.pos++
+			switch e := p.src[p.pos]; e {
+			case '"', '\\', '/':
+				b.WriteByte(e)
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if p.pos+4 >= len(p.src) {
+					p.fail("bad unicode escape")
+					return
+				}
+				code, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					p.fail("bad unicode escape")
+					return
+				}
+				b.WriteRune(rune(code))
+				p.pos += 4
+			default:
+				p.fail("bad escape \\%c", e)
+				return
+			}
+			p.pos++
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.tok = token{kind: tokString, pos: start}
+	p.fail("unterminated string")
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+func (p *parser) is(text string) bool {
+	return (p.tok.kind == tokPunct || p.tok.kind == tokName) && p.tok.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if p.err != nil {
+		return p.err
+	}
+	if !p.is(text) {
+		return p.fail("expected %q, got %q", text, p.tok.text)
+	}
+	p.next()
+	return p.err
+}
+
+func (p *parser) name() (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	if p.tok.kind != tokName {
+		return "", p.fail("expected a name, got %q", p.tok.text)
+	}
+	name := p.tok.text
+	p.next()
+	return name, p.err
+}
+
+func (p *parser) parseOperation() (operation, error) {
+	op := operation{defaults: make(map[string]any)}
+	if p.is("{") {
+		sel, err := p.parseSelectionSet()
+		op.selection = sel
+		return op, err
+	}
+
+	switch {
+	case p.is("query"):
+		p.next()
+	case p.is("mutation"), p.is("subscription"):
+		return op, p.fail("only queries are supported")
+	case p.is("fragment"):
+		return op, p.fail("fragments are not supported")
+	default:
+		return op, p.fail("expected a query, got %q", p.tok.text)
+	}
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		p.next()
+	}
+	if p.is("(") {
+		if err := p.parseVariableDefinitions(op.defaults); err != nil {
+			return op, err
+		}
+	}
+	if p.is("@") {
+		return op, p.fail("directives are not supported")
+	}
+	sel, err := p.parseSelectionSet()
+	op.selection = sel
+	return op, err
+}
+
+// parseVariableDefinitions reads ($name: Type = default, ...), keeping only
+// the defaults: arguments are checked by the resolvers that take them
+func (p *parser) parseVariableDefinitions(defaults map[string]any) error {
+	p.next()
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		if p.is("=") {
+			p.next()
+			v, err := p.parseValue(true)
+			if err != nil {
+				return err
+			}
+			defaults[name] = v.literal
+		}
+		if p.tok.kind == tokEOF {
+			return p.fail("unterminated variable definitions")
+		}
+	}
+	p.next()
+	return p.err
+}
+
+func (p *parser) parseType() error {
+	if p.is("[") {
+		p.next()
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	if p.is("!") {
+		p.next()
+	}
+	return p.err
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var set []selection
+	for !p.is("}") {
+		if p.is("...") {
+			return nil, p.fail("fragments are not supported")
+		}
+		sel, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, sel)
+		if p.tok.kind == tokEOF {
+			return nil, p.fail("unterminated selection set")
+		}
+	}
+	if len(set) == 0 {
+		return nil, p.fail("empty selection set")
+	}
+	p.next()
+	return set, p.err
+}
+
+func (p *parser) parseField() (selection, error) {
+	name, err := p.name()
+	if err != nil {
+		return selection{}, err
+	}
+	sel := selection{alias: name, name: name}
+	if p.is(":") {
+		p.next()
+		if sel.name, err = p.name(); err != nil {
+			return sel, err
+		}
+	}
+	if p.is("(") {
+		p.next()
+		sel.args = make(map[string]value)
+		for !p.is(")") {
+			argName, err := p.name()
+			if err != nil {
+				return sel, err
+			}
+			if err := p.expect(":"); err != nil {
+				return sel, err
+			}
+			if sel.args[argName], err = p.parseValue(false); err != nil {
+				return sel, err
+			}
+			if p.tok.kind == tokEOF {
+				return sel, p.fail("unterminated arguments")
+			}
+		}
+		p.next()
+	}
+	if p.is("@") {
+		return sel, p.fail("directives are not supported")
+	}
+	if p.is("{") {
+		if sel.selection, err = p.parseSelectionSet(); err != nil {
+			return sel, err
+		}
+	}
+	return sel, p.err
+}
+
+// parseValue reads an argument value; constant values can't hold variables
+func (p *parser) parseValue(constant bool) (value, error) {
+	if p.err != nil {
+		return value{}, p.err
+	}
+	tok := p.tok
+	switch {
+	case p.is("$"):
+		if constant {
+			return value{}, p.fail("a default can't use a variable")
+		}
+		p.next()
+		name, err := p.name()
+		return value{variable: name}, err
+	case p.is("["):
+		p.next()
+		list := []any{}
+		for !p.is("]") {
+			v, err := p.parseValue(true)
+			if err != nil {
+				return value{}, err
+			}
+			list = append(list, v.literal)
+			if p.tok.kind == tokEOF {
+				return value{}, p.fail("unterminated list")
+			}
+		}
+		p.next()
+		return value{literal: list}, p.err
+	case p.is("{"):
+		return value{}, p.fail("input objects are not supported")
+	}
+
+	p.next()
+	switch tok.kind {
+	case tokInt:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return value{}, p.fail("bad integer %s", tok.text)
+		}
+		return value{literal: n}, p.err
+	case tokFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return value{}, p.fail("bad number %s", tok.text)
+		}
+		return value{literal: f}, p.err
+	case tokString:
+		return value{literal: tok.text}, p.err
+	case tokName:
+		switch tok.text {
+		case "true":
+			return value{literal: true}, p.err
+		case "false":
+			return value{literal: false}, p.err
+		case "null":
+			return value{}, p.err
+		}
+		return value{literal: tok.text}, p.err // enum values are passed as strings
+	}
+	return value{}, p.fail("expected a value, got %q", tok.text)
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"treacherest/internal/game"
 	"treacherest/internal/game/ability"
+	localMiddleware "treacherest/internal/middleware"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -24,6 +25,9 @@ func (h *Handler) TriggerWearerAbility(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionAbility) {
+		return
+	}
 
 	player := room.GetPlayer(playerID)
 	if player == nil {
@@ -73,9 +77,10 @@ func (h *Handler) TriggerWearerAbility(w http.ResponseWriter, r *http.Request) {
 		log.Printf("🎭 Wearer ability for %s in room %s - X=0, no transformation", player.Name, roomCode)
 
 		h.eventBus.Publish(Event{
-			Type:     "role_revealed",
+			Type:     EventRoleRevealed,
 			RoomCode: room.Code,
 			Data:     room,
+			Actor:    h.requestActor(r, room),
 		})
 
 		w.WriteHeader(http.StatusOK)
@@ -161,9 +166,10 @@ func (h *Handler) TriggerWearerAbility(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event
 	h.eventBus.Publish(Event{
-		Type:     "ability_triggered",
+		Type:     EventAbilityTriggered,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -262,9 +268,10 @@ func (h *Handler) SelectWearerCard(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event
 	h.eventBus.Publish(Event{
-		Type:     "transformation_complete",
+		Type:     EventTransformationComplete,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -345,9 +352,10 @@ func (h *Handler) ConfirmAbility(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update all clients
 	h.eventBus.Publish(Event{
-		Type:     "ability_confirmed",
+		Type:     EventAbilityConfirmed,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -375,6 +383,9 @@ func (h *Handler) TriggerMetamorphAbility(w http.ResponseWriter, r *http.Request
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionAbility) {
+		return
+	}
 
 	player := room.GetPlayer(playerID)
 	if player == nil {
@@ -418,9 +429,10 @@ func (h *Handler) TriggerMetamorphAbility(w http.ResponseWriter, r *http.Request
 
 	// Publish event
 	h.eventBus.Publish(Event{
-		Type:     "metamorph_activated",
+		Type:     EventMetamorphActivated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -506,13 +518,14 @@ func (h *Handler) StealRole(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event
 	h.eventBus.Publish(Event{
-		Type:     "role_stolen",
+		Type:     EventRoleStolen,
 		RoomCode: room.Code,
 		Data: map[string]interface{}{
 			"room":    room,
 			"stealer": player,
 			"victim":  targetPlayer,
 		},
+		Actor: h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -561,9 +574,10 @@ func (h *Handler) EndMetamorphEffect(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event
 	h.eventBus.Publish(Event{
-		Type:     "metamorph_ended",
+		Type:     EventMetamorphEnded,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -580,6 +594,9 @@ func (h *Handler) EliminatePlayer(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionEliminate) {
+		return
+	}
 
 	requestingPlayer, ok := h.requireEffectivePlayer(w, r, room, roomCode)
 	if !ok {
@@ -619,12 +636,13 @@ func (h *Handler) EliminatePlayer(w http.ResponseWriter, r *http.Request) {
 
 	// Publish elimination event
 	h.eventBus.Publish(Event{
-		Type:     "player_eliminated",
+		Type:     EventPlayerEliminated,
 		RoomCode: room.Code,
 		Data: map[string]interface{}{
 			"room":              room,
 			"eliminated_player": targetPlayer,
 		},
+		Actor: h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -643,6 +661,9 @@ func (h *Handler) TriggerPuppetMasterAbility(w http.ResponseWriter, r *http.Requ
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionAbility) {
+		return
+	}
 
 	player := room.GetPlayer(playerID)
 	if player == nil {
@@ -713,9 +734,10 @@ func (h *Handler) TriggerPuppetMasterAbility(w http.ResponseWriter, r *http.Requ
 	log.Printf("🎭 Puppet Master ability triggered for %s in room %s", player.Name, roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "ability_triggered",
+		Type:     EventAbilityTriggered,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -764,6 +786,9 @@ func (h *Handler) PuppetMasterSelectPlayers(w http.ResponseWriter, r *http.Reque
 		SelectedPlayers []string `json:"selectedPlayers"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
 		log.Printf("🎭 Failed to parse JSON body: %v", err)
 		http.Error(w, "Failed to parse request body", http.StatusBadRequest)
 		return
@@ -790,9 +815,10 @@ func (h *Handler) PuppetMasterSelectPlayers(w http.ResponseWriter, r *http.Reque
 		log.Printf("🎭 Puppet Master redistribution skipped (fewer than 2 players selected) for %s in room %s", player.Name, roomCode)
 
 		h.eventBus.Publish(Event{
-			Type:     "ability_resolved",
+			Type:     EventAbilityResolved,
 			RoomCode: room.Code,
 			Data:     room,
+			Actor:    h.requestActor(r, room),
 		})
 
 		w.WriteHeader(http.StatusOK)
@@ -808,9 +834,10 @@ func (h *Handler) PuppetMasterSelectPlayers(w http.ResponseWriter, r *http.Reque
 	log.Printf("🎭 Puppet Master selected %d players for redistribution in room %s", len(validatedPlayers), roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "ability_updated",
+		Type:     EventAbilityUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -850,9 +877,10 @@ func (h *Handler) PuppetMasterSkip(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🎭 Puppet Master redistribution skipped for %s in room %s", player.Name, roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "ability_resolved",
+		Type:     EventAbilityResolved,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -892,9 +920,10 @@ func (h *Handler) PuppetMasterBack(w http.ResponseWriter, r *http.Request) {
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "ability_updated",
+		Type:     EventAbilityUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -940,6 +969,9 @@ func (h *Handler) PuppetMasterExecute(w http.ResponseWriter, r *http.Request) {
 		Assignments map[string]int `json:"assignments"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
 		log.Printf("🎭 Failed to parse JSON body in Execute: %v", err)
 		http.Error(w, "Failed to parse request body", http.StatusBadRequest)
 		return
@@ -1012,9 +1044,10 @@ func (h *Handler) PuppetMasterExecute(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🎭 Puppet Master redistribution executed by %s in room %s", player.Name, roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "puppet_master_redistribution",
+		Type:     EventPuppetMasterRedistribution,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -20,9 +20,9 @@ func TestTriggerWearerAbility(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room with CardPool
 	room, _ := memStore.CreateRoom()
@@ -177,9 +177,9 @@ func TestSelectWearerCard(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room with CardPool
 	room, _ := memStore.CreateRoom()
@@ -426,9 +426,9 @@ func TestWearerAbilityEventPublishing(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room with player and leader
 	room, _ := memStore.CreateRoom()
@@ -547,9 +547,9 @@ func TestConfirmAbility(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	t.Run("Leader can confirm ability successfully", func(t *testing.T) {
 		room, _ := memStore.CreateRoom()
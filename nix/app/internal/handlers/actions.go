@@ -101,7 +101,7 @@ func (h *Handler) StartGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// CRITICAL: Use the same validation function as SSE updates
-	roleService := game.NewRoleConfigService(h.config)
+	roleService := game.NewRoleConfigService(h.roomConfig(room))
 	validationState := room.GetValidationState(roleService)
 
 	log.Printf("🔍 Validation state: CanStart=%v, RequiredRoles=%d, ConfiguredRoles=%d, Message=%s",
@@ -149,29 +149,8 @@ func (h *Handler) StartGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Assign roles using room configuration
-	players := room.GetPlayers()
-	log.Printf("🎲 Assigning roles to %d players", len(players))
-	if h.cardService != nil {
-		if room.RoleConfig != nil {
-			log.Printf("🎲 Using role configuration: %+v", room.RoleConfig)
-			roleService := game.NewRoleConfigService(h.config)
-			game.AssignRolesWithConfig(players, h.cardService, room.RoleConfig, roleService)
-		} else {
-			// Fallback to legacy assignment
-			log.Printf("🎲 Using legacy role assignment")
-			game.AssignRoles(players, h.cardService)
-		}
-		// Log assigned roles
-		for _, p := range players {
-			if p.Role != nil {
-				log.Printf("🎲 Player %s assigned role: %s", p.Name, p.Role.Name)
-			} else {
-				log.Printf("❌ Player %s has no role assigned!", p.Name)
-			}
-		}
-	} else {
-		log.Printf("❌ CardService is nil, cannot assign roles")
+	if err := h.dealRoles(room); err != nil {
+		log.Printf("❌ Cannot assign roles in room %s: %v", roomCode, err)
 		sse := datastar.NewSSE(w, r)
 		errorHTML := `<div id="start-game-error" class="alert alert-error mt-4">
 			<svg xmlns="http://www.w3.org/2000/svg" class="stroke-current shrink-0 h-6 w-6" fill="none" viewBox="0 0 24 24">
@@ -186,23 +165,17 @@ func (h *Handler) StartGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update game state
-	room.State = game.StateCountdown
-	room.CountdownRemaining = 5
-	room.StartedAt = time.Now()
-	h.store.UpdateRoom(room)
-
-	// Start countdown immediately
-	go h.runCountdown(room)
-
-	// Notify all players
-	h.eventBus.Publish(Event{
-		Type:     "game_started",
-		RoomCode: room.Code,
-		Data:     room,
-	})
+	h.finishStart(w, r, room)
+}
 
-	log.Printf("✅ Game started successfully for room %s", roomCode)
+// sendToGamePage answers a request that started the game. A lobby page
+// (?view=lobby) is carried into the game by its room stream, which swaps the
+// game body in without reloading; anything else is sent to the game page.
+func (h *Handler) sendToGamePage(w http.ResponseWriter, r *http.Request, roomCode string) {
+	if r.URL.Query().Get("view") == "lobby" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
 
 	// Use datastar to redirect directly in the POST response
 	sse := datastar.NewSSE(w, r)
@@ -214,13 +187,7 @@ func (h *Handler) StartGame(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) startCoupGame(w http.ResponseWriter, r *http.Request, room *game.Room) {
-	var err error
-	if room.CoupRoleCountsCustom {
-		err = game.AssignCoupRolesWithCountsAndInformationUnsafe(room.GetPlayers(), room.CoupRoleCounts, room.CoupInfoPolicy, room.CoupAllowUnsafeRoleCounts)
-	} else {
-		err = game.AssignCoupRolesWithInformation(room.GetPlayers(), room.CoupPreset, room.CoupInfoPolicy)
-	}
-	if err != nil {
+	if err := h.dealRoles(room); err != nil {
 		log.Printf("❌ Room cannot start: %s", err.Error())
 
 		sse := datastar.NewSSE(w, r)
@@ -244,26 +211,7 @@ func (h *Handler) startCoupGame(w http.ResponseWriter, r *http.Request, room *ga
 		return
 	}
 
-	room.State = game.StateCountdown
-	room.CountdownRemaining = 5
-	room.StartedAt = time.Now()
-	h.store.UpdateRoom(room)
-
-	go h.runCountdown(room)
-
-	h.eventBus.Publish(Event{
-		Type:     "game_started",
-		RoomCode: room.Code,
-		Data:     room,
-	})
-
-	log.Printf("✅ Coup game started successfully for room %s", room.Code)
-
-	sse := datastar.NewSSE(w, r)
-	sse.ExecuteScript("window.location.href = '/game/" + room.Code + "'")
-	if flusher, ok := w.(http.Flusher); ok {
-		flusher.Flush()
-	}
+	h.finishStart(w, r, room)
 }
 
 // LeaveRoom removes a player from a room
@@ -288,7 +236,7 @@ func (h *Handler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Remove player
-	room.RemovePlayer(playerCookie.Value)
+	h.record(room, game.PlayerLeftEvent(playerCookie.Value))
 	h.store.UpdateRoom(room)
 
 	// Clear cookie
@@ -301,9 +249,10 @@ func (h *Handler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 
 	// Notify other players
 	h.eventBus.Publish(Event{
-		Type:     "player_left",
+		Type:     EventPlayerLeft,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	// Use datastar to redirect since this is called via @post
@@ -325,6 +274,9 @@ func (h *Handler) ToggleReveal(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionReveal) {
+		return
+	}
 
 	me, ok := h.requireEffectivePlayer(w, r, room, roomCode)
 	if !ok {
@@ -346,10 +298,8 @@ func (h *Handler) ToggleReveal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if room.RulesMode == game.RulesModeCoup {
-		target.RoleRevealed = true
-		target.FaceUp = true
-	} else {
+	revealed, faceUp := true, true
+	if room.RulesMode != game.RulesModeCoup {
 		// Leaders cannot hide their role (they start face-up per game rules)
 		if target.Role != nil && target.Role.GetRoleType() == game.RoleLeader && target.RoleRevealed {
 			log.Printf("❌ Leader %s attempted to hide their role (not allowed)", target.Name)
@@ -358,22 +308,22 @@ func (h *Handler) ToggleReveal(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Toggle the reveal state
-		target.RoleRevealed = !target.RoleRevealed
+		revealed = !target.RoleRevealed
 		// Revealing also turns the card face up (you can't reveal a face-down card)
 		// Hiding does NOT turn face down - use the separate "Turn Face Down" action for that
-		if target.RoleRevealed {
-			target.FaceUp = true
-		}
+		faceUp = target.FaceUp || revealed
 	}
+	h.record(room, game.RoleRevealedEvent(target.ID, revealed, faceUp))
 	h.store.UpdateRoom(room)
 
 	log.Printf("🎭 Player %s toggled role reveal to %v (FaceUp: %v) in room %s", target.Name, target.RoleRevealed, target.FaceUp, roomCode)
 
 	// Publish event to update all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_revealed",
+		Type:     EventRoleRevealed,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	// Return success - SSE will handle the UI update
@@ -438,9 +388,10 @@ func (h *Handler) ToggleFaceState(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "face_state_changed",
+		Type:     EventFaceStateChanged,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	// Return success - SSE will handle the UI update
@@ -531,9 +482,10 @@ func (h *Handler) DismissModal(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update UI
 	h.eventBus.Publish(Event{
-		Type:     "modal_dismissed",
+		Type:     EventModalDismissed,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -580,9 +532,10 @@ func (h *Handler) RestoreModal(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update UI
 	h.eventBus.Publish(Event{
-		Type:     "modal_restored",
+		Type:     EventModalRestored,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -677,44 +630,54 @@ func (h *Handler) SetRoleOption(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_options_changed",
+		Type:     EventRoleOptionsChanged,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
 }
 
-// runCountdown runs the countdown timer
-func (h *Handler) runCountdown(room *game.Room) {
-	ticker := time.NewTicker(1 * time.Second)
+// runCountdown runs the countdown timer for the start at startedAt, stopping
+// if that start is cancelled
+func (h *Handler) runCountdown(room *game.Room, startedAt time.Time) {
+	ticker := h.clock.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	for i := 5; i > 0; i-- {
+	for i := countdownSeconds; i > 0; i-- {
+		room.Lock()
+		if !countdownRunning(room, startedAt) {
+			room.Unlock()
+			return
+		}
 		room.CountdownRemaining = i
 		h.store.UpdateRoom(room)
 		log.Printf("⏰ Publishing countdown_update for room %s: %d", room.Code, i)
 
 		h.eventBus.Publish(Event{
-			Type:     "countdown_update",
+			Type:     EventCountdownUpdate,
 			RoomCode: room.Code,
 			Data:     room,
 		})
+		room.Unlock()
 
-		<-ticker.C
+		<-ticker.C()
 	}
 
 	// Transition to playing state
-	room.State = game.StatePlaying
-	room.CountdownRemaining = 0
-	room.LeaderRevealed = true
-	h.store.UpdateRoom(room)
+	room.Lock()
+	defer room.Unlock()
+	if !countdownRunning(room, startedAt) {
+		return
+	}
+	h.beginPlaying(room, EventActor{})
+}
 
-	h.eventBus.Publish(Event{
-		Type:     "game_playing",
-		RoomCode: room.Code,
-		Data:     room,
-	})
+// countdownRunning reports whether the room is still counting down for the
+// start at startedAt, rather than dealt again. The caller holds the room's lock.
+func countdownRunning(room *game.Room, startedAt time.Time) bool {
+	return room.State == game.StateCountdown && room.StartedAt.Equal(startedAt)
 }
 
 // UnveilPlayer handles the universal unveil action for any card
@@ -730,6 +693,9 @@ func (h *Handler) UnveilPlayer(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionReveal) {
+		return
+	}
 
 	me, ok := h.requireEffectivePlayer(w, r, room, roomCode)
 	if !ok {
@@ -780,9 +746,10 @@ func (h *Handler) UnveilPlayer(w http.ResponseWriter, r *http.Request) {
 
 	// Publish event to update all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_revealed",
+		Type:     EventRoleRevealed,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -917,6 +884,11 @@ func (h *Handler) RestoreRoom(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if !h.inTenant(r, room) {
+		log.Printf("❌ RestoreRoom: backup for %s belongs to another tenant", req.RoomCode)
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
 
 	// Re-register the restored room
 	if err := h.store.RegisterRestoredRoom(room); err != nil {
@@ -924,6 +896,10 @@ func (h *Handler) RestoreRoom(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to restore room", http.StatusInternalServerError)
 		return
 	}
+	h.trackRoomLogs(room.Code)
+	for _, player := range room.GetPlayers() {
+		h.rememberIdentity(player)
+	}
 
 	log.Printf("✅ Room %s restored from backup by player %s", req.RoomCode, req.PlayerID)
 	w.Header().Set("Content-Type", "application/json")
@@ -934,7 +910,7 @@ func (h *Handler) RestoreRoom(w http.ResponseWriter, r *http.Request) {
 // This is only available when debugModeEnabled is true
 func (h *Handler) DebugClearRoom(w http.ResponseWriter, r *http.Request) {
 	// Only allow in debug mode
-	if !h.config.Server.DebugModeEnabled {
+	if !h.config().Server.DebugModeEnabled {
 		http.Error(w, "Debug endpoints only available when debugModeEnabled is true", http.StatusForbidden)
 		return
 	}
@@ -956,12 +932,12 @@ func (h *Handler) DebugClearRoom(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
-	sessionCookie, err := r.Cookie("session")
-	if err != nil {
+	sessionID, ok := h.sessionID(r)
+	if !ok {
 		http.Error(w, "Debug operator access required", http.StatusUnauthorized)
 		return
 	}
-	if !room.IsOperatorSession(sessionCookie.Value) {
+	if !room.IsOperatorSession(sessionID) {
 		http.Error(w, "Debug operator access required", http.StatusForbidden)
 		return
 	}
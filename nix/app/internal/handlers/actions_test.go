@@ -863,7 +863,7 @@ func TestSetupRouter_RoutesCoupRoyalGuardAction(t *testing.T) {
 	room.AddPlayer(blue)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -1230,29 +1230,26 @@ func TestHandler_runCountdown(t *testing.T) {
 		events := h.eventBus.Subscribe(room.Code)
 		defer h.eventBus.Unsubscribe(room.Code, events)
 
-		// Run countdown in goroutine
-		done := make(chan bool)
+		// Run countdown in goroutine, on fake time
+		fake := withFakeClock(h)
+		done := make(chan struct{})
 		go func() {
-			h.runCountdown(room)
-			done <- true
+			h.runCountdown(room, room.StartedAt)
+			close(done)
 		}()
-
-		// Collect events
-		var receivedEvents []Event
-		timeout := time.After(6 * time.Second)
-
-		collecting := true
-		for collecting {
+		advanceUntil(t, fake, func() bool {
 			select {
-			case event := <-events:
-				receivedEvents = append(receivedEvents, event)
 			case <-done:
-				// Give a bit more time for final event
-				time.Sleep(100 * time.Millisecond)
-				collecting = false
-			case <-timeout:
-				t.Fatal("countdown took too long")
+				return true
+			default:
+				return false
 			}
+		})
+
+		// Collect events
+		var receivedEvents []Event
+		for len(events) > 0 {
+			receivedEvents = append(receivedEvents, <-events)
 		}
 
 		// Verify countdown events were sent
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// requireAdmin checks the request's bearer token against the admin token.
+// Without a configured token the admin endpoints do not exist.
+func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		http.NotFound(w, r)
+		return false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !h.isAdminToken(token) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Admin token required", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// isAdminToken reports whether token is the configured admin token
+func (h *Handler) isAdminToken(token string) bool {
+	return h.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+// writeAdminJSON writes v as the JSON response of an admin endpoint
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -16,6 +16,8 @@ import (
 	"treacherest/internal/store"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/views/components"
 )
 
 // BenchmarkRoomCreation benchmarks the time to create a new room
@@ -27,6 +29,7 @@ func BenchmarkRoomCreation(b *testing.B) {
 	// Create a form request
 	form := url.Values{}
 	form.Add("playerName", "TestPlayer")
+	form.Add(components.BotElapsedField, "5000")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -102,7 +105,7 @@ func BenchmarkSSEBroadcast(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				start := time.Now()
 				h.eventBus.Publish(Event{
-					Type:     "player_joined",
+					Type:     EventPlayerJoined,
 					RoomCode: room.Code,
 					Data:     room,
 				})
@@ -369,3 +372,39 @@ func createRealisticRoom(s *store.MemoryStore, numPlayers int) *game.Room {
 	s.UpdateRoom(room)
 	return room
 }
+
+// discardStream is an SSE response that throws its body away, so a benchmark
+// measures encoding rather than a growing buffer
+type discardStream struct{ header http.Header }
+
+func (d *discardStream) Header() http.Header         { return d.header }
+func (d *discardStream) Write(p []byte) (int, error) { return len(p), nil }
+func (d *discardStream) WriteHeader(int)             {}
+func (d *discardStream) Flush()                      {}
+
+// BenchmarkCountdownSignal compares marshalling the countdown signal per
+// stream per tick with the payloads patchCountdown encodes once
+func BenchmarkCountdownSignal(b *testing.B) {
+	newStream := func() *datastar.ServerSentEventGenerator {
+		return datastar.NewSSE(&discardStream{header: http.Header{}}, httptest.NewRequest("GET", "/sse/room/ABCDE", nil))
+	}
+
+	b.Run("marshal", func(b *testing.B) {
+		sse := newStream()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := sse.MarshalAndPatchSignals(map[string]interface{}{"countdown": i % (countdownSeconds + 1)}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("preserialized", func(b *testing.B) {
+		sse := newStream()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := patchCountdown(sse, i%(countdownSeconds+1)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
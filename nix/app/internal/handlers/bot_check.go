@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"treacherest/internal/views/components"
+	"treacherest/internal/views/pages"
+)
+
+// Reasons a create or join post looks scripted, used in logs and metrics
+const (
+	botReasonHoneypot   = "honeypot"
+	botReasonHeadless   = "headless_user_agent"
+	botReasonFastSubmit = "fast_submit"
+	botReasonNoTiming   = "missing_timing"
+)
+
+var botReasons = []string{botReasonHoneypot, botReasonHeadless, botReasonFastSubmit, botReasonNoTiming}
+
+// botVerificationTTL is how long a verification page's token can be redeemed
+const botVerificationTTL = 10 * time.Minute
+
+// headlessUserAgents are lower-cased User-Agent fragments of automation
+// tools and HTTP libraries that no player's browser sends
+var headlessUserAgents = []string{
+	"headlesschrome",
+	"phantomjs",
+	"selenium",
+	"python-requests",
+	"python-urllib",
+	"go-http-client",
+	"curl/",
+	"wget/",
+	"scrapy",
+	"node-fetch",
+	"axios/",
+}
+
+// botCheckMetrics counts suspected bots by reason and the clients that
+// passed verification, so the heuristics can be tuned against real traffic
+type botCheckMetrics struct {
+	mu       sync.Mutex
+	flagged  map[string]int64
+	verified int64
+}
+
+func newBotCheckMetrics() *botCheckMetrics {
+	return &botCheckMetrics{flagged: make(map[string]int64)}
+}
+
+func (m *botCheckMetrics) recordFlagged(reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flagged[reason]++
+}
+
+func (m *botCheckMetrics) recordVerified() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verified++
+}
+
+// counts returns suspected bots by reason and the verified total
+func (m *botCheckMetrics) counts() (map[string]int64, int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	flagged := make(map[string]int64, len(botReasons))
+	for _, reason := range botReasons {
+		flagged[reason] = m.flagged[reason]
+	}
+	return flagged, m.verified
+}
+
+// botVerifications are the tokens handed out with verification pages, each
+// redeemable once. A post only counts as verified with one, so a client can't
+// skip the checks by sending the confirmation field itself.
+type botVerifications struct {
+	mu     sync.Mutex
+	issued map[string]time.Time
+}
+
+func newBotVerifications() *botVerifications {
+	return &botVerifications{issued: make(map[string]time.Time)}
+}
+
+// issue returns a new token, dropping ones too old to redeem
+func (v *botVerifications) issue(now time.Time) string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for token, at := range v.issued {
+		if now.Sub(at) >= botVerificationTTL {
+			delete(v.issued, token)
+		}
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	token := hex.EncodeToString(b)
+	v.issued[token] = now
+	return token
+}
+
+// redeem uses up token, reporting whether it was issued at least minWait and
+// less than botVerificationTTL ago. A token redeemed too soon is still used
+// up, so the page has to be loaded again.
+func (v *botVerifications) redeem(token string, now time.Time, minWait time.Duration) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	at, ok := v.issued[token]
+	if !ok {
+		return false
+	}
+	delete(v.issued, token)
+	age := now.Sub(at)
+	return age >= minWait && age < botVerificationTTL
+}
+
+// botSignal returns why a form post looks scripted, or "" when it looks like
+// a person, and whether it was confirmed on a verification page. A verified
+// post has already waited out BotMinSubmitTime on the server's own clock, so
+// only the honeypot still counts against it.
+func (h *Handler) botSignal(r *http.Request) (string, bool) {
+	if r.PostFormValue(components.BotHoneypotField) != "" {
+		return botReasonHoneypot, false
+	}
+	minWait := h.config().Server.BotMinSubmitTime
+	if r.PostFormValue(components.BotVerifiedField) == "yes" &&
+		h.botTokens.redeem(r.PostFormValue(components.BotTokenField), h.clock.Now(), minWait) {
+		return "", true
+	}
+	if isHeadlessUserAgent(r.UserAgent()) {
+		return botReasonHeadless, false
+	}
+	// Only scripts fill in the elapsed time; a client without them goes
+	// through the verification page, whose token needs no timing
+	elapsed, ok := formElapsed(r)
+	if !ok {
+		return botReasonNoTiming, false
+	}
+	if elapsed < minWait {
+		return botReasonFastSubmit, false
+	}
+	return "", false
+}
+
+func isHeadlessUserAgent(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	for _, fragment := range headlessUserAgents {
+		if strings.Contains(userAgent, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// formElapsed returns how long the form was open before it was submitted
+func formElapsed(r *http.Request) (time.Duration, bool) {
+	ms, err := strconv.ParseInt(r.PostFormValue(components.BotElapsedField), 10, 64)
+	if err != nil || ms < 0 {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// rejectIfSuspectedBot serves the verification page instead of creating a
+// room or seat when the post to action looks scripted. Every decision that
+// involves a bot signal is logged for tuning.
+func (h *Handler) rejectIfSuspectedBot(w http.ResponseWriter, r *http.Request, action string) bool {
+	if !h.config().Server.BotChecks {
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		return false
+	}
+
+	reason, verified := h.botSignal(r)
+	if reason == "" {
+		if verified {
+			h.botChecks.recordVerified()
+			log.Printf("🤖 Bot check passed after verification on %s from %s (user agent %q)", action, h.clientIP(r), r.UserAgent())
+		}
+		return false
+	}
+
+	h.botChecks.recordFlagged(reason)
+	log.Printf("🤖 Suspected bot on %s from %s: %s (user agent %q)", action, h.clientIP(r), reason, r.UserAgent())
+	w.WriteHeader(http.StatusForbidden)
+	pages.BotVerification(action, h.botTokens.issue(h.clock.Now()), carriedFormFields(r)).Render(r.Context(), w)
+	return true
+}
+
+// carriedFormFields returns the posted values to resubmit from the
+// verification page, without the bot check fields, sorted by name
+func carriedFormFields(r *http.Request) []pages.HiddenField {
+	names := make([]string, 0, len(r.PostForm))
+	for name := range r.PostForm {
+		switch name {
+		case components.BotHoneypotField, components.BotElapsedField, components.BotVerifiedField, components.BotTokenField:
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []pages.HiddenField
+	for _, name := range names {
+		for _, value := range r.PostForm[name] {
+			fields = append(fields, pages.HiddenField{Name: name, Value: value})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func postForm(router http.Handler, path string, form url.Values, userAgent string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateRoomSendsSuspectedBotsToVerification(t *testing.T) {
+	const browser = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/137.0.0.0 Safari/537.36"
+
+	tests := []struct {
+		name      string
+		form      url.Values
+		userAgent string
+		reason    string
+	}{
+		{"honeypot", url.Values{"playerName": {"Alice"}, components.BotHoneypotField: {"http://spam.example"}}, browser, botReasonHoneypot},
+		{"headless", url.Values{"playerName": {"Alice"}}, "Mozilla/5.0 HeadlessChrome/137.0.0.0", botReasonHeadless},
+		{"fast submit", url.Values{"playerName": {"Alice"}, components.BotElapsedField: {"150"}}, browser, botReasonFastSubmit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler()
+			router := newTestRouter(h)
+
+			w := postForm(router, "/room/new", tt.form, tt.userAgent)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("expected the verification page, got %d", w.Code)
+			}
+			body := w.Body.String()
+			if !strings.Contains(body, `id="bot-verification"`) || !strings.Contains(body, `name="playerName" value="Alice"`) {
+				t.Errorf("expected a verification form carrying the name, got %s", body)
+			}
+			if strings.Contains(body, "spam.example") {
+				t.Error("the honeypot value must not be carried over")
+			}
+			if len(h.store.Rooms()) != 0 {
+				t.Error("a suspected bot must not create a room")
+			}
+			if flagged, _ := h.botChecks.counts(); flagged[tt.reason] != 1 {
+				t.Errorf("expected one %s flag, got %v", tt.reason, flagged)
+			}
+		})
+	}
+}
+
+// verificationToken returns the token a verification page was served with
+func verificationToken(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	match := regexp.MustCompile(`name="` + components.BotTokenField + `" value="([0-9a-f]+)"`).FindStringSubmatch(w.Body.String())
+	if match == nil {
+		t.Fatalf("expected a verification page with a token, got %d: %s", w.Code, w.Body.String())
+	}
+	return match[1]
+}
+
+func TestBotVerificationLetsPeopleThrough(t *testing.T) {
+	const headless = "Mozilla/5.0 HeadlessChrome/137.0.0.0"
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	router := newTestRouter(h)
+
+	form := url.Values{"playerName": {"Alice"}}
+	token := verificationToken(t, postForm(router, "/room/new", form, headless))
+
+	// Confirming without scripts sends no timing; the token stands in for it
+	form.Set(components.BotVerifiedField, "yes")
+	form.Set(components.BotTokenField, token)
+	fake.Advance(2 * time.Second)
+	w := postForm(router, "/room/new", form, headless)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected a verified post to create the room, got %d", w.Code)
+	}
+	if _, verified := h.botChecks.counts(); verified != 1 {
+		t.Errorf("expected the verification to be counted, got %d", verified)
+	}
+
+	// A token is only good once
+	if w := postForm(router, "/room/new", form, headless); w.Code != http.StatusForbidden {
+		t.Errorf("expected a redeemed token to be refused, got %d", w.Code)
+	}
+
+	// The honeypot still counts after verifying
+	form.Set(components.BotTokenField, verificationToken(t, postForm(router, "/room/new", url.Values{"playerName": {"Alice"}}, headless)))
+	form.Set(components.BotHoneypotField, "x")
+	fake.Advance(2 * time.Second)
+	if w := postForm(router, "/room/new", form, ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected a filled honeypot to be refused, got %d", w.Code)
+	}
+}
+
+func TestBotVerificationNeedsAnIssuedToken(t *testing.T) {
+	const headless = "Mozilla/5.0 HeadlessChrome/137.0.0.0"
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	router := newTestRouter(h)
+
+	// Claiming to have verified doesn't skip the checks
+	forged := url.Values{"playerName": {"Alice"}, components.BotVerifiedField: {"yes"}, components.BotElapsedField: {"4000"}}
+	if w := postForm(router, "/room/new", forged, headless); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a forged verification to be refused, got %d", w.Code)
+	}
+	forged.Set(components.BotTokenField, "0123456789abcdef0123456789abcdef")
+	if w := postForm(router, "/room/new", forged, headless); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a made-up token to be refused, got %d", w.Code)
+	}
+
+	// Nor does confirming faster than a person could, on the server's clock
+	form := url.Values{"playerName": {"Alice"}, components.BotVerifiedField: {"yes"}}
+	form.Set(components.BotTokenField, verificationToken(t, postForm(router, "/room/new", form, headless)))
+	if w := postForm(router, "/room/new", form, headless); w.Code != http.StatusForbidden {
+		t.Fatalf("expected an instant confirmation to be refused, got %d", w.Code)
+	}
+
+	// Nor one that waited too long
+	form.Set(components.BotTokenField, verificationToken(t, postForm(router, "/room/new", form, headless)))
+	fake.Advance(botVerificationTTL)
+	if w := postForm(router, "/room/new", form, headless); w.Code != http.StatusForbidden {
+		t.Fatalf("expected an expired token to be refused, got %d", w.Code)
+	}
+	if len(h.store.Rooms()) != 0 {
+		t.Error("no unverified post should create a room")
+	}
+}
+
+func TestCreateRoomWithoutFormTimingIsSuspect(t *testing.T) {
+	const browser = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/137.0.0.0 Safari/537.36"
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	if w := postForm(router, "/room/new", url.Values{"playerName": {"Alice"}}, browser); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a post without the form's timing to be checked, got %d", w.Code)
+	}
+	if flagged, _ := h.botChecks.counts(); flagged[botReasonNoTiming] != 1 {
+		t.Errorf("expected one %s flag, got %v", botReasonNoTiming, flagged)
+	}
+	form := url.Values{"playerName": {"Alice"}, components.BotElapsedField: {"4000"}}
+	if w := postForm(router, "/room/new", form, browser); w.Code != http.StatusSeeOther {
+		t.Errorf("expected a timed post from a browser to create the room, got %d", w.Code)
+	}
+}
+
+func TestJoinRoomPostChecksForBots(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+
+	form := url.Values{"room_code": {operator.RoomCode}, "player_name": {"Bob"}}
+	if w := postForm(router, "/join-room", form, "python-requests/2.31"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a scripted join to be checked, got %d", w.Code)
+	}
+
+	h.config().Server.BotChecks = false
+	if w := postForm(router, "/join-room", form, "python-requests/2.31"); w.Code != http.StatusSeeOther {
+		t.Errorf("expected bot checks to be switchable off, got %d", w.Code)
+	}
+}
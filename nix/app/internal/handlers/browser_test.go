@@ -27,7 +27,7 @@ func TestLobbySSEMultiplePlayers(t *testing.T) {
 	h := newTestHandler()
 
 	// Create test server
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -148,7 +148,7 @@ func TestLobbyDOMStructurePreservation(t *testing.T) {
 	h := newTestHandler()
 
 	// Create test server
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -185,7 +185,7 @@ func TestLobbyDOMStructurePreservation(t *testing.T) {
 	room.AddPlayer(&game.Player{ID: "player2", Name: "Player2"})
 	h.store.UpdateRoom(room)
 	h.eventBus.Publish(Event{
-		Type:     "player_joined",
+		Type:     EventPlayerJoined,
 		RoomCode: roomCode,
 		Data:     room,
 	})
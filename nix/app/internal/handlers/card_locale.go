@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+// SetCardLocale sets the language the room's setup and card pages show card
+// text in
+func (h *Handler) SetCardLocale(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	locale, ok := game.NormalizeCardLocale(r.FormValue("locale"))
+	if !ok || !h.cardService.HasLocale(locale) {
+		http.Error(w, game.ErrUnknownCardLocale.Error(), http.StatusBadRequest)
+		return
+	}
+	if locale == game.DefaultCardLocale {
+		locale = ""
+	}
+	room.CardLocale = locale
+	h.store.UpdateRoom(room)
+	log.Printf("🌐 Room %s shows card text in %q", room.Code, room.CardLocale)
+
+	h.eventBus.Publish(Event{
+		Type:     EventRoleConfigUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+	w.WriteHeader(http.StatusOK)
+}
+
+// CardPage shows one card's full text, /cards/{id}, in the language ?lang
+// asks for. A language the cards don't have shows the English text.
+func (h *Handler) CardPage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	card := h.cardService.GetCardByID(id)
+	if err != nil || card == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	locale, ok := game.NormalizeCardLocale(r.URL.Query().Get("lang"))
+	if !ok || !h.cardService.HasLocale(locale) {
+		locale = game.DefaultCardLocale
+	}
+	pages.CardDetailPage(card.In(locale), locale, h.cardService.Locales()).Render(r.Context(), w)
+}
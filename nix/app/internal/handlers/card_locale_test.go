@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+)
+
+func newCardLocaleTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	cardService, err := game.NewCardService([]byte(packedTestCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cardService.AddTranslations("de", []byte(`{"1": {"name": "Der König"}}`)); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	s := store.NewMemoryStore(cfg)
+	s.SetCardService(cardService)
+	return New(s, cardService, cfg, nil)
+}
+
+func TestSetCardLocale(t *testing.T) {
+	h := newCardLocaleTestHandler(t)
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	path := "/room/" + room.Code + "/config/card-locale"
+
+	if w := postPhaseForm(router, path, "s1", url.Values{"locale": {"de"}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player to be refused, got %d", w.Code)
+	}
+	if w := postPhaseForm(router, path, "operator-session", url.Values{"locale": {"fr"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a language without card text to be refused, got %d", w.Code)
+	}
+
+	if w := postPhaseForm(router, path, "operator-session", url.Values{"locale": {"DE"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.CardLocale != "de" {
+		t.Fatalf("expected the room to show German card text, got %q", room.CardLocale)
+	}
+	if w := postPhaseForm(router, path, "operator-session", url.Values{"locale": {"en"}}); w.Code != http.StatusOK || room.CardLocale != "" {
+		t.Errorf("expected English to clear the room's card language, got %d %q", w.Code, room.CardLocale)
+	}
+}
+
+func TestCardPageShowsLocalizedText(t *testing.T) {
+	router := newTestRouter(newCardLocaleTestHandler(t))
+
+	for _, tc := range []struct{ path, want string }{
+		{"/cards/1?lang=de", "Der König"},
+		{"/cards/1?lang=fr", "The King"},
+		{"/cards/2?lang=de", "The Bodyguard"},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", tc.path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), tc.want) {
+			t.Errorf("%s: expected %q, got %d", tc.path, tc.want, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/cards/99", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown card to 404, got %d", w.Code)
+	}
+}
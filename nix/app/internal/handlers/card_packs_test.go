@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+	"treacherest/internal/views/components"
+)
+
+const packedTestCardsJSON = `{
+	"set_name": "Treachery",
+	"packs": [{"id": "base", "name": "Base Set"}, {"id": "community", "name": "Community Pack"}],
+	"cards": [
+		{"id": 1, "name": "The King", "name_anchor": "the-king", "types": {"supertype": "Identity", "subtype": "Leader"}},
+		{"id": 2, "name": "The Bodyguard", "name_anchor": "the-bodyguard", "types": {"supertype": "Identity", "subtype": "Guardian"}},
+		{"id": 3, "name": "The Usurper", "name_anchor": "the-usurper", "pack": "community", "types": {"supertype": "Identity", "subtype": "Leader"}}
+	]
+}`
+
+func TestToggleCardPack(t *testing.T) {
+	cardService, err := game.NewCardService([]byte(packedTestCardsJSON), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.DefaultConfig()
+	cfg.Server.CardPacks = []string{"base"}
+	s := store.NewMemoryStore(cfg)
+	s.SetCardService(cardService)
+	h := New(s, cardService, cfg, nil)
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+
+	if enabled, total := room.RoleConfig.PackCardsEnabled(cardService, "community"); enabled != 0 || total != 1 {
+		t.Fatalf("expected the community pack off in a new room, got %d of %d", enabled, total)
+	}
+
+	post := func(packID, session string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/room/"+room.Code+"/config/pack/"+packID, strings.NewReader(`{"enabled": true}`))
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post("community", "s1"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a player to be refused, got %d", w.Code)
+	}
+	if w := post("expansion", "operator-session"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown pack, got %d", w.Code)
+	}
+	if w := post("community", "operator-session"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !room.RoleConfig.RoleTypes["Leader"].EnabledCards["The Usurper"] || room.RoleConfig.PresetName != "custom" {
+		t.Errorf("expected the community cards enabled in a custom config, got %+v", room.RoleConfig.RoleTypes["Leader"])
+	}
+
+	var html strings.Builder
+	if err := components.CardPackToggles(room, cardService, cardService.Packs()).Render(context.Background(), &html); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), "Community Pack") || !strings.Contains(html.String(), "1 of 1 cards enabled") {
+		t.Errorf("expected the pack toggles with their counts, got %s", html.String())
+	}
+}
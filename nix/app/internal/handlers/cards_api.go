@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"treacherest/internal/api"
+)
+
+const (
+	defaultCardSearchLimit = 20
+	maxCardSearchLimit     = 100
+	maxCardSearchQuery     = 100 // runes
+)
+
+// cardSearchResult is one card in the card search response
+type cardSearchResult struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Anchor   string `json:"anchor"`
+	RoleType string `json:"roleType"`
+	Text     string `json:"text"`
+	Score    int    `json:"score"`
+}
+
+// cardSearchResponse is the body of GET /api/v1/cards/search
+type cardSearchResponse struct {
+	Query   string             `json:"query"`
+	Results []cardSearchResult `json:"results"`
+}
+
+// SearchCards searches card names and rules text for q, best match first.
+// limit caps the results (default 20, at most 100).
+func (h *Handler) SearchCards(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if utf8.RuneCountInString(query) > maxCardSearchQuery {
+		http.Error(w, "Search query too long", http.StatusBadRequest)
+		return
+	}
+
+	limit, err := api.ParseLimit(r.URL.Query().Get(api.ParamLimit), defaultCardSearchLimit, maxCardSearchLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := cardSearchResponse{Query: query, Results: make([]cardSearchResult, 0)}
+	if h.cardService != nil {
+		for _, result := range h.cardService.Search(query, limit) {
+			response.Results = append(response.Results, cardSearchResult{
+				ID:       result.Card.ID,
+				Name:     result.Card.Name,
+				Anchor:   result.Card.NameAnchor,
+				RoleType: result.Card.Types.Subtype,
+				Text:     result.Card.Text,
+				Score:    result.Score,
+			})
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSearchCards(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	search := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/cards/search?"+query, nil))
+		return w
+	}
+
+	w := search("q=" + url.QueryEscape(h.cardService.Traitors[0].Name))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected JSON, got %q", contentType)
+	}
+	var response cardSearchResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(response.Results) == 0 || response.Results[0].Name != h.cardService.Traitors[0].Name || response.Results[0].RoleType != "Traitor" {
+		t.Errorf("expected %s first, got %+v", h.cardService.Traitors[0].Name, response.Results)
+	}
+
+	w = search("q=&limit=5")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"results":[]`) {
+		t.Errorf("expected an empty result list for an empty query, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, bad := range []string{"q=a&limit=0", "q=a&limit=x", "q=" + strings.Repeat("a", maxCardSearchQuery+1)} {
+		if w := search(bad); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", bad, w.Code)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+//go:build chaos
+
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/chaos"
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+// TestChaosTableConverges plays a game over a bus that delays and drops
+// events and a store that fails now and then, retrying what the server
+// refuses the way a client would, then checks every player's resync agrees
+// with the room. Run it with -tags chaos; CHAOS_SEED repeats a failing run.
+func TestChaosTableConverges(t *testing.T) {
+	settings := chaos.Settings{
+		Seed:           time.Now().UnixNano(),
+		MaxDelay:       50 * time.Millisecond,
+		DropRate:       0.2,
+		StoreErrorRate: 0.2,
+	}
+	if v := os.Getenv("CHAOS_SEED"); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			t.Fatalf("CHAOS_SEED: %v", err)
+		}
+		settings.Seed = seed
+	}
+	t.Logf("chaos: %s", settings)
+
+	h, in := newChaosHandler()
+	players := []string{"Alice", "Bob", "Carol", "Dave"}
+	s := newScenario(t, h)
+	for _, name := range players {
+		s.Join(name)
+	}
+
+	room, err := h.store.GetRoom(s.RoomCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Set(settings)
+
+	// A lookup that fails inside a handler reads as "Room not found"; the
+	// page's restore script reloads on that, so it is retried like a 503
+	retry := func(name, path string) {
+		t.Helper()
+		for attempt := 0; attempt < 50; attempt++ {
+			w := s.Client(name).Post(strings.ReplaceAll(path, "{code}", s.RoomCode), nil)
+			if w.Code == http.StatusNotFound && h.store.RoomExists(s.RoomCode) {
+				continue
+			}
+			if w.Code < 500 {
+				if w.Code >= 400 {
+					t.Fatalf("%s POST %s: got %d: %s", name, path, w.Code, w.Body.String())
+				}
+				return
+			}
+		}
+		t.Fatalf("%s POST %s kept failing", name, path)
+	}
+
+	// A start that failed underway answers with an error fragment, and the
+	// operator presses Start again
+	for attempt := 0; room.State == game.StateLobby; attempt++ {
+		if attempt == 50 {
+			t.Fatal("the room never left the lobby")
+		}
+		retry(testkit.OperatorName, "/room/{code}/start")
+	}
+	room.Lock()
+	h.beginPlaying(room, EventActor{})
+	room.Unlock()
+	for _, name := range players {
+		if room.GetPlayer(s.Client(name).PlayerID()).RoleRevealed {
+			continue // the Leader starts face up
+		}
+		retry(name, "/room/{code}/reveal/"+s.Client(name).PlayerID())
+	}
+
+	for _, name := range players {
+		var body string
+		for attempt := 0; attempt < 50; attempt++ {
+			w := s.Client(name).Get("/room/" + s.RoomCode + "/resync")
+			if w.Code == http.StatusOK {
+				body = w.Body.String()
+				break
+			}
+		}
+		if !strings.Contains(body, `id="game-container"`) {
+			t.Fatalf("expected %s's resync to show the game, got %q", name, body)
+		}
+		role := room.GetPlayer(s.Client(name).PlayerID()).Role
+		if role == nil || !strings.Contains(body, role.Name) {
+			t.Fatalf("expected %s's resync to show their role, got %q", name, body)
+		}
+	}
+}
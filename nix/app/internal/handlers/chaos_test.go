@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/chaos"
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+	"treacherest/internal/testkit"
+)
+
+// chaosFaults adapts an Injector to the event bus
+func chaosFaults(in *chaos.Injector) EventFaults {
+	return func(Event) (time.Duration, bool) { return in.Event() }
+}
+
+// newChaosHandler creates a test handler whose store and event bus fail as
+// in says; in causes no faults until Set
+func newChaosHandler() (*Handler, *chaos.Injector) {
+	cfg := config.DefaultConfig()
+	in := chaos.New(chaos.Settings{})
+	s := chaos.WrapStore(store.NewMemoryStore(cfg), in)
+	cardService := createMockCardService()
+	s.SetCardService(cardService)
+	h := New(s, cardService, cfg, nil)
+	h.SetEventFaults(chaosFaults(in))
+	return h, in
+}
+
+func TestResyncCatchesUpAfterDroppedEvents(t *testing.T) {
+	h, in := newChaosHandler()
+	s := newScenario(t, h).Join("Alice").Join("Bob").Join("Carol")
+
+	in.Set(chaos.Settings{DropRate: 1})
+	s.Start()
+	if s.Stream("Alice").WaitFor("Revealing roles in", 200*time.Millisecond) {
+		t.Fatalf("expected the start to be lost on the way, got %s", s.Stream("Alice").Data())
+	}
+
+	w := s.Client("Alice").Get("/room/" + s.RoomCode + "/resync")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `id="game-container"`) {
+		t.Fatalf("expected resync to bring Alice to the game, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTransientStoreErrorIsRetried(t *testing.T) {
+	h, in := newChaosHandler()
+	s := newScenario(t, h).Join("Alice").Join("Bob").Join("Carol")
+	room, _ := h.store.GetRoom(s.RoomCode)
+
+	in.Set(chaos.Settings{StoreErrorRate: 1})
+	w := s.Client(testkit.OperatorName).Post("/room/"+s.RoomCode+"/start", nil)
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected a retryable 503 while the store fails, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.State != game.StateLobby {
+		t.Fatalf("expected the failed start to leave the lobby alone, got %s", room.State)
+	}
+
+	in.Set(chaos.Settings{})
+	s.Start().ExpectEvent(string(EventGameStarted))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+// PostChat adds a message to the room's table chat. Every seated player's
+// stream patches the new log in; the sender gets an empty form back.
+func (h *Handler) PostChat(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+
+	if _, err := room.PostChat(player.ID, r.FormValue("text"), h.clock.Now()); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, game.ErrPlayerNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventChatPosted,
+		Actor:    h.requestActor(r, room),
+		RoomCode: room.Code,
+		Data:     room,
+	})
+
+	// The form ignores morphs so typing survives other messages; replace it
+	// outright to clear the sender's input
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElements(renderFragment(pages.RoomChatForm(room), "#room-chat-form", room.Code), datastar.WithSelector("#room-chat-form"), datastar.WithModeReplace())
+}
+
+// hostDashboardShowsChat reports whether the host dashboard's current view
+// has the chat: the lobby setup and the live game do, the rest don't
+func hostDashboardShowsChat(room *game.Room) bool {
+	return (room.State == game.StateLobby && !room.DealPending) || room.State == game.StatePlaying
+}
+
+// patchRoomChat sends page the room's current chat log
+func (h *Handler) patchRoomChat(sse *datastar.ServerSentEventGenerator, page PageType, room *game.Room) {
+	h.patchElements(sse, page, renderFragment(pages.RoomChatLog(room), "#room-chat-log", room.Code), "#room-chat-log")
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/testkit"
+)
+
+func TestPostChat(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	chatPath := "/room/" + room.Code + "/chat"
+	alice := seatedClient(t, router, room.Code, player.ID)
+
+	if w := testkit.NewClient(t, router).Post(chatPath, url.Values{"text": {"hello"}}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected anonymous chat to be rejected, got %d", w.Code)
+	}
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+
+	w := alice.Post(chatPath, url.Values{"text": {"  who wants to go first?  "}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "room-chat-form") {
+		t.Errorf("expected an empty form back for the sender, got %s", w.Body.String())
+	}
+	chat := room.ChatMessages()
+	if len(chat) != 1 || chat[0].Text != "who wants to go first?" || chat[0].Name != "Alice" {
+		t.Fatalf("expected the trimmed message from Alice, got %+v", chat)
+	}
+	select {
+	case ev := <-events:
+		if ev.Type != EventChatPosted {
+			t.Fatalf("expected %s, got %s", EventChatPosted, ev.Type)
+		}
+	default:
+		t.Fatal("expected the message to be broadcast")
+	}
+
+	if w := alice.Post(chatPath, url.Values{"text": {"   "}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an empty message to be rejected, got %d", w.Code)
+	}
+}
+
+func TestLobbyPageShowsTableChat(t *testing.T) {
+	h := newTestHandler()
+	room, player := newPhaseTestRoom(t, h)
+	if _, err := room.PostChat(player.ID, "ready when you are", h.clock.Now()); err != nil {
+		t.Fatalf("post chat: %v", err)
+	}
+
+	w := seatedClient(t, newTestRouter(h), room.Code, player.ID).Get("/room/" + room.Code)
+	body := w.Body.String()
+	if !strings.Contains(body, `id="room-chat-log"`) || !strings.Contains(body, "ready when you are") {
+		t.Errorf("expected the lobby to show the table chat, got %s", body)
+	}
+}
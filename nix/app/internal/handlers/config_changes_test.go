@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/views/components"
+)
+
+func TestPresetSwitchShowsConfigChangesUntilDismissed(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	room.RoleConfig.PresetName = "custom"
+	room.RoleConfig.RoleTypes["Guardian"].Count = 0
+
+	w := postPhaseForm(router, "/room/"+room.Code+"/config/preset", "operator-session", url.Values{"preset": {"standard"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	changes := room.ConfigChanges()
+	if len(changes) == 0 || changes[0] != "Preset custom→standard" {
+		t.Fatalf("expected the preset switch among the changes, got %q", changes)
+	}
+
+	var html strings.Builder
+	if err := components.RoleConfigChanges(room).Render(context.Background(), &html); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), "Preset custom→standard") || !strings.Contains(html.String(), "/config/changes/dismiss") {
+		t.Errorf("expected the changes and a dismiss button, got %s", html.String())
+	}
+
+	if w := postPhaseForm(router, "/room/"+room.Code+"/config/changes/dismiss", "s1", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a player to be refused, got %d", w.Code)
+	}
+	if w := postPhaseForm(router, "/room/"+room.Code+"/config/changes/dismiss", "operator-session", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if changes := room.ConfigChanges(); changes != nil {
+		t.Errorf("expected the changes dismissed, got %q", changes)
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	localMiddleware "treacherest/internal/middleware"
+	"treacherest/internal/views/components"
+)
+
+// configMutationPrefix starts the route of every room setup change
+const configMutationPrefix = "/room/{code}/config/"
+
+// configMutationRoom keys the per-room config rate limit: the room code of a
+// setup change, or "" for every other request, which it leaves alone. A host
+// holding down a +/- button would otherwise re-render every page in the room
+// as fast as the browser repeats the click.
+func configMutationRoom(r *http.Request) string {
+	if r.Method != http.MethodPost {
+		return ""
+	}
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || !strings.HasPrefix(rctx.RoutePattern(), configMutationPrefix) {
+		return ""
+	}
+	return chi.URLParam(r, "code")
+}
+
+// limitConfigMutations applies limiter to room setup changes, per room. A
+// datastar request past the limit gets the slow down notice on the Operator
+// Dashboard rather than an error the page can't show; anything else gets 429.
+// Either way it carries a Retry-After.
+func (h *Handler) limitConfigMutations(limiter *localMiddleware.RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			roomCode := configMutationRoom(r)
+			if roomCode == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ok, retryAfter := limiter.Allow(roomCode)
+			if ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h.requestLogger(r).Debug("config change refused at the room's rate limit")
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+			if r.Header.Get("Datastar-Request") != "true" {
+				http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			sse := datastar.NewSSE(w, r)
+			h.patchElements(sse, PageHost, renderToString(components.ConfigSlowDownNotice(true)), "#config-slow-down")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestConfigMutationsAreRateLimitedPerRoom(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.ConfigRateLimit = 0.5
+	h.config().Server.ConfigRateLimitBurst = 2
+	router := SetupRouter(h, h.config(), &RouterOptions{DisableRequestLogger: true})
+	room, _ := newPhaseTestRoom(t, h)
+	path := "/room/" + room.Code + "/config/phases"
+
+	for i := 0; i < 2; i++ {
+		if w := postPhaseForm(router, path, "operator-session", url.Values{"enabled": {"true"}}); w.Code == http.StatusTooManyRequests {
+			t.Fatalf("expected change %d within the burst to pass, got 429", i+1)
+		}
+	}
+	w := postPhaseForm(router, path, "operator-session", url.Values{"enabled": {"false"}})
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the change past the burst to get 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+
+	if w := postPhaseForm(router, "/room/"+room.Code+"/notes", "s1", url.Values{"notes": {"hi"}}); w.Code == http.StatusTooManyRequests {
+		t.Error("expected requests outside the room setup to skip the config limit")
+	}
+}
+
+func TestConfigMutationPastTheLimitAsksDatastarClientsToSlowDown(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.ConfigRateLimit = 0.5
+	h.config().Server.ConfigRateLimitBurst = 1
+	router := SetupRouter(h, h.config(), &RouterOptions{DisableRequestLogger: true})
+	room, _ := newPhaseTestRoom(t, h)
+	path := "/room/" + room.Code + "/config/phases"
+
+	postPhaseForm(router, path, "operator-session", url.Values{"enabled": {"true"}})
+
+	req := httptest.NewRequest("POST", path, strings.NewReader(url.Values{"enabled": {"false"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Datastar-Request", "true")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the slow down notice as a datastar patch, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After")
+	}
+	body := w.Body.String()
+	for _, expected := range []string{"selector #config-slow-down", "Slow down a little"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected %q in %s", expected, body)
+		}
+	}
+	if !room.PhaseSettings.Enabled {
+		t.Error("expected the refused change not to be applied")
+	}
+}
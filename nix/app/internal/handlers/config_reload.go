@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+)
+
+// liveConfig is the config the handlers serve with. A reload builds a new
+// one and publishes it whole, so a request never reads one half-replaced.
+type liveConfig struct {
+	server  *config.ServerConfig
+	roles   *game.RoleConfigService
+	tenants map[string]*config.ServerConfig // the server config scoped to each tenant, by ID
+}
+
+// configurable is a store whose new rooms follow a reloaded config
+type configurable interface {
+	SetConfig(cfg *config.ServerConfig)
+}
+
+// publishConfig makes cfg the config requests see from now on. cfg must not
+// change afterwards; a reload publishes a new one instead.
+func (h *Handler) publishConfig(cfg *config.ServerConfig) {
+	roles := game.NewRoleConfigService(cfg)
+	roles.SetCardService(h.cardService)
+	tenants := make(map[string]*config.ServerConfig, len(h.tenants))
+	for id, t := range h.tenants {
+		tenants[id] = cfg.ForTenant(t.TenantConfig)
+	}
+	h.live.Store(&liveConfig{server: cfg, roles: roles, tenants: tenants})
+}
+
+// config returns the server config as of now
+func (h *Handler) config() *config.ServerConfig {
+	if live := h.live.Load(); live != nil {
+		return live.server
+	}
+	return nil
+}
+
+// roleConfigService returns the role configuration service for the server
+// config as of now
+func (h *Handler) roleConfigService() *game.RoleConfigService {
+	return h.live.Load().roles
+}
+
+// tenantConfig returns the server config scoped to t, as of now
+func (h *Handler) tenantConfig(t *tenant) *config.ServerConfig {
+	return h.live.Load().tenants[t.ID]
+}
+
+// roomConfig returns the config governing room, which is the server config,
+// scoped to the room's tenant if it has one, unless a reload pinned the room
+// to the one it was created under
+func (h *Handler) roomConfig(room *game.Room) *config.ServerConfig {
+	return roomConfigIn(h.live.Load(), h.tenants, room)
+}
+
+func roomConfigIn(live *liveConfig, tenants map[string]*tenant, room *game.Room) *config.ServerConfig {
+	if _, ok := tenants[room.Tenant]; ok {
+		return room.Config(live.tenants[room.Tenant])
+	}
+	return room.Config(live.server)
+}
+
+// roleConfigFor returns the role configuration service for the room's config
+func (h *Handler) roleConfigFor(room *game.Room) *game.RoleConfigService {
+	live := h.live.Load()
+	cfg := roomConfigIn(live, h.tenants, room)
+	if cfg == live.server {
+		return live.roles
+	}
+	service := game.NewRoleConfigService(cfg)
+	service.SetCardService(h.cardService)
+	return service
+}
+
+// ReloadConfig applies the room and role settings of a re-read config. Rooms
+// next would invalidate, e.g. with more seated players than its cap or a
+// preset it removed, are pinned to the config they were using and their
+// hosts get a banner, rather than later validation failing without a reason.
+// Listener, timeout and secret settings need a restart. It returns how many
+// rooms were pinned.
+//
+// The new config is published before live rooms are checked against it, so
+// a room created during the reload is either checked or already on it.
+func (h *Handler) ReloadConfig(next *config.ServerConfig) int {
+	h.reloadMu.Lock()
+	defer h.reloadMu.Unlock()
+
+	previous := h.live.Load()
+	merged := *previous.server
+	merged.Server.MaxPlayersPerRoom = next.Server.MaxPlayersPerRoom
+	merged.Server.MinPlayersPerRoom = next.Server.MinPlayersPerRoom
+	merged.Server.DefaultGameSize = next.Server.DefaultGameSize
+	merged.Server.LargeRoomThreshold = next.Server.LargeRoomThreshold
+	merged.Roles = next.Roles
+	h.publishConfig(&merged)
+	if s, ok := h.store.(configurable); ok {
+		s.SetConfig(&merged)
+	}
+	current := h.live.Load()
+
+	pinned := 0
+	for _, room := range h.store.Rooms() {
+		room.Lock()
+		if room.PinnedConfig == nil {
+			captured, scopedNext := roomConfigIn(previous, h.tenants, room), roomConfigIn(current, h.tenants, room)
+			if conflicts := room.ConfigConflicts(scopedNext); len(conflicts) > 0 {
+				room.PinConfig(captured, "Server settings changed: "+strings.Join(conflicts, "; ")+". This room keeps its original settings until it closes.")
+				pinned++
+				log.Printf("📌 Room %s pinned to its previous config: %s", room.Code, strings.Join(conflicts, "; "))
+				h.eventBus.Publish(Event{
+					Type:     EventConfigMigrated,
+					RoomCode: room.Code,
+					Data:     room,
+				})
+			} else if room.State == game.StateLobby {
+				room.FitConfig(scopedNext)
+			}
+		}
+		room.Unlock()
+	}
+
+	log.Printf("🔄 Config reloaded: max players per room = %d, %d presets, %d rooms pinned", next.Server.MaxPlayersPerRoom, len(next.Roles.Presets), pinned)
+	return pinned
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"testing"
+
+	"treacherest/internal/config"
+)
+
+func TestReloadConfigPinsInvalidatedRooms(t *testing.T) {
+	h := newTestHandler()
+	presetRoom, _ := newPhaseTestRoom(t, h)
+	customRoom, err := h.store.CreateRoom()
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	customRoom.RoleConfig.PresetName = "custom"
+	events := h.eventBus.Subscribe(presetRoom.Code)
+	defer h.eventBus.Unsubscribe(presetRoom.Code, events)
+
+	next := config.DefaultConfig()
+	next.Server.MaxPlayersPerRoom = 8
+	delete(next.Roles.Presets, "standard")
+
+	if pinned := h.ReloadConfig(next); pinned != 1 {
+		t.Fatalf("expected one room pinned, got %d", pinned)
+	}
+
+	if presetRoom.PinnedConfig == nil || presetRoom.ConfigNotice == "" {
+		t.Fatal("expected the room using the removed preset to be pinned with a notice")
+	}
+	if _, ok := h.roomConfig(presetRoom).GetPreset("standard"); !ok {
+		t.Error("expected the pinned room to keep the removed preset")
+	}
+	if h.roomConfig(presetRoom).Server.MaxPlayersPerRoom != 20 {
+		t.Errorf("expected the pinned room to keep its player cap, got %d", h.roomConfig(presetRoom).Server.MaxPlayersPerRoom)
+	}
+	select {
+	case event := <-events:
+		if event.Type != "config_migrated" {
+			t.Errorf("expected config_migrated, got %s", event.Type)
+		}
+	default:
+		t.Error("expected the pinned room's host to be notified")
+	}
+
+	if customRoom.PinnedConfig != nil {
+		t.Error("expected a room the new config leaves valid to follow it")
+	}
+	if customRoom.MaxPlayers != 8 || customRoom.RoleConfig.MaxPlayers > 8 {
+		t.Errorf("expected the lobby's caps clamped to 8, got %d and %d", customRoom.MaxPlayers, customRoom.RoleConfig.MaxPlayers)
+	}
+	if _, ok := h.config().GetPreset("standard"); ok || h.config().Server.MaxPlayersPerRoom != 8 {
+		t.Error("expected the server config to take the reloaded settings")
+	}
+
+	// A later reload never re-pins or re-notifies
+	if pinned := h.ReloadConfig(next); pinned != 0 {
+		t.Errorf("expected an already pinned room to stay as it is, got %d pinned", pinned)
+	}
+}
+
+func TestReloadConfigWhileServing(t *testing.T) {
+	h := newTestHandler()
+	room, _ := newPhaseTestRoom(t, h)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			next := config.DefaultConfig()
+			next.Server.MaxPlayersPerRoom = 12 + i%2
+			h.ReloadConfig(next)
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			if got := h.config().Server.MaxPlayersPerRoom; got != 13 {
+				t.Errorf("expected the last reload's cap, got %d", got)
+			}
+			return
+		default:
+		}
+		room.Lock()
+		h.roomConfig(room).GetPreset("standard")
+		h.roleConfigFor(room)
+		room.Unlock()
+		_ = h.config().Server.DefaultGameSize
+	}
+}
+
+func TestRoomsCreatedAfterAReloadFollowIt(t *testing.T) {
+	h := newTestHandler()
+	next := config.DefaultConfig()
+	next.Server.MaxPlayersPerRoom = 8
+	h.ReloadConfig(next)
+
+	room, err := h.store.CreateRoom()
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	if room.MaxPlayers != 8 {
+		t.Errorf("expected a new room to start from the reloaded cap, got %d", room.MaxPlayers)
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+)
+
+// SetCountdownStyle saves how the effective player wants the countdown shown.
+// Mid-countdown the new presentation replaces the old one on the caller's
+// page; nobody else's page changes, so no event is published.
+func (h *Handler) SetCountdownStyle(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+
+	style, err := game.ParseCountdownStyle(r.FormValue("style"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := room.SetPlayerCountdownStyle(player.ID, style); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, game.ErrPlayerNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	if room.State != game.StateCountdown || room.StartRitual.Ritual == game.StartRitualConfirm {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	sse := datastar.NewSSE(w, r)
+	html := renderFragment(components.CountdownForPlayer(style, room.CountdownRemaining, components.RevealCountdownMessage), "#countdown-display", roomCode)
+	h.patchElements(sse, PageGame, html, "#countdown-display")
+}
+
+// announceCountdown re-renders the countdown sentence for a player who reads
+// the countdown as text, so each second reaches their screen reader from the
+// server. The caller holds the room's read lock.
+func (h *Handler) announceCountdown(sse *datastar.ServerSentEventGenerator, r *http.Request, room *game.Room, player *game.Player) {
+	if room == nil || room.State != game.StateCountdown {
+		return
+	}
+	renderPlayer := h.effectivePlayerForRender(r, room, player)
+	if renderPlayer.CountdownPresentation() != game.CountdownText {
+		return
+	}
+	html := renderFragment(components.CountdownAnnouncement(room.CountdownRemaining), "#countdown-announcement", room.Code)
+	h.patchElements(sse, PageGame, html, "#countdown-announcement")
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestSetCountdownStyle(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	stylePath := "/room/" + room.Code + "/countdown-style"
+	alice := seatedClient(t, router, room.Code, player.ID)
+
+	if w := testkit.NewClient(t, router).Post(stylePath, url.Values{"style": {"text"}}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected anonymous change to be rejected, got %d", w.Code)
+	}
+	if w := alice.Post(stylePath, url.Values{"style": {"morse"}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an unknown style to be rejected, got %d", w.Code)
+	}
+
+	if w := alice.Post(stylePath, url.Values{"style": {"progress"}}); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 in the lobby, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := room.GetPlayer(player.ID).CountdownPresentation(); got != game.CountdownProgress {
+		t.Fatalf("expected the progress style to be stored, got %q", got)
+	}
+
+	room.State = game.StateCountdown
+	room.CountdownRemaining = 3
+	w := alice.Post(stylePath, url.Values{"style": {"text"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the countdown to be re-rendered, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{"selector #countdown-display", `data-countdown-style="text"`, "Roles are revealed in 3 seconds."} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected response to contain %q, got %s", expected, body)
+		}
+	}
+}
+
+func TestAnnounceCountdownOnlyForTextStyle(t *testing.T) {
+	h := newTestHandler()
+	room, player := newPhaseTestRoom(t, h)
+	room.State = game.StateCountdown
+	room.CountdownRemaining = 1
+
+	announce := func() string {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/sse/room/"+room.Code+"?view=game", nil)
+		viewRoom(room, func() bool {
+			h.announceCountdown(datastar.NewSSE(w, r), r, room, player)
+			return false
+		})
+		return w.Body.String()
+	}
+
+	if body := announce(); strings.Contains(body, "countdown-announcement") {
+		t.Fatalf("expected no announcement for the numeric style, got %s", body)
+	}
+	if err := room.SetPlayerCountdownStyle(player.ID, game.CountdownText); err != nil {
+		t.Fatal(err)
+	}
+	if body := announce(); !strings.Contains(body, "Roles are revealed in 1 second.") {
+		t.Fatalf("expected the text style to be announced, got %s", body)
+	}
+}
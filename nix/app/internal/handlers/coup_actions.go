@@ -55,9 +55,10 @@ func (h *Handler) UseCoupRoyalGuard(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🛡️ Blue Knight %s used Royal Guard in room %s", player.Name, roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "role_revealed",
+		Type:     EventRoleRevealed,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -142,9 +143,10 @@ func (h *Handler) CallCoupInquisition(w http.ResponseWriter, r *http.Request) {
 	log.Printf("🔎 Blue Knight %s called Inquisition naming %s in room %s", player.Name, target.Name, roomCode)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_inquisition_called",
+		Type:     EventCoupInquisitionCalled,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -205,9 +207,10 @@ func (h *Handler) ConfirmCoupInquisition(w http.ResponseWriter, r *http.Request)
 	log.Printf("🔎 Inquisition in room %s confirmed by %s (success: %v)", roomCode, witness.Name, result.Success)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_inquisition_resolved",
+		Type:     EventCoupInquisitionResolved,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
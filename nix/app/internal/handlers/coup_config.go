@@ -38,31 +38,39 @@ func (h *Handler) UpdateCoupPreset(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Preset required", http.StatusBadRequest)
 		return
 	}
-	if _, ok := game.CoupPresetPlayerCount(preset); !ok {
-		http.Error(w, "Invalid Coup preset", http.StatusBadRequest)
-		return
-	}
-	counts, ok := game.CoupRoleCountsForPreset(preset)
-	if !ok {
+	if !applyCoupPreset(room, preset) {
 		http.Error(w, "Invalid Coup preset", http.StatusBadRequest)
 		return
 	}
-
-	room.CoupPreset = preset
-	room.CoupRoleCounts = counts
-	room.CoupRoleCountsCustom = false
-	room.CoupAllowUnsafeRoleCounts = false
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
 }
 
+// applyCoupPreset selects a Coup preset and reseeds its role counts.
+func applyCoupPreset(room *game.Room, preset game.CoupPreset) bool {
+	if _, ok := game.CoupPresetPlayerCount(preset); !ok {
+		return false
+	}
+	counts, ok := game.CoupRoleCountsForPreset(preset)
+	if !ok {
+		return false
+	}
+
+	room.CoupPreset = preset
+	room.CoupRoleCounts = counts
+	room.CoupRoleCountsCustom = false
+	room.CoupAllowUnsafeRoleCounts = false
+	return true
+}
+
 // IncrementCoupPlayerCount selects the next supported default Coup preset.
 func (h *Handler) IncrementCoupPlayerCount(w http.ResponseWriter, r *http.Request) {
 	h.updateCoupPlayerCount(w, r, 1)
@@ -120,9 +128,10 @@ func (h *Handler) updateCoupPlayerCount(w http.ResponseWriter, r *http.Request,
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -173,9 +182,10 @@ func (h *Handler) UpdateCoupRoleCounts(w http.ResponseWriter, r *http.Request) {
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -236,9 +246,10 @@ func (h *Handler) updateCoupRoleCount(w http.ResponseWriter, r *http.Request, de
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -314,9 +325,10 @@ func (h *Handler) UpdateCoupInfoPolicy(w http.ResponseWriter, r *http.Request) {
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -355,9 +367,10 @@ func (h *Handler) UpdateCoupRoyalGuardSettings(w http.ResponseWriter, r *http.Re
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -391,9 +404,10 @@ func (h *Handler) UpdateCoupInquisitionSettings(w http.ResponseWriter, r *http.R
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
@@ -427,43 +441,34 @@ func (h *Handler) UpdateCoupGreenHuntSettings(w http.ResponseWriter, r *http.Req
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	h.renderCoupConfigResponse(w, r, room)
 }
 
 func (h *Handler) renderCoupConfigResponse(w http.ResponseWriter, r *http.Request, room *game.Room) {
-	playerCookie, err := r.Cookie("player_" + room.Code)
-	if err != nil {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-	player := room.GetPlayer(playerCookie.Value)
-	if player == nil {
+	viewer := h.viewerContext(r, room)
+	if viewer.Player == nil {
 		w.WriteHeader(http.StatusOK)
 		return
 	}
 
 	sse := datastar.NewSSE(w, r)
-	if room.IsOperatorSession(player.SessionID) || requestHasHostDashboardCookie(r, room.Code) {
+	if viewer.CanControl {
 		h.renderHostDashboardCoupConfigUpdate(sse, room)
 		return
 	}
-	h.renderLobby(sse, room, player)
+	h.renderLobby(sse, room, viewer.Player)
 }
 
 func (h *Handler) renderHostDashboardCoupConfigUpdate(sse *datastar.ServerSentEventGenerator, room *game.Room) {
-	setupHTML := renderToString(pages.HostDashboardCoupSetup(room))
-	sse.PatchElements(setupHTML, datastar.WithSelector("#host-dashboard-coup-setup"))
-
-	startHTML := renderToString(pages.HostDashboardStartControls(room, h.config))
-	sse.PatchElements(startHTML, datastar.WithSelector("#operator-start-controls"))
-}
+	setupHTML := renderFragment(pages.HostDashboardCoupSetup(room), "#host-dashboard-coup-setup", room.Code)
+	h.patchElements(sse, PageHost, setupHTML, "#host-dashboard-coup-setup")
 
-func requestHasHostDashboardCookie(r *http.Request, roomCode string) bool {
-	hostCookie, err := r.Cookie("host_" + roomCode)
-	return err == nil && hostCookie.Value == "true"
+	startHTML := renderFragment(pages.HostDashboardStartControls(room, h.roomConfig(room)), "#operator-start-controls", room.Code)
+	h.patchElements(sse, PageHost, startHTML, "#operator-start-controls")
 }
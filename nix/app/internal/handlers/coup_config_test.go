@@ -184,7 +184,7 @@ func TestUpdateCoupPlayerCountIncrementsPresetAndRoleCounts(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -236,7 +236,7 @@ func TestUpdateCoupPlayerCountFromHostCookiePatchesHostSurface(t *testing.T) {
 	markRoomOperatorForTest(room, operator)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -273,7 +273,7 @@ func TestUpdateCoupPlayerCountAtMaximumRerendersCurrentState(t *testing.T) {
 	markRoomOperatorForTest(room, host)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -321,7 +321,7 @@ func TestUpdateCoupPlayerCountPreservesCustomRoleCounts(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -505,7 +505,7 @@ func TestSetupRouter_RoutesCoupRoleCountUpdates(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -549,7 +549,7 @@ func TestSetupRouter_RoutesCoupRoleCountStepperUpdatesOneRole(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -595,7 +595,7 @@ func TestSetupRouter_RejectsLockedCoupRoleCountStepperWithoutUnsafeOverride(t *t
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -633,7 +633,7 @@ func TestSetupRouter_AllowsRequiredCoupRoleStepperWithUnsafeOverride(t *testing.
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -711,7 +711,7 @@ func TestSetupRouter_RoutesCoupPresetUpdates(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -786,7 +786,7 @@ func TestSetupRouter_RoutesCoupInfoPolicyUpdates(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -852,7 +852,7 @@ func TestSetupRouter_RoutesCoupRoyalGuardSettings(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -918,7 +918,7 @@ func TestSetupRouter_RoutesCoupInquisitionSettings(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -990,7 +990,7 @@ func TestSetupRouter_RoutesCoupGreenHuntSettings(t *testing.T) {
 	markRoomOperatorForTest(room, player)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
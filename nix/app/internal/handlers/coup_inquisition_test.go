@@ -281,7 +281,7 @@ func TestSetupRouter_RoutesCoupInquisitionFlow(t *testing.T) {
 	h := newTestHandler()
 	room, blue, red, green := setupCoupInquisitionRoom(t, h)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -25,9 +25,10 @@ func (h *Handler) ConfirmCoupWinPrompt(w http.ResponseWriter, r *http.Request) {
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "game_ended",
+		Type:     EventGameEnded,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
@@ -50,9 +51,10 @@ func (h *Handler) RejectCoupWinPrompt(w http.ResponseWriter, r *http.Request) {
 	h.store.UpdateRoom(room)
 
 	h.eventBus.Publish(Event{
-		Type:     "coup_win_prompt_rejected",
+		Type:     EventCoupWinPromptRejected,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	w.WriteHeader(http.StatusOK)
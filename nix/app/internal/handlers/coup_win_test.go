@@ -82,7 +82,7 @@ func TestConfirmCoupWinPrompt_EliminatedPlayerCannotConfirm(t *testing.T) {
 
 func TestSetupRouter_RoutesCoupWinPromptDecisions(t *testing.T) {
 	h := newTestHandler()
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"treacherest/internal/game"
+)
+
+// errNoCardService is a treachery deal on a server whose cards failed to load
+var errNoCardService = errors.New("card service unavailable")
+
+// dealRoles assigns every player a role under the room's rules without
+// telling anyone; finishStart broadcasts the deal or holds it for approval
+func (h *Handler) dealRoles(room *game.Room) error {
+	players := room.GetPlayers()
+	if room.RulesMode == game.RulesModeCoup {
+		if room.CoupRoleCountsCustom {
+			return game.AssignCoupRolesWithCountsAndInformationUnsafe(players, room.CoupRoleCounts, room.CoupInfoPolicy, room.CoupAllowUnsafeRoleCounts)
+		}
+		return game.AssignCoupRolesWithInformation(players, room.CoupPreset, room.CoupInfoPolicy)
+	}
+
+	if h.cardService == nil {
+		return errNoCardService
+	}
+	log.Printf("🎲 Assigning roles to %d players", len(players))
+	if room.RoleConfig != nil {
+		log.Printf("🎲 Using role configuration: %+v", room.RoleConfig)
+		roleService := game.NewRoleConfigService(h.roomConfig(room))
+		game.AssignRolesWithConfig(players, h.cardService, room.RoleConfig, roleService)
+	} else {
+		// Fallback to legacy assignment
+		log.Printf("🎲 Using legacy role assignment")
+		game.AssignRoles(players, h.cardService)
+	}
+	game.ApplyRoleKnowledge(players, h.roomConfig(room))
+	for _, p := range players {
+		if p.Role != nil {
+			log.Printf("🎲 Player %s assigned role: %s", p.Name, p.Role.Name)
+		} else {
+			log.Printf("❌ Player %s has no role assigned!", p.Name)
+		}
+	}
+	return nil
+}
+
+// finishStart sends a dealt room's roles to the players, or, when the Room
+// Operator approves deals, holds them on the host dashboard instead
+func (h *Handler) finishStart(w http.ResponseWriter, r *http.Request, room *game.Room) {
+	if room.DealApprovalOn() {
+		room.DealPending = true
+		h.store.UpdateRoom(room)
+		h.eventBus.Publish(Event{
+			Type:     EventDealPending,
+			RoomCode: room.Code,
+			Data:     room,
+			Actor:    h.requestActor(r, room),
+		})
+		log.Printf("🃏 Deal in room %s is waiting for the Room Operator's approval", room.Code)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	h.broadcastStart(room, h.requestActor(r, room))
+	h.sendToGamePage(w, r, room.Code)
+}
+
+// broadcastStart runs the start ritual for a dealt room and tells every player
+func (h *Handler) broadcastStart(room *game.Room, actor EventActor) {
+	room.DealPending = false
+	h.beginStart(room, actor)
+
+	h.eventBus.Publish(Event{
+		Type:     EventGameStarted,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    actor,
+	})
+
+	log.Printf("✅ Game started successfully for room %s by %s", room.Code, actor)
+}
+
+// ApproveDeal sends the deal the Room Operator has looked over to the players
+// and starts the game
+func (h *Handler) ApproveDeal(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requirePendingDeal(w, r)
+	if !ok {
+		return
+	}
+	if room.DealOutdated() {
+		http.Error(w, game.ErrDealOutdated.Error(), http.StatusConflict)
+		return
+	}
+	if problem := h.dealProblem(room); problem != "" {
+		http.Error(w, problem, http.StatusConflict)
+		return
+	}
+
+	h.broadcastStart(room, h.requestActor(r, room))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Redeal deals the held game again, for a deal the Room Operator doesn't like
+// or one that is missing a player who joined after it
+func (h *Handler) Redeal(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requirePendingDeal(w, r)
+	if !ok {
+		return
+	}
+	if problem := h.dealProblem(room); problem != "" {
+		http.Error(w, problem, http.StatusConflict)
+		return
+	}
+	if err := h.dealRoles(room); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventDealPending,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+	log.Printf("🃏 Room Operator dealt room %s again", room.Code)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requirePendingDeal returns the room named in the URL when the request is
+// its Room Operator's and a deal is waiting for approval
+func (h *Handler) requirePendingDeal(w http.ResponseWriter, r *http.Request) (*game.Room, bool) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return nil, false
+	}
+	if room.State != game.StateLobby || !room.DealPending {
+		http.Error(w, game.ErrNoDealPending.Error(), http.StatusConflict)
+		return nil, false
+	}
+	return room, true
+}
+
+// dealProblem is why the room can't be dealt as it stands now, or "". Coup
+// role counts are checked by the deal itself.
+func (h *Handler) dealProblem(room *game.Room) string {
+	if room.RulesMode == game.RulesModeCoup {
+		return ""
+	}
+	validationState := room.GetValidationState(game.NewRoleConfigService(h.roomConfig(room)))
+	if !validationState.CanStart {
+		return validationState.ValidationMessage
+	}
+	return ""
+}
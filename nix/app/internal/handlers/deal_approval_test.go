@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestHostApprovesDealBeforePlayersSeeRoles(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.JoinRoom(t, router, host.RoomCode, "Alice")
+	testkit.JoinRoom(t, router, host.RoomCode, "Bob")
+	room, _ := h.store.GetRoom(host.RoomCode)
+
+	if w := host.Post("/room/"+room.Code+"/config/start-ritual", url.Values{"approveDeal": {"true"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected the setting to save, got %d: %s", w.Code, w.Body.String())
+	}
+	lobby := alice.OpenSSE("/sse/room/" + room.Code + "?view=lobby")
+	defer lobby.Close()
+	dashboard := host.OpenSSE("/sse/room/" + room.Code + "?view=host")
+	defer dashboard.Close()
+
+	if w := host.Post("/room/"+room.Code+"/start", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the start to hold the deal, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.State != game.StateLobby || !room.DealPending {
+		t.Fatalf("expected the deal to wait in the lobby, got %s pending=%v", room.State, room.DealPending)
+	}
+	aliceRole := room.GetPlayer(alice.PlayerID()).Role
+	if aliceRole == nil {
+		t.Fatal("expected roles to be dealt")
+	}
+	if !dashboard.WaitFor("operator-deal-preview", 2*time.Second) || !strings.Contains(dashboard.Data(), aliceRole.Name) {
+		t.Fatalf("expected the host dashboard to show the deal, got %s", dashboard.Data())
+	}
+	if strings.Contains(lobby.Data(), aliceRole.Name) || strings.Contains(lobby.Data(), "game-container") {
+		t.Fatalf("expected players to see nothing of the held deal, got %s", lobby.Data())
+	}
+
+	if w := alice.Post("/room/"+room.Code+"/deal/approve", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player's approval to be refused, got %d", w.Code)
+	}
+
+	// Someone joining after the deal has no role until it's dealt again
+	carol := testkit.JoinRoom(t, router, room.Code, "Carol")
+	if w := host.Post("/room/"+room.Code+"/deal/approve", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected an outdated deal to be refused, got %d", w.Code)
+	}
+	if w := host.Post("/room/"+room.Code+"/deal/redeal", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the re-deal to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.GetPlayer(carol.PlayerID()).Role == nil || room.State != game.StateLobby {
+		t.Fatalf("expected the re-deal to include Carol and still wait, got %s", room.State)
+	}
+
+	if w := host.Post("/room/"+room.Code+"/deal/approve", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the approval to start the game, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.State != game.StateCountdown || room.DealPending {
+		t.Fatalf("expected the approved deal to start the countdown, got %s pending=%v", room.State, room.DealPending)
+	}
+	if !lobby.WaitFor("game-container", 2*time.Second) {
+		t.Fatal("expected the lobby to move into the game once the deal was approved")
+	}
+	if w := host.Post("/room/"+room.Code+"/deal/redeal", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected no re-deal once the game started, got %d", w.Code)
+	}
+}
+
+func TestDealApprovalNeedsAHostWhoIsNotPlaying(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Operator", false)
+	testkit.JoinRoom(t, router, operator.RoomCode, "Alice")
+	room, _ := h.store.GetRoom(operator.RoomCode)
+	room.StartRitual.ApproveDeal = true
+
+	if room.DealApprovalOn() {
+		t.Fatal("expected a playing Room Operator not to see the deal")
+	}
+	if w := operator.Post("/room/"+room.Code+"/start?view=lobby", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the start to go ahead, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.State != game.StateCountdown || room.DealPending {
+		t.Fatalf("expected the game to start without approval, got %s pending=%v", room.State, room.DealPending)
+	}
+}
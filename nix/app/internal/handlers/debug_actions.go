@@ -3,10 +3,8 @@ package handlers
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/go-chi/chi/v5"
-	datastar "github.com/starfederation/datastar-go/datastar"
 	"treacherest/internal/game"
 )
 
@@ -61,8 +59,9 @@ func (h *Handler) DebugStartAsIs(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Internal server error: Cannot assign roles", http.StatusInternalServerError)
 			return
 		}
-		roleService := game.NewRoleConfigService(h.config)
+		roleService := game.NewRoleConfigService(h.roomConfig(room))
 		game.AssignRolesWithConfig(room.GetPlayers(), h.cardService, room.RoleConfig, roleService)
+		game.ApplyRoleKnowledge(room.GetPlayers(), h.roomConfig(room))
 	}
 
 	room.DebugStartMode = game.DebugStartModeAsIs
@@ -103,28 +102,21 @@ func (h *Handler) DebugOperatorView(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) finishDebugStartedRoom(w http.ResponseWriter, r *http.Request, room *game.Room) {
-	room.State = game.StateCountdown
-	room.CountdownRemaining = 5
-	room.StartedAt = time.Now()
-	h.store.UpdateRoom(room)
-
-	go h.runCountdown(room)
+	actor := h.requestActor(r, room)
+	h.beginStart(room, actor)
 
 	h.eventBus.Publish(Event{
-		Type:     "game_started",
+		Type:     EventGameStarted,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    actor,
 	})
 
-	sse := datastar.NewSSE(w, r)
-	sse.ExecuteScript("window.location.href = '/game/" + room.Code + "'")
-	if flusher, ok := w.(http.Flusher); ok {
-		flusher.Flush()
-	}
+	h.sendToGamePage(w, r, room.Code)
 }
 
 func (h *Handler) requireDebugHostRoom(w http.ResponseWriter, r *http.Request, roomCode string) (*game.Room, bool) {
-	if !h.config.Server.DebugModeEnabled {
+	if !h.config().Server.DebugModeEnabled {
 		http.Error(w, "Debug endpoints only available when debugModeEnabled is true", http.StatusForbidden)
 		return nil, false
 	}
@@ -135,12 +127,12 @@ func (h *Handler) requireDebugHostRoom(w http.ResponseWriter, r *http.Request, r
 		return nil, false
 	}
 
-	sessionCookie, err := r.Cookie("session")
-	if err != nil {
+	sessionID, ok := h.sessionID(r)
+	if !ok {
 		http.Error(w, "Debug operator access required", http.StatusUnauthorized)
 		return nil, false
 	}
-	if !room.IsOperatorSession(sessionCookie.Value) {
+	if !room.IsOperatorSession(sessionID) {
 		http.Error(w, "Debug operator access required", http.StatusForbidden)
 		return nil, false
 	}
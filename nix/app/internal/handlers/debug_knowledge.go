@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+	"treacherest/internal/views/pages"
+)
+
+// DebugKnowledge shows every seated player's view of the room side by side:
+// the role and known info they were dealt, the page they see and the
+// signals their stream sends. It is for checking per-player render paths
+// after a change, so it exists only in debug mode, for the Room Operator or
+// an admin token.
+func (h *Handler) DebugKnowledge(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	var room *game.Room
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && h.isAdminToken(token) {
+		var err error
+		if room, err = h.store.GetRoom(roomCode); err != nil {
+			http.Error(w, "Room not found", http.StatusNotFound)
+			return
+		}
+	} else if room, ok = h.requireDebugHostRoom(w, r, roomCode); !ok {
+		return
+	}
+
+	players := room.GetActivePlayers()
+	views := make([]pages.KnowledgeView, 0, len(players))
+	for _, player := range players {
+		views = append(views, h.knowledgeView(room, player, players))
+	}
+	pages.DebugKnowledgePage(room, views).Render(r.Context(), w)
+}
+
+// knowledgeView renders the content player's stream would send them now
+func (h *Handler) knowledgeView(room *game.Room, player *game.Player, players []*game.Player) pages.KnowledgeView {
+	var component templ.Component
+	var signals map[string]interface{}
+	if room.State == game.StateLobby {
+		component = pages.LobbyContent(room, player, h.roomConfig(room), h.cardService)
+		validationState := room.GetValidationState(game.NewRoleConfigService(h.roomConfig(room)))
+		signals = lobbyValidationSignals(room, components.NewViewerContext(room, player), validationState)
+	} else {
+		component = pages.GameContent(room, player)
+		signals = map[string]interface{}{"countdown": room.CountdownRemaining}
+	}
+
+	body := renderToString(component)
+	encoded, err := json.MarshalIndent(signals, "", "  ")
+	if err != nil {
+		encoded = []byte(err.Error())
+	}
+	return pages.KnowledgeView{
+		Player:  player,
+		HTML:    body,
+		Signals: string(encoded),
+		Leaks:   roleLeaks(body, player, players),
+	}
+}
+
+// roleLeaks names the cards of other players that appear in body although
+// they aren't public: not the Leader's, not revealed or face up. Known info
+// only ever tells a player a role type, so each is a lead to check; card text
+// that mentions another card can name one legitimately.
+func roleLeaks(body string, viewer *game.Player, players []*game.Player) []string {
+	var leaks []string
+	for _, other := range players {
+		if other.ID == viewer.ID || other.Role == nil || rolePublic(other) {
+			continue
+		}
+		if viewer.Role != nil && viewer.Role.Name == other.Role.Name {
+			continue
+		}
+		if strings.Contains(body, html.EscapeString(other.Role.Name)) {
+			leaks = append(leaks, fmt.Sprintf("%s (%s)", other.Role.Name, other.Name))
+		}
+	}
+	return leaks
+}
+
+// rolePublic reports whether every player may see player's card
+func rolePublic(player *game.Player) bool {
+	return player.RoleRevealed || player.FaceUp || player.Role.GetRoleType() == game.RoleLeader
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+)
+
+func TestDebugKnowledgeShowsEveryPlayersView(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Server.DebugModeEnabled = true
+	s := store.NewMemoryStore(cfg)
+	h := New(s, createMockCardService(), cfg, nil)
+	h.SetAdminToken("secret")
+	router := newTestRouter(h)
+
+	room, alice := newPhaseTestRoom(t, h)
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	bob.Role = &game.Card{ID: 3, Name: "Test Assassin", Types: game.CardTypes{Subtype: "Assassin"}}
+	bob.FaceUp = false
+	room.AddPlayer(bob)
+	alice.KnownInfo = []game.KnownInfo{{PlayerID: bob.ID, PlayerName: bob.Name, RoleType: game.RoleAssassin}}
+	room.State = game.StatePlaying
+	path := "/room/" + room.Code + "/debug/knowledge"
+
+	get := func(session, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if session != "" {
+			req.AddCookie(&http.Cookie{Name: "session", Value: session})
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("s1", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player to be refused, got %d", w.Code)
+	}
+	if w := get("", "secret"); w.Code != http.StatusOK {
+		t.Fatalf("expected an admin token to open the page, got %d", w.Code)
+	}
+
+	w := get("operator-session", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`data-player-id="p1"`, `data-player-id="p2"`, "Bob is a Assassin", `&#34;countdown&#34;`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the page to show %q", want)
+		}
+	}
+	if strings.Contains(body, "Names unrevealed roles") {
+		t.Error("expected no player's page to name another's card")
+	}
+}
+
+func TestRoleLeaksFlagsOtherPlayersUnrevealedCards(t *testing.T) {
+	alice := game.NewPlayer("p1", "Alice", "s1")
+	alice.Role = &game.Card{Name: "The Bodyguard"}
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	bob.Role = &game.Card{Name: "The Oracle's Apprentice"}
+	alice.FaceUp, bob.FaceUp = false, false
+	carol := game.NewPlayer("p3", "Carol", "s3")
+	carol.Role = &game.Card{Name: "The King"}
+	carol.RoleRevealed, carol.FaceUp = true, false
+	players := []*game.Player{alice, bob, carol}
+
+	body := "<p>The Bodyguard</p><p>The King</p><p>The Oracle&#39;s Apprentice</p>"
+	if leaks := roleLeaks(body, alice, players); len(leaks) != 1 || leaks[0] != "The Oracle's Apprentice (Bob)" {
+		t.Errorf("expected only Bob's unrevealed card flagged, got %v", leaks)
+	}
+	if leaks := roleLeaks(body, bob, players); len(leaks) != 1 || leaks[0] != "The Bodyguard (Alice)" {
+		t.Errorf("expected Bob's own card not flagged, got %v", leaks)
+	}
+}
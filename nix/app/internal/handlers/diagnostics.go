@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/roomlog"
+)
+
+// ipAddress matches IPv4 addresses, and IPv6 ones loosely, in log lines
+var ipAddress = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b|\b(?:[0-9a-fA-F]{1,4}:){2,7}[0-9a-fA-F]{1,4}\b`)
+
+// roomDiagnostics is the problem report a Room Operator downloads. Player
+// names, IDs, sessions, tokens and dealt roles are scrubbed, so the file can
+// be attached to a public bug report.
+type roomDiagnostics struct {
+	RoomCode    string                 `json:"roomCode"`
+	GeneratedAt time.Time              `json:"generatedAt"`
+	State       game.GameState         `json:"state"`
+	RulesMode   string                 `json:"rulesMode"`
+	Players     []diagnosticPlayer     `json:"players"`
+	Config      diagnosticConfig       `json:"config"`
+	Entries     []roomlog.Entry        `json:"entries"`
+	Connections map[string]interface{} `json:"connections"`
+}
+
+type diagnosticPlayer struct {
+	Alias      string `json:"alias"`
+	IsHost     bool   `json:"isHost,omitempty"`
+	IsDebug    bool   `json:"isDebug,omitempty"`
+	HasRole    bool   `json:"hasRole"`
+	Revealed   bool   `json:"revealed,omitempty"`
+	Eliminated bool   `json:"eliminated,omitempty"`
+}
+
+type diagnosticConfig struct {
+	MaxPlayers           int                      `json:"maxPlayers"`
+	RoleConfig           *game.RoleConfiguration  `json:"roleConfig,omitempty"`
+	CoupPreset           game.CoupPreset          `json:"coupPreset,omitempty"`
+	Phases               game.PhaseSettings       `json:"phases"`
+	StartRitual          game.StartRitualSettings `json:"startRitual"`
+	ScreenshotDeterrence bool                     `json:"screenshotDeterrence,omitempty"`
+}
+
+// trackRoomLogs starts capturing log lines for a new or restored room
+func (h *Handler) trackRoomLogs(roomCode string) {
+	if h.roomLogs != nil {
+		h.roomLogs.Track(roomCode)
+	}
+}
+
+// DownloadDiagnostics is the Room Operator's "report a problem" action: a
+// scrubbed JSON bundle of the room's recent server logs, events and settings
+func (h *Handler) DownloadDiagnostics(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	log.Printf("🧾 Diagnostics requested for room %s", room.Code)
+
+	report := roomDiagnostics{
+		RoomCode:    room.Code,
+		GeneratedAt: h.clock.Now().UTC(),
+		State:       room.State,
+		RulesMode:   rulesModeName(room.RulesMode),
+		Config: diagnosticConfig{
+			MaxPlayers:           room.MaxPlayers,
+			RoleConfig:           room.RoleConfig,
+			CoupPreset:           room.CoupPreset,
+			Phases:               room.PhaseSettings,
+			StartRitual:          room.StartRitual,
+			ScreenshotDeterrence: room.ScreenshotDeterrence,
+		},
+		Entries: []roomlog.Entry{},
+		Connections: map[string]interface{}{
+			"openStreams": h.connTracker.GetConnectionCount(room.Code),
+		},
+	}
+
+	scrub := newDiagnosticScrubber(room)
+	for i, player := range sortedPlayers(room) {
+		report.Players = append(report.Players, diagnosticPlayer{
+			Alias:      scrub.aliases[i].alias,
+			IsHost:     player.IsHost,
+			IsDebug:    player.IsDebug,
+			HasRole:    player.Role != nil,
+			Revealed:   player.RoleRevealed,
+			Eliminated: player.IsEliminated,
+		})
+	}
+	if h.roomLogs != nil {
+		for _, entry := range h.roomLogs.Entries(room.Code) {
+			entry.Text = scrub.apply(entry.Text)
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		http.Error(w, "Failed to build diagnostics", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="treacherest-%s-%s.json"`, room.Code, report.GeneratedAt.Format("20060102-150405")))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+}
+
+// diagnosticScrubber replaces personal and secret strings in log lines
+type diagnosticScrubber struct {
+	aliases      []scrubAlias
+	replacements *strings.Replacer
+}
+
+type scrubAlias struct {
+	player *game.Player
+	alias  string
+}
+
+func newDiagnosticScrubber(room *game.Room) diagnosticScrubber {
+	var s diagnosticScrubber
+	var pairs []string
+	for i, player := range sortedPlayers(room) {
+		alias := fmt.Sprintf("player-%d", i+1)
+		if player.IsHost {
+			alias = fmt.Sprintf("host-%d", i+1)
+		}
+		s.aliases = append(s.aliases, scrubAlias{player: player, alias: alias})
+		for _, secret := range []string{player.ID, player.SessionID, player.Name} {
+			if secret != "" {
+				pairs = append(pairs, secret, alias)
+			}
+		}
+		// The Room Operator only ever learns roles the table has seen
+		if player.Role != nil && player.Role.Name != "" && !player.RoleRevealed {
+			pairs = append(pairs, player.Role.Name, "[hidden role]")
+		}
+	}
+	for _, token := range []string{room.CreatorToken, room.OverlayToken} {
+		if token != "" {
+			pairs = append(pairs, token, "[token]")
+		}
+	}
+	for _, link := range room.GetWatchLinks() {
+		pairs = append(pairs, link.Token, "[token]")
+	}
+	s.replacements = strings.NewReplacer(longestFirst(pairs)...)
+	return s
+}
+
+func (s diagnosticScrubber) apply(text string) string {
+	return ipAddress.ReplaceAllString(s.replacements.Replace(text), "[ip]")
+}
+
+// longestFirst orders old/new pairs so a name never pre-empts a longer
+// secret that contains it
+func longestFirst(pairs []string) []string {
+	type pair struct{ old, new string }
+	ordered := make([]pair, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ordered = append(ordered, pair{pairs[i], pairs[i+1]})
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return len(ordered[i].old) > len(ordered[j].old) })
+	flat := make([]string, 0, len(pairs))
+	for _, p := range ordered {
+		flat = append(flat, p.old, p.new)
+	}
+	return flat
+}
+
+// sortedPlayers lists every seat, hosts included, in join order
+func sortedPlayers(room *game.Room) []*game.Player {
+	players := room.GetPlayers()
+	sort.SliceStable(players, func(i, j int) bool { return players[i].JoinedAt.Before(players[j].JoinedAt) })
+	return players
+}
+
+func rulesModeName(mode game.RulesMode) string {
+	if mode == game.RulesModeCoup {
+		return "coup"
+	}
+	return "treachery"
+}
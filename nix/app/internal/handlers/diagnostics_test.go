@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/roomlog"
+)
+
+func TestDownloadDiagnosticsScrubsPlayers(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	capture := roomlog.New(&bytes.Buffer{}, 0, 0)
+	h.SetRoomLogs(capture)
+	room, player := newPhaseTestRoom(t, h)
+	h.trackRoomLogs(room.Code)
+
+	logger := log.New(capture, "", 0)
+	logger.Printf("Player %s (%s) joined room %s from 203.0.113.7", player.Name, player.ID, room.Code)
+	logger.Printf("Player %s assigned role: %s in room %s", player.ID, player.Role.Name, room.Code)
+	h.eventBus.Publish(Event{Type: EventPlayerJoined, RoomCode: room.Code})
+
+	get := func(session string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/room/"+room.Code+"/diagnostics", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("s1"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected players to be refused, got %d", w.Code)
+	}
+
+	w := get("operator-session")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment;") {
+		t.Fatalf("expected a download, got %q", disposition)
+	}
+
+	body := w.Body.String()
+	for _, secret := range []string{"Alice", player.SessionID, "203.0.113.7", room.CreatorToken} {
+		if secret != "" && strings.Contains(body, secret) {
+			t.Errorf("expected %q to be scrubbed from %s", secret, body)
+		}
+	}
+
+	var report roomDiagnostics
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if len(report.Players) != 2 || len(report.Entries) != 3 {
+		t.Fatalf("expected 2 players and 3 entries, got %+v", report)
+	}
+	if want := fmt.Sprintf("Player player-2 (player-2) joined room %s from [ip]", room.Code); report.Entries[0].Text != want {
+		t.Errorf("expected %q, got %q", want, report.Entries[0].Text)
+	}
+	if want := "Player player-2 assigned role: [hidden role] in room " + room.Code; report.Entries[1].Text != want {
+		t.Errorf("expected the dealt role to be hidden, got %q", report.Entries[1].Text)
+	}
+	if report.Entries[2].Kind != roomlog.KindEvent || report.Entries[2].Text != "player_joined" {
+		t.Errorf("expected the published event, got %+v", report.Entries[2])
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+)
+
+const (
+	// Clients told to reconnect wait drainReconnectMin plus up to
+	// drainReconnectSpread, so they do not all hit the new instance at once
+	drainReconnectMin    = 2 * time.Second
+	drainReconnectSpread = 8 * time.Second
+
+	// drainRetryAfter is sent with SSE requests refused while draining
+	drainRetryAfter = "5"
+)
+
+// drainer moves SSE clients off this instance ahead of a restart. Once
+// draining, new streams are refused and open streams are closed with a
+// reconnect hint; readiness reports unavailable so the load balancer sends
+// the reconnects to the new instance.
+type drainer struct {
+	mu       sync.Mutex
+	draining chan struct{} // closed when draining starts
+	started  bool
+	active   int
+	idle     *sync.Cond // signalled when active drops to zero
+}
+
+func newDrainer() *drainer {
+	d := &drainer{draining: make(chan struct{})}
+	d.idle = sync.NewCond(&d.mu)
+	return d
+}
+
+// IsDraining reports whether draining has started
+func (d *drainer) IsDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.started
+}
+
+// Active returns the number of open SSE streams
+func (d *drainer) Active() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.active
+}
+
+// start begins draining; it is safe to call more than once
+func (d *drainer) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.started {
+		d.started = true
+		close(d.draining)
+		log.Printf("🚰 Draining: refusing new SSE streams and moving %d open stream(s)", d.active)
+	}
+}
+
+// acquire registers a stream, failing once draining has started
+func (d *drainer) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.started {
+		return false
+	}
+	d.active++
+	return true
+}
+
+func (d *drainer) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active--
+	if d.active == 0 {
+		d.idle.Broadcast()
+	}
+}
+
+// wait blocks until every stream has closed or ctx is done
+func (d *drainer) wait(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.idle.Broadcast()
+	})
+	defer stop()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for d.active > 0 {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%d SSE stream(s) still open: %w", d.active, ctx.Err())
+		}
+		d.idle.Wait()
+	}
+	return nil
+}
+
+// Drain starts draining and waits until all SSE streams have closed or ctx is done
+func (h *Handler) Drain(ctx context.Context) error {
+	h.drainer.start()
+	return h.drainer.wait(ctx)
+}
+
+// StartDrain starts draining from an admin request. It returns immediately;
+// the deploy script polls GET /admin/drain until activeStreams reaches zero.
+func (h *Handler) StartDrain(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	h.drainer.start()
+	h.GetDrain(w, r)
+}
+
+// GetDrain reports drain progress to an admin
+func (h *Handler) GetDrain(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	writeAdminJSON(w, map[string]interface{}{
+		"draining":      h.drainer.IsDraining(),
+		"activeStreams": h.drainer.Active(),
+	})
+}
+
+// Ready reports readiness; a draining instance is not ready for new traffic.
+// With ?details=1 it answers in JSON, with how the card set and the
+// configured roles line up.
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.drainer.IsDraining() {
+		http.Error(w, "Draining", http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Query().Get("details") == "" {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+		return
+	}
+
+	details := map[string]interface{}{"status": "ok"}
+	if h.cardService != nil {
+		details["cards"], _ = game.CheckCardCompatibility(h.config(), h.cardService)
+	}
+	writeAdminJSON(w, details)
+}
+
+// drainableSSE wraps an SSE handler so draining refuses it up front, or ends
+// it with a reconnect hint if it is already streaming
+func (h *Handler) drainableSSE(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.drainer.acquire() {
+			w.Header().Set("Retry-After", drainRetryAfter)
+			http.Error(w, "Server is restarting, retry shortly", http.StatusServiceUnavailable)
+			return
+		}
+		defer h.drainer.release()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		// A client that stops taking writes has gone, whatever its TCP
+		// connection says
+		w = h.withWriteDeadlines(w, func(err error) {
+			h.stuckWriter(r, err)
+			cancel()
+		})
+		// A slow client or a slow write holds up the writer goroutine, not
+		// the stream's event loop
+		w, closeQueue := h.withSendQueue(w, cancel)
+		defer closeQueue()
+		go func() {
+			select {
+			case <-h.drainer.draining:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		next(w, r.WithContext(ctx))
+
+		// The stream ended because of the drain and the client is still there:
+		// tell it to reload once the new instance is taking traffic
+		if r.Context().Err() == nil && h.drainer.IsDraining() && w.Header().Get("Content-Type") == "text/event-stream" {
+			delay := drainReconnectMin + time.Duration(rand.Int63n(int64(drainReconnectSpread)))
+			sse := datastar.NewSSE(w, r)
+			sse.ExecuteScript(fmt.Sprintf("setTimeout(() => window.location.reload(), %d)", delay.Milliseconds()))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+)
+
+func TestDrainMovesOpenStreamsAndRefusesNewOnes(t *testing.T) {
+	h := newTestHandler()
+
+	streaming := make(chan struct{})
+	stream := h.drainableSSE(func(w http.ResponseWriter, r *http.Request) {
+		datastar.NewSSE(w, r)
+		close(streaming)
+		<-r.Context().Done()
+	})
+
+	w := httptest.NewRecorder()
+	finished := make(chan struct{})
+	go func() {
+		stream(w, httptest.NewRequest("GET", "/sse/game/ABCDE", nil))
+		close(finished)
+	}()
+	<-streaming
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := h.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	<-finished
+
+	if !strings.Contains(w.Body.String(), "window.location.reload()") {
+		t.Errorf("expected a reconnect hint on the drained stream, got %q", w.Body.String())
+	}
+
+	refused := httptest.NewRecorder()
+	stream(refused, httptest.NewRequest("GET", "/sse/game/ABCDE", nil))
+	if refused.Code != http.StatusServiceUnavailable || refused.Header().Get("Retry-After") == "" {
+		t.Errorf("expected 503 with Retry-After for a new stream, got %d %q", refused.Code, refused.Header().Get("Retry-After"))
+	}
+
+	ready := httptest.NewRecorder()
+	h.Ready(ready, httptest.NewRequest("GET", "/health/ready", nil))
+	if ready.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness to fail while draining, got %d", ready.Code)
+	}
+}
+
+func TestReadyDetailsReportCardCompatibility(t *testing.T) {
+	h := newTestHandler()
+
+	w := httptest.NewRecorder()
+	h.Ready(w, httptest.NewRequest("GET", "/health/ready", nil))
+	if w.Body.String() != "OK" {
+		t.Errorf("expected the plain probe answer unchanged, got %q", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.Ready(w, httptest.NewRequest("GET", "/health/ready?details=1", nil))
+	var details struct {
+		Status string                 `json:"status"`
+		Cards  game.CardCompatibility `json:"cards"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &details); err != nil {
+		t.Fatalf("expected JSON details, got %q: %v", w.Body.String(), err)
+	}
+	if details.Status != "ok" || len(details.Cards.Roles) != len(h.config().Roles.Available) {
+		t.Errorf("expected every configured role in the report, got %+v", details)
+	}
+}
+
+func TestDrainWaitStopsAtDeadline(t *testing.T) {
+	d := newDrainer()
+	if !d.acquire() {
+		t.Fatal("expected acquire to succeed before draining")
+	}
+	d.start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := d.wait(ctx); err == nil {
+		t.Error("expected wait to give up with a stream still open")
+	}
+
+	d.release()
+	if err := d.wait(context.Background()); err != nil {
+		t.Errorf("expected wait to finish once the stream closed: %v", err)
+	}
+}
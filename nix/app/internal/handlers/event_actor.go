@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"treacherest/internal/game"
+)
+
+// EventActor is who caused an event: the player behind the request, or the
+// zero value for countdowns, timers and other server-driven events
+type EventActor struct {
+	PlayerID string
+	Name     string
+	Operator bool // acted with Room Operator authority
+}
+
+// String names the actor for logs
+func (a EventActor) String() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Operator:
+		return "Room Operator"
+	default:
+		return "server"
+	}
+}
+
+// requestActor returns the actor behind r in room: the seated player the
+// request's session belongs to, preferring the player cookie, and whether it
+// holds Room Operator authority
+func (h *Handler) requestActor(r *http.Request, room *game.Room) EventActor {
+	if room == nil {
+		return EventActor{}
+	}
+	sessionID, ok := h.sessionID(r)
+	if !ok {
+		return EventActor{}
+	}
+	actor := EventActor{Operator: room.IsOperatorSession(sessionID)}
+
+	if player := sessionPlayer(r, room, sessionID); player != nil {
+		actor.PlayerID = player.ID
+		actor.Name = player.Name
+	}
+	return actor
+}
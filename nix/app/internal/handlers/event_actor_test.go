@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+	"treacherest/internal/roomlog"
+	"treacherest/internal/views/components"
+)
+
+func TestRequestActor(t *testing.T) {
+	h := newTestHandler()
+	room, alice := newPhaseTestRoom(t, h)
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	if actor := h.requestActor(req, room); actor.Name != "Operator" || !actor.Operator {
+		t.Errorf("expected the seated Room Operator, got %+v", actor)
+	}
+
+	req = httptest.NewRequest("POST", "/", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: alice.SessionID})
+	req.AddCookie(&http.Cookie{Name: "player_" + room.Code, Value: alice.ID})
+	if actor := h.requestActor(req, room); actor.PlayerID != alice.ID || actor.Operator {
+		t.Errorf("expected Alice without operator authority, got %+v", actor)
+	}
+
+	if actor := h.requestActor(httptest.NewRequest("POST", "/", nil), room); actor != (EventActor{}) || actor.String() != "server" {
+		t.Errorf("expected no actor without a session, got %+v", actor)
+	}
+}
+
+func TestStartEventsCarryTheActingPlayer(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	router := newTestRouter(h)
+	capture := roomlog.New(&bytes.Buffer{}, 0, 0)
+	h.SetRoomLogs(capture)
+	room, alice := newPhaseTestRoom(t, h)
+	h.trackRoomLogs(room.Code)
+	room.StartRitual = game.StartRitualSettings{Ritual: game.StartRitualConfirm}
+
+	h.beginStart(room, EventActor{PlayerID: "op", Name: "Operator", Operator: true})
+	if room.StartedBy != "Operator" {
+		t.Fatalf("expected the room to record who started it, got %q", room.StartedBy)
+	}
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+	postStartConfirm(router, room, alice)
+	if event := <-events; event.Type != "start_confirmed" || event.Actor.Name != "Alice" {
+		t.Fatalf("expected Alice's confirmation to name her, got %s by %+v", event.Type, event.Actor)
+	}
+	if entries := capture.Entries(room.Code); len(entries) != 2 || entries[0].Text != "start_confirmed by Alice" || entries[1].Text != "game_playing by Alice" {
+		t.Errorf("expected the room log to attribute the event, got %+v", entries)
+	}
+
+	var buf bytes.Buffer
+	if err := components.StartedByNotice(room.StartedBy).Render(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Operator started the game") {
+		t.Errorf("expected the notice to name the starter, got %s", buf.String())
+	}
+}
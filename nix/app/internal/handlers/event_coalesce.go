@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// configEventTypes are the events a room setup change publishes. Each makes
+// every page in the room re-render, so bursts of them are coalesced.
+var configEventTypes = map[EventType]bool{
+	EventRoleConfigUpdated:           true,
+	EventCoupConfigUpdated:           true,
+	EventPhaseSettingsUpdated:        true,
+	EventStartRitualUpdated:          true,
+	EventScreenshotDeterrenceUpdated: true,
+}
+
+// eventCoalescer lets one event of each coalesced type through per room per
+// window. Later ones in the window are held, each replacing the last, and the
+// latest is published when the window ends: a host holding down a +/- button
+// costs the room one re-render per window instead of one per click. The
+// events carry the room itself, so the held one renders the newest state.
+type eventCoalescer struct {
+	types  map[EventType]bool
+	window time.Duration
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // room code + event type -> last delivery
+	held     map[string]Event
+}
+
+func newEventCoalescer(types map[EventType]bool, window time.Duration) *eventCoalescer {
+	return &eventCoalescer{
+		types:    types,
+		window:   window,
+		lastSent: make(map[string]time.Time),
+		held:     make(map[string]Event),
+	}
+}
+
+// hold reports whether event is held back. The first held event of a window
+// schedules publish to deliver the latest one when the window ends.
+func (c *eventCoalescer) hold(event Event, publish func(Event)) bool {
+	if !c.types[event.Type] {
+		return false
+	}
+	key := event.RoomCode + "/" + string(event.Type)
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, waiting := c.held[key]; waiting {
+		c.held[key] = event
+		return true
+	}
+	if wait := c.window - now.Sub(c.lastSent[key]); wait > 0 {
+		c.held[key] = event
+		time.AfterFunc(wait, func() { c.flush(key, publish) })
+		return true
+	}
+	c.lastSent[key] = now
+	c.sweep(now)
+	return false
+}
+
+// flush publishes the event held for key
+func (c *eventCoalescer) flush(key string, publish func(Event)) {
+	c.mu.Lock()
+	event, waiting := c.held[key]
+	delete(c.held, key)
+	c.lastSent[key] = time.Now()
+	c.mu.Unlock()
+
+	if waiting {
+		publish(event)
+	}
+}
+
+// sweep forgets deliveries too old to hold anything back. The caller holds
+// c.mu.
+func (c *eventCoalescer) sweep(now time.Time) {
+	if len(c.lastSent) < 256 {
+		return
+	}
+	for key, sent := range c.lastSent {
+		if _, waiting := c.held[key]; !waiting && now.Sub(sent) >= c.window {
+			delete(c.lastSent, key)
+		}
+	}
+}
+
+// SetCoalescing holds back bursts of the given event types per room, so at
+// most one of each is delivered per window; 0 turns coalescing off
+func (eb *EventBus) SetCoalescing(types map[EventType]bool, window time.Duration) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	if window <= 0 {
+		eb.coalescer = nil
+		return
+	}
+	eb.coalescer = newEventCoalescer(types, window)
+}
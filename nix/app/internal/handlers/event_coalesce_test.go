@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventBusCoalescesConfigEvents(t *testing.T) {
+	bus := NewEventBus()
+	bus.SetCoalescing(configEventTypes, 50*time.Millisecond)
+	events := bus.Subscribe("ROOM1")
+	defer bus.Unsubscribe("ROOM1", events)
+
+	for _, actor := range []string{"first", "second", "third"} {
+		bus.Publish(Event{Type: EventRoleConfigUpdated, RoomCode: "ROOM1", Actor: EventActor{Name: actor}})
+	}
+	bus.Publish(Event{Type: EventPlayerJoined, RoomCode: "ROOM1"})
+
+	if got := <-events; got.Type != EventRoleConfigUpdated || got.Actor.Name != "first" {
+		t.Fatalf("expected the first change straight away, got %+v", got)
+	}
+	if got := <-events; got.Type != EventPlayerJoined {
+		t.Fatalf("expected other events to pass untouched, got %+v", got)
+	}
+	select {
+	case got := <-events:
+		t.Fatalf("expected the rest of the burst to be held, got %+v", got)
+	default:
+	}
+
+	select {
+	case got := <-events:
+		if got.Actor.Name != "third" {
+			t.Errorf("expected the held burst to deliver the latest change, got %+v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the held change when the window ended")
+	}
+	select {
+	case got := <-events:
+		t.Fatalf("expected one update for the burst, got another %+v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// An unknown event type is a spike once a stream sees this many within the window
+	unknownEventSpikeCount  = 10
+	unknownEventSpikeWindow = 5 * time.Minute
+)
+
+// knownEventTypes is AllEventTypes as a set, for streams whose default
+// branch handles every known type
+var knownEventTypes = newEventSet(AllEventTypes...)
+
+// UnknownEventCount is how often one SSE stream received one event type it
+// neither handles nor deliberately ignores, a sign the publishers and the
+// stream handlers have drifted apart
+type UnknownEventCount struct {
+	Stream   string    `json:"stream"`
+	Type     EventType `json:"type"`
+	Total    int64     `json:"total"`
+	Recent   int64     `json:"recent"` // within the current spike window
+	Spike    bool      `json:"spike"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type unknownEventKey struct {
+	stream    string
+	eventType EventType
+}
+
+type unknownEventCounter struct {
+	total       int64
+	windowStart time.Time
+	windowCount int64
+	lastSeen    time.Time
+}
+
+// unknownEventMetrics counts unknown events per stream and type for the
+// Prometheus endpoint and the admin telemetry; counts reset on restart
+type unknownEventMetrics struct {
+	mu     sync.Mutex
+	counts map[unknownEventKey]*unknownEventCounter
+}
+
+func newUnknownEventMetrics() *unknownEventMetrics {
+	return &unknownEventMetrics{counts: make(map[unknownEventKey]*unknownEventCounter)}
+}
+
+// record counts one eventType the stream did not expect, logging when the
+// type starts to spike
+func (m *unknownEventMetrics) record(stream string, eventType EventType, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := unknownEventKey{stream, eventType}
+	counter := m.counts[key]
+	if counter == nil {
+		counter = &unknownEventCounter{}
+		m.counts[key] = counter
+	}
+	if now.Sub(counter.windowStart) >= unknownEventSpikeWindow {
+		counter.windowStart = now
+		counter.windowCount = 0
+	}
+	counter.total++
+	counter.windowCount++
+	counter.lastSeen = now
+	if counter.windowCount == unknownEventSpikeCount {
+		log.Printf("⚠️ %d unknown %s events in %s SSE within %s", counter.windowCount, eventType, stream, unknownEventSpikeWindow)
+	}
+}
+
+// snapshot returns every counted stream and type, sorted by stream then type
+func (m *unknownEventMetrics) snapshot(now time.Time) []UnknownEventCount {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make([]UnknownEventCount, 0, len(m.counts))
+	for key, counter := range m.counts {
+		recent := int64(0)
+		if now.Sub(counter.windowStart) < unknownEventSpikeWindow {
+			recent = counter.windowCount
+		}
+		counts = append(counts, UnknownEventCount{
+			Stream:   key.stream,
+			Type:     key.eventType,
+			Total:    counter.total,
+			Recent:   recent,
+			Spike:    recent >= unknownEventSpikeCount,
+			LastSeen: counter.lastSeen,
+		})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Stream != counts[j].Stream {
+			return counts[i].Stream < counts[j].Stream
+		}
+		return counts[i].Type < counts[j].Type
+	})
+	return counts
+}
+
+// recordUnknownEvent counts an event the stream neither handles nor ignores
+func (h *Handler) recordUnknownEvent(stream string, eventType EventType) {
+	h.unknownEvents.record(stream, eventType, h.clock.Now())
+}
+
+// GetMetrics serves the server's counters in the Prometheus text format to
+// an admin; scrape it with the admin token as a bearer credential. A
+// tenant's admin token gets only the series tagged with the tenant.
+func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	scope, ok := h.requireAdminScope(w, r)
+	if !ok {
+		return
+	}
+
+	var b strings.Builder
+	if scope != nil {
+		h.writeTenantMetrics(&b, []*tenant{scope}, false)
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write([]byte(b.String()))
+		return
+	}
+
+	b.WriteString("# HELP treacherest_sse_unknown_events_total Events an SSE stream neither handled nor deliberately ignored.\n")
+	b.WriteString("# TYPE treacherest_sse_unknown_events_total counter\n")
+	for _, count := range h.unknownEvents.snapshot(h.clock.Now()) {
+		fmt.Fprintf(&b, "treacherest_sse_unknown_events_total{stream=\"%s\",type=\"%s\"} %d\n",
+			prometheusLabel(count.Stream), prometheusLabel(string(count.Type)), count.Total)
+	}
+
+	rejected := h.roomQuota.rejectedCounts()
+	b.WriteString("# HELP treacherest_room_creation_rejected_total Room creations refused by a quota.\n")
+	b.WriteString("# TYPE treacherest_room_creation_rejected_total counter\n")
+	for _, scope := range []string{roomQuotaPerIP, roomQuotaGlobal} {
+		fmt.Fprintf(&b, "treacherest_room_creation_rejected_total{scope=\"%s\"} %d\n", scope, rejected[scope])
+	}
+
+	flagged, verified := h.botChecks.counts()
+	b.WriteString("# HELP treacherest_suspected_bots_total Create and join posts sent to bot verification.\n")
+	b.WriteString("# TYPE treacherest_suspected_bots_total counter\n")
+	for _, reason := range botReasons {
+		fmt.Fprintf(&b, "treacherest_suspected_bots_total{reason=\"%s\"} %d\n", reason, flagged[reason])
+	}
+	b.WriteString("# HELP treacherest_bot_verifications_total Posts that passed after confirming on the verification page.\n")
+	b.WriteString("# TYPE treacherest_bot_verifications_total counter\n")
+	fmt.Fprintf(&b, "treacherest_bot_verifications_total %d\n", verified)
+
+	b.WriteString("# HELP treacherest_sse_stuck_writers_total SSE streams ended because a client stopped taking writes.\n")
+	b.WriteString("# TYPE treacherest_sse_stuck_writers_total counter\n")
+	fmt.Fprintf(&b, "treacherest_sse_stuck_writers_total %d\n", h.stuckWriters.Load())
+
+	b.WriteString("# HELP treacherest_sse_send_queue_depth SSE writes waiting for their clients, across every stream.\n")
+	b.WriteString("# TYPE treacherest_sse_send_queue_depth gauge\n")
+	fmt.Fprintf(&b, "treacherest_sse_send_queue_depth %d\n", h.sendQueues.depth.Load())
+	b.WriteString("# HELP treacherest_sse_send_queue_depth_max The most writes any one SSE stream has had waiting.\n")
+	b.WriteString("# TYPE treacherest_sse_send_queue_depth_max gauge\n")
+	fmt.Fprintf(&b, "treacherest_sse_send_queue_depth_max %d\n", h.sendQueues.depthMax.Load())
+	b.WriteString("# HELP treacherest_sse_send_queue_superseded_total Queued element patches dropped for a newer patch of the same target.\n")
+	b.WriteString("# TYPE treacherest_sse_send_queue_superseded_total counter\n")
+	fmt.Fprintf(&b, "treacherest_sse_send_queue_superseded_total %d\n", h.sendQueues.superseded.Load())
+	b.WriteString("# HELP treacherest_sse_send_queue_overflows_total SSE streams ended because their client fell too far behind.\n")
+	b.WriteString("# TYPE treacherest_sse_send_queue_overflows_total counter\n")
+	fmt.Fprintf(&b, "treacherest_sse_send_queue_overflows_total %d\n", h.sendQueues.overflows.Load())
+
+	tenants := make([]*tenant, 0, len(h.tenants))
+	for _, id := range h.tenantIDs() {
+		tenants = append(tenants, h.tenants[id])
+	}
+	h.writeTenantMetrics(&b, tenants, true)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(b.String()))
+}
+
+// writeTenantMetrics writes the series tagged with a tenant: live rooms per
+// tenant (the server's own under tenant="" when withOwn) and each tenant's
+// refused room creations
+func (h *Handler) writeTenantMetrics(b *strings.Builder, tenants []*tenant, withOwn bool) {
+	live := make(map[string]int)
+	for _, room := range h.store.Rooms() {
+		live[roomTenant(room)]++
+	}
+
+	b.WriteString("# HELP treacherest_tenant_rooms Live rooms by tenant.\n")
+	b.WriteString("# TYPE treacherest_tenant_rooms gauge\n")
+	if withOwn {
+		fmt.Fprintf(b, "treacherest_tenant_rooms{tenant=\"\"} %d\n", live[""])
+	}
+	for _, t := range tenants {
+		fmt.Fprintf(b, "treacherest_tenant_rooms{tenant=\"%s\"} %d\n", prometheusLabel(t.ID), live[t.ID])
+	}
+
+	b.WriteString("# HELP treacherest_tenant_room_creation_rejected_total A tenant's room creations refused by its quotas.\n")
+	b.WriteString("# TYPE treacherest_tenant_room_creation_rejected_total counter\n")
+	for _, t := range tenants {
+		rejected := t.roomQuota.rejectedCounts()
+		for _, scope := range []string{roomQuotaPerIP, roomQuotaGlobal} {
+			fmt.Fprintf(b, "treacherest_tenant_room_creation_rejected_total{tenant=\"%s\",scope=\"%s\"} %d\n", prometheusLabel(t.ID), scope, rejected[scope])
+		}
+	}
+}
+
+// prometheusLabel escapes value for use inside a quoted label value
+func prometheusLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnknownEventMetricsFlagSpikes(t *testing.T) {
+	metrics := newUnknownEventMetrics()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < unknownEventSpikeCount-1; i++ {
+		metrics.record("lobby", "player_renamed", start)
+	}
+	metrics.record("host", "player_renamed", start)
+
+	counts := metrics.snapshot(start)
+	if len(counts) != 2 || counts[0].Stream != "host" || counts[1].Stream != "lobby" {
+		t.Fatalf("expected host then lobby counts, got %+v", counts)
+	}
+	if counts[1].Spike {
+		t.Errorf("%d events should not be a spike yet", counts[1].Recent)
+	}
+
+	metrics.record("lobby", "player_renamed", start.Add(time.Minute))
+	lobby := metrics.snapshot(start.Add(time.Minute))[1]
+	if !lobby.Spike || lobby.Total != unknownEventSpikeCount {
+		t.Errorf("expected a spike at %d events, got %+v", unknownEventSpikeCount, lobby)
+	}
+
+	// Once the window passes, the total stays but the spike clears
+	lobby = metrics.snapshot(start.Add(unknownEventSpikeWindow + time.Second))[1]
+	if lobby.Spike || lobby.Recent != 0 || lobby.Total != unknownEventSpikeCount {
+		t.Errorf("expected the spike to clear after the window, got %+v", lobby)
+	}
+}
+
+func TestGetMetricsServesUnknownEventCounters(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	h.SetAdminToken("secret")
+	router := newTestRouter(h)
+
+	h.recordUnknownEvent("watch", `odd"type`)
+	h.recordUnknownEvent("watch", `odd"type`)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/metrics", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected metrics to need the admin token, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/admin/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", w.Header().Get("Content-Type"))
+	}
+	want := `treacherest_sse_unknown_events_total{stream="watch",type="odd\"type"} 2`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("expected %s in:\n%s", want, w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/admin/telemetry", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var body struct {
+		UnknownEvents []UnknownEventCount `json:"unknownEvents"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode telemetry: %v", err)
+	}
+	if len(body.UnknownEvents) != 1 || body.UnknownEvents[0].Recent != 2 {
+		t.Errorf("expected the unknown events on the admin telemetry, got %+v", body.UnknownEvents)
+	}
+}
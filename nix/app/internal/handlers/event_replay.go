@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+)
+
+const (
+	// eventHistoryLimit is how many recent events per room a reconnecting
+	// stream can catch up on; one further behind gets a full resync
+	eventHistoryLimit = 100
+	// eventHistoryTTL is how long a room's history outlives its last event
+	eventHistoryTTL = 10 * time.Minute
+)
+
+// eventHistory numbers the events delivered to each room and keeps the most
+// recent ones, so a browser that reconnects after a network blip with the
+// Last-Event-ID of the last event it handled is sent the ones it missed.
+// IDs carry the server's start time, so an ID from before a restart is never
+// mistaken for one issued since.
+type eventHistory struct {
+	epoch string
+
+	mu        sync.Mutex
+	rooms     map[string]*roomHistory
+	lastSwept time.Time
+}
+
+type roomHistory struct {
+	seq    uint64
+	events []Event
+	last   time.Time
+}
+
+func newEventHistory() *eventHistory {
+	return &eventHistory{
+		epoch:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		rooms:     make(map[string]*roomHistory),
+		lastSwept: time.Now(),
+	}
+}
+
+// stamp gives event the next ID for its room and keeps it for replay. The
+// kept copy drops Data: streams re-read the room from the store, and the
+// history should not hold on to deleted rooms.
+func (eh *eventHistory) stamp(event Event) Event {
+	now := time.Now()
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	if now.Sub(eh.lastSwept) > eventHistoryTTL {
+		for code, rh := range eh.rooms {
+			if now.Sub(rh.last) > eventHistoryTTL {
+				delete(eh.rooms, code)
+			}
+		}
+		eh.lastSwept = now
+	}
+
+	rh := eh.rooms[event.RoomCode]
+	if rh == nil {
+		rh = &roomHistory{}
+		eh.rooms[event.RoomCode] = rh
+	}
+	rh.seq++
+	rh.last = now
+	event.ID = eh.epoch + "-" + strconv.FormatUint(rh.seq, 10)
+
+	kept := event
+	kept.Data = nil
+	rh.events = append(rh.events, kept)
+	if len(rh.events) > eventHistoryLimit {
+		rh.events = append([]Event(nil), rh.events[len(rh.events)-eventHistoryLimit:]...)
+	}
+	return event
+}
+
+// since returns the events of room after lastID. ok is false when lastID is
+// not one the history can bridge from: issued before a restart, or fallen
+// out of the last eventHistoryLimit events.
+func (eh *eventHistory) since(roomCode, lastID string) (missed []Event, ok bool) {
+	if lastID == "" {
+		return nil, true
+	}
+	epoch, n, found := strings.Cut(lastID, "-")
+	seq, err := strconv.ParseUint(n, 10, 64)
+	if !found || err != nil || epoch != eh.epoch {
+		return nil, false
+	}
+
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	rh := eh.rooms[roomCode]
+	if rh == nil {
+		return nil, false
+	}
+	if seq >= rh.seq {
+		// Nothing newer, or an ID this room never issued
+		return nil, seq == rh.seq
+	}
+	first := rh.seq - uint64(len(rh.events)) + 1
+	if seq+1 < first {
+		return nil, false
+	}
+	return append([]Event(nil), rh.events[seq+1-first:]...), true
+}
+
+// Since returns the events published to room after the one with ID lastID,
+// see eventHistory.since
+func (eb *EventBus) Since(roomCode, lastID string) (missed []Event, ok bool) {
+	return eb.history.since(roomCode, lastID)
+}
+
+// replayFeed puts the events a reconnecting stream missed, going by its
+// Last-Event-ID, ahead of the live events from its subscription. resync
+// reports that the gap could not be bridged, so the stream should send the
+// full state instead.
+func (h *Handler) replayFeed(ctx context.Context, r *http.Request, roomCode string, events chan Event) (feed chan Event, resync bool) {
+	missed, ok := h.eventBus.Since(roomCode, r.Header.Get("Last-Event-ID"))
+	if !ok {
+		h.requestLogger(r).Info("Last-Event-ID is too old to replay, sending a full resync", "last_event_id", r.Header.Get("Last-Event-ID"))
+		return events, true
+	}
+	if len(missed) == 0 {
+		return events, false
+	}
+	h.requestLogger(r).Info("replaying missed events", "count", len(missed))
+
+	replayed := make(map[string]bool, len(missed))
+	for _, event := range missed {
+		replayed[event.ID] = true
+	}
+	feed = make(chan Event, cap(events))
+	go func() {
+		for _, event := range missed {
+			select {
+			case feed <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		for {
+			select {
+			case event, open := <-events:
+				if !open {
+					close(feed)
+					return
+				}
+				// Published between the subscription and the replay
+				if replayed[event.ID] {
+					continue
+				}
+				select {
+				case feed <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return feed, false
+}
+
+// markEvent tells the browser event has been handled, so a reconnect's
+// Last-Event-ID picks up after it. It rides on an empty signal patch, which
+// the client applies as a no-op.
+func markEvent(sse *datastar.ServerSentEventGenerator, event Event) error {
+	if event.ID == "" {
+		return nil
+	}
+	return sse.PatchSignals([]byte("{}"), datastar.WithPatchSignalsEventID(event.ID))
+}
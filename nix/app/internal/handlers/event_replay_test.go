@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+)
+
+func TestEventBusReplaysEventsSinceLastID(t *testing.T) {
+	bus := NewEventBus()
+	events := bus.Subscribe("ROOM1")
+	defer bus.Unsubscribe("ROOM1", events)
+
+	bus.Publish(Event{Type: EventPlayerJoined, RoomCode: "ROOM1"})
+	bus.Publish(Event{Type: EventChatPosted, RoomCode: "ROOM1", Data: "kept out of history"})
+	bus.Publish(Event{Type: EventPlayerLeft, RoomCode: "ROOM1"})
+	first := <-events
+	if first.ID == "" {
+		t.Fatal("expected delivered events to carry an ID")
+	}
+
+	missed, ok := bus.Since("ROOM1", first.ID)
+	if !ok || len(missed) != 2 || missed[0].Type != EventChatPosted || missed[1].Type != EventPlayerLeft {
+		t.Fatalf("expected the two later events, got %+v ok=%v", missed, ok)
+	}
+	if missed[0].Data != nil {
+		t.Error("expected the history not to hold on to event data")
+	}
+	if missed, ok := bus.Since("ROOM1", missed[1].ID); !ok || len(missed) != 0 {
+		t.Errorf("expected nothing missed after the latest event, got %+v ok=%v", missed, ok)
+	}
+	if _, ok := bus.Since("ROOM1", "0-1"); ok {
+		t.Error("expected an ID from before a restart to need a resync")
+	}
+
+	for i := 0; i < eventHistoryLimit; i++ {
+		bus.Publish(Event{Type: EventPlayerJoined, RoomCode: "ROOM1"})
+	}
+	if _, ok := bus.Since("ROOM1", first.ID); ok {
+		t.Error("expected an ID past the history limit to need a resync")
+	}
+}
+
+func TestLobbyStreamReplaysMissedEventsOnReconnect(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	stream := player.OpenSSE("/sse/room/" + roomCode)
+	time.Sleep(100 * time.Millisecond)
+	testkit.JoinRoom(t, router, roomCode, "Carol")
+	if !stream.WaitFor("Carol", 2*time.Second) {
+		t.Fatalf("expected the join on the stream, got %s", stream.Data())
+	}
+	lastID := stream.LastEventID()
+	if lastID == "" {
+		t.Fatalf("expected handled events to be marked with an ID, got %s", stream.Data())
+	}
+	stream.Close()
+
+	// Dave joins during the blip
+	testkit.JoinRoom(t, router, roomCode, "Dave")
+
+	resumed := player.ResumeSSE("/sse/room/"+roomCode, lastID)
+	defer resumed.Close()
+	if !resumed.WaitFor("Dave", 2*time.Second) {
+		t.Fatalf("expected the missed join replayed on reconnect, got %s", resumed.Data())
+	}
+}
+
+func TestLobbyStreamResyncsWhenReplayCannotBridgeTheGap(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	stream := player.ResumeSSE("/sse/room/"+roomCode, "0-1")
+	defer stream.Close()
+	if !stream.WaitFor("lobby-content", 2*time.Second) {
+		t.Fatalf("expected a full lobby resync, got %s", stream.Data())
+	}
+	if !strings.Contains(stream.Data(), "Bob") {
+		t.Errorf("expected the resync to carry the lobby, got %s", stream.Data())
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+// EventType names an event published on the EventBus
+type EventType string
+
+// Room and lobby events
+const (
+	EventPlayerJoined                EventType = "player_joined"
+	EventPlayerLeft                  EventType = "player_left"
+	EventPlayerRemoved               EventType = "player_removed" // the Room Operator kicked or banned a player
+	EventRoleConfigUpdated           EventType = "role_config_updated"
+	EventRoleOptionsChanged          EventType = "role_options_changed"
+	EventCoupConfigUpdated           EventType = "coup_config_updated"
+	EventPhaseSettingsUpdated        EventType = "phase_settings_updated"
+	EventConfigMigrated              EventType = "config_migrated"
+	EventPollUpdated                 EventType = "poll_updated"
+	EventChatPosted                  EventType = "chat_posted"
+	EventStartRitualUpdated          EventType = "start_ritual_updated"
+	EventScreenshotDeterrenceUpdated EventType = "screenshot_deterrence_updated"
+	EventWatchLinkRevoked            EventType = "watch_link_revoked"
+	EventMaintenanceUpdated          EventType = "maintenance_updated"
+	EventOperatorChanged             EventType = "operator_changed"
+)
+
+// Game lifecycle events
+const (
+	EventDealPending     EventType = "deal_pending"
+	EventGameStarted     EventType = "game_started"
+	EventStartCancelled  EventType = "start_cancelled"
+	EventCountdownUpdate EventType = "countdown_update"
+	EventStartConfirmed  EventType = "start_confirmed"
+	EventGamePlaying     EventType = "game_playing"
+	EventGameEnded       EventType = "game_ended"
+	EventGameRestarted   EventType = "game_restarted"
+)
+
+// In-game events
+const (
+	EventRoleRevealed            EventType = "role_revealed"
+	EventFaceStateChanged        EventType = "face_state_changed"
+	EventModalDismissed          EventType = "modal_dismissed"
+	EventModalRestored           EventType = "modal_restored"
+	EventPlayerEliminated        EventType = "player_eliminated"
+	EventPhaseChanged            EventType = "phase_changed"
+	EventVoteOpened              EventType = "vote_opened"
+	EventVoteCast                EventType = "vote_cast"
+	EventVoteClosed              EventType = "vote_closed"
+	EventCoupInquisitionCalled   EventType = "coup_inquisition_called"
+	EventCoupInquisitionResolved EventType = "coup_inquisition_resolved"
+	EventCoupWinPromptRejected   EventType = "coup_win_prompt_rejected"
+)
+
+// Ability events
+const (
+	EventAbilityTriggered           EventType = "ability_triggered"
+	EventAbilityUpdated             EventType = "ability_updated"
+	EventAbilityConfirmed           EventType = "ability_confirmed"
+	EventAbilityResolved            EventType = "ability_resolved"
+	EventMetamorphActivated         EventType = "metamorph_activated"
+	EventMetamorphEnded             EventType = "metamorph_ended"
+	EventRoleStolen                 EventType = "role_stolen"
+	EventPuppetMasterRedistribution EventType = "puppet_master_redistribution"
+	EventTransformationComplete     EventType = "transformation_complete"
+)
+
+// AllEventTypes lists every event type the handlers publish. Each stream
+// handler either handles or deliberately ignores every entry.
+var AllEventTypes = []EventType{
+	EventPlayerJoined,
+	EventPlayerLeft,
+	EventPlayerRemoved,
+	EventRoleConfigUpdated,
+	EventRoleOptionsChanged,
+	EventCoupConfigUpdated,
+	EventPhaseSettingsUpdated,
+	EventConfigMigrated,
+	EventPollUpdated,
+	EventChatPosted,
+	EventStartRitualUpdated,
+	EventScreenshotDeterrenceUpdated,
+	EventWatchLinkRevoked,
+	EventMaintenanceUpdated,
+	EventOperatorChanged,
+
+	EventDealPending,
+	EventGameStarted,
+	EventStartCancelled,
+	EventCountdownUpdate,
+	EventStartConfirmed,
+	EventGamePlaying,
+	EventGameEnded,
+	EventGameRestarted,
+
+	EventRoleRevealed,
+	EventFaceStateChanged,
+	EventModalDismissed,
+	EventModalRestored,
+	EventPlayerEliminated,
+	EventPhaseChanged,
+	EventVoteOpened,
+	EventVoteCast,
+	EventVoteClosed,
+	EventCoupInquisitionCalled,
+	EventCoupInquisitionResolved,
+	EventCoupWinPromptRejected,
+
+	EventAbilityTriggered,
+	EventAbilityUpdated,
+	EventAbilityConfirmed,
+	EventAbilityResolved,
+	EventMetamorphActivated,
+	EventMetamorphEnded,
+	EventRoleStolen,
+	EventPuppetMasterRedistribution,
+	EventTransformationComplete,
+}
+
+// eventSet is a set of event types
+type eventSet map[EventType]bool
+
+func newEventSet(types ...EventType) eventSet {
+	set := make(eventSet, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
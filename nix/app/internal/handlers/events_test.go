@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseHandlerSources parses the package's non-test sources, keyed by file name
+func parseHandlerSources(t *testing.T) map[string]*ast.File {
+	t.Helper()
+	names, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	files := make(map[string]*ast.File)
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+		files[name] = file
+	}
+	return files
+}
+
+// eventConstants maps each EventType constant name in events.go to its value
+func eventConstants(t *testing.T, files map[string]*ast.File) map[string]EventType {
+	t.Helper()
+	consts := make(map[string]EventType)
+	for _, decl := range files["events.go"].Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if ident, ok := value.Type.(*ast.Ident); !ok || ident.Name != "EventType" {
+				continue
+			}
+			for i, name := range value.Names {
+				lit := value.Values[i].(*ast.BasicLit)
+				unquoted, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				consts[name.Name] = EventType(unquoted)
+			}
+		}
+	}
+	return consts
+}
+
+// isEventTypeSelector reports whether expr is event.Type
+func isEventTypeSelector(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Type" {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == "event"
+}
+
+// streamEventSwitch returns the event types the `switch event.Type` in the
+// function named stream handles, and whether it has a default branch
+func streamEventSwitch(t *testing.T, files map[string]*ast.File, consts map[string]EventType, stream string) (eventSet, bool) {
+	t.Helper()
+	var fn *ast.FuncDecl
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if f, ok := decl.(*ast.FuncDecl); ok && f.Name.Name == stream {
+				fn = f
+			}
+		}
+	}
+	require.NotNil(t, fn, "stream handler %s not found", stream)
+
+	var sw *ast.SwitchStmt
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if s, ok := n.(*ast.SwitchStmt); ok && sw == nil && isEventTypeSelector(s.Tag) {
+			sw = s
+		}
+		return sw == nil
+	})
+	require.NotNil(t, sw, "%s has no switch on event.Type", stream)
+
+	handled := make(eventSet)
+	hasDefault := false
+	for _, stmt := range sw.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			hasDefault = true
+		}
+		for _, expr := range clause.List {
+			ident, ok := expr.(*ast.Ident)
+			if !assert.True(t, ok, "%s: case %s must use an EventType constant", stream, exprString(expr)) {
+				continue
+			}
+			eventType, known := consts[ident.Name]
+			if assert.True(t, known, "%s: case %s is not an EventType constant", stream, ident.Name) {
+				handled[eventType] = true
+			}
+		}
+	}
+	return handled, hasDefault
+}
+
+// exprString renders expr for failure messages
+func exprString(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return lit.Value
+	}
+	return "expression"
+}
+
+// assertEventsExhaustive checks that stream handles or deliberately ignores
+// every published event type, and never both
+func assertEventsExhaustive(t *testing.T, stream string, handled, ignored eventSet) {
+	t.Helper()
+	for _, eventType := range AllEventTypes {
+		switch {
+		case handled[eventType] && ignored[eventType]:
+			t.Errorf("%s handles %s but also lists it as ignored", stream, eventType)
+		case !handled[eventType] && !ignored[eventType]:
+			t.Errorf("%s neither handles nor ignores %s", stream, eventType)
+		}
+	}
+}
+
+func TestAllEventTypesListsEveryConstant(t *testing.T) {
+	consts := eventConstants(t, parseHandlerSources(t))
+
+	listed := newEventSet(AllEventTypes...)
+	assert.Len(t, listed, len(AllEventTypes), "AllEventTypes has duplicates")
+	for name, eventType := range consts {
+		assert.True(t, listed[eventType], "AllEventTypes is missing %s", name)
+	}
+	assert.Len(t, AllEventTypes, len(consts))
+}
+
+func TestEventsPublishedWithConstants(t *testing.T) {
+	files := parseHandlerSources(t)
+
+	for name, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CompositeLit:
+				if ident, ok := node.Type.(*ast.Ident); !ok || ident.Name != "Event" {
+					return true
+				}
+				for _, elt := range node.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Type" {
+						_, literal := kv.Value.(*ast.BasicLit)
+						assert.False(t, literal, "%s: Event published with literal type %s", name, exprString(kv.Value))
+					}
+				}
+			case *ast.BinaryExpr:
+				if isEventTypeSelector(node.X) || isEventTypeSelector(node.Y) {
+					t.Errorf("%s: compare event.Type in a switch so exhaustiveness is checked", name)
+				}
+			}
+			return true
+		})
+	}
+}
+
+func TestStreamsHandleEveryEventType(t *testing.T) {
+	files := parseHandlerSources(t)
+	consts := eventConstants(t, files)
+
+	tests := []struct {
+		stream  string
+		ignored eventSet
+		// rendersAll streams re-render the whole view in their default branch,
+		// so every event type reaches them
+		rendersAll bool
+	}{
+		{stream: "streamLobby", ignored: lobbyIgnoredEvents},
+		{stream: "StreamHost", ignored: hostIgnoredEvents},
+		{stream: "StreamLobbyEnhanced", ignored: enhancedLobbyIgnoredEvents},
+		{stream: "streamGame", rendersAll: true},
+		{stream: "StreamOverlay", rendersAll: true},
+		{stream: "streamSpectator", rendersAll: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.stream, func(t *testing.T) {
+			handled, hasDefault := streamEventSwitch(t, files, consts, tt.stream)
+			if tt.rendersAll {
+				assert.True(t, hasDefault, "%s must re-render in its default branch", tt.stream)
+				return
+			}
+			assertEventsExhaustive(t, tt.stream, handled, tt.ignored)
+		})
+	}
+}
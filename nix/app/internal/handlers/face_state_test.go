@@ -21,9 +21,9 @@ func TestToggleFaceState(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room
 	room, _ := memStore.CreateRoom()
@@ -173,9 +173,9 @@ func TestToggleFaceStateWithTransformation(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room with card pool
 	room, _ := memStore.CreateRoom()
@@ -292,9 +292,9 @@ func TestToggleFaceStateEventPublishing(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room and player
 	room, _ := memStore.CreateRoom()
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"treacherest/internal/views/components"
+	"unicode/utf8"
+)
+
+// Run one target at a time, e.g. go test ./internal/handlers -run '^$' -fuzz FuzzParseCardID
+
+func FuzzParseCardID(f *testing.F) {
+	for _, seed := range []string{
+		"card-Leader-the-augur",
+		"card-Traitor-the-Ætherist",
+		"card--x",
+		"card-Leader-",
+		"card-",
+		"Leader-the-augur",
+		"card-Leader-the augur",
+		"card-Leader-\x00",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, cardID string) {
+		roleType, cardAnchor, ok := parseCardID(cardID)
+		if !ok {
+			return
+		}
+		if roleType == "" || cardAnchor == "" || strings.Contains(roleType, "-") {
+			t.Fatalf("parseCardID(%q) = %q, %q", cardID, roleType, cardAnchor)
+		}
+		if "card-"+roleType+"-"+cardAnchor != cardID {
+			t.Fatalf("parseCardID(%q) = %q, %q does not round-trip", cardID, roleType, cardAnchor)
+		}
+		if !utf8.ValidString(cardAnchor) || strings.ContainsAny(cardAnchor, " \t\r\n") {
+			t.Fatalf("parseCardID(%q) accepted anchor %q", cardID, cardAnchor)
+		}
+	})
+}
+
+func FuzzToggleRoleCard(f *testing.F) {
+	f.Add("card-Traitor-the-test-traitor", true)
+	f.Add("card-Traitor-the-test-traitor", false)
+	f.Add("card-Traitor-<script>", true)
+	f.Add("card-__proto__-x", true)
+	f.Add("card-Leader-"+strings.Repeat("a", 200), true)
+
+	h := newTestHandler()
+	h.cardService.Traitors[0].NameAnchor = "the-test-traitor"
+	cardNames := make(map[string]bool)
+	for _, roleType := range []string{"Leader", "Guardian", "Assassin", "Traitor"} {
+		for _, card := range h.getCardsForRoleType(roleType) {
+			cardNames[card.Name] = true
+		}
+	}
+	operator, room := newConcurrencyRoom(f, h)
+
+	f.Fuzz(func(t *testing.T, cardID string, checked bool) {
+		body, _ := json.Marshal(map[string]interface{}{"cardId": cardID, "cardChecked": checked})
+		w := operator.do(context.Background(), "POST", "/room/"+room.Code+"/config/card-toggle", string(body))
+		if w.Code != http.StatusOK && w.Code != http.StatusBadRequest {
+			t.Fatalf("card %q: got %d: %s", cardID, w.Code, w.Body.String())
+		}
+
+		room.RLock()
+		defer room.RUnlock()
+		for roleType, typeConfig := range room.RoleConfig.RoleTypes {
+			for name := range typeConfig.EnabledCards {
+				if !cardNames[name] {
+					t.Fatalf("card %q enabled unknown %s card %q", cardID, roleType, name)
+				}
+			}
+		}
+	})
+}
+
+func FuzzJoinRoomPost(f *testing.F) {
+	f.Add("", "Alice")
+	f.Add(" ", " ")
+	f.Add("ABCDE", "Bob")
+	f.Add("abcde", "<script>alert(1)</script>")
+	f.Add("AB\r\nCD", "Carol")
+	f.Add(strings.Repeat("A", 100), strings.Repeat("d", 100))
+	f.Add("ABCDE", "   Dave   ")
+
+	h := newTestHandler()
+
+	f.Fuzz(func(t *testing.T, roomCode, playerName string) {
+		room, err := h.store.CreateRoom()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// ABCDE stands in for the room just created; other codes are arbitrary
+		if roomCode == "ABCDE" {
+			roomCode = room.Code
+		}
+
+		c := &roomClient{router: newTestRouter(h)}
+		w := c.do(context.Background(), "POST", "/join-room",
+			url.Values{"room_code": {roomCode}, "player_name": {playerName}, components.BotElapsedField: {"5000"}}.Encode())
+
+		switch w.Code {
+		case http.StatusSeeOther, http.StatusBadRequest, http.StatusNotFound:
+		default:
+			t.Fatalf("join %q as %q: got %d: %s", roomCode, playerName, w.Code, w.Body.String())
+		}
+		if w.Code != http.StatusSeeOther {
+			return
+		}
+
+		for _, p := range room.GetPlayers() {
+			name, err := normalizePlayerName(p.Name)
+			if err != nil || name != p.Name {
+				t.Fatalf("join as %q stored name %q", playerName, p.Name)
+			}
+		}
+	})
+}
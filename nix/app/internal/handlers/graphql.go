@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/graphql"
+)
+
+// maxGraphQLRequest bounds a GraphQL request body
+const maxGraphQLRequest = 64 << 10
+
+// graphQLViewer is who a GraphQL request reads as, decided from its bearer
+// token: the server admin, a tenant's admin, or nobody
+type graphQLViewer struct {
+	admin  bool
+	tenant *tenant // set for a tenant's admin, who reads only its rooms
+}
+
+type graphQLViewerKey struct{}
+
+func viewerFrom(ctx context.Context) graphQLViewer {
+	v, _ := ctx.Value(graphQLViewerKey{}).(graphQLViewer)
+	return v
+}
+
+var (
+	errAdminRequired  = errors.New("rooms need an admin token")
+	errRoleHidden     = errors.New("hidden roles need the server admin token")
+	errHistoryMissing = errors.New("the room's history could not be read")
+)
+
+// graphQLRoom is a room as read under its lock, so resolvers never touch the
+// live room
+type graphQLRoom struct {
+	Code      string
+	Tenant    string
+	State     game.GameState
+	RulesMode game.RulesMode
+	CreatedAt time.Time
+	StartedAt time.Time
+	Players   []graphQLPlayer
+}
+
+type graphQLPlayer struct {
+	ID           string
+	Name         string
+	IsHost       bool
+	IsEliminated bool
+	RoleRevealed bool
+	Role         *game.Card
+	rolePublic   bool // revealed, or the game is over
+}
+
+type graphQLEvent struct {
+	game.RoomEvent
+	PlayerName string
+}
+
+func snapshotGraphQLRoom(room *game.Room) graphQLRoom {
+	room.RLock()
+	defer room.RUnlock()
+
+	snapshot := graphQLRoom{
+		Code:      room.Code,
+		Tenant:    room.Tenant,
+		State:     room.State,
+		RulesMode: room.RulesMode,
+		CreatedAt: room.CreatedAt,
+		StartedAt: room.StartedAt,
+	}
+	players := room.GetPlayers()
+	sort.Slice(players, func(i, j int) bool { return players[i].JoinedAt.Before(players[j].JoinedAt) })
+	for _, p := range players {
+		snapshot.Players = append(snapshot.Players, graphQLPlayer{
+			ID:           p.ID,
+			Name:         p.Name,
+			IsHost:       p.IsHost,
+			IsEliminated: p.IsEliminated,
+			RoleRevealed: p.RoleRevealed,
+			Role:         p.Role,
+			rolePublic:   p.RoleRevealed || room.State == game.StateEnded,
+		})
+	}
+	return snapshot
+}
+
+// visibleRooms returns the rooms the viewer may read, oldest first
+func (h *Handler) visibleRooms(v graphQLViewer) []graphQLRoom {
+	var rooms []graphQLRoom
+	for _, room := range h.store.Rooms() {
+		snapshot := snapshotGraphQLRoom(room)
+		if v.tenant == nil || snapshot.Tenant == v.tenant.ID {
+			rooms = append(rooms, snapshot)
+		}
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].CreatedAt.Before(rooms[j].CreatedAt) })
+	return rooms
+}
+
+func requireGraphQLAdmin(ctx context.Context, _ any) error {
+	if !viewerFrom(ctx).admin {
+		return errAdminRequired
+	}
+	return nil
+}
+
+// scalar resolves a field from its parent with get
+func scalar[T any](get func(T) any) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source any, _ graphql.Args) (any, error) {
+		return get(source.(T)), nil
+	}}
+}
+
+func formatGraphQLTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// graphQLSchema builds the read API's schema over the store and card service
+func (h *Handler) graphQLSchema() *graphql.Schema {
+	card := &graphql.Object{Name: "Card", Fields: map[string]*graphql.Field{
+		"id":       scalar(func(c *game.Card) any { return c.ID }),
+		"name":     scalar(func(c *game.Card) any { return c.Name }),
+		"roleType": scalar(func(c *game.Card) any { return c.Types.Subtype }),
+		"text":     scalar(func(c *game.Card) any { return c.Text }),
+	}}
+
+	player := &graphql.Object{Name: "Player", Fields: map[string]*graphql.Field{
+		"id":           scalar(func(p graphQLPlayer) any { return p.ID }),
+		"name":         scalar(func(p graphQLPlayer) any { return p.Name }),
+		"isHost":       scalar(func(p graphQLPlayer) any { return p.IsHost }),
+		"eliminated":   scalar(func(p graphQLPlayer) any { return p.IsEliminated }),
+		"roleRevealed": scalar(func(p graphQLPlayer) any { return p.RoleRevealed }),
+		"role": {
+			Type: card,
+			// Roles still secret at the table are the server admin's alone
+			Guard: func(ctx context.Context, source any) error {
+				if v := viewerFrom(ctx); source.(graphQLPlayer).rolePublic || v.admin && v.tenant == nil {
+					return nil
+				}
+				return errRoleHidden
+			},
+			Resolve: func(_ context.Context, source any, _ graphql.Args) (any, error) {
+				return source.(graphQLPlayer).Role, nil
+			},
+		},
+	}}
+
+	event := &graphql.Object{Name: "RoomEvent", Fields: map[string]*graphql.Field{
+		"seq":        scalar(func(e graphQLEvent) any { return e.Seq }),
+		"at":         scalar(func(e graphQLEvent) any { return formatGraphQLTime(e.At) }),
+		"kind":       scalar(func(e graphQLEvent) any { return string(e.Kind) }),
+		"playerId":   scalar(func(e graphQLEvent) any { return e.PlayerID }),
+		"playerName": scalar(func(e graphQLEvent) any { return e.PlayerName }),
+	}}
+
+	room := &graphql.Object{Name: "Room", Fields: map[string]*graphql.Field{
+		"code":      scalar(func(r graphQLRoom) any { return r.Code }),
+		"tenant":    scalar(func(r graphQLRoom) any { return r.Tenant }),
+		"state":     scalar(func(r graphQLRoom) any { return string(r.State) }),
+		"rulesMode": scalar(func(r graphQLRoom) any { return string(r.RulesMode) }),
+		"createdAt": scalar(func(r graphQLRoom) any { return formatGraphQLTime(r.CreatedAt) }),
+		"startedAt": scalar(func(r graphQLRoom) any { return formatGraphQLTime(r.StartedAt) }),
+		"players": {Type: player, Resolve: func(_ context.Context, source any, _ graphql.Args) (any, error) {
+			return source.(graphQLRoom).Players, nil
+		}},
+		// history(since: 0) reads the room's event journal after seq since
+		"history": {Type: event, Resolve: func(_ context.Context, source any, args graphql.Args) (any, error) {
+			return h.graphQLHistory(source.(graphQLRoom), args.Int("since", 0))
+		}},
+	}}
+
+	return &graphql.Schema{Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"rooms": {Type: room, Guard: requireGraphQLAdmin, Resolve: func(ctx context.Context, _ any, _ graphql.Args) (any, error) {
+			return h.visibleRooms(viewerFrom(ctx)), nil
+		}},
+		"room": {Type: room, Guard: requireGraphQLAdmin, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			found, err := h.store.GetRoom(strings.ToUpper(args.String("code")))
+			if err != nil {
+				return nil, nil
+			}
+			snapshot := snapshotGraphQLRoom(found)
+			if v := viewerFrom(ctx); v.tenant != nil && snapshot.Tenant != v.tenant.ID {
+				return nil, nil
+			}
+			return snapshot, nil
+		}},
+		// cards(roleType: "Leader") lists the dealable role cards; they're public
+		"cards": {Type: card, Resolve: func(_ context.Context, _ any, args graphql.Args) (any, error) {
+			return h.graphQLCards(args.String("roleType")), nil
+		}},
+	}}}
+}
+
+func (h *Handler) graphQLHistory(room graphQLRoom, since int) ([]graphQLEvent, error) {
+	events, err := h.store.Events(room.Code, since)
+	if err != nil {
+		return nil, errHistoryMissing
+	}
+	names := make(map[string]string, len(room.Players))
+	for _, p := range room.Players {
+		names[p.ID] = p.Name
+	}
+
+	history := make([]graphQLEvent, 0, len(events))
+	for _, e := range events {
+		// Only who and when leave the journal: deals and configs stay behind
+		entry := graphQLEvent{RoomEvent: game.RoomEvent{Seq: e.Seq, At: e.At, Kind: e.Kind, PlayerID: e.PlayerID}}
+		if e.Player != nil {
+			entry.PlayerID = e.Player.ID
+			entry.PlayerName = e.Player.Name
+		} else {
+			entry.PlayerName = names[e.PlayerID]
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+func (h *Handler) graphQLCards(roleType string) []*game.Card {
+	if h.cardService == nil {
+		return nil
+	}
+	var cards []*game.Card
+	for _, dealt := range h.cardService.RoleTypes() {
+		for _, c := range h.cardService.CardsOfType(dealt) {
+			if roleType == "" || strings.EqualFold(c.Types.Subtype, roleType) {
+				cards = append(cards, c)
+			}
+		}
+	}
+	return cards
+}
+
+// GraphQL answers read-only GraphQL queries for dashboards (see
+// graphQLSchema). Cards are open to anyone; rooms need an admin bearer token,
+// and a tenant's token reads only that tenant's rooms.
+func (h *Handler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLRequest)).Decode(&req); err != nil || req.Query == "" {
+		http.Error(w, "Expected a JSON body with a query", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphQLViewerKey{}, h.graphQLViewer(r))
+	writeAdminJSON(w, h.graphQLSchema().Execute(ctx, req))
+}
+
+func (h *Handler) graphQLViewer(r *http.Request) graphQLViewer {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return graphQLViewer{}
+	}
+	if h.isAdminToken(token) {
+		return graphQLViewer{admin: true}
+	}
+	if t := h.tenantForAdminToken(token); t != nil {
+		return graphQLViewer{admin: true, tenant: t}
+	}
+	return graphQLViewer{}
+}
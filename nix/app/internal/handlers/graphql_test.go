@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+// postGraphQL posts query to the router's GraphQL endpoint, bearing token
+// when it's set, and returns the decoded response
+func postGraphQL(t *testing.T, router http.Handler, token, query string) (int, map[string]any) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest("POST", "/api/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp map[string]any
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode %s: %v", w.Body.String(), err)
+		}
+	}
+	return w.Code, resp
+}
+
+func TestGraphQLIsBehindItsFeatureFlag(t *testing.T) {
+	h := newTestHandler()
+	if code, _ := postGraphQL(t, newTestRouter(h), "", `{ cards { name } }`); code != http.StatusNotFound && code != http.StatusMethodNotAllowed {
+		t.Errorf("expected no GraphQL endpoint without the flag, got %d", code)
+	}
+}
+
+func TestGraphQLReadsRoomsPerFieldAuth(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.GraphQLEnabled = true
+	h.SetAdminToken("server-secret")
+	router := newTestRouter(h)
+
+	creator := testkit.CreateRoom(t, router, "Alice", false)
+	testkit.JoinRoom(t, router, creator.RoomCode, "Bob")
+	room, _ := h.store.GetRoom(creator.RoomCode)
+	for _, p := range room.GetPlayers() {
+		p.Role = h.cardService.Traitors[0]
+	}
+
+	query := `{ room(code: "` + creator.RoomCode + `") { code state players { name role { roleType } } history { kind playerName } } }`
+
+	// The server admin reads everything, hidden roles included
+	_, resp := postGraphQL(t, router, "server-secret", query)
+	if resp["errors"] != nil {
+		t.Fatalf("expected no errors for the server admin, got %v", resp["errors"])
+	}
+	got := resp["data"].(map[string]any)["room"].(map[string]any)
+	if got["code"] != creator.RoomCode || got["state"] != string(game.StateLobby) {
+		t.Errorf("expected the room, got %v", got)
+	}
+	players := got["players"].([]any)
+	if len(players) != 2 || players[0].(map[string]any)["name"] != "Alice" {
+		t.Fatalf("expected both players in join order, got %v", players)
+	}
+	if role := players[1].(map[string]any)["role"]; role == nil || role.(map[string]any)["roleType"] != "Traitor" {
+		t.Errorf("expected the server admin to see hidden roles, got %v", role)
+	}
+	if history := got["history"].([]any); len(history) == 0 {
+		t.Errorf("expected the room's journal in its history")
+	}
+
+	// Nobody without a token reads rooms, but cards are public
+	_, resp = postGraphQL(t, router, "", `{ room(code: "`+creator.RoomCode+`") { code } cards(roleType: "leader") { name } }`)
+	data := resp["data"].(map[string]any)
+	if data["room"] != nil || !strings.Contains(resp["errors"].([]any)[0].(map[string]any)["message"].(string), "admin token") {
+		t.Errorf("expected rooms refused without a token, got %v", resp)
+	}
+	if cards := data["cards"].([]any); len(cards) != len(h.cardService.Leaders) {
+		t.Errorf("expected the leader cards, got %v", cards)
+	}
+}
+
+func TestGraphQLTenantAdminSeesOnlyItsRoomsAndRevealedRoles(t *testing.T) {
+	h := newTenantTestHandler()
+	h.config().Server.GraphQLEnabled = true
+	h.SetTenantAdminToken("spikes", "spikes-secret")
+	router := newTestRouter(h)
+
+	spikes := testkit.CreateRoom(t, onHost(router, "spikes.example.com"), "Alice", false)
+	testkit.CreateRoom(t, router, "Bob", false)
+	room, _ := h.store.GetRoom(spikes.RoomCode)
+	for _, p := range room.GetPlayers() {
+		p.Role = h.cardService.Leaders[0]
+	}
+
+	_, resp := postGraphQL(t, router, "spikes-secret", `{ rooms { code tenant players { role { name } } } }`)
+	rooms := resp["data"].(map[string]any)["rooms"].([]any)
+	if len(rooms) != 1 || rooms[0].(map[string]any)["tenant"] != "spikes" {
+		t.Fatalf("expected only the tenant's room, got %v", rooms)
+	}
+	errs, _ := resp["errors"].([]any)
+	if len(errs) != 1 || !strings.Contains(errs[0].(map[string]any)["message"].(string), "hidden roles") {
+		t.Errorf("expected the unrevealed role refused to the tenant's admin, got %v", resp["errors"])
+	}
+
+	room.GetPlayers()[0].RoleRevealed = true
+	if _, resp := postGraphQL(t, router, "spikes-secret", `{ rooms { players { role { name } } } }`); resp["errors"] != nil {
+		t.Errorf("expected a revealed role readable, got %v", resp["errors"])
+	}
+}
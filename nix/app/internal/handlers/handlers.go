@@ -3,61 +3,179 @@ package handlers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"log"
+	"log/slog"
 	"math/big"
-	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
+	"treacherest/internal/clock"
 	"treacherest/internal/config"
 	"treacherest/internal/game"
+	"treacherest/internal/logging"
+	"treacherest/internal/mail"
+	localMiddleware "treacherest/internal/middleware"
+	"treacherest/internal/privacy"
+	"treacherest/internal/roomlog"
+	"treacherest/internal/secrets"
 	"treacherest/internal/store"
 )
 
 // Handler holds dependencies for HTTP handlers
 type Handler struct {
-	store             *store.MemoryStore
-	eventBus          *EventBus
-	cardService       *game.CardService
-	config            *config.ServerConfig
-	roleConfigService *game.RoleConfigService
-	backupService     *game.BackupService
+	store          store.RoomStore
+	eventBus       *EventBus
+	cardService    *game.CardService
+	live           atomic.Pointer[liveConfig] // see config_reload.go
+	reloadMu       sync.Mutex                 // one config reload at a time
+	backupService  *game.BackupService
+	connTracker    *ConnectionTracker
+	sessionKeys    *secrets.Keyring               // nil leaves session cookies unsigned
+	adminToken     string                         // empty disables the /admin endpoints
+	tenants        map[string]*tenant             // by ID, see tenant.go
+	trustedProxies localMiddleware.TrustedProxies // peers whose forwarding headers are believed
+	mailer         mail.Sender                    // nil disables email invites
+	maintenance    *maintenanceMode
+	drainer        *drainer
+	telemetry      *sseTelemetry
+	unknownEvents  *unknownEventMetrics
+	roomQuota      *roomCreationQuota
+	botChecks      *botCheckMetrics
+	botTokens      *botVerifications
+	seatPINs       *seatPINGuard
+	roleImages     *roleImageCache
+	tables         *tableRegistry
+	onboarding     *onboarding
+	tabs           *playerTabs
+	presence       *operatorPresence
+	roomLogs       *roomlog.Capture  // nil disables per-room log capture
+	redactor       *privacy.Redactor // nil logs player identities as they are
+	stuckWriters   atomic.Int64      // SSE streams ended by a write past its deadline
+	sendQueues     sendQueueMetrics  // SSE streams' outbound queues
+	clock          clock.Clock
+	attribution    string       // licence and attribution notice for /about
+	logger         *slog.Logger // structured, at the configured level and format
 }
 
 // New creates a new handler
-func New(store *store.MemoryStore, cardService *game.CardService, cfg *config.ServerConfig, backupService *game.BackupService) *Handler {
-	roleConfigService := game.NewRoleConfigService(cfg)
-	roleConfigService.SetCardService(cardService)
-
-	return &Handler{
-		store:             store,
-		eventBus:          NewEventBus(),
-		cardService:       cardService,
-		config:            cfg,
-		roleConfigService: roleConfigService,
-		backupService:     backupService,
+func New(store store.RoomStore, cardService *game.CardService, cfg *config.ServerConfig, backupService *game.BackupService) *Handler {
+	h := &Handler{
+		store:          store,
+		eventBus:       NewEventBus(),
+		cardService:    cardService,
+		backupService:  backupService,
+		connTracker:    NewConnectionTracker(),
+		maintenance:    newMaintenanceMode(cfg.Server.MaintenanceStateFile),
+		drainer:        newDrainer(),
+		telemetry:      newSSETelemetry(),
+		unknownEvents:  newUnknownEventMetrics(),
+		roomQuota:      newRoomCreationQuota(),
+		tenants:        newTenants(cfg),
+		trustedProxies: cfg.Server.TrustedProxyRanges(),
+		botChecks:      newBotCheckMetrics(),
+		botTokens:      newBotVerifications(),
+		seatPINs:       newSeatPINGuard(),
+		roleImages:     newRoleImageCache(),
+		tables:         newTableRegistry(),
+		onboarding:     newOnboarding(),
+		tabs:           newPlayerTabs(),
+		presence:       newOperatorPresence(),
+		clock:          clock.Real(),
+		logger:         logging.New(cfg.Server.LogLevel, cfg.Server.LogFormat),
 	}
+	h.publishConfig(cfg)
+	return h
+}
+
+// SetSessionKeys turns on session cookie signing. The newest key signs; older
+// keys are still accepted so a rotation does not log everyone out.
+func (h *Handler) SetSessionKeys(keys *secrets.Keyring) {
+	h.sessionKeys = keys
+}
+
+// SetAdminToken enables the /admin endpoints for requests bearing token
+func (h *Handler) SetAdminToken(token string) {
+	h.adminToken = token
+}
+
+// SetMailer enables email invites, sent through sender
+func (h *Handler) SetMailer(sender mail.Sender) {
+	h.mailer = sender
+}
+
+// SetRoomLogs captures per-room log lines and events into logs for problem
+// reports; logs must also be the standard logger's output
+func (h *Handler) SetRoomLogs(logs *roomlog.Capture) {
+	h.roomLogs = logs
+	h.eventBus.SetRecorder(func(event Event) {
+		text := string(event.Type)
+		if event.Actor != (EventActor{}) {
+			text += " by " + h.redactIdentities(event.Actor.String())
+		}
+		logs.Record(event.RoomCode, roomlog.KindEvent, text)
+	})
+}
+
+// SetEventFaults makes the event bus delay or drop deliveries through fn
+func (h *Handler) SetEventFaults(fn EventFaults) {
+	h.eventBus.SetFaults(fn)
+}
+
+// SetAttribution sets the licence and attribution notice shown on /about
+func (h *Handler) SetAttribution(text string) {
+	h.attribution = text
+}
+
+// SetClock replaces the wall clock behind countdowns, phase timers and
+// heartbeats, letting tests advance time instead of sleeping
+func (h *Handler) SetClock(c clock.Clock) {
+	h.clock = c
+	h.maintenance.clock = c
 }
 
 // Store returns the handler's store (for testing)
-func (h *Handler) Store() *store.MemoryStore {
+func (h *Handler) Store() store.RoomStore {
 	return h.store
 }
 
+// record appends e to room's event journal for a change the handler has
+// already checked, so applying it can't be refused; a failure to record it
+// is only logged. The caller holds the room's lock.
+func (h *Handler) record(room *game.Room, e game.RoomEvent) {
+	if err := h.store.Append(room, e); err != nil {
+		log.Printf("❌ Failed to record %s in room %s: %v", e.Kind, room.Code, err)
+	}
+}
+
 // Event represents a game event
 type Event struct {
-	Type     string
+	Type     EventType
 	RoomCode string
 	Data     interface{}
+	Actor    EventActor // who caused the event; zero for timers and the server
+	ID       string     // set by the bus when it delivers the event, see replay
 }
 
 // EventBus manages event subscriptions
 type EventBus struct {
 	mu          sync.RWMutex
 	subscribers map[string][]chan Event
+	recorder    func(Event)     // optional, sees every published event
+	faults      EventFaults     // optional, delays or drops deliveries
+	coalescer   *eventCoalescer // optional, merges bursts of config events
+	history     *eventHistory   // recent events per room, for Last-Event-ID replay
 }
 
+// EventFaults decides the fate of one delivery of an event to a subscriber:
+// how long to hold it back, or whether to drop it. Chaos builds set one to
+// exercise replay and resync; production never does.
+type EventFaults func(Event) (delay time.Duration, drop bool)
+
 // NewEventBus creates a new event bus
 func NewEventBus() *EventBus {
 	return &EventBus{
 		subscribers: make(map[string][]chan Event),
+		history:     newEventHistory(),
 	}
 }
 
@@ -86,45 +204,101 @@ func (eb *EventBus) Unsubscribe(roomCode string, ch chan Event) {
 	}
 }
 
-// Publish publishes an event to all subscribers
+// SetRecorder registers fn to see every event published to a room
+func (eb *EventBus) SetRecorder(fn func(Event)) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.recorder = fn
+}
+
+// SetFaults registers fn to delay or drop deliveries of published events
+func (eb *EventBus) SetFaults(fn EventFaults) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.faults = fn
+}
+
+// Publish publishes an event to all subscribers. A config event published
+// within the coalescing window of the last one for its room is held back
+// instead, see SetCoalescing.
 func (eb *EventBus) Publish(event Event) {
+	eb.mu.RLock()
+	coalescer := eb.coalescer
+	eb.mu.RUnlock()
+	if coalescer != nil && coalescer.hold(event, eb.publishNow) {
+		return
+	}
+	eb.publishNow(event)
+}
+
+// publishNow stamps event with its ID and delivers it to the room's
+// subscribers
+func (eb *EventBus) publishNow(event Event) {
+	event = eb.history.stamp(event)
+
 	eb.mu.RLock()
 	defer eb.mu.RUnlock()
 
+	if eb.recorder != nil {
+		eb.recorder(event)
+	}
+
 	subscribers := eb.subscribers[event.RoomCode]
 
 	for _, ch := range subscribers {
-		select {
-		case ch <- event:
-			// Event sent successfully
-		default:
-			// Channel full, skip
-		}
+		eb.deliver(ch, event)
 	}
 }
 
-// getOrCreateSession gets or creates a session for the user
-func getOrCreateSession(w http.ResponseWriter, r *http.Request) string {
-	cookie, err := r.Cookie("session")
-	if err == nil {
-		return cookie.Value
+// Broadcast publishes an event to the subscribers of every room
+func (eb *EventBus) Broadcast(event Event) {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+
+	for roomCode, subscribers := range eb.subscribers {
+		event.RoomCode = roomCode
+		stamped := eb.history.stamp(event)
+		for _, ch := range subscribers {
+			eb.deliver(ch, stamped)
+		}
 	}
+}
 
-	// Create new session
-	b := make([]byte, 16)
-	rand.Read(b)
-	sessionID := hex.EncodeToString(b)
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session",
-		Value:    sessionID,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400 * 7, // 7 days
-	})
+// deliver sends event to ch, through the faults when there are any. The
+// caller holds the read lock.
+func (eb *EventBus) deliver(ch chan Event, event Event) {
+	if eb.faults != nil {
+		delay, drop := eb.faults(event)
+		if drop {
+			return
+		}
+		if delay > 0 {
+			time.AfterFunc(delay, func() { eb.deliverLate(ch, event) })
+			return
+		}
+	}
+	select {
+	case ch <- event:
+		// Event sent successfully
+	default:
+		// Channel full, skip
+	}
+}
 
-	return sessionID
+// deliverLate sends a held-back event, unless ch was unsubscribed (and
+// closed) in the meantime
+func (eb *EventBus) deliverLate(ch chan Event, event Event) {
+	eb.mu.RLock()
+	defer eb.mu.RUnlock()
+	for _, sub := range eb.subscribers[event.RoomCode] {
+		if sub == ch {
+			select {
+			case ch <- event:
+			default:
+			}
+			return
+		}
+	}
 }
 
 // generatePlayerID generates a unique player ID
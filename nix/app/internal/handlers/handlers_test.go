@@ -301,12 +301,60 @@ func TestEventBus_ConcurrentOperations(t *testing.T) {
 	wg.Wait()
 }
 
+func TestEventBus_Faults(t *testing.T) {
+	t.Run("dropped events are never delivered", func(t *testing.T) {
+		eb := NewEventBus()
+		eb.SetFaults(func(Event) (time.Duration, bool) { return 0, true })
+		ch := eb.Subscribe("room1")
+
+		eb.Publish(Event{Type: "test", RoomCode: "room1"})
+
+		select {
+		case <-ch:
+			t.Error("received a dropped event")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("delayed events arrive late", func(t *testing.T) {
+		eb := NewEventBus()
+		eb.SetFaults(func(Event) (time.Duration, bool) { return 30 * time.Millisecond, false })
+		ch := eb.Subscribe("room1")
+
+		sent := time.Now()
+		eb.Publish(Event{Type: "test", RoomCode: "room1"})
+
+		select {
+		case <-ch:
+			if waited := time.Since(sent); waited < 30*time.Millisecond {
+				t.Errorf("expected the event held back 30ms, got it after %s", waited)
+			}
+		case <-time.After(time.Second):
+			t.Error("delayed event never arrived")
+		}
+	})
+
+	t.Run("unsubscribing while an event is held back", func(t *testing.T) {
+		eb := NewEventBus()
+		eb.SetFaults(func(Event) (time.Duration, bool) { return 10 * time.Millisecond, false })
+		ch := eb.Subscribe("room1")
+
+		eb.Publish(Event{Type: "test", RoomCode: "room1"})
+		eb.Unsubscribe("room1", ch) // closes ch; the late delivery must not panic
+
+		time.Sleep(30 * time.Millisecond)
+		if _, ok := <-ch; ok {
+			t.Error("expected no delivery to an unsubscribed channel")
+		}
+	})
+}
+
 func TestGetOrCreateSession(t *testing.T) {
 	t.Run("creates new session when no cookie exists", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/", nil)
 		w := httptest.NewRecorder()
 
-		sessionID := getOrCreateSession(w, req)
+		sessionID := newTestHandler().getOrCreateSession(w, req)
 
 		if sessionID == "" {
 			t.Error("expected non-empty session ID")
@@ -345,7 +393,7 @@ func TestGetOrCreateSession(t *testing.T) {
 		})
 		w := httptest.NewRecorder()
 
-		sessionID := getOrCreateSession(w, req)
+		sessionID := newTestHandler().getOrCreateSession(w, req)
 
 		if sessionID != existingSession {
 			t.Errorf("expected %s, got %s", existingSession, sessionID)
@@ -365,7 +413,7 @@ func TestGetOrCreateSession(t *testing.T) {
 			req := httptest.NewRequest("GET", "/", nil)
 			w := httptest.NewRecorder()
 
-			sessionID := getOrCreateSession(w, req)
+			sessionID := newTestHandler().getOrCreateSession(w, req)
 
 			if sessions[sessionID] {
 				t.Errorf("duplicate session ID generated: %s", sessionID)
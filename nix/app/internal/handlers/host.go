@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// HostPage renders the host dashboard at /host/{code}. A browser without Room
+// Operator authority gets the recovery form instead, so a host on a new
+// device or with expired cookies can take the room back with its recovery code.
+func (h *Handler) HostPage(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		component := pages.RoomNotFound(roomCode)
+		w.WriteHeader(http.StatusNotFound)
+		component.Render(r.Context(), w)
+		return
+	}
+
+	if player := h.operatorPlayer(r, room); player != nil {
+		// Re-issue the cookies the operator may have lost, keyed to the player
+		// their session is seated as
+		setPlayerCookie(w, room.Code, player.ID)
+		if player.IsHost {
+			setHostCookie(w, room.Code)
+		}
+		h.renderOperatorDashboardPage(w, r, room, player)
+		return
+	}
+
+	// A host cookie that no longer grants access would bounce the player
+	// views back here, so drop it
+	if hasHostCookie(r, room.Code) {
+		clearHostCookie(w, room.Code)
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+	pages.HostRecover(room.Code, r.URL.Query().Get("token"), "").Render(r.Context(), w)
+}
+
+// RecoverHost moves Room Operator access to the requesting browser when the
+// posted token is the room's creator token
+func (h *Handler) RecoverHost(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	token := strings.TrimSpace(r.FormValue("token"))
+	seat := operatorSeat(room.Code)
+	if wait := h.seatPINs.lockedFor(seat, h.clock.Now()); wait > 0 {
+		w.WriteHeader(http.StatusTooManyRequests)
+		pages.HostRecover(room.Code, token, seatLockedMessage(wait)).Render(r.Context(), w)
+		return
+	}
+
+	sessionID := h.getOrCreateSession(w, r)
+	player, err := room.RecoverOperator(token, strings.TrimSpace(r.FormValue("pin")), sessionID)
+	switch {
+	case errors.Is(err, game.ErrSeatPINMismatch):
+		h.seatPINs.failed(seat, h.clock.Now())
+		log.Printf("🔐 Rejected host recovery for room %s: wrong seat PIN", room.Code)
+		w.WriteHeader(http.StatusForbidden)
+		pages.HostRecover(room.Code, token, "The host's seat is protected by a PIN. Enter the PIN it was created with.").Render(r.Context(), w)
+		return
+	case err != nil:
+		log.Printf("🔐 Rejected host recovery attempt for room %s", room.Code)
+		w.WriteHeader(http.StatusForbidden)
+		pages.HostRecover(room.Code, "", "That recovery code is not valid for this room.").Render(r.Context(), w)
+		return
+	}
+
+	h.seatPINs.succeeded(seat)
+	if player == nil {
+		// The original host left the room; seat a fresh non-playing host
+		player = game.NewPlayer(generatePlayerID(), "Host", sessionID)
+		player.IsHost = true
+		if err := h.store.Append(room, game.PlayerJoinedEvent(player)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.rememberIdentity(player)
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🔐 Host access for room %s recovered by player %s", room.Code, player.ID)
+
+	setPlayerCookie(w, room.Code, player.ID)
+	if player.IsHost {
+		setHostCookie(w, room.Code)
+	}
+	http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+}
+
+// operatorPlayer returns the player the request's Room Operator session is
+// seated as, preferring the player cookie; nil when the request is not the
+// Room Operator's
+func (h *Handler) operatorPlayer(r *http.Request, room *game.Room) *game.Player {
+	sessionID, ok := h.sessionID(r)
+	if !ok || !room.IsOperatorSession(sessionID) {
+		return nil
+	}
+	return sessionPlayer(r, room, sessionID)
+}
+
+// sessionPlayer returns the player seated under sessionID, preferring the
+// request's player cookie, or nil
+func sessionPlayer(r *http.Request, room *game.Room, sessionID string) *game.Player {
+	if playerCookie, err := r.Cookie("player_" + room.Code); err == nil {
+		if player := room.GetPlayer(playerCookie.Value); player != nil && player.SessionID == sessionID {
+			return player
+		}
+	}
+	for _, player := range room.GetPlayers() {
+		if player.SessionID == sessionID {
+			return player
+		}
+	}
+	return nil
+}
+
+func hasHostCookie(r *http.Request, roomCode string) bool {
+	hostCookie, err := r.Cookie("host_" + roomCode)
+	return err == nil && hostCookie.Value == "true"
+}
+
+func setPlayerCookie(w http.ResponseWriter, roomCode, playerID string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "player_" + roomCode,
+		Value:    playerID,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   86400, // 1 day
+	})
+}
+
+func setHostCookie(w http.ResponseWriter, roomCode string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "host_" + roomCode,
+		Value:    "true",
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   86400, // 1 day
+	})
+}
+
+func clearHostCookie(w http.ResponseWriter, roomCode string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   "host_" + roomCode,
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
+	})
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func TestRecoverHostAccess(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	host := testkit.CreateRoom(t, router, "Host", true)
+	room, _ := h.store.GetRoom(host.RoomCode)
+	token := room.CreatorToken
+	if token == "" {
+		t.Fatal("expected the room to get a creator token on creation")
+	}
+	hostPath := "/host/" + host.RoomCode
+
+	if w := host.Get(hostPath); !strings.Contains(w.Body.String(), token) {
+		t.Error("expected the host dashboard to show the recovery code")
+	}
+
+	device := testkit.NewClient(t, router)
+	device.RoomCode = host.RoomCode
+	w := device.Get(hostPath + "?token=" + token)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a new device to get the recovery form with 401, got %d", w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, `id="host-recover"`) || !strings.Contains(body, `value="`+token+`"`) {
+		t.Fatal("expected the recovery form, pre-filled from the link")
+	}
+
+	if w := device.Post(hostPath+"/recover", url.Values{"token": {"wrong"}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a wrong recovery code to be refused with 403, got %d", w.Code)
+	}
+
+	w = device.Post(hostPath+"/recover", url.Values{"token": {token}})
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != hostPath {
+		t.Fatalf("expected recovery to redirect to %s, got %d %q", hostPath, w.Code, w.Header().Get("Location"))
+	}
+	if device.PlayerID() != host.PlayerID() || device.Cookie("host_"+host.RoomCode) == nil {
+		t.Error("expected the new device to be seated as the original host")
+	}
+	if w := device.Get(hostPath); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `id="host-dashboard-container"`) {
+		t.Fatalf("expected the new device to see the host dashboard, got %d", w.Code)
+	}
+
+	// The old device lost access, and its host cookie is dropped so the
+	// player views stop bouncing it to the host page
+	if w := host.Get(hostPath); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected the old device to lose host access, got %d", w.Code)
+	}
+	if host.Cookie("host_"+host.RoomCode) != nil {
+		t.Error("expected the stale host cookie to be cleared")
+	}
+}
+
+func TestRecoverHostAccessNeedsSeatPIN(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	host := testkit.NewClient(t, router)
+	if w := host.Post("/room/new", url.Values{"playerName": {"Host"}, "hostOnly": {"true"}, "pin": {"12345"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a PIN that isn't 4 digits to be refused, got %d", w.Code)
+	}
+	w := host.Post("/room/new", url.Values{"playerName": {"Host"}, "hostOnly": {"true"}, "pin": {"2468"}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the room to be created, got %d: %s", w.Code, w.Body.String())
+	}
+	hostPath := w.Header().Get("Location")
+	room, _ := h.store.GetRoom(strings.TrimPrefix(hostPath, "/host/"))
+
+	// The recovery code is on the dashboard for anyone in the room to see;
+	// without the PIN it doesn't move the seat
+	device := testkit.NewClient(t, router)
+	w = device.Post(hostPath+"/recover", url.Values{"token": {room.CreatorToken}, "pin": {"1357"}})
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "protected by a PIN") {
+		t.Fatalf("expected a wrong PIN to be refused with 403, got %d", w.Code)
+	}
+	if w := host.Get(hostPath); w.Code != http.StatusOK {
+		t.Fatalf("expected the host to keep access after a refused PIN, got %d", w.Code)
+	}
+
+	w = device.Post(hostPath+"/recover", url.Values{"token": {room.CreatorToken}, "pin": {"2468"}})
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != hostPath {
+		t.Fatalf("expected the right PIN to recover host access, got %d", w.Code)
+	}
+}
+
+func TestHostNavigationGuards(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	host := testkit.CreateRoom(t, router, "Host", true)
+	hostPath := "/host/" + host.RoomCode
+
+	// The player cookie expired but the session still operates the room
+	playerID := host.PlayerID()
+	host.SetCookie(&http.Cookie{Name: "player_" + host.RoomCode, MaxAge: -1})
+	for _, path := range []string{"/room/" + host.RoomCode, "/game/" + host.RoomCode} {
+		if w := host.Get(path); w.Code != http.StatusSeeOther || w.Header().Get("Location") != hostPath {
+			t.Errorf("expected %s to send the host to %s, got %d %q", path, hostPath, w.Code, w.Header().Get("Location"))
+		}
+	}
+	if w := host.Get(hostPath); w.Code != http.StatusOK {
+		t.Fatalf("expected the host page to re-seat the host, got %d", w.Code)
+	}
+	if host.PlayerID() != playerID {
+		t.Errorf("expected the host's player cookie to be re-issued, got %q", host.PlayerID())
+	}
+
+	// Players are left on the player views
+	player := testkit.JoinRoom(t, router, host.RoomCode, "Player")
+	if w := player.Get("/room/" + host.RoomCode); w.Code != http.StatusOK {
+		t.Errorf("expected a player to stay in the lobby, got %d", w.Code)
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// operatorFallbackGrace is how long a room waits for its Room Operator to
+// reconnect before control passes to the earliest-joined player
+const operatorFallbackGrace = 2 * time.Minute
+
+// TransferHost hands Room Operator authority to another seated player, who
+// gets the setup and start controls; the old operator's dashboard closes.
+func (h *Handler) TransferHost(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	target, err := room.TransferOperator(chi.URLParam(r, "playerID"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("👑 Room Operator of room %s handed control to player %s", room.Code, target.ID)
+
+	h.eventBus.Publish(Event{
+		Type:     EventOperatorChanged,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// operatorPresence counts each session's open streams per room, so a room
+// whose Room Operator has been gone for the grace period can move control to
+// someone still at the table
+type operatorPresence struct {
+	mu      sync.Mutex
+	streams map[string]int // by room code and session ID
+}
+
+func newOperatorPresence() *operatorPresence {
+	return &operatorPresence{streams: make(map[string]int)}
+}
+
+// open counts a stream for sessionID in roomCode and returns the func that
+// closes it again, reporting whether it was the session's last
+func (p *operatorPresence) open(roomCode, sessionID string) (close func() (last bool)) {
+	key := roomCode + "/" + sessionID
+	p.mu.Lock()
+	p.streams[key]++
+	p.mu.Unlock()
+
+	return func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.streams[key]--
+		if p.streams[key] > 0 {
+			return false
+		}
+		delete(p.streams, key)
+		return true
+	}
+}
+
+// connected reports whether sessionID has a stream open in roomCode
+func (p *operatorPresence) connected(roomCode, sessionID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.streams[roomCode+"/"+sessionID] > 0
+}
+
+// trackPresence counts the request's stream for its session until the
+// returned func is called. When that closes the Room Operator's last stream,
+// control falls back to the earliest-joined player unless the operator is
+// back within operatorFallbackGrace.
+func (h *Handler) trackPresence(r *http.Request, roomCode string) func() {
+	sessionID, ok := h.sessionID(r)
+	if !ok {
+		return func() {}
+	}
+	closeStream := h.presence.open(roomCode, sessionID)
+	return func() {
+		if !closeStream() {
+			return
+		}
+		room, err := h.store.GetRoom(roomCode)
+		if err != nil || !room.IsOperatorSession(sessionID) {
+			return
+		}
+		h.clock.AfterFunc(operatorFallbackGrace, func() { h.fallBackOperator(roomCode, sessionID) })
+	}
+}
+
+// fallBackOperator moves control of roomCode to the earliest-joined player
+// when sessionID still holds it and has not reconnected
+func (h *Handler) fallBackOperator(roomCode, sessionID string) {
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		return
+	}
+	room.Lock()
+	defer room.Unlock()
+
+	if !room.IsOperatorSession(sessionID) || h.presence.connected(roomCode, sessionID) {
+		return
+	}
+	next := room.OperatorFallback()
+	if next == nil {
+		return
+	}
+	if _, err := room.TransferOperator(next.ID); err != nil {
+		log.Printf("❌ Failed to hand room %s to player %s: %v", roomCode, next.ID, err)
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("👑 Room Operator of room %s was away for %s, control passed to player %s", roomCode, operatorFallbackGrace, next.ID)
+
+	h.eventBus.Publish(Event{
+		Type:     EventOperatorChanged,
+		RoomCode: roomCode,
+		Data:     room,
+	})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestTransferHostMovesRoomOperatorAuthority(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+	room.EnsureCreatorToken()
+	path := "/room/" + room.Code + "/host/transfer/"
+
+	if w := postPhaseForm(router, path+"op", "s1", url.Values{}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player to be refused, got %d", w.Code)
+	}
+	for _, target := range []string{"op", "nobody"} {
+		if w := postPhaseForm(router, path+target, "operator-session", url.Values{}); w.Code != http.StatusBadRequest {
+			t.Errorf("expected handing control to %q to be refused, got %d", target, w.Code)
+		}
+	}
+
+	if w := postPhaseForm(router, path+player.ID, "operator-session", url.Values{}); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if !room.IsOperatorSession("s1") || room.IsOperatorSession("operator-session") {
+		t.Fatal("expected Alice's session to hold Room Operator authority")
+	}
+	if room.CreatorToken != "" {
+		t.Error("expected the old recovery code to be cleared")
+	}
+	select {
+	case event := <-events:
+		if event.Type != EventOperatorChanged {
+			t.Errorf("expected %s, got %s", EventOperatorChanged, event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the transfer to be published")
+	}
+}
+
+func TestTransferHostCallsOffAPendingDeal(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.JoinRoom(t, router, host.RoomCode, "Alice")
+	testkit.JoinRoom(t, router, host.RoomCode, "Bob")
+	room, _ := h.store.GetRoom(host.RoomCode)
+
+	if w := host.Post("/room/"+room.Code+"/config/start-ritual", url.Values{"approveDeal": {"true"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected the setting to save, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := host.Post("/room/"+room.Code+"/start", nil); w.Code != http.StatusNoContent || !room.DealPending {
+		t.Fatalf("expected the start to hold the deal, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := host.Post("/room/"+room.Code+"/host/transfer/"+alice.PlayerID(), nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the transfer to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.DealPending || room.State != game.StateLobby {
+		t.Fatalf("expected the held deal called off, got %s pending=%v", room.State, room.DealPending)
+	}
+	for _, player := range room.GetPlayers() {
+		if player.Role != nil {
+			t.Errorf("expected %s's dealt role wiped, got %s", player.Name, player.Role.Name)
+		}
+	}
+
+	w := alice.Get("/host/" + room.Code)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected Alice to reach the host dashboard, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "operator-deal-preview") {
+		t.Error("expected the new operator not to be shown the deal")
+	}
+}
+
+func TestOperatorFallsBackToEarliestPlayerAfterGracePeriod(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	room, player := newPhaseTestRoom(t, h)
+
+	stream := httptest.NewRequest("GET", "/sse/room/"+room.Code, nil)
+	stream.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+
+	// Back within the grace period: control stays put
+	h.trackPresence(stream, room.Code)()
+	reconnected := h.trackPresence(stream, room.Code)
+	fake.Advance(operatorFallbackGrace)
+	if !room.IsOperatorSession("operator-session") {
+		t.Fatal("expected a reconnected operator to keep control")
+	}
+
+	reconnected()
+	fake.Advance(operatorFallbackGrace - time.Second)
+	if !room.IsOperatorSession("operator-session") {
+		t.Fatal("expected control to wait out the grace period")
+	}
+	fake.Advance(time.Second)
+	if !room.IsOperatorSession(player.SessionID) {
+		t.Fatal("expected control to pass to the earliest-joined player")
+	}
+}
@@ -1,23 +1,22 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
 	"net/url"
-	"strings"
 	"sync"
 	"testing"
 	"time"
 	"treacherest/internal/config"
 	"treacherest/internal/game"
 	"treacherest/internal/store"
+	"treacherest/internal/testkit"
 
 	"github.com/go-chi/chi/v5"
+	"treacherest/internal/views/components"
 )
 
-// IntegrationTestHelper provides utilities for integration testing
+// IntegrationTestHelper drives the production router through testkit browsers
 type IntegrationTestHelper struct {
 	t       *testing.T
 	handler *Handler
@@ -31,221 +30,44 @@ func NewIntegrationTestHelper(t *testing.T) *IntegrationTestHelper {
 	gameStore := store.NewMemoryStore(cfg)
 	h := New(gameStore, createMockCardService(), cfg, nil)
 
-	// Set up router with all routes
-	r := chi.NewRouter()
-	r.Get("/", h.Home)
-	r.Post("/room/new", h.CreateRoom)
-	r.Get("/room/{code}", h.JoinRoom)
-	r.Post("/join-room", h.JoinRoomPost)
-	r.Post("/room/{code}/start", h.StartGame)
-	r.Post("/room/{code}/leave", h.LeaveRoom)
-	r.Get("/game/{code}", h.GamePage)
-	r.Get("/sse/lobby/{code}", h.StreamLobby)
-	r.Get("/sse/game/{code}", h.StreamGame)
-
 	return &IntegrationTestHelper{
 		t:       t,
 		handler: h,
-		router:  r,
+		router:  newTestRouter(h),
 		store:   gameStore,
 	}
 }
 
-// CreateRoom creates a new room and returns the room code
-func (h *IntegrationTestHelper) CreateRoom(playerName string) (string, *http.Cookie) {
-	form := url.Values{"playerName": {playerName}}
-	req := httptest.NewRequest("POST", "/room/new", strings.NewReader(form.Encode()))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	w := httptest.NewRecorder()
-
-	h.router.ServeHTTP(w, req)
-
-	if w.Code != http.StatusSeeOther {
-		h.t.Fatalf("Expected redirect, got %d", w.Code)
-	}
-
-	location := w.Header().Get("Location")
-	if !strings.HasPrefix(location, "/room/") {
-		h.t.Fatalf("Expected redirect to room, got %s", location)
-	}
-
-	roomCode := strings.TrimPrefix(location, "/room/")
-
-	// Get player cookie
-	var playerCookie *http.Cookie
-	for _, cookie := range w.Result().Cookies() {
-		if cookie.Name == "player_"+roomCode {
-			playerCookie = cookie
-			break
-		}
-	}
-
-	if playerCookie == nil {
-		h.t.Fatal("No player cookie set")
-	}
-
-	return roomCode, playerCookie
+// CreateRoom creates a new room and returns the creator's browser
+func (h *IntegrationTestHelper) CreateRoom(playerName string) *testkit.Client {
+	return testkit.CreateRoom(h.t, h.router, playerName, false)
 }
 
-// JoinRoom joins an existing room
-func (h *IntegrationTestHelper) JoinRoom(roomCode, playerName string) *http.Cookie {
-	// Use POST to join room
-	formData := "room_code=" + roomCode + "&player_name=" + url.QueryEscape(playerName)
-	req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	w := httptest.NewRecorder()
-
-	h.router.ServeHTTP(w, req)
-
-	if w.Code != http.StatusSeeOther {
-		h.t.Fatalf("Expected redirect status 303, got %d", w.Code)
-	}
-
-	// Check redirect location
-	expectedLocation := "/room/" + roomCode
-	if location := w.Header().Get("Location"); location != expectedLocation {
-		h.t.Fatalf("Expected redirect to %s, got %s", expectedLocation, location)
-	}
-
-	// Get player cookie
-	var playerCookie *http.Cookie
-	for _, cookie := range w.Result().Cookies() {
-		if cookie.Name == "player_"+roomCode {
-			playerCookie = cookie
-			break
-		}
-	}
-
-	if playerCookie == nil {
-		h.t.Fatal("No player cookie set")
-	}
-
-	return playerCookie
+// JoinRoom joins an existing room in a fresh browser
+func (h *IntegrationTestHelper) JoinRoom(roomCode, playerName string) *testkit.Client {
+	return testkit.JoinRoom(h.t, h.router, roomCode, playerName)
 }
 
-// StartGame starts a game in the given room
-func (h *IntegrationTestHelper) StartGame(roomCode string, playerCookie *http.Cookie) {
-	req := httptest.NewRequest("POST", "/room/"+roomCode+"/start", nil)
-	req.AddCookie(playerCookie)
-	w := httptest.NewRecorder()
-
-	h.router.ServeHTTP(w, req)
-
-	if w.Code != http.StatusOK {
-		h.t.Fatalf("Expected OK, got %d", w.Code)
+// StartGame starts a game as the given browser
+func (h *IntegrationTestHelper) StartGame(browser *testkit.Client) {
+	if code := browser.StartGame(); code != http.StatusOK {
+		h.t.Fatalf("Expected OK, got %d", code)
 	}
 }
 
-// LeaveRoom leaves a room
-func (h *IntegrationTestHelper) LeaveRoom(roomCode string, playerCookie *http.Cookie) {
-	req := httptest.NewRequest("POST", "/room/"+roomCode+"/leave", nil)
-	req.AddCookie(playerCookie)
-	w := httptest.NewRecorder()
-
-	h.router.ServeHTTP(w, req)
-
+// LeaveRoom leaves the browser's room
+func (h *IntegrationTestHelper) LeaveRoom(browser *testkit.Client) {
+	w := browser.Post("/room/"+browser.RoomCode+"/leave", nil)
 	if w.Code != http.StatusSeeOther {
 		h.t.Fatalf("Expected redirect, got %d", w.Code)
 	}
 }
 
-// SSEClient simulates an SSE client connection
-type SSEClient struct {
-	t        *testing.T
-	ctx      context.Context
-	cancel   context.CancelFunc
-	events   chan string
-	errors   chan error
-	response *httptest.ResponseRecorder
-}
-
-// NewSSEClient creates a new SSE client for testing
-func (h *IntegrationTestHelper) NewSSEClient(path string, playerCookie *http.Cookie) *SSEClient {
-	ctx, cancel := context.WithCancel(context.Background())
-	client := &SSEClient{
-		t:      h.t,
-		ctx:    ctx,
-		cancel: cancel,
-		events: make(chan string, 10),
-		errors: make(chan error, 1),
-	}
-
-	// Create request with context
-	req := httptest.NewRequest("GET", path, nil).WithContext(ctx)
-	req.Header.Set("Accept", "text/event-stream")
-	if playerCookie != nil {
-		req.AddCookie(playerCookie)
-	}
-
-	// Create a custom response writer that captures SSE data
-	w := &sseResponseWriter{
-		ResponseRecorder: httptest.NewRecorder(),
-		client:           client,
-	}
-
-	// Start the SSE handler in a goroutine
-	go func() {
-		h.router.ServeHTTP(w, req)
-	}()
-
-	// Give the connection time to establish
+// OpenSSE connects the browser to an SSE endpoint and gives the connection time to establish
+func (h *IntegrationTestHelper) OpenSSE(browser *testkit.Client, path string) *testkit.Stream {
+	stream := browser.OpenSSE(path)
 	time.Sleep(100 * time.Millisecond)
-
-	return client
-}
-
-// Close closes the SSE client
-func (c *SSEClient) Close() {
-	c.cancel()
-}
-
-// WaitForEvent waits for an SSE event with timeout
-func (c *SSEClient) WaitForEvent(timeout time.Duration) (string, error) {
-	select {
-	case event := <-c.events:
-		return event, nil
-	case err := <-c.errors:
-		return "", err
-	case <-time.After(timeout):
-		return "", fmt.Errorf("timeout waiting for event")
-	}
-}
-
-// Custom response writer for SSE testing
-type sseResponseWriter struct {
-	*httptest.ResponseRecorder
-	client *SSEClient
-	mu     sync.Mutex
-}
-
-func (w *sseResponseWriter) Write(data []byte) (int, error) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-
-	// Capture the data
-	n, err := w.ResponseRecorder.Write(data)
-
-	// Parse SSE events - looking specifically for HTML fragments
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "data: fragments ") {
-			// Extract the HTML fragment
-			htmlFragment := strings.TrimPrefix(line, "data: fragments ")
-			if htmlFragment != "" {
-				select {
-				case w.client.events <- htmlFragment:
-				default:
-					// Buffer full, skip
-				}
-			}
-		}
-	}
-
-	return n, err
-}
-
-func (w *sseResponseWriter) Flush() {
-	// SSE requires flushing - no-op for test recorder
+	return stream
 }
 
 // Helper to get room from store
@@ -262,28 +84,23 @@ func TestFullGameFlow(t *testing.T) {
 	helper := NewIntegrationTestHelper(t)
 
 	// Create room
-	roomCode, hostCookie := helper.CreateRoom("Host Player")
+	host := helper.CreateRoom("Host Player")
+	roomCode := host.RoomCode
 
 	// Start SSE connection for host
-	hostSSE := helper.NewSSEClient("/sse/lobby/"+roomCode, hostCookie)
+	hostSSE := helper.OpenSSE(host, "/sse/lobby/"+roomCode)
 	defer hostSSE.Close()
 
 	// Note: No initial lobby state is sent - SSE only sends updates on events
 	// This is intentional as the page already has the correct content
 
 	// Join 3 more players
-	var playerCookies []*http.Cookie
 	for i := 2; i <= 4; i++ {
 		playerName := fmt.Sprintf("Player %d", i)
-		cookie := helper.JoinRoom(roomCode, playerName)
-		playerCookies = append(playerCookies, cookie)
+		helper.JoinRoom(roomCode, playerName)
 
 		// Wait for join event
-		event, err := hostSSE.WaitForEvent(2 * time.Second)
-		if err != nil {
-			t.Fatalf("Failed to get join event: %v", err)
-		}
-		if !strings.Contains(event, playerName) {
+		if !hostSSE.WaitFor(playerName, 2*time.Second) {
 			t.Errorf("Expected %s in lobby update", playerName)
 		}
 	}
@@ -295,7 +112,7 @@ func TestFullGameFlow(t *testing.T) {
 	}
 
 	// Start game
-	helper.StartGame(roomCode, hostCookie)
+	helper.StartGame(host)
 
 	// Wait for countdown
 	time.Sleep(100 * time.Millisecond)
@@ -312,7 +129,7 @@ func TestConcurrentJoins(t *testing.T) {
 	helper := NewIntegrationTestHelper(t)
 
 	// Create room
-	roomCode, _ := helper.CreateRoom("Host")
+	roomCode := helper.CreateRoom("Host").RoomCode
 
 	// Join 7 players concurrently
 	var wg sync.WaitGroup
@@ -323,13 +140,12 @@ func TestConcurrentJoins(t *testing.T) {
 		go func(playerNum int) {
 			defer wg.Done()
 
-			playerName := fmt.Sprintf("Player %d", playerNum)
-			formData := "room_code=" + roomCode + "&player_name=" + url.QueryEscape(playerName)
-			req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
-			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-			w := httptest.NewRecorder()
-
-			helper.router.ServeHTTP(w, req)
+			browser := testkit.NewClient(t, helper.router)
+			w := browser.Post("/join-room", url.Values{
+				"room_code":                {roomCode},
+				"player_name":              {fmt.Sprintf("Player %d", playerNum)},
+				components.BotElapsedField: {"5000"},
+			})
 
 			if w.Code != http.StatusSeeOther {
 				errors <- fmt.Errorf("player %d got status %d (expected 303)", playerNum, w.Code)
@@ -357,7 +173,8 @@ func TestPlayerReconnection(t *testing.T) {
 	helper := NewIntegrationTestHelper(t)
 
 	// Create room and join
-	roomCode, hostCookie := helper.CreateRoom("Host")
+	host := helper.CreateRoom("Host")
+	roomCode := host.RoomCode
 
 	// Get player ID from room
 	room := helper.GetRoom(roomCode)
@@ -368,7 +185,7 @@ func TestPlayerReconnection(t *testing.T) {
 	}
 
 	// Simulate disconnect by leaving
-	helper.LeaveRoom(roomCode, hostCookie)
+	helper.LeaveRoom(host)
 
 	// Verify player removed
 	room = helper.GetRoom(roomCode)
@@ -377,13 +194,7 @@ func TestPlayerReconnection(t *testing.T) {
 	}
 
 	// Rejoin with same session cookie
-	formData := "room_code=" + roomCode + "&player_name=Host"
-	req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.AddCookie(hostCookie)
-	w := httptest.NewRecorder()
-
-	helper.router.ServeHTTP(w, req)
+	w := host.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Host"}, components.BotElapsedField: {"5000"}})
 
 	if w.Code != http.StatusSeeOther {
 		t.Fatalf("Expected redirect on rejoin, got %d", w.Code)
@@ -408,29 +219,25 @@ func TestSSEReconnection(t *testing.T) {
 	helper := NewIntegrationTestHelper(t)
 
 	// Create room
-	roomCode, hostCookie := helper.CreateRoom("Host")
+	host := helper.CreateRoom("Host")
+	roomCode := host.RoomCode
 
 	// Start first SSE connection
-	sse1 := helper.NewSSEClient("/sse/lobby/"+roomCode, hostCookie)
+	sse1 := helper.OpenSSE(host, "/sse/lobby/"+roomCode)
 
 	// No initial event is sent for lobby SSE - this is intentional
 	// Close first connection
 	sse1.Close()
 
 	// Start second SSE connection (reconnection)
-	sse2 := helper.NewSSEClient("/sse/lobby/"+roomCode, hostCookie)
+	sse2 := helper.OpenSSE(host, "/sse/lobby/"+roomCode)
 	defer sse2.Close()
 
 	// Join another player to trigger an event
-	_ = helper.JoinRoom(roomCode, "Player 2")
+	helper.JoinRoom(roomCode, "Player 2")
 
 	// Should receive the join event
-	event, err := sse2.WaitForEvent(2 * time.Second)
-	if err != nil {
-		t.Fatalf("Failed to get join event: %v", err)
-	}
-
-	if !strings.Contains(event, "Player 2") {
+	if !sse2.WaitFor("Player 2", 2*time.Second) {
 		t.Error("Expected player join event")
 	}
 }
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+// inviteCookie carries an invite's token from its link to the join form
+const inviteCookie = "invite"
+
+// maxInviteAddresses caps how many addresses one submission may list
+const maxInviteAddresses = 50
+
+// SendInvites emails a one-time join link to each address the Room Operator
+// entered, up to the room's hourly invite limit
+func (h *Handler) SendInvites(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if h.mailer == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	addresses, invalid := parseInviteAddresses(r.FormValue("emails"))
+	if len(addresses) > maxInviteAddresses {
+		http.Error(w, fmt.Sprintf("Invite at most %d addresses at a time", maxInviteAddresses), http.StatusBadRequest)
+		return
+	}
+
+	now := h.clock.Now()
+	var limited []string
+	if limit := h.config().Server.InviteEmailsPerHour; limit > 0 {
+		remaining := max(limit-room.InvitesSentSince(now.Add(-time.Hour)), 0)
+		if len(addresses) > remaining {
+			addresses, limited = addresses[:remaining], addresses[remaining:]
+		}
+	}
+
+	var failed []string
+	for _, address := range addresses {
+		invite := game.NewInvite(address, now)
+		link := getBaseURL(r) + h.tenantPrefix(room) + "/invite/" + invite.Token
+		if err := h.mailer.Send(address, "You're invited to a Treacherest game", inviteEmailBody(room.Code, link)); err != nil {
+			log.Printf("⚠️ Invite for room %s not sent: %v", room.Code, err)
+			failed = append(failed, address)
+			continue
+		}
+		room.AddInvite(invite)
+	}
+	sent := len(addresses) - len(failed)
+	if sent > 0 {
+		h.store.UpdateRoom(room)
+		log.Printf("✉️ %d invite(s) sent for room %s", sent, room.Code)
+	}
+
+	notice := inviteNotice(sent, invalid, limited, failed)
+	sse := datastar.NewSSE(w, r)
+	html := renderFragment(pages.HostDashboardInvites(room, notice), "#operator-invites", room.Code)
+	h.patchElements(sse, PageHost, html, "#operator-invites")
+}
+
+// AcceptInviteLink follows an emailed invite: it remembers the invite for
+// the join form and sends the invitee to the room
+func (h *Handler) AcceptInviteLink(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	room, invite, ok := h.findInvite(token)
+	if !ok || !h.inTenant(r, room) {
+		http.Error(w, "Invite not found", http.StatusNotFound)
+		return
+	}
+	if invite.Joined() {
+		http.Error(w, "This invite has already been used; ask the host for the room code", http.StatusGone)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     inviteCookie,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int((24 * time.Hour).Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/room/"+room.Code, http.StatusSeeOther)
+}
+
+// acceptInvite marks the invite the joining browser followed, if any, as
+// used by player. The caller holds the room.
+func (h *Handler) acceptInvite(w http.ResponseWriter, r *http.Request, room *game.Room, player *game.Player) {
+	cookie, err := r.Cookie(inviteCookie)
+	if err != nil {
+		return
+	}
+	if room.AcceptInvite(cookie.Value, player.ID, h.clock.Now()) {
+		log.Printf("✉️ Invite accepted in room %s by %s", room.Code, player.Name)
+		http.SetCookie(w, &http.Cookie{Name: inviteCookie, Path: "/", MaxAge: -1})
+	}
+}
+
+// findInvite returns the live room holding the invite behind token
+func (h *Handler) findInvite(token string) (*game.Room, game.Invite, bool) {
+	if token == "" {
+		return nil, game.Invite{}, false
+	}
+	for _, room := range h.store.Rooms() {
+		if invite, ok := room.FindInvite(token); ok {
+			return room, invite, true
+		}
+	}
+	return nil, game.Invite{}, false
+}
+
+// parseInviteAddresses splits the invite form's addresses on commas and
+// whitespace, dropping repeats and keeping what doesn't parse apart
+func parseInviteAddresses(raw string) (addresses, invalid []string) {
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	}) {
+		parsed, err := mail.ParseAddress(field)
+		if err != nil || parsed.Name != "" {
+			invalid = append(invalid, field)
+			continue
+		}
+		key := strings.ToLower(parsed.Address)
+		if !seen[key] {
+			seen[key] = true
+			addresses = append(addresses, parsed.Address)
+		}
+	}
+	return addresses, invalid
+}
+
+func inviteEmailBody(roomCode, link string) string {
+	return "You've been invited to a game of Treacherest in room " + roomCode + ".\n\n" +
+		"Join here: " + link + "\n\n" +
+		"The link works once. If it has already been used, ask the host for the room code.\n"
+}
+
+// inviteNotice sums up a submission for the host
+func inviteNotice(sent int, invalid, limited, failed []string) string {
+	var parts []string
+	if sent > 0 {
+		parts = append(parts, fmt.Sprintf("Sent %d invite(s).", sent))
+	}
+	if len(invalid) > 0 {
+		parts = append(parts, "Not an email address: "+strings.Join(invalid, ", ")+".")
+	}
+	if len(limited) > 0 {
+		parts = append(parts, "Hourly invite limit reached, not sent: "+strings.Join(limited, ", ")+".")
+	}
+	if len(failed) > 0 {
+		parts = append(parts, "Couldn't send to: "+strings.Join(failed, ", ")+".")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+// fakeMailer records what it sends and refuses addresses at refuse.example
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent map[string]string // body by recipient
+}
+
+func (m *fakeMailer) Send(to, subject, body string) error {
+	if strings.HasSuffix(to, "@refuse.example") {
+		return errors.New("550 mailbox unavailable")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent[to] = body
+	return nil
+}
+
+// inviteLink returns the path of the link emailed to to
+func (m *fakeMailer) inviteLink(t *testing.T, to string) string {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	body, ok := m.sent[to]
+	_, link, found := strings.Cut(body, "http://example.com")
+	if !ok || !found {
+		t.Fatalf("expected an invite link emailed to %s, got %q", to, body)
+	}
+	return strings.Fields(link)[0]
+}
+
+func TestEmailInvites(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.SMTPAddr = "smtp.example.com:587"
+	h.config().Server.InviteEmailsPerHour = 3
+	mailer := &fakeMailer{sent: make(map[string]string)}
+	h.SetMailer(mailer)
+	router := newTestRouter(h)
+	host := testkit.CreateRoom(t, router, "Host", true)
+
+	w := host.Post("/room/"+host.RoomCode+"/invites", url.Values{"emails": {"alice@example.com, not-an-email\nbob@example.com alice@example.com carol@refuse.example"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the invites sent, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, want := range []string{"Sent 2 invite(s).", "Not an email address: not-an-email.", "Couldn&#39;t send to: carol@refuse.example."} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("expected %q in the host's notice, got %s", want, w.Body.String())
+		}
+	}
+	room, _ := h.store.GetRoom(host.RoomCode)
+	if invites := room.GetInvites(); len(invites) != 2 {
+		t.Fatalf("expected the two delivered invites recorded, got %+v", invites)
+	}
+
+	// Alice follows her link and joins
+	alice := testkit.NewClient(t, router)
+	link := mailer.inviteLink(t, "alice@example.com")
+	if w := alice.Get(link); w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/room/"+host.RoomCode {
+		t.Fatalf("expected the invite link to lead to the room, got %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	if w := alice.Post("/join-room", url.Values{"room_code": {host.RoomCode}, "player_name": {"Alice"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected Alice to join, got %d", w.Code)
+	}
+	if alice.Cookie(inviteCookie) != nil && alice.Cookie(inviteCookie).Value != "" {
+		t.Error("expected the invite cookie cleared once used")
+	}
+
+	page := host.Get("/host/" + host.RoomCode).Body.String()
+	if !strings.Contains(page, "Joined as Alice") || !strings.Contains(page, "Pending") {
+		t.Errorf("expected Alice joined and Bob pending on the dashboard")
+	}
+
+	// The link works once
+	if w := testkit.NewClient(t, router).Get(link); w.Code != http.StatusGone {
+		t.Errorf("expected a used invite gone, got %d", w.Code)
+	}
+	if w := testkit.NewClient(t, router).Get("/invite/nope"); w.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown invite not found, got %d", w.Code)
+	}
+
+	// One more fits in the hour
+	w = host.Post("/room/"+host.RoomCode+"/invites", url.Values{"emails": {"dave@example.com, erin@example.com"}})
+	if !strings.Contains(w.Body.String(), "Sent 1 invite(s).") || !strings.Contains(w.Body.String(), "Hourly invite limit reached, not sent: erin@example.com.") {
+		t.Errorf("expected the hourly limit applied, got %s", w.Body.String())
+	}
+}
+
+func TestEmailInvitesNeedTheOperatorAndAMailer(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	host := testkit.CreateRoom(t, router, "Host", true)
+
+	if w := host.Post("/room/"+host.RoomCode+"/invites", url.Values{"emails": {"alice@example.com"}}); w.Code != http.StatusNotFound {
+		t.Errorf("expected invites off without a mailer, got %d", w.Code)
+	}
+
+	h.SetMailer(&fakeMailer{sent: make(map[string]string)})
+	guest := testkit.JoinRoom(t, router, host.RoomCode, "Guest")
+	if w := guest.Post("/room/"+host.RoomCode+"/invites", url.Values{"emails": {"alice@example.com"}}); w.Code == http.StatusOK {
+		t.Error("expected only the Room Operator to send invites")
+	}
+}
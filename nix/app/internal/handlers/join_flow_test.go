@@ -16,7 +16,7 @@ func TestJoinFlowBackNavigation(t *testing.T) {
 
 	// Create first room
 	w1 := httptest.NewRecorder()
-	r1 := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Alice"))
+	r1 := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Alice&form_elapsed=5000"))
 	r1.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	h.CreateRoom(w1, r1)
 
@@ -40,7 +40,7 @@ func TestJoinFlowBackNavigation(t *testing.T) {
 
 	// Create second room
 	w2 := httptest.NewRecorder()
-	r2 := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Bob"))
+	r2 := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Bob&form_elapsed=5000"))
 	r2.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	h.CreateRoom(w2, r2)
 
@@ -52,7 +52,7 @@ func TestJoinFlowBackNavigation(t *testing.T) {
 	// This simulates user pressing back button or entering URL directly
 	t.Run("JoinFirstRoomAfterSecond", func(t *testing.T) {
 		// Join room 1 using POST
-		testRouter := setupTestRouter(h)
+		testRouter := newTestRouter(h)
 		w := joinRoomViaPost(t, h, testRouter, roomCode1, "Charlie", sessionCookie)
 
 		// Should redirect to room
@@ -84,7 +84,7 @@ func TestJoinFlowBackNavigation(t *testing.T) {
 	// Scenario 2: Try to join a different room in the same tab
 	t.Run("JoinDifferentRoomSameSession", func(t *testing.T) {
 		// First join room 1 using POST
-		testRouter := setupTestRouter(h)
+		testRouter := newTestRouter(h)
 		w1 := joinRoomViaPost(t, h, testRouter, roomCode1, "Dave", sessionCookie)
 
 		if w1.Code != http.StatusSeeOther {
@@ -128,7 +128,7 @@ func TestJoinFlowBackNavigation(t *testing.T) {
 	// Scenario 3: Multiple SSE connections
 	t.Run("MultipleSSEConnections", func(t *testing.T) {
 		// Join room as a player using POST
-		testRouter := setupTestRouter(h)
+		testRouter := newTestRouter(h)
 		w := joinRoomViaPost(t, h, testRouter, roomCode1, "Eve", sessionCookie)
 
 		playerCookie := getPlayerCookie(w.Result().Cookies(), roomCode1)
@@ -186,7 +186,7 @@ func getPlayerCookie(cookies []*http.Cookie, roomCode string) *http.Cookie {
 
 // joinRoomViaPost is a helper function to join a room using the new POST endpoint
 func joinRoomViaPost(t *testing.T, h *Handler, router *chi.Mux, roomCode, playerName string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
-	formData := fmt.Sprintf("room_code=%s&player_name=%s", roomCode, playerName)
+	formData := fmt.Sprintf("room_code=%s&player_name=%s&form_elapsed=5000", roomCode, playerName)
 	req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
@@ -201,33 +201,13 @@ func joinRoomViaPost(t *testing.T, h *Handler, router *chi.Mux, roomCode, player
 	return w
 }
 
-func setupTestRouter(h *Handler) *chi.Mux {
-	r := chi.NewRouter()
-
-	// Room routes
-	r.Post("/room/create", h.CreateRoom)
-	r.Get("/room/{code}", h.JoinRoom)
-	r.Post("/join-room", h.JoinRoomPost) // New POST endpoint
-	r.Post("/room/{code}/leave", h.LeaveRoom)
-	r.Post("/room/{code}/start", h.StartGame)
-
-	// SSE routes
-	r.Get("/sse/lobby/{code}", h.StreamLobby)
-	r.Get("/sse/game/{code}", h.StreamGame)
-
-	// Game routes
-	r.Get("/game/{code}", h.GamePage)
-
-	return r
-}
-
 func TestJoinFlowBrowserBackButton(t *testing.T) {
 	h := newTestHandler()
-	testRouter := setupTestRouter(h)
+	testRouter := newTestRouter(h)
 
 	// Create a room
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Alice"))
+	r := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Alice&form_elapsed=5000"))
 	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	h.CreateRoom(w, r)
 
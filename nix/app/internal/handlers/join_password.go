@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"treacherest/internal/game"
+)
+
+// joinTokenCookie carries a private room's QR join token from the scanned
+// link to the join form
+func joinTokenCookie(roomCode string) string {
+	return "join_" + roomCode
+}
+
+// spectateCookie carries the pass that lets a browser which gave a private
+// room's password watch it
+func spectateCookie(roomCode string) string {
+	return "spectate_" + roomCode
+}
+
+// joinPasswordFromForm reads a room password field; surrounding spaces are
+// dropped, as phone keyboards like to add them
+func joinPasswordFromForm(r *http.Request, field string) (string, error) {
+	password := strings.TrimSpace(r.FormValue(field))
+	if utf8.RuneCountInString(password) > game.MaxJoinPasswordLength {
+		return "", game.ErrJoinPasswordTooLong
+	}
+	return password, nil
+}
+
+// rememberJoinToken keeps the QR join token in the link that brought the
+// browser to a private room, reporting whether there was a live one
+func (h *Handler) rememberJoinToken(w http.ResponseWriter, r *http.Request, room *game.Room) bool {
+	token := r.URL.Query().Get("join")
+	if token == "" || !room.HasJoinToken(token) {
+		return false
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     joinTokenCookie(room.Code),
+		Value:    token,
+		Path:     "/",
+		MaxAge:   3600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return true
+}
+
+// joinTokenFrom returns the browser's QR join token for room, if it is
+// still unused
+func joinTokenFrom(r *http.Request, room *game.Room) string {
+	cookie, err := r.Cookie(joinTokenCookie(room.Code))
+	if err != nil || !room.HasJoinToken(cookie.Value) {
+		return ""
+	}
+	return cookie.Value
+}
+
+// hasInviteTo reports whether the browser followed an unused emailed invite
+// to room; the Room Operator sending it stands in for the password
+func hasInviteTo(r *http.Request, room *game.Room) bool {
+	cookie, err := r.Cookie(inviteCookie)
+	if err != nil {
+		return false
+	}
+	invite, ok := room.FindInvite(cookie.Value)
+	return ok && !invite.Joined()
+}
+
+// needsJoinPassword reports whether the browser must type room's password to
+// join: the room is private and it came by neither a QR token nor an invite
+func needsJoinPassword(r *http.Request, room *game.Room) bool {
+	return room.HasJoinPassword() && joinTokenFrom(r, room) == "" && !hasInviteTo(r, room)
+}
+
+// admitToPrivateRoom checks a join form against room's password. It returns
+// the QR join token to spend once the player is seated, if that is what let
+// them in.
+func admitToPrivateRoom(r *http.Request, room *game.Room) (string, error) {
+	if !room.HasJoinPassword() {
+		return "", nil
+	}
+	if token := joinTokenFrom(r, room); token != "" {
+		return token, nil
+	}
+	if hasInviteTo(r, room) {
+		return "", nil
+	}
+	password, err := joinPasswordFromForm(r, "join_password")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", game.ErrJoinPasswordRequired
+	}
+	if !room.JoinPasswordMatches(password) {
+		return "", game.ErrJoinPasswordMismatch
+	}
+	return "", nil
+}
+
+// spendJoinToken uses up the QR join token a player was seated with, so the
+// QR code on screen moves on to a fresh one
+func spendJoinToken(w http.ResponseWriter, room *game.Room, token string) {
+	if token == "" {
+		return
+	}
+	room.UseJoinToken(token)
+	http.SetCookie(w, &http.Cookie{Name: joinTokenCookie(room.Code), Path: "/", MaxAge: -1})
+}
+
+// canSpectate reports whether the browser may watch room without a seat: the
+// room is open, or the browser holds what would let it join — a QR token, an
+// invite or the pass from giving the password on the spectate page
+func canSpectate(r *http.Request, room *game.Room) bool {
+	if !room.HasJoinPassword() || joinTokenFrom(r, room) != "" || hasInviteTo(r, room) {
+		return true
+	}
+	cookie, err := r.Cookie(spectateCookie(room.Code))
+	return err == nil && room.SpectatePassMatches(cookie.Value)
+}
+
+// admitSpectator checks a spectate form against room's password and, when
+// it matches, keeps the pass that lets the browser watch
+func admitSpectator(w http.ResponseWriter, r *http.Request, room *game.Room) error {
+	password, err := joinPasswordFromForm(r, "join_password")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return game.ErrJoinPasswordRequired
+	}
+	if !room.JoinPasswordMatches(password) {
+		return game.ErrJoinPasswordMismatch
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     spectateCookie(room.Code),
+		Value:    room.SpectatePass(),
+		Path:     "/",
+		MaxAge:   86400,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+// createPrivateRoom creates a room as Alice with the join password password
+func createPrivateRoom(t *testing.T, router http.Handler, password string) *testkit.Client {
+	t.Helper()
+	operator := testkit.NewClient(t, router)
+	w := operator.Post("/room/new", url.Values{"playerName": {"Alice"}, "joinPassword": {password}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("create room: expected 303, got %d: %s", w.Code, w.Body.String())
+	}
+	operator.RoomCode = strings.TrimPrefix(w.Header().Get("Location"), "/room/")
+	return operator
+}
+
+func TestPrivateRoomTakesItsPassword(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	operator := createPrivateRoom(t, router, "swordfish")
+	roomCode := operator.RoomCode
+
+	stranger := testkit.NewClient(t, router)
+	w := stranger.Get("/room/" + roomCode)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `name="join_password"`) {
+		t.Fatalf("expected the join page to ask for the password, got %d", w.Code)
+	}
+	for _, password := range []string{"", "marlin"} {
+		w = stranger.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Mallory"}, "join_password": {password}, components.BotElapsedField: {"5000"}})
+		if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `name="join_password"`) {
+			t.Errorf("expected password %q refused with the prompt again, got %d", password, w.Code)
+		}
+	}
+	if w := stranger.Get("/room/" + roomCode + "/qr.png"); w.Code != http.StatusNotFound {
+		t.Errorf("expected a stranger not to get the room's QR code, got %d", w.Code)
+	}
+
+	w = stranger.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Bob"}, "join_password": {" swordfish "}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the password to let Bob in, got %d: %s", w.Code, w.Body.String())
+	}
+	room, _ := h.store.GetRoom(roomCode)
+	if len(room.Players) != 2 {
+		t.Errorf("expected Alice and Bob seated, got %d players", len(room.Players))
+	}
+}
+
+func TestPrivateRoomQRCodeLetsOnePlayerIn(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	operator := createPrivateRoom(t, router, "swordfish")
+	roomCode := operator.RoomCode
+
+	if w := operator.Get("/room/" + roomCode + "/qr.png"); w.Code != http.StatusOK {
+		t.Fatalf("expected the Room Operator's QR code, got %d", w.Code)
+	}
+	room, _ := h.store.GetRoom(roomCode)
+	if len(room.JoinTokens) != 1 {
+		t.Fatalf("expected the QR code to carry a join token, got %d", len(room.JoinTokens))
+	}
+	token := room.JoinTokens[0]
+
+	scanner := testkit.NewClient(t, router)
+	if w := scanner.Get("/room/" + roomCode + "?join=" + token); w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/room/"+roomCode {
+		t.Fatalf("expected the token kept and dropped from the address, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	if w := scanner.Get("/room/" + roomCode); strings.Contains(w.Body.String(), `name="join_password"`) {
+		t.Error("expected no password prompt for a scanned QR code")
+	}
+	if w := scanner.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Bob"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the QR token to let Bob in, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.HasJoinToken(token) || room.UsedJoinTokens() != 1 {
+		t.Error("expected the token spent")
+	}
+
+	// A second browser that scanned the same code is too late
+	late := testkit.NewClient(t, router)
+	late.SetCookie(&http.Cookie{Name: joinTokenCookie(roomCode), Value: token})
+	if w := late.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Carol"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusForbidden {
+		t.Errorf("expected a spent token refused, got %d", w.Code)
+	}
+	if w := late.Get("/room/" + roomCode + "?join=" + token); w.Code != http.StatusOK {
+		t.Errorf("expected a spent token link to show the join page, got %d", w.Code)
+	}
+
+	// Bob, now seated, can show the code to the next player
+	bob := scanner
+	if w := bob.Get("/room/" + roomCode + "/qr.png"); w.Code != http.StatusOK {
+		t.Errorf("expected a seated player's QR code, got %d", w.Code)
+	}
+}
+
+func TestOpenRoomQRCodeCarriesNoToken(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+
+	stranger := testkit.NewClient(t, router)
+	if w := stranger.Get("/room/" + operator.RoomCode + "/qr.png"); w.Code != http.StatusOK {
+		t.Fatalf("expected an open room's QR code served to anyone, got %d", w.Code)
+	}
+	room, _ := h.store.GetRoom(operator.RoomCode)
+	if len(room.JoinTokens) != 0 {
+		t.Errorf("expected no join tokens for an open room, got %d", len(room.JoinTokens))
+	}
+}
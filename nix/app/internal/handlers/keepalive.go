@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"time"
+
+	"treacherest/internal/clock"
+)
+
+const (
+	// Intervals used when the config leaves them unset
+	defaultSSEKeepalive      = 15 * time.Second
+	defaultSSEKeepaliveFloor = 5 * time.Second
+
+	// A client report with this many reconnects suggests something between it
+	// and the server cuts idle streams
+	keepaliveReconnectThreshold = 3
+
+	// Each sign of trouble halves a room's keepalive interval, at most this
+	// many times, and a quiet stretch this long undoes one halving
+	maxKeepaliveShortening = 3
+	keepaliveRelaxAfter    = 30 * time.Minute
+)
+
+// keepaliveTrouble records a failed keepalive or a reconnect-heavy report for
+// roomCode, shortening the room's keepalive interval
+func (t *sseTelemetry) keepaliveTrouble(roomCode string, failed bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.rooms[roomCode]
+	if stats == nil {
+		stats = &RoomSSETelemetry{Browsers: make(map[string]int)}
+		t.rooms[roomCode] = stats
+	}
+	if failed {
+		stats.KeepaliveFailures++
+	}
+	stats.KeepaliveShortened = min(stats.KeepaliveShortened+1, maxKeepaliveShortening)
+	stats.lastKeepaliveTrouble = now
+}
+
+// keepaliveShortening is how many times roomCode's keepalive interval is
+// halved now, undoing a halving once the room has been quiet for a while
+func (t *sseTelemetry) keepaliveShortening(roomCode string, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.rooms[roomCode]
+	if stats == nil {
+		return 0
+	}
+	if stats.KeepaliveShortened > 0 && now.Sub(stats.lastKeepaliveTrouble) >= keepaliveRelaxAfter {
+		stats.KeepaliveShortened--
+		stats.lastKeepaliveTrouble = now
+	}
+	return stats.KeepaliveShortened
+}
+
+// keepaliveInterval is how often streams in roomCode send a keepalive: the
+// configured interval, halved for rooms whose clients keep dropping, but
+// never below the configured floor
+func (h *Handler) keepaliveInterval(roomCode string) time.Duration {
+	base := h.config().Server.SSEKeepalive
+	if base <= 0 {
+		base = defaultSSEKeepalive
+	}
+	floor := h.config().Server.SSEKeepaliveFloor
+	if floor <= 0 {
+		floor = defaultSSEKeepaliveFloor
+	}
+	floor = min(floor, base)
+
+	interval := base >> h.telemetry.keepaliveShortening(roomCode, h.clock.Now())
+	return max(interval, floor)
+}
+
+// keepaliveTicker fires when a stream should send its next keepalive, and
+// follows the room's interval as it changes
+type keepaliveTicker struct {
+	h        *Handler
+	roomCode string
+	interval time.Duration
+	ticker   clock.Ticker
+}
+
+func (h *Handler) newKeepaliveTicker(roomCode string) *keepaliveTicker {
+	interval := h.keepaliveInterval(roomCode)
+	return &keepaliveTicker{h: h, roomCode: roomCode, interval: interval, ticker: h.clock.NewTicker(interval)}
+}
+
+func (k *keepaliveTicker) C() <-chan time.Time { return k.ticker.C() }
+
+func (k *keepaliveTicker) Stop() { k.ticker.Stop() }
+
+// sent is called after each keepalive, and restarts the ticker if the room's
+// interval has changed since
+func (k *keepaliveTicker) sent() {
+	if interval := k.h.keepaliveInterval(k.roomCode); interval != k.interval {
+		k.ticker.Stop()
+		k.interval = interval
+		k.ticker = k.h.clock.NewTicker(interval)
+	}
+}
+
+// failed records a keepalive that could not be written, so the room's
+// streams, including this client's reconnect, keep alive more often
+func (k *keepaliveTicker) failed() {
+	k.h.telemetry.keepaliveTrouble(k.roomCode, true, k.h.clock.Now())
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestKeepaliveIntervalShortensForTroubledRooms(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	room, _ := h.store.CreateRoom()
+	quiet, _ := h.store.CreateRoom()
+
+	if got := h.keepaliveInterval(room.Code); got != 15*time.Second {
+		t.Fatalf("expected the configured 15s to start with, got %v", got)
+	}
+
+	// A client that had to reconnect again and again
+	h.ReportSSETelemetry(httptest.NewRecorder(), telemetryRequest(url.Values{"room": {room.Code}, "reconnects": {"4"}}, ""))
+	if got := h.keepaliveInterval(room.Code); got != 7500*time.Millisecond {
+		t.Errorf("expected a reconnect-heavy report to halve the interval, got %v", got)
+	}
+
+	ticker := h.newKeepaliveTicker(room.Code)
+	defer ticker.Stop()
+	ticker.failed()
+	ticker.failed()
+	if got := h.keepaliveInterval(room.Code); got != 5*time.Second {
+		t.Errorf("expected failed keepalives to stop at the 5s floor, got %v", got)
+	}
+	ticker.sent()
+	if ticker.interval != 5*time.Second {
+		t.Errorf("expected the ticker to follow the room's interval, got %v", ticker.interval)
+	}
+	if got := h.keepaliveInterval(quiet.Code); got != 15*time.Second {
+		t.Errorf("expected other rooms to keep the configured interval, got %v", got)
+	}
+
+	stats := h.telemetry.snapshot(func(string) bool { return true })[room.Code]
+	if stats.KeepaliveFailures != 2 || stats.KeepaliveShortened != 3 {
+		t.Errorf("expected the failures and shortening in telemetry, got %+v", stats)
+	}
+
+	fake.Advance(keepaliveRelaxAfter)
+	if got := h.keepaliveInterval(room.Code); got != 5*time.Second {
+		t.Errorf("expected one quiet stretch to undo only one halving, got %v", got)
+	}
+	fake.Advance(keepaliveRelaxAfter)
+	fake.Advance(keepaliveRelaxAfter)
+	h.keepaliveInterval(room.Code)
+	fake.Advance(keepaliveRelaxAfter)
+	if got := h.keepaliveInterval(room.Code); got != 15*time.Second {
+		t.Errorf("expected a quiet room to return to the configured interval, got %v", got)
+	}
+}
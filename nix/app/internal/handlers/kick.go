@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+)
+
+// KickPlayer removes a player from the lobby; they can join again
+func (h *Handler) KickPlayer(w http.ResponseWriter, r *http.Request) {
+	h.removePlayer(w, r, false)
+}
+
+// BanPlayer removes a player from the lobby and keeps their browser from
+// joining the room again
+func (h *Handler) BanPlayer(w http.ResponseWriter, r *http.Request) {
+	h.removePlayer(w, r, true)
+}
+
+// removePlayer gives up a lobby player's seat for the Room Operator. The
+// player's stream sends them to the join page, which clears their player
+// cookie and says they were removed.
+func (h *Handler) removePlayer(w http.ResponseWriter, r *http.Request, ban bool) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	target, err := room.RemovalTarget(chi.URLParam(r, "playerID"))
+	if errors.Is(err, game.ErrGameAlreadyStarted) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ban {
+		h.record(room, game.PlayerBannedEvent(target))
+	} else {
+		h.record(room, game.PlayerLeftEvent(target.ID))
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🚪 Room Operator of room %s removed player %s (banned: %t)", room.Code, target.ID, ban)
+
+	h.eventBus.Publish(Event{
+		Type:     EventPlayerRemoved,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sendRemoved sends a removed player's page to the join page, which tells
+// them why
+func sendRemoved(sse *datastar.ServerSentEventGenerator, roomCode string) {
+	sse.ExecuteScript("window.location.href = '/room/" + roomCode + "?removed=1'")
+}
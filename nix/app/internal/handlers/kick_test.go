@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func TestKickPlayerSendsThemToTheJoinPage(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	if w := player.Post("/room/"+roomCode+"/kick/"+operator.PlayerID(), url.Values{}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player to be refused, got %d", w.Code)
+	}
+	if w := operator.Post("/room/"+roomCode+"/kick/"+operator.PlayerID(), url.Values{}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected the Room Operator not to remove themselves, got %d", w.Code)
+	}
+
+	stream := player.OpenSSE("/sse/room/" + roomCode)
+	defer stream.Close()
+	time.Sleep(100 * time.Millisecond)
+	if w := operator.Post("/room/"+roomCode+"/kick/"+player.PlayerID(), url.Values{}); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if !stream.WaitFor("?removed=1", 2*time.Second) {
+		t.Fatalf("expected the kicked player sent to the join page, got %s", stream.Data())
+	}
+
+	w := player.Get("/room/" + roomCode + "?removed=1")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "You were removed from this room.") {
+		t.Errorf("expected the join page to say why, got %d", w.Code)
+	}
+	if cookie := player.PlayerCookie(); cookie != nil && cookie.Value != "" {
+		t.Errorf("expected the stale player cookie cleared, got %q", cookie.Value)
+	}
+	testkit.JoinRoom(t, router, roomCode, "Bob")
+}
+
+func TestBannedPlayerCannotRejoin(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	if w := operator.Post("/room/"+roomCode+"/ban/"+player.PlayerID(), url.Values{}); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := player.Get("/room/" + roomCode); w.Code != http.StatusForbidden {
+		t.Errorf("expected the join page to turn the banned browser away, got %d", w.Code)
+	}
+	w := player.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Bobby"}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected a banned browser not to rejoin, got %d", w.Code)
+	}
+	room, _ := h.store.GetRoom(roomCode)
+	if len(room.Players) != 1 {
+		t.Errorf("expected only the Room Operator seated, got %d players", len(room.Players))
+	}
+
+	// The ban is in the journal, so a rebuilt room keeps it
+	rebuilt, err := h.store.Rebuild(roomCode)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt.GetPlayer(player.PlayerID()) != nil || !rebuilt.IsBanned(player.SessionCookie().Value) {
+		t.Error("expected the rebuilt room to have Bob unseated and banned")
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"treacherest/internal/logging"
+)
+
+// requestLogger is the handler's logger with the request's ID and, on room
+// routes, the room code attached, so one request's lines can be found
+// together
+func (h *Handler) requestLogger(r *http.Request) *slog.Logger {
+	logger := h.baseLogger()
+	if id := middleware.GetReqID(r.Context()); id != "" {
+		logger = logger.With(logging.KeyRequestID, id)
+	}
+	if code := chi.URLParam(r, "code"); code != "" {
+		logger = logger.With(logging.KeyRoom, code)
+	}
+	return logger
+}
+
+// roomLogger is the handler's logger for work on a room outside a request
+func (h *Handler) roomLogger(code string) *slog.Logger {
+	return h.baseLogger().With(logging.KeyRoom, code)
+}
+
+// baseLogger is the handler's logger, or slog's default for a Handler that
+// was not built by New
+func (h *Handler) baseLogger() *slog.Logger {
+	if h.logger == nil {
+		return slog.Default()
+	}
+	return h.logger
+}
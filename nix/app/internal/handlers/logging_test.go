@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+
+	"treacherest/internal/logging"
+)
+
+func TestRoleConfigLogsCarryRequestIDAndRoom(t *testing.T) {
+	h := newTestHandler()
+	var out bytes.Buffer
+	h.logger = logging.NewTo(&out, "info", "json")
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+
+	req := httptest.NewRequest("POST", "/room/"+room.Code+"/config/hide-distribution", strings.NewReader(`{"hideRoleDistribution":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("expected JSON log lines, got %q: %v", line, err)
+		}
+		if record["msg"] != "hide role distribution setting changed" {
+			continue
+		}
+		found = true
+		if record[logging.KeyRoom] != room.Code || record[logging.KeyRequestID] == nil || record["to"] != true {
+			t.Errorf("expected the room, request ID and new value as attributes, got %v", record)
+		}
+	}
+	if !found {
+		t.Fatalf("expected the setting change to be logged, got %s", out.String())
+	}
+}
+
+func TestDebugLogsFollowTheConfiguredLevel(t *testing.T) {
+	h := newTestHandler()
+	var out bytes.Buffer
+	h.logger = logging.NewTo(&out, "info", "text")
+	room, player := newPhaseTestRoom(t, h)
+
+	h.renderGame(datastar.NewSSE(httptest.NewRecorder(), httptest.NewRequest("GET", "/sse/room/"+room.Code, nil)), room, player)
+	if strings.Contains(out.String(), "sent game update") {
+		t.Errorf("expected debug records to be dropped at info, got %s", out.String())
+	}
+
+	h.logger = logging.NewTo(&out, "debug", "text")
+	h.renderGame(datastar.NewSSE(httptest.NewRecorder(), httptest.NewRequest("GET", "/sse/room/"+room.Code, nil)), room, player)
+	if !strings.Contains(out.String(), "sent game update") || !strings.Contains(out.String(), "room="+room.Code) {
+		t.Errorf("expected the debug record with its room, got %s", out.String())
+	}
+}
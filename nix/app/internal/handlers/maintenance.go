@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/clock"
+	"treacherest/internal/views/components"
+	"treacherest/internal/views/pages"
+)
+
+// defaultMaintenanceMessage is shown when the admin does not supply one
+const defaultMaintenanceMessage = "We're doing some quick maintenance. Games in progress keep running; new rooms will be back soon."
+
+// MaintenanceState is the server-wide maintenance flag. It is kept apart from
+// ServerConfig so reloading the config never flips it.
+type MaintenanceState struct {
+	Enabled bool      `json:"enabled"`
+	Message string    `json:"message,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+}
+
+// maintenanceMode guards the current state and mirrors it to path, if set
+type maintenanceMode struct {
+	mu    sync.RWMutex
+	state MaintenanceState
+	path  string
+	clock clock.Clock
+}
+
+// newMaintenanceMode restores the state saved at path, so a deploy script's
+// toggle outlives the process it was sent to
+func newMaintenanceMode(path string) *maintenanceMode {
+	m := &maintenanceMode{path: path, clock: clock.Real()}
+	if path == "" {
+		return m
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m
+	}
+	if err != nil {
+		log.Printf("⚠️ Failed to read maintenance state %s: %v", path, err)
+		return m
+	}
+	if err := json.Unmarshal(data, &m.state); err != nil {
+		log.Printf("⚠️ Ignoring unreadable maintenance state %s: %v", path, err)
+		return m
+	}
+	if m.state.Enabled {
+		log.Printf("🚧 Maintenance mode restored from %s", path)
+	}
+	return m
+}
+
+// State returns a copy of the current state
+func (m *maintenanceMode) State() MaintenanceState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Set replaces the state and saves it. The in-memory state changes even when
+// saving fails, so the toggle still takes effect on this instance.
+func (m *maintenanceMode) Set(enabled bool, message string) (MaintenanceState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !enabled {
+		m.state = MaintenanceState{}
+	} else {
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		since := m.state.Since
+		if !m.state.Enabled {
+			since = m.clock.Now()
+		}
+		m.state = MaintenanceState{Enabled: true, Message: message, Since: since}
+	}
+
+	if m.path == "" {
+		return m.state, nil
+	}
+	data, err := json.Marshal(m.state)
+	if err != nil {
+		return m.state, err
+	}
+	return m.state, os.WriteFile(m.path, data, 0o644)
+}
+
+// GetMaintenance reports the maintenance state to an admin
+func (h *Handler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+	writeAdminJSON(w, h.maintenance.State())
+}
+
+// SetMaintenance turns maintenance mode on or off. New rooms are refused while
+// it is on; running games continue and every connected client gets a banner.
+func (h *Handler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r) {
+		return
+	}
+
+	enabled, err := strconv.ParseBool(r.FormValue("enabled"))
+	if err != nil {
+		http.Error(w, "enabled must be true or false", http.StatusBadRequest)
+		return
+	}
+
+	state, err := h.maintenance.Set(enabled, r.FormValue("message"))
+	if err != nil {
+		log.Printf("⚠️ Failed to save maintenance state: %v", err)
+	}
+	log.Printf("🚧 Maintenance mode enabled=%v", state.Enabled)
+
+	h.eventBus.Broadcast(Event{Type: EventMaintenanceUpdated})
+	writeAdminJSON(w, state)
+}
+
+// rejectIfMaintenance serves the "back soon" page instead of creating a room
+func (h *Handler) rejectIfMaintenance(w http.ResponseWriter, r *http.Request) bool {
+	state := h.maintenance.State()
+	if !state.Enabled {
+		return false
+	}
+
+	w.Header().Set("Retry-After", "300")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	pages.MaintenancePage(state.Message).Render(r.Context(), w)
+	return true
+}
+
+// patchMaintenanceBanner shows or clears the banner on a connected page
+func (h *Handler) patchMaintenanceBanner(sse *datastar.ServerSentEventGenerator, page PageType) {
+	state := h.maintenance.State()
+	html := renderToString(components.MaintenanceBanner(state.Enabled, state.Message))
+	if err := h.patchElements(sse, page, html, "#maintenance-banner"); err != nil {
+		log.Printf("❌ Failed to patch maintenance banner: %v", err)
+	}
+}
+
+// sendInitialMaintenanceBanner shows the banner to a client that connects mid-maintenance
+func (h *Handler) sendInitialMaintenanceBanner(sse *datastar.ServerSentEventGenerator, page PageType) {
+	if h.maintenance.State().Enabled {
+		h.patchMaintenanceBanner(sse, page)
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"treacherest/internal/views/components"
+)
+
+func maintenanceRequest(token string, form url.Values) *http.Request {
+	req := httptest.NewRequest("POST", "/admin/maintenance", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestMaintenanceEndpointsRequireAdminToken(t *testing.T) {
+	h := newTestHandler()
+	form := url.Values{"enabled": {"true"}}
+
+	w := httptest.NewRecorder()
+	h.SetMaintenance(w, maintenanceRequest("secret", form))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without a configured admin token, got %d", w.Code)
+	}
+
+	h.SetAdminToken("secret")
+	w = httptest.NewRecorder()
+	h.SetMaintenance(w, maintenanceRequest("wrong", form))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a wrong token, got %d", w.Code)
+	}
+	if h.maintenance.State().Enabled {
+		t.Error("maintenance mode should not change without a valid token")
+	}
+}
+
+func TestMaintenanceModeBlocksNewRoomsAndNotifiesStreams(t *testing.T) {
+	h := newTestHandler()
+	h.SetAdminToken("secret")
+
+	existing, _ := h.store.CreateRoom()
+	events := h.eventBus.Subscribe(existing.Code)
+	defer h.eventBus.Unsubscribe(existing.Code, events)
+
+	w := httptest.NewRecorder()
+	h.SetMaintenance(w, maintenanceRequest("secret", url.Values{"enabled": {"true"}, "message": {"Deploying v2"}}))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != "maintenance_updated" || event.RoomCode != existing.Code {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a maintenance_updated event for the running room")
+	}
+
+	w = httptest.NewRecorder()
+	h.CreateRoom(w, httptest.NewRequest("POST", "/room/new", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while in maintenance, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Deploying v2") {
+		t.Error("expected the back soon page to show the maintenance message")
+	}
+	if _, err := h.store.GetRoom(existing.Code); err != nil {
+		t.Errorf("existing room should keep running: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	h.SetMaintenance(w, maintenanceRequest("secret", url.Values{"enabled": {"false"}}))
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/room/new", strings.NewReader(components.BotElapsedField+"=5000"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	h.CreateRoom(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Errorf("expected room creation to resume, got %d", w.Code)
+	}
+}
+
+func TestMaintenanceStatePersistsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maintenance.json")
+
+	m := newMaintenanceMode(path)
+	if _, err := m.Set(true, "Back at 10"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := newMaintenanceMode(path).State()
+	if !restored.Enabled || restored.Message != "Back at 10" {
+		t.Errorf("expected persisted state, got %+v", restored)
+	}
+}
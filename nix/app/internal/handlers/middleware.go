@@ -9,6 +9,8 @@ import (
 // allowedSSEParams defines the whitelist of allowed query parameters for SSE endpoints
 var allowedSSEParams = map[string]bool{
 	"datastar": true, // Datastar automatically sends this with client state
+	"token":    true, // Read-only overlay access token
+	"view":     true, // Which page a room stream serves
 }
 
 // allowedDatastarSignals defines all valid signal names that can appear in the datastar parameter
@@ -83,6 +85,16 @@ func ValidateSSERequest(next http.HandlerFunc) http.HandlerFunc {
 
 			// Additional validation for known parameters
 			switch key {
+			case "token":
+				if len(values) != 1 || len(values[0]) > 64 {
+					http.Error(w, "Invalid token parameter", http.StatusBadRequest)
+					return
+				}
+			case "view":
+				if len(values) != 1 || (values[0] != "host" && values[0] != "lobby" && values[0] != "game") {
+					http.Error(w, "Invalid view parameter", http.StatusBadRequest)
+					return
+				}
 			case "datastar":
 				// Datastar should only have one value
 				if len(values) != 1 {
@@ -21,9 +21,9 @@ func TestDismissModal(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room and player
 	room, _ := memStore.CreateRoom()
@@ -177,9 +177,9 @@ func TestRestoreModal(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room and player
 	room, _ := memStore.CreateRoom()
@@ -273,9 +273,9 @@ func TestModalEventPublishing(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room and player
 	room, _ := memStore.CreateRoom()
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+// SaveNotes auto-saves the effective player's private scratchpad.
+// No event is published: nobody else renders these notes, and re-rendering
+// the author's own page mid-typing would only fight the textarea.
+func (h *Handler) SaveNotes(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+
+	if err := room.SetPlayerNotes(player.ID, r.FormValue("notes")); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, game.ErrPlayerNotFound) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	w.WriteHeader(http.StatusNoContent)
+}
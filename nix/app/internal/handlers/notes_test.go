@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestSaveNotes(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	room.State = game.StatePlaying
+	notesPath := "/room/" + room.Code + "/notes"
+	alice := seatedClient(t, router, room.Code, player.ID)
+
+	if w := testkit.NewClient(t, router).Post(notesPath, url.Values{"notes": {"sneaky"}}); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected anonymous save to be rejected, got %d", w.Code)
+	}
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+
+	if w := alice.Post(notesPath, url.Values{"notes": {"Bob is sus"}}); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.GetPlayer(player.ID).Notes != "Bob is sus" {
+		t.Fatalf("expected notes to be stored, got %q", room.GetPlayer(player.ID).Notes)
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("saving notes should not broadcast, got %s", ev.Type)
+	default:
+	}
+
+	if w := alice.Post(notesPath, url.Values{"notes": {strings.Repeat("x", game.MaxNotesLength+1)}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected oversized notes to be rejected, got %d", w.Code)
+	}
+}
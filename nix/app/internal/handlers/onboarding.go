@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+)
+
+// onboardingForgetAfter is how long an identity's onboarding record outlives
+// its last visit: the session cookie's own lifetime
+const onboardingForgetAfter = 7 * 24 * time.Hour
+
+// onboarding remembers, per session, the room of its first game and whether
+// it turned hints off. A session with no game behind it gets first-game hints
+// through that game; later rooms don't show them.
+type onboarding struct {
+	mu        sync.Mutex
+	sessions  map[string]*onboardingRecord
+	lastSweep time.Time
+}
+
+type onboardingRecord struct {
+	firstRoom string // room of the session's first game; empty before it starts
+	hintsOff  bool
+	shown     map[string]bool // room code + moment of the hints already sent
+	seenAt    time.Time
+}
+
+func newOnboarding() *onboarding {
+	return &onboarding{sessions: make(map[string]*onboardingRecord)}
+}
+
+// record returns sessionID's record, creating it, and drops records not seen
+// for onboardingForgetAfter. The caller holds o.mu.
+func (o *onboarding) record(sessionID string, now time.Time) *onboardingRecord {
+	if now.Sub(o.lastSweep) >= time.Hour {
+		o.lastSweep = now
+		for id, rec := range o.sessions {
+			if now.Sub(rec.seenAt) >= onboardingForgetAfter {
+				delete(o.sessions, id)
+			}
+		}
+	}
+	rec, ok := o.sessions[sessionID]
+	if !ok {
+		rec = &onboardingRecord{shown: make(map[string]bool)}
+		o.sessions[sessionID] = rec
+	}
+	rec.seenAt = now
+	return rec
+}
+
+// takeHint reports whether sessionID should get moment's hint in roomCode,
+// marking it sent. Only a session whose first game is this room (or hasn't
+// started) gets hints, each once per room, unless it turned them off.
+func (o *onboarding) takeHint(sessionID, roomCode string, moment components.HintMoment, gameStarted bool, now time.Time) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	rec := o.record(sessionID, now)
+	if rec.hintsOff || rec.firstRoom != "" && rec.firstRoom != roomCode {
+		return false
+	}
+	if gameStarted {
+		rec.firstRoom = roomCode
+	}
+	key := roomCode + "/" + string(moment)
+	if rec.shown[key] {
+		return false
+	}
+	rec.shown[key] = true
+	return true
+}
+
+// markPlayed records that sessionID has a game behind it, so it never counts
+// as new. Only a session with no first game yet takes roomCode as its first.
+func (o *onboarding) markPlayed(sessionID, roomCode string, now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if rec := o.record(sessionID, now); rec.firstRoom == "" {
+		rec.firstRoom = roomCode
+	}
+}
+
+func (o *onboarding) turnOff(sessionID string, now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.record(sessionID, now).hintsOff = true
+}
+
+// playedRoom returns a room, other than except, where sessionID holds a seat
+// in a started game: the session has played before even if this server never
+// saw its onboarding record. It returns "" for none.
+func (h *Handler) playedRoom(sessionID, except string) string {
+	for _, room := range h.store.Rooms() {
+		if room.Code == except {
+			continue
+		}
+		room.RLock()
+		started := room.State != game.StateLobby
+		room.RUnlock()
+		if !started {
+			continue
+		}
+		for _, p := range room.GetPlayers() {
+			if p.SessionID == sessionID && !p.IsHost {
+				return room.Code
+			}
+		}
+	}
+	return ""
+}
+
+// sendOnboardingHint pushes moment's hint to a first-time player's stream.
+// The caller holds the room's read lock.
+func (h *Handler) sendOnboardingHint(sse *datastar.ServerSentEventGenerator, page PageType, r *http.Request, room *game.Room, player *game.Player, moment components.HintMoment) {
+	sessionID, ok := h.sessionID(r)
+	if !ok || player == nil || player.IsHost {
+		return
+	}
+	now := h.clock.Now()
+	if played := h.playedRoom(sessionID, room.Code); played != "" {
+		h.onboarding.markPlayed(sessionID, played, now)
+		return
+	}
+	if !h.onboarding.takeHint(sessionID, room.Code, moment, room.State != game.StateLobby, now) {
+		return
+	}
+	log.Printf("💡 Sending the %s hint to %s in room %s", moment, player.Name, room.Code)
+	html := renderFragment(components.OnboardingHint(moment, room, player), "#onboarding-hint", room.Code)
+	h.patchElements(sse, page, html, "#onboarding-hint")
+}
+
+// sendGameHint pushes the hint for the game's current moment: the countdown,
+// or the role once it's dealt. The caller holds the room's read lock.
+func (h *Handler) sendGameHint(sse *datastar.ServerSentEventGenerator, r *http.Request, room *game.Room, player *game.Player) {
+	switch room.State {
+	case game.StateCountdown:
+		h.sendOnboardingHint(sse, PageGame, r, room, player, components.HintCountdown)
+	case game.StatePlaying:
+		h.sendOnboardingHint(sse, PageGame, r, room, player, components.HintRoleDealt)
+	}
+}
+
+// TurnOffHints stops first-game hints for the caller's session and clears
+// the one on screen
+func (h *Handler) TurnOffHints(w http.ResponseWriter, r *http.Request) {
+	if sessionID, ok := h.sessionID(r); ok {
+		h.onboarding.turnOff(sessionID, h.clock.Now())
+	}
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElements(renderFragment(components.OnboardingHintSlot(), "#onboarding-hint", ""), datastar.WithSelector("#onboarding-hint"))
+}
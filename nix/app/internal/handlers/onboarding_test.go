@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func TestFirstTimePlayerGetsTheLobbyHint(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.JoinRoom(t, router, host.RoomCode, "Alice")
+
+	lobby := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	if !lobby.WaitFor(`data-hint="lobby"`, 2*time.Second) {
+		t.Fatalf("expected a first-time player to get the lobby hint, got %s", lobby.Data())
+	}
+	lobby.Close()
+
+	// Once per room: a reconnect doesn't repeat it
+	again := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer again.Close()
+	if again.WaitFor(`data-hint=`, 200*time.Millisecond) {
+		t.Fatalf("expected the hint only once, got %s", again.Data())
+	}
+}
+
+func TestExperiencedPlayerGetsNoHints(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	earlier := testkit.CreateRoom(t, router, "Host", true)
+	veteran := testkit.JoinRoom(t, router, earlier.RoomCode, "Alice")
+	played, _ := h.store.GetRoom(earlier.RoomCode)
+	played.Lock()
+	played.State = game.StatePlaying
+	played.Unlock()
+
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.NewClient(t, router)
+	alice.SetCookie(veteran.SessionCookie())
+	alice.RoomCode = host.RoomCode
+	if w := alice.Post("/join-room", url.Values{"room_code": {host.RoomCode}, "player_name": {"Alice"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the join to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	lobby := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer lobby.Close()
+	if lobby.WaitFor(`data-hint=`, 200*time.Millisecond) {
+		t.Fatalf("expected a player with a game behind them to get no hint, got %s", lobby.Data())
+	}
+}
+
+func TestTurningHintsOffStopsThem(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.JoinRoom(t, router, host.RoomCode, "Alice")
+
+	w := alice.Post("/hints/off", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `id="onboarding-hint"`) {
+		t.Fatalf("expected the hint slot cleared, got %d: %s", w.Code, w.Body.String())
+	}
+
+	lobby := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer lobby.Close()
+	if lobby.WaitFor(`data-hint=`, 200*time.Millisecond) {
+		t.Fatalf("expected no hint once turned off, got %s", lobby.Data())
+	}
+}
@@ -3,21 +3,31 @@ package handlers
 import (
 	"net/http"
 	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func (h *Handler) isRoomOperator(r *http.Request, room *game.Room) bool {
 	if room == nil {
 		return false
 	}
-	sessionCookie, err := r.Cookie("session")
-	if err != nil {
-		return false
+	sessionID, ok := h.sessionID(r)
+	return ok && room.IsOperatorSession(sessionID)
+}
+
+// viewerContext is who fragments rendered in response to r are for. Control
+// follows the request's session, like every Room Operator check.
+func (h *Handler) viewerContext(r *http.Request, room *game.Room) components.ViewerContext {
+	viewer := components.ViewerContext{CanControl: h.isRoomOperator(r, room)}
+	if playerCookie, err := r.Cookie("player_" + room.Code); err == nil {
+		viewer.Player = room.GetPlayer(playerCookie.Value)
 	}
-	return room.IsOperatorSession(sessionCookie.Value)
+	return viewer
 }
 
 func (h *Handler) debugControlsEnabled(r *http.Request, room *game.Room) bool {
-	return h.config.Server.DebugModeEnabled && h.isRoomOperator(r, room)
+	return h.config().Server.DebugModeEnabled && h.isRoomOperator(r, room)
 }
 
 func (h *Handler) debugViewedPlayer(room *game.Room) *game.Player {
@@ -68,3 +78,18 @@ func (h *Handler) effectivePlayerForRender(r *http.Request, room *game.Room, fal
 	}
 	return room.GetPlayer(fallback.ID)
 }
+
+// requireRoomOperator loads the room from the URL and rejects callers without Room Operator authority.
+func (h *Handler) requireRoomOperator(w http.ResponseWriter, r *http.Request) (*game.Room, bool) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return nil, false
+	}
+	if !h.isRoomOperator(r, room) {
+		http.Error(w, "Only the Room Operator can do that", http.StatusForbidden)
+		return nil, false
+	}
+	return room, true
+}
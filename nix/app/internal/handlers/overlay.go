@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	localMiddleware "treacherest/internal/middleware"
+	"treacherest/internal/views/pages"
+)
+
+// overlayRoom resolves the room for a tokenized overlay request.
+// Overlay access never depends on cookies; the token in the query string is the only credential.
+func (h *Handler) overlayRoom(w http.ResponseWriter, r *http.Request) (*game.Room, string, bool) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return nil, "", false
+	}
+
+	token := r.URL.Query().Get("token")
+	if !room.IsOverlayToken(token) {
+		http.Error(w, "Invalid overlay token", http.StatusForbidden)
+		return nil, "", false
+	}
+
+	return room, token, true
+}
+
+// OverlayPage renders the read-only streaming overlay for a room
+func (h *Handler) OverlayPage(w http.ResponseWriter, r *http.Request) {
+	room, token, ok := h.overlayRoom(w, r)
+	if !ok {
+		return
+	}
+
+	// Overlays are meant to be embedded by streaming software, but only by
+	// the sites the deployment allows; X-Frame-Options can't list them
+	w.Header().Del("X-Frame-Options")
+	w.Header().Set("Content-Security-Policy", localMiddleware.ContentSecurityPolicy(h.config().Server.ContentSecurityPolicy, h.config().Server.OverlayFrameAncestors))
+	pages.OverlayPage(room, token).Render(r.Context(), w)
+}
+
+// StreamOverlay streams public room state to an overlay without a player session
+func (h *Handler) StreamOverlay(w http.ResponseWriter, r *http.Request) {
+	room, _, ok := h.overlayRoom(w, r)
+	if !ok {
+		return
+	}
+	roomCode := room.Code
+	log.Printf("📺 Overlay SSE connection established for room %s", roomCode)
+
+	sse := datastar.NewSSE(w, r)
+
+	events := h.eventBus.Subscribe(roomCode)
+	defer h.eventBus.Unsubscribe(roomCode, events)
+	h.connTracker.AddViewer(roomCode)
+	defer h.connTracker.RemoveViewer(roomCode)
+
+	room.RLock()
+	h.renderOverlay(sse, room)
+	room.RUnlock()
+
+	heartbeat := h.newKeepaliveTicker(roomCode)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			log.Printf("📺 Overlay SSE context cancelled for room %s", roomCode)
+			return
+		case <-heartbeat.C():
+			if _, err := w.Write([]byte(":\n\n")); err != nil {
+				heartbeat.failed()
+				log.Printf("📺 Keepalive failed for overlay %s: %v - closing connection", roomCode, err)
+				return
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			heartbeat.sent()
+		case event := <-events:
+			room, err := h.store.GetRoom(roomCode)
+			if err != nil {
+				log.Printf("📺 Room %s no longer exists, closing overlay SSE", roomCode)
+				return
+			}
+
+			viewRoom(room, func() bool {
+				switch event.Type {
+				case EventCountdownUpdate:
+					patchCountdown(sse, room.CountdownRemaining)
+				case EventMaintenanceUpdated:
+					// Overlays are shown to stream audiences; keep them banner-free
+				case EventChatPosted:
+					// Table chat is for seated players
+				default:
+					if !knownEventTypes[event.Type] {
+						h.recordUnknownEvent("overlay", event.Type)
+					}
+					h.renderOverlay(sse, room)
+				}
+				return false
+			})
+		}
+	}
+}
+
+// renderOverlay patches the overlay content and countdown signal
+func (h *Handler) renderOverlay(sse *datastar.ServerSentEventGenerator, room *game.Room) {
+	html := renderFragment(pages.OverlayContent(room), "#overlay-content", room.Code)
+	if err := h.patchElements(sse, PageOverlay, html, "#overlay-content"); err != nil {
+		log.Printf("❌ Failed to render overlay for room %s: %v", room.Code, err)
+		return
+	}
+	patchCountdown(sse, room.CountdownRemaining)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+func overlayRequest(method, path, code string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", code)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestOverlayPage_RequiresToken(t *testing.T) {
+	h := newTestHandler()
+	room, _ := h.store.CreateRoom()
+	token := room.EnsureOverlayToken()
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "missing token", query: "", status: http.StatusForbidden},
+		{name: "wrong token", query: "?token=nope", status: http.StatusForbidden},
+		{name: "valid token", query: "?token=" + token, status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.OverlayPage(w, overlayRequest("GET", "/overlay/"+room.Code+tt.query, room.Code))
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOverlayPage_UnknownRoom(t *testing.T) {
+	h := newTestHandler()
+	w := httptest.NewRecorder()
+	h.OverlayPage(w, overlayRequest("GET", "/overlay/NOPE1?token=x", "NOPE1"))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestStreamOverlay_WorksWithoutCookies(t *testing.T) {
+	h := newTestHandler()
+	room, _ := h.store.CreateRoom()
+	token := room.EnsureOverlayToken()
+	room.AddPlayer(game.NewPlayer("p1", "Alice", "s1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := overlayRequest("GET", "/sse/overlay/"+room.Code+"?token="+token, room.Code)
+	req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, chi.RouteContext(req.Context())))
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		h.StreamOverlay(w, req)
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	body := w.Body.String()
+	if !strings.Contains(body, "overlay-content") || !strings.Contains(body, "Alice") {
+		t.Fatalf("expected initial overlay render in stream, got %s", body)
+	}
+}
+
+func TestOverlayRoutes_Registered(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := h.store.CreateRoom()
+	token := room.EnsureOverlayToken()
+
+	req := httptest.NewRequest("GET", "/overlay/"+room.Code+"?token="+token, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w.Header().Get("X-Frame-Options") != "" {
+		t.Error("overlay framing should be left to its content security policy")
+	}
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.HasSuffix(csp, "frame-ancestors 'none'") {
+		t.Errorf("expected overlays to be unframeable by default, got %q", csp)
+	}
+
+	h.config().Server.OverlayFrameAncestors = "https://obs.example"
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.HasSuffix(csp, "frame-ancestors https://obs.example") {
+		t.Errorf("expected the configured frame ancestors, got %q", csp)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.HasSuffix(csp, "frame-ancestors 'none'") {
+		t.Errorf("other pages must stay unframeable, got %q", csp)
+	}
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/a-h/templ"
 	"github.com/go-chi/chi/v5"
+	"log"
 	"net/http"
+	"strings"
+	"treacherest/internal/config"
 	"treacherest/internal/game"
 	"treacherest/internal/views/pages"
 )
@@ -14,17 +18,85 @@ func (h *Handler) Home(w http.ResponseWriter, r *http.Request) {
 	component.Render(r.Context(), w)
 }
 
-// CreateRoom creates a new room and redirects to it
+// About serves the card set attribution and licence notice
+func (h *Handler) About(w http.ResponseWriter, r *http.Request) {
+	var info game.CardSetInfo
+	if h.cardService != nil {
+		info = h.cardService.Info()
+	}
+	component := pages.About(info, h.attribution)
+	component.Render(r.Context(), w)
+}
+
+// Rules shows the rules reference: every role type's win condition by mode
+func (h *Handler) Rules(w http.ResponseWriter, r *http.Request) {
+	ref, err := config.Rules()
+	if err != nil {
+		log.Printf("❌ Failed to load the rules reference: %v", err)
+		http.Error(w, "Rules reference unavailable", http.StatusInternalServerError)
+		return
+	}
+	pages.Rules(ref).Render(r.Context(), w)
+}
+
+// RoleRules shows one role type's rules page, /rules/{role}
+func (h *Handler) RoleRules(w http.ResponseWriter, r *http.Request) {
+	ref, err := config.Rules()
+	if err != nil {
+		log.Printf("❌ Failed to load the rules reference: %v", err)
+		http.Error(w, "Rules reference unavailable", http.StatusInternalServerError)
+		return
+	}
+	role, ok := ref.Role(chi.URLParam(r, "role"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	for _, mode := range ref.Modes {
+		if mode.ID == role.Mode {
+			pages.RoleRulesPage(mode, role).Render(r.Context(), w)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// CreateRoom creates a new room and redirects to it. Host-only creators manage
+// the room without playing and are sent to the host dashboard instead.
 func (h *Handler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	if h.rejectIfMaintenance(w, r) {
+		return
+	}
+	if h.rejectIfSuspectedBot(w, r, "/room/new") {
+		return
+	}
+
 	rulesMode, ok := game.ParseRulesMode(r.FormValue("rulesMode"))
 	if !ok {
 		http.Error(w, "Invalid rules mode", http.StatusBadRequest)
 		return
 	}
 
-	playerName := r.FormValue("playerName")
-	if playerName == "" {
-		playerName = generateRandomName()
+	playerName, err := normalizePlayerName(r.FormValue("playerName"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seatPIN, err := seatPINFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	joinPassword, err := joinPasswordFromForm(r, "joinPassword")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.rejectIfRoomQuotaExceeded(w, r) {
+		return
 	}
 
 	// Check if creating as host only
@@ -36,12 +108,16 @@ func (h *Handler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to create room", http.StatusInternalServerError)
 		return
 	}
+	h.trackRoomLogs(room.Code)
 	room.RulesMode = rulesMode
+	room.SetJoinPassword(joinPassword)
+	h.scopeTenantRoom(r, room)
 
 	// Create player
-	sessionID := getOrCreateSession(w, r)
+	sessionID := h.getOrCreateSession(w, r)
 	room.OperatorSessionID = sessionID
 	player := game.NewPlayer(generatePlayerID(), playerName, sessionID)
+	player.SetSeatPIN(seatPIN)
 
 	// Set host flag if requested
 	if hostOnly {
@@ -49,32 +125,27 @@ func (h *Handler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Add player to room
-	room.AddPlayer(player)
+	if err := h.store.Append(room, game.PlayerJoinedEvent(player)); err != nil {
+		http.Error(w, "Failed to create room", http.StatusInternalServerError)
+		return
+	}
+	h.rememberIdentity(player)
+	room.EnsureCreatorToken()
 	h.store.UpdateRoom(room)
 
 	// Store player ID in session
-	http.SetCookie(w, &http.Cookie{
-		Name:     "player_" + room.Code,
-		Value:    player.ID,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400, // 1 day
-	})
+	setPlayerCookie(w, room.Code, player.ID)
 
 	// If host only, also set a host cookie
 	if hostOnly {
-		http.SetCookie(w, &http.Cookie{
-			Name:     "host_" + room.Code,
-			Value:    "true",
-			Path:     "/",
-			HttpOnly: true,
-			SameSite: http.SameSiteLaxMode,
-			MaxAge:   86400, // 1 day
-		})
+		setHostCookie(w, room.Code)
 	}
 
-	// Redirect to room
+	// Hosts are not playing, so they go straight to the dashboard and its QR code
+	if hostOnly {
+		http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+		return
+	}
 	http.Redirect(w, r, "/room/"+room.Code, http.StatusSeeOther)
 }
 
@@ -91,6 +162,13 @@ func (h *Handler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Host-only browsers belong on /host/{code}, which also offers recovery
+	// when the host cookie outlived the operator session
+	if hasHostCookie(r, room.Code) && !h.isRoomOperator(r, room) {
+		http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+		return
+	}
+
 	// Check if player is already in room
 	playerCookie, err := r.Cookie("player_" + roomCode)
 	if err == nil {
@@ -100,7 +178,7 @@ func (h *Handler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 			if h.debugControlsEnabled(r, room) {
 				if viewedPlayer := h.debugViewedPlayer(room); viewedPlayer != nil {
 					if room.State == game.StateLobby {
-						component := pages.LobbyPageWithDebug(room, viewedPlayer, h.config, h.cardService, true)
+						component := pages.LobbyPageWithDebug(room, viewedPlayer, h.roomConfig(room), h.cardService, true)
 						component.Render(r.Context(), w)
 					} else {
 						http.Redirect(w, r, "/game/"+roomCode, http.StatusSeeOther)
@@ -112,7 +190,7 @@ func (h *Handler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 			// Show appropriate page based on player type and game state
 			if h.isRoomOperator(r, room) {
 				if room.State == game.StateLobby {
-					component := pages.HostDashboardLobby(room, player, h.config, h.cardService)
+					component := pages.HostDashboardLobby(room, player, h.roomConfig(room), h.cardService)
 					component.Render(r.Context(), w)
 				} else if player.IsHost {
 					h.renderOperatorDashboardPage(w, r, room, player)
@@ -121,7 +199,7 @@ func (h *Handler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 				}
 			} else if room.State == game.StateLobby {
 				// Regular player sees lobby
-				component := pages.LobbyPage(room, player, h.config, h.cardService)
+				component := pages.LobbyPage(room, player, h.roomConfig(room), h.cardService)
 				component.Render(r.Context(), w)
 			} else {
 				// Regular player in active game
@@ -139,14 +217,37 @@ func (h *Handler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Check if game already started
+	// A host-only Room Operator's player cookie expired; the host page re-seats them
+	if operator := h.operatorPlayer(r, room); operator != nil && operator.IsHost {
+		http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+		return
+	}
+
+	// Too late to join, but a player who set a seat PIN can move their seat here
 	if room.State != game.StateLobby {
-		http.Error(w, "Game already started", http.StatusBadRequest)
+		w.WriteHeader(http.StatusBadRequest)
+		pages.SeatReclaim(room.Code, "Game already started. If you have a seat on another device, you can take it back here.").Render(r.Context(), w)
 		return
 	}
 
+	// A private room's QR code links here with a one-time token that stands
+	// in for the password; keep it out of the address bar
+	if h.rememberJoinToken(w, r, room) {
+		http.Redirect(w, r, "/room/"+room.Code, http.StatusSeeOther)
+		return
+	}
+
+	// Players the Room Operator removed land here from their lobby stream
+	errorMsg := ""
+	if sessionID, ok := h.sessionID(r); ok && room.IsBanned(sessionID) {
+		w.WriteHeader(http.StatusForbidden)
+		errorMsg = "You were removed from this room and can't join it again."
+	} else if r.URL.Query().Has("removed") {
+		errorMsg = "You were removed from this room."
+	}
+
 	// Show join form - no longer process name parameter for security
-	component := pages.Join(roomCode, "")
+	component := pages.Join(roomCode, errorMsg, needsJoinPassword(r, room))
 	component.Render(r.Context(), w)
 }
 
@@ -183,18 +284,24 @@ func (h *Handler) OperatorDashboard(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) renderOperatorDashboardPage(w http.ResponseWriter, r *http.Request, room *game.Room, player *game.Player) {
+	if room.OverlayToken == "" || room.CreatorToken == "" {
+		room.EnsureOverlayToken()
+		room.EnsureCreatorToken()
+		h.store.UpdateRoom(room)
+	}
+
 	var component templ.Component
 	switch room.State {
 	case game.StateLobby:
-		component = pages.HostDashboardLobby(room, player, h.config, h.cardService)
+		component = pages.HostDashboardLobby(room, player, h.roomConfig(room), h.cardService)
 	case game.StateCountdown:
-		component = pages.HostDashboardCountdownPage(room, player, h.config, h.cardService)
+		component = pages.HostDashboardCountdownPage(room, player, h.roomConfig(room), h.cardService)
 	case game.StatePlaying:
-		component = pages.HostDashboardPlayingPage(room, player, h.config, h.cardService)
+		component = pages.HostDashboardPlayingPage(room, player, h.roomConfig(room), h.cardService)
 	case game.StateEnded:
-		component = pages.HostDashboardEndedPage(room, player, h.config, h.cardService)
+		component = pages.HostDashboardEndedPage(room, player, h.roomConfig(room), h.cardService)
 	default:
-		component = pages.HostDashboardLobby(room, player, h.config, h.cardService)
+		component = pages.HostDashboardLobby(room, player, h.roomConfig(room), h.cardService)
 	}
 	component.Render(r.Context(), w)
 }
@@ -207,80 +314,94 @@ func (h *Handler) JoinRoomPost(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to parse form", http.StatusBadRequest)
 		return
 	}
+	if h.rejectIfSuspectedBot(w, r, "/join-room") {
+		return
+	}
 
-	roomCode := r.FormValue("room_code")
-	playerName := r.FormValue("player_name")
+	roomCode := strings.TrimSpace(r.FormValue("room_code"))
 
 	// Validate room code
 	if roomCode == "" {
 		http.Error(w, "Room code is required", http.StatusBadRequest)
 		return
 	}
-
-	// Generate random name if not provided
-	if playerName == "" {
-		playerName = generateRandomName()
+	if !validRoomCode(roomCode) {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
 	}
-	if len(playerName) < 1 || len(playerName) > 20 {
-		http.Error(w, "Player name must be between 1 and 20 characters", http.StatusBadRequest)
+
+	playerName, err := normalizePlayerName(r.FormValue("player_name"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	// Basic validation - alphanumeric and spaces only
-	for _, ch := range playerName {
-		if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == ' ') {
-			http.Error(w, "Player name must contain only letters, numbers, and spaces", http.StatusBadRequest)
-			return
-		}
+	seatPIN, err := seatPINFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	// Get room
 	room, err := h.store.GetRoom(roomCode)
-	if err != nil {
+	if err != nil || !h.inTenant(r, room) {
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
 
+	// The room code is a form field here, so neither scopeRoom nor lockRoom
+	// can see it
+	room.Lock()
+	defer room.Unlock()
+
 	// Check if game already started
 	if room.State != game.StateLobby {
 		http.Error(w, "Game already started", http.StatusBadRequest)
 		return
 	}
+	if sessionID, ok := h.sessionID(r); ok && room.IsBanned(sessionID) {
+		http.Error(w, game.ErrBanned.Error(), http.StatusForbidden)
+		return
+	}
+	joinToken, err := admitToPrivateRoom(r, room)
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		pages.Join(room.Code, err.Error(), needsJoinPassword(r, room)).Render(r.Context(), w)
+		return
+	}
 
 	// Create player
-	sessionID := getOrCreateSession(w, r)
+	sessionID := h.getOrCreateSession(w, r)
 	playerID := generatePlayerID()
 	player := game.NewPlayer(playerID, playerName, sessionID)
+	player.SetSeatPIN(seatPIN)
 
 	// Check if this player should be marked as a host
 	// This happens when they previously created the room as host-only
-	if hostCookie, err := r.Cookie("host_" + roomCode); err == nil && hostCookie.Value == "true" {
+	if hasHostCookie(r, roomCode) {
 		player.IsHost = true
 	}
 
 	// Add player to room
-	err = room.AddPlayer(player)
+	err = h.store.Append(room, game.PlayerJoinedEvent(player))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	h.rememberIdentity(player)
+	h.acceptInvite(w, r, room, player)
+	spendJoinToken(w, room, joinToken)
 
 	h.store.UpdateRoom(room)
 
 	// Store player ID in session cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "player_" + room.Code,
-		Value:    player.ID,
-		Path:     "/",
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400, // 1 day
-	})
+	setPlayerCookie(w, room.Code, player.ID)
 
 	// Notify other players
 	h.eventBus.Publish(Event{
-		Type:     "player_joined",
+		Type:     EventPlayerJoined,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 
 	// Redirect to room (no name in URL)
@@ -300,9 +421,20 @@ func (h *Handler) GamePage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Hosts without operator access recover it on the host page, and a host
+	// who lost their player cookie is re-seated there
+	if hasHostCookie(r, room.Code) && !h.isRoomOperator(r, room) {
+		http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+		return
+	}
+
 	// Get player from cookie
 	playerCookie, err := r.Cookie("player_" + roomCode)
 	if err != nil {
+		if operator := h.operatorPlayer(r, room); operator != nil && operator.IsHost {
+			http.Redirect(w, r, "/host/"+room.Code, http.StatusSeeOther)
+			return
+		}
 		http.Error(w, "Not in game", http.StatusUnauthorized)
 		return
 	}
@@ -327,14 +459,14 @@ func (h *Handler) GamePage(w http.ResponseWriter, r *http.Request) {
 		var component templ.Component
 		switch room.State {
 		case game.StateCountdown:
-			component = pages.HostDashboardCountdownPage(room, player, h.config, h.cardService)
+			component = pages.HostDashboardCountdownPage(room, player, h.roomConfig(room), h.cardService)
 		case game.StatePlaying:
-			component = pages.HostDashboardPlayingPage(room, player, h.config, h.cardService)
+			component = pages.HostDashboardPlayingPage(room, player, h.roomConfig(room), h.cardService)
 		case game.StateEnded:
-			component = pages.HostDashboardEndedPage(room, player, h.config, h.cardService)
+			component = pages.HostDashboardEndedPage(room, player, h.roomConfig(room), h.cardService)
 		default:
 			// Shouldn't happen, but fallback to playing view
-			component = pages.HostDashboardPlayingPage(room, player, h.config, h.cardService)
+			component = pages.HostDashboardPlayingPage(room, player, h.roomConfig(room), h.cardService)
 		}
 		component.Render(r.Context(), w)
 	} else {
@@ -342,3 +474,49 @@ func (h *Handler) GamePage(w http.ResponseWriter, r *http.Request) {
 		component.Render(r.Context(), w)
 	}
 }
+
+// maxRoomCodeLength bounds room codes taken from forms; generated codes are
+// five characters
+const maxRoomCodeLength = 16
+
+// validRoomCode reports whether code is shaped like a room code, so arbitrary
+// input never reaches cookie names or logs
+func validRoomCode(code string) bool {
+	if len(code) == 0 || len(code) > maxRoomCodeLength {
+		return false
+	}
+	for _, ch := range code {
+		if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizePlayerName trims name and checks it, generating a random name if
+// it is blank
+func normalizePlayerName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return generateRandomName(), nil
+	}
+	if len(name) > 20 {
+		return "", errors.New("Player name must be between 1 and 20 characters")
+	}
+	// Basic validation - alphanumeric and spaces only
+	for _, ch := range name {
+		if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == ' ') {
+			return "", errors.New("Player name must contain only letters, numbers, and spaces")
+		}
+	}
+	return name, nil
+}
+
+// seatPINFromForm returns the optional seat PIN posted with a create or join
+func seatPINFromForm(r *http.Request) (string, error) {
+	pin := strings.TrimSpace(r.FormValue("pin"))
+	if pin != "" && !game.ValidSeatPIN(pin) {
+		return "", game.ErrSeatPINInvalid
+	}
+	return pin, nil
+}
@@ -155,7 +155,7 @@ func TestJoinRoomDirect(t *testing.T) {
 		defer h.eventBus.Unsubscribe(room.Code, events)
 
 		// Join via POST
-		formData := "room_code=" + room.Code + "&player_name=NewPlayer"
+		formData := "room_code=" + room.Code + "&player_name=NewPlayer&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w := httptest.NewRecorder()
@@ -215,7 +215,7 @@ func TestJoinRoomDirect(t *testing.T) {
 		h.Store().UpdateRoom(room)
 
 		// Try to join via POST
-		formData := "room_code=" + room.Code + "&player_name=NewPlayer"
+		formData := "room_code=" + room.Code + "&player_name=NewPlayer&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w := httptest.NewRecorder()
@@ -7,7 +7,10 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"treacherest/internal/config"
 	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
 )
 
 func TestHandler_Home(t *testing.T) {
@@ -36,12 +39,39 @@ func TestHandler_Home(t *testing.T) {
 	}
 }
 
+func TestHandler_About(t *testing.T) {
+	h := newTestHandler()
+	h.cardService = game.NewSandboxCardService(2)
+	h.cardService.DropImages()
+	h.SetAttribution("Cards by <b>someone</b>.\n\nNot affiliated with anyone.\n")
+
+	w := httptest.NewRecorder()
+	newTestRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/about", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		"generated sandbox cards",
+		"<dd>8</dd>",
+		"text only",
+		"<p class=\"text-base-content/80\">Cards by &lt;b&gt;someone&lt;/b&gt;.</p>",
+		"<p class=\"text-base-content/80\">Not affiliated with anyone.</p>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the about page", want)
+		}
+	}
+}
+
 func TestHandler_CreateRoom(t *testing.T) {
 	t.Run("creates room successfully", func(t *testing.T) {
 		h := newTestHandler()
 
 		form := url.Values{}
 		form.Add("playerName", "Test Player")
+		form.Add(components.BotElapsedField, "5000")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -104,6 +134,7 @@ func TestHandler_CreateRoom(t *testing.T) {
 
 		form := url.Values{}
 		form.Add("playerName", "Test Player")
+		form.Add(components.BotElapsedField, "5000")
 		form.Add("rulesMode", "coup")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
@@ -133,6 +164,7 @@ func TestHandler_CreateRoom(t *testing.T) {
 
 		form := url.Values{}
 		form.Add("playerName", "Test Player")
+		form.Add(components.BotElapsedField, "5000")
 		form.Add("rulesMode", "bogus")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
@@ -157,6 +189,7 @@ func TestHandler_CreateRoom(t *testing.T) {
 
 		form := url.Values{}
 		form.Add("playerName", "")
+		form.Add(components.BotElapsedField, "5000")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -208,6 +241,7 @@ func TestHandler_CreateRoom(t *testing.T) {
 
 		form := url.Values{}
 		form.Add("playerName", "Test Player")
+		form.Add(components.BotElapsedField, "5000")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -249,6 +283,7 @@ func TestHandler_CreateRoom(t *testing.T) {
 
 		form := url.Values{}
 		form.Add("playerName", "Host Player")
+		form.Add(components.BotElapsedField, "5000")
 		form.Add("hostOnly", "true")
 
 		req := httptest.NewRequest("POST", "/create-room", strings.NewReader(form.Encode()))
@@ -266,7 +301,10 @@ func TestHandler_CreateRoom(t *testing.T) {
 		}
 
 		location := w.Result().Header.Get("Location")
-		roomCode := strings.TrimPrefix(location, "/room/")
+		if !strings.HasPrefix(location, "/host/") {
+			t.Fatalf("expected host-only creator to land on the host dashboard, got %q", location)
+		}
+		roomCode := strings.TrimPrefix(location, "/host/")
 		room, err := h.store.GetRoom(roomCode)
 		if err != nil {
 			t.Fatalf("room not found in store: %v", err)
@@ -326,7 +364,7 @@ func TestHandler_JoinRoom(t *testing.T) {
 		}
 	})
 
-	t.Run("shows join form even with host cookie", func(t *testing.T) {
+	t.Run("redirects a host cookie without operator access to the host page", func(t *testing.T) {
 		h := newTestHandler()
 
 		// Create a room first
@@ -348,18 +386,11 @@ func TestHandler_JoinRoom(t *testing.T) {
 
 		router.ServeHTTP(w, req)
 
-		resp := w.Result()
-		if resp.StatusCode != http.StatusOK {
-			t.Errorf("expected status 200, got %d", resp.StatusCode)
-		}
-
-		// Should still show join form since no player cookie
-		body := w.Body.String()
-		if !strings.Contains(body, "Join Game Room") {
-			t.Error("expected join form to be shown")
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("expected status 303, got %d", w.Code)
 		}
-		if !strings.Contains(body, roomCode) {
-			t.Error("expected room code in join form")
+		if location := w.Header().Get("Location"); location != "/host/"+roomCode {
+			t.Errorf("expected redirect to the host page, got %q", location)
 		}
 	})
 
@@ -486,7 +517,7 @@ func TestHandler_JoinRoom(t *testing.T) {
 		room.OperatorSessionID = operator.SessionID
 		h.store.UpdateRoom(room)
 
-		router := SetupRouter(h, h.config, &RouterOptions{
+		router := SetupRouter(h, h.config(), &RouterOptions{
 			DisableRateLimiting:  true,
 			DisableRequestLogger: true,
 			StaticDir:            ".",
@@ -514,6 +545,36 @@ func TestHandler_JoinRoom(t *testing.T) {
 		}
 	})
 
+	t.Run("host-only creator lands on the host dashboard with the QR code", func(t *testing.T) {
+		h := newTestHandler()
+		router := SetupRouter(h, h.config(), &RouterOptions{
+			DisableRateLimiting:  true,
+			DisableRequestLogger: true,
+			StaticDir:            ".",
+		})
+
+		host := testkit.CreateRoom(t, router, "Host Player", true)
+		w := host.Get("/host/" + host.RoomCode)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected host dashboard status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		for _, want := range []string{`id="host-dashboard-container"`, `id="qr-code-img"`, "/room/" + host.RoomCode + "/qr.png"} {
+			if !strings.Contains(w.Body.String(), want) {
+				t.Errorf("expected %q on the host dashboard", want)
+			}
+		}
+
+		room, _ := h.store.GetRoom(host.RoomCode)
+		if room.GetActivePlayerCount() != 0 {
+			t.Errorf("expected the host to take no seat, got %d active players", room.GetActivePlayerCount())
+		}
+
+		player := testkit.JoinRoom(t, router, host.RoomCode, "Player")
+		if w := player.Get("/host/" + host.RoomCode); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected a player to be refused the host dashboard, got %d", w.Code)
+		}
+	})
+
 	t.Run("legacy host flag does not grant non-operator lobby management controls", func(t *testing.T) {
 		h := newTestHandler()
 
@@ -575,7 +636,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		room, _ := h.store.CreateRoom()
 
 		// Join via POST
-		formData := "room_code=" + room.Code + "&player_name=Test+Player"
+		formData := "room_code=" + room.Code + "&player_name=Test+Player&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w := httptest.NewRecorder()
@@ -620,7 +681,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		room, _ := h.store.CreateRoom()
 
 		// Join via POST with host cookie
-		formData := "room_code=" + room.Code + "&player_name=Host+Player"
+		formData := "room_code=" + room.Code + "&player_name=Host+Player&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		// Add host cookie
@@ -657,7 +718,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		room, _ := h.store.CreateRoom()
 
 		// First player joins
-		formData := "room_code=" + room.Code + "&player_name=Alice"
+		formData := "room_code=" + room.Code + "&player_name=Alice&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w := httptest.NewRecorder()
@@ -669,7 +730,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		}
 
 		// Second player tries to join with same name
-		formData2 := "room_code=" + room.Code + "&player_name=Alice"
+		formData2 := "room_code=" + room.Code + "&player_name=Alice&form_elapsed=5000"
 		req2 := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData2))
 		req2.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w2 := httptest.NewRecorder()
@@ -700,7 +761,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		room, _ := h.store.CreateRoom()
 
 		// First player joins with lowercase
-		formData := "room_code=" + room.Code + "&player_name=alice"
+		formData := "room_code=" + room.Code + "&player_name=alice&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w := httptest.NewRecorder()
@@ -712,7 +773,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		}
 
 		// Second player tries to join with uppercase
-		formData2 := "room_code=" + room.Code + "&player_name=ALICE"
+		formData2 := "room_code=" + room.Code + "&player_name=ALICE&form_elapsed=5000"
 		req2 := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData2))
 		req2.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w2 := httptest.NewRecorder()
@@ -738,7 +799,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		room2, _ := h.store.CreateRoom()
 
 		// Join first room
-		formData1 := "room_code=" + room1.Code + "&player_name=Alice"
+		formData1 := "room_code=" + room1.Code + "&player_name=Alice&form_elapsed=5000"
 		req1 := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData1))
 		req1.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w1 := httptest.NewRecorder()
@@ -750,7 +811,7 @@ func TestHandler_JoinRoomPost(t *testing.T) {
 		}
 
 		// Join second room with same name
-		formData2 := "room_code=" + room2.Code + "&player_name=Alice"
+		formData2 := "room_code=" + room2.Code + "&player_name=Alice&form_elapsed=5000"
 		req2 := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData2))
 		req2.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		w2 := httptest.NewRecorder()
@@ -865,7 +926,7 @@ func TestHandler_GamePage(t *testing.T) {
 
 	t.Run("does not render debug surface for non-operator player when debug enabled", func(t *testing.T) {
 		h := newTestHandler()
-		h.config.Server.DebugModeEnabled = true
+		h.config().Server.DebugModeEnabled = true
 
 		room, _ := h.store.CreateRoom()
 		operator := game.NewPlayer("operator", "Operator", "session-operator")
@@ -905,7 +966,7 @@ func TestHandler_GamePage(t *testing.T) {
 
 	t.Run("renders full debug surface for playing room operator when debug enabled", func(t *testing.T) {
 		h := newTestHandler()
-		h.config.Server.DebugModeEnabled = true
+		h.config().Server.DebugModeEnabled = true
 
 		room, _ := h.store.CreateRoom()
 		operator := game.NewPlayer("p1", "Operator", "session-operator")
@@ -1001,3 +1062,41 @@ func TestHandler_GamePage(t *testing.T) {
 		}
 	})
 }
+
+func TestRulesPages(t *testing.T) {
+	router := newTestRouter(newTestHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/rules", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `href="/rules/blue-knight"`) {
+		t.Fatalf("expected the reference to link every role, got %d:\n%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/rules/traitor", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "The Traitor wins if they are the last player standing.") {
+		t.Errorf("expected the Traitor's win condition, got %d:\n%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/rules/jester", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown role not found, got %d", w.Code)
+	}
+}
+
+// TestRulesCoverEveryRoleType keeps rules.yaml in step with the roles the
+// game deals, so no role card links to a missing page
+func TestRulesCoverEveryRoleType(t *testing.T) {
+	ref, err := config.Rules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	roleTypes := []game.RoleType{game.RoleLeader, game.RoleGuardian, game.RoleAssassin, game.RoleTraitor}
+	roleTypes = append(roleTypes, game.CoupRoleCountOptions()...)
+	for _, roleType := range roleTypes {
+		if _, ok := ref.Role(config.RulesSlug(string(roleType))); !ok {
+			t.Errorf("rules.yaml has no entry for %s", roleType)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"treacherest/internal/game"
+)
+
+// maxPhaseDuration caps timer-driven phases so a typo can't stall a table for hours
+const maxPhaseDuration = 30 * time.Minute
+
+// UpdatePhaseSettings enables or disables the day/night phase engine before the game starts
+func (h *Handler) UpdatePhaseSettings(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	settings := game.PhaseSettings{Enabled: r.FormValue("enabled") == "true" || r.FormValue("enabled") == "on"}
+	if raw := r.FormValue("durationSeconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			http.Error(w, "Invalid phase duration", http.StatusBadRequest)
+			return
+		}
+		settings.Duration = time.Duration(seconds) * time.Second
+		if settings.Duration > maxPhaseDuration {
+			settings.Duration = maxPhaseDuration
+		}
+	}
+
+	room.PhaseSettings = settings
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventPhaseSettingsUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// AdvancePhase lets the Room Operator end the current phase early or run phases manually
+func (h *Handler) AdvancePhase(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if room.State != game.StatePlaying {
+		http.Error(w, "Phases only advance while the game is playing", http.StatusConflict)
+		return
+	}
+
+	if err := h.advancePhase(room, h.requestActor(r, room)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// advancePhase moves the room to its next phase, broadcasts it and arms the
+// next timer. actor is zero when the phase timer expired.
+func (h *Handler) advancePhase(room *game.Room, actor EventActor) error {
+	next, err := room.AdvancePhase(h.clock.Now())
+	if err != nil {
+		return err
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🌗 Room %s entered %s", room.Code, next.Label())
+
+	h.eventBus.Publish(Event{
+		Type:     EventPhaseChanged,
+		RoomCode: room.Code,
+		Data:     next,
+		Actor:    actor,
+	})
+
+	h.schedulePhaseTimer(room.Code, next, room.PhaseSettings.Duration)
+	return nil
+}
+
+// schedulePhaseTimer advances a timer-driven phase once it expires.
+// A manual advance in the meantime makes the pending timer a no-op.
+func (h *Handler) schedulePhaseTimer(roomCode string, phase game.PhaseState, duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+
+	h.clock.AfterFunc(duration, func() {
+		room, err := h.store.GetRoom(roomCode)
+		if err != nil {
+			return
+		}
+		room.Lock()
+		defer room.Unlock()
+		if room.State != game.StatePlaying {
+			return
+		}
+		current, ok := room.CurrentPhase()
+		if !ok || current != phase {
+			return
+		}
+		if err := h.advancePhase(room, EventActor{}); err != nil {
+			log.Printf("❌ Failed to advance phase for room %s: %v", roomCode, err)
+		}
+	})
+}
+
+// startPhases begins the first day once a phased game starts playing
+func (h *Handler) startPhases(room *game.Room) {
+	room.StartPhases(h.clock.Now())
+	phase, ok := room.CurrentPhase()
+	if !ok {
+		return
+	}
+	h.store.UpdateRoom(room)
+	h.schedulePhaseTimer(room.Code, phase, room.PhaseSettings.Duration)
+}
+
+// rejectIfPhaseForbids writes a 409 when the current phase doesn't allow action
+func (h *Handler) rejectIfPhaseForbids(w http.ResponseWriter, room *game.Room, action game.PhaseAction) bool {
+	if room.PhaseAllows(action) {
+		return false
+	}
+
+	phase, _ := room.CurrentPhase()
+	http.Error(w, fmt.Sprintf("%s is not allowed during %s", action, phase.Label()), http.StatusConflict)
+	return true
+}
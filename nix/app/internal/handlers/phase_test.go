@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+)
+
+func newPhaseTestRoom(t *testing.T, h *Handler) (*game.Room, *game.Player) {
+	t.Helper()
+	room, err := h.store.CreateRoom()
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	operator := game.NewPlayer("op", "Operator", "operator-session")
+	operator.IsHost = true
+	room.OperatorSessionID = operator.SessionID
+	room.AddPlayer(operator)
+	player := game.NewPlayer("p1", "Alice", "s1")
+	player.Role = mockGuardianCard()
+	room.AddPlayer(player)
+	return room, player
+}
+
+func postPhaseForm(router http.Handler, path, session string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: "session", Value: session})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdatePhaseSettings(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	path := "/room/" + room.Code + "/config/phases"
+
+	w := postPhaseForm(router, path, "s1", url.Values{"enabled": {"true"}})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected non-operator to be rejected, got %d", w.Code)
+	}
+
+	w = postPhaseForm(router, path, "operator-session", url.Values{"enabled": {"true"}, "durationSeconds": {"90"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !room.PhaseSettings.Enabled || room.PhaseSettings.Duration != 90*time.Second {
+		t.Fatalf("unexpected settings %+v", room.PhaseSettings)
+	}
+
+	room.State = game.StatePlaying
+	w = postPhaseForm(router, path, "operator-session", url.Values{"enabled": {"false"}})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected settings to lock after start, got %d", w.Code)
+	}
+}
+
+func TestAdvancePhase_BroadcastsAndGatesActions(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	room.PhaseSettings.Enabled = true
+	room.State = game.StatePlaying
+	h.startPhases(room)
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+
+	// Day: reveals are held until night
+	req := httptest.NewRequest("POST", "/room/"+room.Code+"/reveal/"+player.ID, nil)
+	req.AddCookie(&http.Cookie{Name: "player_" + room.Code, Value: player.ID})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected reveal to be blocked during the day, got %d", w.Code)
+	}
+
+	w = postPhaseForm(router, "/room/"+room.Code+"/phase/advance", "operator-session", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected advance to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != "phase_changed" {
+			t.Fatalf("expected phase_changed, got %s", event.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a phase_changed broadcast")
+	}
+
+	phase, _ := room.CurrentPhase()
+	if phase.Current != game.PhaseNight {
+		t.Fatalf("expected night, got %s", phase.Current)
+	}
+}
+
+func TestSchedulePhaseTimer_AdvancesAutomatically(t *testing.T) {
+	h := newTestHandler()
+	room, _ := newPhaseTestRoom(t, h)
+	room.PhaseSettings = game.PhaseSettings{Enabled: true, Duration: 20 * time.Millisecond}
+	room.State = game.StatePlaying
+	h.startPhases(room)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		phase, _ := room.CurrentPhase()
+		if phase.Current == game.PhaseNight {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected timer to advance to night")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	room.State = game.StateEnded
+}
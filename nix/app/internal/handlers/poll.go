@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+// OpenPresetPoll lets the Room Operator ask the lobby which preset to play
+func (h *Handler) OpenPresetPoll(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	// Offer the checked presets, or every preset when none are checked
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	selected := make(map[string]bool)
+	for _, id := range r.Form["presets"] {
+		selected[id] = true
+	}
+	var options []game.VoteOption
+	for _, option := range game.PresetPollOptions(room, h.roomConfig(room)) {
+		if len(selected) == 0 || selected[option.ID] {
+			options = append(options, option)
+		}
+	}
+
+	question := strings.TrimSpace(r.FormValue("question"))
+	if question == "" {
+		question = "Which preset tonight?"
+	}
+	if len(question) > 120 {
+		http.Error(w, "Question is too long", http.StatusBadRequest)
+		return
+	}
+
+	if err := room.OpenPoll(question, options); err != nil {
+		http.Error(w, err.Error(), pollErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🗳️ Preset poll opened in room %s with %d options", room.Code, len(options))
+
+	h.publishPollUpdated(room, h.requestActor(r, room))
+	w.WriteHeader(http.StatusOK)
+}
+
+// CastPollVote records a lobby player's anonymous poll ballot
+func (h *Handler) CastPollVote(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if room.State != game.StateLobby {
+		http.Error(w, "Polls are only open in the lobby", http.StatusConflict)
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+	if player.IsHost {
+		http.Error(w, "The Room Operator does not vote in polls", http.StatusForbidden)
+		return
+	}
+
+	if err := room.CastPollVote(player.ID, chi.URLParam(r, "optionID")); err != nil {
+		http.Error(w, err.Error(), pollErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	// Ballots are anonymous, so the update carries no actor
+	h.publishPollUpdated(room, EventActor{})
+	w.WriteHeader(http.StatusOK)
+}
+
+// ApplyPollWinner applies the winning preset and closes the poll
+func (h *Handler) ApplyPollWinner(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	winner, err := room.PollWinner()
+	if err != nil {
+		http.Error(w, err.Error(), pollErrorStatus(err))
+		return
+	}
+
+	configEvent := EventRoleConfigUpdated
+	if room.RulesMode == game.RulesModeCoup {
+		if !applyCoupPreset(room, game.CoupPreset(winner.ID)) {
+			http.Error(w, "Invalid Coup preset", http.StatusBadRequest)
+			return
+		}
+		configEvent = EventCoupConfigUpdated
+	} else {
+		before := room.RoleConfig.Clone()
+		if err := h.applyRolePreset(room, winner.ID); err != nil {
+			http.Error(w, "Invalid preset", http.StatusBadRequest)
+			return
+		}
+		room.KeepConfigBaseline(before)
+	}
+	if configEvent == EventRoleConfigUpdated {
+		h.record(room, game.ConfigChangedEvent(room.RoleConfig))
+	}
+	room.ClosePoll()
+	h.store.UpdateRoom(room)
+	log.Printf("🗳️ Poll winner %q applied in room %s", winner.Label, room.Code)
+
+	h.eventBus.Publish(Event{
+		Type:     configEvent,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+	h.publishPollUpdated(room, h.requestActor(r, room))
+	w.WriteHeader(http.StatusOK)
+}
+
+// ClosePoll discards the open poll without applying anything
+func (h *Handler) ClosePoll(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	if err := room.ClosePoll(); err != nil {
+		http.Error(w, err.Error(), pollErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	h.publishPollUpdated(room, h.requestActor(r, room))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) publishPollUpdated(room *game.Room, actor EventActor) {
+	h.eventBus.Publish(Event{
+		Type:     EventPollUpdated,
+		Actor:    actor,
+		RoomCode: room.Code,
+		Data:     room,
+	})
+}
+
+func pollErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, game.ErrNoOpenPoll), errors.Is(err, game.ErrPollAlreadyOpen), errors.Is(err, game.ErrPollUndecided):
+		return http.StatusConflict
+	default:
+		return voteErrorStatus(err)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+)
+
+func TestPresetPollAppliesWinner(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	room.AddPlayer(bob)
+	base := "/room/" + room.Code + "/poll/"
+
+	// The default config has a single preset, which is not worth polling
+	if w := postPhaseForm(router, base+"open", "operator-session", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a one-option poll to be rejected, got %d", w.Code)
+	}
+
+	h.config().Roles.Presets["chaos"] = config.Preset{
+		Name:          "Chaos",
+		Distributions: map[int]map[string]int{5: {"leader": 1, "assassin": 2, "traitor": 2}},
+	}
+	if w := postPhaseForm(router, base+"open", "s1", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected non-operator open to be rejected, got %d", w.Code)
+	}
+	if w := postPhaseForm(router, base+"open", "operator-session", url.Values{"presets": {"chaos", "standard"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.Poll == nil || len(room.Poll.Options) != 2 {
+		t.Fatalf("expected a two-option poll, got %+v", room.Poll)
+	}
+
+	alice, bobs := seatedClient(t, router, room.Code, player.ID), seatedClient(t, router, room.Code, bob.ID)
+	alice.Post(base+"vote/chaos", nil)
+	bobs.Post(base+"vote/standard", nil)
+	if w := postPhaseForm(router, base+"apply", "operator-session", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected a tied poll to be rejected, got %d", w.Code)
+	}
+	if w := seatedClient(t, router, room.Code, "op").Post(base+"vote/chaos", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the operator to be unable to vote, got %d", w.Code)
+	}
+	if w := bobs.Post(base+"vote/chaos", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected Bob to change his vote, got %d", w.Code)
+	}
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+	if w := postPhaseForm(router, base+"apply", "operator-session", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected apply to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.RoleConfig.PresetName != "chaos" {
+		t.Fatalf("expected the chaos preset to be applied, got %q", room.RoleConfig.PresetName)
+	}
+	if room.Poll != nil {
+		t.Fatal("expected the poll to close once applied")
+	}
+	if ev := <-events; ev.Type != "role_config_updated" {
+		t.Fatalf("expected role_config_updated, got %s", ev.Type)
+	}
+	if ev := <-events; ev.Type != "poll_updated" {
+		t.Fatalf("expected poll_updated, got %s", ev.Type)
+	}
+}
+
+func TestPresetPollClosedOnceStarted(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	room.RulesMode = game.RulesModeCoup
+	if err := room.OpenPoll("Which preset tonight?", game.PresetPollOptions(room, h.config())); err != nil {
+		t.Fatalf("open poll: %v", err)
+	}
+	room.State = game.StatePlaying
+
+	if w := seatedClient(t, router, room.Code, player.ID).Post("/room/"+room.Code+"/poll/vote/"+room.Poll.Options[0].ID, nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected voting after start to conflict, got %d", w.Code)
+	}
+	if w := postPhaseForm(router, "/room/"+room.Code+"/poll/apply", "operator-session", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected apply after start to conflict, got %d", w.Code)
+	}
+}
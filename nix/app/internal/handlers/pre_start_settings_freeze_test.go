@@ -15,7 +15,7 @@ func TestPreStartSettingsFreezeRejectsTreacheryPlayerCountAfterLobby(t *testing.
 	room, _ := h.store.CreateRoom()
 	room.RulesMode = game.RulesModeTreachery
 	room.State = game.StateCountdown
-	roleConfig, err := h.roleConfigService.CreateFromPreset("standard", 5)
+	roleConfig, err := h.roleConfigService().CreateFromPreset("standard", 5)
 	if err != nil {
 		t.Fatalf("failed to create role config: %v", err)
 	}
@@ -25,7 +25,7 @@ func TestPreStartSettingsFreezeRejectsTreacheryPlayerCountAfterLobby(t *testing.
 	markRoomOperatorForTest(room, operator)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -69,7 +69,7 @@ func TestPreStartSettingsFreezeRejectsCoupPresetAfterLobby(t *testing.T) {
 	markRoomOperatorForTest(room, operator)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -304,7 +304,7 @@ func TestPreStartSettingsFreezeRejectsAllSetupEndpointsAfterLobby(t *testing.T)
 
 func TestPreStartSettingsFreezeRejectsDebugModeSetupMutationAfterLobby(t *testing.T) {
 	h, room, operator := newCoupRoomForPreStartFreezeTest(t, game.StatePlaying)
-	h.config.Server.DebugModeEnabled = true
+	h.config().Server.DebugModeEnabled = true
 
 	w := postPreStartSettingForTest(t, h, room, operator, "/config/coup-info", "application/x-www-form-urlencoded", "kingToBlue=king-knows-no-blue")
 
@@ -326,7 +326,7 @@ func TestPreStartSettingsFreezeRejectsLegacyRoleOptionsAfterLobby(t *testing.T)
 	room, _ := h.store.CreateRoom()
 	room.RulesMode = game.RulesModeTreachery
 	room.State = game.StatePlaying
-	roleConfig, err := h.roleConfigService.CreateFromPreset("standard", 5)
+	roleConfig, err := h.roleConfigService().CreateFromPreset("standard", 5)
 	if err != nil {
 		t.Fatalf("failed to create role config: %v", err)
 	}
@@ -358,7 +358,7 @@ func newTreacheryRoomForPreStartFreezeTest(t *testing.T, state game.GameState) (
 	room, _ := h.store.CreateRoom()
 	room.RulesMode = game.RulesModeTreachery
 	room.State = state
-	roleConfig, err := h.roleConfigService.CreateFromPreset("standard", 5)
+	roleConfig, err := h.roleConfigService().CreateFromPreset("standard", 5)
 	if err != nil {
 		t.Fatalf("failed to create role config: %v", err)
 	}
@@ -398,7 +398,7 @@ func newCoupRoomForPreStartFreezeTest(t *testing.T, state game.GameState) (*Hand
 func postPreStartSettingForTest(t *testing.T, h *Handler, room *game.Room, operator *game.Player, path string, contentType string, body string) *httptest.ResponseRecorder {
 	t.Helper()
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -450,7 +450,7 @@ func TestPreStartSettingsFreezeOmitsSetupControlsFromPostStartDOM(t *testing.T)
 					for _, tt := range actors {
 						t.Run(tt.name, func(t *testing.T) {
 							h, room, actor := newPostStartRoomForPreStartDOMTest(t, mode, state, tt.actorKind)
-							router := SetupRouter(h, h.config, &RouterOptions{
+							router := SetupRouter(h, h.config(), &RouterOptions{
 								DisableRateLimiting:  true,
 								DisableRequestLogger: true,
 							})
@@ -488,7 +488,7 @@ func newPostStartRoomForPreStartDOMTest(t *testing.T, mode game.RulesMode, state
 		}
 		room.CoupRoleCounts = counts
 	} else {
-		roleConfig, err := h.roleConfigService.CreateFromPreset("standard", 5)
+		roleConfig, err := h.roleConfigService().CreateFromPreset("standard", 5)
 		if err != nil {
 			t.Fatalf("failed to create role config: %v", err)
 		}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/privacy"
+)
+
+// historySweepInterval is how often history older than the retention period
+// is dropped; the retention period itself when that is shorter
+const historySweepInterval = time.Minute
+
+// SetRedactor turns on privacy logging: players are added to redactor as they
+// join, and it must also be the standard logger's output
+func (h *Handler) SetRedactor(redactor *privacy.Redactor) {
+	h.redactor = redactor
+	for _, room := range h.store.Rooms() {
+		for _, player := range room.GetPlayers() {
+			h.rememberIdentity(player)
+		}
+	}
+}
+
+// rememberIdentity keeps player's name, ID and session out of the log in
+// privacy logging mode
+func (h *Handler) rememberIdentity(player *game.Player) {
+	if h.redactor != nil {
+		h.redactor.Add(player.ID, player.SessionID, player.Name)
+	}
+}
+
+// redactIdentities is text as privacy logging would write it
+func (h *Handler) redactIdentities(text string) string {
+	if h.redactor == nil {
+		return text
+	}
+	return h.redactor.Redact(text)
+}
+
+// PruneHistory drops game logs, journaled room events, captured log lines and
+// connection telemetry older than the configured HistoryRetention; a zero
+// retention keeps them
+func (h *Handler) PruneHistory() {
+	retention := h.config().Server.HistoryRetention
+	if retention <= 0 {
+		return
+	}
+	cutoff := h.clock.Now().Add(-retention)
+
+	entries := 0
+	for _, room := range h.store.Rooms() {
+		entries += room.PruneLog(cutoff)
+		room.Lock()
+		events, err := h.store.PruneEvents(room, cutoff)
+		room.Unlock()
+		if err != nil {
+			log.Printf("⚠️ Failed to prune room %s's journal: %v", room.Code, err)
+		}
+		entries += events
+	}
+	if h.roomLogs != nil {
+		entries += h.roomLogs.Prune(cutoff)
+	}
+	rooms := h.telemetry.prune(cutoff)
+	if entries > 0 || rooms > 0 {
+		log.Printf("🧹 History retention dropped %d history entries and telemetry for %d rooms older than %s", entries, rooms, retention)
+	}
+}
+
+// RunHistoryRetention prunes history on a timer until ctx is done. It returns
+// at once when HistoryRetention is zero.
+func (h *Handler) RunHistoryRetention(ctx context.Context) {
+	retention := h.config().Server.HistoryRetention
+	if retention <= 0 {
+		return
+	}
+	ticker := h.clock.NewTicker(min(retention, historySweepInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			h.PruneHistory()
+		}
+	}
+}
+
+// forgetMeResult says how much history POST /privacy/forget-me purged
+type forgetMeResult struct {
+	Rooms   int `json:"rooms"`
+	Entries int `json:"entries"`
+}
+
+// ForgetMe purges the history the server keeps about the caller's session:
+// every game log entry and captured log line that names one of its players
+// or mentions their IDs or the session, and every journaled room event about
+// its players, including ones who have since left. Seats in live games are
+// left alone, and connection telemetry is per room, so it has nothing to
+// purge.
+func (h *Handler) ForgetMe(w http.ResponseWriter, r *http.Request) {
+	var result forgetMeResult
+	if sessionID, ok := h.sessionID(r); ok {
+		for _, room := range h.store.Rooms() {
+			entries := h.forgetSessionIn(room, sessionID)
+			if entries > 0 {
+				result.Rooms++
+				result.Entries += entries
+			}
+		}
+	}
+	log.Printf("🧹 Forgot %d history entries across %d rooms on request", result.Entries, result.Rooms)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(result)
+}
+
+// forgetSessionIn purges what room's history holds about sessionID's players,
+// returning how many entries and events it dropped
+func (h *Handler) forgetSessionIn(room *game.Room, sessionID string) int {
+	room.Lock()
+	defer room.Unlock()
+
+	entries, err := h.store.ForgetSession(room, sessionID)
+	if err != nil {
+		log.Printf("⚠️ Failed to purge room %s's journal: %v", room.Code, err)
+	}
+
+	terms := []string{sessionID}
+	for _, player := range room.GetPlayers() {
+		if player.SessionID == sessionID {
+			terms = append(terms, player.ID, player.Name)
+		}
+	}
+	if len(terms) > 1 {
+		entries += room.PurgeLog(terms[1:]...)
+	}
+	if h.roomLogs != nil {
+		entries += h.roomLogs.Purge(room.Code, terms...)
+	}
+	return entries
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/privacy"
+	"treacherest/internal/roomlog"
+	"treacherest/internal/testkit"
+)
+
+func TestPrivacyLoggingKeepsPlayersOutOfTheLog(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	capture := roomlog.New(&bytes.Buffer{}, 0, 0)
+	h.SetRoomLogs(capture)
+	var out bytes.Buffer
+	redactor := privacy.NewRedactor(&out, 0)
+	log.SetOutput(redactor)
+	defer log.SetOutput(os.Stderr)
+	h.SetRedactor(redactor)
+
+	operator := testkit.CreateRoom(t, router, "Operator", false)
+	alice := testkit.JoinRoom(t, router, operator.RoomCode, "Alice")
+	h.trackRoomLogs(operator.RoomCode)
+	room, _ := h.store.GetRoom(operator.RoomCode)
+	player := room.GetPlayer(alice.PlayerID())
+
+	log.Printf("Player %s (%s, session %s) reconnected to room %s", player.Name, player.ID, player.SessionID, room.Code)
+	h.eventBus.Publish(Event{Type: EventPlayerJoined, RoomCode: room.Code, Actor: EventActor{Name: player.Name}})
+
+	written := out.String()
+	for _, secret := range []string{"Alice", "Operator", player.ID, player.SessionID} {
+		if strings.Contains(written, secret) {
+			t.Errorf("expected %q out of the log, got %s", secret, written)
+		}
+	}
+	if !strings.Contains(written, "Player "+redactor.Hash(player.ID)+" ("+redactor.Hash(player.ID)) || !strings.Contains(written, room.Code) {
+		t.Errorf("expected the player as their hash and the room code kept, got %s", written)
+	}
+	entries := capture.Entries(room.Code)
+	if len(entries) == 0 || entries[len(entries)-1].Text != "player_joined by "+redactor.Hash(player.ID) {
+		t.Errorf("expected the recorded event's actor as a hash, got %+v", entries)
+	}
+}
+
+func TestForgetMePurgesTheCallersHistory(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	capture := roomlog.New(&bytes.Buffer{}, 0, 0)
+	h.SetRoomLogs(capture)
+	room, alice := newPhaseTestRoom(t, h)
+	h.trackRoomLogs(room.Code)
+
+	room.AppendLog("Alice revealed their role")
+	room.AppendLog("The game started")
+	logger := log.New(capture, "", 0)
+	logger.Printf("Player %s joined room %s", alice.ID, room.Code)
+	logger.Printf("Session %s reconnected to room %s", alice.SessionID, room.Code)
+	logger.Printf("Room %s started", room.Code)
+
+	forget := func(session string) forgetMeResult {
+		req := httptest.NewRequest("POST", "/privacy/forget-me", nil)
+		if session != "" {
+			req.AddCookie(&http.Cookie{Name: "session", Value: session})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var result forgetMeResult
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("decode result: %v", err)
+		}
+		return result
+	}
+
+	if result := forget(""); result != (forgetMeResult{}) {
+		t.Errorf("expected nothing forgotten without a session, got %+v", result)
+	}
+	if result := forget(alice.SessionID); result.Rooms != 1 || result.Entries != 3 {
+		t.Errorf("expected Alice's three entries forgotten, got %+v", result)
+	}
+	if got := room.GetLog(); len(got) != 1 || got[0].Message != "The game started" {
+		t.Errorf("expected only the game log entry without Alice, got %+v", got)
+	}
+	if got := capture.Entries(room.Code); len(got) != 1 || got[0].Text != "Room "+room.Code+" started" {
+		t.Errorf("expected only the log line without Alice, got %+v", got)
+	}
+	if room.GetPlayer(alice.ID) == nil {
+		t.Error("expected Alice to keep their seat")
+	}
+}
+
+func TestForgetMePurgesJournaledEvents(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := h.store.CreateRoom()
+	room.Lock()
+	for _, e := range []game.RoomEvent{
+		game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "s1")),
+		game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2")),
+		game.PlayerLeftEvent("p1"),
+	} {
+		if err := h.store.Append(room, e); err != nil {
+			t.Fatalf("append %s: %v", e.Kind, err)
+		}
+	}
+	room.Unlock()
+
+	// Alice has left, but their join and leave are still in the journal
+	req := httptest.NewRequest("POST", "/privacy/forget-me", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var result forgetMeResult
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Rooms != 1 || result.Entries != 2 {
+		t.Errorf("expected Alice's two events forgotten, got %+v", result)
+	}
+	events, _ := h.store.Events(room.Code, 0)
+	for _, e := range events {
+		if e.Player != nil && e.Player.SessionID == "s1" || e.PlayerID == "p1" {
+			t.Errorf("expected nothing about Alice in the journal, got %+v", e)
+		}
+	}
+	if rebuilt, err := h.store.Rebuild(room.Code); err != nil || rebuilt.GetPlayer("p2") == nil {
+		t.Errorf("expected the room still rebuilt with Bob, got %v", err)
+	}
+}
+
+func TestPruneHistoryDropsWhatIsOlderThanRetention(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	room, _ := newPhaseTestRoom(t, h)
+	stale, quiet := fake.Now().Add(-2*time.Hour), fake.Now().Add(-time.Minute)
+	room.Log = []game.LogEntry{{At: stale, Message: "old"}, {At: quiet, Message: "recent"}}
+	room.Lock()
+	if err := h.store.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2"))); err != nil {
+		t.Fatalf("append join: %v", err)
+	}
+	room.Unlock()
+	h.telemetry.record(room.Code, 0, 0, 0, "firefox", false, stale)
+	h.telemetry.record("OTHER", 0, 0, 0, "firefox", false, quiet)
+
+	h.PruneHistory()
+	if len(room.GetLog()) != 2 {
+		t.Fatal("expected nothing pruned without a retention period")
+	}
+
+	h.config().Server.HistoryRetention = time.Hour
+	h.PruneHistory()
+	if got := room.GetLog(); len(got) != 1 || got[0].Message != "recent" {
+		t.Errorf("expected only the recent entry kept, got %+v", got)
+	}
+	kept := h.telemetry.snapshot(func(string) bool { return true })
+	if _, ok := kept[room.Code]; ok || len(kept) != 1 {
+		t.Errorf("expected only recent telemetry kept, got %+v", kept)
+	}
+	if events, _ := h.store.Events(room.Code, 0); len(events) != 1 {
+		t.Fatalf("expected Bob's fresh join kept in the journal, got %+v", events)
+	}
+	fake.Advance(2 * time.Hour)
+	h.PruneHistory()
+	if events, _ := h.store.Events(room.Code, 0); len(events) != 0 {
+		t.Errorf("expected Bob's join pruned once stale, got %+v", events)
+	}
+}
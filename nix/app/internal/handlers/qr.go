@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"encoding/base64"
-	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -11,12 +10,24 @@ import (
 // RoomQRCode serves a normal PNG image for the room join QR code.
 func (h *Handler) RoomQRCode(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
-	if _, err := h.store.GetRoom(roomCode); err != nil {
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
 		http.NotFound(w, r)
 		return
 	}
 
-	qrURL := fmt.Sprintf("%s/room/%s", getBaseURL(r), roomCode)
+	qrURL := getBaseURL(r) + h.roomPath(room)
+	if room.HasJoinPassword() {
+		// The code of a private room carries a one-time token in place of
+		// its password, so only those already in the room may show it
+		sessionID, ok := h.sessionID(r)
+		if !ok || sessionPlayer(r, room, sessionID) == nil {
+			http.NotFound(w, r)
+			return
+		}
+		qrURL += "?join=" + room.IssueJoinToken()
+		h.store.UpdateRoom(room)
+	}
 	encodedPNG, err := generateQRCode(qrURL)
 	if err != nil {
 		http.Error(w, "Failed to generate QR code", http.StatusInternalServerError)
@@ -42,7 +42,7 @@ func TestJoinRoomPostWithRandomName(t *testing.T) {
 	room, _ := h.store.CreateRoom()
 
 	// Join with empty name
-	formData := "room_code=" + room.Code + "&player_name="
+	formData := "room_code=" + room.Code + "&player_name=&form_elapsed=5000"
 	req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	w := httptest.NewRecorder()
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+)
+
+func TestRebalanceStrategyAppliesToCustomPlayerCountChanges(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	room.RoleConfig.PresetName = "custom"
+	room.RoleConfig.MaxPlayers = 5
+	for role, count := range map[string]int{"Leader": 1, "Guardian": 2, "Assassin": 1, "Traitor": 1} {
+		room.RoleConfig.RoleTypes[role].Count = count
+	}
+	path := "/room/" + room.Code + "/config/"
+
+	w := postPhaseForm(router, path+"rebalance-strategy", "operator-session", url.Values{"strategy": {"bogus"}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an unknown strategy to be rejected, got %d", w.Code)
+	}
+
+	postPhaseForm(router, path+"player-count/increment", "operator-session", nil)
+	if got := room.RoleConfig.RoleTypes["Guardian"].Count; got != 2 {
+		t.Fatalf("expected explicit counts to be kept without a strategy, got %d Guardians", got)
+	}
+
+	w = postPhaseForm(router, path+"rebalance-strategy", "operator-session", url.Values{"strategy": {game.RebalanceGuardianFirst}})
+	if w.Code != http.StatusOK || room.RoleConfig.RebalanceStrategy != game.RebalanceGuardianFirst {
+		t.Fatalf("expected the strategy to be saved, got %d: %q", w.Code, room.RoleConfig.RebalanceStrategy)
+	}
+	if !strings.Contains(w.Body.String(), "Will add 2 Guardians") {
+		t.Errorf("expected the increment tooltip to preview the rebalance, got %s", w.Body.String())
+	}
+
+	postPhaseForm(router, path+"player-count/increment", "operator-session", nil)
+	if room.RoleConfig.MaxPlayers != 7 {
+		t.Fatalf("expected 7 players, got %d", room.RoleConfig.MaxPlayers)
+	}
+	total := 0
+	for _, typeConfig := range room.RoleConfig.RoleTypes {
+		total += typeConfig.Count
+	}
+	if total != 7 || room.RoleConfig.RoleTypes["Guardian"].Count != 4 {
+		t.Errorf("expected Guardians to absorb the new players, got %d Guardians of %d roles", room.RoleConfig.RoleTypes["Guardian"].Count, total)
+	}
+}
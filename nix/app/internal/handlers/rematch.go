@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"treacherest/internal/game"
+)
+
+// RestartGame takes a game in play or over back to the lobby for a rematch.
+// Players keep their seats and the room its setup, so the table plays again
+// without a new room or another round of QR scans; every page follows the
+// room back to its lobby.
+func (h *Handler) RestartGame(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.Append(room, game.GameRestartedEvent()); errors.Is(err, game.ErrNoGameToRestart) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		log.Printf("❌ Failed to restart room %s: %v", room.Code, err)
+		http.Error(w, "Failed to restart the game", http.StatusInternalServerError)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	actor := h.requestActor(r, room)
+	h.eventBus.Publish(Event{
+		Type:     EventGameRestarted,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    actor,
+	})
+	log.Printf("🔁 %s restarted the game in room %s for a rematch", actor, room.Code)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestRestartTakesTheTableBackToTheLobbyForARematch(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+
+	s := newScenario(t, h).Join("Alice").Join("Bob").Join("Carol")
+	room, _ := h.store.GetRoom(s.RoomCode)
+	if w := s.Client(testkit.OperatorName).Post("/room/"+room.Code+"/restart", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected no restart before a game, got %d", w.Code)
+	}
+
+	s.Start().ExpectEvent(string(EventGameStarted))
+	room.Lock()
+	h.beginPlaying(room, EventActor{})
+	room.Unlock()
+	s.ExpectEvent(string(EventGamePlaying))
+	if w := s.Client("Alice").Post("/room/"+room.Code+"/restart", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player's restart to be refused, got %d", w.Code)
+	}
+
+	s.Post(testkit.OperatorName, "/room/{code}/restart", nil).
+		ExpectEvent(string(EventGameRestarted)).
+		ExpectFragmentContains("#page-body", "lobby-container").
+		ExpectStreamContains("history.replaceState(null, '', '/room/" + room.Code + "')")
+	if room.State != game.StateLobby || len(room.GetActivePlayers()) != 3 || room.GetPlayer(s.Client("Alice").PlayerID()).Role != nil {
+		t.Fatalf("expected everyone seated in the lobby without roles, got %s", room.State)
+	}
+
+	// The same streams follow the next game
+	s.Start().ExpectEvent(string(EventGameStarted))
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		stream := s.Stream(name)
+		deadline := time.Now().Add(2 * time.Second)
+		for strings.Count(stream.Data(), "Revealing roles in") < 2 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if strings.Count(stream.Data(), "Revealing roles in") < 2 || stream.Closed() {
+			t.Fatalf("expected %s's page to swap in the second game, got %s", name, stream.Data())
+		}
+	}
+}
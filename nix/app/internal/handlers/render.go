@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+
+	"github.com/a-h/templ"
+)
+
+// renderFallbackMessage is shown in place of a fragment whose component failed to render
+const renderFallbackMessage = "Something went wrong. Refresh the page."
+
+// renderComponent renders a templ component to string, turning a panic inside
+// the component (e.g. a nil pointer) into an error instead of killing the caller
+func renderComponent(component templ.Component) (html string, err error) {
+	buf := &bytes.Buffer{}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			html = ""
+			err = fmt.Errorf("panic in %s: %v", panickingComponent(), recovered)
+		}
+	}()
+
+	if err := component.Render(context.Background(), buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderFragment renders a component for an SSE patch into selector. When the
+// component fails, the failure is logged with the component name and room, and
+// a fallback carrying the selector's id is returned so the stream stays open and
+// later patches still find their target. Pass an empty selector when the caller
+// wraps the fragment in the target element itself.
+func renderFragment(component templ.Component, selector, roomCode string) string {
+	html, err := renderComponent(component)
+	if err == nil {
+		return html
+	}
+
+	log.Printf("❌ Render failed (room %s, target %q): %v", roomCode, selector, err)
+	return renderFallback(selector)
+}
+
+// renderToString renders a component that isn't tied to a room
+func renderToString(component templ.Component) string {
+	return renderFragment(component, "", "-")
+}
+
+func renderFallback(selector string) string {
+	idAttr := ""
+	if id, ok := strings.CutPrefix(selector, "#"); ok && id != "" {
+		idAttr = fmt.Sprintf(` id="%s"`, templ.EscapeString(id))
+	}
+	return fmt.Sprintf(`<div%s role="alert" class="alert alert-warning">%s</div>`, idAttr, renderFallbackMessage)
+}
+
+// panickingComponent names the innermost view function on the panicking stack,
+// e.g. "pages.KnownInfoPanel". It must be called from the deferred recover.
+func panickingComponent() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.Contains(frame.Function, "/internal/views/") {
+			name := frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+			if i := strings.Index(name, ".func"); i > 0 {
+				name = name[:i]
+			}
+			return name
+		}
+		if !more {
+			return "templ component"
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/a-h/templ"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+func TestRenderFragmentRecoversFromPanics(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	var player *game.Player
+	panicking := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, "<div>"+player.Name+"</div>")
+		return err
+	})
+
+	html := renderFragment(panicking, "#game-container", "ABCDE")
+	if !strings.Contains(html, `id="game-container"`) || !strings.Contains(html, renderFallbackMessage) {
+		t.Fatalf("expected a fallback that keeps the target id, got %s", html)
+	}
+	if !strings.Contains(logs.String(), "room ABCDE") || !strings.Contains(logs.String(), "nil pointer") {
+		t.Fatalf("expected the failure to be logged with room context, got %s", logs.String())
+	}
+}
+
+func TestRenderFragmentReportsErrors(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	failing := templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		io.WriteString(w, "<div>partial")
+		return errors.New("boom")
+	})
+
+	html := renderFragment(failing, "", "ABCDE")
+	if strings.Contains(html, "partial") || strings.Contains(html, "id=") {
+		t.Fatalf("expected an id-less fallback without the partial output, got %s", html)
+	}
+	if !strings.Contains(logs.String(), "boom") {
+		t.Fatalf("expected the error to be logged, got %s", logs.String())
+	}
+}
+
+func TestRenderFragmentNamesPanickingComponent(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	// A player slice with a nil entry makes the roster component dereference nil
+	room := &game.Room{Code: "ABCDE", Players: map[string]*game.Player{"p1": nil}}
+	renderFragment(pages.OverlayContent(room), "#overlay-content", room.Code)
+	if !strings.Contains(logs.String(), "panic in pages.OverlayContent") {
+		t.Fatalf("expected the panicking component to be named, got %s", logs.String())
+	}
+}
+
+func TestRenderFragmentPassesThroughSuccess(t *testing.T) {
+	room := &game.Room{Code: "ABCDE", Players: make(map[string]*game.Player)}
+	html := renderFragment(pages.OverlayContent(room), "#overlay-content", room.Code)
+	if !strings.Contains(html, `id="overlay-content"`) || strings.Contains(html, renderFallbackMessage) {
+		t.Fatalf("expected the rendered component, got %s", html)
+	}
+}
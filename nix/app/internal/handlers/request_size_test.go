@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	localMiddleware "treacherest/internal/middleware"
+	"treacherest/internal/testkit"
+)
+
+const testMaxRequestSize = 256
+
+// oversizedRequest builds a POST whose body is over testMaxRequestSize.
+// streamed hides the length, as a chunked upload would, so the limit is only
+// hit while reading.
+func oversizedRequest(path, contentType, body string, streamed bool) *http.Request {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if streamed {
+		req.ContentLength = -1
+	}
+	return req
+}
+
+func assertRequestTooLarge(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d: %s", w.Code, w.Body.String())
+	}
+	var body localMiddleware.RequestTooLarge
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("expected a JSON error body: %v", err)
+	}
+	if body.Error != "request_too_large" || body.MaxBytes != testMaxRequestSize {
+		t.Errorf("unexpected error body %+v", body)
+	}
+}
+
+func TestConfigEndpointsRefuseOversizedBodies(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.MaxRequestSize = testMaxRequestSize
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	code := operator.RoomCode
+	room, _ := h.store.GetRoom(code)
+	padding := strings.Repeat("x", 2*testMaxRequestSize)
+
+	t.Run("declared JSON body", func(t *testing.T) {
+		body := `{"allowLeaderless":true,"padding":"` + padding + `"}`
+		assertRequestTooLarge(t, operator.Do(oversizedRequest("/room/"+code+"/config/leaderless", "application/json", body, false)))
+		if room.RoleConfig.AllowLeaderlessGame {
+			t.Error("an oversized request must not change the config")
+		}
+	})
+
+	t.Run("streamed JSON body", func(t *testing.T) {
+		body := `{"hideDistribution":true,"padding":"` + padding + `"}`
+		assertRequestTooLarge(t, operator.Do(oversizedRequest("/room/"+code+"/config/hide-distribution", "application/json", body, true)))
+	})
+
+	t.Run("streamed form body", func(t *testing.T) {
+		body := url.Values{"enabled": {"true"}, "padding": {padding}}.Encode()
+		assertRequestTooLarge(t, operator.Do(oversizedRequest("/room/"+code+"/config/phases", "application/x-www-form-urlencoded", body, true)))
+		if room.PhaseSettings.Enabled {
+			t.Error("an oversized form must not change the config")
+		}
+	})
+
+	t.Run("small body still accepted", func(t *testing.T) {
+		w := operator.Post("/room/"+code+"/config/phases", url.Values{"enabled": {"true"}})
+		if w.Code != http.StatusOK || !room.PhaseSettings.Enabled {
+			t.Errorf("expected the phase settings to save, got %d", w.Code)
+		}
+	})
+}
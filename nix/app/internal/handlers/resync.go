@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+	"treacherest/internal/views/pages"
+)
+
+// sendConnectionLost pushes the connection banner as a stream's last
+// fragment, so a player whose stream the server closes sees why instead of a
+// frozen page. After a failed keepalive the write usually fails too.
+func (h *Handler) sendConnectionLost(sse *datastar.ServerSentEventGenerator, page PageType, roomCode, reason string) {
+	html := renderToString(components.ConnectionBanner(roomCode, reason))
+	if err := h.patchElements(sse, page, html, "#connection-banner"); err != nil {
+		log.Printf("📡 Could not send %s connection banner for room %s: %v", reason, roomCode, err)
+	}
+}
+
+// ResyncRenderer renders the authoritative full state of a room for one
+// player. The manual /resync endpoint sends it as a page body; a reconnecting
+// stream whose Last-Event-ID replay can't bridge the gap sends it as one
+// fragment set instead of replaying everything.
+type ResyncRenderer struct {
+	h *Handler
+}
+
+func (h *Handler) resyncRenderer() ResyncRenderer {
+	return ResyncRenderer{h: h}
+}
+
+// Body returns the lobby or game page body the player should see now
+func (rr ResyncRenderer) Body(room *game.Room, player *game.Player) templ.Component {
+	if room.State == game.StateLobby {
+		return pages.LobbyBody(room, player, rr.h.roomConfig(room), rr.h.cardService)
+	}
+	return pages.GameBody(room, player)
+}
+
+// Patch sends the same state as Body to a stream on page: the page content,
+// the signals that drive it, and a cleared connection banner. A lobby page
+// whose game has started is sent on to the game page instead.
+func (rr ResyncRenderer) Patch(sse *datastar.ServerSentEventGenerator, page PageType, room *game.Room, player *game.Player, eventID string) error {
+	h := rr.h
+	eventOpt := datastar.WithPatchElementsEventID(eventID)
+
+	if page == PageLobby && room.State != game.StateLobby {
+		return sse.ExecuteScript("window.location.href = '/game/" + room.Code + "'")
+	}
+	if err := h.patchElements(sse, page, renderToString(components.ConnectionBanner(room.Code, "")), "#connection-banner"); err != nil {
+		return err
+	}
+
+	if page == PageLobby {
+		html := renderFragment(pages.LobbyContent(room, player, h.roomConfig(room), h.cardService), "#lobby-content", room.Code)
+		if err := h.patchElements(sse, page, html, "#lobby-content", datastar.WithModeInner(), eventOpt); err != nil {
+			return err
+		}
+		roleService := game.NewRoleConfigService(h.roomConfig(room))
+		viewer := components.NewViewerContext(room, player)
+		return sse.MarshalAndPatchSignals(lobbyValidationSignals(room, viewer, room.GetValidationState(roleService)))
+	}
+
+	html := renderFragment(pages.GameContent(room, player), "#game-container", room.Code)
+	if err := h.patchElements(sse, page, html, "#game-container", eventOpt); err != nil {
+		return err
+	}
+	if err := patchCountdown(sse, room.CountdownRemaining); err != nil {
+		return err
+	}
+	h.emitStateBackup(sse, room)
+	return nil
+}
+
+// ResyncRoom returns the player's current lobby or game page body, for the
+// retry button on the connection banner. Replacing the body reopens its stream.
+func (h *Handler) ResyncRoom(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	playerCookie, err := r.Cookie("player_" + roomCode)
+	if err != nil {
+		http.Error(w, "Not in room", http.StatusUnauthorized)
+		return
+	}
+	player := room.GetPlayer(playerCookie.Value)
+	if player == nil {
+		http.Error(w, "Player not found", http.StatusUnauthorized)
+		return
+	}
+	renderPlayer := h.effectivePlayerForRender(r, room, player)
+	if renderPlayer == nil {
+		http.Error(w, "Player not found", http.StatusUnauthorized)
+		return
+	}
+
+	// Datastar patches HTML responses using these headers
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("datastar-selector", "#page-body")
+	w.Header().Set("datastar-mode", "replace")
+	h.resyncRenderer().Body(room, renderPlayer).Render(r.Context(), w)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+)
+
+func TestResyncRoomReturnsCurrentPageBody(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	w := player.Get("/room/" + roomCode + "/resync")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("datastar-selector") != "#page-body" || w.Header().Get("datastar-mode") != "replace" {
+		t.Errorf("expected the body to replace #page-body, got headers %v", w.Header())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`id="page-body"`, "/sse/room/" + roomCode, `id="lobby-container"`, `id="connection-banner"`, "Bob"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in the lobby resync body", want)
+		}
+	}
+	if strings.Contains(body, "<html") {
+		t.Error("resync should return only the page body")
+	}
+
+	operator.StartGame()
+	body = player.Get("/room/" + roomCode + "/resync").Body.String()
+	if !strings.Contains(body, "/sse/room/"+roomCode) || !strings.Contains(body, `id="game-container"`) {
+		t.Errorf("expected the game body once the game started, got %s", body)
+	}
+
+	if w := testkit.NewClient(t, router).Get("/room/" + roomCode + "/resync"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a stranger to be refused, got %d", w.Code)
+	}
+	if w := player.Get("/room/NOPE1/resync"); w.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown room to 404, got %d", w.Code)
+	}
+}
+
+func TestStreamsSendConnectionBannerBeforeClosing(t *testing.T) {
+	t.Run("room gone", func(t *testing.T) {
+		h := newTestHandler()
+		fake := withFakeClock(h)
+		router := newTestRouter(h)
+
+		operator := testkit.CreateRoom(t, router, "Alice", false)
+		player := testkit.JoinRoom(t, router, operator.RoomCode, "Bob")
+		stream := player.OpenSSE("/sse/lobby/" + operator.RoomCode)
+		defer stream.Close()
+
+		fake.BlockUntil(1)
+		h.store.DeleteRoom(operator.RoomCode)
+		fake.Advance(15 * time.Second)
+
+		if !stream.WaitFor(`data-reason="room_gone"`, 2*time.Second) {
+			t.Fatalf("expected a room_gone banner, got %s", stream.Data())
+		}
+		if strings.Contains(stream.Data(), "connection-retry") {
+			t.Error("a closed room has nothing to resync")
+		}
+	})
+
+	t.Run("player removed", func(t *testing.T) {
+		h := newTestHandler()
+		router := newTestRouter(h)
+
+		operator := testkit.CreateRoom(t, router, "Alice", false)
+		roomCode := operator.RoomCode
+		player := testkit.JoinRoom(t, router, roomCode, "Bob")
+		stream := player.OpenSSE("/sse/game/" + roomCode)
+		defer stream.Close()
+		operator.StartGame()
+		if !stream.WaitFor("game-container", 2*time.Second) {
+			t.Fatalf("expected the initial game render, got %s", stream.Data())
+		}
+
+		room, _ := h.store.GetRoom(roomCode)
+		room.Lock()
+		room.RemovePlayer(player.PlayerID())
+		room.Unlock()
+		h.eventBus.Publish(Event{Type: EventPlayerLeft, RoomCode: roomCode})
+
+		if !stream.WaitFor(`data-reason="player_removed"`, 2*time.Second) {
+			t.Fatalf("expected a player_removed banner, got %s", stream.Data())
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"treacherest/internal/game"
+)
+
+// revealSyncLead is how long before a countdown's reveal the roles are sent.
+// Pages hold them hidden until the room's RevealAt on their synced clock, so
+// every player sees theirs at the same instant even on a slower connection.
+const revealSyncLead = 750 * time.Millisecond
+
+// scheduleRevealEnd forgets the room's reveal time once it has passed, so
+// later renders show roles without the hidden gate. The caller holds the
+// room's lock.
+func (h *Handler) scheduleRevealEnd(room *game.Room) {
+	if room.RevealAt.IsZero() {
+		return
+	}
+	revealAt := room.RevealAt
+	h.clock.AfterFunc(max(revealAt.Sub(h.clock.Now()), 0), func() {
+		room.Lock()
+		defer room.Unlock()
+		// A later game in the same room has its own reveal
+		if !room.RevealAt.Equal(revealAt) {
+			return
+		}
+		room.RevealAt = time.Time{}
+		h.store.UpdateRoom(room)
+	})
+}
+
+// recordReveal adds one page's reveal skew and clock sync round trip to
+// roomCode's totals
+func (t *sseTelemetry) recordReveal(roomCode string, skew, rtt time.Duration, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.rooms[roomCode]
+	if stats == nil {
+		stats = &RoomSSETelemetry{Browsers: make(map[string]int)}
+		t.rooms[roomCode] = stats
+	}
+	skewMs := skew.Milliseconds()
+	if stats.RevealReports == 0 || skewMs < stats.EarliestRevealMs {
+		stats.EarliestRevealMs = skewMs
+	}
+	if stats.RevealReports == 0 || skewMs > stats.LatestRevealMs {
+		stats.LatestRevealMs = skewMs
+	}
+	stats.MaxRevealRTTMs = max(stats.MaxRevealRTTMs, rtt.Milliseconds())
+	stats.RevealReports++
+	stats.LastReportAt = now
+}
+
+// ServerClock answers with the server's time in Unix milliseconds. Pages
+// time a few round trips to it to find their clock's offset from the
+// server's before a scheduled reveal.
+func (h *Handler) ServerClock(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]int64{"now": h.clock.Now().UnixMilli()})
+}
+
+// ReportRevealTelemetry takes how far from the room's scheduled reveal a
+// page showed its roles, on the page's synced clock, and the round trip its
+// clock sync measured, sent with navigator.sendBeacon right after the reveal
+func (h *Handler) ReportRevealTelemetry(w http.ResponseWriter, r *http.Request) {
+	roomCode := strings.ToUpper(strings.TrimSpace(r.FormValue("room")))
+	if _, err := h.store.GetRoom(roomCode); err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	skewMs, err := strconv.ParseInt(r.FormValue("skewMs"), 10, 64)
+	if err != nil {
+		http.Error(w, "skewMs must be a number", http.StatusBadRequest)
+		return
+	}
+	skew := time.Duration(skewMs) * time.Millisecond
+	skew = min(max(skew, -maxTelemetryGap), maxTelemetryGap)
+	rtt, err := telemetryGap(r.FormValue("rttMs"))
+	if err != nil {
+		http.Error(w, "rttMs must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	h.telemetry.recordReveal(roomCode, skew, rtt, h.clock.Now())
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+func renderGameContent(t *testing.T, room *game.Room, player *game.Player) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := pages.GameContent(room, player).Render(context.Background(), &buf); err != nil {
+		t.Fatalf("render game content: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCountdownSchedulesRevealInAdvance(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	room, alice := newPhaseTestRoom(t, h)
+
+	room.Lock()
+	h.beginStart(room, EventActor{})
+	room.Unlock()
+
+	room.RLock()
+	defer room.RUnlock()
+	want := room.StartedAt.Add(countdownSeconds*time.Second + revealSyncLead)
+	if !room.RevealAt.Equal(want) {
+		t.Fatalf("expected the reveal at %v, got %v", want, room.RevealAt)
+	}
+	html := renderGameContent(t, room, alice)
+	if !strings.Contains(html, `data-reveal-at="`+strconv.FormatInt(want.UnixMilli(), 10)+`"`) {
+		t.Errorf("expected the countdown to carry the reveal time, got %s", html)
+	}
+	privy, _, _ := strings.Cut(html[strings.Index(html, `id="zone-privy"`):], `id="zone-notices"`)
+	if strings.Contains(privy, alice.Role.Name) {
+		t.Errorf("expected no role in the privy zone during the countdown, got %s", privy)
+	}
+}
+
+func TestRolesStayGatedUntilRevealAt(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	room, alice := newPhaseTestRoom(t, h)
+	room.State = game.StateCountdown
+	room.RevealAt = fake.Now().Add(revealSyncLead)
+
+	room.Lock()
+	h.beginPlaying(room, EventActor{})
+	room.Unlock()
+
+	html := renderGameContent(t, room, alice)
+	if !strings.Contains(html, `data-reveal-at="`+strconv.FormatInt(room.RevealAt.UnixMilli(), 10)+`" style="visibility: hidden"`) {
+		t.Fatalf("expected roles sent ahead of the reveal to be hidden, got %s", html)
+	}
+	if !strings.Contains(html, alice.Role.Name) {
+		t.Errorf("expected the role to arrive ahead of the reveal, got %s", html)
+	}
+
+	fake.Advance(revealSyncLead)
+	if !room.RevealAt.IsZero() {
+		t.Fatalf("expected the reveal time to be forgotten once passed, got %v", room.RevealAt)
+	}
+	if html := renderGameContent(t, room, alice); strings.Contains(html, "data-reveal-at") {
+		t.Errorf("expected no gate after the reveal, got %s", html)
+	}
+}
+
+func TestConfirmStartHoldsNothingBack(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	room, _ := newPhaseTestRoom(t, h)
+	room.RevealAt = time.Now()
+	room.StartRitual = game.StartRitualSettings{Ritual: game.StartRitualConfirm}
+
+	h.beginStart(room, EventActor{})
+	if !room.RevealAt.IsZero() {
+		t.Errorf("expected a confirm start to show roles right away, got a reveal at %v", room.RevealAt)
+	}
+}
+
+func TestServerClock(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+
+	w := httptest.NewRecorder()
+	newTestRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/telemetry/clock", nil))
+	var body struct {
+		Now int64 `json:"now"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode clock: %v", err)
+	}
+	if body.Now != fake.Now().UnixMilli() {
+		t.Errorf("expected %d, got %d", fake.Now().UnixMilli(), body.Now)
+	}
+	if w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected the clock not to be cached, got %q", w.Header().Get("Cache-Control"))
+	}
+}
+
+func TestRevealTelemetryTracksSpread(t *testing.T) {
+	h := newTestHandler()
+	h.SetAdminToken("secret")
+	room, _ := h.store.CreateRoom()
+
+	for _, form := range []url.Values{
+		{"room": {room.Code}, "skewMs": {"40"}, "rttMs": {"120"}},
+		{"room": {room.Code}, "skewMs": {"-15"}, "rttMs": {"30"}},
+		{"room": {room.Code}, "skewMs": {"5"}},
+	} {
+		w := httptest.NewRecorder()
+		h.ReportRevealTelemetry(w, telemetryRequest(form, ""))
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	for name, form := range map[string]url.Values{
+		"unknown room": {"room": {"NOPE1"}, "skewMs": {"1"}},
+		"missing skew": {"room": {room.Code}},
+		"negative rtt": {"room": {room.Code}, "skewMs": {"1"}, "rttMs": {"-1"}},
+	} {
+		w := httptest.NewRecorder()
+		h.ReportRevealTelemetry(w, telemetryRequest(form, ""))
+		if w.Code < 400 {
+			t.Errorf("%s: expected the report to be refused, got %d", name, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	admin := httptest.NewRequest("GET", "/admin/telemetry", nil)
+	admin.Header.Set("Authorization", "Bearer secret")
+	h.GetSSETelemetry(w, admin)
+	var body struct {
+		Rooms map[string]RoomSSETelemetry `json:"rooms"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode telemetry: %v", err)
+	}
+	stats := body.Rooms[room.Code]
+	if stats.RevealReports != 3 || stats.EarliestRevealMs != -15 || stats.LatestRevealMs != 40 || stats.MaxRevealRTTMs != 120 {
+		t.Errorf("unexpected reveal totals %+v", stats)
+	}
+	if stats.Reports != 0 {
+		t.Errorf("expected reveal reports apart from reconnect reports, got %+v", stats)
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRoleTypeCountRespectsConfiguredBounds(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	leader := room.RoleConfig.RoleTypes["Leader"]
+	leader.Count = 1
+	path := "/room/" + room.Code + "/config/role-type/Leader/"
+
+	w := postPhaseForm(router, path+"increment", "operator-session", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "At most 1 Leader allowed") {
+		t.Fatalf("expected the Leader maximum to be enforced, got %d: %s", w.Code, w.Body.String())
+	}
+	w = postPhaseForm(router, path+"decrement", "operator-session", nil)
+	if !strings.Contains(w.Body.String(), "At least 1 Leader required") {
+		t.Fatalf("expected the Leader minimum to be enforced, got %s", w.Body.String())
+	}
+	if leader.Count != 1 {
+		t.Fatalf("expected the Leader count to stay 1, got %d", leader.Count)
+	}
+
+	room.RoleConfig.AllowLeaderlessGame = true
+	postPhaseForm(router, path+"decrement", "operator-session", nil)
+	if leader.Count != 0 {
+		t.Errorf("expected leaderless games to allow zero Leaders, got %d", leader.Count)
+	}
+}
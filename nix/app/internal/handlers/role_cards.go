@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"net/http"
+	"strconv"
+	"strings"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+	"unicode/utf8"
+)
+
+// GetRoleCards serves one page of a role type's cards for the role config,
+// optionally filtered by the card search query q. The page shown is kept on
+// the room so later role config re-renders keep it.
+func (h *Handler) GetRoleCards(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	roleType := chi.URLParam(r, "roleType")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if !h.isRoomCreator(r, room) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	typeConfig, exists := room.RoleConfig.RoleTypes[roleType]
+	cards := h.getCardsForRoleType(roleType)
+	if !exists || cards == nil {
+		http.Error(w, "Invalid role type", http.StatusBadRequest)
+		return
+	}
+
+	view := game.CardListView{Page: 1, Query: strings.TrimSpace(r.URL.Query().Get("q"))}
+	if utf8.RuneCountInString(view.Query) > maxCardSearchQuery {
+		http.Error(w, "Search query too long", http.StatusBadRequest)
+		return
+	}
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		view.Page, err = strconv.Atoi(raw)
+		if err != nil || view.Page < 1 {
+			http.Error(w, "page must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+	// Store the clamped page, so a stale Next link cannot run past the end
+	view.Page = components.NewRoleCardPage(h.cardService, cards, view).Page
+
+	if room.CardListViews == nil {
+		room.CardListViews = make(map[string]game.CardListView)
+	}
+	room.CardListViews[roleType] = view
+
+	viewer := h.viewerContext(r, room)
+	sse := datastar.NewSSE(w, r)
+	listID := "#" + components.RoleCardsID(roleType)
+	sse.PatchElements(renderFragment(components.RoleTypeCards(viewer, room, roleType, typeConfig, h.cardService, cards), listID, room.Code),
+		datastar.WithSelector(listID))
+	modalsID := "#" + components.RoleCardModalsID(roleType)
+	sse.PatchElements(renderFragment(components.RoleTypeCardModals(viewer, room, roleType, h.cardService, cards), modalsID, room.Code),
+		datastar.WithSelector(modalsID))
+}
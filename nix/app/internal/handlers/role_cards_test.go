@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+)
+
+func TestGetRoleCards(t *testing.T) {
+	h := newTestHandler()
+	h.cardService = game.NewSandboxCardService(components.RoleCardPageSize + 2)
+	operator, room := newConcurrencyRoom(t, h)
+	cardsURL := "/room/" + room.Code + "/config/cards/"
+
+	lobby := renderToString(components.RoleConfigurationNew(components.ViewerContext{CanControl: true}, room, h.config(), h.cardService, components.PlayerCountDisplay{}))
+	if strings.Contains(lobby, "card-Guardian-sandbox-guardian-1") {
+		t.Fatal("expected the initial role config to leave the card lists unloaded")
+	}
+	if !strings.Contains(lobby, `id="role-cards-Guardian"`) {
+		t.Fatal("expected a placeholder for the Guardian card list")
+	}
+
+	w := operator.do(context.Background(), "GET", cardsURL+"Guardian?page=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{"selector #role-cards-Guardian", "selector #role-card-modals-Guardian", "card-Guardian-sandbox-guardian-12", "Page 2 of 2"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the card page response", want)
+		}
+	}
+	if strings.Contains(body, "card-Guardian-sandbox-guardian-1\"") {
+		t.Error("expected the first page's cards to be left out of page 2")
+	}
+
+	// Re-renders keep the loaded page and leave other role types unloaded
+	lobby = renderToString(components.RoleConfigurationNew(components.ViewerContext{CanControl: true}, room, h.config(), h.cardService, components.PlayerCountDisplay{}))
+	if !strings.Contains(lobby, "card-Guardian-sandbox-guardian-12") || strings.Contains(lobby, "card-Leader-sandbox-leader-1") {
+		t.Error("expected a re-render to show only the loaded Guardian page")
+	}
+
+	w = operator.do(context.Background(), "GET", cardsURL+"Guardian?q=guardian+3", "")
+	if !strings.Contains(w.Body.String(), "card-Guardian-sandbox-guardian-3") || strings.Contains(w.Body.String(), "card-Guardian-sandbox-guardian-4") {
+		t.Errorf("expected the search to narrow the list to Sandbox Guardian 3: %s", w.Body.String())
+	}
+	if view := room.CardListViews["Guardian"]; view != (game.CardListView{Page: 1, Query: "guardian 3"}) {
+		t.Errorf("expected the filtered view to be kept, got %+v", view)
+	}
+
+	for path, want := range map[string]int{
+		cardsURL + "Wizard":               http.StatusBadRequest,
+		cardsURL + "Guardian?page=0":      http.StatusBadRequest,
+		"/room/NOPE1/config/cards/Leader": http.StatusNotFound,
+	} {
+		if w := operator.do(context.Background(), "GET", path, ""); w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", path, want, w.Code)
+		}
+	}
+
+	stranger := &roomClient{router: newTestRouter(h)}
+	if w := stranger.do(context.Background(), "GET", cardsURL+"Leader", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a non-creator to get 401, got %d", w.Code)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	datastar "github.com/starfederation/datastar-go/datastar"
-	"log"
+	"html"
 	"net/http"
 	"strings"
 	"treacherest/internal/game"
+	"treacherest/internal/logging"
+	localMiddleware "treacherest/internal/middleware"
 	"treacherest/internal/views/components"
+	"unicode"
+	"unicode/utf8"
 )
 
 // UpdateRolePreset updates the role preset for a room
@@ -43,31 +47,70 @@ func (h *Handler) UpdateRolePreset(w http.ResponseWriter, r *http.Request) {
 		// Keep current custom configuration
 		room.RoleConfig.PresetName = "custom"
 	} else {
-		// Load preset configuration using current player count from role config
-		playerCount := room.RoleConfig.MaxPlayers
-		if playerCount == 0 {
-			// Fallback to default game size if not set
-			playerCount = h.config.Server.DefaultGameSize
-		}
-		newConfig, err := h.roleConfigService.CreateFromPreset(presetName, playerCount)
-		if err != nil {
+		before := room.RoleConfig.Clone()
+		if err := h.applyRolePreset(room, presetName); err != nil {
 			http.Error(w, "Invalid preset", http.StatusBadRequest)
 			return
 		}
-		room.RoleConfig = newConfig
-		log.Printf("📊 Preset '%s' applied for room %s. New player count: %d", presetName, roomCode, room.RoleConfig.MaxPlayers)
+		room.KeepConfigBaseline(before)
 	}
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+}
+
+// DismissConfigChanges hides the role config changes since the last preset
+// switch from the Room Operator
+func (h *Handler) DismissConfigChanges(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if !h.isRoomCreator(r, room) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	room.DismissConfigChanges()
+	h.eventBus.Publish(Event{
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
+// applyRolePreset loads a named preset into the room's role configuration
+func (h *Handler) applyRolePreset(room *game.Room, presetName string) error {
+	// Load preset configuration using current player count from role config
+	playerCount := 0
+	if room.RoleConfig != nil {
+		playerCount = room.RoleConfig.MaxPlayers
+	}
+	if playerCount == 0 {
+		// Fallback to default game size if not set
+		playerCount = h.config().Server.DefaultGameSize
+	}
+	newConfig, err := h.roleConfigFor(room).CreateFromPreset(presetName, playerCount)
+	if err != nil {
+		return err
+	}
+	room.RoleConfig = newConfig
+	h.roomLogger(room.Code).Info("preset applied", "preset", presetName, "players", room.RoleConfig.MaxPlayers)
+	return nil
+}
+
 // ToggleRole enables/disables a role
 func (h *Handler) ToggleRole(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
@@ -108,11 +151,11 @@ func (h *Handler) updatePlayerLimits(room *game.Room) {
 // UpdateLeaderlessGame updates the leaderless game setting for a room
 func (h *Handler) UpdateLeaderlessGame(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
-	log.Printf("🔍 UpdateLeaderlessGame called for room: %s", roomCode)
+	logger := h.requestLogger(r)
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
-		log.Printf("❌ Room not found: %s", roomCode)
+		logger.Info("room not found")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingLeaderless": false,
@@ -122,7 +165,7 @@ func (h *Handler) UpdateLeaderlessGame(w http.ResponseWriter, r *http.Request) {
 
 	// Verify player is room creator
 	if !h.isRoomCreator(r, room) {
-		log.Printf("❌ Unauthorized access attempt for room: %s", roomCode)
+		logger.Warn("unauthorized role config change")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingLeaderless": false,
@@ -139,7 +182,10 @@ func (h *Handler) UpdateLeaderlessGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		log.Printf("❌ Invalid request body for room %s: %v", roomCode, err)
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
+		logger.Info("invalid request body", logging.KeyError, err)
 		// Send SSE response to reset loading state
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
@@ -149,40 +195,34 @@ func (h *Handler) UpdateLeaderlessGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log state change
-	previousState := room.RoleConfig.AllowLeaderlessGame
-	leaderCount := 0
-	if leaderConfig, exists := room.RoleConfig.RoleTypes["Leader"]; exists {
-		leaderCount = leaderConfig.Count
-	}
-
-	log.Printf("📊 UpdateLeaderlessGame state change for room %s:", roomCode)
-	log.Printf("  - Previous AllowLeaderlessGame: %v", previousState)
-	log.Printf("  - New AllowLeaderlessGame: %v", body.AllowLeaderless)
-	log.Printf("  - Current Leader count: %d", leaderCount)
+	logger.Info("leaderless game setting changed", "from", room.RoleConfig.AllowLeaderlessGame, "to", body.AllowLeaderless, "leaders", room.RoleConfig.LeaderCount())
 
 	// Update the setting
 	room.RoleConfig.AllowLeaderlessGame = body.AllowLeaderless
 
-	// If disabling leaderless games and leader count is 0, set it to 1
-	if !body.AllowLeaderless {
-		if leaderConfig, exists := room.RoleConfig.RoleTypes["Leader"]; exists && leaderConfig.Count == 0 {
-			log.Printf("  - Auto-adding 1 Leader because leaderless disabled and leader count was 0")
-			leaderConfig.Count = 1
+	// Bring the leader count back within the policy, e.g. re-add the Leader
+	// when leaderless games are disabled
+	if leaderConfig, exists := room.RoleConfig.RoleTypes["Leader"]; exists {
+		policy := game.NewRoleConfigService(h.roomConfig(room)).LeaderPolicy(room.RoleConfig)
+		if normalized := policy.Normalize(leaderConfig.Count); normalized != leaderConfig.Count {
+			logger.Info("leader count adjusted for the leader policy", "from", leaderConfig.Count, "to", normalized)
+			leaderConfig.Count = normalized
 			room.RoleConfig.PresetName = "custom"
 		}
 	}
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
-	log.Printf("✅ UpdateLeaderlessGame completed for room %s", roomCode)
 
 	// Send immediate SSE response to reset loading state
 	h.sendUpdatedRoleConfigUI(w, r, room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
@@ -193,7 +233,6 @@ func (h *Handler) sendRoleValidation(w http.ResponseWriter, r *http.Request, roo
 
 // IncrementRoleTypeCount increments the count for a specific role type
 func (h *Handler) IncrementRoleTypeCount(w http.ResponseWriter, r *http.Request) {
-	log.Printf("DEBUG: IncrementRoleTypeCount called")
 	h.updateRoleTypeCount(w, r, "increment")
 }
 
@@ -234,7 +273,22 @@ func (h *Handler) updateRoleTypeCount(w http.ResponseWriter, r *http.Request, ac
 	if !exists {
 		// Return error fragment
 		sse := datastar.NewSSE(w, r)
-		sse.PatchElements(fmt.Sprintf(`<div class="alert alert-error">Invalid role type: %s</div>`, roleType),
+		sse.PatchElements(fmt.Sprintf(`<div class="alert alert-error">Invalid role type: %s</div>`, html.EscapeString(roleType)),
+			datastar.WithSelector("#role-validation"))
+		return
+	}
+
+	// Keep the count within the role's configured minimum and maximum
+	bounds := game.NewRoleConfigService(h.roomConfig(room)).RoleCountBounds(room.RoleConfig, roleType)
+	if action == "increment" && !bounds.CanIncrement(typeConfig.Count) {
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElements(fmt.Sprintf(`<div class="alert alert-error">At most %d %s allowed</div>`, bounds.Max, html.EscapeString(roleType)),
+			datastar.WithSelector("#role-validation"))
+		return
+	}
+	if action == "decrement" && typeConfig.Count > 0 && !bounds.CanDecrement(typeConfig.Count) {
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElements(fmt.Sprintf(`<div class="alert alert-error">At least %d %s required</div>`, bounds.Min, html.EscapeString(roleType)),
 			datastar.WithSelector("#role-validation"))
 		return
 	}
@@ -251,7 +305,7 @@ func (h *Handler) updateRoleTypeCount(w http.ResponseWriter, r *http.Request, ac
 		}
 	default:
 		// This should never happen with our current implementation
-		log.Printf("ERROR: Invalid action '%s'", action)
+		h.requestLogger(r).Error("invalid role count action", "action", action)
 		return
 	}
 
@@ -264,21 +318,23 @@ func (h *Handler) updateRoleTypeCount(w http.ResponseWriter, r *http.Request, ac
 		// Update MaxPlayers to match the new total (this trickles up from roles to player count)
 		room.RoleConfig.MaxPlayers = totalRoles
 		// Ensure we don't go below server minimums or above maximums
-		if room.RoleConfig.MaxPlayers < h.config.Server.MinPlayersPerRoom {
-			room.RoleConfig.MaxPlayers = h.config.Server.MinPlayersPerRoom
+		if room.RoleConfig.MaxPlayers < h.roomConfig(room).Server.MinPlayersPerRoom {
+			room.RoleConfig.MaxPlayers = h.roomConfig(room).Server.MinPlayersPerRoom
 		}
-		if room.RoleConfig.MaxPlayers > h.config.Server.MaxPlayersPerRoom {
-			room.RoleConfig.MaxPlayers = h.config.Server.MaxPlayersPerRoom
+		if room.RoleConfig.MaxPlayers > h.roomConfig(room).Server.MaxPlayersPerRoom {
+			room.RoleConfig.MaxPlayers = h.roomConfig(room).Server.MaxPlayersPerRoom
 		}
 	}
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
@@ -305,7 +361,10 @@ func (h *Handler) ToggleRoleCard(w http.ResponseWriter, r *http.Request) {
 	var body map[string]interface{}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		log.Printf("ERROR: Failed to decode body: %v", err)
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
+		h.requestLogger(r).Info("invalid request body", logging.KeyError, err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
@@ -314,29 +373,16 @@ func (h *Handler) ToggleRoleCard(w http.ResponseWriter, r *http.Request) {
 	cardId, _ := body["cardId"].(string)
 	enabled, _ := body["cardChecked"].(bool)
 
-	// Parse the card ID: "card-{roleType}-{cardAnchor}"
-	parts := strings.Split(cardId, "-")
-	if len(parts) < 3 || parts[0] != "card" {
-		log.Printf("ERROR: Invalid card ID format: %s", cardId)
+	roleType, cardAnchor, ok := parseCardID(cardId)
+	if !ok {
+		h.requestLogger(r).Info("invalid card ID format", "card_id", cardId)
 		http.Error(w, "Invalid card ID format", http.StatusBadRequest)
 		return
 	}
 
-	roleType := parts[1]
-	cardAnchor := strings.Join(parts[2:], "-") // Handle card names with hyphens
-
-	// Find the card name from the anchor
-	var cardName string
-	cards := h.getCardsForRoleType(roleType)
-	for _, card := range cards {
-		if card.NameAnchor == cardAnchor {
-			cardName = card.Name
-			break
-		}
-	}
-
+	cardName := h.cardNameForAnchor(roleType, cardAnchor)
 	if cardName == "" {
-		log.Printf("ERROR: Card not found for anchor: '%s' in role type: '%s'", cardAnchor, roleType)
+		h.requestLogger(r).Info("card not found", "anchor", cardAnchor, "role_type", roleType)
 		http.Error(w, "Card not found", http.StatusBadRequest)
 		return
 	}
@@ -344,7 +390,7 @@ func (h *Handler) ToggleRoleCard(w http.ResponseWriter, r *http.Request) {
 	// Validate role type exists
 	typeConfig, exists := room.RoleConfig.RoleTypes[roleType]
 	if !exists {
-		log.Printf("ERROR: Invalid role type received: '%s'", roleType)
+		h.requestLogger(r).Info("invalid role type", "role_type", roleType)
 		http.Error(w, "Invalid role type", http.StatusBadRequest)
 		return
 	}
@@ -358,13 +404,63 @@ func (h *Handler) ToggleRoleCard(w http.ResponseWriter, r *http.Request) {
 	typeConfig.EnabledCards[cardName] = enabled
 	room.RoleConfig.PresetName = "custom"
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+}
+
+// ToggleCardPack enables or disables every card of one card pack at once
+func (h *Handler) ToggleCardPack(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	packID := chi.URLParam(r, "packID")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	if !h.isRoomCreator(r, room) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+	if !h.cardService.HasPack(packID) {
+		http.Error(w, "Card pack not found", http.StatusNotFound)
+		return
+	}
+
+	var body struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	room.RoleConfig.SetPackEnabled(h.cardService, packID, body.Enabled)
+	room.RoleConfig.PresetName = "custom"
+
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventRoleConfigUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
@@ -378,8 +474,8 @@ func (h *Handler) updatePlayerLimitsNew(room *game.Room) {
 
 	// Min players is the total roles needed (or server minimum)
 	minPlayers := totalRoles
-	if minPlayers < h.config.Server.MinPlayersPerRoom {
-		minPlayers = h.config.Server.MinPlayersPerRoom
+	if minPlayers < h.roomConfig(room).Server.MinPlayersPerRoom {
+		minPlayers = h.roomConfig(room).Server.MinPlayersPerRoom
 	}
 
 	// Max players should be at least min players, up to server maximum
@@ -387,8 +483,8 @@ func (h *Handler) updatePlayerLimitsNew(room *game.Room) {
 	if maxPlayers < minPlayers {
 		maxPlayers = minPlayers
 	}
-	if maxPlayers > h.config.Server.MaxPlayersPerRoom {
-		maxPlayers = h.config.Server.MaxPlayersPerRoom
+	if maxPlayers > h.roomConfig(room).Server.MaxPlayersPerRoom {
+		maxPlayers = h.roomConfig(room).Server.MaxPlayersPerRoom
 	}
 
 	room.RoleConfig.MinPlayers = minPlayers
@@ -419,16 +515,7 @@ func (h *Handler) ToggleRoleCardFast(w http.ResponseWriter, r *http.Request) {
 	cardAnchor := r.Header.Get("X-Card-Anchor")
 	enabled := r.Header.Get("X-Enabled") == "true"
 
-	// Find the card name from the anchor
-	var cardName string
-	cards := h.getCardsForRoleType(roleType)
-	for _, card := range cards {
-		if card.NameAnchor == cardAnchor {
-			cardName = card.Name
-			break
-		}
-	}
-
+	cardName := h.cardNameForAnchor(roleType, cardAnchor)
 	if cardName == "" {
 		http.Error(w, "Card not found", http.StatusBadRequest)
 		return
@@ -486,6 +573,9 @@ func (h *Handler) ToggleRoleCardOptimistic(w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
@@ -497,6 +587,12 @@ func (h *Handler) ToggleRoleCardOptimistic(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Only real cards may become EnabledCards keys
+	if !h.isCardOfRoleType(body.RoleType, body.CardName) {
+		http.Error(w, "Card not found", http.StatusBadRequest)
+		return
+	}
+
 	// Check if EnabledCards is nil and initialize if needed
 	if typeConfig.EnabledCards == nil {
 		typeConfig.EnabledCards = make(map[string]bool)
@@ -506,6 +602,7 @@ func (h *Handler) ToggleRoleCardOptimistic(w http.ResponseWriter, r *http.Reques
 	typeConfig.EnabledCards[body.CardName] = body.Enabled
 	room.RoleConfig.PresetName = "custom"
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
 
 	// Send only validation update (checkbox already updated optimistically)
@@ -513,25 +610,66 @@ func (h *Handler) ToggleRoleCardOptimistic(w http.ResponseWriter, r *http.Reques
 
 	// If other players are watching, notify them
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
+// maxCardIDLength bounds card IDs well above the longest rendered one
+const maxCardIDLength = 128
+
+// parseCardID splits a role config checkbox ID, "card-{roleType}-{cardAnchor}"
+// as rendered by RoleConfigurationNew. Role types never contain hyphens, so
+// everything after the second one is the anchor.
+func parseCardID(cardID string) (roleType, cardAnchor string, ok bool) {
+	if len(cardID) > maxCardIDLength || !utf8.ValidString(cardID) {
+		return "", "", false
+	}
+	for _, ch := range cardID {
+		if unicode.IsSpace(ch) || unicode.IsControl(ch) {
+			return "", "", false
+		}
+	}
+
+	rest, found := strings.CutPrefix(cardID, "card-")
+	if !found {
+		return "", "", false
+	}
+	roleType, cardAnchor, found = strings.Cut(rest, "-")
+	if !found || roleType == "" || cardAnchor == "" {
+		return "", "", false
+	}
+	return roleType, cardAnchor, true
+}
+
+// cardNameForAnchor returns the name of the roleType card with the given
+// anchor, or "" if there is none
+func (h *Handler) cardNameForAnchor(roleType, cardAnchor string) string {
+	for _, card := range h.getCardsForRoleType(roleType) {
+		if card.NameAnchor == cardAnchor {
+			return card.Name
+		}
+	}
+	return ""
+}
+
+// isCardOfRoleType reports whether cardName is a roleType card
+func (h *Handler) isCardOfRoleType(roleType, cardName string) bool {
+	for _, card := range h.getCardsForRoleType(roleType) {
+		if card.Name == cardName {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handler) getCardsForRoleType(roleType string) []*game.Card {
-	switch roleType {
-	case "Leader":
-		return h.cardService.Leaders
-	case "Guardian":
-		return h.cardService.Guardians
-	case "Assassin":
-		return h.cardService.Assassins
-	case "Traitor":
-		return h.cardService.Traitors
-	default:
+	if h.cardService == nil {
 		return nil
 	}
+	return h.cardService.CardsOfType(game.RoleType(roleType))
 }
 
 func (h *Handler) sendRoleValidationNew(w http.ResponseWriter, r *http.Request, room *game.Room) {
@@ -540,7 +678,6 @@ func (h *Handler) sendRoleValidationNew(w http.ResponseWriter, r *http.Request,
 
 	// Validate role configuration
 	totalRoles := 0
-	hasLeader := false
 
 	for roleType, typeConfig := range room.RoleConfig.RoleTypes {
 		if typeConfig.Count == 0 {
@@ -561,19 +698,20 @@ func (h *Handler) sendRoleValidationNew(w http.ResponseWriter, r *http.Request,
 		}
 
 		totalRoles += typeConfig.Count
-
-		if roleType == "Leader" && typeConfig.Count > 0 {
-			hasLeader = true
-		}
 	}
 
-	// Check for required leader role
-	if !hasLeader {
-		if room.RoleConfig.AllowLeaderlessGame {
-			warnings = append(warnings, "⚠️ Leaderless game - All roles will be hidden. Guardians and Assassins must deduce their allies without a revealed Leader.")
-		} else {
-			errors = append(errors, "Leader role is required")
-		}
+	// Check the leader count against the leader policy
+	leaders := game.NewRoleConfigService(h.roomConfig(room)).LeaderPolicy(room.RoleConfig)
+	leaderCount := room.RoleConfig.LeaderCount()
+	switch {
+	case leaders.RunsLeaderless(leaderCount):
+		warnings = append(warnings, "⚠️ Leaderless game - All roles will be hidden. Guardians and Assassins must deduce their allies without a revealed Leader.")
+	case leaders.Missing(leaderCount) && leaders.Min() > 1:
+		errors = append(errors, fmt.Sprintf("At least %d Leader required", leaders.Min()))
+	case leaders.Missing(leaderCount):
+		errors = append(errors, "Leader role is required")
+	case leaders.TooMany(leaderCount):
+		errors = append(errors, fmt.Sprintf("At most %d Leader allowed", leaders.MaxCount))
 	}
 
 	// Check player count constraints
@@ -581,8 +719,8 @@ func (h *Handler) sendRoleValidationNew(w http.ResponseWriter, r *http.Request,
 	if totalRoles < activePlayerCount && activePlayerCount > 0 {
 		errors = append(errors, fmt.Sprintf("Not enough roles (%d) for current players (%d)", totalRoles, activePlayerCount))
 	}
-	if totalRoles > h.config.Server.MaxPlayersPerRoom {
-		errors = append(errors, fmt.Sprintf("Too many roles (%d), max is %d", totalRoles, h.config.Server.MaxPlayersPerRoom))
+	if totalRoles > h.roomConfig(room).Server.MaxPlayersPerRoom {
+		errors = append(errors, fmt.Sprintf("Too many roles (%d), max is %d", totalRoles, h.roomConfig(room).Server.MaxPlayersPerRoom))
 	}
 
 	// Check if we have enough roles for min players
@@ -613,36 +751,23 @@ func (h *Handler) sendRoleValidationNew(w http.ResponseWriter, r *http.Request,
 }
 
 func (h *Handler) sendUpdatedRoleConfigUI(w http.ResponseWriter, r *http.Request, room *game.Room) {
-	log.Printf("📤 sendUpdatedRoleConfigUI called for room %s", room.Code)
 	sse := datastar.NewSSE(w, r)
 
-	// Log current state
-	leaderCount := 0
-	if leaderConfig, exists := room.RoleConfig.RoleTypes["Leader"]; exists {
-		leaderCount = leaderConfig.Count
-	}
-	log.Printf("  - Current AllowLeaderlessGame: %v", room.RoleConfig.AllowLeaderlessGame)
-	log.Printf("  - Current Leader count: %d", leaderCount)
-
 	// Create player count display data
 	playerCountDisplay := h.createPlayerCountDisplay(room)
 
 	// Re-render just the role configuration component
-	component := components.RoleConfigurationNew(room, h.config, h.cardService, playerCountDisplay)
-	html := renderToString(component)
-
-	log.Printf("  - Sending role config update with selector #role-config")
+	component := components.RoleConfigurationNew(h.viewerContext(r, room), room, h.roomConfig(room), h.cardService, playerCountDisplay)
+	html := renderFragment(component, "#role-config", room.Code)
 
 	// Send the role config fragment
 	sse.PatchElements(html,
 		datastar.WithSelector("#role-config"))
 
 	// Also update validation state
-	roleService := game.NewRoleConfigService(h.config)
+	roleService := game.NewRoleConfigService(h.roomConfig(room))
 	validationState := room.GetValidationState(roleService)
 
-	log.Printf("  - Validation state: CanStart=%v, Message=%s", validationState.CanStart, validationState.ValidationMessage)
-
 	// Get auto-scale details for presets
 	var autoScaleDetails string
 	if room.RoleConfig.PresetName != "custom" && roleService != nil {
@@ -672,15 +797,15 @@ func (h *Handler) sendUpdatedRoleConfigUI(w http.ResponseWriter, r *http.Request
 		"fullyRandomRoles":         room.RoleConfig.FullyRandomRoles,     // Sync checkbox state
 	}
 
-	log.Printf("  - Sending signals: %+v", signals)
+	h.requestLogger(r).Debug("sent role config update", "can_start", validationState.CanStart, "validation_message", validationState.ValidationMessage, "leaderless", room.RoleConfig.AllowLeaderlessGame, "leaders", room.RoleConfig.LeaderCount())
 	sse.MarshalAndPatchSignals(signals)
 }
 
 func (h *Handler) createPlayerCountDisplay(room *game.Room) components.PlayerCountDisplay {
 	// Use RoleConfig.MaxPlayers which represents the current game size
 	currentPlayerCount := room.RoleConfig.MaxPlayers
-	canDecrement := currentPlayerCount > h.config.Server.MinPlayersPerRoom && currentPlayerCount > len(room.Players)
-	canIncrement := currentPlayerCount < h.config.Server.MaxPlayersPerRoom
+	canDecrement := currentPlayerCount > h.roomConfig(room).Server.MinPlayersPerRoom && currentPlayerCount > len(room.Players)
+	canIncrement := currentPlayerCount < h.roomConfig(room).Server.MaxPlayersPerRoom
 
 	// Build tooltips
 	incrementTooltip := "Increase player count"
@@ -689,24 +814,22 @@ func (h *Handler) createPlayerCountDisplay(room *game.Room) components.PlayerCou
 	if !canIncrement {
 		incrementTooltip = "Maximum player count reached"
 	} else if room.RoleConfig.PresetName == "custom" {
-		// Calculate which role would be added
-		roleToAdd := h.calculateRoleAdjustment(room, true)
-		if roleToAdd != "" {
-			incrementTooltip = fmt.Sprintf("Will add 1 %s", roleToAdd)
+		// Describe what the rebalance strategy would add
+		if preview := h.rebalancePreview(room, true); preview != "" {
+			incrementTooltip = preview
 		}
 	}
 
 	if !canDecrement {
-		if currentPlayerCount <= h.config.Server.MinPlayersPerRoom {
+		if currentPlayerCount <= h.roomConfig(room).Server.MinPlayersPerRoom {
 			decrementTooltip = "Minimum player count reached"
 		} else if currentPlayerCount <= len(room.Players) {
 			decrementTooltip = fmt.Sprintf("Cannot reduce below %d connected players", len(room.Players))
 		}
 	} else if room.RoleConfig.PresetName == "custom" {
-		// Calculate which role would be removed
-		roleToRemove := h.calculateRoleAdjustment(room, false)
-		if roleToRemove != "" {
-			decrementTooltip = fmt.Sprintf("Will remove 1 %s", roleToRemove)
+		// Describe what the rebalance strategy would remove
+		if preview := h.rebalancePreview(room, false); preview != "" {
+			decrementTooltip = preview
 		}
 	}
 
@@ -718,101 +841,47 @@ func (h *Handler) createPlayerCountDisplay(room *game.Room) components.PlayerCou
 	}
 }
 
-func (h *Handler) calculateRoleAdjustment(room *game.Room, increment bool) string {
-	config := room.RoleConfig
-
-	// For preset mode, return empty (no specific role preview)
-	if config.PresetName != "custom" {
-		return ""
-	}
-
-	// Build map of roles that can be adjusted
-	roleCounts := make(map[string]int)
-	roleTypes := []string{}
-
-	// Always include Guardian, Assassin, Traitor
-	for _, role := range []string{"Guardian", "Assassin", "Traitor"} {
-		if typeConfig, exists := config.RoleTypes[role]; exists {
-			roleCounts[role] = typeConfig.Count
-			roleTypes = append(roleTypes, role)
-		}
-	}
-
-	// Include Leader only if count > 1
-	if leaderConfig, exists := config.RoleTypes["Leader"]; exists && leaderConfig.Count > 1 {
-		roleCounts["Leader"] = leaderConfig.Count
-		roleTypes = append(roleTypes, "Leader")
-	}
-
-	// Calculate average (excluding roles not in calculation)
-	total := 0
-	for _, count := range roleCounts {
-		total += count
+// rebalancePreview describes what the room's rebalance strategy would change
+// when the game size moves one step up (increment) or down. It is empty when
+// the room keeps its explicit role counts.
+func (h *Handler) rebalancePreview(room *game.Room, increment bool) string {
+	target := room.RoleConfig.MaxPlayers - 1
+	if increment {
+		target = room.RoleConfig.MaxPlayers + 1
 	}
-
-	if len(roleCounts) == 0 {
+	counts, ok := game.NewRoleConfigService(h.roomConfig(room)).Rebalance(room.RoleConfig, target)
+	if !ok {
 		return ""
 	}
 
-	avg := float64(total) / float64(len(roleCounts))
-
-	if increment {
-		// Find most underrepresented role
-		maxDeviation := 0.0
-		roleToAdd := ""
-
-		for _, role := range roleTypes {
-			deviation := avg - float64(roleCounts[role])
-			if deviation > maxDeviation || (deviation == maxDeviation && role == "Guardian") {
-				maxDeviation = deviation
-				roleToAdd = role
-			}
+	var changes []string
+	for _, roleType := range game.ConfiguredRoleTypes(room.RoleConfig) {
+		role := string(roleType)
+		typeConfig := room.RoleConfig.RoleTypes[role]
+		diff, verb := counts[role]-typeConfig.Count, "add"
+		if diff < 0 {
+			diff, verb = -diff, "remove"
 		}
-
-		return roleToAdd
-	} else {
-		// Find most overrepresented role that can be safely reduced
-		maxDeviation := 0.0
-		roleToRemove := ""
-
-		for _, role := range roleTypes {
-			count := roleCounts[role]
-			canReduce := true
-
-			// Check minimum constraints
-			switch role {
-			case "Leader":
-				canReduce = count > 1
-			case "Assassin":
-				canReduce = count > 1
-			default:
-				canReduce = count > 0
-			}
-
-			if canReduce {
-				deviation := float64(count) - avg
-				if deviation > maxDeviation {
-					maxDeviation = deviation
-					roleToRemove = role
-				}
-			}
+		switch {
+		case diff == 1:
+			changes = append(changes, fmt.Sprintf("%s 1 %s", verb, role))
+		case diff > 1:
+			changes = append(changes, fmt.Sprintf("%s %d %ss", verb, diff, role))
 		}
-
-		return roleToRemove
 	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return "Will " + strings.Join(changes, ", ")
 }
 
 // IncrementPlayerCount increments the player count for a room
 func (h *Handler) IncrementPlayerCount(w http.ResponseWriter, r *http.Request) {
-	roomCode := chi.URLParam(r, "code")
-	log.Printf("🔍 DEBUG: IncrementPlayerCount called for room %s", roomCode)
 	h.updatePlayerCount(w, r, "increment")
 }
 
 // DecrementPlayerCount decrements the player count for a room
 func (h *Handler) DecrementPlayerCount(w http.ResponseWriter, r *http.Request) {
-	roomCode := chi.URLParam(r, "code")
-	log.Printf("🔍 DEBUG: DecrementPlayerCount called for room %s", roomCode)
 	h.updatePlayerCount(w, r, "decrement")
 }
 
@@ -845,7 +914,7 @@ func (h *Handler) updatePlayerCount(w http.ResponseWriter, r *http.Request, acti
 
 	switch action {
 	case "increment":
-		if currentPlayerCount >= h.config.Server.MaxPlayersPerRoom {
+		if currentPlayerCount >= h.roomConfig(room).Server.MaxPlayersPerRoom {
 			sse := datastar.NewSSE(w, r)
 			sse.PatchElements(roleValidationErrorFragment("Maximum player count reached"),
 				datastar.WithSelector("#role-validation"))
@@ -854,7 +923,7 @@ func (h *Handler) updatePlayerCount(w http.ResponseWriter, r *http.Request, acti
 		room.RoleConfig.MaxPlayers++
 
 	case "decrement":
-		if currentPlayerCount <= h.config.Server.MinPlayersPerRoom {
+		if currentPlayerCount <= h.roomConfig(room).Server.MinPlayersPerRoom {
 			sse := datastar.NewSSE(w, r)
 			sse.PatchElements(roleValidationErrorFragment("Minimum player count reached"),
 				datastar.WithSelector("#role-validation"))
@@ -872,7 +941,7 @@ func (h *Handler) updatePlayerCount(w http.ResponseWriter, r *http.Request, acti
 		room.RoleConfig.MaxPlayers--
 
 	default:
-		log.Printf("ERROR: Invalid action '%s' for player count update", action)
+		h.requestLogger(r).Error("invalid player count action", "action", action)
 		return
 	}
 
@@ -886,26 +955,26 @@ func (h *Handler) updatePlayerCount(w http.ResponseWriter, r *http.Request, acti
 
 	if room.RoleConfig.PresetName != "custom" {
 		// Preset mode: immediately apply preset distribution for new player count (both host and non-host modes)
-		log.Printf("🔍 DEBUG: Calling applyPresetForPlayerCount for room %s (preset: %s, active players: %d)", roomCode, room.RoleConfig.PresetName, activePlayerCount)
+		h.requestLogger(r).Debug("applying preset for player count", "preset", room.RoleConfig.PresetName, "players", room.RoleConfig.MaxPlayers, "active_players", activePlayerCount)
 		h.applyPresetForPlayerCount(room)
+	} else {
+		// Custom mode: keep explicit counts unless the room picked a rebalance strategy
+		h.rebalanceCustomRoles(room)
 	}
-	// Custom mode: just update player count, no immediate role changes
 
 	// Update room
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
 
 	h.sendUpdatedRoleConfigUI(w, r, room)
 
-	log.Printf("🔍 DEBUG: About to publish role_config_updated event for room %s after %s player count", roomCode, action)
-
 	// Publish event - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
-
-	log.Printf("🔍 DEBUG: Finished publishing role_config_updated event for room %s", roomCode)
 }
 
 func roleValidationErrorFragment(message string) string {
@@ -917,80 +986,91 @@ func (h *Handler) applyPresetForPlayerCount(room *game.Room) {
 	playerCount := room.RoleConfig.MaxPlayers
 
 	// Get preset distribution
-	preset, exists := h.config.Roles.Presets[presetName]
+	preset, exists := h.roomConfig(room).Roles.Presets[presetName]
 	if !exists {
-		log.Printf("ERROR: Preset '%s' not found", presetName)
+		h.roomLogger(room.Code).Error("preset not found", "preset", presetName)
 		return
 	}
 
 	distribution, exists := preset.Distributions[playerCount]
 	if !exists {
-		log.Printf("ERROR: No distribution for %d players in preset '%s'", playerCount, presetName)
+		h.roomLogger(room.Code).Error("preset has no distribution for the player count", "preset", presetName, "players", playerCount)
 		return
 	}
 
 	// Apply distribution
-	if leaderConfig, exists := room.RoleConfig.RoleTypes["Leader"]; exists {
-		leaderConfig.Count = distribution["leader"]
+	for _, typeConfig := range room.RoleConfig.RoleTypes {
+		typeConfig.Count = 0
 	}
-	if guardianConfig, exists := room.RoleConfig.RoleTypes["Guardian"]; exists {
-		guardianConfig.Count = distribution["guardian"]
+	for roleType, count := range game.PresetRoleTypeCounts(h.roomConfig(room), distribution) {
+		if typeConfig, exists := room.RoleConfig.RoleTypes[string(roleType)]; exists {
+			typeConfig.Count = count
+		}
 	}
-	if assassinConfig, exists := room.RoleConfig.RoleTypes["Assassin"]; exists {
-		assassinConfig.Count = distribution["assassin"]
+}
+
+// rebalanceCustomRoles applies the room's rebalance strategy to its custom
+// role counts for the current game size
+func (h *Handler) rebalanceCustomRoles(room *game.Room) {
+	counts, ok := game.NewRoleConfigService(h.roomConfig(room)).Rebalance(room.RoleConfig, room.RoleConfig.MaxPlayers)
+	if !ok {
+		return
 	}
-	if traitorConfig, exists := room.RoleConfig.RoleTypes["Traitor"]; exists {
-		traitorConfig.Count = distribution["traitor"]
+	for role, count := range counts {
+		if typeConfig, exists := room.RoleConfig.RoleTypes[role]; exists {
+			typeConfig.Count = count
+		}
 	}
 }
 
-func (h *Handler) rebalanceCustomRoles(room *game.Room, increment bool) {
-	// Use the same logic as calculateRoleAdjustment to determine which role to adjust
-	roleToAdjust := h.calculateRoleAdjustment(room, increment)
-
-	if roleToAdjust == "" {
-		// Fallback: adjust the first available role
-		if increment {
-			roleToAdjust = "Guardian"
-		} else {
-			// Find a role that can be decremented
-			for _, role := range []string{"Traitor", "Guardian", "Assassin", "Leader"} {
-				if config, exists := room.RoleConfig.RoleTypes[role]; exists {
-					switch role {
-					case "Leader", "Assassin":
-						if config.Count > 1 {
-							roleToAdjust = role
-							break
-						}
-					default:
-						if config.Count > 0 {
-							roleToAdjust = role
-							break
-						}
-					}
-				}
-			}
-		}
+// UpdateRebalanceStrategy sets how a custom role configuration follows player
+// count changes; an empty strategy keeps the explicit counts
+func (h *Handler) UpdateRebalanceStrategy(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
 	}
 
-	// Apply the adjustment
-	if roleConfig, exists := room.RoleConfig.RoleTypes[roleToAdjust]; exists {
-		if increment {
-			roleConfig.Count++
-		} else {
-			roleConfig.Count--
-		}
+	// Verify player is room creator
+	if !h.isRoomCreator(r, room) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	strategy := r.FormValue("strategy")
+	if _, ok := game.RebalanceStrategyByName(strategy); strategy != "" && !ok {
+		http.Error(w, "Invalid rebalance strategy", http.StatusBadRequest)
+		return
 	}
+	room.RoleConfig.RebalanceStrategy = strategy
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
+	h.store.UpdateRoom(room)
+
+	h.sendUpdatedRoleConfigUI(w, r, room)
+
+	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
+	h.eventBus.Publish(Event{
+		Type:     EventRoleConfigUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
 }
 
 // UpdateHideDistribution updates the hide role distribution setting for a room
 func (h *Handler) UpdateHideDistribution(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
-	log.Printf("🔍 UpdateHideDistribution called for room: %s", roomCode)
+	logger := h.requestLogger(r)
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
-		log.Printf("❌ Room not found: %s", roomCode)
+		logger.Info("room not found")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingHideDistribution": false,
@@ -1000,7 +1080,7 @@ func (h *Handler) UpdateHideDistribution(w http.ResponseWriter, r *http.Request)
 
 	// Verify player is room creator
 	if !h.isRoomCreator(r, room) {
-		log.Printf("❌ Unauthorized access attempt for room: %s", roomCode)
+		logger.Warn("unauthorized role config change")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingHideDistribution": false,
@@ -1014,7 +1094,10 @@ func (h *Handler) UpdateHideDistribution(w http.ResponseWriter, r *http.Request)
 	// Parse JSON body into a generic map
 	var body map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		log.Printf("❌ Invalid request body for room %s: %v", roomCode, err)
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
+		logger.Info("invalid request body", logging.KeyError, err)
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingHideDistribution": false,
@@ -1028,7 +1111,7 @@ func (h *Handler) UpdateHideDistribution(w http.ResponseWriter, r *http.Request)
 		// Fallback for the simple format, just in case
 		hide, ok = body["hide"].(bool)
 		if !ok {
-			log.Printf("❌ Could not find a valid 'hide' or 'hideRoleDistribution' boolean in request for room %s", roomCode)
+			logger.Info("request has no 'hide' or 'hideRoleDistribution' boolean")
 			sse := datastar.NewSSE(w, r)
 			sse.MarshalAndPatchSignals(map[string]interface{}{
 				"updatingHideDistribution": false,
@@ -1038,42 +1121,40 @@ func (h *Handler) UpdateHideDistribution(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Log state change
-	previousState := room.RoleConfig.HideRoleDistribution
-	log.Printf("📊 UpdateHideDistribution state change for room %s:", roomCode)
-	log.Printf("  - Previous HideRoleDistribution: %v", previousState)
-	log.Printf("  - New HideRoleDistribution: %v", hide)
+	logger.Info("hide role distribution setting changed", "from", room.RoleConfig.HideRoleDistribution, "to", hide)
 
 	// Update the setting
 	room.RoleConfig.HideRoleDistribution = hide
 
 	// If hiding distribution and fully random was enabled, disable it (mutual exclusivity)
 	if hide && room.RoleConfig.FullyRandomRoles {
-		log.Printf("  - Disabling FullyRandomRoles due to mutual exclusivity")
+		logger.Info("fully random roles disabled, it excludes hiding the role distribution")
 		room.RoleConfig.FullyRandomRoles = false
 	}
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
-	log.Printf("✅ UpdateHideDistribution completed for room %s", roomCode)
 
 	// Send immediate SSE response to reset loading state
 	h.sendUpdatedRoleConfigUI(w, r, room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
 
 // UpdateFullyRandom updates the fully random roles setting for a room
 func (h *Handler) UpdateFullyRandom(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
-	log.Printf("🔍 UpdateFullyRandom called for room: %s", roomCode)
+	logger := h.requestLogger(r)
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
-		log.Printf("❌ Room not found: %s", roomCode)
+		logger.Info("room not found")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingFullyRandom": false,
@@ -1083,7 +1164,7 @@ func (h *Handler) UpdateFullyRandom(w http.ResponseWriter, r *http.Request) {
 
 	// Verify player is room creator
 	if !h.isRoomCreator(r, room) {
-		log.Printf("❌ Unauthorized access attempt for room: %s", roomCode)
+		logger.Warn("unauthorized role config change")
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingFullyRandom": false,
@@ -1097,7 +1178,10 @@ func (h *Handler) UpdateFullyRandom(w http.ResponseWriter, r *http.Request) {
 	// Parse JSON body into a generic map
 	var body map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		log.Printf("❌ Invalid request body for room %s: %v", roomCode, err)
+		if localMiddleware.RejectIfTooLarge(w, err) {
+			return
+		}
+		logger.Info("invalid request body", logging.KeyError, err)
 		sse := datastar.NewSSE(w, r)
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"updatingFullyRandom": false,
@@ -1111,7 +1195,7 @@ func (h *Handler) UpdateFullyRandom(w http.ResponseWriter, r *http.Request) {
 		// Fallback for the simple format, just in case
 		random, ok = body["random"].(bool)
 		if !ok {
-			log.Printf("❌ Could not find a valid 'random' or 'fullyRandomRoles' boolean in request for room %s", roomCode)
+			logger.Info("request has no 'random' or 'fullyRandomRoles' boolean")
 			sse := datastar.NewSSE(w, r)
 			sse.MarshalAndPatchSignals(map[string]interface{}{
 				"updatingFullyRandom": false,
@@ -1121,30 +1205,28 @@ func (h *Handler) UpdateFullyRandom(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Log state change
-	previousState := room.RoleConfig.FullyRandomRoles
-	log.Printf("📊 UpdateFullyRandom state change for room %s:", roomCode)
-	log.Printf("  - Previous FullyRandomRoles: %v", previousState)
-	log.Printf("  - New FullyRandomRoles: %v", random)
+	logger.Info("fully random roles setting changed", "from", room.RoleConfig.FullyRandomRoles, "to", random)
 
 	// Update the setting
 	room.RoleConfig.FullyRandomRoles = random
 
 	// If enabling fully random and hide distribution was enabled, disable it (mutual exclusivity)
 	if random && room.RoleConfig.HideRoleDistribution {
-		log.Printf("  - Disabling HideRoleDistribution due to mutual exclusivity")
+		logger.Info("hidden role distribution disabled, it excludes fully random roles")
 		room.RoleConfig.HideRoleDistribution = false
 	}
 
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
 	h.store.UpdateRoom(room)
-	log.Printf("✅ UpdateFullyRandom completed for room %s", roomCode)
 
 	// Send immediate SSE response to reset loading state
 	h.sendUpdatedRoleConfigUI(w, r, room)
 
 	// Notify all players - SSE handlers will take care of sending UI updates to all connected clients
 	h.eventBus.Publish(Event{
-		Type:     "role_config_updated",
+		Type:     EventRoleConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
+		Actor:    h.requestActor(r, room),
 	})
 }
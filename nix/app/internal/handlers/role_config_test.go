@@ -147,7 +147,7 @@ func TestUpdateTreacheryPlayerCountPatchesVisibleCountImmediately(t *testing.T)
 
 	room, _ := h.store.CreateRoom()
 	room.RulesMode = game.RulesModeTreachery
-	roleConfig, err := h.roleConfigService.CreateFromPreset("standard", 5)
+	roleConfig, err := h.roleConfigService().CreateFromPreset("standard", 5)
 	if err != nil {
 		t.Fatalf("failed to create role config: %v", err)
 	}
@@ -157,7 +157,7 @@ func TestUpdateTreacheryPlayerCountPatchesVisibleCountImmediately(t *testing.T)
 	markRoomOperatorForTest(room, operator)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -199,7 +199,7 @@ func TestUpdateTreacheryPlayerCountErrorIncludesValidationWrapper(t *testing.T)
 
 	room, _ := h.store.CreateRoom()
 	room.RulesMode = game.RulesModeTreachery
-	roleConfig, err := h.roleConfigService.CreateFromPreset("standard", h.config.Server.MaxPlayersPerRoom)
+	roleConfig, err := h.roleConfigService().CreateFromPreset("standard", h.config().Server.MaxPlayersPerRoom)
 	if err != nil {
 		t.Fatalf("failed to create role config: %v", err)
 	}
@@ -209,7 +209,7 @@ func TestUpdateTreacheryPlayerCountErrorIncludesValidationWrapper(t *testing.T)
 	markRoomOperatorForTest(room, operator)
 	h.store.UpdateRoom(room)
 
-	router := SetupRouter(h, h.config, &RouterOptions{
+	router := SetupRouter(h, h.config(), &RouterOptions{
 		DisableRateLimiting:  true,
 		DisableRequestLogger: true,
 	})
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	_ "image/jpeg" // card art is usually JPEG
+	"image/png"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/f64"
+	"golang.org/x/image/math/fixed"
+)
+
+// Watermark text is drawn at this scale so the bitmap font stays legible on
+// full-size card art, in diagonal rows this far apart
+const (
+	watermarkScale      = 2
+	watermarkRowSpacing = 90
+	watermarkAngle      = -math.Pi / 6 // rising left to right
+)
+
+// roleImageCacheSize bounds how many watermarked images are kept in memory
+const roleImageCacheSize = 256
+
+// roleImage is one watermarked role image, ready to serve
+type roleImage struct {
+	contentType string
+	data        []byte
+	etag        string
+}
+
+// roleImageCache keeps recently watermarked role images, so a page refresh
+// doesn't redraw the card. Entries are keyed by everything the image depends
+// on, so a rename or new role simply misses; the oldest entry goes first.
+type roleImageCache struct {
+	mu      sync.Mutex
+	entries map[string]roleImage
+	order   []string
+}
+
+func newRoleImageCache() *roleImageCache {
+	return &roleImageCache{entries: make(map[string]roleImage)}
+}
+
+func (c *roleImageCache) get(key string) (roleImage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	img, ok := c.entries[key]
+	return img, ok
+}
+
+func (c *roleImageCache) put(key string, img roleImage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	if len(c.order) >= roleImageCacheSize {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = img
+	c.order = append(c.order, key)
+}
+
+// UpdateScreenshotDeterrence turns watermarked role images on or off before the game starts
+func (h *Handler) UpdateScreenshotDeterrence(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	room.ScreenshotDeterrence = r.FormValue("enabled") == "true" || r.FormValue("enabled") == "on"
+	if room.ScreenshotDeterrence {
+		room.EnsureRoleImageSecret()
+	}
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventScreenshotDeterrenceUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RoleImage serves a player's own role card art with their name and the room
+// faintly repeated across it, for rooms with screenshot deterrence on. The
+// token in the URL names the player, so the image works without the session
+// cookie and a leaked copy points back to them. The page loads it once; any
+// further load is logged, since it usually means the image was opened or
+// saved on its own.
+func (h *Handler) RoleImage(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if !room.ScreenshotDeterrence {
+		http.Error(w, "Role images are sent inline in this room", http.StatusNotFound)
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+	player := room.RoleImagePlayer(token)
+	if player == nil {
+		http.Error(w, "Role image not found", http.StatusNotFound)
+		return
+	}
+	if player.Role == nil || player.Role.GetImageBase64() == "" {
+		http.Error(w, "No role image", http.StatusNotFound)
+		return
+	}
+
+	if loads := room.CountRoleImageLoad(player.ID, player.Role.ID); loads > 1 {
+		log.Printf("📸 Role image for %s in room %s loaded again (%d loads)", player.Name, room.Code, loads)
+	}
+
+	key := fmt.Sprintf("%s/%d/%s", token, player.Role.ID, player.Name)
+	img, ok := h.roleImages.get(key)
+	if !ok {
+		contentType, data, err := watermarkRoleImage(player.Role.GetImageBase64(), []string{player.Name, room.Code})
+		if err != nil {
+			log.Printf("❌ Could not watermark role image in room %s: %v", room.Code, err)
+			http.Error(w, "Role image unavailable", http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(data)
+		img = roleImage{contentType: contentType, data: data, etag: `"` + hex.EncodeToString(sum[:8]) + `"`}
+		h.roleImages.put(key, img)
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Header().Set("ETag", img.etag)
+	if r.Header.Get("If-None-Match") == img.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", img.contentType)
+	w.Write(img.data)
+}
+
+// watermarkRoleImage decodes a card's data URI and repeats lines diagonally
+// across it. Raster art is re-encoded as PNG; SVG placeholder art gets the
+// text as SVG.
+func watermarkRoleImage(dataURI string, lines []string) (string, []byte, error) {
+	header, encoded, ok := strings.Cut(strings.TrimPrefix(dataURI, "data:"), ",")
+	if !ok || !strings.HasSuffix(header, ";base64") {
+		return "", nil, errors.New("card image is not a base64 data URI")
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if strings.TrimSuffix(header, ";base64") == "image/svg+xml" {
+		return "image/svg+xml", watermarkSVG(raw, lines), nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return "", nil, fmt.Errorf("decode card image: %w", err)
+	}
+	bounds := src.Bounds()
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, src, bounds.Min, draw.Src)
+
+	// Draw the text small on a square that covers the art at any angle,
+	// then rotate and scale it up over the art
+	side := int(math.Hypot(float64(bounds.Dx()), float64(bounds.Dy())))/watermarkScale + 1
+	text := image.NewRGBA(image.Rect(0, 0, side, side))
+	drawer := &font.Drawer{Dst: text, Face: basicfont.Face7x13}
+	label := strings.Join(lines, " | ") + "     "
+	row := 0
+	for y := 16; y < side; y += watermarkRowSpacing / watermarkScale {
+		// Stagger the rows so cropping one out still leaves another
+		x := -(row % 3) * 40
+		for _, layer := range []struct {
+			offset int
+			color  color.Color
+		}{{1, color.NRGBA{0, 0, 0, 60}}, {0, color.NRGBA{255, 255, 255, 100}}} {
+			drawer.Src = image.NewUniform(layer.color)
+			drawer.Dot = fixed.P(x+layer.offset, y+layer.offset)
+			drawer.DrawString(strings.Repeat(label, side/drawer.MeasureString(label).Ceil()+2))
+		}
+		row++
+	}
+
+	sin, cos := math.Sincos(watermarkAngle)
+	k := float64(watermarkScale)
+	half := float64(side) / 2
+	cx := float64(bounds.Min.X) + float64(bounds.Dx())/2
+	cy := float64(bounds.Min.Y) + float64(bounds.Dy())/2
+	rotate := f64.Aff3{
+		k * cos, -k * sin, cx - k*(cos*half-sin*half),
+		k * sin, k * cos, cy - k*(sin*half+cos*half),
+	}
+	draw.ApproxBiLinear.Transform(dst, rotate, text, text.Bounds(), draw.Over, nil)
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, dst); err != nil {
+		return "", nil, err
+	}
+	return "image/png", out.Bytes(), nil
+}
+
+// watermarkSVG adds lines as faint diagonal text just before the closing tag
+func watermarkSVG(raw []byte, lines []string) []byte {
+	svg := string(raw)
+	end := strings.LastIndex(svg, "</svg>")
+	if end < 0 {
+		return raw
+	}
+	var marks strings.Builder
+	label := html.EscapeString(strings.Join(lines, " | "))
+	// Placeholder cards are 488x680; rows run past the edges so the rotated
+	// text still covers the corners
+	marks.WriteString(`<g transform="rotate(-30 244 340)" font-family="monospace" font-size="18" fill="#fff" fill-opacity="0.4" stroke="#000" stroke-opacity="0.25" stroke-width="0.5">`)
+	for y := -300; y < 980; y += watermarkRowSpacing {
+		fmt.Fprintf(&marks, `<text x="-300" y="%d">%s</text>`, y, strings.Repeat(label+"     ", 6))
+	}
+	marks.WriteString(`</g>`)
+	return []byte(svg[:end] + marks.String() + svg[end:])
+}
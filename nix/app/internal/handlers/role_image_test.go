@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+// jpegDataURI returns a plain grey card-sized JPEG as a data URI
+func jpegDataURI(t *testing.T) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 244, 340))
+	for y := 0; y < 340; y++ {
+		for x := 0; x < 244; x++ {
+			img.Set(x, y, color.RGBA{90, 90, 90, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestRoleImageWatermarksAndLogsReloads(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	code := operator.RoomCode
+	player := testkit.JoinRoom(t, router, code, "Bob")
+
+	room, _ := h.store.GetRoom(code)
+	if room.RoleImageToken(player.PlayerID()) != "" {
+		t.Fatal("expected no role image tokens while deterrence is off")
+	}
+	if w := operator.Post("/room/"+code+"/config/screenshot-deterrence", url.Values{"enabled": {"true"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected the setting to save, got %d", w.Code)
+	}
+	operator.StartGame()
+
+	bob := room.GetPlayer(player.PlayerID())
+	bob.Role = &game.Card{ID: 42, Name: "Test Guardian", Types: game.CardTypes{Subtype: "Guardian"}, Base64Image: jpegDataURI(t)}
+	bob.FaceUp, bob.RoleRevealed = false, false
+	room.State = game.StatePlaying
+
+	token := room.RoleImageToken(bob.ID)
+	if token == "" || token == room.RoleImageToken(operator.PlayerID()) {
+		t.Fatalf("expected a distinct token per player, got %q", token)
+	}
+	imageURL := "/room/" + code + "/role-image/" + token + "?card=42"
+
+	page := player.Get("/game/" + code).Body.String()
+	if !strings.Contains(page, imageURL) || strings.Contains(page, bob.Role.Base64Image) {
+		t.Error("expected the role card to use the watermarked image instead of inline art")
+	}
+	if strings.Contains(operator.Get("/game/"+code).Body.String(), token) {
+		t.Error("expected Bob's token to stay off other players' pages")
+	}
+
+	// The token is the credential, so the image loads without a session
+	w := testkit.NewClient(t, router).Get(imageURL)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Content-Type") != "image/png" || !strings.HasPrefix(w.Header().Get("Cache-Control"), "private") {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	marked, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("decode watermarked image: %v", err)
+	}
+	if marked.Bounds().Dx() != 244 || !hasMarkedPixel(marked) {
+		t.Error("expected the watermark text over the grey art")
+	}
+
+	etag := w.Header().Get("ETag")
+	req := httptest.NewRequest(http.MethodGet, imageURL, nil)
+	req.Header.Set("If-None-Match", etag)
+	if again := player.Do(req); again.Code != http.StatusNotModified {
+		t.Errorf("expected a revalidation to be answered from cache, got %d", again.Code)
+	}
+	if loads := room.CountRoleImageLoad(bob.ID, 42); loads != 3 {
+		t.Errorf("expected both loads to be counted, got %d before this one", loads-1)
+	}
+
+	bob.Name = "Robert"
+	if w := player.Get(imageURL); w.Header().Get("ETag") == etag {
+		t.Error("expected a rename to redraw the watermark")
+	}
+
+	if w := player.Get("/room/" + code + "/role-image/" + strings.Repeat("0", 32)); w.Code != http.StatusNotFound {
+		t.Errorf("expected an unknown token to be refused, got %d", w.Code)
+	}
+}
+
+// hasMarkedPixel reports whether any pixel is noticeably lighter than the grey card
+func hasMarkedPixel(img image.Image) bool {
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r>>8 > 120 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestWatermarkSVGRoleImage(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="488" height="680"><rect width="488" height="680"/></svg>`
+	uri := "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(svg))
+
+	contentType, data, err := watermarkRoleImage(uri, []string{"Bob & Co", "ABCDE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "image/svg+xml" || !strings.Contains(string(data), "Bob &amp; Co | ABCDE") || !strings.HasSuffix(string(data), "</g></svg>") {
+		t.Errorf("expected escaped watermark text before the closing tag, got %s", data)
+	}
+
+	if _, _, err := watermarkRoleImage("data:image/jpeg;base64,test", nil); err == nil {
+		t.Error("expected undecodable art to fail")
+	}
+}
@@ -22,9 +22,9 @@ func TestGetRoleOptions(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room with options
 	room, _ := memStore.CreateRoom()
@@ -150,9 +150,9 @@ func TestSetRoleOption(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room
 	room, _ := memStore.CreateRoom()
@@ -349,9 +349,9 @@ func TestRoleOptionsEventPublishing(t *testing.T) {
 	eventBus := NewEventBus()
 	handler := &Handler{
 		store:    memStore,
-		config:   cfg,
 		eventBus: eventBus,
 	}
+	handler.publishConfig(cfg)
 
 	// Create room
 	room, _ := memStore.CreateRoom()
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"treacherest/internal/game"
+)
+
+// These tests hammer one room from many goroutines. Run them with -race: the
+// invariants catch lost updates, the race detector catches unguarded access.
+
+// roomClient is one browser: its cookies follow it across requests
+type roomClient struct {
+	router  http.Handler
+	cookies []*http.Cookie
+}
+
+func (c *roomClient) do(ctx context.Context, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body)).WithContext(ctx)
+	if strings.HasPrefix(body, "{") {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	c.cookies = append(c.cookies, w.Result().Cookies()...)
+	return w
+}
+
+// newConcurrencyRoom creates a room through the router and returns its operator
+func newConcurrencyRoom(t testing.TB, h *Handler) (*roomClient, *game.Room) {
+	t.Helper()
+	operator := &roomClient{router: newTestRouter(h)}
+	w := operator.do(context.Background(), "POST", "/room/new", "playerName=Operator&form_elapsed=5000")
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("create room: got %d", w.Code)
+	}
+	code := strings.TrimPrefix(w.Header().Get("Location"), "/room/")
+	room, err := h.store.GetRoom(code)
+	if err != nil {
+		t.Fatalf("created room %s not found: %v", code, err)
+	}
+	return operator, room
+}
+
+// roomState reads the state the way a stream does, so the test itself stays race-free
+func roomState(room *game.Room) game.GameState {
+	room.RLock()
+	defer room.RUnlock()
+	return room.State
+}
+
+func joinConcurrencyRoom(h *Handler, code string, i int) *roomClient {
+	c := &roomClient{router: newTestRouter(h)}
+	c.do(context.Background(), "POST", "/join-room", fmt.Sprintf("room_code=%s&player_name=Player%d&form_elapsed=5000", code, i))
+	return c
+}
+
+func TestRoomConcurrentJoinsNeverExceedMaxPlayers(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.MaxPlayersPerRoom = 6
+	_, room := newConcurrencyRoom(t, h)
+	room.MaxPlayers = 6
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			joinConcurrencyRoom(h, room.Code, i)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(room.GetPlayers()); got != room.MaxPlayers {
+		t.Errorf("expected room to fill to exactly %d players, got %d", room.MaxPlayers, got)
+	}
+}
+
+func TestRoomConcurrentLobbyTraffic(t *testing.T) {
+	h := newTestHandler()
+	operator, room := newConcurrencyRoom(t, h)
+	code := room.Code
+
+	streamCtx, stopStreams := context.WithCancel(context.Background())
+	var streams sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		streams.Add(1)
+		go func() {
+			defer streams.Done()
+			viewer := &roomClient{router: operator.router, cookies: operator.cookies}
+			viewer.do(streamCtx, "GET", "/sse/lobby/"+code, "")
+		}()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := joinConcurrencyRoom(h, code, i)
+			if i%3 == 0 {
+				c.do(context.Background(), "POST", "/room/"+code+"/leave", "")
+			}
+		}(i)
+	}
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			config := &roomClient{router: operator.router, cookies: operator.cookies}
+			switch i % 4 {
+			case 0:
+				config.do(context.Background(), "POST", "/room/"+code+"/config/player-count/increment", "")
+			case 1:
+				config.do(context.Background(), "POST", "/room/"+code+"/config/role-type/Guardian/increment", "")
+			case 2:
+				config.do(context.Background(), "POST", "/room/"+code+"/config/role-type/Guardian/decrement", "")
+			case 3:
+				config.do(context.Background(), "POST", "/room/"+code+"/config/card-toggle", `{"cardId":"card-Traitor-test-traitor","cardChecked":true}`)
+			}
+		}(i)
+	}
+	wg.Wait()
+	stopStreams()
+	streams.Wait()
+
+	if got := len(room.GetPlayers()); got > room.MaxPlayers {
+		t.Errorf("player count %d exceeds max %d", got, room.MaxPlayers)
+	}
+	if state := roomState(room); state != game.StateLobby {
+		t.Errorf("lobby traffic must not leave the lobby, got state %s", state)
+	}
+}
+
+func TestRoomConcurrentStartsAssignRolesOnce(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	operator, room := newConcurrencyRoom(t, h)
+	for i := 0; i < 3; i++ {
+		joinConcurrencyRoom(h, room.Code, i)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	started := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			starter := &roomClient{router: operator.router, cookies: operator.cookies}
+			w := starter.do(context.Background(), "POST", "/room/"+room.Code+"/start", "")
+			if strings.Contains(w.Body.String(), "/game/"+room.Code) {
+				mu.Lock()
+				started++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if started != 1 {
+		t.Errorf("expected exactly one start to succeed, got %d", started)
+	}
+	if state := roomState(room); state != game.StateCountdown && state != game.StatePlaying {
+		t.Errorf("expected countdown or playing after start, got %s", state)
+	}
+
+	seen := make(map[int]string)
+	for _, p := range room.GetPlayers() {
+		if p.Role == nil {
+			t.Errorf("player %s has no role", p.Name)
+			continue
+		}
+		if other, dup := seen[p.Role.ID]; dup {
+			t.Errorf("card %d dealt to both %s and %s", p.Role.ID, other, p.Name)
+		}
+		seen[p.Role.ID] = p.Name
+	}
+
+	// Run the countdown out so its goroutine does not outlive the test
+	advanceUntil(t, fake, func() bool { return roomState(room) == game.StatePlaying })
+}
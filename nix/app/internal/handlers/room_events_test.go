@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func TestJournalRebuildsAPlayedRoom(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Operator", false)
+	alice := testkit.JoinRoom(t, router, operator.RoomCode, "Alice")
+	bob := testkit.JoinRoom(t, router, operator.RoomCode, "Bob")
+	room, _ := h.store.GetRoom(operator.RoomCode)
+
+	form := url.Values{"strategy": {game.RebalanceGuardianFirst}}
+	if w := operator.Post("/room/"+room.Code+"/config/rebalance-strategy", form); w.Code != http.StatusOK {
+		t.Fatalf("expected the strategy changed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := bob.Post("/room/"+room.Code+"/leave", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected Bob to leave, got %d", w.Code)
+	}
+	if w := operator.Post("/room/"+room.Code+"/start?view=lobby", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the game to start, got %d: %s", w.Code, w.Body.String())
+	}
+	// Leaders start revealed, so whoever isn't one reveals themselves
+	revealer := alice
+	if room.GetPlayer(alice.PlayerID()).Role.GetRoleType() == game.RoleLeader {
+		revealer = operator
+	}
+	if w := revealer.Post("/room/"+room.Code+"/reveal/"+revealer.PlayerID(), nil); w.Code != http.StatusOK {
+		t.Fatalf("expected the reveal, got %d: %s", w.Code, w.Body.String())
+	}
+
+	room.Lock()
+	rebuilt, err := h.store.Rebuild(room.Code)
+	room.Unlock()
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt.State != room.State || !rebuilt.StartedAt.Equal(room.StartedAt) || rebuilt.StartedBy != room.StartedBy {
+		t.Errorf("expected the rebuilt room started as the live one, got %s at %v", rebuilt.State, rebuilt.StartedAt)
+	}
+	if rebuilt.RoleConfig == nil || rebuilt.RoleConfig.RebalanceStrategy != game.RebalanceGuardianFirst {
+		t.Errorf("expected the changed configuration, got %+v", rebuilt.RoleConfig)
+	}
+	if len(rebuilt.Players) != len(room.Players) || rebuilt.GetPlayer(bob.PlayerID()) != nil {
+		t.Fatalf("expected the same players, got %d", len(rebuilt.Players))
+	}
+	for _, live := range room.GetPlayers() {
+		got := rebuilt.GetPlayer(live.ID)
+		if got == nil || got.Name != live.Name || got.Role == nil || got.Role.ID != live.Role.ID {
+			t.Errorf("expected %s rebuilt with their role, got %+v", live.Name, got)
+			continue
+		}
+		if got.RoleRevealed != live.RoleRevealed || got.FaceUp != live.FaceUp {
+			t.Errorf("expected %s's reveal state rebuilt, got %v/%v", live.Name, got.RoleRevealed, got.FaceUp)
+		}
+	}
+	if !rebuilt.GetPlayer(revealer.PlayerID()).RoleRevealed {
+		t.Error("expected the reveal replayed")
+	}
+}
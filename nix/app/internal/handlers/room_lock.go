@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+// lockRoom serializes requests for the room named in the URL. Handlers read
+// and write Room fields directly, so two requests for the same room (or a
+// request and a stream rendering it) must not overlap. Streams are not
+// wrapped: they hold the room with viewRoom while handling each event.
+func (h *Handler) lockRoom(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roomCode := chi.URLParam(r, "code")
+		if roomCode == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		room, err := h.store.GetRoom(roomCode)
+		if err != nil {
+			// A live room the store failed to return must not reach the
+			// handler unlocked; the client can retry
+			if h.store.RoomExists(roomCode) {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "Room temporarily unavailable, retry shortly", http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		room.Lock()
+		defer room.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// viewRoom runs fn while holding room for reading and returns its result.
+// Streams use it around each event so they never render a half-updated room.
+func viewRoom(room *game.Room, fn func() bool) bool {
+	room.RLock()
+	defer room.RUnlock()
+	return fn()
+}
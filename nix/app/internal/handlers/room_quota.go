@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Room creation quota scopes, used in logs and metrics
+const (
+	roomQuotaPerIP  = "ip"
+	roomQuotaGlobal = "global"
+)
+
+// roomQuotaDecision is the outcome of one room creation against the quotas
+type roomQuotaDecision struct {
+	Allowed      bool
+	Scope        string        // the exceeded quota when refused
+	RetryAfter   time.Duration // until the window resets
+	FirstRefusal bool          // the client's first refusal this window
+}
+
+// roomCreationQuota counts rooms created per client IP and server-wide in a
+// fixed window, so a script can't exhaust memory by creating rooms. Limits
+// are passed on each call so a config reload takes effect immediately.
+type roomCreationQuota struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	byIP        map[string]int
+	total       int
+	refusedIPs  map[string]bool  // refused this window, so each is logged once
+	rejected    map[string]int64 // by scope, since startup
+}
+
+func newRoomCreationQuota() *roomCreationQuota {
+	return &roomCreationQuota{
+		byIP:       make(map[string]int),
+		refusedIPs: make(map[string]bool),
+		rejected:   make(map[string]int64),
+	}
+}
+
+// allow counts a room created by ip unless that would exceed perIP or global
+// rooms in the current window (0 turns a quota off)
+func (q *roomCreationQuota) allow(ip string, perIP, global int, window time.Duration, now time.Time) roomQuotaDecision {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if now.Sub(q.windowStart) >= window {
+		q.windowStart = now
+		q.byIP = make(map[string]int)
+		q.refusedIPs = make(map[string]bool)
+		q.total = 0
+	}
+
+	decision := roomQuotaDecision{}
+	switch {
+	case perIP > 0 && q.byIP[ip] >= perIP:
+		decision.Scope = roomQuotaPerIP
+	case global > 0 && q.total >= global:
+		decision.Scope = roomQuotaGlobal
+	default:
+		q.byIP[ip]++
+		q.total++
+		decision.Allowed = true
+		return decision
+	}
+
+	q.rejected[decision.Scope]++
+	decision.RetryAfter = q.windowStart.Add(window).Sub(now)
+	decision.FirstRefusal = !q.refusedIPs[ip]
+	q.refusedIPs[ip] = true
+	return decision
+}
+
+// rejectedCounts returns refused room creations by scope since startup
+func (q *roomCreationQuota) rejectedCounts() map[string]int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	counts := map[string]int64{roomQuotaPerIP: 0, roomQuotaGlobal: 0}
+	for scope, count := range q.rejected {
+		counts[scope] = count
+	}
+	return counts
+}
+
+// rejectIfRoomQuotaExceeded answers 429 with Retry-After when the client or
+// the server has created its quota of rooms for the current window. A
+// tenant's rooms count against the tenant's own quotas.
+func (h *Handler) rejectIfRoomQuotaExceeded(w http.ResponseWriter, r *http.Request) bool {
+	quota, settings, logTag := h.roomQuota, h.config().Server, ""
+	if t := h.requestTenant(r); t != nil {
+		quota, settings, logTag = t.roomQuota, h.tenantConfig(t).Server, "[tenant "+t.ID+"] "
+	}
+	if settings.RoomCreationPerIP <= 0 && settings.RoomCreationGlobal <= 0 {
+		return false
+	}
+
+	ip := h.clientIP(r)
+	decision := quota.allow(ip, settings.RoomCreationPerIP, settings.RoomCreationGlobal, settings.RoomCreationWindow, h.clock.Now())
+	if decision.Allowed {
+		return false
+	}
+
+	// Further refusals this window only show in the metrics
+	if decision.FirstRefusal {
+		log.Printf("🚫 %sRoom creation refused for %s: %s quota reached, retry in %s", logTag, ip, decision.Scope, decision.RetryAfter.Round(time.Second))
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+	http.Error(w, "Too many rooms created, please try again later", http.StatusTooManyRequests)
+	return true
+}
+
+// clientIP returns the address a request came from, as the rate limits see
+// it: a forwarded one only behind a trusted proxy
+func (h *Handler) clientIP(r *http.Request) string {
+	return h.trustedProxies.ClientIP(r)
+}
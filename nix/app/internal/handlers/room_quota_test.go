@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func TestRoomCreationQuotaAllow(t *testing.T) {
+	quota := newRoomCreationQuota()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if d := quota.allow("10.0.0.1", 2, 3, time.Hour, start); !d.Allowed {
+			t.Fatalf("room %d should be allowed, got %+v", i+1, d)
+		}
+	}
+
+	d := quota.allow("10.0.0.1", 2, 3, time.Hour, start.Add(15*time.Minute))
+	if d.Allowed || d.Scope != roomQuotaPerIP || d.RetryAfter != 45*time.Minute || !d.FirstRefusal {
+		t.Errorf("expected a first per-IP refusal with 45m left, got %+v", d)
+	}
+	if d := quota.allow("10.0.0.1", 2, 3, time.Hour, start.Add(15*time.Minute)); d.FirstRefusal {
+		t.Error("only the first refusal in a window should be flagged")
+	}
+
+	if d := quota.allow("10.0.0.2", 2, 3, time.Hour, start); !d.Allowed {
+		t.Fatalf("another client should still be allowed, got %+v", d)
+	}
+	if d := quota.allow("10.0.0.3", 2, 3, time.Hour, start); d.Allowed || d.Scope != roomQuotaGlobal {
+		t.Errorf("expected the global quota to refuse, got %+v", d)
+	}
+
+	// A new window starts every count over
+	if d := quota.allow("10.0.0.1", 2, 3, time.Hour, start.Add(time.Hour)); !d.Allowed {
+		t.Errorf("expected the quota to reset after the window, got %+v", d)
+	}
+
+	counts := quota.rejectedCounts()
+	if counts[roomQuotaPerIP] != 2 || counts[roomQuotaGlobal] != 1 {
+		t.Errorf("expected 2 per-IP and 1 global refusals, got %v", counts)
+	}
+}
+
+func TestCreateRoomRejectsOverQuota(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.RoomCreationPerIP = 2
+	h.config().Server.RoomCreationWindow = time.Minute
+	withFakeClock(h)
+	h.SetAdminToken("secret")
+	router := newTestRouter(h)
+
+	testkit.CreateRoom(t, router, "Alice", false)
+	testkit.CreateRoom(t, router, "Bob", false)
+
+	w := testkit.NewClient(t, router).Post("/room/new", url.Values{"playerName": {"Carol"}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the quota is used up, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("expected Retry-After 60, got %q", got)
+	}
+
+	// Another client behind the proxy has its own quota
+	h.trustedProxies = config.ServerSettings{TrustedProxies: []string{"10.0.0.0/8"}}.TrustedProxyRanges()
+	req := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Dave&form_elapsed=5000"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.RemoteAddr = "10.0.0.1:4711"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Errorf("expected a different client to create a room, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/admin/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	want := `treacherest_room_creation_rejected_total{scope="ip"} 1`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("expected %s in:\n%s", want, w.Body.String())
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	h := newTestHandler()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "192.0.2.10:5555"
+	if got := h.clientIP(req); got != "192.0.2.10" {
+		t.Errorf("expected the remote host, got %q", got)
+	}
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	if got := h.clientIP(req); got != "192.0.2.10" {
+		t.Errorf("expected a forwarded address from an untrusted peer ignored, got %q", got)
+	}
+
+	h.trustedProxies = config.ServerSettings{TrustedProxies: []string{"192.0.2.0/24"}}.TrustedProxyRanges()
+	if got := h.clientIP(req); got != "203.0.113.7" {
+		t.Errorf("expected the trusted proxy's forwarded address, got %q", got)
+	}
+}
+
+func TestRoomQuotaIgnoresSpoofedForwardedFor(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.RoomCreationPerIP = 1
+	h.config().Server.RoomCreationWindow = time.Minute
+	withFakeClock(h)
+	h.trustedProxies = config.ServerSettings{TrustedProxies: []string{"10.0.0.0/8"}}.TrustedProxyRanges()
+	router := newTestRouter(h)
+	create := func(remoteAddr, forwarded string) int {
+		req := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Eve&form_elapsed=5000"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwarded)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := create("198.51.100.4:4711", "203.0.113.1"); code != http.StatusSeeOther {
+		t.Fatalf("expected the first room created, got %d", code)
+	}
+	if code := create("198.51.100.4:4712", "203.0.113.2"); code != http.StatusTooManyRequests {
+		t.Errorf("expected a new forwarded address from an untrusted peer to count against its own, got %d", code)
+	}
+
+	// Behind the proxy, only the hop it added names the client
+	if code := create("10.0.0.1:4711", "203.0.113.9"); code != http.StatusSeeOther {
+		t.Fatalf("expected the proxy's client to create a room, got %d", code)
+	}
+	if code := create("10.0.0.1:4712", "203.0.113.3, 203.0.113.9"); code != http.StatusTooManyRequests {
+		t.Errorf("expected a hop the client added ignored, got %d", code)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,6 +18,12 @@ type RouterOptions struct {
 	StaticDir            string // defaults to "static"
 }
 
+// BuildRouter creates the production router for h. main and the route contract
+// test both use it, so the tested route table is the one that ships.
+func BuildRouter(h *Handler) *chi.Mux {
+	return SetupRouter(h, h.config(), nil)
+}
+
 // SetupRouter creates the application router with all routes and middleware
 func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi.Mux {
 	if opts == nil {
@@ -31,11 +38,16 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 	// Set up router
 	r := chi.NewRouter()
 
-	// Chi's built-in middleware (conditionally applied)
+	// Chi's built-in middleware (conditionally applied). Handlers log the
+	// request ID as request_id, so it comes first.
+	r.Use(middleware.RequestID)
 	if !opts.DisableRequestLogger {
 		r.Use(middleware.Logger)
 	}
 	r.Use(middleware.Recoverer)
+	if cfg.Server.A11yAuditEnabled {
+		r.Use(localMiddleware.A11yAudit())
+	}
 
 	// Group for regular routes WITH timeout
 	r.Group(func(r chi.Router) {
@@ -46,12 +58,22 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 
 		// Our custom middleware
 		r.Use(localMiddleware.RequestSizeLimiter(cfg.Server.MaxRequestSize))
-		r.Use(localMiddleware.SecurityHeaders())
+		r.Use(localMiddleware.SecurityHeaders(localMiddleware.SecurityPolicy{
+			ContentSecurityPolicy: localMiddleware.ContentSecurityPolicy(cfg.Server.ContentSecurityPolicy, "'none'"),
+			HSTSMaxAge:            cfg.Server.HSTSMaxAge,
+		}))
 
 		// Rate limiting (conditionally applied)
 		if !opts.DisableRateLimiting {
 			rateLimiter := localMiddleware.NewRateLimiter(cfg.Server.RateLimit, cfg.Server.RateLimitBurst)
-			r.Use(rateLimiter.Middleware())
+			r.Use(h.rateLimitByTenant(rateLimiter))
+			configLimiter := localMiddleware.NewRateLimiter(cfg.Server.ConfigRateLimit, cfg.Server.ConfigRateLimitBurst)
+			r.Use(h.limitConfigMutations(configLimiter))
+			// The changes that get through re-render the room at most
+			// ConfigRateLimit times a second
+			if cfg.Server.ConfigRateLimit > 0 {
+				h.eventBus.SetCoalescing(configEventTypes, time.Duration(float64(time.Second)/cfg.Server.ConfigRateLimit))
+			}
 		}
 
 		// Apply custom middleware if provided
@@ -59,27 +81,66 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 			r.Use(mw)
 		}
 
+		// Other tenants' rooms don't exist here, and one request at a time
+		// per room; group middleware runs after routing, so the {code} URL
+		// param is already set
+		r.Use(h.scopeRoom)
+		r.Use(h.lockRoom)
+
 		// Static files
 		r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir(opts.StaticDir))))
 
 		// Main pages
 		r.Get("/", h.Home)
+		r.Get("/about", h.About)
+		r.Get("/rules", h.Rules)
+		r.Get("/rules/{role}", h.RoleRules)
+		r.Get("/cards/{id}", h.CardPage)
+		r.Get("/t/{tenant}", h.EnterTenant)
+		r.Get("/t/{tenant}/*", h.EnterTenant)
+		r.Get("/api/v1/cards/search", h.SearchCards)
 		r.Post("/room/new", h.CreateRoom) // Changed from /room/create to match form action
 		r.Get("/room/{code}/qr.png", h.RoomQRCode)
 		r.Get("/room/{code}", h.JoinRoom)
 		r.Get("/room/{code}/operator", h.OperatorDashboard)
+		r.Get("/room/{code}/diagnostics", h.DownloadDiagnostics)
+		r.Get("/room/{code}/metrics", h.SessionMetrics)
+		r.Get("/host/{code}", h.HostPage)
+		r.Post("/host/{code}/recover", h.RecoverHost)
+		r.Get("/room/{code}/reclaim", h.SeatReclaimPage)
+		r.Post("/room/{code}/reclaim", h.ReclaimSeat)
 		r.Post("/join-room", h.JoinRoomPost)   // New POST endpoint for joining rooms
 		r.Post("/room/restore", h.RestoreRoom) // Restore room from client backup
+
 		r.Post("/room/{code}/leave", h.LeaveRoom)
+		r.Post("/room/{code}/host/transfer/{playerID}", h.TransferHost)
+		r.Post("/room/{code}/kick/{playerID}", h.KickPlayer)
+		r.Post("/room/{code}/ban/{playerID}", h.BanPlayer)
 		r.Post("/room/{code}/start", h.StartGame)
+		r.Post("/room/{code}/start/confirm", h.ConfirmStart)
+		r.Post("/room/{code}/start/cancel", h.CancelStart)
+		r.Post("/room/{code}/restart", h.RestartGame)
+		r.Post("/room/{code}/deal/approve", h.ApproveDeal)
+		r.Post("/room/{code}/deal/redeal", h.Redeal)
 		r.Post("/room/{code}/reveal/{playerID}", h.ToggleReveal)
 		r.Post("/room/{code}/facestate/{playerID}", h.ToggleFaceState)
 		r.Post("/room/{code}/unveil/{playerID}", h.UnveilPlayer)
 		r.Get("/room/{code}/unveil-modal/{playerID}", h.GetUnveilModal)
 		r.Get("/game/{code}", h.GamePage)
+		r.Get("/room/{code}/resync", h.ResyncRoom)
+		r.Get("/room/{code}/role-image/{token}", h.RoleImage)
+		r.Get("/overlay/{code}", h.OverlayPage)
+		r.Get("/watch/{token}", h.WatchPage)
+		r.Get("/room/{code}/spectate", h.SpectatePage)
+		r.Post("/room/{code}/spectate", h.AdmitSpectator)
+		r.Post("/room/{code}/watch-links", h.CreateWatchLink)
+		r.Post("/room/{code}/watch-links/{token}/revoke", h.RevokeWatchLink)
+		r.Post("/room/{code}/invites", h.SendInvites)
+		r.Get("/invite/{token}", h.AcceptInviteLink)
 
 		// Role configuration endpoints
 		r.Post("/room/{code}/config/preset", h.UpdateRolePreset)
+		r.Post("/room/{code}/config/changes/dismiss", h.DismissConfigChanges)
 		r.Post("/room/{code}/config/coup-preset", h.UpdateCoupPreset)
 		r.Post("/room/{code}/config/coup-player-count/increment", h.IncrementCoupPlayerCount)
 		r.Post("/room/{code}/config/coup-player-count/decrement", h.DecrementCoupPlayerCount)
@@ -95,10 +156,59 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 		r.Post("/room/{code}/coup/inquisition/confirm", h.ConfirmCoupInquisition)
 		r.Post("/room/{code}/coup/win/confirm", h.ConfirmCoupWinPrompt)
 		r.Post("/room/{code}/coup/win/reject", h.RejectCoupWinPrompt)
+		r.Get("/room/{code}/config/cards/{roleType}", h.GetRoleCards)
 		r.Post("/room/{code}/config/toggle", h.ToggleRole)
 		r.Post("/room/{code}/config/count", h.UpdateRoleCount)
 		r.Post("/room/{code}/config/leaderless", h.UpdateLeaderlessGame)
 		r.Post("/room/{code}/config/hide-distribution", h.UpdateHideDistribution)
+		r.Post("/room/{code}/config/phases", h.UpdatePhaseSettings)
+		r.Post("/room/{code}/config/start-ritual", h.UpdateStartRitual)
+		r.Post("/room/{code}/config/screenshot-deterrence", h.UpdateScreenshotDeterrence)
+		r.Post("/room/{code}/phase/advance", h.AdvancePhase)
+		r.Post("/room/{code}/vote/open", h.OpenVote)
+		r.Post("/room/{code}/vote/cast/{optionID}", h.CastVote)
+		r.Post("/room/{code}/vote/close", h.CloseVote)
+		r.Post("/room/{code}/poll/open", h.OpenPresetPoll)
+		r.Post("/room/{code}/poll/vote/{optionID}", h.CastPollVote)
+		r.Post("/room/{code}/poll/apply", h.ApplyPollWinner)
+		r.Post("/room/{code}/poll/close", h.ClosePoll)
+		r.Post("/room/{code}/notes", h.SaveNotes)
+		r.Post("/room/{code}/countdown-style", h.SetCountdownStyle)
+		r.Post("/room/{code}/chat", h.PostChat)
+
+		// Admin endpoints (bearer token, see requireAdmin)
+		r.Get("/admin/maintenance", h.GetMaintenance)
+		r.Post("/admin/maintenance", h.SetMaintenance)
+		r.Get("/admin/drain", h.GetDrain)
+		r.Post("/admin/drain", h.StartDrain)
+		r.Get("/admin/telemetry", h.GetSSETelemetry)
+		r.Get("/admin/metrics", h.GetMetrics)
+
+		// Read-only GraphQL API for dashboards, see GraphQL
+		if cfg.Server.GraphQLEnabled {
+			r.Post("/api/graphql", h.GraphQL)
+		}
+
+		// Client connection quality reports, see ReportSSETelemetry
+		r.Post("/telemetry/sse", h.ReportSSETelemetry)
+
+		// Clock sync and skew reports for the scheduled role reveal, see revealSyncLead
+		r.Get("/telemetry/clock", h.ServerClock)
+		r.Post("/telemetry/reveal", h.ReportRevealTelemetry)
+
+		// Purges the caller's history, see ForgetMe
+		r.Post("/privacy/forget-me", h.ForgetMe)
+
+		// First-game hints preference, see sendOnboardingHint
+		r.Post("/hints/off", h.TurnOffHints)
+
+		// Pass-the-phone tables: one shared device, no room or stream
+		r.Post("/table/new", h.NewTable)
+		r.Get("/table/{id}", h.TablePage)
+		r.Post("/table/{id}/seat", h.SeatAtTable)
+		r.Post("/table/{id}/deal", h.DealTable)
+		r.Post("/table/{id}/reveal", h.RevealAtTable)
+		r.Post("/table/{id}/hide", h.HideAtTable)
 
 		// Role options endpoints (for card-specific configuration)
 		r.Get("/room/{code}/options", h.GetRoleOptions)
@@ -133,11 +243,14 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 		r.Post("/room/{code}/config/role-type/{roleType}/decrement", h.DecrementRoleTypeCount)
 		r.Post("/room/{code}/config/player-count/increment", h.IncrementPlayerCount)
 		r.Post("/room/{code}/config/player-count/decrement", h.DecrementPlayerCount)
+		r.Post("/room/{code}/config/rebalance-strategy", h.UpdateRebalanceStrategy)
 
 		// New role configuration endpoints
+		r.Post("/room/{code}/config/card-locale", h.SetCardLocale)
 		r.Post("/room/{code}/config/card-toggle", h.ToggleRoleCard)
 		r.Post("/room/{code}/config/card-toggle-fast", h.ToggleRoleCardFast)
 		r.Post("/room/{code}/config/card-toggle-optimistic", h.ToggleRoleCardOptimistic)
+		r.Post("/room/{code}/config/pack/{packID}", h.ToggleCardPack)
 
 		if cfg.Server.DebugModeEnabled {
 			r.Post("/room/{code}/debug/clear", h.DebugClearRoom)
@@ -145,6 +258,7 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 			r.Post("/room/{code}/debug/start-as-is", h.DebugStartAsIs)
 			r.Get("/room/{code}/debug/operator-view", h.DebugOperatorView)
 			r.Get("/room/{code}/debug/view-as/{playerID}", h.DebugViewAsPlayer)
+			r.Get("/room/{code}/debug/knowledge", h.DebugKnowledge)
 		}
 	})
 
@@ -153,11 +267,20 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 		// SSE routes should have no timeout - they're long-lived connections
 		// Don't apply any timeout middleware to this group
 		// NOTE: SSE routes should NOT inherit RequestTimeout from regular routes
+		r.Use(h.scopeRoom)
 
 		// SSE routes with validation middleware
-		r.Get("/sse/lobby/{code}", ValidateSSERequest(h.StreamLobby))
-		r.Get("/sse/game/{code}", ValidateSSERequest(h.StreamGame))
-		r.Get("/sse/host/{code}", ValidateSSERequest(h.StreamHost))
+		// Streams are drainable so deploys can move clients to the new instance
+		r.Get("/sse/lobby/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamLobby))))
+		r.Get("/sse/game/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamGame))))
+		r.Get("/sse/host/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamHost))))
+		r.Get("/sse/room/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamRoom))))
+		r.Get("/sse/overlay/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamOverlay))))
+		r.Get("/sse/watch/{token}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamWatch))))
+		r.Get("/sse/spectate/{code}", ValidateSSERequest(h.drainableSSE(h.limitedSSE(h.StreamSpectate))))
+
+		// The room streams over a WebSocket, for clients behind proxies that buffer SSE
+		r.Get("/ws/room/{code}", h.RoomSocket)
 	})
 
 	// Health check endpoints (no auth required)
@@ -166,15 +289,8 @@ func SetupRouter(h *Handler, cfg *config.ServerConfig, opts *RouterOptions) *chi
 		w.Write([]byte("OK"))
 	})
 
-	r.Get("/health/ready", func(w http.ResponseWriter, r *http.Request) {
-		// In production, you might check:
-		// - Database connections
-		// - External service availability
-		// - Cache connections
-		// For now, we assume the service is ready if we can respond
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	// Ready turns unavailable while draining so load balancers stop routing here
+	r.Get("/health/ready", h.Ready)
 
 	return r
 }
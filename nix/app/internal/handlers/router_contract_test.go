@@ -0,0 +1,407 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// productionRoutes is the route table main serves. Adding, renaming or
+// removing a route means updating this list in the same change.
+var productionRoutes = []string{
+	"GET /",
+	"GET /about",
+	"GET /t/{tenant}",
+	"GET /t/{tenant}/*",
+	"GET /admin/drain",
+	"GET /admin/maintenance",
+	"GET /admin/metrics",
+	"GET /admin/telemetry",
+	"GET /api/v1/cards/search",
+	"GET /cards/{id}",
+	"GET /game/{code}",
+	"GET /invite/{token}",
+	"GET /health/live",
+	"GET /health/ready",
+	"GET /host/{code}",
+	"GET /overlay/{code}",
+	"GET /room/{code}",
+	"GET /room/{code}/config/cards/{roleType}",
+	"GET /room/{code}/diagnostics",
+	"GET /room/{code}/metrics",
+	"GET /room/{code}/operator",
+	"GET /room/{code}/options",
+	"GET /room/{code}/qr.png",
+	"GET /room/{code}/resync",
+	"GET /room/{code}/role-image/{token}",
+	"GET /room/{code}/spectate",
+	"GET /room/{code}/unveil-modal/{playerID}",
+	"GET /rules",
+	"GET /rules/{role}",
+	"GET /sse/game/{code}",
+	"GET /sse/host/{code}",
+	"GET /sse/lobby/{code}",
+	"GET /sse/overlay/{code}",
+	"GET /sse/room/{code}",
+	"GET /sse/spectate/{code}",
+	"GET /sse/watch/{token}",
+	"ANY /static/*",
+	"GET /table/{id}",
+	"GET /telemetry/clock",
+	"GET /watch/{token}",
+	"GET /ws/room/{code}",
+	"POST /admin/drain",
+	"POST /admin/maintenance",
+	"POST /host/{code}/recover",
+	"GET /room/{code}/reclaim",
+	"POST /room/{code}/reclaim",
+	"POST /join-room",
+	"POST /privacy/forget-me",
+	"POST /hints/off",
+	"POST /room/new",
+	"POST /room/restore",
+	"POST /room/{code}/ability/{abilityID}/confirm",
+	"POST /room/{code}/ability/{abilityID}/dismiss",
+	"POST /room/{code}/ability/{abilityID}/restore",
+	"POST /room/{code}/ability/{abilityID}/select-card/{cardID}",
+	"POST /room/{code}/chat",
+	"POST /room/{code}/config/card-locale",
+	"POST /room/{code}/config/card-toggle",
+	"POST /room/{code}/config/card-toggle-fast",
+	"POST /room/{code}/config/card-toggle-optimistic",
+	"POST /room/{code}/config/changes/dismiss",
+	"POST /room/{code}/config/count",
+	"POST /room/{code}/config/coup-green-hunt",
+	"POST /room/{code}/config/coup-info",
+	"POST /room/{code}/config/coup-inquisition",
+	"POST /room/{code}/config/coup-player-count/decrement",
+	"POST /room/{code}/config/coup-player-count/increment",
+	"POST /room/{code}/config/coup-preset",
+	"POST /room/{code}/config/coup-role-count/{role}/decrement",
+	"POST /room/{code}/config/coup-role-count/{role}/increment",
+	"POST /room/{code}/config/coup-role-counts",
+	"POST /room/{code}/config/coup-royal-guard",
+	"POST /room/{code}/config/fully-random",
+	"POST /room/{code}/config/hide-distribution",
+	"POST /room/{code}/config/leaderless",
+	"POST /room/{code}/config/pack/{packID}",
+	"POST /room/{code}/config/phases",
+	"POST /room/{code}/config/player-count/decrement",
+	"POST /room/{code}/config/player-count/increment",
+	"POST /room/{code}/config/preset",
+	"POST /room/{code}/config/rebalance-strategy",
+	"POST /room/{code}/config/role-type/{roleType}/decrement",
+	"POST /room/{code}/config/role-type/{roleType}/increment",
+	"POST /room/{code}/config/screenshot-deterrence",
+	"POST /room/{code}/config/start-ritual",
+	"POST /room/{code}/config/toggle",
+	"POST /room/{code}/coup/inquisition/confirm",
+	"POST /room/{code}/coup/inquisition/{playerID}",
+	"POST /room/{code}/coup/royal-guard/{playerID}",
+	"POST /room/{code}/coup/win/confirm",
+	"POST /room/{code}/coup/win/reject",
+	"POST /room/{code}/countdown-style",
+	"POST /room/{code}/deal/approve",
+	"POST /room/{code}/deal/redeal",
+	"POST /room/{code}/facestate/{playerID}",
+	"POST /room/{code}/host/transfer/{playerID}",
+	"POST /room/{code}/kick/{playerID}",
+	"POST /room/{code}/ban/{playerID}",
+	"POST /room/{code}/leave",
+	"POST /room/{code}/notes",
+	"POST /room/{code}/options",
+	"POST /room/{code}/phase/advance",
+	"POST /room/{code}/player/{playerID}/eliminate",
+	"POST /room/{code}/player/{playerID}/end-metamorph",
+	"POST /room/{code}/player/{playerID}/steal-role/{targetPlayerID}",
+	"POST /room/{code}/player/{playerID}/trigger-metamorph",
+	"POST /room/{code}/player/{playerID}/trigger-puppet-master",
+	"POST /room/{code}/player/{playerID}/trigger-wearer/{xValue}",
+	"POST /room/{code}/poll/apply",
+	"POST /room/{code}/poll/close",
+	"POST /room/{code}/poll/open",
+	"POST /room/{code}/poll/vote/{optionID}",
+	"POST /room/{code}/puppet-master/{abilityID}/back",
+	"POST /room/{code}/puppet-master/{abilityID}/execute",
+	"POST /room/{code}/puppet-master/{abilityID}/select-players",
+	"POST /room/{code}/puppet-master/{abilityID}/skip",
+	"POST /room/{code}/reveal/{playerID}",
+	"POST /room/{code}/spectate",
+	"POST /room/{code}/start",
+	"POST /room/{code}/start/cancel",
+	"POST /room/{code}/restart",
+	"POST /room/{code}/start/confirm",
+	"POST /room/{code}/unveil/{playerID}",
+	"POST /room/{code}/vote/cast/{optionID}",
+	"POST /room/{code}/vote/close",
+	"POST /room/{code}/vote/open",
+	"POST /room/{code}/invites",
+	"POST /room/{code}/watch-links",
+	"POST /room/{code}/watch-links/{token}/revoke",
+	"POST /table/new",
+	"POST /table/{id}/deal",
+	"POST /table/{id}/hide",
+	"POST /table/{id}/reveal",
+	"POST /table/{id}/seat",
+	"POST /telemetry/reveal",
+	"POST /telemetry/sse",
+}
+
+// debugRoutes are only mounted when DebugModeEnabled is set
+var debugRoutes = []string{
+	"GET /room/{code}/debug/knowledge",
+	"GET /room/{code}/debug/operator-view",
+	"GET /room/{code}/debug/view-as/{playerID}",
+	"POST /room/{code}/debug/clear",
+	"POST /room/{code}/debug/start-as-is",
+	"POST /room/{code}/debug/start-with-debug-players",
+}
+
+// graphQLRoutes are only mounted when GraphQLEnabled is set
+var graphQLRoutes = []string{
+	"POST /api/graphql",
+}
+
+var allMethods = []string{
+	http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions,
+	http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace,
+}
+
+// routeSet lists the router's routes as "METHOD pattern"; patterns mounted
+// with Handle answer every method and are listed once as "ANY pattern"
+func routeSet(t *testing.T, router chi.Routes) []string {
+	t.Helper()
+	methods := make(map[string]map[string]bool)
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if methods[route] == nil {
+			methods[route] = make(map[string]bool)
+		}
+		methods[route][method] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+
+	var routes []string
+	for route, set := range methods {
+		if len(set) == len(allMethods) {
+			routes = append(routes, "ANY "+route)
+			continue
+		}
+		for method := range set {
+			routes = append(routes, method+" "+route)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func sortedRoutes(groups ...[]string) []string {
+	var routes []string
+	for _, g := range groups {
+		routes = append(routes, g...)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func diffRoutes(t *testing.T, got, want []string) {
+	t.Helper()
+	have := make(map[string]bool, len(got))
+	for _, r := range got {
+		have[r] = true
+	}
+	expected := make(map[string]bool, len(want))
+	for _, r := range want {
+		expected[r] = true
+		if !have[r] {
+			t.Errorf("missing route %s", r)
+		}
+	}
+	for _, r := range got {
+		if !expected[r] {
+			t.Errorf("unexpected route %s; add it to the route contract", r)
+		}
+	}
+}
+
+func TestBuildRouterMatchesRouteContract(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.DebugModeEnabled = false
+	diffRoutes(t, routeSet(t, BuildRouter(h)), sortedRoutes(productionRoutes))
+
+	h.config().Server.DebugModeEnabled = true
+	diffRoutes(t, routeSet(t, BuildRouter(h)), sortedRoutes(productionRoutes, debugRoutes))
+
+	h.config().Server.DebugModeEnabled = false
+	h.config().Server.GraphQLEnabled = true
+	diffRoutes(t, routeSet(t, BuildRouter(h)), sortedRoutes(productionRoutes, graphQLRoutes))
+}
+
+func TestTestRouterServesProductionRoutes(t *testing.T) {
+	h := newTestHandler()
+	diffRoutes(t, routeSet(t, newTestRouter(h)), routeSet(t, BuildRouter(h)))
+}
+
+// TestTestRequestsTargetProductionRoutes parses this package's tests and checks
+// that every request a test serves through a router resolves in the
+// production route table, so a test cannot pass against a route that does
+// not exist. Requests handed straight to a handler method are not checked.
+func TestTestRequestsTargetProductionRoutes(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.DebugModeEnabled = true
+	h.config().Server.GraphQLEnabled = true
+	router := BuildRouter(h)
+
+	files, err := filepath.Glob("*_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checked := 0
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", file, err)
+		}
+
+		// Track request variables in source order so reassignments win
+		requests := make(map[string][2]string)
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+					return true
+				}
+				name, ok := n.Lhs[0].(*ast.Ident)
+				call := newRequestCall(n.Rhs[0])
+				if !ok || call == nil {
+					return true
+				}
+				if method, path, ok := requestTarget(call.Args[0], call.Args[1]); ok {
+					requests[name.Name] = [2]string{method, path}
+				} else {
+					delete(requests, name.Name)
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "ServeHTTP" || len(n.Args) != 2 {
+					return true
+				}
+				arg, ok := n.Args[1].(*ast.Ident)
+				if !ok {
+					return true
+				}
+				target, ok := requests[arg.Name]
+				if !ok {
+					return true
+				}
+				checked++
+				if !router.Match(chi.NewRouteContext(), target[0], target[1]) {
+					t.Errorf("%s: %s %s does not match any production route", fset.Position(n.Pos()), target[0], target[1])
+				}
+			}
+			return true
+		})
+	}
+
+	if checked == 0 {
+		t.Fatal("found no router requests to check; the test parser no longer recognises this package's tests")
+	}
+}
+
+// newRequestCall returns the httptest.NewRequest call in e, looking through .WithContext(ctx)
+func newRequestCall(e ast.Expr) *ast.CallExpr {
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	switch sel.Sel.Name {
+	case "WithContext":
+		return newRequestCall(sel.X)
+	case "NewRequest":
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "httptest" && len(call.Args) >= 2 {
+			return call
+		}
+	}
+	return nil
+}
+
+// requestTarget resolves a method and path from NewRequest arguments.
+// Non-literal path segments (room codes, IDs) are replaced with a placeholder;
+// paths that do not start with a literal "/", or where a non-literal is not
+// a whole segment, are skipped.
+func requestTarget(methodArg, pathArg ast.Expr) (string, string, bool) {
+	var method string
+	switch m := methodArg.(type) {
+	case *ast.BasicLit:
+		method, _ = strconv.Unquote(m.Value)
+	case *ast.SelectorExpr:
+		method = strings.ToUpper(strings.TrimPrefix(m.Sel.Name, "Method"))
+	}
+	if method == "" {
+		return "", "", false
+	}
+
+	var parts []ast.Expr
+	var flatten func(e ast.Expr)
+	flatten = func(e ast.Expr) {
+		if bin, ok := e.(*ast.BinaryExpr); ok && bin.Op == token.ADD {
+			flatten(bin.X)
+			flatten(bin.Y)
+			return
+		}
+		parts = append(parts, e)
+	}
+	flatten(pathArg)
+
+	var path strings.Builder
+	for i, part := range parts {
+		if s, ok := stringLiteral(part); ok {
+			path.WriteString(s)
+			continue
+		}
+		if !strings.HasSuffix(path.String(), "/") {
+			return "", "", false
+		}
+		if i+1 < len(parts) {
+			next, ok := stringLiteral(parts[i+1])
+			if !ok || !strings.HasPrefix(next, "/") && !strings.HasPrefix(next, "?") {
+				return "", "", false
+			}
+		}
+		path.WriteString("ABCDE")
+	}
+
+	target := path.String()
+	if !strings.HasPrefix(target, "/") {
+		return "", "", false
+	}
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target = target[:i]
+	}
+	return method, target, true
+}
+
+func stringLiteral(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
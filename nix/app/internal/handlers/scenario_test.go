@@ -0,0 +1,39 @@
+package handlers
+
+import "testing"
+
+func TestScenarioReconnectAfterRevealShowsTheRevealedRole(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+
+	s := newScenario(t, h).
+		Join("Alice").Join("Bob").Join("Carol").
+		Start().
+		ExpectEvent(string(EventGameStarted)).
+		ExpectFragmentContains("#page-body", "Revealing roles in")
+
+	// End the countdown without waiting it out
+	room, _ := h.store.GetRoom(s.RoomCode)
+	room.Lock()
+	h.beginPlaying(room, EventActor{})
+	room.Unlock()
+	s.ExpectEvent(string(EventGamePlaying))
+
+	bobsRole := room.GetPlayer(s.Client("Bob").PlayerID()).Role.Name
+	s.Reveal("Bob").
+		ExpectEvent(string(EventRoleRevealed)).
+		Reconnect("Alice").
+		Only("Alice").
+		ExpectFragmentContains("#game-container", "Revealed: "+bobsRole)
+}
+
+func TestScenarioPlayerLeavingTheLobbyDropsOffTheRoster(t *testing.T) {
+	h := newTestHandler()
+
+	newScenario(t, h).
+		Join("Alice").Join("Bob").
+		Only("Alice").ExpectFragmentContains("#player-list-card", "2 of ").
+		Leave("Bob").
+		ExpectEvent(string(EventPlayerLeft)).
+		ExpectFragmentContains("#player-list-card", "1 of ")
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+const (
+	// maxSeatPINFailures wrong PINs in a row lock a seat for seatPINLockout,
+	// so guessing one of the 10,000 PINs takes days rather than seconds
+	maxSeatPINFailures = 5
+	seatPINLockout     = 15 * time.Minute
+)
+
+// seatPINGuard counts wrong PINs per seat across every browser, since an
+// attacker can always start a fresh session
+type seatPINGuard struct {
+	mu    sync.Mutex
+	seats map[string]*seatPINFailures
+}
+
+type seatPINFailures struct {
+	count       int
+	lockedUntil time.Time
+}
+
+func newSeatPINGuard() *seatPINGuard {
+	return &seatPINGuard{seats: make(map[string]*seatPINFailures)}
+}
+
+// playerSeat and operatorSeat name the seats the guard counts for
+func playerSeat(roomCode, name string) string { return roomCode + "/" + strings.ToLower(name) }
+func operatorSeat(roomCode string) string     { return roomCode + ":operator" }
+
+// lockedFor returns how long seat stays locked after too many wrong PINs;
+// zero when it isn't
+func (g *seatPINGuard) lockedFor(seat string, now time.Time) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if f := g.seats[seat]; f != nil && now.Before(f.lockedUntil) {
+		return f.lockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// failed counts a wrong PIN for seat, locking it on the last one allowed
+func (g *seatPINGuard) failed(seat string, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f := g.seats[seat]
+	if f == nil {
+		f = &seatPINFailures{}
+		g.seats[seat] = f
+	}
+	f.count++
+	if f.count >= maxSeatPINFailures {
+		f.count = 0
+		f.lockedUntil = now.Add(seatPINLockout)
+	}
+}
+
+// succeeded forgets seat's wrong PINs
+func (g *seatPINGuard) succeeded(seat string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.seats, seat)
+}
+
+// seatLockedMessage tells a browser how long to wait before trying a PIN again
+func seatLockedMessage(wait time.Duration) string {
+	minutes := int(wait.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes == 1 {
+		return "Too many wrong PINs for that seat. Try again in a minute."
+	}
+	return "Too many wrong PINs for that seat. Try again in " + strconv.Itoa(minutes) + " minutes."
+}
+
+// SeatReclaimPage shows the form for moving a PIN-protected seat to this browser
+func (h *Handler) SeatReclaimPage(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		pages.RoomNotFound(roomCode).Render(r.Context(), w)
+		return
+	}
+	pages.SeatReclaim(room.Code, "").Render(r.Context(), w)
+}
+
+// ReclaimSeat moves the seat of the posted player name to the requesting
+// browser when the posted PIN is the one the seat was protected with
+func (h *Handler) ReclaimSeat(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("player_name"))
+	seat := playerSeat(room.Code, name)
+	if wait := h.seatPINs.lockedFor(seat, h.clock.Now()); wait > 0 {
+		w.WriteHeader(http.StatusTooManyRequests)
+		pages.SeatReclaim(room.Code, seatLockedMessage(wait)).Render(r.Context(), w)
+		return
+	}
+
+	sessionID := h.getOrCreateSession(w, r)
+	player, err := room.ReclaimSeat(name, strings.TrimSpace(r.FormValue("pin")), sessionID)
+	switch {
+	case errors.Is(err, game.ErrSeatPINMismatch):
+		h.seatPINs.failed(seat, h.clock.Now())
+		log.Printf("🔐 Rejected seat reclaim in room %s: wrong seat PIN", room.Code)
+		w.WriteHeader(http.StatusForbidden)
+		pages.SeatReclaim(room.Code, "That PIN doesn't match the seat.").Render(r.Context(), w)
+		return
+	case err != nil:
+		w.WriteHeader(http.StatusBadRequest)
+		pages.SeatReclaim(room.Code, seatReclaimMessage(err)).Render(r.Context(), w)
+		return
+	}
+
+	h.seatPINs.succeeded(seat)
+	h.store.UpdateRoom(room)
+	log.Printf("🔐 Player %s moved their seat in room %s to another browser", player.ID, room.Code)
+
+	setPlayerCookie(w, room.Code, player.ID)
+	http.Redirect(w, r, "/room/"+room.Code, http.StatusSeeOther)
+}
+
+// seatReclaimMessage explains why a seat didn't move
+func seatReclaimMessage(err error) string {
+	switch {
+	case errors.Is(err, game.ErrPlayerNotFound):
+		return "Nobody by that name is seated in this room."
+	case errors.Is(err, game.ErrSeatNotProtected):
+		return "That seat has no PIN, so it can't be moved to another device."
+	case errors.Is(err, game.ErrSeatIsOperators):
+		return "That's the Room Operator's seat. Recover it with the recovery code from the host dashboard."
+	case errors.Is(err, game.ErrAlreadySeated):
+		return "This browser already has a seat in this room."
+	}
+	return "That seat couldn't be moved."
+}
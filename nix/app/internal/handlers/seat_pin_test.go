@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+func TestReclaimSeatMovesAPINProtectedSeat(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+
+	laptop := testkit.NewClient(t, router)
+	laptop.RoomCode = roomCode
+	w := laptop.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Bob"}, "pin": {"2468"}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("join: expected 303, got %d", w.Code)
+	}
+	bob := laptop.PlayerID()
+	if bob == "" {
+		t.Fatal("expected Bob to get a seat")
+	}
+
+	phone := testkit.NewClient(t, router)
+	phone.RoomCode = roomCode
+	if w := phone.Get("/room/" + roomCode + "/reclaim"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `id="seat-reclaim"`) {
+		t.Fatalf("expected the reclaim form, got %d", w.Code)
+	}
+	w = phone.Post("/room/"+roomCode+"/reclaim", url.Values{"player_name": {"Bob"}, "pin": {"1357"}})
+	if w.Code != http.StatusForbidden || phone.PlayerCookie() != nil {
+		t.Fatalf("expected a wrong PIN to be refused, got %d", w.Code)
+	}
+	w = phone.Post("/room/"+roomCode+"/reclaim", url.Values{"player_name": {"bob"}, "pin": {"2468"}})
+	if w.Code != http.StatusSeeOther || phone.PlayerID() != bob {
+		t.Fatalf("expected the right PIN to move Bob's seat, got %d", w.Code)
+	}
+	room, _ := h.store.GetRoom(roomCode)
+	if got := room.GetPlayer(bob); got == nil || got.SessionID != phone.SessionCookie().Value {
+		t.Error("expected Bob's seat to follow the phone's session")
+	}
+
+	// The operator's seat needs the recovery code as well, and a seat without
+	// a PIN can't move at all
+	other := testkit.NewClient(t, router)
+	if w := other.Post("/room/"+roomCode+"/reclaim", url.Values{"player_name": {"Alice"}, "pin": {"0000"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected the operator's seat to be refused, got %d", w.Code)
+	}
+}
+
+func TestSeatPINGuessesAreLimited(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	router := newTestRouter(h)
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	joiner := testkit.NewClient(t, router)
+	joiner.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {"Bob"}, "pin": {"2468"}, components.BotElapsedField: {"5000"}})
+
+	// Each guess comes from a fresh browser, as a script's would
+	reclaim := func(pin string) int {
+		return testkit.NewClient(t, router).Post("/room/"+roomCode+"/reclaim", url.Values{"player_name": {"Bob"}, "pin": {pin}}).Code
+	}
+	for i := 0; i < maxSeatPINFailures; i++ {
+		if code := reclaim("0000"); code != http.StatusForbidden {
+			t.Fatalf("guess %d: expected 403, got %d", i+1, code)
+		}
+	}
+	if code := reclaim("2468"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the seat locked after %d wrong PINs, got %d", maxSeatPINFailures, code)
+	}
+
+	fake.Advance(seatPINLockout)
+	if code := reclaim("2468"); code != http.StatusSeeOther {
+		t.Errorf("expected the right PIN to work once the lock ran out, got %d", code)
+	}
+}
+
+func TestRecoverHostAccessLimitsPINGuesses(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	host := testkit.NewClient(t, router)
+	w := host.Post("/room/new", url.Values{"playerName": {"Host"}, "hostOnly": {"true"}, "pin": {"2468"}, components.BotElapsedField: {"5000"}})
+	hostPath := w.Header().Get("Location")
+	room, _ := h.store.GetRoom(strings.TrimPrefix(hostPath, "/host/"))
+
+	for i := 0; i < maxSeatPINFailures; i++ {
+		testkit.NewClient(t, router).Post(hostPath+"/recover", url.Values{"token": {room.CreatorToken}, "pin": {"0000"}})
+	}
+	w = testkit.NewClient(t, router).Post(hostPath+"/recover", url.Values{"token": {room.CreatorToken}, "pin": {"2468"}})
+	if w.Code != http.StatusTooManyRequests || !strings.Contains(w.Body.String(), "Too many wrong PINs") {
+		t.Errorf("expected host recovery locked after %d wrong PINs, got %d", maxSeatPINFailures, w.Code)
+	}
+}
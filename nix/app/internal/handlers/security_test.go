@@ -10,11 +10,11 @@ import (
 // TestURLParameterSecurityFix verifies that the URL parameter vulnerability is fixed
 func TestURLParameterSecurityFix(t *testing.T) {
 	h := newTestHandler()
-	testRouter := setupTestRouter(h)
+	testRouter := newTestRouter(h)
 
 	// Create a room
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Alice"))
+	r := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Alice&form_elapsed=5000"))
 	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	h.CreateRoom(w, r)
 
@@ -62,7 +62,7 @@ func TestURLParameterSecurityFix(t *testing.T) {
 	t.Run("JoinRequiresPOST", func(t *testing.T) {
 		// Create a new room to test joining
 		w2 := httptest.NewRecorder()
-		r2 := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Bob"))
+		r2 := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Bob&form_elapsed=5000"))
 		r2.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		h.CreateRoom(w2, r2)
 
@@ -97,7 +97,7 @@ func TestURLParameterSecurityFix(t *testing.T) {
 	t.Run("JoinOnlyViaPOST", func(t *testing.T) {
 		// Create a new room
 		w3 := httptest.NewRecorder()
-		r3 := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=Dave"))
+		r3 := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=Dave&form_elapsed=5000"))
 		r3.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		h.CreateRoom(w3, r3)
 
@@ -105,7 +105,7 @@ func TestURLParameterSecurityFix(t *testing.T) {
 		roomCode3 := strings.TrimPrefix(location3, "/room/")
 
 		// Join properly via POST
-		formData := "room_code=" + roomCode3 + "&player_name=Eve"
+		formData := "room_code=" + roomCode3 + "&player_name=Eve&form_elapsed=5000"
 		req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		req.AddCookie(sessionCookie)
@@ -154,7 +154,7 @@ func TestURLParameterSecurityFix(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				// Create a new room for each test
 				w := httptest.NewRecorder()
-				r := httptest.NewRequest("POST", "/room/create", strings.NewReader("playerName=TestHost"))
+				r := httptest.NewRequest("POST", "/room/new", strings.NewReader("playerName=TestHost&form_elapsed=5000"))
 				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 				h.CreateRoom(w, r)
 
@@ -162,7 +162,7 @@ func TestURLParameterSecurityFix(t *testing.T) {
 				testRoomCode := strings.TrimPrefix(location, "/room/")
 
 				// Try to join with the test name
-				formData := "room_code=" + testRoomCode + "&player_name=" + tc.name
+				formData := "room_code=" + testRoomCode + "&player_name=" + tc.name + "&form_elapsed=5000"
 				req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 				req.AddCookie(sessionCookie)
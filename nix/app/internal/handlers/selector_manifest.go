@@ -0,0 +1,49 @@
+package handlers
+
+//go:generate go run ../../cmd/selectormanifest
+
+import (
+	"log"
+	"strings"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+)
+
+// PageType identifies which page template a streaming viewer has loaded
+type PageType string
+
+const (
+	PageLobby   PageType = "lobby"
+	PageGame    PageType = "game"
+	PageHost    PageType = "host"
+	PageOverlay PageType = "overlay"
+	PageWatch   PageType = "watch"
+)
+
+// pageHasSelector reports whether selector targets an element the page can render.
+// Only plain id selectors are checked; anything else is assumed to be valid.
+func pageHasSelector(page PageType, selector string) bool {
+	id, ok := strings.CutPrefix(selector, "#")
+	if !ok || strings.ContainsAny(id, " .[>:") {
+		return true
+	}
+	ids, known := pageSelectors[page]
+	if !known {
+		return true
+	}
+	return ids[id]
+}
+
+// patchElements sends a fragment to selector after checking it against the viewer's page.
+// A selector the page never renders would only surface as NoTargetsFound in the browser:
+// it is logged, and dropped entirely when StrictSelectors is on.
+func (h *Handler) patchElements(sse *datastar.ServerSentEventGenerator, page PageType, html, selector string, opts ...datastar.PatchElementOption) error {
+	if !pageHasSelector(page, selector) {
+		if h.config().Server.StrictSelectors {
+			log.Printf("🎯 Skipping patch: %s is not rendered on the %s page", selector, page)
+			return nil
+		}
+		log.Printf("🎯 Selector mismatch: %s is not rendered on the %s page", selector, page)
+	}
+	return sse.PatchElements(html, append([]datastar.PatchElementOption{datastar.WithSelector(selector)}, opts...)...)
+}
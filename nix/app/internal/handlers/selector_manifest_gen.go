@@ -0,0 +1,271 @@
+// Code generated by cmd/selectormanifest; DO NOT EDIT.
+
+package handlers
+
+// pageSelectors lists the literal element ids each streaming page can render
+var pageSelectors = map[PageType]map[string]bool{
+	PageLobby: {
+		"app-operator-chip":              true,
+		"app-room-code-chip":             true,
+		"backup-handler":                 true,
+		"connection-banner":              true,
+		"connection-retry":               true,
+		"countdown-style":                true,
+		"countdown-style-form":           true,
+		"debug-clear":                    true,
+		"debug-control-surface":          true,
+		"debug-dump":                     true,
+		"debug-info":                     true,
+		"debug-insights-container":       true,
+		"debug-operator-view":            true,
+		"debug-panel":                    true,
+		"debug-panel-minimized":          true,
+		"debug-panel-toggle":             true,
+		"debug-persistence-controls":     true,
+		"debug-player-context":           true,
+		"debug-restore":                  true,
+		"debug-role-knowledge":           true,
+		"debug-start-as-is":              true,
+		"debug-start-override-controls":  true,
+		"debug-start-with-debug-players": true,
+		"debug-view-as-player-container": true,
+		"debug-view-as-player-result":    true,
+		"debug-view-as-player-select":    true,
+		"large-roster":                   true,
+		"large-roster-item":              true,
+		"lobby-container":                true,
+		"lobby-content":                  true,
+		"lobby-poll":                     true,
+		"lobby-qr-code":                  true,
+		"lobby-settings-summary":         true,
+		"lobby-status-line":              true,
+		"maintenance-banner":             true,
+		"modal-container":                true,
+		"onboarding-hint":                true,
+		"page-body":                      true,
+		"player-list-card":               true,
+		"player-lobby":                   true,
+		"player-lobby-hero":              true,
+		"role-distribution":              true,
+		"role-distribution-summary":      true,
+		"room-chat":                      true,
+		"room-chat-form":                 true,
+		"room-chat-input":                true,
+		"room-chat-log":                  true,
+		"rules-reference":                true,
+	},
+	PageGame: {
+		"app-operator-chip":              true,
+		"app-room-code-chip":             true,
+		"backup-handler":                 true,
+		"connection-banner":              true,
+		"connection-retry":               true,
+		"countdown-announcement":         true,
+		"countdown-display":              true,
+		"countdown-style":                true,
+		"countdown-style-form":           true,
+		"coup-inquisition-form":          true,
+		"debug-clear":                    true,
+		"debug-control-surface":          true,
+		"debug-dump":                     true,
+		"debug-info":                     true,
+		"debug-insights-container":       true,
+		"debug-operator-view":            true,
+		"debug-panel":                    true,
+		"debug-panel-minimized":          true,
+		"debug-panel-toggle":             true,
+		"debug-persistence-controls":     true,
+		"debug-player-context":           true,
+		"debug-restore":                  true,
+		"debug-role-knowledge":           true,
+		"debug-start-as-is":              true,
+		"debug-start-override-controls":  true,
+		"debug-start-with-debug-players": true,
+		"debug-view-as-player-container": true,
+		"debug-view-as-player-result":    true,
+		"debug-view-as-player-select":    true,
+		"game-container":                 true,
+		"game-started-by":                true,
+		"known-info":                     true,
+		"leader-confirmation-prompts":    true,
+		"maintenance-banner":             true,
+		"metamorph-steal-modal":          true,
+		"modal-container":                true,
+		"onboarding-hint":                true,
+		"operator-cancel-start":          true,
+		"operator-dashboard-link":        true,
+		"operator-play-again":            true,
+		"page-body":                      true,
+		"pending-abilities-container":    true,
+		"phase-chip":                     true,
+		"player-notes":                   true,
+		"player-notes-input":             true,
+		"redeal-setup-link":              true,
+		"redeal-start":                   true,
+		"redeal-waiting":                 true,
+		"room-chat":                      true,
+		"room-chat-form":                 true,
+		"room-chat-input":                true,
+		"room-chat-log":                  true,
+		"show-original-card":             true,
+		"show-original-metamorph":        true,
+		"start-confirm":                  true,
+		"start-confirm-button":           true,
+		"stolen-identity-display":        true,
+		"sync-pill":                      true,
+		"transformation-display":         true,
+		"vote-panel":                     true,
+		"zone-actions":                   true,
+		"zone-notices":                   true,
+		"zone-privy":                     true,
+		"zone-roster":                    true,
+		"zone-status":                    true,
+	},
+	PageHost: {
+		"allow-leaderless":               true,
+		"app-operator-chip":              true,
+		"app-room-code-chip":             true,
+		"backup-handler":                 true,
+		"card-locale":                    true,
+		"card-locale-form":               true,
+		"card-search":                    true,
+		"config-slow-down":               true,
+		"coup-green-hunt-requirement":    true,
+		"coup-green-hunt-settings-form":  true,
+		"coup-info-form":                 true,
+		"coup-inquisition-amnesty":       true,
+		"coup-inquisition-result":        true,
+		"coup-inquisition-settings-form": true,
+		"coup-preset":                    true,
+		"coup-preset-form":               true,
+		"coup-role-counts-form":          true,
+		"coup-role-counts-list":          true,
+		"coup-royal-guard-blockers":      true,
+		"coup-royal-guard-form":          true,
+		"coup-rules-variants":            true,
+		"coup-unsafe-role-counts-form":   true,
+		"debug-clear":                    true,
+		"debug-control-surface":          true,
+		"debug-dump":                     true,
+		"debug-info":                     true,
+		"debug-insights-container":       true,
+		"debug-operator-view":            true,
+		"debug-panel":                    true,
+		"debug-panel-minimized":          true,
+		"debug-panel-toggle":             true,
+		"debug-persistence-controls":     true,
+		"debug-player-context":           true,
+		"debug-restore":                  true,
+		"debug-role-knowledge":           true,
+		"debug-start-as-is":              true,
+		"debug-start-override-controls":  true,
+		"debug-start-with-debug-players": true,
+		"debug-view-as-player-container": true,
+		"debug-view-as-player-result":    true,
+		"debug-view-as-player-select":    true,
+		"fully-random-roles":             true,
+		"game-log":                       true,
+		"game-started-by":                true,
+		"hide-role-distribution":         true,
+		"host-config-notice":             true,
+		"host-dashboard-container":       true,
+		"host-dashboard-content":         true,
+		"host-dashboard-coup-setup":      true,
+		"host-recovery-code":             true,
+		"host-report-problem":            true,
+		"maintenance-banner":             true,
+		"modal-container":                true,
+		"operator-advance-phase":         true,
+		"operator-apply-poll":            true,
+		"operator-approve-deal":          true,
+		"operator-cancel-start":          true,
+		"operator-close-vote":            true,
+		"operator-create-watch-link":     true,
+		"operator-dashboard":             true,
+		"operator-deal-assignments":      true,
+		"operator-deal-preview":          true,
+		"operator-invite-emails":         true,
+		"operator-invite-notice":         true,
+		"operator-invites":               true,
+		"operator-last-vote":             true,
+		"operator-live-board":            true,
+		"operator-live-dashboard":        true,
+		"operator-open-poll":             true,
+		"operator-open-vote":             true,
+		"operator-overlay-link":          true,
+		"operator-phase":                 true,
+		"operator-phase-settings":        true,
+		"operator-play-again":            true,
+		"operator-poll":                  true,
+		"operator-poll-tally":            true,
+		"operator-public-coup-facts":     true,
+		"operator-redeal":                true,
+		"operator-screenshot-deterrence": true,
+		"operator-spectators":            true,
+		"operator-start-checklist":       true,
+		"operator-start-controls":        true,
+		"operator-start-game":            true,
+		"operator-start-ritual":          true,
+		"operator-vote":                  true,
+		"operator-vote-tally":            true,
+		"operator-watch-links":           true,
+		"phase-chip":                     true,
+		"player-list":                    true,
+		"preset-form":                    true,
+		"private-room-note":              true,
+		"qr-code-container":              true,
+		"qr-code-img":                    true,
+		"rebalance-strategy":             true,
+		"rebalance-strategy-form":        true,
+		"role-config":                    true,
+		"role-config-changes":            true,
+		"role-count-advanced":            true,
+		"role-count-mode-label":          true,
+		"role-preset":                    true,
+		"role-validation":                true,
+		"room-chat":                      true,
+		"room-chat-form":                 true,
+		"room-chat-input":                true,
+		"room-chat-log":                  true,
+		"session-metrics":                true,
+		"treachery-card-packs":           true,
+		"treachery-role-counts":          true,
+		"treachery-rules-variants":       true,
+	},
+	PageOverlay: {
+		"overlay":           true,
+		"overlay-content":   true,
+		"overlay-countdown": true,
+		"overlay-leader":    true,
+		"overlay-players":   true,
+	},
+	PageWatch: {
+		"app-operator-chip":              true,
+		"app-room-code-chip":             true,
+		"backup-handler":                 true,
+		"debug-clear":                    true,
+		"debug-control-surface":          true,
+		"debug-dump":                     true,
+		"debug-info":                     true,
+		"debug-insights-container":       true,
+		"debug-operator-view":            true,
+		"debug-panel":                    true,
+		"debug-panel-minimized":          true,
+		"debug-panel-toggle":             true,
+		"debug-persistence-controls":     true,
+		"debug-player-context":           true,
+		"debug-restore":                  true,
+		"debug-role-knowledge":           true,
+		"debug-start-as-is":              true,
+		"debug-start-override-controls":  true,
+		"debug-start-with-debug-players": true,
+		"debug-view-as-player-container": true,
+		"debug-view-as-player-result":    true,
+		"debug-view-as-player-select":    true,
+		"maintenance-banner":             true,
+		"modal-container":                true,
+		"watch":                          true,
+		"watch-content":                  true,
+		"watch-players":                  true,
+	},
+}
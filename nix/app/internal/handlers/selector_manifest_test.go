@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+)
+
+func TestPageHasSelector(t *testing.T) {
+	tests := []struct {
+		page     PageType
+		selector string
+		want     bool
+	}{
+		{PageGame, "#game-container", true},
+		{PageGame, "#sync-pill", true},
+		{PageLobby, "#lobby-poll", true},
+		{PageHost, "#operator-watch-links", true},
+		{PageLobby, "#game-container", false},
+		{PageOverlay, "#role-config", false},
+		{PageLobby, ".not-an-id", true},
+		{PageType("unknown"), "#anything", true},
+	}
+	for _, tt := range tests {
+		if got := pageHasSelector(tt.page, tt.selector); got != tt.want {
+			t.Errorf("pageHasSelector(%s, %s) = %v, want %v", tt.page, tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestPatchElementsStrictSelectors(t *testing.T) {
+	h := newTestHandler()
+
+	send := func() string {
+		w := httptest.NewRecorder()
+		sse := datastar.NewSSE(w, httptest.NewRequest("GET", "/sse/lobby/ABCDE", nil))
+		h.patchElements(sse, PageLobby, `<div id="game-container">x</div>`, "#game-container")
+		return w.Body.String()
+	}
+
+	if body := send(); !strings.Contains(body, "game-container") {
+		t.Fatalf("expected mismatched patch to still be sent outside strict mode, got %q", body)
+	}
+
+	h.config().Server.StrictSelectors = true
+	if body := send(); strings.Contains(body, "game-container") {
+		t.Fatalf("expected mismatched patch to be dropped in strict mode, got %q", body)
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const sessionCookieName = "session"
+
+// sessionID returns the caller's session ID. With session keys configured the
+// cookie must carry a valid signature; unsigned or forged cookies count as no session.
+func (h *Handler) sessionID(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	if h.sessionKeys == nil {
+		return cookie.Value, true
+	}
+	id, _, ok := h.sessionKeys.Verify(cookie.Value)
+	return id, ok
+}
+
+// getOrCreateSession gets or creates a session for the user. A cookie signed
+// with a retired key is re-issued under the current key.
+func (h *Handler) getOrCreateSession(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && cookie.Value != "" {
+		if h.sessionKeys == nil {
+			return cookie.Value
+		}
+		if id, current, ok := h.sessionKeys.Verify(cookie.Value); ok {
+			if !current {
+				h.setSessionCookie(w, id)
+			}
+			return id
+		}
+	}
+
+	// Create new session
+	b := make([]byte, 16)
+	rand.Read(b)
+	sessionID := hex.EncodeToString(b)
+	h.setSessionCookie(w, sessionID)
+
+	return sessionID
+}
+
+func (h *Handler) setSessionCookie(w http.ResponseWriter, sessionID string) {
+	value := sessionID
+	if h.sessionKeys != nil {
+		value = h.sessionKeys.Sign(sessionID)
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   86400 * 7, // 7 days
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+)
+
+// SessionMetrics sends the Room Operator the last game's session stats, for
+// the Game Over dashboard: lobby and reveal times and setup changes from the
+// room's journal, and the reconnects its browsers reported
+func (h *Handler) SessionMetrics(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if room.State != game.StateEnded {
+		http.Error(w, "Session stats are ready once the game is over", http.StatusConflict)
+		return
+	}
+	events, err := h.store.Events(room.Code, 0)
+	if err != nil {
+		log.Printf("❌ Failed to read room %s's journal for session stats: %v", room.Code, err)
+	}
+
+	metrics := game.LastGameMetrics(room, events)
+	metrics.Reconnects = h.telemetry.reconnects(room.Code)
+
+	html := renderToString(pages.HostSessionMetrics(metrics))
+	h.patchElements(datastar.NewSSE(w, r), PageHost, html, "#session-metrics")
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+)
+
+func TestSessionMetricsForTheRoomOperatorAfterTheGame(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	h.record(room, game.ConfigChangedEvent(room.RoleConfig))
+	room.State = game.StatePlaying
+	room.StartedAt = time.Now()
+	h.record(room, game.RoleRevealedEvent(player.ID, true, true))
+	h.telemetry.record(room.Code, 2, 0, 0, "firefox", false, time.Now())
+
+	get := func(session string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/room/"+room.Code+"/metrics", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: session})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("operator-session"); w.Code != http.StatusConflict {
+		t.Fatalf("expected no stats before the game is over, got %d", w.Code)
+	}
+	room.State = game.StateEnded
+	if w := get("s1"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected players to be refused the stats, got %d", w.Code)
+	}
+
+	w := get("operator-session")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{"session-metrics", "Time to first reveal", `id="session-metrics-config">1<`, `id="session-metrics-reconnects">2<`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in the stats, got %s", want, body)
+		}
+	}
+}
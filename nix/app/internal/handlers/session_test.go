@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"treacherest/internal/secrets"
+)
+
+func testKeyring(t *testing.T, keys ...string) *secrets.Keyring {
+	t.Helper()
+	k, err := secrets.ParseKeyring(strings.Join(keys, ","))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+var (
+	sessionKeyA = strings.Repeat("a", secrets.MinKeyLength)
+	sessionKeyB = strings.Repeat("b", secrets.MinKeyLength)
+)
+
+func TestSignedSessionCookies(t *testing.T) {
+	h := newTestHandler()
+	h.SetSessionKeys(testKeyring(t, sessionKeyA))
+
+	w := httptest.NewRecorder()
+	id := h.getOrCreateSession(w, httptest.NewRequest("GET", "/", nil))
+	cookie := w.Result().Cookies()[0]
+	if cookie.Value == id || !strings.HasPrefix(cookie.Value, id+".") {
+		t.Fatalf("expected signed cookie for %s, got %s", id, cookie.Value)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(cookie)
+	if got, ok := h.sessionID(req); !ok || got != id {
+		t.Errorf("expected signed cookie to resolve to %s, got %q %v", id, got, ok)
+	}
+
+	// An unsigned cookie guessing the ID is not a session
+	forged := httptest.NewRequest("GET", "/", nil)
+	forged.AddCookie(&http.Cookie{Name: "session", Value: id})
+	if _, ok := h.sessionID(forged); ok {
+		t.Error("expected unsigned cookie to be rejected when keys are configured")
+	}
+
+	w = httptest.NewRecorder()
+	if fresh := h.getOrCreateSession(w, forged); fresh == id {
+		t.Error("expected a new session instead of trusting the unsigned cookie")
+	}
+}
+
+func TestSessionKeyRotationResignsCookie(t *testing.T) {
+	h := newTestHandler()
+	h.SetSessionKeys(testKeyring(t, sessionKeyA))
+
+	w := httptest.NewRecorder()
+	id := h.getOrCreateSession(w, httptest.NewRequest("GET", "/", nil))
+	oldCookie := w.Result().Cookies()[0]
+
+	// Rotate to B while still accepting A
+	h.SetSessionKeys(testKeyring(t, sessionKeyB, sessionKeyA))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(oldCookie)
+	w = httptest.NewRecorder()
+	if got := h.getOrCreateSession(w, req); got != id {
+		t.Fatalf("expected the session to survive rotation, got %s want %s", got, id)
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value == oldCookie.Value {
+		t.Fatalf("expected the cookie to be re-signed under the new key, got %v", cookies)
+	}
+
+	// Once A is retired only the re-signed cookie still works
+	h.SetSessionKeys(testKeyring(t, sessionKeyB))
+	retired := httptest.NewRequest("GET", "/", nil)
+	retired.AddCookie(oldCookie)
+	if _, ok := h.sessionID(retired); ok {
+		t.Error("expected cookie signed by a retired key to be rejected")
+	}
+	resigned := httptest.NewRequest("GET", "/", nil)
+	resigned.AddCookie(cookies[0])
+	if got, ok := h.sessionID(resigned); !ok || got != id {
+		t.Errorf("expected re-signed cookie to resolve, got %q %v", got, ok)
+	}
+}
+
+func TestSignedSessionAuthorizesRoomOperator(t *testing.T) {
+	h := newTestHandler()
+	h.SetSessionKeys(testKeyring(t, sessionKeyA))
+	room, _ := h.store.CreateRoom()
+	room.OperatorSessionID = "operator-session"
+
+	signed := httptest.NewRequest("GET", "/", nil)
+	signed.AddCookie(&http.Cookie{Name: "session", Value: h.sessionKeys.Sign("operator-session")})
+	if !h.isRoomOperator(signed, room) {
+		t.Error("expected signed operator session to be authorized")
+	}
+
+	raw := httptest.NewRequest("GET", "/", nil)
+	raw.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	if h.isRoomOperator(raw, room) {
+		t.Error("expected unsigned operator session to be refused")
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+// countdownSignalPayloads are the countdown signal patches a start ritual
+// sends, encoded once. Every stream in a room gets one a second during the
+// countdown, and marshalling a one-entry map per stream per tick costs several
+// allocations for a few bytes of JSON (see BenchmarkCountdownSignal).
+var countdownSignalPayloads = func() [][]byte {
+	payloads := make([][]byte, countdownSeconds+1)
+	for n := range payloads {
+		payloads[n] = encodeCountdownSignal(n)
+	}
+	return payloads
+}()
+
+// encodeCountdownSignal is {"countdown":n}, as json.Marshal would write it
+func encodeCountdownSignal(n int) []byte {
+	return append(strconv.AppendInt([]byte(`{"countdown":`), int64(n), 10), '}')
+}
+
+// countdownSignal returns the countdown signal patch for n seconds remaining
+func countdownSignal(n int) []byte {
+	if n >= 0 && n < len(countdownSignalPayloads) {
+		return countdownSignalPayloads[n]
+	}
+	return encodeCountdownSignal(n)
+}
+
+// patchCountdown sets the page's countdown signal to n seconds remaining; 0
+// clears it once the game is playing
+func patchCountdown(sse *datastar.ServerSentEventGenerator, n int) error {
+	if err := sse.PatchSignals(countdownSignal(n)); err != nil {
+		return fmt.Errorf("failed to patch countdown signal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+func TestCountdownSignalMatchesMarshalledMap(t *testing.T) {
+	for n := -1; n <= countdownSeconds+2; n++ {
+		want, _ := json.Marshal(map[string]interface{}{"countdown": n})
+		if got := countdownSignal(n); string(got) != string(want) {
+			t.Errorf("countdown %d: got %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestPatchCountdownSendsSignalEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	sse := datastar.NewSSE(w, httptest.NewRequest("GET", "/sse/room/ABCDE", nil))
+	if err := patchCountdown(sse, 3); err != nil {
+		t.Fatalf("patch countdown: %v", err)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "event: datastar-patch-signals\ndata: signals {\"countdown\":3}\n") {
+		t.Errorf("unexpected event %q", body)
+	}
+}
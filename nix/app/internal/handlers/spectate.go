@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+// SpectatePage renders a room's public view for someone watching without
+// joining: they take no seat, get no role and don't count toward MaxPlayers.
+// A private room asks for its password first.
+func (h *Handler) SpectatePage(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		pages.RoomNotFound(roomCode).Render(r.Context(), w)
+		return
+	}
+	if !canSpectate(r, room) {
+		pages.SpectatePassword(room.Code, "").Render(r.Context(), w)
+		return
+	}
+
+	pages.SpectatePage(room).Render(r.Context(), w)
+}
+
+// AdmitSpectator takes a private room's password from the spectate page and
+// lets the browser watch when it matches
+func (h *Handler) AdmitSpectator(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		pages.RoomNotFound(roomCode).Render(r.Context(), w)
+		return
+	}
+	if err := admitSpectator(w, r, room); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		pages.SpectatePassword(room.Code, err.Error()).Render(r.Context(), w)
+		return
+	}
+
+	http.Redirect(w, r, "/room/"+room.Code+"/spectate", http.StatusSeeOther)
+}
+
+// StreamSpectate streams a room's public state to a spectator until the
+// room is gone
+func (h *Handler) StreamSpectate(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if !canSpectate(r, room) {
+		http.Error(w, game.ErrJoinPasswordRequired.Error(), http.StatusForbidden)
+		return
+	}
+
+	h.streamSpectator(w, r, room, func(*game.Room) bool { return true }, pages.SpectateClosed())
+}
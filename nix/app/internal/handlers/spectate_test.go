@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/testkit"
+)
+
+func TestSpectatePage_ShowsPublicStateWithoutASeat(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room := newWatchTestRoom(t, h)
+	secret := mockGuardianCard()
+	secret.Name = "Secret Role"
+	room.GetPlayer("p1").Role = secret
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/room/"+room.Code+"/spectate", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "Alice") || !strings.Contains(body, "/sse/spectate/"+room.Code) {
+		t.Errorf("expected the roster and the spectate stream, got %s", body)
+	}
+	if strings.Contains(body, "Secret Role") {
+		t.Error("a hidden role reached a spectator")
+	}
+	if len(room.Players) != 1 {
+		t.Errorf("expected spectating to leave the seats alone, got %d players", len(room.Players))
+	}
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "player_"+room.Code {
+			t.Error("expected no player cookie for a spectator")
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/room/NOPE1/spectate", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing room, got %d", w.Code)
+	}
+}
+
+func TestSpectatePage_PrivateRoomNeedsItsPassword(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room := newWatchTestRoom(t, h)
+	if err := room.SetJoinPassword("swordfish"); err != nil {
+		t.Fatal(err)
+	}
+	path := "/room/" + room.Code + "/spectate"
+	stranger := testkit.NewClient(t, router)
+
+	w := stranger.Get(path)
+	if body := w.Body.String(); strings.Contains(body, "Alice") || strings.Contains(body, "/sse/spectate/") || !strings.Contains(body, "spectate-room-password") {
+		t.Fatalf("expected only the password form, got %s", body)
+	}
+	stream := stranger.OpenSSE("/sse/spectate/" + room.Code)
+	select {
+	case <-stream.Done():
+	case <-time.After(time.Second):
+		stream.Close()
+		t.Fatal("expected the stream refused without the password")
+	}
+	if stream.Status() != http.StatusForbidden {
+		t.Fatalf("expected 403 for the stream, got %d", stream.Status())
+	}
+	if w := stranger.Post(path, url.Values{"join_password": {"guess"}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a wrong password refused, got %d", w.Code)
+	}
+	if body := stranger.Get(path).Body.String(); strings.Contains(body, "Alice") {
+		t.Fatal("expected a wrong password not to let the browser watch")
+	}
+
+	if w := stranger.Post(path, url.Values{"join_password": {"swordfish"}}); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the password to be taken, got %d", w.Code)
+	}
+	if body := stranger.Get(path).Body.String(); !strings.Contains(body, "Alice") || !strings.Contains(body, "/sse/spectate/"+room.Code) {
+		t.Fatalf("expected the room shown once the password was given, got %s", body)
+	}
+
+	// A new password shuts out everyone watching on the old one
+	if err := room.SetJoinPassword("marlin"); err != nil {
+		t.Fatal(err)
+	}
+	if body := stranger.Get(path).Body.String(); strings.Contains(body, "Alice") {
+		t.Error("expected a changed password to void the old pass")
+	}
+}
+
+func TestJoinPage_OffersSpectating(t *testing.T) {
+	h := newTestHandler()
+	room := newWatchTestRoom(t, h)
+
+	w := httptest.NewRecorder()
+	newTestRouter(h).ServeHTTP(w, httptest.NewRequest("GET", "/room/"+room.Code, nil))
+	if !strings.Contains(w.Body.String(), "/room/"+room.Code+"/spectate") {
+		t.Errorf("expected the join page to link to spectating, got %s", w.Body.String())
+	}
+}
+
+func TestStreamSpectate_CountsViewersAndClosesWithTheRoom(t *testing.T) {
+	h := newTestHandler()
+	room := newWatchTestRoom(t, h)
+
+	req := httptest.NewRequest("GET", "/sse/spectate/"+room.Code, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("code", room.Code)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		h.StreamSpectate(w, req)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for h.connTracker.GetViewerCount(room.Code) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected spectator to be tracked as a viewer")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if count := h.connTracker.GetConnectionCount(room.Code); count != 0 {
+		t.Errorf("spectators should not count as player connections, got %d", count)
+	}
+
+	h.store.DeleteRoom(room.Code)
+	h.eventBus.Publish(Event{Type: EventPlayerLeft, RoomCode: room.Code})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected stream to close once the room is gone")
+	}
+	if !strings.Contains(w.Body.String(), "This room has closed") {
+		t.Errorf("expected the closed notice, got %s", w.Body.String())
+	}
+	if count := h.connTracker.GetViewerCount(room.Code); count != 0 {
+		t.Errorf("expected viewer to be released, got %d", count)
+	}
+}
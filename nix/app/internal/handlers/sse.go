@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"bytes"
-	"context"
 	"encoding/base64"
 	"fmt"
 	"github.com/a-h/templ"
@@ -10,11 +9,10 @@ import (
 	datastar "github.com/starfederation/datastar-go/datastar"
 	"github.com/yeqown/go-qrcode/v2"
 	"github.com/yeqown/go-qrcode/writer/standard"
-	"log"
 	"net/http"
-	"os"
 	"time"
 	"treacherest/internal/game"
+	"treacherest/internal/logging"
 	"treacherest/internal/views/components"
 	"treacherest/internal/views/pages"
 )
@@ -22,121 +20,156 @@ import (
 const (
 	gameSyncReconnectAfter = 20 * time.Second
 	gameSyncStaleAfter     = 45 * time.Second
+
+	// stateBackupInterval is how often game streams send a state backup
+	stateBackupInterval = time.Minute
+)
+
+// lobbyIgnoredEvents are the event types StreamLobby deliberately leaves
+// alone: setup details only the Room Operator sees, and in-game events that
+// arrive after lobby players have moved to the game page
+var lobbyIgnoredEvents = newEventSet(
+	EventRoleOptionsChanged, EventPhaseSettingsUpdated, EventConfigMigrated, EventDealPending, EventStartCancelled,
+	EventStartRitualUpdated, EventScreenshotDeterrenceUpdated, EventWatchLinkRevoked, EventStartConfirmed, EventGameEnded, EventGameRestarted,
+	EventRoleRevealed, EventFaceStateChanged, EventModalDismissed, EventModalRestored,
+	EventPlayerEliminated, EventPhaseChanged, EventVoteOpened, EventVoteCast, EventVoteClosed,
+	EventCoupInquisitionCalled, EventCoupInquisitionResolved, EventCoupWinPromptRejected,
+	EventAbilityTriggered, EventAbilityUpdated, EventAbilityConfirmed, EventAbilityResolved,
+	EventMetamorphActivated, EventMetamorphEnded, EventRoleStolen,
+	EventPuppetMasterRedistribution, EventTransformationComplete,
 )
 
-// StreamLobby streams lobby updates
+// hostIgnoredEvents are the event types StreamHost deliberately leaves alone:
+// per-player card and ability state the host dashboard does not show
+var hostIgnoredEvents = newEventSet(
+	EventRoleOptionsChanged, EventWatchLinkRevoked,
+	EventFaceStateChanged, EventModalDismissed, EventModalRestored,
+	EventCoupInquisitionCalled, EventCoupInquisitionResolved,
+	EventAbilityTriggered, EventAbilityUpdated, EventAbilityConfirmed, EventAbilityResolved,
+	EventMetamorphActivated, EventMetamorphEnded, EventRoleStolen,
+	EventPuppetMasterRedistribution, EventTransformationComplete,
+)
+
+// StreamLobby streams a lobby page's updates. Pages loaded before the room
+// stream existed use it, so it carries them into the game the same way.
 func (h *Handler) StreamLobby(w http.ResponseWriter, r *http.Request) {
+	h.streamPlayer(w, r, "lobby")
+}
+
+// streamLobby streams lobby updates from events until the game starts, and
+// reports whether it did so the caller can carry on with game updates on
+// the same connection. resync first sends the whole lobby, for a reconnect
+// whose missed events can't be replayed.
+func (h *Handler) streamLobby(w http.ResponseWriter, r *http.Request, events chan Event, resync bool) (gameStarted bool) {
 	roomCode := chi.URLParam(r, "code")
-	log.Printf("📡 SSE connection established for lobby %s", roomCode)
+	logger := h.requestLogger(r).With(logging.KeyView, "lobby")
+	logger.Info("SSE connection established")
 
-	// Debug mode: log request details
-	if os.Getenv("DEBUG") != "" {
-		log.Printf("DEBUG: 📡 SSE request details - User-Agent: %s, RemoteAddr: %s", r.Header.Get("User-Agent"), r.RemoteAddr)
-		deadline, hasDeadline := r.Context().Deadline()
-		log.Printf("DEBUG: 📡 SSE request timeout context: deadline=%v, hasDeadline=%v", deadline, hasDeadline)
-	}
+	deadline, hasDeadline := r.Context().Deadline()
+	logger.Debug("SSE request details", "user_agent", r.Header.Get("User-Agent"), "remote_addr", r.RemoteAddr, "deadline", deadline, "has_deadline", hasDeadline)
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
-		log.Printf("📡 SSE requested for non-existent room: %s", roomCode)
+		logger.Info("SSE requested for non-existent room")
 		http.Error(w, "Room not found", http.StatusNotFound)
-		return
+		return false
 	}
 
 	// Get player from cookie
 	playerCookie, err := r.Cookie("player_" + roomCode)
 	if err != nil {
 		http.Error(w, "Not in room", http.StatusUnauthorized)
-		return
+		return false
 	}
 
 	player := room.GetPlayer(playerCookie.Value)
 	if player == nil {
 		http.Error(w, "Player not found", http.StatusUnauthorized)
-		return
+		return false
 	}
+	logger = logger.With(logging.KeyPlayer, player.ID)
 
 	// Create SSE connection
 	sse := datastar.NewSSE(w, r)
 
-	// Subscribe to events
-	playerID := player.ID // Capture player ID for defer
-	events := h.eventBus.Subscribe(roomCode)
-	log.Printf("📡 DEBUG: Player %s subscribed to events for room %s", playerID, roomCode)
-	defer func() {
-		h.eventBus.Unsubscribe(roomCode, events)
-		log.Printf("📡 DEBUG: Player %s unsubscribed from room %s", playerID, roomCode)
-	}()
-
-	// Don't send initial render - page already has correct content
-	// But DO send initial validation state to ensure UI is in sync
-	roleService := game.NewRoleConfigService(h.config)
+	h.connTracker.AddConnection(roomCode)
+	defer h.connTracker.RemoveConnection(roomCode)
+
+	// Don't send initial render - page already has correct content, unless
+	// it reconnected from too far behind to replay what it missed
+	if resync {
+		room.RLock()
+		err := h.resyncRenderer().Patch(sse, PageLobby, room, h.effectivePlayerForRender(r, room, player), "")
+		room.RUnlock()
+		if err != nil {
+			logger.Error("failed to send lobby resync", logging.KeyError, err)
+		}
+	}
+
+	// Send initial validation state to ensure UI is in sync
+	roleService := game.NewRoleConfigService(h.roomConfig(room))
+	room.RLock()
 	validationState := room.GetValidationState(roleService)
+	signals := lobbyValidationSignals(room, components.NewViewerContext(room, h.effectivePlayerForRender(r, room, player)), validationState)
+	room.RUnlock()
 
-	err = sse.MarshalAndPatchSignals(map[string]interface{}{
-		"canStartGame":      validationState.CanStart,
-		"validationMessage": validationState.ValidationMessage,
-		"canAutoScale":      validationState.CanAutoScale,
-		"autoScaleDetails":  validationState.AutoScaleDetails,
-		"requiredRoles":     validationState.RequiredRoles,
-		"configuredRoles":   validationState.ConfiguredRoles,
-		// Ensure button is not in loading state on initial connect
-		"isStarting": false,
-		"startError": "",
-	})
+	// Ensure button is not in loading state on initial connect
+	signals["isStarting"] = false
+	signals["startError"] = ""
+	err = sse.MarshalAndPatchSignals(signals)
 
 	if err != nil {
-		log.Printf("❌ Failed to send initial validation state: %v", err)
+		logger.Error("failed to send initial validation state", logging.KeyError, err)
 	}
 
 	// Send debug mode signal if debug mode is enabled (for debug panel visibility)
-	if h.config.Server.DebugModeEnabled {
+	if h.config().Server.DebugModeEnabled {
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"debugmode": true,
 		})
 	}
 
-	log.Printf("📡 SSE connection ready for room %s with validation state v%d", roomCode, validationState.Version)
+	h.sendInitialMaintenanceBanner(sse, PageLobby)
+
+	room.RLock()
+	h.sendOnboardingHint(sse, PageLobby, r, room, player, components.HintJoinedLobby)
+	room.RUnlock()
+
+	// Whether this stream's page holds the large-room roster; the first
+	// roster update always sends the full card, so start from false
+	largeRosterRendered := false
 
-	// Set up a heartbeat to prevent timeouts
-	// 15 seconds is well under our 10-minute WriteTimeout
-	heartbeat := time.NewTicker(15 * time.Second)
+	logger.Info("SSE connection ready", "validation_version", validationState.Version)
+
+	// Set up a heartbeat to prevent timeouts; the interval adapts to the
+	// room (see keepaliveInterval) and stays well under our 10-minute WriteTimeout
+	heartbeat := h.newKeepaliveTicker(roomCode)
 	defer heartbeat.Stop()
 
 	// Stream updates
 	for {
 		select {
 		case <-r.Context().Done():
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 SSE context cancelled for room %s - error: %v", roomCode, r.Context().Err())
-			}
-			log.Printf("📡 Lobby SSE context cancelled for room %s", roomCode)
-			return
-		case <-heartbeat.C:
+			logger.Info("SSE context cancelled", logging.KeyError, r.Context().Err())
+			return false
+		case <-heartbeat.C():
 			// Check if room still exists
 			_, err := h.store.GetRoom(roomCode)
 			if err != nil {
-				log.Printf("📡 Heartbeat: Room %s no longer exists, closing SSE", roomCode)
-				return
-			}
-
-			// Debug mode: log detailed heartbeat info
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 SSE heartbeat for lobby %s - player %s (%s)", roomCode, player.Name, player.ID)
+				logger.Info("room no longer exists, closing SSE")
+				h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostRoomGone)
+				return false
 			}
 
 			// Send minimal keepalive comment to prevent timeout
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Sending keepalive for room %s", roomCode)
-			}
+			logger.Debug("sending keepalive")
 
 			// Send minimal SSE comment - just colon and newlines
 			if _, err := w.Write([]byte(":\n\n")); err != nil {
-				if os.Getenv("DEBUG") != "" {
-					log.Printf("DEBUG: 📡 Keepalive write error: %v", err)
-				}
-				log.Printf("📡 Keepalive failed for room %s: %v - closing connection", roomCode, err)
-				return
+				heartbeat.failed()
+				logger.Warn("keepalive failed, closing connection", logging.KeyError, err)
+				h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostKeepalive)
+				return false
 			}
 
 			// Flush to ensure the comment is sent immediately
@@ -144,106 +177,154 @@ func (h *Handler) StreamLobby(w http.ResponseWriter, r *http.Request) {
 				flusher.Flush()
 			}
 
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Keepalive sent successfully for room %s", roomCode)
-			}
+			logger.Debug("keepalive sent")
+			heartbeat.sent()
 		case event := <-events:
-			log.Printf("📡 SSE event received for %s: %s", roomCode, event.Type)
-
-			switch event.Type {
-			case "player_joined", "player_left", "player_updated":
-				log.Printf("📡 DEBUG: StreamLobby received %s event for room %s, player %s", event.Type, roomCode, player.ID)
-				// Re-render lobby only if still in lobby state
-				room, _ = h.store.GetRoom(roomCode)
-				if room.State == game.StateLobby {
-					log.Printf("📡 DEBUG: Room is in lobby state, current players: %d", len(room.Players))
-					for pid, p := range room.Players {
-						log.Printf("📡 DEBUG:   - Player %s: %s", pid, p.Name)
+			logger.Debug("SSE event received", logging.KeyEvent, event.Type)
+
+			if viewRoom(room, func() bool {
+				switch event.Type {
+				case EventPlayerRemoved:
+					// Removals only happen in the lobby: the removed player
+					// goes to the join page, everyone else sees the new roster
+					room, _ = h.store.GetRoom(roomCode)
+					if room.GetPlayer(player.ID) == nil {
+						logger.Info("player removed by the Room Operator, sending them to the join page")
+						sendRemoved(sse, roomCode)
+						return true
+					}
+					renderPlayer := h.effectivePlayerForRender(r, room, player)
+					if renderPlayer == nil {
+						logger.Info("effective player no longer in room after a removal, closing SSE")
+						h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.sendLobbyUpdate(sse, room, renderPlayer)
+					largeRosterRendered = pages.LobbyLargeRoom(h.roomConfig(room), room)
+				case EventPlayerJoined, EventPlayerLeft:
+					// Re-render lobby only if still in lobby state
+					room, _ = h.store.GetRoom(roomCode)
+					if room.State == game.StateLobby {
+						logger.Debug("player list changed", "players", len(room.Players))
+
+						// Refresh player reference in case it was updated
+						player = room.GetPlayer(player.ID)
+						if player == nil {
+							// Player was removed, close SSE connection gracefully
+							logger.Info("player no longer in room, closing SSE")
+							h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+							return true
+						}
+						// For player events, only send player list update (not the entire lobby)
+						renderPlayer := h.effectivePlayerForRender(r, room, player)
+						if renderPlayer == nil {
+							logger.Info("effective player no longer in room, closing SSE")
+							h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+							return true
+						}
+						// Large rooms only need fresh roster signals once this
+						// stream has rendered the large-room roster
+						largeRoom := pages.LobbyLargeRoom(h.roomConfig(room), room)
+						if largeRoom && largeRosterRendered {
+							sse.MarshalAndPatchSignals(pages.LobbyRosterSignals(room))
+						} else {
+							h.sendPlayerListUpdate(sse, room, renderPlayer)
+						}
+						largeRosterRendered = largeRoom
+					} else {
+						logger.Info("lobby event received after the game started, moving on to the game", logging.KeyEvent, event.Type)
+						gameStarted = true
+						return true
+					}
+				case EventGameStarted, EventCountdownUpdate, EventGamePlaying:
+					// Hand over to game updates; the game body is swapped in
+					// place, so nothing published meanwhile is lost to a redirect
+					logger.Info("game started, moving lobby stream on to the game")
+					gameStarted = true
+					return true
+				case EventRoleConfigUpdated:
+					// Role config was updated - controllers get the full config UI,
+					// everyone gets the role distribution summary
+					logger.Debug("role config updated")
+					room, _ = h.store.GetRoom(roomCode)
+
+					viewer := components.NewViewerContext(room, h.effectivePlayerForRender(r, room, player))
+					if viewer.CanControl {
+						// Send the role config component only to controlling players
+						playerCountDisplay := h.createPlayerCountDisplay(room)
+						component := components.RoleConfigurationNew(viewer, room, h.roomConfig(room), h.cardService, playerCountDisplay)
+						html := renderFragment(component, "#role-config", roomCode)
+						h.patchElements(sse, PageLobby, html, "#role-config")
+
+						// Also update validation state for controlling players
+						roleService := game.NewRoleConfigService(h.roomConfig(room))
+						validationState := room.GetValidationState(roleService)
+
+						sse.MarshalAndPatchSignals(map[string]interface{}{
+							"canStartGame":      validationState.CanStart,
+							"validationMessage": validationState.ValidationMessage,
+							"canAutoScale":      validationState.CanAutoScale,
+							"autoScaleDetails":  validationState.AutoScaleDetails,
+							"requiredRoles":     validationState.RequiredRoles,
+							"configuredRoles":   validationState.ConfiguredRoles,
+						})
 					}
 
-					// Refresh player reference in case it was updated
-					originalPlayerID := player.ID
+					// Everyone sees the role mix unless the Room Operator hides it
+					h.patchElements(sse, PageLobby, renderFragment(pages.RoleDistributionCard(room), "#role-distribution", roomCode), "#role-distribution")
+				case EventCoupConfigUpdated:
+					logger.Debug("Coup config updated")
+					room, _ = h.store.GetRoom(roomCode)
 					player = room.GetPlayer(player.ID)
 					if player == nil {
-						// Player was removed, close SSE connection gracefully
-						log.Printf("📡 Player %s no longer in room %s, closing SSE", originalPlayerID, roomCode)
-						return
+						logger.Info("player no longer in room after Coup config update, closing SSE")
+						h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
 					}
-					// For player events, only send player list update (not the entire lobby)
 					renderPlayer := h.effectivePlayerForRender(r, room, player)
 					if renderPlayer == nil {
-						log.Printf("📡 Effective player no longer in room %s, closing SSE", roomCode)
-						return
+						logger.Info("effective player no longer in room after Coup config update, closing SSE")
+						h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.sendLobbyUpdate(sse, room, renderPlayer)
+				case EventOperatorChanged:
+					// Control moved: the old operator loses the setup controls
+					// and the new one gains them
+					room, _ = h.store.GetRoom(roomCode)
+					renderPlayer := h.effectivePlayerForRender(r, room, room.GetPlayer(player.ID))
+					if renderPlayer == nil {
+						logger.Info("player no longer in room after the operator changed, closing SSE")
+						h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.sendLobbyUpdate(sse, room, renderPlayer)
+				case EventPollUpdated:
+					room, _ = h.store.GetRoom(roomCode)
+					renderPlayer := h.effectivePlayerForRender(r, room, player)
+					if renderPlayer == nil {
+						logger.Info("effective player no longer in room after poll update, closing SSE")
+						h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.patchElements(sse, PageLobby, renderFragment(pages.LobbyPoll(room, renderPlayer), "#lobby-poll", roomCode), "#lobby-poll")
+				case EventChatPosted:
+					room, _ = h.store.GetRoom(roomCode)
+					h.patchRoomChat(sse, PageLobby, room)
+				case EventMaintenanceUpdated:
+					h.patchMaintenanceBanner(sse, PageLobby)
+				default:
+					if !lobbyIgnoredEvents[event.Type] {
+						logger.Warn("unknown event type", logging.KeyEvent, event.Type)
+						h.recordUnknownEvent("lobby", event.Type)
 					}
-					log.Printf("📤 DEBUG: Sending player list update to player %s in room %s", renderPlayer.ID, roomCode)
-					h.sendPlayerListUpdate(sse, room, renderPlayer)
-					log.Printf("📤 DEBUG: Player list update sent successfully to player %s", renderPlayer.ID)
-				} else {
-					log.Printf("🎮 Lobby event received but room %s not in lobby state, closing SSE", roomCode)
-					return
-				}
-			case "game_started":
-				// Redirect to game page when game starts
-				log.Printf("🎮 Game started - redirecting to game page for room %s", roomCode)
-				sse.ExecuteScript("window.location.href = '/game/" + roomCode + "'")
-				// Flush immediately to ensure redirect is sent
-				if flusher, ok := w.(http.Flusher); ok {
-					flusher.Flush()
-				}
-				return // Close the lobby SSE connection
-			case "countdown_update", "game_playing":
-				// These events happen after game has started
-				// Players should already be on the game page, so just close this lobby connection
-				log.Printf("🎮 Game event '%s' received in lobby SSE - closing connection for room %s", event.Type, roomCode)
-				return
-			case "role_config_updated":
-				// Role config was updated - send updates appropriately based on player type
-				log.Printf("🎯 Role config updated for room %s", roomCode)
-				room, _ = h.store.GetRoom(roomCode)
-
-				// Check if current player can control the game
-				canControl := !hasHost(room) && player.ID == getFirstPlayerID(room)
-
-				if canControl {
-					// Send the role config component only to controlling players
-					playerCountDisplay := h.createPlayerCountDisplay(room)
-					component := components.RoleConfigurationNew(room, h.config, h.cardService, playerCountDisplay)
-					html := renderToString(component)
-					sse.PatchElements(html,
-						datastar.WithSelector("#role-config"))
-
-					// Also update validation state for controlling players
-					roleService := game.NewRoleConfigService(h.config)
-					validationState := room.GetValidationState(roleService)
-
-					sse.MarshalAndPatchSignals(map[string]interface{}{
-						"canStartGame":      validationState.CanStart,
-						"validationMessage": validationState.ValidationMessage,
-						"canAutoScale":      validationState.CanAutoScale,
-						"autoScaleDetails":  validationState.AutoScaleDetails,
-						"requiredRoles":     validationState.RequiredRoles,
-						"configuredRoles":   validationState.ConfiguredRoles,
-					})
-				} else {
-					// Non-controlling players don't need role config updates
-					log.Printf("📡 Skipping role config update for non-controlling player %s in room %s", player.ID, roomCode)
-				}
-			case "coup_config_updated":
-				log.Printf("🎯 Coup config updated for room %s", roomCode)
-				room, _ = h.store.GetRoom(roomCode)
-				player = room.GetPlayer(player.ID)
-				if player == nil {
-					log.Printf("📡 Player no longer in room %s after Coup config update, closing SSE", roomCode)
-					return
-				}
-				renderPlayer := h.effectivePlayerForRender(r, room, player)
-				if renderPlayer == nil {
-					log.Printf("📡 Effective player no longer in room %s after Coup config update, closing SSE", roomCode)
-					return
 				}
-				h.sendLobbyUpdate(sse, room, renderPlayer)
-			default:
-				log.Printf("📡 Unknown event type %s for room %s in lobby SSE", event.Type, roomCode)
+				return false
+			}) {
+				return gameStarted
+			}
+			if err := markEvent(sse, event); err != nil {
+				logger.Debug("failed to mark event", logging.KeyError, err)
 			}
 		}
 	}
@@ -252,6 +333,20 @@ func (h *Handler) StreamLobby(w http.ResponseWriter, r *http.Request) {
 // StreamGame streams game updates
 func (h *Handler) StreamGame(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
+	events := h.eventBus.Subscribe(roomCode)
+	defer h.eventBus.Unsubscribe(roomCode, events)
+	// The initial render covers a gap replay can't bridge
+	feed, _ := h.replayFeed(r.Context(), r, roomCode, events)
+	h.streamGame(w, r, feed, false)
+}
+
+// streamGame streams game updates from events. swapBody first replaces the
+// whole page body, for a lobby page whose game has started. It reports
+// whether it ended because the game was restarted, having swapped the lobby
+// body back in, so the caller can carry on with lobby updates.
+func (h *Handler) streamGame(w http.ResponseWriter, r *http.Request, events chan Event, swapBody bool) (restarted bool) {
+	roomCode := chi.URLParam(r, "code")
+	logger := h.requestLogger(r).With(logging.KeyView, "game")
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
@@ -271,105 +366,131 @@ func (h *Handler) StreamGame(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Player not found", http.StatusUnauthorized)
 		return
 	}
+	logger = logger.With(logging.KeyPlayer, player.ID)
 
 	// Create SSE connection
 	sse := datastar.NewSSE(w, r)
 
-	// Subscribe to events
-	events := h.eventBus.Subscribe(roomCode)
-	defer h.eventBus.Unsubscribe(roomCode, events)
+	h.connTracker.AddConnection(roomCode)
+	defer h.connTracker.RemoveConnection(roomCode)
 
 	// Send initial render
-	log.Printf("🎮 Initial render for room %s, state: %s, countdown: %d", roomCode, room.State, room.CountdownRemaining)
-	renderPlayer := h.effectivePlayerForRender(r, room, player)
-	if renderPlayer == nil {
-		http.Error(w, "Player not found", http.StatusUnauthorized)
-		return
-	}
-	h.renderGame(sse, room, renderPlayer)
+	if viewRoom(room, func() bool {
+		logger.Debug("initial render", "state", room.State, "countdown", room.CountdownRemaining)
+		renderPlayer := h.effectivePlayerForRender(r, room, player)
+		if renderPlayer == nil {
+			if swapBody {
+				h.sendConnectionLost(sse, PageLobby, roomCode, components.ConnectionLostPlayerRemoved)
+				return true
+			}
+			http.Error(w, "Player not found", http.StatusUnauthorized)
+			return true
+		}
+		if swapBody {
+			h.swapInGameBody(sse, room, renderPlayer)
+		} else {
+			h.renderGame(sse, room, renderPlayer)
+		}
 
-	// Send initial signals including countdown
-	signals := map[string]interface{}{
-		"countdown": room.CountdownRemaining,
-	}
-	err = sse.MarshalAndPatchSignals(signals)
-	if err != nil {
-		log.Printf("❌ Failed to send initial game signals: %v", err)
-	}
+		// Send initial signals including countdown
+		err = patchCountdown(sse, room.CountdownRemaining)
+		if err != nil {
+			logger.Error("failed to send initial game signals", logging.KeyError, err)
+		}
 
-	// Send initial state backup
-	h.emitStateBackup(sse, room)
+		// Send initial state backup
+		h.emitStateBackup(sse, room)
+		h.sendGameHint(sse, r, room, renderPlayer)
+		return false
+	}) {
+		return
+	}
+	h.sendInitialMaintenanceBanner(sse, PageGame)
 
 	// Send debug mode signal if debug mode is enabled (for debug panel visibility)
-	if h.config.Server.DebugModeEnabled {
+	if h.config().Server.DebugModeEnabled {
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"debugmode": true,
 		})
 	}
 
 	// If joining during countdown, calculate actual remaining time
-	if room.State == game.StateCountdown {
+	room.Lock()
+	// A confirm start has no fixed length; it ends on the last confirmation
+	// or its timeout
+	joinedDuringCountdown := room.State == game.StateCountdown && room.StartRitual.Ritual != game.StartRitualConfirm
+	if joinedDuringCountdown {
 		// Calculate how much time has passed since countdown started
-		elapsed := time.Since(room.StartedAt)
-		originalCountdown := 5 // seconds
-		actualRemaining := originalCountdown - int(elapsed.Seconds())
+		elapsed := h.clock.Since(room.StartedAt)
+		actualRemaining := countdownSeconds - int(elapsed.Seconds())
 
 		// Update the room with actual remaining time
 		if actualRemaining > 0 {
 			room.CountdownRemaining = actualRemaining
 			h.store.UpdateRoom(room) // Save the updated countdown to store
-			log.Printf("📡 Browser connected during countdown for room %s, actual remaining: %d seconds", roomCode, actualRemaining)
+			logger.Info("browser connected during countdown", "remaining", actualRemaining)
 		} else {
 			// Countdown should have finished, transition to playing
 			room.State = game.StatePlaying
 			room.CountdownRemaining = 0
 			room.LeaderRevealed = true
 			h.store.UpdateRoom(room) // Save the updated state to store
-			log.Printf("📡 Browser connected after countdown finished for room %s, showing game state", roomCode)
+			logger.Info("browser connected after countdown finished, showing game state")
 		}
+	}
+	room.Unlock()
 
+	if joinedDuringCountdown {
 		// Re-render with updated state
-		renderPlayer = h.effectivePlayerForRender(r, room, player)
-		if renderPlayer == nil {
+		if viewRoom(room, func() bool {
+			renderPlayer := h.effectivePlayerForRender(r, room, player)
+			if renderPlayer == nil {
+				return true
+			}
+			h.renderGame(sse, room, renderPlayer)
+			return false
+		}) {
 			return
 		}
-		h.renderGame(sse, room, renderPlayer)
 	}
 
-	// Set up a heartbeat to prevent timeouts
-	// 15 seconds is well under our 10-minute WriteTimeout
-	heartbeat := time.NewTicker(15 * time.Second)
+	// Set up a heartbeat to prevent timeouts; the interval adapts to the
+	// room (see keepaliveInterval) and stays well under our 10-minute WriteTimeout
+	heartbeat := h.newKeepaliveTicker(roomCode)
 	defer heartbeat.Stop()
 
-	// Track heartbeat count for periodic backup (every 4 heartbeats = 60 seconds)
-	heartbeatCount := 0
-	lastSyncPatchAt := time.Now()
+	// Send a state backup once a minute, whatever the keepalive interval
+	lastBackupAt := h.clock.Now()
+	lastSyncPatchAt := h.clock.Now()
 
 	// Stream updates
 	for {
 		select {
 		case <-r.Context().Done():
 			return
-		case <-heartbeat.C:
-			heartbeatCount++
+		case <-heartbeat.C():
+			if _, err := h.store.GetRoom(roomCode); err != nil {
+				logger.Info("room no longer exists, closing SSE")
+				h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostRoomGone)
+				return
+			}
 
 			// Send minimal keepalive comment to prevent timeout
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Sending keepalive for game room %s", roomCode)
-			}
+			logger.Debug("sending keepalive")
 
 			// Send minimal SSE comment - just colon and newlines
 			if _, err := w.Write([]byte(":\n\n")); err != nil {
-				if os.Getenv("DEBUG") != "" {
-					log.Printf("DEBUG: 📡 Keepalive write error: %v", err)
-				}
-				log.Printf("📡 Keepalive failed for game room %s: %v - closing connection", roomCode, err)
+				heartbeat.failed()
+				logger.Warn("keepalive failed, closing connection", logging.KeyError, err)
+				h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostKeepalive)
 				return
 			}
 
-			now := time.Now()
+			now := h.clock.Now()
 			if err := h.patchSyncPill(sse, gameSyncPillState(now, lastSyncPatchAt)); err != nil {
-				log.Printf("📡 Sync pill heartbeat failed for game room %s: %v - closing connection", roomCode, err)
+				heartbeat.failed()
+				logger.Warn("sync pill heartbeat failed, closing connection", logging.KeyError, err)
+				h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostKeepalive)
 				return
 			}
 			lastSyncPatchAt = now
@@ -379,69 +500,94 @@ func (h *Handler) StreamGame(w http.ResponseWriter, r *http.Request) {
 				flusher.Flush()
 			}
 
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Keepalive sent successfully for game room %s", roomCode)
-			}
+			logger.Debug("keepalive sent")
+			heartbeat.sent()
 
-			// Send periodic backup every 60 seconds (4 heartbeats)
-			if heartbeatCount%4 == 0 {
+			// Send periodic backup every 60 seconds
+			if now.Sub(lastBackupAt) >= stateBackupInterval {
+				lastBackupAt = now
 				room, _ = h.store.GetRoom(roomCode)
 				if room != nil {
 					h.emitStateBackup(sse, room)
 				}
 			}
 		case event := <-events:
-			log.Printf("📡 SSE event received for game %s: %s", roomCode, event.Type)
-			switch event.Type {
-			case "countdown_update":
-				// Get fresh room data
-				room, _ = h.store.GetRoom(roomCode)
+			logger.Debug("SSE event received", logging.KeyEvent, event.Type)
+			if viewRoom(room, func() bool {
+				switch event.Type {
+				case EventCountdownUpdate:
+					// Get fresh room data
+					room, _ = h.store.GetRoom(roomCode)
+
+					// Send ONLY the countdown signal
+					err := patchCountdown(sse, room.CountdownRemaining)
+					if err != nil {
+						logger.Error("failed to send countdown signal", logging.KeyError, err)
+					} else {
+						logger.Debug("sent countdown signal", "countdown", room.CountdownRemaining)
+					}
+					h.announceCountdown(sse, r, room, player)
+				case EventGamePlaying:
+					// Transition to playing state - render and clear countdown
+					room, _ = h.store.GetRoom(roomCode)
+					player = room.GetPlayer(player.ID)
+					renderPlayer := h.effectivePlayerForRender(r, room, player)
+					if renderPlayer == nil {
+						h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.renderGame(sse, room, renderPlayer)
 
-				// Send ONLY the countdown signal
-				signals := map[string]interface{}{
-					"countdown": room.CountdownRemaining,
-				}
+					// Clear countdown signal
+					patchCountdown(sse, 0)
+					logger.Debug("game playing, cleared countdown signal")
 
-				err := sse.MarshalAndPatchSignals(signals)
-				if err != nil {
-					log.Printf("❌ Failed to send countdown signal: %v", err)
-				} else {
-					log.Printf("⏱️ Sent countdown signal for room %s: %d", roomCode, room.CountdownRemaining)
-				}
-			case "game_playing":
-				// Transition to playing state - render and clear countdown
-				room, _ = h.store.GetRoom(roomCode)
-				player = room.GetPlayer(player.ID)
-				renderPlayer := h.effectivePlayerForRender(r, room, player)
-				if renderPlayer == nil {
-					return
-				}
-				h.renderGame(sse, room, renderPlayer)
+					// Emit backup after game state transition
+					h.emitStateBackup(sse, room)
+					h.sendGameHint(sse, r, room, renderPlayer)
+				case EventGameRestarted:
+					room, _ = h.store.GetRoom(roomCode)
+					player = room.GetPlayer(player.ID)
+					renderPlayer := h.effectivePlayerForRender(r, room, player)
+					if renderPlayer == nil {
+						h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.swapInLobbyBody(sse, room, renderPlayer)
+					restarted = true
+					return true
+				case EventChatPosted:
+					// Patch only the log; a full re-render would only repeat it
+					room, _ = h.store.GetRoom(roomCode)
+					h.patchRoomChat(sse, PageGame, room)
+				case EventMaintenanceUpdated:
+					h.patchMaintenanceBanner(sse, PageGame)
+				default:
+					if !knownEventTypes[event.Type] {
+						h.recordUnknownEvent("game", event.Type)
+					}
+					// All other events need full re-render
+					room, _ = h.store.GetRoom(roomCode)
+					player = room.GetPlayer(player.ID) // Refresh player data
+					renderPlayer := h.effectivePlayerForRender(r, room, player)
+					if renderPlayer == nil {
+						h.sendConnectionLost(sse, PageGame, roomCode, components.ConnectionLostPlayerRemoved)
+						return true
+					}
+					h.renderGame(sse, room, renderPlayer)
 
-				// Clear countdown signal
-				signals := map[string]interface{}{
-					"countdown": 0,
-				}
-				sse.MarshalAndPatchSignals(signals)
-				log.Printf("🎮 Game playing - cleared countdown signal for room %s", roomCode)
+					// Clear any temporary modals (e.g., X input modal for Wearer of Masks)
+					h.clearModalContainer(sse)
 
-				// Emit backup after game state transition
-				h.emitStateBackup(sse, room)
-			default:
-				// All other events need full re-render
-				room, _ = h.store.GetRoom(roomCode)
-				player = room.GetPlayer(player.ID) // Refresh player data
-				renderPlayer := h.effectivePlayerForRender(r, room, player)
-				if renderPlayer == nil {
-					return
+					// Emit backup after any game state change
+					h.emitStateBackup(sse, room)
 				}
-				h.renderGame(sse, room, renderPlayer)
-
-				// Clear any temporary modals (e.g., X input modal for Wearer of Masks)
-				h.clearModalContainer(sse)
-
-				// Emit backup after any game state change
-				h.emitStateBackup(sse, room)
+				return false
+			}) {
+				return
+			}
+			if err := markEvent(sse, event); err != nil {
+				logger.Debug("failed to mark event", logging.KeyError, err)
 			}
 		}
 	}
@@ -451,15 +597,12 @@ func (h *Handler) StreamGame(w http.ResponseWriter, r *http.Request) {
 // This is needed because #modal-container is outside #game-container and doesn't get
 // automatically cleared when game content is morphed
 func (h *Handler) clearModalContainer(sse *datastar.ServerSentEventGenerator) {
-	sse.PatchElements("",
-		datastar.WithSelector("#modal-container"),
-		datastar.WithModeInner())
+	h.patchElements(sse, PageGame, "", "#modal-container", datastar.WithModeInner())
 }
 
 func (h *Handler) patchSyncPill(sse *datastar.ServerSentEventGenerator, state string) error {
 	html := renderToString(components.SyncPill(state))
-	return sse.PatchElements(html,
-		datastar.WithSelector("#sync-pill"))
+	return h.patchElements(sse, PageGame, html, "#sync-pill")
 }
 
 func gameSyncPillState(now, lastSeen time.Time) string {
@@ -476,119 +619,91 @@ func gameSyncPillState(now, lastSeen time.Time) string {
 
 // sendPlayerListUpdate sends only the player list card - minimal update for player join/leave
 func (h *Handler) sendPlayerListUpdate(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) {
-	log.Printf("📤 Sending minimal player list update for room %s", room.Code)
-
 	// Render just the player list card
-	component := pages.LobbyPlayerList(room, player)
-	html := renderToString(component)
-
-	log.Printf("📝 Player list HTML length: %d chars (was 5MB before!)", len(html))
-	log.Printf("[DEBUG] Player list HTML: %s", html)
+	component := pages.LobbyPlayerList(room, player, h.roomConfig(room))
+	html := renderFragment(component, "#player-list-card", room.Code)
 
 	// Send fragment targeting the player list card
-	sse.PatchElements(html,
-		datastar.WithSelector("#player-list-card"))
+	h.patchElements(sse, PageLobby, html, "#player-list-card")
 
-	log.Printf("✅ Sent minimal player list update for room %s", room.Code)
+	h.roomLogger(room.Code).Debug("sent player list update", logging.KeyPlayer, player.ID, "html_bytes", len(html))
+}
+
+// lobbyValidationSignals is the validation signal set sent to viewer. While
+// the role distribution is hidden, players who cannot control the room get it
+// without role counts so the start-button state cannot reveal the role mix.
+func lobbyValidationSignals(room *game.Room, viewer components.ViewerContext, state game.ValidationState) map[string]interface{} {
+	if !viewer.CanControl && room.RoleConfig != nil && room.RoleConfig.HideRoleDistribution {
+		state = state.WithoutRoleCounts()
+	}
+	return map[string]interface{}{
+		"canStartGame":      state.CanStart,
+		"validationMessage": state.ValidationMessage,
+		"canAutoScale":      state.CanAutoScale,
+		"autoScaleDetails":  state.AutoScaleDetails,
+		"requiredRoles":     state.RequiredRoles,
+		"configuredRoles":   state.ConfiguredRoles,
+	}
 }
 
 // sendLobbyUpdate sends a consistent lobby update with validation state
 // This is the helper function that ensures SSE updates use the same validation logic
 func (h *Handler) sendLobbyUpdate(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) error {
 	// CRITICAL: Always use GetValidationState for consistency
-	roleService := game.NewRoleConfigService(h.config)
+	roleService := game.NewRoleConfigService(h.roomConfig(room))
 	validationState := room.GetValidationState(roleService)
 
 	// First send the HTML fragment
-	log.Printf("📤 DEBUG: sendLobbyUpdate called for player %s in room %s", player.ID, room.Code)
-	log.Printf("📤 DEBUG: sendLobbyUpdate calling renderLobby for room %s", room.Code)
 	h.renderLobby(sse, room, player)
 
 	// Then send the validation signals to keep UI in sync
-	err := sse.MarshalAndPatchSignals(map[string]interface{}{
-		"canStartGame":      validationState.CanStart,
-		"validationMessage": validationState.ValidationMessage,
-		"canAutoScale":      validationState.CanAutoScale,
-		"autoScaleDetails":  validationState.AutoScaleDetails,
-		"requiredRoles":     validationState.RequiredRoles,
-		"configuredRoles":   validationState.ConfiguredRoles,
-		// Reset error state on updates
-		"isStarting": false,
-		"startError": "",
-	})
+	signals := lobbyValidationSignals(room, components.NewViewerContext(room, player), validationState)
+	// Reset error state on updates
+	signals["isStarting"] = false
+	signals["startError"] = ""
+	err := sse.MarshalAndPatchSignals(signals)
 
 	if err != nil {
-		log.Printf("❌ Failed to update validation signals: %v", err)
+		h.roomLogger(room.Code).Error("failed to update validation signals", logging.KeyError, err)
 		return err
 	}
 
-	log.Printf("✅ Sent lobby update with validation state v%d for room %s", validationState.Version, room.Code)
+	h.roomLogger(room.Code).Debug("sent lobby update", logging.KeyPlayer, player.ID, "validation_version", validationState.Version)
 	return nil
 }
 
 // renderLobby renders the lobby content (without SSE trigger)
 func (h *Handler) renderLobby(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) {
+	logger := h.roomLogger(room.Code).With(logging.KeyPlayer, player.ID)
+
 	// Only render lobby if room is in lobby state
 	if room.State != game.StateLobby {
-		log.Printf("🚫 Attempted to render lobby for room %s in state %s", room.Code, room.State)
+		logger.Warn("attempted to render the lobby outside the lobby state", "state", room.State)
 		return
 	}
 
-	log.Printf("🎨 Rendering lobby content for room %s with %d players", room.Code, len(room.Players))
-	log.Printf("🎨 DEBUG: Room player details:")
-	for pid, p := range room.Players {
-		log.Printf("🎨 DEBUG:   - Player %s: %s (Host: %v)", pid, p.Name, p.IsHost)
-	}
-	log.Printf("🎨 DEBUG: Active player count: %d", room.GetActivePlayerCount())
-
-	component := pages.LobbyContent(room, player, h.config, h.cardService)
+	component := pages.LobbyContent(room, player, h.roomConfig(room), h.cardService)
 
 	// Render to string
-	html := renderToString(component)
-
-	log.Printf("📝 Rendered lobby HTML length: %d chars", len(html))
-
-	// Debug: show first 200 chars of rendered HTML
-	if len(html) > 200 {
-		log.Printf("📝 HTML preview: %s...", html[:200])
-	} else {
-		log.Printf("📝 Full HTML: %s", html)
-	}
+	html := renderFragment(component, "", room.Code)
 
 	// Send the target wrapper too, so morphing #lobby-content keeps the
 	// element that future patches target.
 	wrappedHTML := fmt.Sprintf(`<div id="lobby-content">%s</div>`, html)
-	log.Printf("📤 DEBUG: Sending fragment with selector #lobby-content, merge mode: morph")
-	sse.PatchElements(wrappedHTML,
-		datastar.WithSelector("#lobby-content"))
-	log.Printf("✅ Sent lobby fragment update for room %s to player %s", room.Code, player.ID)
+	h.patchElements(sse, PageLobby, wrappedHTML, "#lobby-content")
+	logger.Debug("sent lobby update", "players", len(room.Players), "active_players", room.GetActivePlayerCount(), "html_bytes", len(html))
 }
 
 // renderGame renders the game content (without wrapper to prevent re-triggering data-on-load)
 func (h *Handler) renderGame(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) {
-	log.Printf("🎨 Rendering game for room %s, state: %s, countdown: %d", room.Code, room.State, room.CountdownRemaining)
 	component := pages.GameContent(room, player)
 
 	// Render to string
-	html := renderToString(component)
-
-	// Log first 200 chars of rendered HTML for debugging
-	if len(html) > 200 {
-		log.Printf("🎭 Rendered HTML preview: %s...", html[:200])
-	} else {
-		log.Printf("🎭 Rendered HTML: %s", html)
-	}
+	html := renderFragment(component, "#game-container", room.Code)
 
 	// Send as fragment with morph mode and explicit selector
-	sse.PatchElements(html,
-		datastar.WithSelector("#game-container"))
-}
-
-// renderToString renders a templ component to string
-func renderToString(component templ.Component) string {
-	buf := &bytes.Buffer{}
-	component.Render(context.Background(), buf)
-	return buf.String()
+	h.patchElements(sse, PageGame, html, "#game-container")
+	h.roomLogger(room.Code).Debug("sent game update", logging.KeyPlayer, player.ID, "state", room.State, "countdown", room.CountdownRemaining, "html_bytes", len(html))
 }
 
 // emitStateBackup sends an encrypted state backup to the client for localStorage storage
@@ -598,9 +713,10 @@ func (h *Handler) emitStateBackup(sse *datastar.ServerSentEventGenerator, room *
 		return // Backup service not configured
 	}
 
+	logger := h.roomLogger(room.Code)
 	backup, err := h.backupService.CreateBackup(room)
 	if err != nil {
-		log.Printf("❌ Failed to create state backup for room %s: %v", room.Code, err)
+		logger.Error("failed to create state backup", logging.KeyError, err)
 		return
 	}
 
@@ -609,27 +725,28 @@ func (h *Handler) emitStateBackup(sse *datastar.ServerSentEventGenerator, room *
 		"_stateBackup": backup,
 	})
 	if err != nil {
-		log.Printf("❌ Failed to send state backup signal for room %s: %v", room.Code, err)
-	} else if os.Getenv("DEBUG") != "" {
-		log.Printf("DEBUG: 💾 Sent state backup for room %s (%d bytes)", room.Code, len(backup))
+		logger.Error("failed to send state backup signal", logging.KeyError, err)
+	} else {
+		logger.Debug("sent state backup", "bytes", len(backup))
 	}
 }
 
 // StreamHost streams host dashboard updates
 func (h *Handler) StreamHost(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
-	log.Printf("📡 SSE connection established for host dashboard %s", roomCode)
+	logger := h.requestLogger(r).With(logging.KeyView, "host")
+	logger.Info("SSE connection established")
 
 	room, err := h.store.GetRoom(roomCode)
 	if err != nil {
-		log.Printf("📡 SSE requested for non-existent room: %s", roomCode)
+		logger.Info("SSE requested for non-existent room")
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
 
-	sessionCookie, err := r.Cookie("session")
-	if err != nil || !room.IsOperatorSession(sessionCookie.Value) {
-		log.Printf("📡 Unauthorized Operator Dashboard SSE attempt for room: %s", roomCode)
+	sessionID, ok := h.sessionID(r)
+	if !ok || !room.IsOperatorSession(sessionID) {
+		logger.Warn("unauthorized Operator Dashboard SSE attempt")
 		http.Error(w, "Unauthorized - Room Operator access only", http.StatusUnauthorized)
 		return
 	}
@@ -645,20 +762,22 @@ func (h *Handler) StreamHost(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Operator player not found in room", http.StatusUnauthorized)
 		return
 	}
-	if player.SessionID != sessionCookie.Value {
+	if player.SessionID != sessionID {
 		http.Error(w, "Operator player session mismatch", http.StatusUnauthorized)
 		return
 	}
+	logger = logger.With(logging.KeyPlayer, player.ID)
 
 	// Create SSE connection
 	sse := datastar.NewSSE(w, r)
 
 	// Send initial player list
+	room.RLock()
 	h.renderHostDashboard(sse, room, player)
 
 	// Send initial validation state for host dashboard
 	if room.State == game.StateLobby {
-		roleService := game.NewRoleConfigService(h.config)
+		roleService := game.NewRoleConfigService(h.roomConfig(room))
 		validationState := room.GetValidationState(roleService)
 
 		sse.MarshalAndPatchSignals(map[string]interface{}{
@@ -670,63 +789,64 @@ func (h *Handler) StreamHost(w http.ResponseWriter, r *http.Request) {
 			"configuredRoles":   validationState.ConfiguredRoles,
 		})
 
-		log.Printf("📡 Sent initial validation state for host dashboard: canAutoScale=%v", validationState.CanAutoScale)
+		logger.Debug("sent initial validation state", "can_start", validationState.CanStart, "can_auto_scale", validationState.CanAutoScale)
 	} else if room.State == game.StateCountdown {
 		// Send initial countdown signal if joining during countdown
-		signals := map[string]interface{}{
-			"countdown": room.CountdownRemaining,
-		}
-		err = sse.MarshalAndPatchSignals(signals)
+		err = patchCountdown(sse, room.CountdownRemaining)
 		if err != nil {
-			log.Printf("❌ Failed to send initial countdown signal to host: %v", err)
+			logger.Error("failed to send initial countdown signal", logging.KeyError, err)
 		} else {
-			log.Printf("📡 Sent initial countdown signal to host: %d", room.CountdownRemaining)
+			logger.Debug("sent initial countdown signal", "countdown", room.CountdownRemaining)
 		}
 	}
+	room.RUnlock()
 
 	// Send debug mode signal if debug mode is enabled (for debug panel visibility)
-	if h.config.Server.DebugModeEnabled {
+	if h.config().Server.DebugModeEnabled {
 		sse.MarshalAndPatchSignals(map[string]interface{}{
 			"debugmode": true,
 		})
 	}
 
+	h.sendInitialMaintenanceBanner(sse, PageHost)
+
 	// Subscribe to events
 	events := h.eventBus.Subscribe(roomCode)
 	defer h.eventBus.Unsubscribe(roomCode, events)
+	// The full render above covers a gap replay can't bridge
+	events, _ = h.replayFeed(r.Context(), r, roomCode, events)
+	defer h.trackPresence(r, roomCode)()
+	h.connTracker.AddConnection(roomCode)
+	defer h.connTracker.RemoveConnection(roomCode)
 
-	log.Printf("📡 Host SSE connection ready for room %s, waiting for events (subscriber channel: %p)", roomCode, events)
+	logger.Info("SSE connection ready")
 
-	// Set up a heartbeat to prevent timeouts
-	// 15 seconds is well under our 10-minute WriteTimeout
-	heartbeat := time.NewTicker(15 * time.Second)
+	// Set up a heartbeat to prevent timeouts; the interval adapts to the
+	// room (see keepaliveInterval) and stays well under our 10-minute WriteTimeout
+	heartbeat := h.newKeepaliveTicker(roomCode)
 	defer heartbeat.Stop()
 
 	// Stream updates
 	for {
 		select {
 		case <-r.Context().Done():
-			log.Printf("📡 Host SSE context cancelled for room %s", roomCode)
+			logger.Info("SSE context cancelled", logging.KeyError, r.Context().Err())
 			return
-		case <-heartbeat.C:
+		case <-heartbeat.C():
 			// Check if room still exists
 			_, err := h.store.GetRoom(roomCode)
 			if err != nil {
-				log.Printf("📡 Heartbeat: Room %s no longer exists, closing host SSE", roomCode)
+				logger.Info("room no longer exists, closing SSE")
 				return
 			}
 
 			// Send minimal keepalive comment to prevent timeout
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Sending keepalive for host room %s", roomCode)
-			}
+			logger.Debug("sending keepalive")
 
 			// Send minimal SSE comment - just colon and newlines
 			if _, err := w.Write([]byte(":\n\n")); err != nil {
-				if os.Getenv("DEBUG") != "" {
-					log.Printf("DEBUG: 📡 Keepalive write error: %v", err)
-				}
-				log.Printf("📡 Keepalive failed for host room %s: %v - closing connection", roomCode, err)
+				heartbeat.failed()
+				logger.Warn("keepalive failed, closing connection", logging.KeyError, err)
 				return
 			}
 
@@ -735,83 +855,107 @@ func (h *Handler) StreamHost(w http.ResponseWriter, r *http.Request) {
 				flusher.Flush()
 			}
 
-			if os.Getenv("DEBUG") != "" {
-				log.Printf("DEBUG: 📡 Keepalive sent successfully for host room %s", roomCode)
-			}
+			logger.Debug("keepalive sent")
+			heartbeat.sent()
 		case event := <-events:
-			log.Printf("📡 Host SSE event received for %s: %s", roomCode, event.Type)
+			logger.Debug("SSE event received", logging.KeyEvent, event.Type)
+
+			if viewRoom(room, func() bool {
+				switch event.Type {
+				case EventPlayerJoined, EventPlayerLeft, EventPlayerRemoved, EventRoleConfigUpdated, EventCoupConfigUpdated, EventPhaseSettingsUpdated, EventStartRitualUpdated, EventScreenshotDeterrenceUpdated, EventPollUpdated, EventDealPending, EventStartCancelled, EventGameRestarted:
+					// Re-render host dashboard for player changes or setup config updates.
+					room, _ = h.store.GetRoom(roomCode)
+					if room.State == game.StateLobby {
+						// Refresh player reference in case it was updated
+						player = room.GetPlayer(player.ID)
+						if player == nil {
+							// Host was removed, close SSE connection gracefully
+							logger.Info("host no longer in room, closing SSE")
+							return true
+						}
+						h.renderHostDashboard(sse, room, player)
+
+						// Also send validation state for host dashboard
+						roleService := game.NewRoleConfigService(h.roomConfig(room))
+						validationState := room.GetValidationState(roleService)
+
+						sse.MarshalAndPatchSignals(map[string]interface{}{
+							"canStartGame":      validationState.CanStart,
+							"validationMessage": validationState.ValidationMessage,
+							"canAutoScale":      validationState.CanAutoScale,
+							"autoScaleDetails":  validationState.AutoScaleDetails,
+							"requiredRoles":     validationState.RequiredRoles,
+							"configuredRoles":   validationState.ConfiguredRoles,
+						})
+					}
+				case EventGameStarted:
+					// Update dashboard to show countdown state
+					room, _ = h.store.GetRoom(roomCode)
+					h.renderHostDashboard(sse, room, player)
+				case EventCountdownUpdate:
+					// Get fresh room data
+					room, _ = h.store.GetRoom(roomCode)
+
+					// Send ONLY the countdown signal for the host
+					err := patchCountdown(sse, room.CountdownRemaining)
+					if err != nil {
+						logger.Error("failed to send countdown signal", logging.KeyError, err)
+					} else {
+						logger.Debug("sent countdown signal", "countdown", room.CountdownRemaining)
+					}
+					h.announceCountdown(sse, r, room, player)
+				case EventGamePlaying:
+					// Update dashboard to show game state
+					room, _ = h.store.GetRoom(roomCode)
+					h.renderHostDashboard(sse, room, player)
 
-			switch event.Type {
-			case "player_joined", "player_left", "player_updated", "role_config_updated", "coup_config_updated":
-				// Re-render host dashboard for player changes or setup config updates.
-				room, _ = h.store.GetRoom(roomCode)
-				if room.State == game.StateLobby {
-					// Refresh player reference in case it was updated
+					// Clear countdown signal for host
+					patchCountdown(sse, 0)
+					logger.Debug("game playing, cleared countdown signal")
+				case EventRoleRevealed, EventPlayerEliminated, EventCoupWinPromptRejected, EventPhaseChanged, EventVoteOpened, EventVoteCast, EventVoteClosed, EventStartConfirmed:
+					room, _ = h.store.GetRoom(roomCode)
 					player = room.GetPlayer(player.ID)
 					if player == nil {
-						// Host was removed, close SSE connection gracefully
-						log.Printf("📡 Host no longer in room %s, closing SSE", roomCode)
-						return
+						logger.Info("host no longer in room, closing SSE")
+						return true
 					}
 					h.renderHostDashboard(sse, room, player)
-
-					// Also send validation state for host dashboard
-					roleService := game.NewRoleConfigService(h.config)
-					validationState := room.GetValidationState(roleService)
-
-					sse.MarshalAndPatchSignals(map[string]interface{}{
-						"canStartGame":      validationState.CanStart,
-						"validationMessage": validationState.ValidationMessage,
-						"canAutoScale":      validationState.CanAutoScale,
-						"autoScaleDetails":  validationState.AutoScaleDetails,
-						"requiredRoles":     validationState.RequiredRoles,
-						"configuredRoles":   validationState.ConfiguredRoles,
-					})
-				}
-			case "game_started":
-				// Update dashboard to show countdown state
-				room, _ = h.store.GetRoom(roomCode)
-				h.renderHostDashboard(sse, room, player)
-			case "countdown_update":
-				// Get fresh room data
-				room, _ = h.store.GetRoom(roomCode)
-
-				// Send ONLY the countdown signal for the host
-				signals := map[string]interface{}{
-					"countdown": room.CountdownRemaining,
-				}
-
-				err := sse.MarshalAndPatchSignals(signals)
-				if err != nil {
-					log.Printf("❌ Failed to send countdown signal to host: %v", err)
-				} else {
-					log.Printf("⏱️ Sent countdown signal to host for room %s: %d", roomCode, room.CountdownRemaining)
-				}
-			case "game_playing":
-				// Update dashboard to show game state
-				room, _ = h.store.GetRoom(roomCode)
-				h.renderHostDashboard(sse, room, player)
-
-				// Clear countdown signal for host
-				signals := map[string]interface{}{
-					"countdown": 0,
-				}
-				sse.MarshalAndPatchSignals(signals)
-				log.Printf("🎮 Game playing - cleared countdown signal for host in room %s", roomCode)
-			case "role_revealed", "player_eliminated", "coup_win_prompt_rejected":
-				room, _ = h.store.GetRoom(roomCode)
-				player = room.GetPlayer(player.ID)
-				if player == nil {
-					log.Printf("📡 Host no longer in room %s, closing SSE", roomCode)
-					return
+				case EventOperatorChanged:
+					room, _ = h.store.GetRoom(roomCode)
+					if !room.IsOperatorSession(player.SessionID) {
+						logger.Info("Room Operator authority moved to another player, closing SSE")
+						h.sendConnectionLost(sse, PageHost, roomCode, components.ConnectionLostHostTransferred)
+						return true
+					}
+					h.renderHostDashboard(sse, room, player)
+				case EventGameEnded:
+					// Update dashboard to show ended state
+					room, _ = h.store.GetRoom(roomCode)
+					h.renderHostDashboard(sse, room, player)
+				case EventChatPosted:
+					room, _ = h.store.GetRoom(roomCode)
+					if hostDashboardShowsChat(room) {
+						h.patchRoomChat(sse, PageHost, room)
+					}
+				case EventMaintenanceUpdated:
+					h.patchMaintenanceBanner(sse, PageHost)
+				case EventConfigMigrated:
+					room, _ = h.store.GetRoom(roomCode)
+					html := renderToString(components.HostConfigNotice(room.ConfigNotice))
+					h.patchElements(sse, PageHost, html, "#host-config-notice")
+					h.renderHostDashboard(sse, room, player)
+				default:
+					if !hostIgnoredEvents[event.Type] {
+						logger.Warn("unknown event type", logging.KeyEvent, event.Type)
+						h.recordUnknownEvent("host", event.Type)
+					}
 				}
-				h.renderHostDashboard(sse, room, player)
-			case "game_ended":
-				// Update dashboard to show ended state
-				room, _ = h.store.GetRoom(roomCode)
-				h.renderHostDashboard(sse, room, player)
-			default:
-				log.Printf("📡 Unknown event type %s for room %s in host SSE", event.Type, roomCode)
+				return false
+			}) {
+				return
+			}
+			if err := markEvent(sse, event); err != nil {
+				logger.Debug("failed to mark event", logging.KeyError, err)
 			}
 		}
 	}
@@ -824,7 +968,7 @@ func (h *Handler) renderHostDashboard(sse *datastar.ServerSentEventGenerator, ro
 	// Choose the appropriate template based on game state
 	switch room.State {
 	case game.StateLobby:
-		component = pages.HostDashboardContent(room, player, h.config, h.cardService)
+		component = pages.HostDashboardContent(room, player, h.roomConfig(room), h.cardService)
 	case game.StateCountdown:
 		component = pages.HostDashboardCountdown(room, player)
 	case game.StatePlaying:
@@ -832,22 +976,19 @@ func (h *Handler) renderHostDashboard(sse *datastar.ServerSentEventGenerator, ro
 	case game.StateEnded:
 		component = pages.HostDashboardEnded(room, player)
 	default:
-		component = pages.HostDashboardContent(room, player, h.config, h.cardService)
+		component = pages.HostDashboardContent(room, player, h.roomConfig(room), h.cardService)
 	}
 
 	// Render to string
-	html := renderToString(component)
+	html := renderFragment(component, "", room.Code)
 
 	// Wrap content in the dashboard container structure to preserve DOM hierarchy during morph
 	wrappedHTML := fmt.Sprintf(`<div id="host-dashboard-container" class="host-dashboard"><div id="host-dashboard-content">%s</div></div>`, html)
 
-	log.Printf("🎨 Rendering host dashboard for room %s in state %s", room.Code, room.State)
-
 	// Send fragment with full container structure
-	sse.PatchElements(wrappedHTML,
-		datastar.WithSelector("#host-dashboard-container"))
+	h.patchElements(sse, PageHost, wrappedHTML, "#host-dashboard-container")
 
-	log.Printf("✅ Sent host dashboard update for room %s", room.Code)
+	h.roomLogger(room.Code).Debug("sent host dashboard update", "state", room.State)
 }
 
 type qrBufferWriteCloser struct {
@@ -908,31 +1049,3 @@ func getBaseURL(r *http.Request) string {
 
 	return fmt.Sprintf("%s://%s", scheme, host)
 }
-
-// Helper function to check if there's a host in the room
-func hasHost(room *game.Room) bool {
-	for _, player := range room.Players {
-		if player.IsHost {
-			return true
-		}
-	}
-	return false
-}
-
-// Helper function to get the first player ID (room creator)
-func getFirstPlayerID(room *game.Room) string {
-	var firstPlayer *game.Player
-	var firstJoinTime time.Time
-
-	for _, player := range room.Players {
-		if !player.IsHost && (firstPlayer == nil || player.JoinedAt.Before(firstJoinTime)) {
-			firstPlayer = player
-			firstJoinTime = player.JoinedAt
-		}
-	}
-
-	if firstPlayer != nil {
-		return firstPlayer.ID
-	}
-	return ""
-}
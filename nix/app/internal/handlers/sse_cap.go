@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/views/components"
+)
+
+// sseCapRetryAfter is the Retry-After, in seconds, sent with a stream
+// refused at a connection cap
+const sseCapRetryAfter = "5"
+
+// limitedSSE wraps an SSE handler so a stream that would take the server past
+// MaxSSEConnections, or its room past MaxSSEConnectionsPerRoom, is refused with
+// 503 before it subscribes to anything. The refusal still carries the
+// connection banner as a datastar patch, so the page says why it isn't live.
+func (h *Handler) limitedSSE(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		roomCode := chi.URLParam(r, "code")
+		if scope := h.connTracker.Admit(roomCode, h.config().Server.MaxSSEConnections, h.config().Server.MaxSSEConnectionsPerRoom); scope != "" {
+			h.requestLogger(r).Warn("stream refused at connection cap", "scope", scope)
+			h.refuseStream(w, r, roomCode)
+			return
+		}
+		defer h.connTracker.Release(roomCode)
+
+		next(w, r)
+	}
+}
+
+// refuseStream answers a capped stream with 503 and the server busy banner
+func (h *Handler) refuseStream(w http.ResponseWriter, r *http.Request, roomCode string) {
+	w.Header().Set("Retry-After", sseCapRetryAfter)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	sse := datastar.NewSSE(w, r)
+	sse.PatchElements(renderToString(components.ConnectionBanner(roomCode, components.ConnectionLostServerBusy)), datastar.WithSelector("#connection-banner"))
+}
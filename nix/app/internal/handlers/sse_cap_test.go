@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoomStreamRefusedAtConnectionCap(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.MaxSSEConnectionsPerRoom = 1
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+
+	// Another stream already holds the room's only slot
+	h.connTracker.Admit(room.Code, 0, 0)
+
+	req := httptest.NewRequest("GET", "/sse/room/"+room.Code+"?view=lobby", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 at the room cap, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != sseCapRetryAfter {
+		t.Errorf("expected Retry-After %s, got %q", sseCapRetryAfter, got)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{"event: datastar-patch-elements", "selector #connection-banner", `data-reason="server_busy"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected the refusal to contain %q, got %s", expected, body)
+		}
+	}
+
+	h.connTracker.Release(room.Code)
+	if scope := h.connTracker.Admit(room.Code, 0, 1); scope != "" {
+		t.Errorf("expected the refused stream not to hold a slot, got %q", scope)
+	}
+}
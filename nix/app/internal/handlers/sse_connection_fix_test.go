@@ -43,7 +43,7 @@ func TestSSEConnectionExhaustionFixed(t *testing.T) {
 		}
 
 		// Use the actual router
-		router := setupTestRouter(h)
+		router := newTestRouter(h)
 		router.ServeHTTP(w, r)
 	}))
 	defer testServer.Close()
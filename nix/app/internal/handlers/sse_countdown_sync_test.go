@@ -91,11 +91,11 @@ func TestMultipleBrowsersCountdownSync(t *testing.T) {
 	h.store.UpdateRoom(room)
 
 	// Run countdown
-	go h.runCountdown(room)
+	go h.runCountdown(room, room.StartedAt)
 
 	// Publish initial game started event
 	h.eventBus.Publish(Event{
-		Type:     "game_started",
+		Type:     EventGameStarted,
 		RoomCode: room.Code,
 		Data:     room,
 	})
@@ -127,7 +127,7 @@ func TestLateJoinerDuringCountdown(t *testing.T) {
 	h.store.UpdateRoom(room)
 
 	// Start countdown in background
-	go h.runCountdown(room)
+	go h.runCountdown(room, room.StartedAt)
 
 	// Wait 2 seconds
 	time.Sleep(2 * time.Second)
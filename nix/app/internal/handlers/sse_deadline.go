@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// deadlineWriter gives every write and flush on an SSE stream its own
+// deadline. The server's WriteTimeout is off for streaming, so without one a
+// client whose TCP connection stalls would block the stream's writes, and the
+// goroutine behind it, forever. The first write that fails ends the stream as
+// if the client had disconnected.
+type deadlineWriter struct {
+	http.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+	stuck   func(error) // called once, on the first failed write
+	once    sync.Once
+}
+
+// withWriteDeadlines wraps w so each write must finish within the configured
+// SSEWriteTimeout; stuck is called when one doesn't. It returns w as it is
+// when the timeout is off.
+func (h *Handler) withWriteDeadlines(w http.ResponseWriter, stuck func(error)) http.ResponseWriter {
+	timeout := h.config().Server.SSEWriteTimeout
+	if timeout <= 0 {
+		return w
+	}
+	return &deadlineWriter{ResponseWriter: w, rc: http.NewResponseController(w), timeout: timeout, stuck: stuck}
+}
+
+// Write writes p within the deadline. The deadline is on the connection, not
+// the clock the handlers run on, so it is always wall time.
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	d.arm()
+	n, err := d.ResponseWriter.Write(p)
+	d.disarm()
+	if err != nil {
+		d.fail(err)
+	}
+	return n, err
+}
+
+// FlushError sends buffered data to the client within the deadline; the
+// datastar generator flushes after every event through it
+func (d *deadlineWriter) FlushError() error {
+	d.arm()
+	err := d.rc.Flush()
+	d.disarm()
+	if err != nil {
+		d.fail(err)
+	}
+	return err
+}
+
+// Flush is FlushError for callers that only know http.Flusher
+func (d *deadlineWriter) Flush() {
+	d.FlushError()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (d *deadlineWriter) Unwrap() http.ResponseWriter {
+	return d.ResponseWriter
+}
+
+func (d *deadlineWriter) arm() {
+	d.rc.SetWriteDeadline(time.Now().Add(d.timeout))
+}
+
+// disarm clears the deadline, so a connection reused after the stream ends
+// doesn't inherit it
+func (d *deadlineWriter) disarm() {
+	d.rc.SetWriteDeadline(time.Time{})
+}
+
+func (d *deadlineWriter) fail(err error) {
+	d.once.Do(func() { d.stuck(err) })
+}
+
+// stuckWriter records a stream ended because a write to it failed, counting
+// those that ran out of time as stuck writers
+func (h *Handler) stuckWriter(r *http.Request, err error) {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		h.stuckWriters.Add(1)
+		log.Printf("🐌 SSE write to %s stalled for %s, treating it as a disconnect", r.URL.Path, h.config().Server.SSEWriteTimeout)
+		return
+	}
+	log.Printf("📡 SSE write to %s failed, treating it as a disconnect: %v", r.URL.Path, err)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+)
+
+// stallingListener hands out connections that stop taking writes once
+// stalled, like a client whose TCP window has closed: each write then blocks
+// until the connection's write deadline, or forever without one
+type stallingListener struct {
+	net.Listener
+	stalled atomic.Bool
+}
+
+func (l *stallingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &stallingConn{Conn: conn, listener: l, closed: make(chan struct{})}, nil
+}
+
+type stallingConn struct {
+	net.Conn
+	listener  *stallingListener
+	mu        sync.Mutex
+	deadline  time.Time
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *stallingConn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.deadline = t
+	c.mu.Unlock()
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *stallingConn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.deadline = t
+	c.mu.Unlock()
+	return c.Conn.SetWriteDeadline(t)
+}
+
+func (c *stallingConn) Write(p []byte) (int, error) {
+	if !c.listener.stalled.Load() {
+		return c.Conn.Write(p)
+	}
+	c.mu.Lock()
+	deadline := c.deadline
+	c.mu.Unlock()
+	if deadline.IsZero() {
+		<-c.closed
+		return 0, net.ErrClosed
+	}
+	select {
+	case <-time.After(time.Until(deadline)):
+		return 0, &net.OpError{Op: "write", Net: "tcp", Err: os.ErrDeadlineExceeded}
+	case <-c.closed:
+		return 0, net.ErrClosed
+	}
+}
+
+func (c *stallingConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Conn.Close()
+}
+
+func TestStalledClientEndsItsStream(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.SSEWriteTimeout = 100 * time.Millisecond
+	router := newTestRouter(h)
+
+	server := httptest.NewUnstartedServer(router)
+	listener := &stallingListener{Listener: server.Listener}
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	operator := testkit.CreateRoom(t, router, "Operator", false)
+	req, _ := http.NewRequest("GET", server.URL+"/sse/room/"+operator.RoomCode+"?view=lobby", nil)
+	req.AddCookie(operator.SessionCookie())
+	req.AddCookie(operator.PlayerCookie())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	defer resp.Body.Close()
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "event: ") {
+		t.Fatalf("expected the stream to start, got %q (%v)", line, err)
+	}
+
+	// The client stops reading; the next lobby update can't be delivered
+	listener.stalled.Store(true)
+	testkit.JoinRoom(t, router, operator.RoomCode, "Alice")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for h.drainer.Active() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if h.drainer.Active() != 0 {
+		t.Fatal("expected the stalled stream to end")
+	}
+	if got := h.stuckWriters.Load(); got != 1 {
+		t.Errorf("expected one stuck writer counted, got %d", got)
+	}
+}
+
+func TestWriteDeadlinesOff(t *testing.T) {
+	h := newTestHandler()
+	h.config().Server.SSEWriteTimeout = 0
+	w := httptest.NewRecorder()
+	if got := h.withWriteDeadlines(w, func(error) {}); got != w {
+		t.Error("expected no wrapper without a write timeout")
+	}
+}
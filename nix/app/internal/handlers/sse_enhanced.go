@@ -92,17 +92,91 @@ func (es *EventStore) GetEventsSince(roomCode string, lastEventID string) []SSEE
 	return nil
 }
 
+// Replay returns the events a client that last saw lastEventID has missed.
+// ok is false when lastEventID is no longer stored (the client fell too far
+// behind, or the server restarted), so replay can't bridge the gap and the
+// client needs a full resync instead.
+func (es *EventStore) Replay(roomCode string, lastEventID string) (missed []SSEEvent, ok bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	if lastEventID == "" {
+		return nil, true
+	}
+	events := es.events[roomCode]
+	for i, event := range events {
+		if event.ID == lastEventID {
+			return append([]SSEEvent(nil), events[i+1:]...), true
+		}
+	}
+	return nil, false
+}
+
 // ConnectionTracker tracks active SSE connections
+// Read-only spectators are tracked separately so they never count as players.
 type ConnectionTracker struct {
 	mu          sync.RWMutex
 	connections map[string]int64 // roomCode -> connection count
+	viewers     map[string]int64 // roomCode -> spectator connection count
 	totalActive int64            // Total active connections (atomic)
+
+	// Every open stream, spectators' included, counted for the connection
+	// caps (see Admit)
+	admitted      map[string]int64 // roomCode -> admitted stream count
+	admittedTotal int64
 }
 
+// Connection cap scopes, returned by Admit and used in logs
+const (
+	connectionCapGlobal = "global"
+	connectionCapRoom   = "room"
+)
+
 // NewConnectionTracker creates a new connection tracker
 func NewConnectionTracker() *ConnectionTracker {
 	return &ConnectionTracker{
 		connections: make(map[string]int64),
+		viewers:     make(map[string]int64),
+		admitted:    make(map[string]int64),
+	}
+}
+
+// Admit counts a new stream for roomCode unless that would take the server
+// past maxTotal streams or the room past maxPerRoom; a cap of 0 is no cap,
+// and an empty roomCode only counts against maxTotal. It returns the scope
+// of the cap that refused the stream, or "" once the stream is counted, which
+// its caller then gives back with Release.
+func (ct *ConnectionTracker) Admit(roomCode string, maxTotal, maxPerRoom int) string {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if maxTotal > 0 && ct.admittedTotal >= int64(maxTotal) {
+		return connectionCapGlobal
+	}
+	if roomCode != "" && maxPerRoom > 0 && ct.admitted[roomCode] >= int64(maxPerRoom) {
+		return connectionCapRoom
+	}
+	ct.admittedTotal++
+	if roomCode != "" {
+		ct.admitted[roomCode]++
+	}
+	return ""
+}
+
+// Release gives back a stream Admit counted
+func (ct *ConnectionTracker) Release(roomCode string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.admittedTotal > 0 {
+		ct.admittedTotal--
+	}
+	if count, exists := ct.admitted[roomCode]; exists {
+		if count <= 1 {
+			delete(ct.admitted, roomCode)
+		} else {
+			ct.admitted[roomCode]--
+		}
 	}
 }
 
@@ -148,20 +222,47 @@ func (ct *ConnectionTracker) GetTotalConnections() int64 {
 	return atomic.LoadInt64(&ct.totalActive)
 }
 
+// AddViewer increments the spectator connection count for a room
+func (ct *ConnectionTracker) AddViewer(roomCode string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.viewers[roomCode]++
+	log.Printf("SSE: Viewer connected to room %s (room viewers: %d)", roomCode, ct.viewers[roomCode])
+}
+
+// RemoveViewer decrements the spectator connection count for a room
+func (ct *ConnectionTracker) RemoveViewer(roomCode string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if count, exists := ct.viewers[roomCode]; exists && count > 0 {
+		ct.viewers[roomCode]--
+		if ct.viewers[roomCode] == 0 {
+			delete(ct.viewers, roomCode)
+		}
+	}
+}
+
+// GetViewerCount returns the number of spectator connections for a room
+func (ct *ConnectionTracker) GetViewerCount(roomCode string) int64 {
+	ct.mu.RLock()
+	defer ct.mu.RUnlock()
+	return ct.viewers[roomCode]
+}
+
 // EnhancedHandler extends Handler with SSE improvements
 type EnhancedHandler struct {
 	*Handler
 	eventStore   *EventStore
-	connTracker  *ConnectionTracker
 	eventCounter int64 // Atomic counter for event IDs
 }
 
 // NewEnhanced creates a new enhanced handler
-func NewEnhanced(s *store.MemoryStore, cardService *game.CardService, cfg *config.ServerConfig, backupService *game.BackupService) *EnhancedHandler {
+func NewEnhanced(s store.RoomStore, cardService *game.CardService, cfg *config.ServerConfig, backupService *game.BackupService) *EnhancedHandler {
 	return &EnhancedHandler{
 		Handler:      New(s, cardService, cfg, backupService),
 		eventStore:   NewEventStore(100), // Keep last 100 events per room
-		connTracker:  NewConnectionTracker(),
 		eventCounter: 0,
 	}
 }
@@ -169,9 +270,24 @@ func NewEnhanced(s *store.MemoryStore, cardService *game.CardService, cfg *confi
 // generateEventID generates a unique event ID
 func (h *EnhancedHandler) generateEventID() string {
 	id := atomic.AddInt64(&h.eventCounter, 1)
-	return fmt.Sprintf("%d-%d", time.Now().Unix(), id)
+	return fmt.Sprintf("%d-%d", h.clock.Now().Unix(), id)
 }
 
+// enhancedLobbyIgnoredEvents are the event types StreamLobbyEnhanced
+// deliberately leaves alone; it only tracks the roster and the game start
+var enhancedLobbyIgnoredEvents = newEventSet(
+	EventRoleConfigUpdated, EventRoleOptionsChanged, EventCoupConfigUpdated,
+	EventPhaseSettingsUpdated, EventConfigMigrated, EventPollUpdated, EventChatPosted,
+	EventStartRitualUpdated, EventScreenshotDeterrenceUpdated, EventWatchLinkRevoked, EventMaintenanceUpdated, EventOperatorChanged,
+	EventDealPending, EventStartCancelled, EventCountdownUpdate, EventStartConfirmed, EventGamePlaying, EventGameEnded, EventGameRestarted,
+	EventRoleRevealed, EventFaceStateChanged, EventModalDismissed, EventModalRestored,
+	EventPlayerEliminated, EventPhaseChanged, EventVoteOpened, EventVoteCast, EventVoteClosed,
+	EventCoupInquisitionCalled, EventCoupInquisitionResolved, EventCoupWinPromptRejected,
+	EventAbilityTriggered, EventAbilityUpdated, EventAbilityConfirmed, EventAbilityResolved,
+	EventMetamorphActivated, EventMetamorphEnded, EventRoleStolen,
+	EventPuppetMasterRedistribution, EventTransformationComplete,
+)
+
 // StreamLobbyEnhanced streams lobby updates with heartbeat and reconnection support
 func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Request) {
 	roomCode := chi.URLParam(r, "code")
@@ -217,30 +333,37 @@ func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Req
 		log.Printf("SSE: Unsubscribed from room %s events", roomCode)
 	}()
 
-	// Send any missed events
-	if lastEventID != "" {
-		missedEvents := h.eventStore.GetEventsSince(roomCode, lastEventID)
-		for _, event := range missedEvents {
-			log.Printf("SSE: Replaying event %s for room %s", event.ID, roomCode)
-			// For now, skip replaying stored events as datastar API doesn't support Event method
-			_ = event
+	// Send initial render. Stored events only mark re-renders, so the current
+	// render covers any a reconnecting client missed; when its Last-Event-ID
+	// has aged out, replay can't bridge the gap and it gets a full resync.
+	eventID := h.generateEventID()
+	renderKind := "initial_render"
+	if missed, ok := h.eventStore.Replay(roomCode, lastEventID); !ok {
+		log.Printf("SSE: Last-Event-ID %s for room %s is too old to replay, sending a full resync", lastEventID, roomCode)
+		renderKind = "resync"
+		viewRoom(room, func() bool {
+			if err := h.resyncRenderer().Patch(sse, PageLobby, room, player, eventID); err != nil {
+				log.Printf("SSE: Resync failed for room %s: %v", roomCode, err)
+			}
+			return false
+		})
+	} else {
+		if len(missed) > 0 {
+			log.Printf("SSE: Client missed %d event(s) for room %s, re-rendering", len(missed), roomCode)
 		}
+		h.renderLobbyWithID(sse, room, player, eventID)
 	}
 
-	// Send initial render
-	eventID := h.generateEventID()
-	h.renderLobbyWithID(sse, room, player, eventID)
-
 	// Store the initial render event
 	h.eventStore.AddEvent(roomCode, SSEEvent{
 		ID:        eventID,
 		Type:      "lobby_update",
-		Data:      "initial_render",
-		Timestamp: time.Now(),
+		Data:      renderKind,
+		Timestamp: h.clock.Now(),
 	})
 
 	// Start heartbeat ticker
-	heartbeatTicker := time.NewTicker(30 * time.Second)
+	heartbeatTicker := h.clock.NewTicker(30 * time.Second)
 	defer heartbeatTicker.Stop()
 
 	log.Printf("SSE: Started streaming lobby for room %s, player %s", roomCode, player.ID)
@@ -256,10 +379,10 @@ func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Req
 			log.Printf("SSE: Done channel closed for room %s", roomCode)
 			return
 
-		case <-heartbeatTicker.C:
+		case <-heartbeatTicker.C():
 			// Send heartbeat as a script execution
 			heartbeatScript := fmt.Sprintf(`console.log('Heartbeat: %s, connections: %d');`,
-				time.Now().Format(time.RFC3339),
+				h.clock.Now().Format(time.RFC3339),
 				h.connTracker.GetConnectionCount(roomCode))
 			sse.ExecuteScript(heartbeatScript)
 
@@ -271,14 +394,14 @@ func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Req
 				ID:        eventID,
 				Type:      "heartbeat",
 				Data:      "ping",
-				Timestamp: time.Now(),
+				Timestamp: h.clock.Now(),
 			})
 
 			log.Printf("SSE: Sent heartbeat for room %s", roomCode)
 
 		case event := <-events:
 			switch event.Type {
-			case "player_joined", "player_left":
+			case EventPlayerJoined, EventPlayerLeft, EventPlayerRemoved:
 				// Re-render lobby
 				room, _ = h.store.GetRoom(roomCode)
 				eventID := h.generateEventID()
@@ -288,11 +411,11 @@ func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Req
 				h.eventStore.AddEvent(roomCode, SSEEvent{
 					ID:        eventID,
 					Type:      "lobby_update",
-					Data:      event.Type,
-					Timestamp: time.Now(),
+					Data:      string(event.Type),
+					Timestamp: h.clock.Now(),
 				})
 
-			case "game_started":
+			case EventGameStarted:
 				// Redirect to game page
 				eventID := h.generateEventID()
 				sse.ExecuteScript("window.location.href = '/game/" + roomCode + "'")
@@ -302,8 +425,14 @@ func (h *EnhancedHandler) StreamLobbyEnhanced(w http.ResponseWriter, r *http.Req
 					ID:        eventID,
 					Type:      "game_started",
 					Data:      "redirect",
-					Timestamp: time.Now(),
+					Timestamp: h.clock.Now(),
 				})
+
+			default:
+				if !enhancedLobbyIgnoredEvents[event.Type] {
+					log.Printf("SSE: Unknown event type %s for room %s", event.Type, roomCode)
+					h.recordUnknownEvent("lobby-enhanced", event.Type)
+				}
 			}
 		}
 	}
@@ -354,30 +483,37 @@ func (h *EnhancedHandler) StreamGameEnhanced(w http.ResponseWriter, r *http.Requ
 		log.Printf("SSE: Unsubscribed from room %s game events", roomCode)
 	}()
 
-	// Send any missed events
-	if lastEventID != "" {
-		missedEvents := h.eventStore.GetEventsSince(roomCode, lastEventID)
-		for _, event := range missedEvents {
-			log.Printf("SSE: Replaying game event %s for room %s", event.ID, roomCode)
-			// For now, skip replaying stored events as datastar API doesn't support Event method
-			_ = event
+	// Send initial render. Stored events only mark re-renders, so the current
+	// render covers any a reconnecting client missed; when its Last-Event-ID
+	// has aged out, replay can't bridge the gap and it gets a full resync.
+	eventID := h.generateEventID()
+	renderKind := "initial_render"
+	if missed, ok := h.eventStore.Replay(roomCode, lastEventID); !ok {
+		log.Printf("SSE: Last-Event-ID %s for room %s is too old to replay, sending a full game resync", lastEventID, roomCode)
+		renderKind = "resync"
+		viewRoom(room, func() bool {
+			if err := h.resyncRenderer().Patch(sse, PageGame, room, player, eventID); err != nil {
+				log.Printf("SSE: Resync failed for room %s: %v", roomCode, err)
+			}
+			return false
+		})
+	} else {
+		if len(missed) > 0 {
+			log.Printf("SSE: Client missed %d game event(s) for room %s, re-rendering", len(missed), roomCode)
 		}
+		h.renderGameWithID(sse, room, player, eventID)
 	}
 
-	// Send initial render
-	eventID := h.generateEventID()
-	h.renderGameWithID(sse, room, player, eventID)
-
 	// Store the initial render event
 	h.eventStore.AddEvent(roomCode, SSEEvent{
 		ID:        eventID,
 		Type:      "game_update",
-		Data:      "initial_render",
-		Timestamp: time.Now(),
+		Data:      renderKind,
+		Timestamp: h.clock.Now(),
 	})
 
 	// Start heartbeat ticker
-	heartbeatTicker := time.NewTicker(30 * time.Second)
+	heartbeatTicker := h.clock.NewTicker(30 * time.Second)
 	defer heartbeatTicker.Stop()
 
 	log.Printf("SSE: Started streaming game for room %s, player %s", roomCode, player.ID)
@@ -393,10 +529,10 @@ func (h *EnhancedHandler) StreamGameEnhanced(w http.ResponseWriter, r *http.Requ
 			log.Printf("SSE: Game done channel closed for room %s", roomCode)
 			return
 
-		case <-heartbeatTicker.C:
+		case <-heartbeatTicker.C():
 			// Send heartbeat as a script execution
 			heartbeatScript := fmt.Sprintf(`console.log('Game heartbeat: %s, connections: %d, state: %s');`,
-				time.Now().Format(time.RFC3339),
+				h.clock.Now().Format(time.RFC3339),
 				h.connTracker.GetConnectionCount(roomCode),
 				room.State)
 			sse.ExecuteScript(heartbeatScript)
@@ -409,7 +545,7 @@ func (h *EnhancedHandler) StreamGameEnhanced(w http.ResponseWriter, r *http.Requ
 				ID:        eventID,
 				Type:      "heartbeat",
 				Data:      "ping",
-				Timestamp: time.Now(),
+				Timestamp: h.clock.Now(),
 			})
 
 			log.Printf("SSE: Sent game heartbeat for room %s", roomCode)
@@ -427,7 +563,7 @@ func (h *EnhancedHandler) StreamGameEnhanced(w http.ResponseWriter, r *http.Requ
 				ID:        eventID,
 				Type:      "game_update",
 				Data:      "state_change",
-				Timestamp: time.Now(),
+				Timestamp: h.clock.Now(),
 			})
 		}
 	}
@@ -435,14 +571,13 @@ func (h *EnhancedHandler) StreamGameEnhanced(w http.ResponseWriter, r *http.Requ
 
 // renderLobbyWithID renders the lobby body with an event ID
 func (h *EnhancedHandler) renderLobbyWithID(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player, eventID string) {
-	component := pages.LobbyBody(room, player, h.config, h.cardService)
+	component := pages.LobbyBody(room, player, h.roomConfig(room), h.cardService)
 
 	// Render to string
-	html := renderToString(component)
+	html := renderFragment(component, "#lobby-container", room.Code)
 
 	// Send as fragment with morph mode and explicit selector
-	sse.PatchElements(html,
-		datastar.WithSelector("#lobby-container"))
+	h.patchElements(sse, PageLobby, html, "#lobby-container", datastar.WithPatchElementsEventID(eventID))
 }
 
 // renderGameWithID renders the game body with an event ID
@@ -450,9 +585,8 @@ func (h *EnhancedHandler) renderGameWithID(sse *datastar.ServerSentEventGenerato
 	component := pages.GameBody(room, player)
 
 	// Render to string
-	html := renderToString(component)
+	html := renderFragment(component, "#game-container", room.Code)
 
 	// Send as fragment with morph mode and explicit selector
-	sse.PatchElements(html,
-		datastar.WithSelector("#game-container"))
+	h.patchElements(sse, PageGame, html, "#game-container", datastar.WithPatchElementsEventID(eventID))
 }
@@ -24,7 +24,7 @@ func TestSSEConnectionPersistence(t *testing.T) {
 
 	// Create handler and server
 	h := newTestHandler()
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -135,7 +135,7 @@ func TestSSEConnectionSurvives70Seconds(t *testing.T) {
 	skipIfNoBrowser(t)
 
 	h := newTestHandler()
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -217,7 +217,7 @@ func TestNoTargetsFoundError(t *testing.T) {
 	skipIfNoBrowser(t)
 
 	h := newTestHandler()
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -297,7 +297,7 @@ func TestNoSSEConnectionExhaustionDuringCountdown(t *testing.T) {
 
 	// Create handler and server
 	h := newTestHandler()
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -452,7 +452,7 @@ func TestPlayerReconnectionDuringCountdown(t *testing.T) {
 	skipIfNoBrowser(t)
 
 	h := newTestHandler()
-	router := setupTestRouter(h)
+	router := newTestRouter(h)
 	ts := httptest.NewServer(router)
 	defer ts.Close()
 
@@ -302,7 +302,7 @@ func TestStreamHostPlayerUpdates(t *testing.T) {
 
 	// Publish player joined event
 	h.eventBus.Publish(Event{
-		Type:     "player_joined",
+		Type:     EventPlayerJoined,
 		RoomCode: room.Code,
 		Data:     room,
 	})
@@ -369,7 +369,7 @@ func TestStreamHostCoupRevealUpdatesDashboard(t *testing.T) {
 	target.FaceUp = true
 	gameStore.UpdateRoom(room)
 	h.eventBus.Publish(Event{
-		Type:     "role_revealed",
+		Type:     EventRoleRevealed,
 		RoomCode: room.Code,
 		Data:     room,
 	})
@@ -426,7 +426,7 @@ func TestStreamHostCoupConfigUpdatesDashboard(t *testing.T) {
 	room.CoupRoleCounts = counts
 	gameStore.UpdateRoom(room)
 	h.eventBus.Publish(Event{
-		Type:     "coup_config_updated",
+		Type:     EventCoupConfigUpdated,
 		RoomCode: room.Code,
 		Data:     room,
 	})
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+// sendQueueLimit is how many writes an SSE stream may have waiting for its
+// client. A stream past it is ended; the client reconnects and catches up by
+// replay or resync rather than the server holding ever more for it.
+const sendQueueLimit = 64
+
+var errSendQueueClosed = errors.New("SSE send queue is closed")
+
+// sendQueueMetrics are the send queues' counters for the Prometheus endpoint
+type sendQueueMetrics struct {
+	depth      atomic.Int64 // writes waiting across every stream
+	depthMax   atomic.Int64 // the most any one stream has had waiting
+	superseded atomic.Int64 // writes dropped for a newer patch of the same target
+	overflows  atomic.Int64 // streams ended for going past sendQueueLimit
+}
+
+// sendItem is one queued write: the response's status and headers, or one
+// SSE event as the datastar generator wrote it
+type sendItem struct {
+	header http.Header
+	status int
+	data   []byte
+	key    string // see supersedeKey
+}
+
+// sendQueue moves an SSE stream's writes off its event loop, so a slow
+// client or a slow write doesn't hold up the next event: the loop queues
+// each event and a writer goroutine sends them in order. An element patch
+// that replaces its target supersedes an unsent one for the same target, so
+// a client that falls behind gets the latest lobby render, not every one.
+type sendQueue struct {
+	w       http.ResponseWriter
+	header  http.Header
+	metrics *sendQueueMetrics
+	abort   func() // ends the stream, on overflow or a failed write
+
+	mu      sync.Mutex
+	items   []sendItem
+	started bool // status and headers are queued
+	closed  bool
+
+	wake chan struct{}
+	done chan struct{}
+}
+
+// withSendQueue wraps w in a send queue and starts its writer. abort ends
+// the stream; the returned func stops taking writes and waits for the queued
+// ones to be sent.
+func (h *Handler) withSendQueue(w http.ResponseWriter, abort func()) (http.ResponseWriter, func()) {
+	q := &sendQueue{
+		w:       w,
+		header:  w.Header().Clone(),
+		metrics: &h.sendQueues,
+		abort:   abort,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q, q.close
+}
+
+// Header is the response's headers until the first write queues them
+func (q *sendQueue) Header() http.Header {
+	return q.header
+}
+
+func (q *sendQueue) WriteHeader(status int) {
+	q.mu.Lock()
+	q.start(status)
+	q.mu.Unlock()
+	q.signal()
+}
+
+// Write queues p, which the datastar generator writes one whole event at a
+// time. It fails once the queue is closed or has overflowed.
+func (q *sendQueue) Write(p []byte) (int, error) {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return 0, errSendQueueClosed
+	}
+	q.start(http.StatusOK)
+
+	item := sendItem{data: bytes.Clone(p), key: supersedeKey(p)}
+	if item.key != "" {
+		for i, queued := range q.items {
+			if queued.key == item.key {
+				q.items = append(q.items[:i], q.items[i+1:]...)
+				q.metrics.depth.Add(-1)
+				q.metrics.superseded.Add(1)
+				break
+			}
+		}
+	}
+	if len(q.items) >= sendQueueLimit {
+		q.overflowLocked()
+		q.mu.Unlock()
+		q.abort()
+		return 0, errSendQueueClosed
+	}
+	q.items = append(q.items, item)
+	q.metrics.depth.Add(1)
+	raiseMax(&q.metrics.depthMax, int64(len(q.items)))
+	q.mu.Unlock()
+
+	q.signal()
+	return len(p), nil
+}
+
+// Flush sends the headers if nothing else has; the writer flushes after
+// every batch it writes
+func (q *sendQueue) Flush() {
+	q.mu.Lock()
+	if !q.closed {
+		q.start(http.StatusOK)
+	}
+	q.mu.Unlock()
+	q.signal()
+}
+
+// start queues the status and headers ahead of the first write. The caller
+// holds q.mu.
+func (q *sendQueue) start(status int) {
+	if q.started {
+		return
+	}
+	q.started = true
+	q.items = append(q.items, sendItem{header: q.header.Clone(), status: status})
+	q.metrics.depth.Add(1)
+}
+
+// overflowLocked drops everything queued and closes the queue. The caller
+// holds q.mu.
+func (q *sendQueue) overflowLocked() {
+	q.metrics.depth.Add(-int64(len(q.items)))
+	q.items = nil
+	q.closed = true
+	q.metrics.overflows.Add(1)
+	log.Printf("🐌 SSE client fell %d writes behind, ending its stream", sendQueueLimit)
+}
+
+func (q *sendQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run writes queued items in order until the queue is closed and empty. A
+// failed write ends the stream and discards the rest.
+func (q *sendQueue) run() {
+	defer close(q.done)
+	flusher, _ := q.w.(http.Flusher)
+	failed := false
+	for {
+		q.mu.Lock()
+		items := q.items
+		q.items = nil
+		closed := q.closed
+		q.mu.Unlock()
+		q.metrics.depth.Add(-int64(len(items)))
+
+		for _, item := range items {
+			if failed {
+				break
+			}
+			if item.header != nil {
+				dst := q.w.Header()
+				for key, values := range item.header {
+					dst[key] = values
+				}
+				q.w.WriteHeader(item.status)
+				continue
+			}
+			if _, err := q.w.Write(item.data); err != nil {
+				failed = true
+				q.abort()
+			}
+		}
+		if len(items) > 0 && !failed && flusher != nil {
+			flusher.Flush()
+		}
+
+		if closed && len(items) == 0 {
+			return
+		}
+		if len(items) == 0 {
+			<-q.wake
+		}
+	}
+}
+
+// close stops taking writes and waits until the queued ones are written
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+	<-q.done
+}
+
+// raiseMax sets max to n if n is larger
+func raiseMax(max *atomic.Int64, n int64) {
+	for {
+		current := max.Load()
+		if n <= current || max.CompareAndSwap(current, n) {
+			return
+		}
+	}
+}
+
+var (
+	patchElementsLine = []byte("event: " + string(datastar.EventTypePatchElements) + "\n")
+	selectorLine      = []byte("data: " + datastar.SelectorDatalineLiteral)
+	modeLine          = []byte("data: " + datastar.ModeDatalineLiteral)
+	elementsLine      = []byte("data: " + datastar.ElementsDatalineLiteral)
+)
+
+// supersedeKey is the key under which an SSE event replaces an earlier,
+// unsent one: element patches that replace their target, by mode and
+// selector. Anything else is always sent. A superseded patch's event ID goes
+// with it, which is safe: the newer one's ID is later in the journal and its
+// render already shows what the dropped one did.
+func supersedeKey(event []byte) string {
+	rest, ok := bytes.CutPrefix(event, patchElementsLine)
+	if !ok {
+		return ""
+	}
+	selector, mode := "", string(datastar.ElementPatchModeOuter)
+	for len(rest) > 0 {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, []byte("\n"))
+		switch {
+		case len(line) == 0 || bytes.HasPrefix(line, elementsLine):
+			rest = nil
+		case bytes.HasPrefix(line, selectorLine):
+			selector = string(line[len(selectorLine):])
+		case bytes.HasPrefix(line, modeLine):
+			mode = string(line[len(modeLine):])
+		}
+	}
+	if selector == "" {
+		return ""
+	}
+	switch datastar.ElementPatchMode(mode) {
+	case datastar.ElementPatchModeOuter, datastar.ElementPatchModeInner, datastar.ElementPatchModeReplace:
+		return mode + " " + selector
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+// gatedWriter holds every write until its gate opens, like a client that has
+// stopped reading for a while
+type gatedWriter struct {
+	*httptest.ResponseRecorder
+	gate chan struct{}
+	mu   sync.Mutex
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.ResponseRecorder.Write(p)
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{ResponseRecorder: httptest.NewRecorder(), gate: make(chan struct{})}
+}
+
+// waitForQueueDepth waits until the writer has taken all but depth writes
+func waitForQueueDepth(t *testing.T, h *Handler, depth int64) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for h.sendQueues.depth.Load() != depth {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d writes queued, got %d", depth, h.sendQueues.depth.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSendQueueWritesInOrder(t *testing.T) {
+	h := newTestHandler()
+	rec := httptest.NewRecorder()
+	w, closeQueue := h.withSendQueue(rec, func() {})
+
+	sse := datastar.NewSSE(w, httptest.NewRequest("GET", "/sse", nil))
+	sse.PatchSignals([]byte(`{"a":1}`))
+	sse.ExecuteScript("console.log(1)")
+	closeQueue()
+
+	body := rec.Body.String()
+	if rec.Header().Get("Content-Type") != "text/event-stream" {
+		t.Errorf("expected the stream's headers to be sent, got %v", rec.Header())
+	}
+	signals, script := strings.Index(body, "datastar-patch-signals"), strings.Index(body, "console.log(1)")
+	if signals < 0 || script < signals {
+		t.Errorf("expected both events in order, got %q", body)
+	}
+	if got := h.sendQueues.depth.Load(); got != 0 {
+		t.Errorf("expected nothing left queued, got %d", got)
+	}
+}
+
+func TestSendQueueKeepsOnlyTheLatestPatchOfATarget(t *testing.T) {
+	h := newTestHandler()
+	gw := newGatedWriter()
+	w, closeQueue := h.withSendQueue(gw, func() {})
+
+	sse := datastar.NewSSE(w, httptest.NewRequest("GET", "/sse", nil))
+	// The writer takes the headers and blocks on the first event; the rest
+	// queue up behind it
+	sse.PatchElements(`<div id="lobby">first</div>`, datastar.WithSelectorID("lobby"))
+	waitForQueueDepth(t, h, 0)
+	for _, render := range []string{"second", "third", "fourth"} {
+		sse.PatchElements(`<div id="lobby">`+render+`</div>`, datastar.WithSelectorID("lobby"))
+		sse.PatchElements(`<div id="log">`+render+`</div>`, datastar.WithSelectorID("log"), datastar.WithModeAppend())
+	}
+	sse.PatchSignals([]byte(`{"done":true}`))
+	close(gw.gate)
+	closeQueue()
+
+	body := gw.Body.String()
+	for _, want := range []string{"first", "fourth"} {
+		if !strings.Contains(body, `<div id="lobby">`+want) {
+			t.Errorf("expected the %s lobby render to be sent, got %q", want, body)
+		}
+	}
+	for _, dropped := range []string{"second", "third"} {
+		if strings.Contains(body, `<div id="lobby">`+dropped) {
+			t.Errorf("expected the %s lobby render to be superseded", dropped)
+		}
+		if !strings.Contains(body, `<div id="log">`+dropped) {
+			t.Errorf("expected every appended %s log line to be kept", dropped)
+		}
+	}
+	if got := h.sendQueues.superseded.Load(); got < 1 {
+		t.Errorf("expected superseded renders counted, got %d", got)
+	}
+}
+
+func TestSendQueueOverflowEndsTheStream(t *testing.T) {
+	h := newTestHandler()
+	gw := newGatedWriter()
+	aborted := make(chan struct{})
+	var once sync.Once
+	w, closeQueue := h.withSendQueue(gw, func() { once.Do(func() { close(aborted) }) })
+
+	sse := datastar.NewSSE(w, httptest.NewRequest("GET", "/sse", nil))
+	for i := 0; i <= sendQueueLimit+1; i++ {
+		sse.ExecuteScript("console.log(1)")
+	}
+
+	select {
+	case <-aborted:
+	default:
+		t.Fatal("expected a client that fell too far behind to have its stream ended")
+	}
+	if _, err := w.Write([]byte("more")); err != errSendQueueClosed {
+		t.Errorf("expected writes after the overflow to fail, got %v", err)
+	}
+	if got := h.sendQueues.overflows.Load(); got != 1 {
+		t.Errorf("expected one overflow counted, got %d", got)
+	}
+	close(gw.gate)
+	closeQueue()
+	if got := h.sendQueues.depth.Load(); got != 0 {
+		t.Errorf("expected the dropped writes uncounted, got %d", got)
+	}
+}
+
+func TestSupersedeKey(t *testing.T) {
+	cases := map[string]struct {
+		event string
+		want  string
+	}{
+		"outer by default": {"event: datastar-patch-elements\ndata: selector #lobby\ndata: elements <div></div>\n\n", "outer #lobby"},
+		"inner":            {"event: datastar-patch-elements\ndata: selector #lobby\ndata: mode inner\ndata: elements <div></div>\n\n", "inner #lobby"},
+		"append":           {"event: datastar-patch-elements\ndata: selector #log\ndata: mode append\ndata: elements <div></div>\n\n", ""},
+		"no selector":      {"event: datastar-patch-elements\ndata: elements <div id=\"lobby\"></div>\n\n", ""},
+		"with an ID":       {"event: datastar-patch-elements\nid: 7\ndata: selector #lobby\ndata: elements <div></div>\n\n", "outer #lobby"},
+		"signals":          {"event: datastar-patch-signals\ndata: signals {}\n\n", ""},
+		"in the elements":  {"event: datastar-patch-elements\ndata: elements <div></div>\ndata: elements data: selector #x\n\n", ""},
+	}
+	for name, tc := range cases {
+		if got := supersedeKey([]byte(tc.event)); got != tc.want {
+			t.Errorf("%s: expected key %q, got %q", name, tc.want, got)
+		}
+	}
+}
+
+func TestSendQueueIsAFlusher(t *testing.T) {
+	h := newTestHandler()
+	w, closeQueue := h.withSendQueue(httptest.NewRecorder(), func() {})
+	defer closeQueue()
+	if _, ok := w.(http.Flusher); !ok {
+		t.Error("expected the queue to take flushes")
+	}
+}
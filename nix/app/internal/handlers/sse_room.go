@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"treacherest/internal/game"
+	"treacherest/internal/views/components"
+	"treacherest/internal/views/pages"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/starfederation/datastar-go/datastar"
+)
+
+// StreamRoom is the one stream a page holds for a room, whatever its state.
+// The Operator Dashboard (?view=host) gets host-scoped events for the whole
+// game. Players get lobby-scoped events until the game starts, then the game
+// body is swapped in and game-scoped events follow on the same connection,
+// so starting a game no longer closes the stream and reopens it from a new
+// page. A lobby page says so (?view=lobby), so a stream that reconnects after
+// the start still swaps its body, and a game page says so (?view=game), so a
+// countdown cancelled for a re-deal keeps it on the game page.
+func (h *Handler) StreamRoom(w http.ResponseWriter, r *http.Request) {
+	switch view := r.URL.Query().Get("view"); view {
+	case "host":
+		h.StreamHost(w, r)
+	default:
+		h.streamPlayer(w, r, view)
+	}
+}
+
+// streamPlayer streams a player page through lobby and game on one
+// subscription, so nothing published while the lobby hands over to the game
+// is missed. view is the page's ?view, "lobby" for a page still showing the
+// lobby and "game" for a game page.
+func (h *Handler) streamPlayer(w http.ResponseWriter, r *http.Request, view string) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	events := h.eventBus.Subscribe(roomCode)
+	defer h.eventBus.Unsubscribe(roomCode, events)
+	defer h.trackPresence(r, roomCode)()
+
+	// One tab streams per player: this one ends any older tab's stream, and
+	// is ended in turn by the next
+	page := PageGame
+	if cookie, err := r.Cookie("player_" + roomCode); err == nil {
+		tab := h.tabs.claim(roomCode, cookie.Value)
+		defer h.tabs.release(tab)
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			select {
+			case <-tab.superseded:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		client := r
+		r = r.WithContext(ctx)
+		defer func() {
+			if client.Context().Err() == nil && tab.wasSuperseded() && w.Header().Get("Content-Type") == "text/event-stream" {
+				log.Printf("📡 Player %s opened room %s in another tab, closing this one's stream", cookie.Value, roomCode)
+				h.sendConnectionLost(datastar.NewSSE(w, client), page, roomCode, components.ConnectionLostOpenElsewhere)
+			}
+		}()
+	}
+
+	// A browser reconnecting after a blip is sent what it missed first
+	feed, resync := h.replayFeed(r.Context(), r, roomCode, events)
+
+	for {
+		room.RLock()
+		inLobby := room.State == game.StateLobby
+		room.RUnlock()
+
+		// A game page whose countdown was cancelled waits for the new deal on
+		// game events rather than following the lobby
+		lobbyPage := view == "lobby"
+		if inLobby && view != "game" {
+			page = PageLobby
+			if !h.streamLobby(w, r, feed, resync) {
+				return
+			}
+			page = PageGame
+			lobbyPage = true
+		}
+		resync = false
+		if !h.streamGame(w, r, feed, lobbyPage) {
+			return
+		}
+		// The game was restarted and its body swapped for the lobby's: follow
+		// the lobby again until the next start
+		view = "lobby"
+	}
+}
+
+// swapInGameBody replaces a lobby page's body with the game body and moves
+// the address to /game, so a reload lands on the game page. The caller holds
+// the room's read lock.
+func (h *Handler) swapInGameBody(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) {
+	log.Printf("🎮 Game started - swapping the game body into a lobby page for room %s", room.Code)
+	html := renderFragment(pages.GameBodyContent(room, player), "#page-body", room.Code)
+	h.patchElements(sse, PageLobby, html, "#page-body", datastar.WithModeInner())
+	sse.ExecuteScript(fmt.Sprintf("history.replaceState(null, '', '/game/%s'); document.title = document.title.replace('Lobby', 'Game')", room.Code))
+}
+
+// swapInLobbyBody replaces a game page's body with the lobby body and moves
+// the address back to the room, for a game the Room Operator restarted. The
+// caller holds the room's read lock.
+func (h *Handler) swapInLobbyBody(sse *datastar.ServerSentEventGenerator, room *game.Room, player *game.Player) {
+	log.Printf("🔁 Game restarted - swapping the lobby body into a game page for room %s", room.Code)
+	html := renderFragment(pages.LobbyBodyContent(room, player, h.roomConfig(room), h.cardService), "#page-body", room.Code)
+	h.patchElements(sse, PageGame, html, "#page-body", datastar.WithModeInner())
+	sse.ExecuteScript(fmt.Sprintf("history.replaceState(null, '', '/room/%s'); document.title = document.title.replace('Game', 'Lobby')", room.Code))
+}
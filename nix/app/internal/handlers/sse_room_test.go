@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+)
+
+func TestRoomStreamCarriesLobbyIntoGame(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	stream := player.OpenSSE("/sse/room/" + roomCode)
+	defer stream.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	testkit.JoinRoom(t, router, roomCode, "Carol")
+	if !stream.WaitFor("Carol", 2*time.Second) {
+		t.Fatalf("expected lobby updates on the room stream, got %s", stream.Data())
+	}
+
+	operator.StartGame()
+	if !stream.WaitFor("history.replaceState", 2*time.Second) {
+		t.Fatalf("expected the game body to be swapped in, got %s", stream.Data())
+	}
+	data := stream.Data()
+	if strings.Contains(data, "window.location.href") {
+		t.Error("the room stream should not send the page away to start the game")
+	}
+	swapped := strings.Index(data, "history.replaceState")
+	if !strings.Contains(data[:swapped], `id="game-container"`) {
+		t.Error("expected the game body to arrive before the address changes")
+	}
+
+	// Game updates follow on the same connection
+	if !stream.WaitFor(`"countdown"`, 2*time.Second) || stream.Closed() {
+		t.Fatalf("expected the stream to stay open for game updates, got %s", stream.Data())
+	}
+}
+
+func TestRoomStreamServesOperatorDashboard(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+
+	stream := operator.OpenSSE("/sse/room/" + roomCode + "?view=host")
+	defer stream.Close()
+	if !stream.WaitFor("host-dashboard-container", 2*time.Second) {
+		t.Fatalf("expected the Operator Dashboard render, got %s", stream.Data())
+	}
+
+	denied := player.OpenSSE("/sse/room/" + roomCode + "?view=host")
+	<-denied.Done()
+	if denied.Status() != http.StatusUnauthorized {
+		t.Errorf("expected a player to be refused the dashboard stream, got %d", denied.Status())
+	}
+
+	bad := player.OpenSSE("/sse/room/" + roomCode + "?view=admin")
+	<-bad.Done()
+	if bad.Status() != http.StatusBadRequest {
+		t.Errorf("expected an unknown view to be refused, got %d", bad.Status())
+	}
+}
+
+func TestLobbyPageStartsInPlace(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Alice", false)
+	roomCode := operator.RoomCode
+	player := testkit.JoinRoom(t, router, roomCode, "Bob")
+	testkit.JoinRoom(t, router, roomCode, "Carol")
+
+	resp := operator.Post("/room/"+roomCode+"/start?view=lobby", nil)
+	if resp.Code != http.StatusNoContent || strings.Contains(resp.Body.String(), "window.location.href") {
+		t.Fatalf("expected a lobby page's start to leave the move to its stream, got %d %s", resp.Code, resp.Body.String())
+	}
+
+	// A lobby page whose stream reconnects after the start still gets the
+	// game body rather than game updates for elements it doesn't have
+	stream := player.OpenSSE("/sse/room/" + roomCode + "?view=lobby")
+	defer stream.Close()
+	if !stream.WaitFor("history.replaceState", 2*time.Second) {
+		t.Fatalf("expected the game body to be swapped in, got %s", stream.Data())
+	}
+	if !strings.Contains(stream.Data(), `id="game-container"`) || stream.Closed() {
+		t.Errorf("expected the game body on an open stream, got %s", stream.Data())
+	}
+}
@@ -245,24 +245,72 @@ func TestHandler_StreamLobby(t *testing.T) {
 
 		// Send game_started event
 		h.eventBus.Publish(Event{
-			Type:     "game_started",
+			Type:     EventGameStarted,
 			RoomCode: room.Code,
 		})
 
 		// Wait for context to timeout
 		<-ctx.Done()
 
-		// Check if redirect script was sent
+		// Check the game body was swapped in place of a redirect
 		body := w.Body.String()
-		if !strings.Contains(body, "window.location.href") {
-			t.Error("expected redirect script in response")
+		if strings.Contains(body, "window.location.href") {
+			t.Error("expected no redirect script in response")
 		}
-		if !strings.Contains(body, "/game/"+room.Code) {
-			t.Error("expected redirect to game page")
+		if !strings.Contains(body, "history.replaceState(null, '', '/game/"+room.Code+"')") {
+			t.Error("expected the address to move to the game page")
 		}
 	})
 }
 
+func TestStreamLobbyHidesRoleCountsFromPlayers(t *testing.T) {
+	h := newTestHandler()
+
+	room, _ := h.store.CreateRoom()
+	room.RoleConfig.PresetName = "custom"
+	room.RoleConfig.HideRoleDistribution = true
+	for roleType, typeConfig := range room.RoleConfig.RoleTypes {
+		typeConfig.Count = 0
+		if roleType == "Leader" {
+			typeConfig.Count = 1
+		}
+	}
+	operator := game.NewPlayer("op", "Operator", "session-op")
+	player := game.NewPlayer("p1", "Player 1", "session-p1")
+	room.AddPlayer(operator)
+	room.AddPlayer(player)
+	room.OperatorSessionID = operator.SessionID
+	h.store.UpdateRoom(room)
+
+	stream := func(p *game.Player) string {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req := httptest.NewRequest("GET", "/sse/lobby/"+room.Code, nil)
+		req.AddCookie(&http.Cookie{Name: "player_" + room.Code, Value: p.ID})
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: p.SessionID})
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("code", room.Code)
+		req = req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		h.StreamLobby(w, req)
+		return w.Body.String()
+	}
+
+	body := stream(player)
+	if !strings.Contains(body, "finish role setup") {
+		t.Fatalf("expected a neutral validation message for a player, got %q", body)
+	}
+	for _, leak := range []string{"Not enough roles", `"requiredRoles":2`, `"configuredRoles":1`} {
+		if strings.Contains(body, leak) {
+			t.Errorf("expected %q to stay hidden from a player, got %q", leak, body)
+		}
+	}
+
+	if body := stream(operator); !strings.Contains(body, "Not enough roles configured (1) for 2 players") {
+		t.Errorf("expected the Room Operator to keep the full validation message, got %q", body)
+	}
+}
+
 func TestHandler_StreamGame(t *testing.T) {
 	t.Run("streams game updates successfully", func(t *testing.T) {
 		h := newTestHandler()
@@ -366,7 +414,7 @@ func TestHandler_StreamGame(t *testing.T) {
 
 		// Send an event to trigger update
 		h.eventBus.Publish(Event{
-			Type:     "role_revealed",
+			Type:     EventRoleRevealed,
 			RoomCode: room.Code,
 			Data:     map[string]interface{}{"playerID": "p2"},
 		})
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"treacherest/internal/game"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxStartConfirmTimeout caps the confirm start fallback so one absent player
+// can't hold the table in the countdown state for long
+const maxStartConfirmTimeout = 10 * time.Minute
+
+// countdownSeconds is how long the countdown start ritual runs
+const countdownSeconds = game.CountdownSeconds
+
+// UpdateStartRitual picks the fixed countdown or the tap-to-confirm start before the game starts
+func (h *Handler) UpdateStartRitual(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if rejectPreStartSettingsMutationIfLocked(w, room) {
+		return
+	}
+
+	settings := game.StartRitualSettings{
+		Ritual:      game.StartRitualCountdown,
+		ApproveDeal: r.FormValue("approveDeal") == "true" || r.FormValue("approveDeal") == "on",
+	}
+	if r.FormValue("confirm") == "true" || r.FormValue("confirm") == "on" {
+		settings.Ritual = game.StartRitualConfirm
+	}
+	if raw := r.FormValue("timeoutSeconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			http.Error(w, "Invalid confirmation timeout", http.StatusBadRequest)
+			return
+		}
+		settings.Timeout = time.Duration(seconds) * time.Second
+		if settings.Timeout > maxStartConfirmTimeout {
+			settings.Timeout = maxStartConfirmTimeout
+		}
+	}
+
+	room.StartRitual = settings
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventStartRitualUpdated,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ConfirmStart records that a player has seen their role; the game starts
+// playing once every player has confirmed
+func (h *Handler) ConfirmStart(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+
+	allConfirmed, err := room.ConfirmStart(player.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventStartConfirmed,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	if allConfirmed {
+		log.Printf("✅ Every player confirmed the start in room %s", room.Code)
+		h.beginPlaying(room, h.requestActor(r, room))
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// CancelStart deals again during the countdown: the room goes back to the
+// lobby with its roles wiped, for the Room Operator to fix the setup and
+// start again. Players stay where they are; their pages wait for the deal.
+func (h *Handler) CancelStart(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if err := room.CancelStart(); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	actor := h.requestActor(r, room)
+	h.eventBus.Publish(Event{
+		Type:     EventStartCancelled,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    actor,
+	})
+	log.Printf("🔁 %s cancelled the countdown in room %s to deal again", actor, room.Code)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// beginStart moves a room whose roles are assigned into the countdown state
+// and runs its start ritual. The caller holds the room's lock.
+func (h *Handler) beginStart(room *game.Room, actor EventActor) {
+	room.State = game.StateCountdown
+	room.StartedAt = h.clock.Now()
+	room.StartedBy = actor.Name
+	h.record(room, game.GameStartedEvent(room))
+
+	if room.StartRitual.Ritual != game.StartRitualConfirm {
+		room.CountdownRemaining = countdownSeconds
+		room.RevealAt = room.StartedAt.Add(countdownSeconds*time.Second + revealSyncLead)
+		h.store.UpdateRoom(room)
+		go h.runCountdown(room, room.StartedAt)
+		return
+	}
+
+	room.CountdownRemaining = 0
+	// Confirming players already see their roles, so nothing is held back
+	room.RevealAt = time.Time{}
+	room.BeginStartConfirmations()
+	h.store.UpdateRoom(room)
+
+	startedAt := room.StartedAt
+	h.clock.AfterFunc(room.StartRitual.ConfirmTimeout(), func() {
+		room.Lock()
+		defer room.Unlock()
+		// A later game in the same room has its own timer
+		if !room.AwaitingStartConfirmations() || !room.StartedAt.Equal(startedAt) {
+			return
+		}
+		log.Printf("⏰ Start confirmation timed out in room %s, starting anyway", room.Code)
+		h.beginPlaying(room, EventActor{})
+	})
+}
+
+// beginPlaying ends the countdown state and reveals roles. The caller holds
+// the room's lock; actor is zero when a timer ended the countdown.
+func (h *Handler) beginPlaying(room *game.Room, actor EventActor) {
+	room.State = game.StatePlaying
+	room.CountdownRemaining = 0
+	room.LeaderRevealed = true
+	h.store.UpdateRoom(room)
+	h.startPhases(room)
+	h.scheduleRevealEnd(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventGamePlaying,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    actor,
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/testkit"
+)
+
+func postStartConfirm(router http.Handler, room *game.Room, player *game.Player) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/room/"+room.Code+"/start/confirm", nil)
+	req.AddCookie(&http.Cookie{Name: "player_" + room.Code, Value: player.ID})
+	req.AddCookie(&http.Cookie{Name: "session", Value: player.SessionID})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUpdateStartRitual(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+	path := "/room/" + room.Code + "/config/start-ritual"
+
+	if w := postPhaseForm(router, path, "s1", url.Values{"confirm": {"true"}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected non-operator to be rejected, got %d", w.Code)
+	}
+
+	w := postPhaseForm(router, path, "operator-session", url.Values{"confirm": {"true"}, "timeoutSeconds": {"45"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.StartRitual.Ritual != game.StartRitualConfirm || room.StartRitual.ConfirmTimeout() != 45*time.Second {
+		t.Fatalf("unexpected settings %+v", room.StartRitual)
+	}
+
+	room.State = game.StateCountdown
+	if w := postPhaseForm(router, path, "operator-session", url.Values{}); w.Code != http.StatusConflict {
+		t.Fatalf("expected settings to lock after start, got %d", w.Code)
+	}
+}
+
+func TestConfirmStart_PlaysOnceEveryoneConfirms(t *testing.T) {
+	h := newTestHandler()
+	withFakeClock(h)
+	router := newTestRouter(h)
+	room, alice := newPhaseTestRoom(t, h)
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	bob.Role = mockGuardianCard()
+	room.AddPlayer(bob)
+	room.StartRitual = game.StartRitualSettings{Ritual: game.StartRitualConfirm}
+
+	if w := postStartConfirm(router, room, alice); w.Code != http.StatusConflict {
+		t.Fatalf("expected a confirmation outside the start to be refused, got %d", w.Code)
+	}
+
+	h.beginStart(room, EventActor{})
+	if room.State != game.StateCountdown || room.CountdownRemaining != 0 {
+		t.Fatalf("expected a confirm start to wait in the countdown state, got %s %d", room.State, room.CountdownRemaining)
+	}
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+
+	if w := postStartConfirm(router, room, alice); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if event := <-events; event.Type != "start_confirmed" {
+		t.Fatalf("expected a start_confirmed event for the host checklist, got %s", event.Type)
+	}
+	if room.State != game.StateCountdown {
+		t.Fatalf("expected the game to wait for Bob, got %s", room.State)
+	}
+	if confirmed, total := room.StartConfirmationProgress(); confirmed != 1 || total != 2 {
+		t.Fatalf("expected 1 of 2 confirmed, got %d of %d", confirmed, total)
+	}
+
+	postStartConfirm(router, room, bob)
+	if room.State != game.StatePlaying || !room.LeaderRevealed {
+		t.Fatalf("expected the last confirmation to start play, got %s", room.State)
+	}
+}
+
+func TestConfirmStart_TimeoutStartsAnyway(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	router := newTestRouter(h)
+	room, alice := newPhaseTestRoom(t, h)
+	room.AddPlayer(game.NewPlayer("p2", "Bob", "s2"))
+	room.StartRitual = game.StartRitualSettings{Ritual: game.StartRitualConfirm, Timeout: 30 * time.Second}
+
+	room.Lock()
+	h.beginStart(room, EventActor{})
+	room.Unlock()
+	postStartConfirm(router, room, alice)
+
+	fake.Advance(29 * time.Second)
+	if room.State != game.StateCountdown {
+		t.Fatalf("expected the start to keep waiting before the timeout, got %s", room.State)
+	}
+	fake.Advance(time.Second)
+	if room.State != game.StatePlaying {
+		t.Fatalf("expected the timeout to start play, got %s", room.State)
+	}
+}
+
+func TestCancelStartDealsAgainWithoutLeavingTheGamePage(t *testing.T) {
+	h := newTestHandler()
+	fake := withFakeClock(h)
+	router := newTestRouter(h)
+
+	operator := testkit.CreateRoom(t, router, "Operator", false)
+	alice := testkit.JoinRoom(t, router, operator.RoomCode, "Alice")
+	bob := testkit.JoinRoom(t, router, operator.RoomCode, "Bob")
+	room, _ := h.store.GetRoom(operator.RoomCode)
+
+	page := alice.OpenSSE("/sse/room/" + room.Code + "?view=lobby")
+	defer page.Close()
+	if w := operator.Post("/room/"+room.Code+"/start/cancel", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected no re-deal before the start, got %d", w.Code)
+	}
+	if w := operator.Post("/room/"+room.Code+"/start?view=lobby", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the game to start, got %d: %s", w.Code, w.Body.String())
+	}
+	if !page.WaitFor("Revealing roles in", 2*time.Second) {
+		t.Fatal("expected the countdown on Alice's page")
+	}
+	// A game page opened during the countdown stays a game page too; it's
+	// Bob's, as a second tab of Alice's would take her stream over
+	reloaded := bob.OpenSSE("/sse/room/" + room.Code + "?view=game")
+	defer reloaded.Close()
+	if !reloaded.WaitFor("game-container", 2*time.Second) {
+		t.Fatalf("expected the reloaded game page to render, got %s", reloaded.Data())
+	}
+
+	if w := alice.Post("/room/"+room.Code+"/start/cancel", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a player's re-deal to be refused, got %d", w.Code)
+	}
+	if w := operator.Post("/room/"+room.Code+"/start/cancel", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the re-deal to cancel the countdown, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.State != game.StateLobby || room.GetPlayer(alice.PlayerID()).Role != nil || !room.StartedAt.IsZero() {
+		t.Fatalf("expected the room back in the lobby with roles wiped, got %s", room.State)
+	}
+	for _, stream := range []*testkit.Stream{page, reloaded} {
+		if !stream.WaitFor("redeal-waiting", 2*time.Second) || stream.Closed() {
+			t.Fatalf("expected the game pages to wait for the new deal, got %s", stream.Data())
+		}
+	}
+
+	// The cancelled countdown doesn't carry on into the game
+	fake.Advance(countdownSeconds * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	room.RLock()
+	state := room.State
+	room.RUnlock()
+	if state != game.StateLobby {
+		t.Fatalf("expected the cancelled countdown to stop, got %s", state)
+	}
+
+	if w := operator.Post("/room/"+room.Code+"/start?view=lobby", nil); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the game to start again, got %d: %s", w.Code, w.Body.String())
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for strings.Count(page.Data(), "Revealing roles in") < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if strings.Count(page.Data(), "Revealing roles in") < 2 || page.Closed() {
+		t.Fatalf("expected the new countdown on the same page, got %s", page.Data())
+	}
+	if room.GetPlayer(alice.PlayerID()).Role == nil {
+		t.Fatal("expected a new deal")
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// tableTTL is how long a pass-the-phone table is kept; a deal is over in minutes
+	tableTTL = 12 * time.Hour
+
+	// maxTables bounds memory; the oldest table goes first
+	maxTables = 1000
+)
+
+// tableEntry is a pass-the-phone table and the session of the device it is on
+type tableEntry struct {
+	table     *game.Table
+	sessionID string
+}
+
+// tableRegistry holds the pass-the-phone tables. They never reach the room
+// store: no one joins them, nothing streams from them and they aren't backed up.
+type tableRegistry struct {
+	mu     sync.Mutex
+	tables map[string]tableEntry
+}
+
+func newTableRegistry() *tableRegistry {
+	return &tableRegistry{tables: make(map[string]tableEntry)}
+}
+
+// add keeps table for sessionID, dropping expired tables and, when full, the oldest
+func (tr *tableRegistry) add(table *game.Table, sessionID string, now time.Time) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var oldest string
+	for id, entry := range tr.tables {
+		if now.Sub(entry.table.CreatedAt) > tableTTL {
+			delete(tr.tables, id)
+			continue
+		}
+		if oldest == "" || entry.table.CreatedAt.Before(tr.tables[oldest].table.CreatedAt) {
+			oldest = id
+		}
+	}
+	if len(tr.tables) >= maxTables {
+		delete(tr.tables, oldest)
+	}
+	tr.tables[table.ID] = tableEntry{table: table, sessionID: sessionID}
+}
+
+// get returns the table with id if it is on sessionID's device and hasn't expired
+func (tr *tableRegistry) get(id, sessionID string, now time.Time) *game.Table {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	entry, ok := tr.tables[id]
+	if !ok || entry.sessionID != sessionID || now.Sub(entry.table.CreatedAt) > tableTTL {
+		return nil
+	}
+	return entry.table
+}
+
+// NewTable starts a pass-the-phone table on this device
+func (h *Handler) NewTable(w http.ResponseWriter, r *http.Request) {
+	if h.rejectIfMaintenance(w, r) {
+		return
+	}
+
+	sessionID := h.getOrCreateSession(w, r)
+	table := game.NewTable(h.clock.Now())
+	h.tables.add(table, sessionID, h.clock.Now())
+	log.Printf("🃏 Pass-the-phone table started")
+
+	http.Redirect(w, r, "/table/"+table.ID, http.StatusSeeOther)
+}
+
+// TablePage shows a pass-the-phone table at whatever point of the deal it is
+func (h *Handler) TablePage(w http.ResponseWriter, r *http.Request) {
+	table, ok := h.requireTable(w, r)
+	if !ok {
+		return
+	}
+	pages.TablePage(table, h.config().Server.MinPlayersPerRoom, h.config().Server.MaxPlayersPerRoom, "").Render(r.Context(), w)
+}
+
+// SeatAtTable adds the next player's name to a table that is still seating
+func (h *Handler) SeatAtTable(w http.ResponseWriter, r *http.Request) {
+	table, ok := h.requireTable(w, r)
+	if !ok {
+		return
+	}
+	name, err := normalizePlayerName(r.FormValue("player_name"))
+	if err == nil {
+		err = table.Seat(generatePlayerID(), name, h.config().Server.MaxPlayersPerRoom)
+	}
+	h.finishTableAction(w, r, table, err)
+}
+
+// DealTable deals roles to everyone seated
+func (h *Handler) DealTable(w http.ResponseWriter, r *http.Request) {
+	table, ok := h.requireTable(w, r)
+	if !ok {
+		return
+	}
+	err := table.Deal(h.cardService, h.config())
+	if err == nil {
+		log.Printf("🃏 Pass-the-phone table dealt to %d players", len(table.PlayerNames()))
+	}
+	h.finishTableAction(w, r, table, err)
+}
+
+// RevealAtTable shows the current player their role
+func (h *Handler) RevealAtTable(w http.ResponseWriter, r *http.Request) {
+	table, ok := h.requireTable(w, r)
+	if !ok {
+		return
+	}
+	h.finishTableAction(w, r, table, table.Reveal())
+}
+
+// HideAtTable hides the current role and passes the device on
+func (h *Handler) HideAtTable(w http.ResponseWriter, r *http.Request) {
+	table, ok := h.requireTable(w, r)
+	if !ok {
+		return
+	}
+	h.finishTableAction(w, r, table, table.Hide())
+}
+
+// requireTable returns the table named in the URL, answering 404 when this
+// device didn't start it
+func (h *Handler) requireTable(w http.ResponseWriter, r *http.Request) (*game.Table, bool) {
+	sessionID, _ := h.sessionID(r)
+	table := h.tables.get(chi.URLParam(r, "id"), sessionID, h.clock.Now())
+	if table == nil {
+		http.Error(w, "Table not found", http.StatusNotFound)
+		return nil, false
+	}
+	return table, true
+}
+
+// finishTableAction sends the device back to the table page, or shows it
+// with err. A repeated tap on a button already acted on is no error.
+func (h *Handler) finishTableAction(w http.ResponseWriter, r *http.Request, table *game.Table, err error) {
+	if err != nil && !errors.Is(err, game.ErrTableWrongPhase) {
+		w.WriteHeader(http.StatusBadRequest)
+		pages.TablePage(table, h.config().Server.MinPlayersPerRoom, h.config().Server.MaxPlayersPerRoom, err.Error()).Render(r.Context(), w)
+		return
+	}
+	http.Redirect(w, r, "/table/"+table.ID, http.StatusSeeOther)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/testkit"
+)
+
+func TestPassThePhoneTable(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	device := testkit.NewClient(t, router)
+
+	w := device.Post("/table/new", nil)
+	tablePath := w.Header().Get("Location")
+	if w.Code != http.StatusSeeOther || !strings.HasPrefix(tablePath, "/table/") {
+		t.Fatalf("expected a new table, got %d %q", w.Code, tablePath)
+	}
+
+	names := []string{"Ann", "Ben", "Cat", "Dan"}
+	for _, name := range names {
+		if w := device.Post(tablePath+"/seat", url.Values{"player_name": {name}}); w.Code != http.StatusSeeOther {
+			t.Fatalf("expected %s to be seated, got %d", name, w.Code)
+		}
+	}
+	if w := device.Post(tablePath+"/seat", url.Values{"player_name": {"<script>"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected an invalid name to be refused, got %d", w.Code)
+	}
+	if body := device.Get(tablePath).Body.String(); !strings.Contains(body, "Dan") || strings.Contains(body, "@get(&#39;/sse/") {
+		t.Fatal("expected the seated players on a page with no stream")
+	}
+
+	// Another device can't see the table or its roles
+	if w := testkit.NewClient(t, router).Get(tablePath); w.Code != http.StatusNotFound {
+		t.Fatalf("expected another device to get 404, got %d", w.Code)
+	}
+
+	device.Post(tablePath+"/deal", nil)
+	for _, name := range names {
+		body := device.Get(tablePath).Body.String()
+		if !strings.Contains(body, `id="table-current-player"`) || !strings.Contains(body, name) || strings.Contains(body, `id="table-role"`) {
+			t.Fatalf("expected the device to be passed to %s with no role showing", name)
+		}
+		device.Post(tablePath+"/reveal", nil)
+		if body := device.Get(tablePath).Body.String(); !strings.Contains(body, `id="table-role"`) {
+			t.Fatalf("expected %s's role on screen", name)
+		}
+		device.Post(tablePath+"/hide", nil)
+		// A double tap on Hide doesn't skip the next player
+		if w := device.Post(tablePath+"/hide", nil); w.Code != http.StatusSeeOther {
+			t.Fatalf("expected a repeated hide to be ignored, got %d", w.Code)
+		}
+	}
+
+	if body := device.Get(tablePath).Body.String(); !strings.Contains(body, `id="table-leader"`) {
+		t.Error("expected the Leader to be shown once everyone has seen their role")
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import "sync"
+
+// playerTabs keeps one live stream per player. A player who opens the room
+// in a second tab takes the stream over: the older tab's stream is ended
+// with an "open elsewhere" banner, whose button takes it back.
+type playerTabs struct {
+	mu   sync.Mutex
+	open map[string]*playerTab // by room code and player ID
+}
+
+// playerTab is one tab's claim on a player's stream
+type playerTab struct {
+	key        string
+	superseded chan struct{} // closed when a newer tab claims the stream
+}
+
+func newPlayerTabs() *playerTabs {
+	return &playerTabs{open: make(map[string]*playerTab)}
+}
+
+// claim makes a new tab the player's stream, superseding any older one
+func (t *playerTabs) claim(roomCode, playerID string) *playerTab {
+	tab := &playerTab{key: roomCode + "/" + playerID, superseded: make(chan struct{})}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if older := t.open[tab.key]; older != nil {
+		close(older.superseded)
+	}
+	t.open[tab.key] = tab
+	return tab
+}
+
+// release drops tab's claim unless a newer tab already holds the stream
+func (t *playerTabs) release(tab *playerTab) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.open[tab.key] == tab {
+		delete(t.open, tab.key)
+	}
+}
+
+// wasSuperseded reports whether a newer tab took the stream over
+func (tab *playerTab) wasSuperseded() bool {
+	select {
+	case <-tab.superseded:
+		return true
+	default:
+		return false
+	}
+}
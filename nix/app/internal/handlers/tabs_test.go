@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"treacherest/internal/testkit"
+)
+
+func TestSecondTabTakesOverThePlayersStream(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	host := testkit.CreateRoom(t, router, "Host", true)
+	alice := testkit.JoinRoom(t, router, host.RoomCode, "Alice")
+
+	older := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer older.Close()
+	if !older.WaitFor("isStarting", 2*time.Second) {
+		t.Fatalf("expected the first tab's stream to open, got %s", older.Data())
+	}
+	newer := alice.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer newer.Close()
+
+	select {
+	case <-older.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the older tab's stream to end")
+	}
+	if !strings.Contains(older.Data(), `data-reason="open_elsewhere"`) || !strings.Contains(older.Data(), "Take over here") {
+		t.Errorf("expected the older tab to be told the game is open elsewhere, got %s", older.Data())
+	}
+	if newer.Closed() || strings.Contains(newer.Data(), "open_elsewhere") {
+		t.Errorf("expected the newer tab to keep streaming, got %s", newer.Data())
+	}
+
+	// Another player's tab is left alone
+	bob := testkit.JoinRoom(t, router, host.RoomCode, "Bob")
+	bobs := bob.OpenSSE("/sse/room/" + host.RoomCode + "?view=lobby")
+	defer bobs.Close()
+	if newer.WaitFor("open_elsewhere", 200*time.Millisecond) {
+		t.Errorf("expected another player's tab not to supersede Alice's, got %s", newer.Data())
+	}
+}
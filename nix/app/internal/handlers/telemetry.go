@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Client reports are clamped so one misbehaving tab can't swamp a room's totals
+	maxTelemetryReconnects = 1000
+	maxTelemetryGap        = time.Hour
+)
+
+// RoomSSETelemetry aggregates the connection quality clients in one room
+// report, split by browser family and whether the request came through a
+// proxy, along with failed keepalives and how far the room's keepalive
+// interval has been shortened because of them, and how closely its pages
+// showed roles at the scheduled reveal
+type RoomSSETelemetry struct {
+	Reports            int            `json:"reports"`
+	Reconnects         int            `json:"reconnects"`
+	MaxGapMs           int64          `json:"maxGapMs"`
+	TotalGapMs         int64          `json:"totalGapMs"`
+	Browsers           map[string]int `json:"browsers"`
+	ViaProxy           int            `json:"viaProxy"`
+	LastReportAt       time.Time      `json:"lastReportAt"`
+	KeepaliveFailures  int            `json:"keepaliveFailures"`
+	KeepaliveShortened int            `json:"keepaliveShortened"` // halvings of the keepalive interval
+
+	// Reveal skew is relative to the room's RevealAt; the spread between the
+	// earliest and latest page is what players would notice
+	RevealReports    int   `json:"revealReports"`
+	EarliestRevealMs int64 `json:"earliestRevealMs"`
+	LatestRevealMs   int64 `json:"latestRevealMs"`
+	MaxRevealRTTMs   int64 `json:"maxRevealRttMs"`
+
+	lastKeepaliveTrouble time.Time
+}
+
+// sseTelemetry keeps per-room client reports in memory; they are diagnostics
+// for "my lobby froze" reports and are not persisted
+type sseTelemetry struct {
+	mu    sync.Mutex
+	rooms map[string]*RoomSSETelemetry
+}
+
+func newSSETelemetry() *sseTelemetry {
+	return &sseTelemetry{rooms: make(map[string]*RoomSSETelemetry)}
+}
+
+// record adds one client report to roomCode's totals
+func (t *sseTelemetry) record(roomCode string, reconnects int, maxGap, totalGap time.Duration, browser string, viaProxy bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.rooms[roomCode]
+	if stats == nil {
+		stats = &RoomSSETelemetry{Browsers: make(map[string]int)}
+		t.rooms[roomCode] = stats
+	}
+	stats.Reports++
+	stats.Reconnects += reconnects
+	stats.TotalGapMs += totalGap.Milliseconds()
+	if maxGap.Milliseconds() > stats.MaxGapMs {
+		stats.MaxGapMs = maxGap.Milliseconds()
+	}
+	stats.Browsers[browser]++
+	if viaProxy {
+		stats.ViaProxy++
+	}
+	stats.LastReportAt = now
+}
+
+// reconnects returns the stream reconnects clients in roomCode have reported
+func (t *sseTelemetry) reconnects(roomCode string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if stats := t.rooms[roomCode]; stats != nil {
+		return stats.Reconnects
+	}
+	return 0
+}
+
+// snapshot copies the totals for rooms that still exist, forgetting the rest
+func (t *sseTelemetry) snapshot(roomExists func(string) bool) map[string]RoomSSETelemetry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rooms := make(map[string]RoomSSETelemetry, len(t.rooms))
+	for code, stats := range t.rooms {
+		if !roomExists(code) {
+			delete(t.rooms, code)
+			continue
+		}
+		copied := *stats
+		copied.Browsers = make(map[string]int, len(stats.Browsers))
+		for browser, count := range stats.Browsers {
+			copied.Browsers[browser] = count
+		}
+		rooms[code] = copied
+	}
+	return rooms
+}
+
+// prune drops rooms whose last report came before cutoff, returning how many
+func (t *sseTelemetry) prune(cutoff time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	dropped := 0
+	for code, stats := range t.rooms {
+		if stats.LastReportAt.Before(cutoff) && stats.lastKeepaliveTrouble.Before(cutoff) {
+			delete(t.rooms, code)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// ReportSSETelemetry takes a client's reconnect count and observed stream
+// gaps, sent with navigator.sendBeacon as the page is hidden
+func (h *Handler) ReportSSETelemetry(w http.ResponseWriter, r *http.Request) {
+	roomCode := strings.ToUpper(strings.TrimSpace(r.FormValue("room")))
+	if _, err := h.store.GetRoom(roomCode); err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	reconnects, err := strconv.Atoi(r.FormValue("reconnects"))
+	if err != nil || reconnects < 0 {
+		http.Error(w, "reconnects must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+	maxGap, err := telemetryGap(r.FormValue("maxGapMs"))
+	if err != nil {
+		http.Error(w, "maxGapMs must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+	totalGap, err := telemetryGap(r.FormValue("totalGapMs"))
+	if err != nil {
+		http.Error(w, "totalGapMs must be a non-negative number", http.StatusBadRequest)
+		return
+	}
+
+	h.telemetry.record(
+		roomCode,
+		min(reconnects, maxTelemetryReconnects),
+		maxGap,
+		totalGap,
+		browserFamily(r.UserAgent()),
+		r.Header.Get("Via") != "" || r.Header.Get("Forwarded") != "",
+		h.clock.Now(),
+	)
+	if reconnects >= keepaliveReconnectThreshold {
+		h.telemetry.keepaliveTrouble(roomCode, false, h.clock.Now())
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSSETelemetry reports per-room client connection quality to an admin,
+// along with unknown events per stream so publisher drift shows as a spike.
+// A tenant's admin token sees only the tenant's rooms.
+func (h *Handler) GetSSETelemetry(w http.ResponseWriter, r *http.Request) {
+	scope, ok := h.requireAdminScope(w, r)
+	if !ok {
+		return
+	}
+	rooms := h.telemetry.snapshot(func(code string) bool {
+		room, err := h.store.GetRoom(code)
+		return err == nil && (scope == nil || roomTenant(room) == scope.ID)
+	})
+	if scope != nil {
+		// A tenant's admin sees its own rooms, not the server's event counts
+		writeAdminJSON(w, map[string]interface{}{"rooms": rooms})
+		return
+	}
+	writeAdminJSON(w, map[string]interface{}{
+		"rooms":         rooms,
+		"unknownEvents": h.unknownEvents.snapshot(h.clock.Now()),
+	})
+}
+
+func telemetryGap(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	ms, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || ms < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return min(time.Duration(ms)*time.Millisecond, maxTelemetryGap), nil
+}
+
+// browserFamily buckets a User-Agent coarsely enough to spot a browser that
+// struggles with long-lived streams without keeping the full string
+func browserFamily(userAgent string) string {
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		return "edge"
+	case strings.Contains(userAgent, "Firefox/") || strings.Contains(userAgent, "FxiOS/"):
+		return "firefox"
+	case strings.Contains(userAgent, "Chrome/") || strings.Contains(userAgent, "CriOS/"):
+		return "chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		return "safari"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func telemetryRequest(form url.Values, userAgent string) *http.Request {
+	req := httptest.NewRequest("POST", "/telemetry/sse", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	return req
+}
+
+func TestSSETelemetryAggregatesPerRoom(t *testing.T) {
+	h := newTestHandler()
+	h.SetAdminToken("secret")
+	room, _ := h.store.CreateRoom()
+
+	const firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+	const safari = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1"
+
+	w := httptest.NewRecorder()
+	h.ReportSSETelemetry(w, telemetryRequest(url.Values{"room": {room.Code}, "reconnects": {"2"}, "maxGapMs": {"4000"}, "totalGapMs": {"5000"}}, firefox))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	req := telemetryRequest(url.Values{"room": {strings.ToLower(room.Code)}, "reconnects": {"1"}, "maxGapMs": {"9000"}, "totalGapMs": {"9000"}}, safari)
+	req.Header.Set("Via", "1.1 corporate-proxy")
+	h.ReportSSETelemetry(httptest.NewRecorder(), req)
+
+	for name, form := range map[string]url.Values{
+		"unknown room":       {"room": {"NOPE1"}, "reconnects": {"1"}},
+		"negative count":     {"room": {room.Code}, "reconnects": {"-1"}},
+		"non-numeric gap ms": {"room": {room.Code}, "reconnects": {"1"}, "maxGapMs": {"soon"}},
+	} {
+		w := httptest.NewRecorder()
+		h.ReportSSETelemetry(w, telemetryRequest(form, firefox))
+		if w.Code < 400 {
+			t.Errorf("%s: expected the report to be refused, got %d", name, w.Code)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	admin := httptest.NewRequest("GET", "/admin/telemetry", nil)
+	admin.Header.Set("Authorization", "Bearer secret")
+	h.GetSSETelemetry(w, admin)
+	var body struct {
+		Rooms map[string]RoomSSETelemetry `json:"rooms"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode telemetry: %v", err)
+	}
+	stats := body.Rooms[room.Code]
+	if stats.Reports != 2 || stats.Reconnects != 3 || stats.MaxGapMs != 9000 || stats.TotalGapMs != 14000 {
+		t.Errorf("unexpected totals %+v", stats)
+	}
+	if stats.Browsers["firefox"] != 1 || stats.Browsers["safari"] != 1 || stats.ViaProxy != 1 {
+		t.Errorf("expected reports split by browser and proxy, got %+v", stats)
+	}
+	if len(body.Rooms) != 1 {
+		t.Errorf("expected only the real room to be tracked, got %v", body.Rooms)
+	}
+
+	h.store.DeleteRoom(room.Code)
+	w = httptest.NewRecorder()
+	h.GetSSETelemetry(w, admin)
+	if strings.Contains(w.Body.String(), room.Code) {
+		t.Errorf("expected telemetry for closed rooms to be dropped, got %s", w.Body.String())
+	}
+}
+
+func TestSSETelemetryRequiresAdminToken(t *testing.T) {
+	h := newTestHandler()
+	h.SetAdminToken("secret")
+
+	w := httptest.NewRecorder()
+	h.GetSSETelemetry(w, httptest.NewRequest("GET", "/admin/telemetry", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", w.Code)
+	}
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	localMiddleware "treacherest/internal/middleware"
+)
+
+// tenantCookie remembers the tenant a browser entered through /t/{id}
+const tenantCookie = "tenant"
+
+// tenant is one community sharing the instance (see config.TenantConfig).
+// Requests reach it through one of its hosts or, on a shared host, through
+// the cookie /t/{id} sets; the rest belong to the server's own rooms.
+type tenant struct {
+	config.TenantConfig
+	adminToken string // empty leaves the tenant's admin view to the server token
+	limiter    *localMiddleware.RateLimiter
+	roomQuota  *roomCreationQuota
+}
+
+func newTenants(cfg *config.ServerConfig) map[string]*tenant {
+	tenants := make(map[string]*tenant, len(cfg.Tenants))
+	for _, tc := range cfg.Tenants {
+		t := &tenant{TenantConfig: tc, roomQuota: newRoomCreationQuota()}
+		if tc.RateLimit > 0 || tc.RateLimitBurst > 0 {
+			scoped := cfg.ForTenant(tc)
+			t.limiter = localMiddleware.NewRateLimiter(scoped.Server.RateLimit, scoped.Server.RateLimitBurst)
+		}
+		tenants[tc.ID] = t
+	}
+	return tenants
+}
+
+// SetTenantAdminToken lets requests bearing token read the tenant's rooms
+// through the /admin telemetry and metrics endpoints
+func (h *Handler) SetTenantAdminToken(tenantID, token string) {
+	if t, ok := h.tenants[tenantID]; ok {
+		t.adminToken = token
+	}
+}
+
+// tenantIDs returns the configured tenants' IDs in order
+func (h *Handler) tenantIDs() []string {
+	ids := make([]string, 0, len(h.tenants))
+	for id := range h.tenants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// requestTenant returns the tenant a request is for, nil for the server's own
+// rooms. A tenant's host wins over the cookie, so a browser that entered one
+// tenant by path can't carry it onto another's host. X-Forwarded-Host counts
+// only from a trusted proxy; from anyone else it would let a client pick its
+// tenant.
+func (h *Handler) requestTenant(r *http.Request) *tenant {
+	if len(h.tenants) == 0 {
+		return nil
+	}
+	host := r.Host
+	if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" && h.trustedProxies.Trusts(r) {
+		host = forwardedHost
+	}
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	for _, t := range h.tenants {
+		for _, tenantHost := range t.Hosts {
+			if strings.EqualFold(host, tenantHost) {
+				return t
+			}
+		}
+	}
+
+	if cookie, err := r.Cookie(tenantCookie); err == nil {
+		if t, ok := h.tenants[cookie.Value]; ok && len(t.Hosts) == 0 {
+			return t
+		}
+	}
+	return nil
+}
+
+// requestTenantID is requestTenant's ID, empty for the server's own rooms
+func (h *Handler) requestTenantID(r *http.Request) string {
+	if t := h.requestTenant(r); t != nil {
+		return t.ID
+	}
+	return ""
+}
+
+// inTenant reports whether room belongs to the tenant r is for. Rooms of
+// other tenants answer as if they didn't exist.
+func (h *Handler) inTenant(r *http.Request, room *game.Room) bool {
+	return room.Tenant == h.requestTenantID(r)
+}
+
+// roomTenant reads the room's tenant under its read lock, for callers that
+// don't hold the room
+func roomTenant(room *game.Room) string {
+	room.RLock()
+	defer room.RUnlock()
+	return room.Tenant
+}
+
+// scopeRoom answers 404 for requests naming another tenant's room in the URL
+func (h *Handler) scopeRoom(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if roomCode := chi.URLParam(r, "code"); roomCode != "" && len(h.tenants) > 0 {
+			if room, err := h.store.GetRoom(roomCode); err == nil && !h.inTenant(r, room) {
+				http.Error(w, "Room not found", http.StatusNotFound)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// EnterTenant sets the tenant cookie for /t/{tenant} and sends the browser
+// on to the rest of the path, so /t/spikes/room/ABCDE joins a room of tenant
+// spikes on a host shared with others
+func (h *Handler) EnterTenant(w http.ResponseWriter, r *http.Request) {
+	t, ok := h.tenants[chi.URLParam(r, "tenant")]
+	if !ok || len(t.Hosts) > 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     tenantCookie,
+		Value:    t.ID,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	// Stripping leading slashes keeps the target on this host
+	target := "/" + strings.TrimLeft(chi.URLParam(r, "*"), `/\`)
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+// roomPath returns the path that reaches room from any browser
+func (h *Handler) roomPath(room *game.Room) string {
+	return h.tenantPrefix(room) + "/room/" + room.Code
+}
+
+// tenantPrefix returns what links to room's pages need in front of their
+// path: tenants entered by path get /t/{id}, since a newcomer has no cookie yet
+func (h *Handler) tenantPrefix(room *game.Room) string {
+	if t, ok := h.tenants[room.Tenant]; ok && len(t.Hosts) == 0 {
+		return "/t/" + t.ID
+	}
+	return ""
+}
+
+// rateLimitByTenant applies a tenant's own rate limiter to its requests and
+// fallback to the rest
+func (h *Handler) rateLimitByTenant(fallback *localMiddleware.RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := fallback.Middleware(h.trustedProxies)(next)
+		byTenant := make(map[string]http.Handler)
+		for id, t := range h.tenants {
+			if t.limiter != nil {
+				byTenant[id] = t.limiter.Middleware(h.trustedProxies)(next)
+			}
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if t := h.requestTenant(r); t != nil {
+				if tenantLimited, ok := byTenant[t.ID]; ok {
+					tenantLimited.ServeHTTP(w, r)
+					return
+				}
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireAdminScope checks the admin bearer token like requireAdmin, also
+// accepting a tenant's token, which is limited to that tenant's rooms. It
+// returns the tenant a tenant token opened, nil for the server token.
+func (h *Handler) requireAdminScope(w http.ResponseWriter, r *http.Request) (*tenant, bool) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if t := h.tenantForAdminToken(token); t != nil {
+			return t, true
+		}
+	}
+	return nil, h.requireAdmin(w, r)
+}
+
+// tenantForAdminToken returns the tenant whose admin token is token, if any
+func (h *Handler) tenantForAdminToken(token string) *tenant {
+	for _, t := range h.tenants {
+		if t.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(t.adminToken)) == 1 {
+			return t
+		}
+	}
+	return nil
+}
+
+// scopeTenantRoom tags a new room with the tenant creating it and, when the
+// tenant doesn't offer the server's default preset, starts it on the
+// tenant's first one
+func (h *Handler) scopeTenantRoom(r *http.Request, room *game.Room) {
+	t := h.requestTenant(r)
+	if t == nil {
+		return
+	}
+	room.Tenant = t.ID
+	log.Printf("🏷️ [tenant %s] Room %s created", t.ID, room.Code)
+
+	if len(t.Presets) == 0 || room.RoleConfig == nil {
+		return
+	}
+	if _, ok := h.tenantConfig(t).GetPreset(room.RoleConfig.PresetName); ok {
+		return
+	}
+	if err := h.applyRolePreset(room, t.Presets[0]); err != nil {
+		log.Printf("⚠️ [tenant %s] Room %s kept its starting preset: %v", t.ID, room.Code, err)
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/store"
+	"treacherest/internal/testkit"
+	"treacherest/internal/views/components"
+)
+
+// newTenantTestHandler serves tenant spikes on its own host, offering only
+// the duel preset, and tenant club by path on the shared host
+func newTenantTestHandler() *Handler {
+	cfg := config.DefaultConfig()
+	cfg.Roles.Presets["duel"] = cfg.Roles.Presets["standard"]
+	cfg.Tenants = []config.TenantConfig{
+		{ID: "spikes", Hosts: []string{"spikes.example.com"}, Presets: []string{"duel"}, RoomCreationPerIP: 1},
+		{ID: "club"},
+	}
+	s := store.NewMemoryStore(cfg)
+	cardService := createMockCardService()
+	s.SetCardService(cardService)
+	return New(s, cardService, cfg, nil)
+}
+
+// onHost serves router as if every request came in for host
+func onHost(router http.Handler, host string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Host = host
+		router.ServeHTTP(w, r)
+	})
+}
+
+func TestForwardedHostNeedsATrustedProxy(t *testing.T) {
+	h := newTenantTestHandler()
+	forwarded := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-Host", "spikes.example.com")
+		return r
+	}
+
+	if tenant := h.requestTenantID(forwarded("203.0.113.9:4711")); tenant != "" {
+		t.Errorf("expected a spoofed forwarded host ignored with no trusted proxies, got tenant %q", tenant)
+	}
+
+	h.trustedProxies = config.ServerSettings{TrustedProxies: []string{"10.0.0.0/8"}}.TrustedProxyRanges()
+	if tenant := h.requestTenantID(forwarded("203.0.113.9:4711")); tenant != "" {
+		t.Errorf("expected a forwarded host from an untrusted peer ignored, got tenant %q", tenant)
+	}
+	if tenant := h.requestTenantID(forwarded("10.1.2.3:4711")); tenant != "spikes" {
+		t.Errorf("expected the trusted proxy's forwarded host to pick the tenant, got %q", tenant)
+	}
+}
+
+func TestTenantRoomsAreScopedByHost(t *testing.T) {
+	h := newTenantTestHandler()
+	router := newTestRouter(h)
+	spikes := onHost(router, "spikes.example.com:443")
+
+	creator := testkit.CreateRoom(t, spikes, "Alice", false)
+	room, _ := h.store.GetRoom(creator.RoomCode)
+	if room.Tenant != "spikes" {
+		t.Fatalf("expected the room tagged with its tenant, got %q", room.Tenant)
+	}
+	if room.RoleConfig.PresetName != "duel" {
+		t.Errorf("expected the room started on the tenant's preset, got %q", room.RoleConfig.PresetName)
+	}
+	if presets := h.roomConfig(room).Roles.Presets; len(presets) != 1 {
+		t.Errorf("expected only the tenant's presets offered, got %d", len(presets))
+	}
+
+	if w := testkit.NewClient(t, spikes).Get("/room/" + room.Code); w.Code != http.StatusOK {
+		t.Errorf("expected the room open on its tenant's host, got %d", w.Code)
+	}
+	if w := testkit.NewClient(t, router).Get("/room/" + room.Code); w.Code != http.StatusNotFound {
+		t.Errorf("expected the room hidden from the shared host, got %d", w.Code)
+	}
+	w := testkit.NewClient(t, router).Post("/join-room", url.Values{"room_code": {room.Code}, "player_name": {"Mallory"}, components.BotElapsedField: {"5000"}})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected a join from the shared host refused as unknown, got %d", w.Code)
+	}
+
+	// The server's own rooms are hidden from the tenant in turn
+	own := testkit.CreateRoom(t, router, "Bob", false)
+	if w := testkit.NewClient(t, spikes).Get("/room/" + own.RoomCode); w.Code != http.StatusNotFound {
+		t.Errorf("expected the server's room hidden from the tenant, got %d", w.Code)
+	}
+}
+
+func TestEnterTenantByPath(t *testing.T) {
+	h := newTenantTestHandler()
+	router := newTestRouter(h)
+
+	browser := testkit.NewClient(t, router)
+	w := browser.Get("/t/club/room/ABCDE")
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/room/ABCDE" {
+		t.Fatalf("expected a redirect to the rest of the path, got %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	if cookie := browser.Cookie(tenantCookie); cookie == nil || cookie.Value != "club" {
+		t.Fatalf("expected the tenant cookie set, got %v", cookie)
+	}
+	if w := browser.Get(`/t/club/\evil.example`); w.Header().Get("Location") != "/evil.example" {
+		t.Errorf("expected the redirect kept on this host, got %q", w.Header().Get("Location"))
+	}
+
+	w = browser.Post("/room/new", url.Values{"playerName": {"Alice"}, components.BotElapsedField: {"5000"}})
+	code := strings.TrimPrefix(w.Header().Get("Location"), "/room/")
+	room, err := h.store.GetRoom(code)
+	if err != nil || room.Tenant != "club" {
+		t.Fatalf("expected a room of tenant club, got %v (%v)", room, err)
+	}
+	if got := h.roomPath(room); got != "/t/club/room/"+code {
+		t.Errorf("expected the join link to enter the tenant, got %s", got)
+	}
+
+	// A tenant with its own host can't be entered by path
+	for _, path := range []string{"/t/spikes", "/t/nope/room/ABCDE"} {
+		if w := testkit.NewClient(t, router).Get(path); w.Code != http.StatusNotFound {
+			t.Errorf("expected %s not found, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestTenantRoomQuota(t *testing.T) {
+	h := newTenantTestHandler()
+	router := newTestRouter(h)
+	spikes := onHost(router, "spikes.example.com")
+
+	testkit.CreateRoom(t, spikes, "Alice", false)
+	if w := testkit.NewClient(t, spikes).Post("/room/new", url.Values{"playerName": {"Bob"}, components.BotElapsedField: {"5000"}}); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the tenant's own quota to refuse, got %d", w.Code)
+	}
+	testkit.CreateRoom(t, router, "Carol", false)
+}
+
+func TestTenantAdminSeesOnlyItsTenant(t *testing.T) {
+	h := newTenantTestHandler()
+	h.SetAdminToken("server-secret")
+	h.SetTenantAdminToken("spikes", "spikes-secret")
+	router := newTestRouter(h)
+	testkit.CreateRoom(t, onHost(router, "spikes.example.com"), "Alice", false)
+	testkit.CreateRoom(t, router, "Bob", false)
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := get("/admin/metrics", "spikes-secret").Body.String()
+	if !strings.Contains(body, `treacherest_tenant_rooms{tenant="spikes"} 1`) {
+		t.Errorf("expected the tenant's room count, got:\n%s", body)
+	}
+	if strings.Contains(body, `tenant=""`) || strings.Contains(body, "club") || strings.Contains(body, "treacherest_sse_unknown_events_total") {
+		t.Errorf("expected only the tenant's series, got:\n%s", body)
+	}
+
+	body = get("/admin/metrics", "server-secret").Body.String()
+	for _, want := range []string{`treacherest_tenant_rooms{tenant=""} 1`, `treacherest_tenant_rooms{tenant="club"} 0`, `treacherest_tenant_room_creation_rejected_total{tenant="spikes",scope="ip"} 0`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s for the server admin, got:\n%s", want, body)
+		}
+	}
+
+	if w := get("/admin/maintenance", "spikes-secret"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected the tenant token kept out of server-wide admin, got %d", w.Code)
+	}
+	if w := get("/admin/telemetry", "spikes-secret"); w.Code != http.StatusOK || strings.Contains(w.Body.String(), "unknownEvents") {
+		t.Errorf("expected the tenant's telemetry without server event counts, got %d: %s", w.Code, w.Body.String())
+	}
+}
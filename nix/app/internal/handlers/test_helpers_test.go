@@ -7,9 +7,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+	"treacherest/internal/clock"
 	"treacherest/internal/config"
 	"treacherest/internal/game"
 	"treacherest/internal/store"
+	"treacherest/internal/testkit"
 )
 
 // newTestHandler creates a handler with default test configuration
@@ -21,6 +24,27 @@ func newTestHandler() *Handler {
 	return New(s, cardService, cfg, nil) // nil backupService for tests
 }
 
+// withFakeClock moves h onto a fake clock and returns it
+func withFakeClock(h *Handler) *clock.Fake {
+	fake := clock.NewFake(time.Now())
+	h.SetClock(fake)
+	return fake
+}
+
+// advanceUntil moves fake forward a second at a time until cond holds, so
+// countdowns and phase timers run out without really waiting
+func advanceUntil(t testing.TB, fake *clock.Fake, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition never held on fake time")
+		}
+		fake.Advance(time.Second)
+		time.Sleep(time.Millisecond)
+	}
+}
+
 // newTestHandlerWithStore creates a handler with a specific store
 func newTestHandlerWithStore(s *store.MemoryStore) *Handler {
 	cfg := config.DefaultConfig()
@@ -75,7 +99,7 @@ func mockGuardianCard() *game.Card {
 // joinRoomViaPostHelper is a test helper function to join a room using the new POST endpoint
 // It returns the response recorder with the redirect result
 func joinRoomViaPostHelper(t *testing.T, router *chi.Mux, roomCode, playerName string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
-	formData := fmt.Sprintf("room_code=%s&player_name=%s", roomCode, playerName)
+	formData := fmt.Sprintf("room_code=%s&player_name=%s&form_elapsed=5000", roomCode, playerName)
 	req := httptest.NewRequest("POST", "/join-room", strings.NewReader(formData))
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
@@ -89,3 +113,33 @@ func joinRoomViaPostHelper(t *testing.T, router *chi.Mux, roomCode, playerName s
 
 	return w
 }
+
+// seatedClient is a browser holding playerID's seat in roomCode, for rooms
+// whose players were seated straight through the store
+func seatedClient(t *testing.T, router http.Handler, roomCode, playerID string) *testkit.Client {
+	client := testkit.NewClient(t, router)
+	client.RoomCode = roomCode
+	client.SetCookie(&http.Cookie{Name: "player_" + roomCode, Value: playerID})
+	return client
+}
+
+// newTestRouter builds the production route table for h with rate limiting and request logging off
+func newTestRouter(h *Handler) *chi.Mux {
+	return SetupRouter(h, h.config(), &RouterOptions{DisableRateLimiting: true, DisableRequestLogger: true})
+}
+
+// newScenario starts a testkit.Scenario on h's production routes, with
+// ExpectEvent reading h's event bus
+func newScenario(t *testing.T, h *Handler) *testkit.Scenario {
+	return testkit.NewScenario(t, newTestRouter(h)).WithEvents(func(roomCode string) (<-chan string, func()) {
+		events := h.eventBus.Subscribe(roomCode)
+		types := make(chan string, 64)
+		go func() {
+			defer close(types)
+			for event := range events {
+				types <- string(event.Type)
+			}
+		}()
+		return types, func() { h.eventBus.Unsubscribe(roomCode, events) }
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+// OpenVote lets the Room Operator open a vote, either over living players or custom options
+func (h *Handler) OpenVote(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+	if room.State != game.StatePlaying {
+		http.Error(w, "Votes can only be opened while the game is playing", http.StatusConflict)
+		return
+	}
+
+	question := strings.TrimSpace(r.FormValue("question"))
+	if question == "" {
+		question = "Exile a player"
+	}
+	if len(question) > 120 {
+		http.Error(w, "Question is too long", http.StatusBadRequest)
+		return
+	}
+
+	var options []game.VoteOption
+	if custom := strings.TrimSpace(r.FormValue("options")); custom != "" {
+		for i, label := range strings.Split(custom, ",") {
+			label = strings.TrimSpace(label)
+			if label == "" {
+				continue
+			}
+			options = append(options, game.VoteOption{ID: fmt.Sprintf("o%d", i+1), Label: label})
+		}
+	} else {
+		options = game.PlayerVoteOptions(room.GetLivingPlayers())
+	}
+
+	if err := room.OpenVote(question, options, r.FormValue("public") == "true"); err != nil {
+		http.Error(w, err.Error(), voteErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🗳️ Vote opened in room %s: %s", room.Code, question)
+
+	h.eventBus.Publish(Event{
+		Type:     EventVoteOpened,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// CastVote records the effective player's ballot in the open vote
+func (h *Handler) CastVote(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	room, err := h.store.GetRoom(roomCode)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+	if h.rejectIfPhaseForbids(w, room, game.PhaseActionVote) {
+		return
+	}
+
+	player, ok := h.requireEffectivePlayer(w, r, room, roomCode)
+	if !ok {
+		return
+	}
+	if !player.IsActiveInGame() {
+		http.Error(w, "Only living players can vote", http.StatusForbidden)
+		return
+	}
+
+	if err := room.CastVote(player.ID, chi.URLParam(r, "optionID")); err != nil {
+		http.Error(w, err.Error(), voteErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+
+	h.eventBus.Publish(Event{
+		Type:     EventVoteCast,
+		RoomCode: room.Code,
+		Data:     room,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// CloseVote ends the open vote and writes the result to the game log
+func (h *Handler) CloseVote(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := room.CloseVote()
+	if err != nil {
+		http.Error(w, err.Error(), voteErrorStatus(err))
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("🗳️ Vote closed in room %s: %s", room.Code, result.Summary())
+
+	h.eventBus.Publish(Event{
+		Type:     EventVoteClosed,
+		RoomCode: room.Code,
+		Data:     result,
+		Actor:    h.requestActor(r, room),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func voteErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, game.ErrNoOpenVote), errors.Is(err, game.ErrVoteAlreadyOpen), errors.Is(err, game.ErrVoteChangeUsed):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
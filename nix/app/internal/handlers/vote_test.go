@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/game"
+)
+
+func TestVoteLifecycle(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, player := newPhaseTestRoom(t, h)
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	bob.Role = mockGuardianCard()
+	room.AddPlayer(bob)
+	room.State = game.StatePlaying
+	base := "/room/" + room.Code + "/vote/"
+
+	events := h.eventBus.Subscribe(room.Code)
+	defer h.eventBus.Unsubscribe(room.Code, events)
+
+	if w := postPhaseForm(router, base+"open", "s1", url.Values{}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected non-operator open to be rejected, got %d", w.Code)
+	}
+	if w := postPhaseForm(router, base+"open", "operator-session", url.Values{"public": {"true"}}); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if room.Vote == nil || len(room.Vote.Options) != 2 || !room.Vote.PublicTally {
+		t.Fatalf("expected a public vote over the two living players, got %+v", room.Vote)
+	}
+	if ev := <-events; ev.Type != "vote_opened" {
+		t.Fatalf("expected vote_opened, got %s", ev.Type)
+	}
+
+	alice := seatedClient(t, router, room.Code, player.ID)
+	if w := alice.Post(base+"cast/"+bob.ID, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected cast to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := alice.Post(base+"cast/"+player.ID, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected one change to succeed, got %d", w.Code)
+	}
+	if w := alice.Post(base+"cast/"+bob.ID, nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected second change to be rejected, got %d", w.Code)
+	}
+	if w := seatedClient(t, router, room.Code, "op").Post(base+"cast/"+player.ID, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the operator to be unable to vote, got %d", w.Code)
+	}
+	seatedClient(t, router, room.Code, bob.ID).Post(base+"cast/"+player.ID, nil)
+
+	if w := postPhaseForm(router, base+"close", "operator-session", nil); w.Code != http.StatusOK {
+		t.Fatalf("expected close to succeed, got %d", w.Code)
+	}
+	log := room.GetLog()
+	if len(log) != 1 || !strings.Contains(log[0].Message, "Alice") {
+		t.Fatalf("expected the result in the game log, got %+v", log)
+	}
+	if w := postPhaseForm(router, base+"close", "operator-session", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected closing twice to conflict, got %d", w.Code)
+	}
+}
+
+func TestOpenVoteRequiresPlaying(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room, _ := newPhaseTestRoom(t, h)
+
+	w := postPhaseForm(router, "/room/"+room.Code+"/vote/open", "operator-session", url.Values{"options": {"Yes, No"}})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 in the lobby, got %d", w.Code)
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/go-chi/chi/v5"
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"treacherest/internal/game"
+	"treacherest/internal/views/pages"
+)
+
+// CreateWatchLink issues a new read-only share link for remote spectators
+func (h *Handler) CreateWatchLink(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	room.CreateWatchLink()
+	h.store.UpdateRoom(room)
+	log.Printf("👀 Watch link created for room %s", room.Code)
+
+	h.renderWatchLinks(w, r, room)
+}
+
+// RevokeWatchLink revokes a share link and disconnects anyone watching through it
+func (h *Handler) RevokeWatchLink(w http.ResponseWriter, r *http.Request) {
+	room, ok := h.requireRoomOperator(w, r)
+	if !ok {
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+	if !room.RevokeWatchLink(token) {
+		http.Error(w, "Watch link not found", http.StatusNotFound)
+		return
+	}
+	h.store.UpdateRoom(room)
+	log.Printf("👀 Watch link revoked for room %s", room.Code)
+
+	h.eventBus.Publish(Event{
+		Type:     EventWatchLinkRevoked,
+		RoomCode: room.Code,
+		Data:     token,
+		Actor:    h.requestActor(r, room),
+	})
+
+	h.renderWatchLinks(w, r, room)
+}
+
+func (h *Handler) renderWatchLinks(w http.ResponseWriter, r *http.Request, room *game.Room) {
+	sse := datastar.NewSSE(w, r)
+	html := renderFragment(pages.HostDashboardWatchLinks(room), "#operator-watch-links", room.Code)
+	h.patchElements(sse, PageHost, html, "#operator-watch-links")
+}
+
+// WatchPage renders the public spectator view for a share link
+func (h *Handler) WatchPage(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	room, err := h.store.FindRoomByWatchToken(token)
+	if err != nil {
+		http.Error(w, "Watch link not found", http.StatusNotFound)
+		return
+	}
+
+	pages.WatchPage(room, token).Render(r.Context(), w)
+}
+
+// StreamWatch streams public game state to a spectator.
+// Spectators are tracked separately from players and are dropped as soon as their link is revoked.
+func (h *Handler) StreamWatch(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	room, err := h.store.FindRoomByWatchToken(token)
+	if err != nil {
+		http.Error(w, "Watch link not found", http.StatusNotFound)
+		return
+	}
+
+	h.streamSpectator(w, r, room, func(room *game.Room) bool {
+		if room.HasWatchLink(token) {
+			return true
+		}
+		log.Printf("👀 Watch link for room %s is no longer valid, closing SSE", room.Code)
+		return false
+	}, pages.WatchRevoked())
+}
+
+// streamSpectator streams room's public state until the client leaves or
+// allowed turns it away, when gone replaces the view
+func (h *Handler) streamSpectator(w http.ResponseWriter, r *http.Request, room *game.Room, allowed func(*game.Room) bool, gone templ.Component) {
+	roomCode := room.Code
+
+	sse := datastar.NewSSE(w, r)
+
+	events := h.eventBus.Subscribe(roomCode)
+	defer h.eventBus.Unsubscribe(roomCode, events)
+	h.connTracker.AddViewer(roomCode)
+	defer h.connTracker.RemoveViewer(roomCode)
+
+	room.RLock()
+	h.renderWatch(sse, room)
+	room.RUnlock()
+	h.sendInitialMaintenanceBanner(sse, PageWatch)
+
+	heartbeat := h.newKeepaliveTicker(roomCode)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C():
+			if _, err := w.Write([]byte(":\n\n")); err != nil {
+				heartbeat.failed()
+				return
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+			heartbeat.sent()
+		case event := <-events:
+			room, err := h.store.GetRoom(roomCode)
+			if err != nil || !allowed(room) {
+				h.patchElements(sse, PageWatch, renderToString(gone), "#watch-content")
+				return
+			}
+
+			viewRoom(room, func() bool {
+				switch event.Type {
+				case EventCountdownUpdate:
+					patchCountdown(sse, room.CountdownRemaining)
+				case EventMaintenanceUpdated:
+					h.patchMaintenanceBanner(sse, PageWatch)
+				case EventChatPosted:
+					// Table chat is for seated players
+				default:
+					if !knownEventTypes[event.Type] {
+						h.recordUnknownEvent("watch", event.Type)
+					}
+					h.renderWatch(sse, room)
+				}
+				return false
+			})
+		}
+	}
+}
+
+// renderWatch patches the spectator content and countdown signal
+func (h *Handler) renderWatch(sse *datastar.ServerSentEventGenerator, room *game.Room) {
+	html := renderFragment(pages.WatchContent(room), "#watch-content", room.Code)
+	if err := h.patchElements(sse, PageWatch, html, "#watch-content"); err != nil {
+		log.Printf("❌ Failed to render watch view for room %s: %v", room.Code, err)
+		return
+	}
+	patchCountdown(sse, room.CountdownRemaining)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"treacherest/internal/game"
+)
+
+func newWatchTestRoom(t *testing.T, h *Handler) *game.Room {
+	t.Helper()
+	room, err := h.store.CreateRoom()
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	room.OperatorSessionID = "operator-session"
+	room.AddPlayer(game.NewPlayer("p1", "Alice", "s1"))
+	return room
+}
+
+func TestCreateWatchLink_RequiresOperator(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room := newWatchTestRoom(t, h)
+
+	req := httptest.NewRequest("POST", "/room/"+room.Code+"/watch-links", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-operator, got %d", w.Code)
+	}
+	if len(room.GetWatchLinks()) != 0 {
+		t.Fatal("non-operator should not create watch links")
+	}
+
+	req = httptest.NewRequest("POST", "/room/"+room.Code+"/watch-links", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for operator, got %d", w.Code)
+	}
+	links := room.GetWatchLinks()
+	if len(links) != 1 {
+		t.Fatalf("expected one watch link, got %d", len(links))
+	}
+	if !strings.Contains(w.Body.String(), "/watch/"+links[0].Token) {
+		t.Errorf("expected response to patch the new link, got %s", w.Body.String())
+	}
+}
+
+func TestWatchPage_RevokedLinkIsGone(t *testing.T) {
+	h := newTestHandler()
+	router := newTestRouter(h)
+	room := newWatchTestRoom(t, h)
+	link := room.CreateWatchLink()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watch/"+link.Token, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Alice") {
+		t.Errorf("expected roster in watch page")
+	}
+
+	req := httptest.NewRequest("POST", "/room/"+room.Code+"/watch-links/"+link.Token+"/revoke", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "operator-session"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected revoke to succeed, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/watch/"+link.Token, nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected revoked link to 404, got %d", w.Code)
+	}
+}
+
+func TestStreamWatch_CountsViewersAndClosesOnRevoke(t *testing.T) {
+	h := newTestHandler()
+	room := newWatchTestRoom(t, h)
+	link := room.CreateWatchLink()
+
+	req := httptest.NewRequest("GET", "/sse/watch/"+link.Token, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("token", link.Token)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		h.StreamWatch(w, req)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for h.connTracker.GetViewerCount(room.Code) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected spectator to be tracked as a viewer")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if count := h.connTracker.GetConnectionCount(room.Code); count != 0 {
+		t.Errorf("spectators should not count as player connections, got %d", count)
+	}
+
+	room.RevokeWatchLink(link.Token)
+	h.eventBus.Publish(Event{Type: EventWatchLinkRevoked, RoomCode: room.Code, Data: link.Token})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected stream to close after revoke")
+	}
+	if count := h.connTracker.GetViewerCount(room.Code); count != 0 {
+		t.Errorf("expected viewer to be released, got %d", count)
+	}
+}
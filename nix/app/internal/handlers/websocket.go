@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/go-chi/chi/v5"
+)
+
+// socketReadLimit caps a client message; clients only send subscriptions
+const socketReadLimit = 4096
+
+// socketViews are the room stream views a WebSocket client can subscribe to
+var socketViews = map[string]bool{"lobby": true, "game": true, "host": true}
+
+// socketMessage is the WebSocket envelope. The client sends "subscribe" and
+// "unsubscribe" for a view; the server sends each datastar event a view's
+// stream produces as an "event", with the SSE event type in Event and its
+// data lines in Args, then "closed" or "error" when the stream ends.
+type socketMessage struct {
+	Type    string            `json:"type"`
+	View    string            `json:"view"`
+	Event   string            `json:"event,omitempty"`
+	Args    map[string]string `json:"args,omitempty"`
+	Status  int               `json:"status,omitempty"`  // HTTP status of a stream that was refused
+	Message string            `json:"message,omitempty"` // and why
+}
+
+// RoomSocket carries the room streams over a WebSocket, for clients behind
+// proxies that buffer SSE. The client subscribes to the views it shows, and
+// each runs the stream /sse/room/{code}?view=... would, on the same
+// connection, so the two transports render exactly the same updates.
+func (h *Handler) RoomSocket(w http.ResponseWriter, r *http.Request) {
+	roomCode := chi.URLParam(r, "code")
+	if _, err := h.store.GetRoom(roomCode); err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		log.Printf("🔌 WebSocket upgrade for room %s failed: %v", roomCode, err)
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(socketReadLimit)
+	log.Printf("🔌 WebSocket connection established for room %s", roomCode)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	socket := &roomSocket{h: h, conn: conn, ctx: ctx, r: r, views: make(map[string]context.CancelFunc)}
+	defer socket.wait()
+	defer cancel()
+
+	for {
+		var msg socketMessage
+		if err := wsjson.Read(ctx, conn, &msg); err != nil {
+			if websocket.CloseStatus(err) == -1 && ctx.Err() == nil {
+				log.Printf("🔌 WebSocket read for room %s failed: %v", roomCode, err)
+			}
+			return
+		}
+		switch msg.Type {
+		case "subscribe":
+			if !socketViews[msg.View] {
+				socket.send(socketMessage{Type: "error", View: msg.View, Status: http.StatusBadRequest, Message: "Invalid view"})
+				continue
+			}
+			socket.subscribe(msg.View)
+		case "unsubscribe":
+			socket.unsubscribe(msg.View)
+		}
+	}
+}
+
+// roomSocket is one WebSocket connection and the views streaming over it
+type roomSocket struct {
+	h    *Handler
+	conn *websocket.Conn
+	ctx  context.Context // the connection's; ends when the client goes
+	r    *http.Request   // the upgrade request, with the client's cookies
+
+	mu    sync.Mutex
+	views map[string]context.CancelFunc
+	wg    sync.WaitGroup
+}
+
+// subscribe starts view's stream, unless it is already running
+func (s *roomSocket) subscribe(view string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, running := s.views[view]; running {
+		return
+	}
+	ctx, stop := context.WithCancel(s.ctx)
+	s.views[view] = stop
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.stream(ctx, view)
+
+		s.mu.Lock()
+		if ctx.Err() == nil {
+			delete(s.views, view)
+		}
+		s.mu.Unlock()
+		stop()
+	}()
+}
+
+// unsubscribe ends view's stream
+func (s *roomSocket) unsubscribe(view string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stop, running := s.views[view]; running {
+		stop()
+		delete(s.views, view)
+	}
+}
+
+// wait blocks until every view's stream has ended
+func (s *roomSocket) wait() {
+	s.wg.Wait()
+}
+
+// stream runs view's room stream until it ends or ctx is cancelled, then
+// tells the client why it ended unless the client asked for that
+func (s *roomSocket) stream(ctx context.Context, view string) {
+	req := s.r.Clone(ctx)
+	req.URL.RawQuery = "view=" + view
+	out := &socketStream{socket: s, view: view, header: make(http.Header)}
+
+	s.h.drainableSSE(s.h.limitedSSE(s.h.StreamRoom))(out, req)
+
+	if ctx.Err() != nil {
+		return
+	}
+	if out.status != 0 && out.status != http.StatusOK {
+		s.send(socketMessage{Type: "error", View: view, Status: out.status, Message: strings.TrimSpace(string(out.pending))})
+		return
+	}
+	s.send(socketMessage{Type: "closed", View: view})
+}
+
+// send writes msg within the SSE write timeout. A write that runs out of
+// time closes the connection, as a stalled SSE write ends its stream.
+func (s *roomSocket) send(msg socketMessage) error {
+	ctx := s.ctx
+	if timeout := s.h.config().Server.SSEWriteTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return wsjson.Write(ctx, s.conn, msg)
+}
+
+// socketStream is the http.ResponseWriter a room stream writes to when it
+// runs over a WebSocket. It splits what the stream writes into SSE events
+// and sends each one to the client as a socketMessage for its view.
+type socketStream struct {
+	socket  *roomSocket
+	view    string
+	header  http.Header
+	status  int
+	pending []byte // written but not yet a whole event, or a refusal's body
+}
+
+func (s *socketStream) Header() http.Header {
+	return s.header
+}
+
+func (s *socketStream) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+}
+
+func (s *socketStream) Write(p []byte) (int, error) {
+	s.WriteHeader(http.StatusOK)
+	s.pending = append(s.pending, p...)
+	if s.status != http.StatusOK {
+		return len(p), nil
+	}
+	for {
+		end := bytes.Index(s.pending, []byte("\n\n"))
+		if end < 0 {
+			return len(p), nil
+		}
+		msg := socketEvent(s.view, string(s.pending[:end]))
+		s.pending = s.pending[end+2:]
+		if err := s.socket.send(msg); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush is a no-op: every whole event is sent as soon as it is written
+func (s *socketStream) Flush() {}
+
+// socketEvent turns one SSE event into a socketMessage. A keepalive comment
+// becomes a "keepalive" message, so proxies see the connection in use.
+func socketEvent(view, event string) socketMessage {
+	msg := socketMessage{Type: "keepalive", View: view}
+	for _, line := range strings.Split(event, "\n") {
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			msg.Type = "event"
+			msg.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if msg.Args == nil {
+				msg.Args = make(map[string]string)
+			}
+			key, value, _ := strings.Cut(strings.TrimPrefix(line, "data: "), " ")
+			if prev, ok := msg.Args[key]; ok {
+				value = prev + "\n" + value
+			}
+			msg.Args[key] = value
+		}
+	}
+	return msg
+}
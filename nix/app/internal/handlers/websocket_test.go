@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+func dialRoomSocket(t *testing.T, server *httptest.Server, roomCode, playerID string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{}
+	if playerID != "" {
+		header.Set("Cookie", "player_"+roomCode+"="+playerID)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http")+"/ws/room/"+roomCode, &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+// readSocketUntil reads messages until match accepts one
+func readSocketUntil(t *testing.T, conn *websocket.Conn, match func(socketMessage) bool) socketMessage {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for {
+		var msg socketMessage
+		if err := wsjson.Read(ctx, conn, &msg); err != nil {
+			t.Fatalf("expected a matching message, got %v", err)
+		}
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func TestRoomSocket_StreamsTheLobbyView(t *testing.T) {
+	h := newTestHandler()
+	server := httptest.NewServer(newTestRouter(h))
+	defer server.Close()
+	room, player := newPhaseTestRoom(t, h)
+
+	conn := dialRoomSocket(t, server, room.Code, player.ID)
+	if err := wsjson.Write(context.Background(), conn, socketMessage{Type: "subscribe", View: "lobby"}); err != nil {
+		t.Fatal(err)
+	}
+	readSocketUntil(t, conn, func(msg socketMessage) bool {
+		return msg.View == "lobby" && msg.Event == "datastar-patch-signals"
+	})
+
+	if _, err := room.PostChat(player.ID, "over the socket", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	h.eventBus.Publish(Event{Type: EventChatPosted, RoomCode: room.Code})
+
+	msg := readSocketUntil(t, conn, func(msg socketMessage) bool {
+		return msg.Type == "event" && msg.Args["selector"] == "#room-chat-log"
+	})
+	if msg.Event != "datastar-patch-elements" || !strings.Contains(msg.Args["elements"], "over the socket") {
+		t.Errorf("expected the chat log patch, got %+v", msg)
+	}
+}
+
+func TestRoomSocket_RefusesViewsTheClientCannotStream(t *testing.T) {
+	h := newTestHandler()
+	server := httptest.NewServer(newTestRouter(h))
+	defer server.Close()
+	room, _ := newPhaseTestRoom(t, h)
+
+	conn := dialRoomSocket(t, server, room.Code, "")
+	for _, view := range []string{"overlay", "lobby"} {
+		if err := wsjson.Write(context.Background(), conn, socketMessage{Type: "subscribe", View: view}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bad := readSocketUntil(t, conn, func(msg socketMessage) bool { return msg.View == "overlay" })
+	if bad.Type != "error" || bad.Status != http.StatusBadRequest {
+		t.Errorf("expected an unknown view refused, got %+v", bad)
+	}
+	unseated := readSocketUntil(t, conn, func(msg socketMessage) bool { return msg.View == "lobby" })
+	if unseated.Type != "error" || unseated.Status != http.StatusUnauthorized || unseated.Message != "Not in room" {
+		t.Errorf("expected the lobby refused without a seat, got %+v", unseated)
+	}
+}
+
+func TestSocketEventJoinsMultilineData(t *testing.T) {
+	msg := socketEvent("game", "event: datastar-patch-elements\ndata: selector #log\ndata: elements <ul>\ndata: elements </ul>")
+	if msg.Type != "event" || msg.Event != "datastar-patch-elements" || msg.Args["selector"] != "#log" || msg.Args["elements"] != "<ul>\n</ul>" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if keepalive := socketEvent("game", ":"); keepalive.Type != "keepalive" {
+		t.Errorf("expected a keepalive, got %+v", keepalive)
+	}
+}
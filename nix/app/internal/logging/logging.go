@@ -0,0 +1,58 @@
+// Package logging builds the server's structured logger. Records go out
+// through the standard logger's current output, so per-room log capture and
+// privacy redaction see them like any other log line.
+package logging
+
+import (
+	"io"
+	"log"
+	"log/slog"
+)
+
+// Attribute keys shared by every handler, so logs can be queried by them
+const (
+	KeyRequestID = "request_id"
+	KeyRoom      = "room"
+	KeyPlayer    = "player"
+	KeyEvent     = "event"
+	KeyView      = "view"
+	KeyError     = "err"
+)
+
+// New returns a logger writing records at level and above in format, "text"
+// or "json". An empty or unknown level logs info and up; an empty or unknown
+// format is text.
+func New(level, format string) *slog.Logger {
+	return NewTo(stdOutput{}, level, format)
+}
+
+// NewTo is New writing to w instead of the standard logger's output
+func NewTo(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(level)}
+	if format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// ParseLevel maps a logLevel setting to its slog level, info when unknown
+func ParseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// stdOutput writes to whatever the standard logger writes to at the time,
+// which CaptureRoomLogs and RedactLogs replace after the logger is built
+type stdOutput struct{}
+
+func (stdOutput) Write(p []byte) (int, error) {
+	return log.Writer().Write(p)
+}
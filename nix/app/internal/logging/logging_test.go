@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewToFiltersByLevel(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewTo(&out, "warn", "text")
+
+	logger.Info("quiet")
+	logger.Warn("loud", KeyRoom, "ABCDE")
+
+	if strings.Contains(out.String(), "quiet") {
+		t.Errorf("expected info to be dropped at warn, got %s", out.String())
+	}
+	if !strings.Contains(out.String(), "msg=loud room=ABCDE") {
+		t.Errorf("expected the warning with its room, got %s", out.String())
+	}
+}
+
+func TestNewToWritesJSON(t *testing.T) {
+	var out bytes.Buffer
+	NewTo(&out, "debug", "json").Debug("keepalive sent", KeyRoom, "ABCDE", KeyRequestID, "host/1")
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON record, got %s: %v", out.String(), err)
+	}
+	if record["level"] != "DEBUG" || record["msg"] != "keepalive sent" || record[KeyRoom] != "ABCDE" || record[KeyRequestID] != "host/1" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestNewWritesThroughTheStandardLoggersOutput(t *testing.T) {
+	logger := New("", "")
+
+	// The output is swapped after the logger is built, as CaptureRoomLogs does
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	logger.Info("hello")
+	logger.Debug("hidden")
+
+	if !strings.Contains(out.String(), "msg=hello") || strings.Contains(out.String(), "hidden") {
+		t.Errorf("expected info and up in the standard logger's output, got %s", out.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for level, want := range map[string]string{"debug": "DEBUG", "info": "INFO", "warn": "WARN", "error": "ERROR", "": "INFO", "loud": "INFO"} {
+		if got := ParseLevel(level).String(); got != want {
+			t.Errorf("ParseLevel(%q) = %s, want %s", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package mail sends the server's few emails, such as room invites, over SMTP
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Sender delivers a plain text email to one recipient
+type Sender interface {
+	Send(to, subject, body string) error
+}
+
+// SMTP sends through a mail server, upgrading to TLS when it offers STARTTLS
+// and authenticating when Username is set. Credentials are only sent over
+// TLS, or to a server on localhost.
+type SMTP struct {
+	Addr     string // host:port
+	From     string // an address, optionally with a name: Treacherest <games@example.com>
+	Username string
+	Password string
+	Timeout  time.Duration // for the whole exchange; 0 means 10 seconds
+}
+
+// Send implements Sender
+func (s SMTP) Send(to, subject, body string) error {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return fmt.Errorf("smtp address %q: %w", s.Addr, err)
+	}
+	from, err := netmail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("sender %q: %w", s.From, err)
+	}
+
+	conn, err := net.DialTimeout("tcp", s.Addr, timeout)
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", s.Addr, err)
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("connect to %s: %w", s.Addr, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("start TLS with %s: %w", s.Addr, err)
+		}
+	}
+	if s.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return fmt.Errorf("authenticate with %s: %w", s.Addr, err)
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(Message(s.From, to, subject, body)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Message formats a plain text email with CRLF line endings
+func Message(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,30 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	got := string(Message("games@example.com", "alice@example.com", "Join Room ABCDE ✨", "Hi\nJoin here"))
+
+	for _, want := range []string{
+		"From: games@example.com\r\n",
+		"To: alice@example.com\r\n",
+		"Subject: =?utf-8?q?Join_Room_ABCDE_=E2=9C=A8?=\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n\r\nHi\r\nJoin here",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestSendRefusesABadAddress(t *testing.T) {
+	if err := (SMTP{Addr: "no-port", From: "games@example.com"}).Send("alice@example.com", "s", "b"); err == nil {
+		t.Error("expected an address without a port refused")
+	}
+	if err := (SMTP{Addr: "localhost:25", From: "not an address"}).Send("alice@example.com", "s", "b"); err == nil || !strings.Contains(err.Error(), "sender") {
+		t.Errorf("expected a malformed sender refused, got %v", err)
+	}
+}
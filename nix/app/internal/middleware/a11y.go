@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// A11yViolation is one accessibility problem found in rendered HTML
+type A11yViolation struct {
+	Component string // id of the nearest preceding element with an id, or the patch selector
+	Element   string
+	Problem   string
+}
+
+var (
+	openTagPattern     = regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9-]*)((?:\s+[^\s=>"']+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'>]+))?)*)\s*/?>`)
+	attrPattern        = regexp.MustCompile(`([^\s=>"']+)(?:\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+))?`)
+	anyTagPattern      = regexp.MustCompile(`<[^>]*>`)
+	lowContrastPattern = regexp.MustCompile(`^text-[a-z0-9-]+/(5|10|20|30|40)$`)
+)
+
+// AuditHTML runs basic accessibility checks over an HTML page or fragment:
+// images without alt text, buttons without an accessible name, and text
+// classes whose opacity is likely too low to meet contrast requirements.
+func AuditHTML(fragment, component string) []A11yViolation {
+	var violations []A11yViolation
+	nearest := component
+
+	for _, loc := range openTagPattern.FindAllStringSubmatchIndex(fragment, -1) {
+		tag := strings.ToLower(fragment[loc[2]:loc[3]])
+		attrs := parseAttrs(fragment[loc[4]:loc[5]])
+		if id := attrs["id"]; id != "" {
+			nearest = "#" + id
+		}
+
+		switch tag {
+		case "img":
+			if _, ok := attrs["alt"]; !ok {
+				violations = append(violations, A11yViolation{nearest, "<img>", "image has no alt text"})
+			}
+		case "button":
+			if !hasAccessibleName(attrs) && buttonText(fragment[loc[1]:]) == "" {
+				violations = append(violations, A11yViolation{nearest, "<button>", "button has no accessible name"})
+			}
+		}
+
+		for _, class := range strings.Fields(attrs["class"]) {
+			if lowContrastPattern.MatchString(class) {
+				violations = append(violations, A11yViolation{nearest, "<" + tag + ">", "low-contrast text class " + class})
+			}
+		}
+	}
+	return violations
+}
+
+func parseAttrs(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		attrs[strings.ToLower(m[1])] = strings.Trim(m[2], `"'`)
+	}
+	return attrs
+}
+
+func hasAccessibleName(attrs map[string]string) bool {
+	for _, name := range []string{"aria-label", "aria-labelledby", "title"} {
+		if strings.TrimSpace(attrs[name]) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buttonText returns the visible text (including image alt text) up to the closing </button>
+func buttonText(rest string) string {
+	end := strings.Index(strings.ToLower(rest), "</button>")
+	if end < 0 {
+		return ""
+	}
+	inner := rest[:end]
+	var alts []string
+	for _, loc := range openTagPattern.FindAllStringSubmatchIndex(inner, -1) {
+		if alt := parseAttrs(inner[loc[4]:loc[5]])["alt"]; alt != "" {
+			alts = append(alts, alt)
+		}
+	}
+	text := anyTagPattern.ReplaceAllString(inner, " ")
+	return strings.TrimSpace(text + strings.Join(alts, " "))
+}
+
+// A11yAudit is a development middleware that audits HTML pages and Datastar
+// element patches as they are written, logging violations without changing the response.
+func A11yAudit() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			aw := &auditWriter{ResponseWriter: w, route: r.Method + " " + r.URL.Path}
+			next.ServeHTTP(aw, r)
+			aw.audit(true)
+		})
+	}
+}
+
+// auditWriter tees text/html and text/event-stream bodies into a buffer that is
+// audited on every flush (SSE) and once the handler returns (pages)
+type auditWriter struct {
+	http.ResponseWriter
+	route string
+	buf   bytes.Buffer
+}
+
+func (aw *auditWriter) Write(p []byte) (int, error) {
+	contentType := aw.Header().Get("Content-Type")
+	if strings.HasPrefix(contentType, "text/html") || strings.HasPrefix(contentType, "text/event-stream") {
+		aw.buf.Write(p)
+	}
+	return aw.ResponseWriter.Write(p)
+}
+
+func (aw *auditWriter) Flush() {
+	aw.audit(false)
+	if flusher, ok := aw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (aw *auditWriter) Unwrap() http.ResponseWriter {
+	return aw.ResponseWriter
+}
+
+func (aw *auditWriter) audit(final bool) {
+	if aw.buf.Len() == 0 {
+		return
+	}
+
+	if !strings.HasPrefix(aw.Header().Get("Content-Type"), "text/event-stream") {
+		if final {
+			aw.report(AuditHTML(aw.buf.String(), "page"))
+			aw.buf.Reset()
+		}
+		return
+	}
+
+	// Audit complete SSE events only; keep a trailing partial event for the next flush
+	data := aw.buf.String()
+	cut := strings.LastIndex(data, "\n\n")
+	if cut < 0 {
+		return
+	}
+	for _, event := range strings.Split(data[:cut], "\n\n") {
+		if selector, elements := parsePatchElements(event); elements != "" {
+			aw.report(AuditHTML(elements, selector))
+		}
+	}
+	aw.buf.Reset()
+	aw.buf.WriteString(data[cut+2:])
+}
+
+// parsePatchElements extracts the selector and HTML from a datastar-patch-elements event
+func parsePatchElements(event string) (selector, elements string) {
+	var lines []string
+	for _, line := range strings.Split(event, "\n") {
+		switch {
+		case strings.HasPrefix(line, "data: selector "):
+			selector = strings.TrimPrefix(line, "data: selector ")
+		case strings.HasPrefix(line, "data: elements "):
+			lines = append(lines, strings.TrimPrefix(line, "data: elements "))
+		}
+	}
+	return selector, strings.Join(lines, "\n")
+}
+
+func (aw *auditWriter) report(violations []A11yViolation) {
+	for _, v := range violations {
+		log.Printf("♿ a11y [%s] %s %s: %s", aw.route, v.Component, v.Element, v.Problem)
+	}
+}
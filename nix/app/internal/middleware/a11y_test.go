@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	datastar "github.com/starfederation/datastar-go/datastar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		problems []string
+	}{
+		{
+			name: "clean fragment",
+			html: `<div id="zone"><img src="a.png" alt="Card"><img src="b.png" alt=""><button>Start</button><button aria-label="Close"><svg></svg></button></div>`,
+		},
+		{
+			name:     "image without alt",
+			html:     `<div id="roster"><img src="card.jpg"></div>`,
+			problems: []string{"image has no alt text"},
+		},
+		{
+			name:     "icon-only button",
+			html:     `<button class="btn"><svg><path d="M0"/></svg></button>`,
+			problems: []string{"button has no accessible name"},
+		},
+		{
+			name: "button named by image alt text",
+			html: `<button><img src="x.png" alt="Reveal"></button>`,
+		},
+		{
+			name:     "low-contrast text class",
+			html:     `<p class="text-sm text-base-content/30">hint</p><p class="text-base-content/60">ok</p>`,
+			problems: []string{"low-contrast text class text-base-content/30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var problems []string
+			for _, v := range AuditHTML(tt.html, "page") {
+				problems = append(problems, v.Problem)
+			}
+			assert.Equal(t, tt.problems, problems)
+		})
+	}
+}
+
+func TestAuditHTMLNamesNearestComponent(t *testing.T) {
+	violations := AuditHTML(`<section id="zone-actions"><div><img src="x.png"></div></section>`, "page")
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "#zone-actions", violations[0].Component)
+		assert.Equal(t, "<img>", violations[0].Element)
+	}
+}
+
+func TestA11yAuditLogsSSEPatches(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := A11yAudit()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sse := datastar.NewSSE(w, r)
+		sse.PatchElements(`<div id="player-list"><img src="avatar.png"></div>`, datastar.WithSelector("#player-list"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/sse/lobby/ABCDE", nil))
+
+	assert.Contains(t, w.Body.String(), `avatar.png`, "the response must pass through unchanged")
+	assert.Contains(t, logs.String(), "GET /sse/lobby/ABCDE")
+	assert.Contains(t, logs.String(), "#player-list <img>: image has no alt text")
+}
+
+func TestA11yAuditIgnoresNonHTML(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := A11yAudit()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"html":"<img src=x>"}`))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api", nil))
+
+	assert.Empty(t, logs.String())
+}
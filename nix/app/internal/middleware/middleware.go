@@ -1,43 +1,95 @@
 package middleware
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
 
-// RequestSizeLimiter limits the size of incoming request bodies
-func RequestSizeLimiter(maxBytes int64) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
-			next.ServeHTTP(w, r)
-		})
+// RequestTooLarge is the JSON body of a 413 response
+type RequestTooLarge struct {
+	Error    string `json:"error"` // always "request_too_large"
+	Message  string `json:"message"`
+	MaxBytes int64  `json:"maxBytes"`
+}
+
+// WriteRequestTooLarge answers 413 for a request body over maxBytes
+func WriteRequestTooLarge(w http.ResponseWriter, maxBytes int64) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	json.NewEncoder(w).Encode(RequestTooLarge{
+		Error:    "request_too_large",
+		Message:  fmt.Sprintf("Request body is larger than %d bytes", maxBytes),
+		MaxBytes: maxBytes,
+	})
+}
+
+// RejectIfTooLarge answers 413 when err came from reading a body past the
+// RequestSizeLimiter limit
+func RejectIfTooLarge(w http.ResponseWriter, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return false
 	}
+	WriteRequestTooLarge(w, tooLarge.Limit)
+	return true
 }
 
-// SecurityHeaders adds security headers to all responses
-func SecurityHeaders() func(http.Handler) http.Handler {
+// RequestSizeLimiter limits the size of incoming request bodies. A declared
+// Content-Length over the limit is refused before reading; form posts are
+// parsed here so a streamed one over the limit, or a malformed one, is refused
+// instead of reaching the handler with its fields silently missing. JSON handlers check
+// their decode errors with RejectIfTooLarge.
+func RequestSizeLimiter(maxBytes int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Security headers that work with or without HTTPS
-			w.Header().Set("X-Content-Type-Options", "nosniff")
-			w.Header().Set("X-Frame-Options", "DENY")
-			w.Header().Set("X-XSS-Protection", "1; mode=block")
-			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+			if r.ContentLength > maxBytes {
+				WriteRequestTooLarge(w, maxBytes)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+				if err := r.ParseForm(); err != nil {
+					if !RejectIfTooLarge(w, err) {
+						http.Error(w, "Failed to parse form", http.StatusBadRequest)
+					}
+					return
+				}
+			}
 
 			next.ServeHTTP(w, r)
+
+			// Drain what the handler left unread, at most the limit, so the
+			// connection can be reused for the next request
+			io.Copy(io.Discard, r.Body)
 		})
 	}
 }
 
-// RateLimiter implements per-IP rate limiting
+// rateLimiterSweepEvery is how often a RateLimiter drops the limiters of
+// clients that have gone quiet
+const rateLimiterSweepEvery = time.Minute
+
+// RateLimiter implements per-key token bucket rate limiting, keyed by client
+// IP unless MiddlewareBy is given another key
 type RateLimiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.Mutex
-	rate     rate.Limit
-	burst    int
+	limiters  map[string]*rate.Limiter
+	mu        sync.Mutex
+	rate      rate.Limit
+	burst     int
+	lastSweep time.Time
 }
 
 // NewRateLimiter creates a new rate limiter
@@ -49,11 +101,21 @@ func NewRateLimiter(rateLimit float64, burst int) *RateLimiter {
 	}
 }
 
-// getLimiter returns the rate limiter for the given key (IP address)
-func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
+// getLimiter returns the rate limiter for the given key. Limiters whose
+// bucket has refilled are dropped now and then: a fresh one is the same.
+func (rl *RateLimiter) getLimiter(key string, now time.Time) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	if now.Sub(rl.lastSweep) >= rateLimiterSweepEvery {
+		rl.lastSweep = now
+		for k, limiter := range rl.limiters {
+			if limiter.TokensAt(now) >= float64(rl.burst) {
+				delete(rl.limiters, k)
+			}
+		}
+	}
+
 	limiter, exists := rl.limiters[key]
 	if !exists {
 		limiter = rate.NewLimiter(rl.rate, rl.burst)
@@ -63,18 +125,41 @@ func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
 	return limiter
 }
 
-// Middleware returns the rate limiting middleware
-func (rl *RateLimiter) Middleware() func(http.Handler) http.Handler {
+// Allow takes a token from key's bucket. When it is empty it returns how
+// long until the next token, rounded up to a whole second for Retry-After.
+func (rl *RateLimiter) Allow(key string) (bool, time.Duration) {
+	now := time.Now()
+	reservation := rl.getLimiter(key, now).ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return true, 0
+	}
+	reservation.CancelAt(now)
+	return false, time.Duration(math.Ceil(delay.Seconds())) * time.Second
+}
+
+// Middleware returns the rate limiting middleware, keyed by client IP as
+// proxies report it
+func (rl *RateLimiter) Middleware(proxies TrustedProxies) func(http.Handler) http.Handler {
+	return rl.MiddlewareBy(proxies.ClientIP)
+}
+
+// MiddlewareBy returns rate limiting middleware keyed by key(r). Requests
+// with an empty key are not limited. A refused request gets 429 with a
+// Retry-After of the seconds until its bucket has a token again.
+func (rl *RateLimiter) MiddlewareBy(key func(*http.Request) string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Use IP address as rate limit key
-			key := r.RemoteAddr
-			if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-				key = forwarded
+			k := key(r)
+			if k == "" {
+				next.ServeHTTP(w, r)
+				return
 			}
-
-			limiter := rl.getLimiter(key)
-			if !limiter.Allow() {
+			if ok, retryAfter := rl.Allow(k); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
 				http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
 				return
 			}
@@ -83,3 +168,61 @@ func (rl *RateLimiter) Middleware() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// TrustedProxies are the reverse proxies, as address ranges, whose
+// forwarding headers the server believes
+type TrustedProxies []netip.Prefix
+
+// Trusts reports whether r came straight from one of the proxies
+func (p TrustedProxies) Trusts(r *http.Request) bool {
+	return p.contains(remoteHost(r))
+}
+
+// ClientIP returns the address a request came from. Behind a trusted proxy
+// it is the rightmost X-Forwarded-For hop that isn't a trusted proxy too:
+// the hops left of it are whatever the client sent. From anyone else the
+// header is ignored, so a client can't pick the address it is limited by.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	remote := remoteHost(r)
+	if !p.contains(remote) {
+		return remote
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		client = hop
+		if !p.contains(hop) {
+			break
+		}
+	}
+	return client
+}
+
+func (p TrustedProxies) contains(ip string) bool {
+	if len(p) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteHost is the address of r's peer, without the port
+func remoteHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSizeLimiterDrainsUnreadBody(t *testing.T) {
+	body := strings.NewReader("ignored=by+the+handler")
+	handler := RequestSizeLimiter(1024)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", "text/plain")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Zero(t, body.Len(), "the unread body should be drained")
+}
+
+func TestRequestSizeLimiterRefusesDeclaredOversizedBody(t *testing.T) {
+	called := false
+	handler := RequestSizeLimiter(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/", strings.NewReader("far more than eight bytes")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.False(t, called, "the handler should not run")
+}
+
+func TestRateLimiterKeysByClientIPAndSetsRetryAfter(t *testing.T) {
+	proxies := TrustedProxies{netip.MustParsePrefix("10.0.0.0/8")}
+	handler := NewRateLimiter(0.5, 1).Middleware(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	request := func(remoteAddr, forwarded string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remoteAddr
+		if forwarded != "" {
+			req.Header.Set("X-Forwarded-For", forwarded)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, request("192.0.2.1:1000", "").Code)
+	w := request("192.0.2.1:2000", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code, "a new connection from the same IP shares its bucket")
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusTooManyRequests, request("192.0.2.1:3000", "198.51.100.9").Code, "an untrusted peer's forwarded address is ignored")
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000", "203.0.113.7, 10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.2:1000", "203.0.113.7").Code, "the proxy's client shares its bucket")
+	assert.Equal(t, http.StatusTooManyRequests, request("10.0.0.2:1000", "198.51.100.9, 203.0.113.7").Code, "hops the client added are ignored")
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000", "198.51.100.4").Code)
+}
+
+func TestClientIP(t *testing.T) {
+	proxies := TrustedProxies{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.0.2.7/32")}
+	cases := map[string]struct {
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		"no header":             {"198.51.100.4:5555", nil, "198.51.100.4"},
+		"untrusted peer":        {"198.51.100.4:5555", []string{"203.0.113.7"}, "198.51.100.4"},
+		"trusted proxy":         {"10.0.0.1:5555", []string{" 203.0.113.7 "}, "203.0.113.7"},
+		"spoofed hops":          {"10.0.0.1:5555", []string{"1.2.3.4, 203.0.113.7"}, "203.0.113.7"},
+		"chained proxies":       {"10.0.0.1:5555", []string{"203.0.113.7, 10.0.0.9"}, "203.0.113.7"},
+		"split header":          {"10.0.0.1:5555", []string{"1.2.3.4", "203.0.113.7"}, "203.0.113.7"},
+		"all trusted":           {"10.0.0.1:5555", []string{"10.0.0.8, 10.0.0.9"}, "10.0.0.8"},
+		"trusted but no header": {"10.0.0.1:5555", nil, "10.0.0.1"},
+		"mapped proxy address":  {"[::ffff:192.0.2.7]:5555", []string{"203.0.113.7"}, "203.0.113.7"},
+		"remote without a port": {"198.51.100.4", nil, "198.51.100.4"},
+	}
+	for name, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tc.remoteAddr
+		for _, value := range tc.forwarded {
+			req.Header.Add("X-Forwarded-For", value)
+		}
+		if got := proxies.ClientIP(req); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", name, tc.want, got)
+		}
+	}
+}
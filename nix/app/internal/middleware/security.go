@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cspDirectives allow exactly what the pages load: datastar from jsDelivr,
+// which compiles data-* expressions at runtime (unsafe-eval), the inline
+// scripts and style attributes in the layouts, Google and mana fonts, card
+// art and QR codes as data: URIs, and fetches and streams back to this server
+var cspDirectives = []string{
+	"default-src 'self'",
+	"script-src 'self' 'unsafe-inline' 'unsafe-eval' https://cdn.jsdelivr.net",
+	"style-src 'self' 'unsafe-inline' https://fonts.googleapis.com https://cdn.jsdelivr.net",
+	"font-src 'self' data: https://fonts.gstatic.com https://cdn.jsdelivr.net",
+	"img-src 'self' data:",
+	"connect-src 'self'",
+	"object-src 'none'",
+	"base-uri 'self'",
+	"form-action 'self'",
+}
+
+// ContentSecurityPolicy returns policy, or the generated policy when it is
+// empty, with frame-ancestors set to frameAncestors ('none' when empty).
+// Any frame-ancestors in policy is dropped so framing is set in one place.
+func ContentSecurityPolicy(policy, frameAncestors string) string {
+	var directives []string
+	if policy == "" {
+		directives = append(directives, cspDirectives...)
+	} else {
+		for _, directive := range strings.Split(policy, ";") {
+			directive = strings.TrimSpace(directive)
+			if directive == "" || strings.HasPrefix(directive, "frame-ancestors") {
+				continue
+			}
+			directives = append(directives, directive)
+		}
+	}
+	if frameAncestors == "" {
+		frameAncestors = "'none'"
+	}
+	return strings.Join(append(directives, "frame-ancestors "+frameAncestors), "; ")
+}
+
+// SecurityPolicy configures SecurityHeaders
+type SecurityPolicy struct {
+	ContentSecurityPolicy string        // empty sends no Content-Security-Policy
+	HSTSMaxAge            time.Duration // 0 sends no Strict-Transport-Security
+}
+
+// SecurityHeaders adds security headers to all responses. Pages meant to be
+// framed replace X-Frame-Options and the policy's frame-ancestors themselves.
+func SecurityHeaders(policy SecurityPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Security headers that work with or without HTTPS
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("X-Frame-Options", "DENY")
+			w.Header().Set("X-XSS-Protection", "1; mode=block")
+			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+			if policy.ContentSecurityPolicy != "" {
+				w.Header().Set("Content-Security-Policy", policy.ContentSecurityPolicy)
+			}
+
+			// Browsers ignore HSTS over plain HTTP, so only send it over TLS,
+			// including TLS terminated by a proxy in front of the server
+			if policy.HSTSMaxAge > 0 && (r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https") {
+				w.Header().Set("Strict-Transport-Security", "max-age="+strconv.Itoa(int(policy.HSTSMaxAge.Seconds())))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentSecurityPolicy(t *testing.T) {
+	generated := ContentSecurityPolicy("", "")
+	assert.Contains(t, generated, "script-src 'self' 'unsafe-inline' 'unsafe-eval' https://cdn.jsdelivr.net")
+	assert.Contains(t, generated, "connect-src 'self'")
+	assert.Contains(t, generated, "frame-ancestors 'none'")
+
+	custom := ContentSecurityPolicy("default-src 'self'; frame-ancestors *;", "https://obs.example")
+	assert.Equal(t, "default-src 'self'; frame-ancestors https://obs.example", custom)
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	handler := SecurityHeaders(SecurityPolicy{
+		ContentSecurityPolicy: "default-src 'self'",
+		HSTSMaxAge:            24 * time.Hour,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "strict-origin-when-cross-origin", w.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"), "HSTS is meaningless over plain HTTP")
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, "max-age=86400", w.Header().Get("Strict-Transport-Security"))
+}
@@ -0,0 +1,113 @@
+// Package privacy keeps player identities out of the server log when the
+// server runs in privacy logging mode.
+package privacy
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+)
+
+// DefaultMaxIdentities bounds memory; the identity added longest ago is
+// forgotten first
+const DefaultMaxIdentities = 10000
+
+// identity is what the log must not show for one player
+type identity struct {
+	sessionID string
+	name      string
+}
+
+// Redactor is an io.Writer for the standard logger. It passes every line on
+// to next with the player IDs and sessions it knows replaced by salted
+// hashes, and their names by their player's hash, so lines about one player
+// still read together without saying who they are. Only whole tokens are
+// replaced, so a short name leaves longer words alone.
+type Redactor struct {
+	mu            sync.Mutex
+	next          io.Writer
+	salt          []byte
+	maxIdentities int
+	identities    map[string]identity // by player ID
+	order         []string            // player IDs, oldest first
+	replacer      *tokenReplacer      // nil until the next Redact rebuilds it
+}
+
+// NewRedactor returns a Redactor writing through to next. Its salt is random,
+// so hashes only match within one run of the server.
+func NewRedactor(next io.Writer, maxIdentities int) *Redactor {
+	if maxIdentities <= 0 {
+		maxIdentities = DefaultMaxIdentities
+	}
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	return &Redactor{
+		next:          next,
+		salt:          salt,
+		maxIdentities: maxIdentities,
+		identities:    make(map[string]identity),
+	}
+}
+
+// Hash is the stand-in the log shows for id
+func (r *Redactor) Hash(id string) string {
+	sum := sha256.Sum256(append(append([]byte(nil), r.salt...), id...))
+	return "p-" + hex.EncodeToString(sum[:5])
+}
+
+// Add starts redacting a player's ID, session and name
+func (r *Redactor) Add(playerID, sessionID, name string) {
+	if playerID == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.identities[playerID]; !ok {
+		r.order = append(r.order, playerID)
+	}
+	r.identities[playerID] = identity{sessionID: sessionID, name: name}
+	for len(r.order) > r.maxIdentities {
+		delete(r.identities, r.order[0])
+		r.order = r.order[1:]
+	}
+	r.replacer = nil
+}
+
+// Redact returns text with every known identity replaced
+func (r *Redactor) Redact(text string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.replacements().Replace(text)
+}
+
+// Write passes p to the next writer with identities replaced
+func (r *Redactor) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.next, r.Redact(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// replacements builds the replacer for the current identities. The caller
+// holds r.mu.
+func (r *Redactor) replacements() *tokenReplacer {
+	if r.replacer != nil {
+		return r.replacer
+	}
+	var pairs []replacement
+	for playerID, id := range r.identities {
+		hash := r.Hash(playerID)
+		pairs = append(pairs, replacement{playerID, hash})
+		if id.sessionID != "" {
+			pairs = append(pairs, replacement{id.sessionID, r.Hash(id.sessionID)})
+		}
+		if id.name != "" {
+			pairs = append(pairs, replacement{id.name, hash})
+		}
+	}
+	r.replacer = newTokenReplacer(pairs)
+	return r.replacer
+}
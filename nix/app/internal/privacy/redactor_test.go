@@ -0,0 +1,63 @@
+package privacy
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestRedactorHashesIdentities(t *testing.T) {
+	var out bytes.Buffer
+	redactor := NewRedactor(&out, 0)
+	logger := log.New(redactor, "", 0)
+
+	redactor.Add("player-1", "session-abc", "Alice")
+	logger.Printf("🎲 Player Alice (player-1, session session-abc) assigned role: Leader")
+
+	line := out.String()
+	for _, secret := range []string{"Alice", "player-1", "session-abc"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("expected %q out of the log, got %q", secret, line)
+		}
+	}
+	hash := redactor.Hash("player-1")
+	if strings.Count(line, hash) != 2 || !strings.Contains(line, redactor.Hash("session-abc")) {
+		t.Errorf("expected the name and ID as the player's hash, got %q", line)
+	}
+	if !strings.Contains(line, "assigned role: Leader") {
+		t.Errorf("expected the rest of the line kept, got %q", line)
+	}
+}
+
+func TestRedactorHashesOnlyMatchWithinARun(t *testing.T) {
+	a, b := NewRedactor(&bytes.Buffer{}, 0), NewRedactor(&bytes.Buffer{}, 0)
+	if a.Hash("player-1") != a.Hash("player-1") {
+		t.Error("expected a run to hash an ID the same way every time")
+	}
+	if a.Hash("player-1") == b.Hash("player-1") {
+		t.Error("expected each run to salt its hashes differently")
+	}
+}
+
+func TestRedactorForgetsOldestIdentity(t *testing.T) {
+	redactor := NewRedactor(&bytes.Buffer{}, 2)
+	redactor.Add("p1", "", "Alice")
+	redactor.Add("p2", "", "Bob")
+	redactor.Add("p3", "", "Carol")
+
+	if got := redactor.Redact("Alice Bob Carol"); !strings.HasPrefix(got, "Alice ") || strings.Contains(got, "Bob") || strings.Contains(got, "Carol") {
+		t.Errorf("expected only the two newest identities redacted, got %q", got)
+	}
+}
+
+func TestRedactorLeavesLongerWordsAlone(t *testing.T) {
+	redactor := NewRedactor(&bytes.Buffer{}, 0)
+	redactor.Add("p1", "", "a")
+
+	got := redactor.Redact("Player a started a game in room ABCDE")
+	hash := redactor.Hash("p1")
+	if want := "Player " + hash + " started " + hash + " game in room ABCDE"; got != want {
+		t.Errorf("expected only the name as a whole word redacted, got %q", got)
+	}
+}
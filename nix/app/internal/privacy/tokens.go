@@ -0,0 +1,149 @@
+package privacy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names and IDs only count where they stand as whole tokens, so a player
+// called "a" or "Al" doesn't match inside "Alice" or every word with an a in
+// it. A token boundary is anything but a letter, digit or underscore.
+
+// isWordRune reports whether r runs on into a neighbouring token
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// startsToken reports whether a term starting with first can start at
+// text[i]: a term starting with a letter mustn't follow one
+func startsToken(text string, i int, first rune) bool {
+	if i == 0 || !isWordRune(first) {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWordRune(before)
+}
+
+// endsToken reports whether a term ending with last can end at text[i]
+func endsToken(text string, i int, last rune) bool {
+	if i == len(text) || !isWordRune(last) {
+		return true
+	}
+	after, _ := utf8.DecodeRuneInString(text[i:])
+	return !isWordRune(after)
+}
+
+// Mentions reports whether text contains term as a whole token
+func Mentions(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+	for from := 0; from < len(text); {
+		i := strings.Index(text[from:], term)
+		if i < 0 {
+			return false
+		}
+		i += from
+		if startsToken(text, i, first) && endsToken(text, i+len(term), last) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		from = i + size
+	}
+	return false
+}
+
+// MentionsAny reports whether text contains one of terms as a whole token
+func MentionsAny(text string, terms ...string) bool {
+	for _, term := range terms {
+		if Mentions(text, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenReplacer replaces whole-token occurrences of its terms, trying the
+// longest first so a name never pre-empts a longer ID that starts with it
+type tokenReplacer struct {
+	byLead map[string][]replacement // by each term's lead, see leadOf
+}
+
+type replacement struct{ old, new string }
+
+func newTokenReplacer(pairs []replacement) *tokenReplacer {
+	t := &tokenReplacer{byLead: make(map[string][]replacement)}
+	for _, p := range pairs {
+		if p.old != "" {
+			lead := leadOf(p.old)
+			t.byLead[lead] = append(t.byLead[lead], p)
+		}
+	}
+	for _, candidates := range t.byLead {
+		sort.Slice(candidates, func(i, j int) bool {
+			if len(candidates[i].old) != len(candidates[j].old) {
+				return len(candidates[i].old) > len(candidates[j].old)
+			}
+			return candidates[i].old < candidates[j].old
+		})
+	}
+	return t
+}
+
+// leadOf is the run of word runes s starts with, or its first rune when that
+// isn't a word rune. A term can only start where text has the same lead.
+func leadOf(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	if !isWordRune(first) {
+		return s[:size]
+	}
+	end := strings.IndexFunc(s, func(r rune) bool { return !isWordRune(r) })
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+// Replace returns text with every whole-token term replaced
+func (t *tokenReplacer) Replace(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		lead := leadOf(text[i:])
+		if replaced := t.replaceAt(&b, text, i, lead); replaced > 0 {
+			i += replaced
+			continue
+		}
+		// No term starts mid-word, so a word that didn't match is skipped whole
+		b.WriteString(lead)
+		i += len(lead)
+	}
+	return b.String()
+}
+
+// replaceAt writes the replacement for the longest term at text[i] to b,
+// returning how much of text it replaced, or 0 when none is there
+func (t *tokenReplacer) replaceAt(b *strings.Builder, text string, i int, lead string) int {
+	candidates := t.byLead[lead]
+	if len(candidates) == 0 {
+		return 0
+	}
+	first, _ := utf8.DecodeRuneInString(lead)
+	if !startsToken(text, i, first) {
+		return 0
+	}
+	for _, c := range candidates {
+		if !strings.HasPrefix(text[i:], c.old) {
+			continue
+		}
+		last, _ := utf8.DecodeLastRuneInString(c.old)
+		if endsToken(text, i+len(c.old), last) {
+			b.WriteString(c.new)
+			return len(c.old)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,42 @@
+package privacy
+
+import "testing"
+
+func TestMentionsMatchesWholeTokens(t *testing.T) {
+	for _, tc := range []struct {
+		text, term string
+		want       bool
+	}{
+		{"Alice revealed their role", "Alice", true},
+		{"Alice's turn", "Alice", true},
+		{"Malice revealed their role", "Alice", false},
+		{"Alicent revealed their role", "Alice", false},
+		{"a player joined", "a", true},
+		{"Bob drew a card", "a", true},
+		{"Bob drew the card", "a", false},
+		{"Player player-12 joined", "player-1", false},
+		{"Player player-1 joined", "player-1", true},
+		{"Mary Jane joined", "Mary Jane", true},
+		{"Zoë joined", "Zo", false},
+		{"anything", "", false},
+	} {
+		if got := Mentions(tc.text, tc.term); got != tc.want {
+			t.Errorf("Mentions(%q, %q) = %v, want %v", tc.text, tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestTokenReplacerPrefersTheLongestTerm(t *testing.T) {
+	replacer := newTokenReplacer([]replacement{{"Ann", "A"}, {"Ann Lee", "AL"}, {"p1", "P"}})
+
+	for text, want := range map[string]string{
+		"Ann Lee joined":   "AL joined",
+		"Ann Leeds joined": "A Leeds joined",
+		"Anne and Ann":     "Anne and A",
+		"p1,p12 (p1)":      "P,p12 (P)",
+	} {
+		if got := replacer.Replace(text); got != want {
+			t.Errorf("Replace(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Package roomlog keeps a short history of server log lines and events per
+// room, so a Room Operator's problem report can include what the server saw.
+package roomlog
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"treacherest/internal/privacy"
+)
+
+const (
+	// DefaultPerRoom is how many entries each room keeps
+	DefaultPerRoom = 300
+
+	// DefaultMaxRooms bounds memory; the room written to least recently is
+	// forgotten first
+	DefaultMaxRooms = 500
+)
+
+// Entry kinds
+const (
+	KindLog   = "log"
+	KindEvent = "event"
+)
+
+// roomCodeToken finds words that could be room codes in a log line
+var roomCodeToken = regexp.MustCompile(`\b[A-Z0-9]{3,12}\b`)
+
+// Entry is one captured log line or published event
+type Entry struct {
+	At   time.Time `json:"at"`
+	Kind string    `json:"kind"`
+	Text string    `json:"text"`
+}
+
+// ring holds a room's most recent entries
+type ring struct {
+	entries []Entry
+	next    int
+	full    bool
+	touched time.Time
+}
+
+func (r *ring) add(e Entry) {
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.touched = e.At
+}
+
+// keep rebuilds the ring with only the entries keep accepts, returning how
+// many it dropped
+func (r *ring) keep(keep func(Entry) bool) int {
+	entries := r.ordered()
+	r.entries = make([]Entry, len(r.entries))
+	r.next, r.full = 0, false
+	dropped := 0
+	for _, e := range entries {
+		if !keep(e) {
+			dropped++
+			continue
+		}
+		r.entries[r.next] = e
+		r.next = (r.next + 1) % len(r.entries)
+	}
+	return dropped
+}
+
+func (r *ring) ordered() []Entry {
+	if !r.full {
+		return append([]Entry(nil), r.entries[:r.next]...)
+	}
+	return append(append([]Entry(nil), r.entries[r.next:]...), r.entries[:r.next]...)
+}
+
+// Capture is an io.Writer for the standard logger. It passes every line on
+// to next and also files it under each tracked room code the line mentions.
+type Capture struct {
+	mu       sync.Mutex
+	next     io.Writer
+	perRoom  int
+	maxRooms int
+	rooms    map[string]*ring
+	now      func() time.Time
+}
+
+// New returns a Capture writing through to next
+func New(next io.Writer, perRoom, maxRooms int) *Capture {
+	if perRoom <= 0 {
+		perRoom = DefaultPerRoom
+	}
+	if maxRooms <= 0 {
+		maxRooms = DefaultMaxRooms
+	}
+	return &Capture{
+		next:     next,
+		perRoom:  perRoom,
+		maxRooms: maxRooms,
+		rooms:    make(map[string]*ring),
+		now:      time.Now,
+	}
+}
+
+// Track starts capturing lines that mention code. Only tracked codes are
+// matched, so words like "DEBUG" never become rooms.
+func (c *Capture) Track(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ring(code)
+}
+
+// Forget drops everything captured for code
+func (c *Capture) Forget(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.rooms, code)
+}
+
+// Prune drops every room's entries from before cutoff, for history
+// retention, returning how many it dropped
+func (c *Capture) Prune(cutoff time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dropped := 0
+	for _, r := range c.rooms {
+		dropped += r.keep(func(e Entry) bool { return !e.At.Before(cutoff) })
+	}
+	return dropped
+}
+
+// Purge drops code's entries that mention any of terms as a whole token,
+// e.g. a player who asked to be forgotten, returning how many it dropped
+func (c *Capture) Purge(code string, terms ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.rooms[code]
+	if !ok {
+		return 0
+	}
+	return r.keep(func(e Entry) bool { return !privacy.MentionsAny(e.Text, terms...) })
+}
+
+// Record files a non-log entry, e.g. a published event, under code
+func (c *Capture) Record(code, kind, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.rooms[code]; ok {
+		r.add(Entry{At: c.now(), Kind: kind, Text: text})
+	}
+}
+
+// Entries returns code's captured entries, oldest first
+func (c *Capture) Entries(code string) []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.rooms[code]; ok {
+		return r.ordered()
+	}
+	return nil
+}
+
+// Write passes p to the next writer and captures its lines
+func (c *Capture) Write(p []byte) (int, error) {
+	n, err := c.next.Write(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for _, line := range bytes.Split(bytes.TrimRight(p, "\n"), []byte("\n")) {
+		seen := make(map[string]bool)
+		for _, token := range roomCodeToken.FindAll(line, -1) {
+			code := string(token)
+			if seen[code] {
+				continue
+			}
+			seen[code] = true
+			if r, ok := c.rooms[code]; ok {
+				r.add(Entry{At: now, Kind: KindLog, Text: string(line)})
+			}
+		}
+	}
+	return n, err
+}
+
+// ring returns code's ring, creating it and evicting the stalest room when
+// the capture is full. The caller holds c.mu.
+func (c *Capture) ring(code string) *ring {
+	if r, ok := c.rooms[code]; ok {
+		return r
+	}
+	if len(c.rooms) >= c.maxRooms {
+		var stalest string
+		for other, r := range c.rooms {
+			if stalest == "" || r.touched.Before(c.rooms[stalest].touched) {
+				stalest = other
+			}
+		}
+		delete(c.rooms, stalest)
+	}
+	r := &ring{entries: make([]Entry, c.perRoom), touched: c.now()}
+	c.rooms[code] = r
+	return r
+}
@@ -0,0 +1,92 @@
+package roomlog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestCaptureFilesLinesUnderTrackedRooms(t *testing.T) {
+	var out bytes.Buffer
+	capture := New(&out, 3, 2)
+	logger := log.New(capture, "", 0)
+
+	capture.Track("ABCDE")
+	logger.Printf("📡 SSE connection established for lobby ABCDE")
+	logger.Printf("DEBUG: unrelated line for room ZZZZZ")
+	capture.Record("ABCDE", KindEvent, "player_joined")
+
+	if !bytes.Contains(out.Bytes(), []byte("ZZZZZ")) {
+		t.Fatal("expected every line to reach the next writer")
+	}
+	entries := capture.Entries("ABCDE")
+	if len(entries) != 2 || entries[0].Kind != KindLog || entries[1].Text != "player_joined" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if capture.Entries("ZZZZZ") != nil || capture.Entries("DEBUG") != nil {
+		t.Error("expected untracked codes to be ignored")
+	}
+
+	for i := 0; i < 5; i++ {
+		logger.Printf("line %d in ABCDE", i)
+	}
+	entries = capture.Entries("ABCDE")
+	if len(entries) != 3 || entries[0].Text != "line 2 in ABCDE" || entries[2].Text != "line 4 in ABCDE" {
+		t.Fatalf("expected the three newest lines, oldest first, got %+v", entries)
+	}
+}
+
+func TestCaptureEvictsStalestRoom(t *testing.T) {
+	capture := New(&bytes.Buffer{}, 10, 2)
+	for _, code := range []string{"ROOM1", "ROOM2", "ROOM3"} {
+		capture.Track(code)
+		fmt.Fprintf(capture, "created %s\n", code)
+	}
+
+	if capture.Entries("ROOM1") != nil {
+		t.Error("expected the stalest room to be evicted")
+	}
+	if capture.Entries("ROOM3") == nil {
+		t.Error("expected the newest room to be kept")
+	}
+
+	capture.Forget("ROOM3")
+	if capture.Entries("ROOM3") != nil {
+		t.Error("expected Forget to drop the room")
+	}
+}
+
+func TestCapturePrunesAndPurges(t *testing.T) {
+	capture := New(&bytes.Buffer{}, 4, 2)
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	capture.now = func() time.Time { return now }
+
+	capture.Track("ABCDE")
+	for i, line := range []string{"Alice joined ABCDE", "Bob joined ABCDE", "Alice left ABCDE", "Malice joined ABCDE"} {
+		now = start.Add(time.Duration(i) * time.Minute)
+		fmt.Fprintln(capture, line)
+	}
+
+	if dropped := capture.Prune(start.Add(time.Minute)); dropped != 1 {
+		t.Errorf("expected one entry older than the cutoff, dropped %d", dropped)
+	}
+	if dropped := capture.Purge("ABCDE", "Alice", ""); dropped != 1 {
+		t.Errorf("expected one remaining entry naming Alice, dropped %d", dropped)
+	}
+	entries := capture.Entries("ABCDE")
+	if len(entries) != 2 || entries[0].Text != "Bob joined ABCDE" || entries[1].Text != "Malice joined ABCDE" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	// The ring keeps filling in order after a rebuild
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(capture, "line %d in ABCDE\n", i)
+	}
+	entries = capture.Entries("ABCDE")
+	if len(entries) != 4 || entries[0].Text != "Malice joined ABCDE" || entries[3].Text != "line 2 in ABCDE" {
+		t.Fatalf("expected the four newest lines, oldest first, got %+v", entries)
+	}
+}
@@ -0,0 +1,83 @@
+package secrets
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MinKeyLength is the shortest accepted signing key, in bytes
+const MinKeyLength = 32
+
+// Keyring signs values with its newest key and verifies them against every
+// key, so cookies signed before a rotation stay valid until the old key is dropped
+type Keyring struct {
+	keys [][]byte
+}
+
+// ParseKeyring parses a comma-separated key list, newest first
+func ParseKeyring(value string) (*Keyring, error) {
+	var keys [][]byte
+	for i, raw := range strings.Split(value, ",") {
+		key := strings.TrimSpace(raw)
+		if key == "" {
+			continue
+		}
+		if len(key) < MinKeyLength {
+			return nil, fmt.Errorf("key %d is %d bytes, need at least %d", i+1, len(key), MinKeyLength)
+		}
+		keys = append(keys, []byte(key))
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no keys")
+	}
+	return &Keyring{keys: keys}, nil
+}
+
+// LoadKeyring resolves a key list secret from p; it returns nil without error when the secret is unset
+func LoadKeyring(p Provider, name string) (*Keyring, error) {
+	value, ok, err := p.Lookup(name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	keyring, err := ParseKeyring(value)
+	if err != nil {
+		return nil, fmt.Errorf("secret %s: %w", name, err)
+	}
+	return keyring, nil
+}
+
+// Len returns the number of keys, including retired ones still accepted
+func (k *Keyring) Len() int {
+	return len(k.keys)
+}
+
+// Sign returns value with an HMAC-SHA256 signature from the newest key appended
+func (k *Keyring) Sign(value string) string {
+	return value + "." + signature(k.keys[0], value)
+}
+
+// Verify returns the value from a signed string. current reports whether the
+// newest key signed it; callers re-sign when it is false.
+func (k *Keyring) Verify(signed string) (value string, current bool, ok bool) {
+	i := strings.LastIndexByte(signed, '.')
+	if i < 0 {
+		return "", false, false
+	}
+	value, sig := signed[:i], signed[i+1:]
+	for n, key := range k.keys {
+		if hmac.Equal([]byte(sig), []byte(signature(key, value))) {
+			return value, n == 0, true
+		}
+	}
+	return "", false, false
+}
+
+func signature(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
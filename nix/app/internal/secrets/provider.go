@@ -0,0 +1,195 @@
+// Package secrets resolves signing keys and other credentials at startup from
+// the environment, mounted files or Vault, so they never live in server.yaml.
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Well-known secret names
+const (
+	CookieKeys          = "cookie_keys"           // comma-separated, newest first
+	BackupEncryptionKey = "backup_encryption_key" // 64-char hex AES-256 key
+	AdminToken          = "admin_token"           // bearer token for the /admin endpoints
+	SMTPPassword        = "smtp_password"         // for server.smtpUsername on server.smtpAddr
+)
+
+// TenantAdminToken names the bearer token that opens a tenant's share of the
+// /admin endpoints, e.g. tenant_spike_club_admin_token for tenant spike-club
+func TenantAdminToken(tenantID string) string {
+	return "tenant_" + strings.ReplaceAll(tenantID, "-", "_") + "_admin_token"
+}
+
+// Provider looks up secrets by name. A missing secret is not an error.
+type Provider interface {
+	Name() string
+	Lookup(name string) (value string, ok bool, err error)
+}
+
+// EnvProvider reads TREACHEREST_SECRET_<NAME> environment variables
+type EnvProvider struct{}
+
+// Name implements Provider
+func (EnvProvider) Name() string { return "env" }
+
+// Lookup implements Provider
+func (EnvProvider) Lookup(name string) (string, bool, error) {
+	value, ok := os.LookupEnv(EnvVar(name))
+	if !ok || value == "" {
+		return "", false, nil
+	}
+	return value, true, nil
+}
+
+// EnvVar returns the environment variable EnvProvider reads for name
+func EnvVar(name string) string {
+	return "TREACHEREST_SECRET_" + strings.ToUpper(name)
+}
+
+// FileProvider reads one file per secret from Dir, as mounted by Docker or Kubernetes secrets
+type FileProvider struct {
+	Dir string
+}
+
+// Name implements Provider
+func (p FileProvider) Name() string { return "file" }
+
+// Lookup implements Provider
+func (p FileProvider) Lookup(name string) (string, bool, error) {
+	data, err := os.ReadFile(filepath.Join(p.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("read secret %s: %w", name, err)
+	}
+	value := strings.TrimSpace(string(data))
+	return value, value != "", nil
+}
+
+// VaultProvider reads secrets from one Vault KV v2 entry, fetched once and cached
+type VaultProvider struct {
+	Addr   string // e.g. https://vault.internal:8200
+	Token  string
+	Path   string // e.g. secret/data/treacherest
+	Client *http.Client
+
+	once sync.Once
+	data map[string]string
+	err  error
+}
+
+// Name implements Provider
+func (p *VaultProvider) Name() string { return "vault" }
+
+// Lookup implements Provider
+func (p *VaultProvider) Lookup(name string) (string, bool, error) {
+	p.once.Do(p.fetch)
+	if p.err != nil {
+		return "", false, p.err
+	}
+	value, ok := p.data[name]
+	return value, ok && value != "", nil
+}
+
+func (p *VaultProvider) fetch() {
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	url := strings.TrimRight(p.Addr, "/") + "/v1/" + strings.TrimLeft(p.Path, "/")
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		p.err = fmt.Errorf("vault request: %w", err)
+		return
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		p.err = fmt.Errorf("vault request: %w", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		p.err = fmt.Errorf("vault returned %s for %s", resp.Status, p.Path)
+		return
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		p.err = fmt.Errorf("decode vault response: %w", err)
+		return
+	}
+	p.data = body.Data.Data
+}
+
+// Chain consults providers in order; the first one that has a secret wins
+type Chain []Provider
+
+// Name implements Provider
+func (c Chain) Name() string {
+	names := make([]string, len(c))
+	for i, p := range c {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, "+")
+}
+
+// Lookup implements Provider
+func (c Chain) Lookup(name string) (string, bool, error) {
+	for _, p := range c {
+		value, ok, err := p.Lookup(name)
+		if err != nil {
+			return "", false, fmt.Errorf("%s provider: %w", p.Name(), err)
+		}
+		if ok {
+			return value, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// Settings selects and configures the provider chain
+type Settings struct {
+	Provider  string // env (default), file or vault; env is always consulted first
+	Dir       string // FileProvider directory
+	VaultAddr string
+	VaultPath string
+}
+
+// NewProvider builds the provider chain for settings. The environment always
+// comes first so a single secret can be overridden during an incident.
+// The Vault token is read from VAULT_TOKEN and never from config.
+func NewProvider(s Settings) (Provider, error) {
+	switch s.Provider {
+	case "", "env":
+		return Chain{EnvProvider{}}, nil
+	case "file":
+		if s.Dir == "" {
+			return nil, errors.New("file secrets provider needs secretsDir")
+		}
+		return Chain{EnvProvider{}, FileProvider{Dir: s.Dir}}, nil
+	case "vault":
+		token := os.Getenv("VAULT_TOKEN")
+		if s.VaultAddr == "" || s.VaultPath == "" || token == "" {
+			return nil, errors.New("vault secrets provider needs vaultAddr, vaultPath and VAULT_TOKEN")
+		}
+		return Chain{EnvProvider{}, &VaultProvider{Addr: s.VaultAddr, Path: s.VaultPath, Token: token}}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q (want env, file or vault)", s.Provider)
+	}
+}
@@ -0,0 +1,153 @@
+package secrets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var (
+	oldKey = strings.Repeat("o", MinKeyLength)
+	newKey = strings.Repeat("n", MinKeyLength)
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("TREACHEREST_SECRET_COOKIE_KEYS", "from-env")
+
+	value, ok, err := EnvProvider{}.Lookup(CookieKeys)
+	if err != nil || !ok || value != "from-env" {
+		t.Fatalf("got %q %v %v", value, ok, err)
+	}
+	if _, ok, _ := (EnvProvider{}).Lookup("missing"); ok {
+		t.Error("expected missing secret to be absent")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, CookieKeys), []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := FileProvider{Dir: dir}
+	value, ok, err := p.Lookup(CookieKeys)
+	if err != nil || !ok || value != "from-file" {
+		t.Fatalf("got %q %v %v", value, ok, err)
+	}
+	if _, ok, err := p.Lookup("missing"); ok || err != nil {
+		t.Errorf("expected missing file to be absent without error, got %v %v", ok, err)
+	}
+}
+
+func TestVaultProviderFetchesOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1/secret/data/treacherest" || r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, "denied", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"cookie_keys":"from-vault"}}}`))
+	}))
+	defer server.Close()
+
+	p := &VaultProvider{Addr: server.URL, Token: "token", Path: "secret/data/treacherest"}
+	for i := 0; i < 2; i++ {
+		value, ok, err := p.Lookup(CookieKeys)
+		if err != nil || !ok || value != "from-vault" {
+			t.Fatalf("got %q %v %v", value, ok, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected one Vault request, got %d", requests)
+	}
+
+	denied := &VaultProvider{Addr: server.URL, Token: "wrong", Path: "secret/data/treacherest"}
+	if _, _, err := denied.Lookup(CookieKeys); err == nil {
+		t.Error("expected an error when Vault denies access")
+	}
+}
+
+func TestChainPrefersEarlierProviders(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, CookieKeys), []byte("from-file"), 0o600)
+	os.WriteFile(filepath.Join(dir, BackupEncryptionKey), []byte("backup-from-file"), 0o600)
+	t.Setenv("TREACHEREST_SECRET_COOKIE_KEYS", "from-env")
+
+	chain := Chain{EnvProvider{}, FileProvider{Dir: dir}}
+	if value, _, _ := chain.Lookup(CookieKeys); value != "from-env" {
+		t.Errorf("expected env to win, got %q", value)
+	}
+	if value, _, _ := chain.Lookup(BackupEncryptionKey); value != "backup-from-file" {
+		t.Errorf("expected file fallback, got %q", value)
+	}
+}
+
+func TestNewProviderValidatesSettings(t *testing.T) {
+	t.Setenv("VAULT_TOKEN", "")
+
+	if _, err := NewProvider(Settings{}); err != nil {
+		t.Errorf("default provider: %v", err)
+	}
+	if _, err := NewProvider(Settings{Provider: "file"}); err == nil {
+		t.Error("expected file provider without dir to fail")
+	}
+	if _, err := NewProvider(Settings{Provider: "vault", VaultAddr: "http://vault", VaultPath: "p"}); err == nil {
+		t.Error("expected vault provider without VAULT_TOKEN to fail")
+	}
+	if _, err := NewProvider(Settings{Provider: "kms"}); err == nil {
+		t.Error("expected unknown provider to fail")
+	}
+}
+
+func TestKeyringRotation(t *testing.T) {
+	before, err := ParseKeyring(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := before.Sign("session-1")
+
+	// Rotate: the new key goes first, the old one stays accepted
+	after, err := ParseKeyring(newKey + "," + oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, current, ok := after.Verify(signed)
+	if !ok || value != "session-1" || current {
+		t.Fatalf("expected old signature accepted as non-current, got %q %v %v", value, current, ok)
+	}
+
+	resigned := after.Sign(value)
+	if _, current, ok := after.Verify(resigned); !ok || !current {
+		t.Error("expected re-signed value to verify under the current key")
+	}
+
+	// Retire the old key
+	retired, _ := ParseKeyring(newKey)
+	if _, _, ok := retired.Verify(signed); ok {
+		t.Error("expected signature from a retired key to be rejected")
+	}
+}
+
+func TestKeyringRejectsTampering(t *testing.T) {
+	k, _ := ParseKeyring(newKey)
+	signed := k.Sign("session-1")
+
+	for _, forged := range []string{"session-1", "session-2" + signed[len("session-1"):], signed + "x", ""} {
+		if _, _, ok := k.Verify(forged); ok {
+			t.Errorf("expected %q to be rejected", forged)
+		}
+	}
+}
+
+func TestParseKeyringRejectsShortKeys(t *testing.T) {
+	if _, err := ParseKeyring("short"); err == nil {
+		t.Error("expected short key to be rejected")
+	}
+	if _, err := ParseKeyring(" , "); err == nil {
+		t.Error("expected empty key list to be rejected")
+	}
+}
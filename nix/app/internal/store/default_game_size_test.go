@@ -5,6 +5,7 @@ import (
 	"treacherest"
 	"treacherest/internal/config"
 	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
 )
 
 func TestDefaultGameSize(t *testing.T) {
@@ -27,6 +28,7 @@ func TestDefaultGameSize(t *testing.T) {
 	store := NewMemoryStore(cfg)
 
 	// Create card service and set it
+	testhelpers.RequireCardAssets(t)
 	cardService, err := game.NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create card service: %v", err)
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"treacherest/internal/config"
+)
+
+// Driver opens a RoomStore from a DSN whose scheme named the driver
+type Driver func(dsn string, cfg *config.ServerConfig) (RoomStore, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]Driver)
+)
+
+func init() {
+	Register("memory", openMemory)
+}
+
+// openMemory opens the built-in in-memory store. It takes no options; rooms
+// are lost when the process exits.
+func openMemory(dsn string, cfg *config.ServerConfig) (RoomStore, error) {
+	return NewMemoryStore(cfg), nil
+}
+
+// Register makes a driver available to Open under name, the scheme of the
+// DSNs it opens. It panics when name is empty or already taken, as
+// registration happens in init functions.
+func Register(name string, driver Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if name == "" || driver == nil {
+		panic("store: Register needs a name and a driver")
+	}
+	if _, taken := drivers[name]; taken {
+		panic("store: Register called twice for driver " + name)
+	}
+	drivers[name] = driver
+}
+
+// Drivers returns the names of the registered drivers, sorted
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Open opens the store a DSN names, such as "memory://". The DSN's scheme
+// picks the driver; a bare name like "memory" works too, and an empty DSN
+// opens the memory store.
+func Open(dsn string, cfg *config.ServerConfig) (RoomStore, error) {
+	name, err := DriverName(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	driversMu.RLock()
+	driver, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown store driver %q (registered: %s)", name, strings.Join(Drivers(), ", "))
+	}
+
+	s, err := driver(dsn, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("open %s store: %w", name, err)
+	}
+	return s, nil
+}
+
+// DriverName returns the driver a DSN names
+func DriverName(dsn string) (string, error) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return "memory", nil
+	}
+	if !strings.Contains(dsn, ":") {
+		return dsn, nil
+	}
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" {
+		return "", fmt.Errorf("invalid store DSN %q", dsn)
+	}
+	return u.Scheme, nil
+}
@@ -0,0 +1,60 @@
+package store_test
+
+import (
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/store"
+	"treacherest/internal/store/storetest"
+)
+
+func TestMemoryDriverConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.RoomStore {
+		s, err := store.Open("memory://", config.DefaultConfig())
+		if err != nil {
+			t.Fatalf("open memory store: %v", err)
+		}
+		return s
+	})
+}
+
+func TestOpenPicksTheDriverByScheme(t *testing.T) {
+	cfg := config.DefaultConfig()
+	for _, dsn := range []string{"memory://", "memory", ""} {
+		if s, err := store.Open(dsn, cfg); err != nil {
+			t.Errorf("expected %q to open the memory store, got %v", dsn, err)
+		} else if _, ok := s.(*store.MemoryStore); !ok {
+			t.Errorf("expected %q to open the memory store, got %T", dsn, s)
+		}
+	}
+
+	_, err := store.Open("redis://localhost:6379/0", cfg)
+	if err == nil || !strings.Contains(err.Error(), `unknown store driver "redis"`) || !strings.Contains(err.Error(), "memory") {
+		t.Errorf("expected an unknown driver named along with the registered ones, got %v", err)
+	}
+	if _, err := store.Open("://nope", cfg); err == nil {
+		t.Error("expected a malformed DSN refused")
+	}
+}
+
+func TestRegisterAddsADriver(t *testing.T) {
+	opened := ""
+	store.Register("conformance-fake", func(dsn string, cfg *config.ServerConfig) (store.RoomStore, error) {
+		opened = dsn
+		return store.NewMemoryStore(cfg), nil
+	})
+	if _, err := store.Open("conformance-fake://somewhere", config.DefaultConfig()); err != nil || opened != "conformance-fake://somewhere" {
+		t.Errorf("expected the registered driver given the DSN, got %q (%v)", opened, err)
+	}
+	if got := strings.Join(store.Drivers(), ","); got != "conformance-fake,memory" {
+		t.Errorf("expected the drivers listed in order, got %s", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a taken name to panic")
+		}
+	}()
+	store.Register("memory", nil)
+}
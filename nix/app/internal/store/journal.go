@@ -0,0 +1,243 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"treacherest/internal/game"
+)
+
+// snapshotEvery is how many events a room's journal holds before the next
+// append folds them into a fresh snapshot, bounding how much a rebuild replays
+const snapshotEvery = 50
+
+// journal is a room's append-only event log over its last snapshot. Events
+// are encoded as they are appended, so later changes to the live room can't
+// rewrite its history.
+type journal struct {
+	mu          sync.Mutex
+	seq         int
+	snapshot    []byte // the room as it was just before event snapshotSeq+1
+	snapshotSeq int
+	events      [][]byte
+}
+
+// Append applies e to room and records it in the room's journal. The caller
+// holds the room's lock. Nothing is recorded when the event can't be applied.
+//
+// Transitions recorded as events are joins, leaves, bans, role configuration
+// changes, starts and reveals; the rest of a room's state is still changed in
+// place and only reaches the journal through its next snapshot.
+func (s *MemoryStore) Append(room *game.Room, e game.RoomEvent) error {
+	j := s.journalFor(room.Code)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.snapshot == nil || len(j.events) >= snapshotEvery {
+		snapshot, err := json.Marshal(room)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot room %s: %w", room.Code, err)
+		}
+		j.snapshot, j.snapshotSeq, j.events = snapshot, j.seq, nil
+	}
+
+	if err := room.Apply(e); err != nil {
+		return err
+	}
+	e.Seq = j.seq + 1
+	e.At = time.Now()
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to record %s in room %s: %w", e.Kind, room.Code, err)
+	}
+	j.seq = e.Seq
+	j.events = append(j.events, encoded)
+	return nil
+}
+
+// Events returns the room's recorded events after seq, oldest first. Events
+// folded into a snapshot are gone, so the first one returned may come later
+// than seq+1.
+func (s *MemoryStore) Events(code string, since int) ([]game.RoomEvent, error) {
+	j := s.existingJournal(code)
+	if j == nil {
+		return nil, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	events := make([]game.RoomEvent, 0, len(j.events))
+	for _, encoded := range j.events {
+		var e game.RoomEvent
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return nil, fmt.Errorf("failed to read room %s's journal: %w", code, err)
+		}
+		if e.Seq > since {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// Rebuild derives a room from its journal: its last snapshot with every event
+// since replayed over it. The result is a new room, separate from the live one.
+func (s *MemoryStore) Rebuild(code string) (*game.Room, error) {
+	j := s.existingJournal(code)
+	if j == nil {
+		return nil, fmt.Errorf("room %s has no journal", code)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	room := &game.Room{}
+	if err := json.Unmarshal(j.snapshot, room); err != nil {
+		return nil, fmt.Errorf("failed to read room %s's snapshot: %w", code, err)
+	}
+	if room.Players == nil {
+		room.Players = make(map[string]*game.Player)
+	}
+	for _, encoded := range j.events {
+		var e game.RoomEvent
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return nil, fmt.Errorf("failed to read room %s's journal: %w", code, err)
+		}
+		if err := room.Apply(e); err != nil {
+			return nil, fmt.Errorf("failed to replay event %d in room %s: %w", e.Seq, code, err)
+		}
+	}
+	s.RefreshCards(room)
+	return room, nil
+}
+
+// PruneEvents drops the room's events recorded before cutoff. A journal that
+// holds any folds them all into a fresh snapshot, as the next append past
+// snapshotEvery would, so every event it holds is dropped.
+func (s *MemoryStore) PruneEvents(room *game.Room, cutoff time.Time) (int, error) {
+	j := s.existingJournal(room.Code)
+	if j == nil {
+		return 0, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, encoded := range j.events {
+		var e game.RoomEvent
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return 0, fmt.Errorf("failed to read room %s's journal: %w", room.Code, err)
+		}
+		if e.At.Before(cutoff) {
+			return j.fold(room)
+		}
+	}
+	return 0, nil
+}
+
+// ForgetSession drops the room's events about the players sessionID has had,
+// see AboutSession. A journal that holds any folds them all into a fresh
+// snapshot, so the events it drops include ones about other players; only
+// the ones about the session are counted.
+func (s *MemoryStore) ForgetSession(room *game.Room, sessionID string) (int, error) {
+	j := s.existingJournal(room.Code)
+	if j == nil {
+		return 0, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	snapshot := &game.Room{}
+	if j.snapshot != nil {
+		if err := json.Unmarshal(j.snapshot, snapshot); err != nil {
+			return 0, fmt.Errorf("failed to read room %s's snapshot: %w", room.Code, err)
+		}
+	}
+	events := make([]game.RoomEvent, 0, len(j.events))
+	for _, encoded := range j.events {
+		var e game.RoomEvent
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return 0, fmt.Errorf("failed to read room %s's journal: %w", room.Code, err)
+		}
+		events = append(events, e)
+	}
+
+	about := AboutSession([]*game.Room{room, snapshot}, events, sessionID)
+	if len(about) == 0 {
+		return 0, nil
+	}
+	if _, err := j.fold(room); err != nil {
+		return 0, err
+	}
+	return len(about), nil
+}
+
+// AboutSession returns the sequence numbers of the events about the players
+// sessionID has had: the joins that seated them, bans of the session and
+// every leave, reveal and start naming them. A start also records the rest of the table's deal, so
+// it counts as a whole. rooms are where else to look for the session's
+// players, e.g. the live room and the journal's snapshots, for players
+// seated before the events begin.
+func AboutSession(rooms []*game.Room, events []game.RoomEvent, sessionID string) []int {
+	ids := make(map[string]bool)
+	for _, room := range rooms {
+		for _, player := range room.GetPlayers() {
+			if player.SessionID == sessionID {
+				ids[player.ID] = true
+			}
+		}
+	}
+	for _, e := range events {
+		if e.Player != nil && e.Player.SessionID == sessionID {
+			ids[e.Player.ID] = true
+		}
+	}
+
+	var seqs []int
+	for _, e := range events {
+		if e.SessionID == sessionID || aboutPlayers(e, ids) {
+			seqs = append(seqs, e.Seq)
+		}
+	}
+	return seqs
+}
+
+func aboutPlayers(e game.RoomEvent, ids map[string]bool) bool {
+	if e.Player != nil && ids[e.Player.ID] || ids[e.PlayerID] {
+		return true
+	}
+	for id := range e.Deal {
+		if ids[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// fold replaces the journal's snapshot and events with a snapshot of room as
+// it is, returning how many events it dropped. The caller holds j.mu.
+func (j *journal) fold(room *game.Room) (int, error) {
+	snapshot, err := json.Marshal(room)
+	if err != nil {
+		return 0, fmt.Errorf("failed to snapshot room %s: %w", room.Code, err)
+	}
+	dropped := len(j.events)
+	j.snapshot, j.snapshotSeq, j.events = snapshot, j.seq, nil
+	return dropped, nil
+}
+
+func (s *MemoryStore) journalFor(code string) *journal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.journals[code]
+	if !ok {
+		j = &journal{}
+		s.journals[code] = j
+	}
+	return j
+}
+
+func (s *MemoryStore) existingJournal(code string) *journal {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.journals[code]
+}
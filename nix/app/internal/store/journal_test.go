@@ -0,0 +1,94 @@
+package store
+
+import (
+	"testing"
+	"treacherest/internal/game"
+)
+
+func TestJournalRebuildsTheRoom(t *testing.T) {
+	store := newTestStore()
+	room, _ := store.CreateRoom()
+	room.RulesMode = game.RulesModeCoup
+
+	alice := game.NewPlayer("p1", "Alice", "s1")
+	bob := game.NewPlayer("p2", "Bob", "s2")
+	for _, e := range []game.RoomEvent{game.PlayerJoinedEvent(alice), game.PlayerJoinedEvent(bob)} {
+		if err := store.Append(room, e); err != nil {
+			t.Fatalf("append %s: %v", e.Kind, err)
+		}
+	}
+	if err := store.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p3", "ALICE", "s3"))); err == nil {
+		t.Error("expected a join under a taken name refused")
+	}
+
+	alice.Role = &game.Card{ID: 1, Name: "The Usurper"}
+	room.State = game.StateCountdown
+	store.Append(room, game.GameStartedEvent(room))
+	store.Append(room, game.RoleRevealedEvent("p1", true, true))
+	store.Append(room, game.PlayerLeftEvent("p2"))
+
+	// A change after it was recorded doesn't rewrite the history
+	alice.Name = "Mallory"
+
+	events, err := store.Events(room.Code, 0)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if len(events) != 5 || events[0].Seq != 1 || events[4].Kind != game.RoomEventPlayerLeft {
+		t.Fatalf("expected the five recorded events in order, got %+v", events)
+	}
+	if later, _ := store.Events(room.Code, 3); len(later) != 2 || later[0].Seq != 4 {
+		t.Errorf("expected the events after the third, got %+v", later)
+	}
+
+	rebuilt, err := store.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt == room {
+		t.Fatal("expected a new room")
+	}
+	if rebuilt.RulesMode != game.RulesModeCoup || rebuilt.State != game.StateCountdown {
+		t.Errorf("expected the snapshot's rules and the start's state, got %s and %s", rebuilt.RulesMode, rebuilt.State)
+	}
+	got := rebuilt.GetPlayer("p1")
+	if got == nil || got.Name != "Alice" || got.Role == nil || got.Role.ID != 1 || !got.RoleRevealed {
+		t.Errorf("expected Alice seated with their revealed role, got %+v", got)
+	}
+	if rebuilt.GetPlayer("p2") != nil {
+		t.Error("expected Bob's seat given up")
+	}
+	if rebuilt.RoleOptionsManager == nil {
+		t.Error("expected the rebuilt room's runtime state set up")
+	}
+}
+
+func TestJournalSnapshotsAsItGrows(t *testing.T) {
+	store := newTestStore()
+	room, _ := store.CreateRoom()
+	room.MaxPlayers = snapshotEvery * 2
+
+	for i := 0; i <= snapshotEvery; i++ {
+		player := game.NewPlayer(string(rune('a'+i%26))+string(rune('a'+i/26)), "Player "+string(rune('A'+i%26))+string(rune('A'+i/26)), "s")
+		if err := store.Append(room, game.PlayerJoinedEvent(player)); err != nil {
+			t.Fatalf("append %d: %v", i, err)
+		}
+	}
+
+	j := store.existingJournal(room.Code)
+	if j.snapshotSeq != snapshotEvery || len(j.events) != 1 {
+		t.Errorf("expected a snapshot after %d events and one since, got %d and %d", snapshotEvery, j.snapshotSeq, len(j.events))
+	}
+	rebuilt, err := store.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if len(rebuilt.Players) != snapshotEvery+1 {
+		t.Errorf("expected every player seated, got %d", len(rebuilt.Players))
+	}
+
+	store.DeleteRoom(room.Code)
+	if _, err := store.Rebuild(room.Code); err == nil {
+		t.Error("expected a deleted room's journal dropped")
+	}
+}
@@ -13,6 +13,7 @@ import (
 type MemoryStore struct {
 	mu          sync.RWMutex
 	rooms       map[string]*game.Room
+	journals    map[string]*journal
 	config      *config.ServerConfig
 	cardService *game.CardService
 }
@@ -20,14 +21,30 @@ type MemoryStore struct {
 // NewMemoryStore creates a new in-memory store
 func NewMemoryStore(cfg *config.ServerConfig) *MemoryStore {
 	return &MemoryStore{
-		rooms:  make(map[string]*game.Room),
-		config: cfg,
+		rooms:    make(map[string]*game.Room),
+		journals: make(map[string]*journal),
+		config:   cfg,
 	}
 }
 
-// SetCardService sets the card service for the store
+// SetConfig makes new rooms start from cfg, after a config reload
+func (s *MemoryStore) SetConfig(cfg *config.ServerConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config = cfg
+}
+
+// SetCardService sets the card service for the store. Rooms already in the
+// store, like those a durable driver loaded, are pointed at its cards.
 func (s *MemoryStore) SetCardService(cardService *game.CardService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.cardService = cardService
+	for _, room := range s.rooms {
+		s.RefreshCards(room)
+	}
 }
 
 // CreateRoom creates a new game room
@@ -139,6 +156,16 @@ func (s *MemoryStore) RegisterRestoredRoom(room *game.Room) error {
 		return fmt.Errorf("room %s already exists", room.Code)
 	}
 
+	s.RefreshCards(room)
+	s.rooms[room.Code] = room
+	return nil
+}
+
+// RefreshCards points a room decoded from JSON at the current cards. The
+// encoded room may carry outdated card data, so its pool is rebuilt from the
+// CardService and each player's role swapped for the fresh card. Runtime
+// state that isn't encoded, like the RoleOptionsManager, starts over.
+func (s *MemoryStore) RefreshCards(room *game.Room) {
 	// Reinitialize CardPool with current cards from CardService
 	// The backup may have outdated card data, so we need fresh references
 	if s.cardService != nil {
@@ -163,9 +190,6 @@ func (s *MemoryStore) RegisterRestoredRoom(room *game.Room) error {
 	if room.RoleOptionsManager == nil {
 		room.RoleOptionsManager = game.NewRoleOptionsManager()
 	}
-
-	s.rooms[room.Code] = room
-	return nil
 }
 
 // RoomExists checks if a room with the given code exists
@@ -176,9 +200,35 @@ func (s *MemoryStore) RoomExists(code string) bool {
 	return exists
 }
 
+// Rooms returns every live room
+func (s *MemoryStore) Rooms() []*game.Room {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rooms := make([]*game.Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// FindRoomByWatchToken returns the room a share link token belongs to
+func (s *MemoryStore) FindRoomByWatchToken(token string) (*game.Room, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, room := range s.rooms {
+		if room.HasWatchLink(token) {
+			return room, nil
+		}
+	}
+	return nil, fmt.Errorf("watch link not found")
+}
+
 // DeleteRoom removes a room from the store (used for debug/testing)
 func (s *MemoryStore) DeleteRoom(code string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.rooms, code)
+	delete(s.journals, code)
 }
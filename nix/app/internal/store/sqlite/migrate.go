@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations bring the schema from each version to the next; the database's
+// user_version is how many have run. Append new ones, never edit old ones.
+var migrations = []string{
+	// 1: rooms as they are now, and each room's journal over its snapshots
+	`CREATE TABLE rooms (
+		code       TEXT PRIMARY KEY,
+		data       BLOB NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE TABLE room_events (
+		code TEXT NOT NULL,
+		seq  INTEGER NOT NULL,
+		data BLOB NOT NULL,
+		PRIMARY KEY (code, seq)
+	);
+	CREATE TABLE room_snapshots (
+		code TEXT NOT NULL,
+		seq  INTEGER NOT NULL,
+		data BLOB NOT NULL,
+		PRIMARY KEY (code, seq)
+	);`,
+}
+
+// migrate runs the migrations the database hasn't had yet, each in its own
+// transaction. A database from a newer build is refused rather than guessed at.
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this build's %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("migrate to schema version %d: %w", i+1, err)
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate to schema version %d: %w", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate to schema version %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migrate to schema version %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
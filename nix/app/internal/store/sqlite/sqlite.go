@@ -0,0 +1,390 @@
+// Package sqlite is a room store driver that keeps rooms and their event
+// journals in an SQLite database, so a single-node host keeps its games
+// across restarts without running Redis. Importing it registers the "sqlite"
+// driver:
+//
+//	STORE_DSN=sqlite:///var/lib/treacherest/rooms.db
+//
+// Live rooms are held in memory like the memory store's; every UpdateRoom
+// writes the room through to the database and every Append records its
+// event, and Open loads the rooms back. Fields a room doesn't encode, like
+// its creator token, don't survive a restart, just as with backups.
+//
+// SQLite comes from modernc.org/sqlite, a pure-Go translation, so the driver
+// works in the CGO_ENABLED=0 builds the project ships.
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+
+	_ "modernc.org/sqlite"
+)
+
+// snapshotEvery is how many events a room's journal gathers before the next
+// append writes a fresh snapshot for rebuilds to start from
+const snapshotEvery = 50
+
+func init() {
+	store.Register("sqlite", Open)
+}
+
+// Store is a RoomStore backed by an SQLite database
+type Store struct {
+	*store.MemoryStore
+	db *sql.DB
+}
+
+var _ store.RoomStore = (*Store)(nil)
+
+// Open opens, creating if needed, the database at the DSN's path, brings its
+// schema up to date and loads the rooms it holds
+func Open(dsn string, cfg *config.ServerConfig) (store.RoomStore, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(dsn, "sqlite:"), "//")
+	if path == "" {
+		return nil, fmt.Errorf("sqlite DSN %q has no database path", dsn)
+	}
+
+	// One connection: SQLite takes one writer at a time anyway, and it keeps
+	// each Append's read-then-insert of the next sequence number atomic
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Store{MemoryStore: store.NewMemoryStore(cfg), db: db}
+	if err := s.load(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// load registers every room in the database as a live room
+func (s *Store) load() error {
+	rows, err := s.db.Query(`SELECT code, data FROM rooms`)
+	if err != nil {
+		return fmt.Errorf("load rooms: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code string
+		var data []byte
+		if err := rows.Scan(&code, &data); err != nil {
+			return fmt.Errorf("load rooms: %w", err)
+		}
+		room, err := decodeRoom(data)
+		if err != nil {
+			return fmt.Errorf("load room %s: %w", code, err)
+		}
+		if err := s.MemoryStore.RegisterRestoredRoom(room); err != nil {
+			return fmt.Errorf("load room %s: %w", code, err)
+		}
+	}
+	return rows.Err()
+}
+
+// CreateRoom creates a lobby and saves it
+func (s *Store) CreateRoom() (*game.Room, error) {
+	room, err := s.MemoryStore.CreateRoom()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(room); err != nil {
+		s.MemoryStore.DeleteRoom(room.Code)
+		return nil, err
+	}
+	return room, nil
+}
+
+// UpdateRoom saves a room's changes
+func (s *Store) UpdateRoom(room *game.Room) error {
+	if err := s.MemoryStore.UpdateRoom(room); err != nil {
+		return err
+	}
+	return s.save(room)
+}
+
+// RegisterRestoredRoom adds a room restored from a backup and saves it
+func (s *Store) RegisterRestoredRoom(room *game.Room) error {
+	if err := s.MemoryStore.RegisterRestoredRoom(room); err != nil {
+		return err
+	}
+	return s.save(room)
+}
+
+// DeleteRoom removes a room, its journal and its snapshots
+func (s *Store) DeleteRoom(code string) {
+	s.MemoryStore.DeleteRoom(code)
+	for _, table := range []string{"rooms", "room_events", "room_snapshots"} {
+		if _, err := s.db.Exec(`DELETE FROM `+table+` WHERE code = ?`, code); err != nil {
+			// The room is gone from memory; a leftover row comes back as a
+			// live room only after a restart
+			log.Printf("⚠️ Failed to delete room %s from %s: %v", code, table, err)
+		}
+	}
+}
+
+func (s *Store) save(room *game.Room) error {
+	return saveRoom(s.db, room)
+}
+
+// execer is a database or a transaction
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func saveRoom(db execer, room *game.Room) error {
+	data, err := json.Marshal(room)
+	if err != nil {
+		return fmt.Errorf("encode room %s: %w", room.Code, err)
+	}
+	_, err = db.Exec(`INSERT INTO rooms (code, data, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (code) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+		room.Code, data, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("save room %s: %w", room.Code, err)
+	}
+	return nil
+}
+
+// Append applies e to room, records it in the room's journal and saves the
+// room. The caller holds the room's lock. Nothing is recorded when the event
+// can't be applied.
+func (s *Store) Append(room *game.Room, e game.RoomEvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("record %s in room %s: %w", e.Kind, room.Code, err)
+	}
+	defer tx.Rollback()
+
+	var seq, snapshotSeq int
+	err = tx.QueryRow(`SELECT COALESCE(MAX(seq), 0), (SELECT COALESCE(MAX(seq), -1) FROM room_snapshots WHERE code = ?)
+		FROM room_events WHERE code = ?`, room.Code, room.Code).Scan(&seq, &snapshotSeq)
+	if err != nil {
+		return fmt.Errorf("record %s in room %s: %w", e.Kind, room.Code, err)
+	}
+	// A compaction may have dropped the latest events, but never the
+	// snapshot that covers them, so numbering carries on from there
+	seq = max(seq, snapshotSeq)
+	if snapshotSeq < 0 || seq-snapshotSeq >= snapshotEvery {
+		snapshot, err := json.Marshal(room)
+		if err != nil {
+			return fmt.Errorf("snapshot room %s: %w", room.Code, err)
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO room_snapshots (code, seq, data) VALUES (?, ?, ?)`, room.Code, seq, snapshot); err != nil {
+			return fmt.Errorf("snapshot room %s: %w", room.Code, err)
+		}
+	}
+
+	if err := room.Apply(e); err != nil {
+		return err
+	}
+	e.Seq = seq + 1
+	e.At = time.Now()
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("record %s in room %s: %w", e.Kind, room.Code, err)
+	}
+	if _, err := tx.Exec(`INSERT INTO room_events (code, seq, data) VALUES (?, ?, ?)`, room.Code, e.Seq, encoded); err != nil {
+		return fmt.Errorf("record %s in room %s: %w", e.Kind, room.Code, err)
+	}
+	// The saved room moves with its journal, so a restart never loads a room
+	// behind its own events
+	if err := saveRoom(tx, room); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Events returns the room's recorded events after seq, oldest first. Unlike
+// the memory store's, the database keeps events a snapshot covers, so a
+// room's history stays readable until PruneEvents or ForgetSession drops it.
+func (s *Store) Events(code string, since int) ([]game.RoomEvent, error) {
+	return readEvents(s.db, code, since)
+}
+
+// querier is a database or a transaction
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+func readEvents(db querier, code string, since int) ([]game.RoomEvent, error) {
+	rows, err := db.Query(`SELECT data FROM room_events WHERE code = ? AND seq > ? ORDER BY seq`, code, since)
+	if err != nil {
+		return nil, fmt.Errorf("read room %s's journal: %w", code, err)
+	}
+	defer rows.Close()
+
+	var events []game.RoomEvent
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("read room %s's journal: %w", code, err)
+		}
+		var e game.RoomEvent
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, fmt.Errorf("read room %s's journal: %w", code, err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// Rebuild derives a room from its latest snapshot and the events since
+func (s *Store) Rebuild(code string) (*game.Room, error) {
+	var seq int
+	var data []byte
+	err := s.db.QueryRow(`SELECT seq, data FROM room_snapshots WHERE code = ? ORDER BY seq DESC LIMIT 1`, code).Scan(&seq, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("room %s has no journal", code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read room %s's snapshot: %w", code, err)
+	}
+	room, err := decodeRoom(data)
+	if err != nil {
+		return nil, fmt.Errorf("read room %s's snapshot: %w", code, err)
+	}
+
+	events, err := s.Events(code, seq)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		if err := room.Apply(e); err != nil {
+			return nil, fmt.Errorf("failed to replay event %d in room %s: %w", e.Seq, code, err)
+		}
+	}
+	s.RefreshCards(room)
+	return room, nil
+}
+
+// PruneEvents drops the room's events recorded before cutoff, compacting
+// its journal first when there are any
+func (s *Store) PruneEvents(room *game.Room, cutoff time.Time) (int, error) {
+	return s.dropEvents(room, func(_ []*game.Room, events []game.RoomEvent) []int {
+		var seqs []int
+		for _, e := range events {
+			if e.At.Before(cutoff) {
+				seqs = append(seqs, e.Seq)
+			}
+		}
+		return seqs
+	})
+}
+
+// ForgetSession drops the room's events about the players sessionID has had,
+// see store.AboutSession, compacting its journal first when there are any
+func (s *Store) ForgetSession(room *game.Room, sessionID string) (int, error) {
+	return s.dropEvents(room, func(snapshots []*game.Room, events []game.RoomEvent) []int {
+		return store.AboutSession(append(snapshots, room), events, sessionID)
+	})
+}
+
+// dropEvents deletes the events pick chooses from the room's journal, given
+// its snapshots and events. So that Rebuild never needs them, the journal is
+// compacted first: the room as it is becomes its only snapshot, covering
+// every event. Nothing changes when pick chooses nothing.
+func (s *Store) dropEvents(room *game.Room, pick func([]*game.Room, []game.RoomEvent) []int) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("compact room %s's journal: %w", room.Code, err)
+	}
+	defer tx.Rollback()
+
+	snapshots, seq, err := readSnapshots(tx, room.Code)
+	if err != nil {
+		return 0, err
+	}
+	events, err := readEvents(tx, room.Code, 0)
+	if err != nil {
+		return 0, err
+	}
+	seqs := pick(snapshots, events)
+	if len(seqs) == 0 {
+		return 0, nil
+	}
+	if len(events) > 0 {
+		seq = max(seq, events[len(events)-1].Seq)
+	}
+
+	snapshot, err := json.Marshal(room)
+	if err != nil {
+		return 0, fmt.Errorf("snapshot room %s: %w", room.Code, err)
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO room_snapshots (code, seq, data) VALUES (?, ?, ?)`, room.Code, seq, snapshot); err != nil {
+		return 0, fmt.Errorf("snapshot room %s: %w", room.Code, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM room_snapshots WHERE code = ? AND seq < ?`, room.Code, seq); err != nil {
+		return 0, fmt.Errorf("compact room %s's journal: %w", room.Code, err)
+	}
+	for _, dropped := range seqs {
+		if _, err := tx.Exec(`DELETE FROM room_events WHERE code = ? AND seq = ?`, room.Code, dropped); err != nil {
+			return 0, fmt.Errorf("compact room %s's journal: %w", room.Code, err)
+		}
+	}
+	if err := saveRoom(tx, room); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("compact room %s's journal: %w", room.Code, err)
+	}
+	return len(seqs), nil
+}
+
+// readSnapshots returns a room's snapshots, oldest first, and the sequence
+// number of the latest
+func readSnapshots(db querier, code string) ([]*game.Room, int, error) {
+	rows, err := db.Query(`SELECT seq, data FROM room_snapshots WHERE code = ? ORDER BY seq`, code)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read room %s's snapshots: %w", code, err)
+	}
+	defer rows.Close()
+
+	var snapshots []*game.Room
+	seq := 0
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&seq, &data); err != nil {
+			return nil, 0, fmt.Errorf("read room %s's snapshots: %w", code, err)
+		}
+		room, err := decodeRoom(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("read room %s's snapshots: %w", code, err)
+		}
+		snapshots = append(snapshots, room)
+	}
+	return snapshots, seq, rows.Err()
+}
+
+func decodeRoom(data []byte) (*game.Room, error) {
+	room := &game.Room{}
+	if err := json.Unmarshal(data, room); err != nil {
+		return nil, err
+	}
+	if room.Players == nil {
+		room.Players = make(map[string]*game.Player)
+	}
+	return room, nil
+}
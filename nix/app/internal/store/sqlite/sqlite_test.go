@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+	"treacherest/internal/store/storetest"
+)
+
+func openTestStore(t *testing.T, path string) *Store {
+	t.Helper()
+	s, err := store.Open("sqlite://"+path, config.DefaultConfig())
+	if err != nil {
+		t.Fatalf("open sqlite store: %v", err)
+	}
+	t.Cleanup(func() { s.(*Store).Close() })
+	return s.(*Store)
+}
+
+func TestConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.RoomStore {
+		return openTestStore(t, filepath.Join(t.TempDir(), "rooms.db"))
+	})
+}
+
+func TestRoomsSurviveARestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rooms.db")
+	s := openTestStore(t, path)
+	room, _ := s.CreateRoom()
+	room.RulesMode = game.RulesModeCoup
+	room.Ban("banned-session")
+	room.SetJoinPassword("swordfish")
+	s.UpdateRoom(room)
+	s.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "s1")))
+	gone, _ := s.CreateRoom()
+	s.DeleteRoom(gone.Code)
+	s.Close()
+
+	reopened := openTestStore(t, path)
+	got, err := reopened.GetRoom(room.Code)
+	if err != nil {
+		t.Fatalf("expected the room loaded after the restart: %v", err)
+	}
+	if got.RulesMode != game.RulesModeCoup || got.GetPlayer("p1") == nil {
+		t.Errorf("expected the room as it was saved, got %s with %d players", got.RulesMode, len(got.Players))
+	}
+	if !got.IsBanned("banned-session") {
+		t.Error("expected the ban kept across the restart")
+	}
+	if got.JoinPasswordMatches("") || !got.JoinPasswordMatches("swordfish") {
+		t.Error("expected the room still private after the restart")
+	}
+	if reopened.RoomExists(gone.Code) {
+		t.Error("expected the deleted room to stay deleted")
+	}
+
+	// The journal carries on where it left off
+	if err := reopened.Append(got, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2"))); err != nil {
+		t.Fatalf("append after the restart: %v", err)
+	}
+	events, _ := reopened.Events(room.Code, 0)
+	if len(events) != 2 || events[1].Seq != 2 {
+		t.Errorf("expected the journal numbered on from before the restart, got %+v", events)
+	}
+	rebuilt, err := reopened.Rebuild(room.Code)
+	if err != nil || len(rebuilt.Players) != 2 {
+		t.Errorf("expected both players rebuilt, got %v (%v)", rebuilt, err)
+	}
+}
+
+func TestJournalKeepsHistoryPastSnapshots(t *testing.T) {
+	s := openTestStore(t, filepath.Join(t.TempDir(), "rooms.db"))
+	room, _ := s.CreateRoom()
+	room.MaxPlayers = snapshotEvery * 2
+	for i := 0; i <= snapshotEvery; i++ {
+		player := game.NewPlayer(string(rune('a'+i%26))+string(rune('a'+i/26)), "Player "+string(rune('A'+i%26))+string(rune('A'+i/26)), "s")
+		if err := s.Append(room, game.PlayerJoinedEvent(player)); err != nil {
+			t.Fatalf("append %d: %v", i, err)
+		}
+	}
+
+	var snapshots int
+	s.db.QueryRow(`SELECT COUNT(*) FROM room_snapshots WHERE code = ?`, room.Code).Scan(&snapshots)
+	if snapshots != 2 {
+		t.Errorf("expected a second snapshot after %d events, got %d snapshots", snapshotEvery, snapshots)
+	}
+	if events, _ := s.Events(room.Code, 0); len(events) != snapshotEvery+1 {
+		t.Errorf("expected every event kept, got %d", len(events))
+	}
+	if rebuilt, err := s.Rebuild(room.Code); err != nil || len(rebuilt.Players) != snapshotEvery+1 {
+		t.Errorf("expected every player rebuilt, got %v", err)
+	}
+}
+
+func TestOpenRefusesANewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rooms.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec(`PRAGMA user_version = 99`)
+	db.Close()
+
+	if _, err := Open("sqlite://"+path, config.DefaultConfig()); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("expected a newer schema refused, got %v", err)
+	}
+	if _, err := Open("sqlite://", config.DefaultConfig()); err == nil {
+		t.Error("expected a DSN without a path refused")
+	}
+}
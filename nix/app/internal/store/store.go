@@ -0,0 +1,57 @@
+package store
+
+import (
+	"time"
+	"treacherest/internal/game"
+)
+
+// RoomStore holds the live rooms and their event journals. MemoryStore is
+// the built-in implementation; other drivers register with Register and must
+// pass the conformance suite in internal/store/storetest.
+//
+// Rooms are shared: GetRoom returns the room every request works on, and
+// callers serialize changes to it with the room's own lock, calling UpdateRoom
+// once they are done. A store never expires a room on its own; rooms live
+// until DeleteRoom.
+type RoomStore interface {
+	// SetCardService gives the store the cards new and restored rooms draw from
+	SetCardService(cardService *game.CardService)
+
+	// CreateRoom creates a lobby with a code no live room has
+	CreateRoom() (*game.Room, error)
+	// GetRoom returns the live room with code, or an error when there is none
+	GetRoom(code string) (*game.Room, error)
+	// UpdateRoom saves changes made to a room
+	UpdateRoom(room *game.Room) error
+	// RegisterRestoredRoom adds a room restored from a backup, failing when a
+	// room with its code is already live
+	RegisterRestoredRoom(room *game.Room) error
+	// RoomExists reports whether a room with code is live
+	RoomExists(code string) bool
+	// Rooms returns every live room
+	Rooms() []*game.Room
+	// FindRoomByWatchToken returns the room a share link token belongs to
+	FindRoomByWatchToken(token string) (*game.Room, error)
+	// DeleteRoom removes a room and its journal
+	DeleteRoom(code string)
+
+	// Append applies an event to a room and records it in the room's
+	// journal, numbering recorded events from 1 with no gaps
+	Append(room *game.Room, e game.RoomEvent) error
+	// Events returns a room's recorded events after seq, oldest first
+	Events(code string, since int) ([]game.RoomEvent, error)
+	// Rebuild derives a new room from its journal. Rooms are loaded from
+	// their saved state, not rebuilt: changes made in place rather than
+	// appended only reach the journal through its next snapshot.
+	Rebuild(code string) (*game.Room, error)
+	// PruneEvents drops a room's events recorded before cutoff, returning
+	// how many it dropped. The caller holds the room's lock.
+	PruneEvents(room *game.Room, cutoff time.Time) (int, error)
+	// ForgetSession drops a room's events about the players sessionID has
+	// had and replaces its snapshots with one of the room as it is, so no
+	// older snapshot still holds them. It returns how many events it
+	// dropped. The caller holds the room's lock.
+	ForgetSession(room *game.Room, sessionID string) (int, error)
+}
+
+var _ RoomStore = (*MemoryStore)(nil)
@@ -0,0 +1,329 @@
+// Package storetest is the conformance suite every room store driver must
+// pass. A driver's tests call Run with a function that opens an empty store:
+//
+//	func TestConformance(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) store.RoomStore {
+//			s, err := store.Open("mydriver://...", config.DefaultConfig())
+//			if err != nil {
+//				t.Fatal(err)
+//			}
+//			return s
+//		})
+//	}
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"treacherest/internal/game"
+	"treacherest/internal/store"
+)
+
+// Open returns a new, empty store for one subtest
+type Open func(t *testing.T) store.RoomStore
+
+// Run checks that a driver behaves as the handlers expect a RoomStore to
+func Run(t *testing.T, open Open) {
+	t.Run("Rooms", func(t *testing.T) { testRooms(t, open(t)) })
+	t.Run("Restore", func(t *testing.T) { testRestore(t, open(t)) })
+	t.Run("WatchLinks", func(t *testing.T) { testWatchLinks(t, open(t)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, open(t)) })
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, open(t)) })
+	t.Run("Versioning", func(t *testing.T) { testVersioning(t, open(t)) })
+	t.Run("Rebuild", func(t *testing.T) { testRebuild(t, open(t)) })
+	t.Run("RebuildKeepsAccess", func(t *testing.T) { testRebuildKeepsAccess(t, open(t)) })
+	t.Run("PruneEvents", func(t *testing.T) { testPruneEvents(t, open(t)) })
+	t.Run("ForgetSession", func(t *testing.T) { testForgetSession(t, open(t)) })
+}
+
+func testRooms(t *testing.T, s store.RoomStore) {
+	room, err := s.CreateRoom()
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	if room.Code == "" || room.State != game.StateLobby || room.Players == nil {
+		t.Fatalf("expected an empty lobby with a code, got %+v", room)
+	}
+	if !s.RoomExists(room.Code) {
+		t.Error("expected the new room to exist")
+	}
+
+	// Every request works on the same room
+	got, err := s.GetRoom(room.Code)
+	if err != nil || got != room {
+		t.Fatalf("expected the created room back, got %v (%v)", got, err)
+	}
+	room.State = game.StateCountdown
+	if err := s.UpdateRoom(room); err != nil {
+		t.Fatalf("update room: %v", err)
+	}
+	if got, _ := s.GetRoom(room.Code); got.State != game.StateCountdown {
+		t.Errorf("expected the update saved, got %s", got.State)
+	}
+
+	if _, err := s.GetRoom("NOPE0"); err == nil {
+		t.Error("expected an unknown room to be an error")
+	}
+	if s.RoomExists("NOPE0") {
+		t.Error("expected an unknown room not to exist")
+	}
+}
+
+func testRestore(t *testing.T, s store.RoomStore) {
+	if err := s.RegisterRestoredRoom(nil); err == nil {
+		t.Error("expected a nil room refused")
+	}
+
+	restored := &game.Room{Code: "RSTR1", State: game.StatePlaying, Players: map[string]*game.Player{}}
+	if err := s.RegisterRestoredRoom(restored); err != nil {
+		t.Fatalf("register restored room: %v", err)
+	}
+	if got, err := s.GetRoom(restored.Code); err != nil || got.State != game.StatePlaying {
+		t.Fatalf("expected the restored room live, got %v (%v)", got, err)
+	}
+	if err := s.RegisterRestoredRoom(&game.Room{Code: restored.Code, Players: map[string]*game.Player{}}); err == nil {
+		t.Error("expected a second restore of a live room refused")
+	}
+}
+
+func testWatchLinks(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	link := room.CreateWatchLink()
+	s.UpdateRoom(room)
+
+	if got, err := s.FindRoomByWatchToken(link.Token); err != nil || got.Code != room.Code {
+		t.Errorf("expected the room behind the link, got %v (%v)", got, err)
+	}
+	if _, err := s.FindRoomByWatchToken("not-a-token"); err == nil {
+		t.Error("expected an unknown token to be an error")
+	}
+}
+
+func testDelete(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	if err := s.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "s1"))); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	s.DeleteRoom(room.Code)
+	if s.RoomExists(room.Code) {
+		t.Error("expected the deleted room gone")
+	}
+	for _, live := range s.Rooms() {
+		if live.Code == room.Code {
+			t.Error("expected the deleted room out of the room list")
+		}
+	}
+	if events, _ := s.Events(room.Code, 0); len(events) != 0 {
+		t.Errorf("expected the deleted room's journal gone, got %d events", len(events))
+	}
+	s.DeleteRoom(room.Code) // deleting twice is harmless
+}
+
+func testConcurrency(t *testing.T, s store.RoomStore) {
+	const rooms = 32
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		codes = make(map[string]bool)
+	)
+	for i := 0; i < rooms; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			room, err := s.CreateRoom()
+			if err != nil {
+				t.Errorf("create room: %v", err)
+				return
+			}
+			room.Lock()
+			player := game.NewPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), "s")
+			err = s.Append(room, game.PlayerJoinedEvent(player))
+			s.UpdateRoom(room)
+			room.Unlock()
+			if err != nil {
+				t.Errorf("append: %v", err)
+			}
+			s.Rooms()
+
+			mu.Lock()
+			codes[room.Code] = true
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(codes) != rooms {
+		t.Errorf("expected %d distinct room codes, got %d", rooms, len(codes))
+	}
+	if got := len(s.Rooms()); got != rooms {
+		t.Errorf("expected %d live rooms, got %d", rooms, got)
+	}
+	for code := range codes {
+		if events, _ := s.Events(code, 0); len(events) != 1 || events[0].Seq != 1 {
+			t.Errorf("expected room %s's own journal to hold its one join, got %+v", code, events)
+		}
+	}
+}
+
+func testVersioning(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	room.MaxPlayers = 10
+	alice := game.NewPlayer("p1", "Alice", "s1")
+
+	mustAppend(t, s, room, game.PlayerJoinedEvent(alice))
+	if err := s.Append(room, game.PlayerJoinedEvent(game.NewPlayer("p2", "alice", "s2"))); !errors.Is(err, game.ErrDuplicateName) {
+		t.Errorf("expected a refused event's error, got %v", err)
+	}
+	if room.GetPlayer("p2") != nil {
+		t.Error("expected a refused event to change nothing")
+	}
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p3", "Bob", "s3")))
+	mustAppend(t, s, room, game.PlayerLeftEvent("p3"))
+
+	events, err := s.Events(room.Code, 0)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected the three applied events recorded, got %d", len(events))
+	}
+	for i, e := range events {
+		if e.Seq != i+1 {
+			t.Errorf("expected event %d numbered %d, got %d", i, i+1, e.Seq)
+		}
+		if e.At.IsZero() {
+			t.Errorf("expected event %d timestamped", e.Seq)
+		}
+	}
+	if later, _ := s.Events(room.Code, 2); len(later) != 1 || later[0].Kind != game.RoomEventPlayerLeft {
+		t.Errorf("expected only the event after the second, got %+v", later)
+	}
+
+	// History is what happened then, not what the room looks like now
+	alice.Name = "Mallory"
+	if events, _ := s.Events(room.Code, 0); events[0].Player.Name != "Alice" {
+		t.Errorf("expected the recorded join unchanged, got %q", events[0].Player.Name)
+	}
+}
+
+func testRebuild(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	room.RulesMode = game.RulesModeCoup
+	alice := game.NewPlayer("p1", "Alice", "s1")
+	mustAppend(t, s, room, game.PlayerJoinedEvent(alice))
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2")))
+
+	alice.Role = &game.Card{ID: 1, Name: "The Usurper"}
+	room.State = game.StateCountdown
+	mustAppend(t, s, room, game.GameStartedEvent(room))
+	mustAppend(t, s, room, game.RoleRevealedEvent("p1", true, true))
+
+	rebuilt, err := s.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt == room {
+		t.Fatal("expected the rebuilt room separate from the live one")
+	}
+	if rebuilt.RulesMode != room.RulesMode || rebuilt.State != room.State || len(rebuilt.Players) != 2 {
+		t.Errorf("expected the live room's rules, state and players, got %s, %s and %d", rebuilt.RulesMode, rebuilt.State, len(rebuilt.Players))
+	}
+	if got := rebuilt.GetPlayer("p1"); got == nil || got.Role == nil || got.Role.ID != 1 || !got.RoleRevealed {
+		t.Errorf("expected Alice's revealed role rebuilt, got %+v", got)
+	}
+	if _, err := s.Rebuild("NOPE0"); err == nil {
+		t.Error("expected an unknown room's rebuild to be an error")
+	}
+}
+
+// testRebuildKeepsAccess checks that who may join survives the room going
+// through storage, including a ban made after the journal began
+func testRebuildKeepsAccess(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	room.SetJoinPassword("swordfish")
+	mallory := game.NewPlayer("p1", "Mallory", "banned-session")
+	mustAppend(t, s, room, game.PlayerJoinedEvent(mallory))
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2")))
+	mustAppend(t, s, room, game.PlayerBannedEvent(mallory))
+
+	rebuilt, err := s.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if !rebuilt.IsBanned("banned-session") || rebuilt.IsBanned("s2") || rebuilt.GetPlayer("p1") != nil {
+		t.Error("expected Mallory unseated and only their session still banned")
+	}
+	if !rebuilt.HasJoinPassword() || rebuilt.JoinPasswordMatches("") || !rebuilt.JoinPasswordMatches("swordfish") {
+		t.Error("expected the room to still take its password")
+	}
+}
+
+func testPruneEvents(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "s1")))
+
+	if n, err := s.PruneEvents(room, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("expected nothing pruned before the cutoff, got %d, %v", n, err)
+	}
+	n, err := s.PruneEvents(room, time.Now().Add(time.Second))
+	if err != nil || n != 1 {
+		t.Fatalf("expected the join pruned, got %d, %v", n, err)
+	}
+	if events, _ := s.Events(room.Code, 0); len(events) != 0 {
+		t.Errorf("expected no events left, got %+v", events)
+	}
+
+	// The journal goes on from where it was, and still rebuilds the room
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2")))
+	if events, _ := s.Events(room.Code, 0); len(events) != 1 || events[0].Seq != 2 {
+		t.Errorf("expected Bob's join numbered 2, got %+v", events)
+	}
+	rebuilt, err := s.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt.GetPlayer("p1") == nil || rebuilt.GetPlayer("p2") == nil {
+		t.Errorf("expected both players rebuilt, got %+v", rebuilt.Players)
+	}
+}
+
+func testForgetSession(t *testing.T, s store.RoomStore) {
+	room, _ := s.CreateRoom()
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p1", "Alice", "s1")))
+	mustAppend(t, s, room, game.PlayerJoinedEvent(game.NewPlayer("p2", "Bob", "s2")))
+	mustAppend(t, s, room, game.PlayerLeftEvent("p1"))
+
+	if n, err := s.ForgetSession(room, "nobody"); err != nil || n != 0 {
+		t.Fatalf("expected nothing forgotten for an unknown session, got %d, %v", n, err)
+	}
+	n, err := s.ForgetSession(room, "s1")
+	if err != nil || n != 2 {
+		t.Fatalf("expected Alice's join and leave forgotten, got %d, %v", n, err)
+	}
+	events, _ := s.Events(room.Code, 0)
+	for _, e := range events {
+		if e.Player != nil && e.Player.SessionID == "s1" || e.PlayerID == "p1" {
+			t.Errorf("expected nothing about Alice left, got %+v", e)
+		}
+	}
+
+	rebuilt, err := s.Rebuild(room.Code)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt.GetPlayer("p1") != nil || rebuilt.GetPlayer("p2") == nil {
+		t.Errorf("expected only Bob rebuilt, got %+v", rebuilt.Players)
+	}
+}
+
+func mustAppend(t *testing.T, s store.RoomStore, room *game.Room, e game.RoomEvent) {
+	t.Helper()
+	if err := s.Append(room, e); err != nil {
+		t.Fatalf("append %s: %v", e.Kind, err)
+	}
+}
@@ -0,0 +1,16 @@
+package testhelpers
+
+import (
+	"testing"
+
+	"treacherest"
+)
+
+// RequireCardAssets skips tests that need the real card set and artwork when
+// the binary was built with -tags nocardassets or nocardimages
+func RequireCardAssets(t testing.TB) {
+	t.Helper()
+	if !treacherest.CardAssetsEmbedded || !treacherest.CardImagesEmbedded {
+		t.Skip("card assets not embedded (built with -tags nocardassets or nocardimages)")
+	}
+}
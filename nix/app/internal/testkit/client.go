@@ -0,0 +1,106 @@
+// Package testkit provides route-level test helpers that drive the real router.
+//
+// It deliberately takes an http.Handler rather than building one, so that
+// tests inside the handlers package can pass handlers.SetupRouter without an
+// import cycle and every test exercises the production route table.
+package testkit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Client simulates a single browser: it keeps the cookies the server sets
+// and sends them with every subsequent request
+type Client struct {
+	t        testing.TB
+	router   http.Handler
+	RoomCode string
+
+	mu      sync.Mutex
+	cookies map[string]*http.Cookie
+}
+
+// NewClient creates a browser with an empty cookie jar
+func NewClient(t testing.TB, router http.Handler) *Client {
+	return &Client{
+		t:       t,
+		router:  router,
+		cookies: make(map[string]*http.Cookie),
+	}
+}
+
+// Do sends the request with the client's cookies and records any cookies set by the response
+func (c *Client) Do(req *http.Request) *httptest.ResponseRecorder {
+	c.addCookies(req)
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+	c.storeCookies(w.Result().Cookies())
+	return w
+}
+
+// Get issues a GET request
+func (c *Client) Get(path string) *httptest.ResponseRecorder {
+	return c.Do(httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// Post issues a form-encoded POST request; form may be nil
+func (c *Client) Post(path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.Do(req)
+}
+
+// Cookie returns the named cookie from the jar, or nil
+func (c *Client) Cookie(name string) *http.Cookie {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cookies[name]
+}
+
+// SetCookie puts a cookie into the jar, e.g. to impersonate a session
+func (c *Client) SetCookie(cookie *http.Cookie) {
+	c.storeCookies([]*http.Cookie{cookie})
+}
+
+// SessionCookie returns the session cookie, or nil before the first response that set one
+func (c *Client) SessionCookie() *http.Cookie {
+	return c.Cookie("session")
+}
+
+// PlayerCookie returns the player cookie for the client's room, or nil
+func (c *Client) PlayerCookie() *http.Cookie {
+	return c.Cookie("player_" + c.RoomCode)
+}
+
+// PlayerID returns the player ID the server assigned in the client's room
+func (c *Client) PlayerID() string {
+	if cookie := c.PlayerCookie(); cookie != nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+func (c *Client) addCookies(req *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cookie := range c.cookies {
+		req.AddCookie(cookie)
+	}
+}
+
+func (c *Client) storeCookies(cookies []*http.Cookie) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cookie := range cookies {
+		if cookie.MaxAge < 0 {
+			delete(c.cookies, cookie.Name)
+			continue
+		}
+		c.cookies[cookie.Name] = &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+	}
+}
@@ -0,0 +1,263 @@
+package testkit
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// OperatorName is the Scenario's Room Operator, for the methods taking a name
+const OperatorName = "Operator"
+
+// EventSource subscribes to a room's published event types. testkit can't
+// see the handlers' event bus, so tests in the handlers package supply one.
+type EventSource func(roomCode string) (types <-chan string, stop func())
+
+// Scenario scripts a multi-player flow against the real router: a room run
+// by a non-playing Room Operator, players who join with a live room stream
+// each, and expectations on what the room published and what the streams
+// received. Every step fails the test on its own, so a flow reads as a list
+// of steps:
+//
+//	testkit.NewScenario(t, router).
+//		Join("Alice").Join("Bob").
+//		SetPreset("standard").
+//		Start().
+//		ExpectFragmentContains("#game-container", "Revealing roles in")
+type Scenario struct {
+	t       testing.TB
+	router  http.Handler
+	timeout time.Duration
+
+	// RoomCode is the scenario's room
+	RoomCode string
+
+	clients map[string]*Client
+	streams map[string]*Stream
+	players []string // in join order
+	focus   []string // whose streams expectations look at; nil for every player
+	events  <-chan string
+}
+
+// NewScenario creates a room run by a non-playing Room Operator, whose
+// dashboard stream stays open for the whole scenario
+func NewScenario(t testing.TB, router http.Handler) *Scenario {
+	t.Helper()
+	s := &Scenario{
+		t:       t,
+		router:  router,
+		timeout: 2 * time.Second,
+		clients: make(map[string]*Client),
+		streams: make(map[string]*Stream),
+	}
+	operator := CreateRoom(t, router, OperatorName, true)
+	s.RoomCode = operator.RoomCode
+	s.clients[OperatorName] = operator
+	s.open(OperatorName, "?view=host")
+	t.Cleanup(func() {
+		for _, stream := range s.streams {
+			stream.Close()
+		}
+	})
+	return s
+}
+
+// WithEvents subscribes to the room's events through source, for ExpectEvent
+func (s *Scenario) WithEvents(source EventSource) *Scenario {
+	types, stop := source(s.RoomCode)
+	s.events = types
+	s.t.Cleanup(stop)
+	return s
+}
+
+// WithTimeout sets how long each expectation waits; the default is two seconds
+func (s *Scenario) WithTimeout(timeout time.Duration) *Scenario {
+	s.timeout = timeout
+	return s
+}
+
+// Client returns the browser of the named player or OperatorName
+func (s *Scenario) Client(name string) *Client {
+	s.t.Helper()
+	c, ok := s.clients[name]
+	if !ok {
+		s.t.Fatalf("scenario: no player named %s", name)
+	}
+	return c
+}
+
+// Stream returns the named player's current room stream
+func (s *Scenario) Stream(name string) *Stream {
+	s.t.Helper()
+	stream, ok := s.streams[name]
+	if !ok {
+		s.t.Fatalf("scenario: %s has no open stream", name)
+	}
+	return stream
+}
+
+// Join seats a player through the join form and opens their lobby stream
+func (s *Scenario) Join(name string) *Scenario {
+	s.t.Helper()
+	s.clients[name] = JoinRoom(s.t, s.router, s.RoomCode, name)
+	s.players = append(s.players, name)
+	s.open(name, "?view=lobby")
+	return s
+}
+
+// SetPreset applies a role preset as the Room Operator
+func (s *Scenario) SetPreset(preset string) *Scenario {
+	s.t.Helper()
+	return s.Post(OperatorName, "/room/{code}/config/preset", url.Values{"preset": {preset}})
+}
+
+// Start starts the game as the Room Operator
+func (s *Scenario) Start() *Scenario {
+	s.t.Helper()
+	return s.Post(OperatorName, "/room/{code}/start", nil)
+}
+
+// Reveal turns the named player's role face up
+func (s *Scenario) Reveal(name string) *Scenario {
+	s.t.Helper()
+	return s.Post(name, "/room/{code}/reveal/"+s.Client(name).PlayerID(), nil)
+}
+
+// Leave takes the named player out of the room and closes their stream
+func (s *Scenario) Leave(name string) *Scenario {
+	s.t.Helper()
+	s.Post(name, "/room/{code}/leave", nil)
+	s.disconnect(name)
+	return s
+}
+
+// Disconnect closes the named player's stream, as if they lost their signal
+func (s *Scenario) Disconnect(name string) *Scenario {
+	s.t.Helper()
+	s.disconnect(name)
+	return s
+}
+
+// Reconnect reopens the named player's stream, from whichever page the room's
+// state gives them
+func (s *Scenario) Reconnect(name string) *Scenario {
+	s.t.Helper()
+	s.disconnect(name)
+	s.open(name, "")
+	return s
+}
+
+// Post sends a form as the named player or OperatorName and fails unless it
+// succeeds; {code} in path is the room code
+func (s *Scenario) Post(name, path string, form url.Values) *Scenario {
+	s.t.Helper()
+	path = strings.ReplaceAll(path, "{code}", s.RoomCode)
+	w := s.Client(name).Post(path, form)
+	if w.Code >= 400 {
+		s.t.Fatalf("scenario: %s POST %s: got %d: %s", name, path, w.Code, w.Body.String())
+	}
+	return s
+}
+
+// Only points the expectations that follow at the named streams
+func (s *Scenario) Only(names ...string) *Scenario {
+	s.focus = names
+	return s
+}
+
+// Everyone points the expectations that follow back at every player
+func (s *Scenario) Everyone() *Scenario {
+	s.focus = nil
+	return s
+}
+
+// ExpectEvent waits for the room to publish an event of eventType, passing
+// over any others
+func (s *Scenario) ExpectEvent(eventType string) *Scenario {
+	s.t.Helper()
+	if s.events == nil {
+		s.t.Fatal("scenario: ExpectEvent needs WithEvents")
+	}
+	deadline := time.After(s.timeout)
+	var seen []string
+	for {
+		select {
+		case published, ok := <-s.events:
+			if !ok {
+				s.t.Fatalf("scenario: event stream closed waiting for %s, saw %v", eventType, seen)
+			}
+			if published == eventType {
+				return s
+			}
+			seen = append(seen, published)
+		case <-deadline:
+			s.t.Fatalf("scenario: expected a %s event, saw %v", eventType, seen)
+		}
+	}
+}
+
+// ExpectFragmentContains waits until every focused stream has received an
+// element patch for selector containing substr
+func (s *Scenario) ExpectFragmentContains(selector, substr string) *Scenario {
+	s.t.Helper()
+	for _, name := range s.focused() {
+		if stream := s.Stream(name); !stream.WaitForFragment(selector, substr, s.timeout) {
+			s.t.Fatalf("scenario: expected %s's %s to contain %q, got %q", name, selector, substr, stream.Fragments(selector))
+		}
+	}
+	return s
+}
+
+// ExpectStreamContains waits until every focused stream has received substr
+// anywhere, e.g. in a signal patch
+func (s *Scenario) ExpectStreamContains(substr string) *Scenario {
+	s.t.Helper()
+	for _, name := range s.focused() {
+		if stream := s.Stream(name); !stream.WaitFor(substr, s.timeout) {
+			s.t.Fatalf("scenario: expected %s's stream to contain %q, got %s", name, substr, stream.Data())
+		}
+	}
+	return s
+}
+
+// ExpectStreamLacks checks that no focused stream has received substr so far
+func (s *Scenario) ExpectStreamLacks(substr string) *Scenario {
+	s.t.Helper()
+	for _, name := range s.focused() {
+		if stream := s.Stream(name); strings.Contains(stream.Data(), substr) {
+			s.t.Fatalf("scenario: expected %s's stream not to contain %q, got %s", name, substr, stream.Data())
+		}
+	}
+	return s
+}
+
+func (s *Scenario) focused() []string {
+	if s.focus != nil {
+		return s.focus
+	}
+	return s.players
+}
+
+// open connects name's stream and waits for its first write, so nothing the
+// next step publishes is missed
+func (s *Scenario) open(name, query string) {
+	s.t.Helper()
+	stream := s.Client(name).OpenSSE("/sse/room/" + s.RoomCode + query)
+	deadline := time.Now().Add(s.timeout)
+	for stream.Status() == 0 && !stream.Closed() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if stream.Status() != http.StatusOK {
+		s.t.Fatalf("scenario: %s's stream opened with %d: %s", name, stream.Status(), stream.Data())
+	}
+	s.streams[name] = stream
+}
+
+func (s *Scenario) disconnect(name string) {
+	if stream, ok := s.streams[name]; ok {
+		stream.Close()
+		delete(s.streams, name)
+	}
+}
@@ -0,0 +1,61 @@
+package testkit
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"treacherest/internal/views/components"
+)
+
+// formElapsed is how long a person takes over the create and join forms, as
+// the forms' script reports it to the bot checks
+const formElapsed = "5000"
+
+// CreateRoom submits the home page form and returns the creator's browser.
+// With hostOnly the creator is a non-playing Room Operator.
+func CreateRoom(t testing.TB, router http.Handler, playerName string, hostOnly bool) *Client {
+	t.Helper()
+
+	c := NewClient(t, router)
+	form := url.Values{"playerName": {playerName}, components.BotElapsedField: {formElapsed}}
+	if hostOnly {
+		form.Set("hostOnly", "true")
+	}
+
+	w := c.Post("/room/new", form)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("create room as %s: expected 303, got %d: %s", playerName, w.Code, w.Body.String())
+	}
+
+	// Players land on /room/{code}, hosts on /host/{code}
+	location := w.Header().Get("Location")
+	c.RoomCode = strings.TrimPrefix(strings.TrimPrefix(location, "/room/"), "/host/")
+	if c.RoomCode == "" || c.PlayerCookie() == nil {
+		t.Fatalf("create room as %s: no room code or player cookie in response", playerName)
+	}
+	return c
+}
+
+// JoinRoom submits the join form for roomCode in a fresh browser
+func JoinRoom(t testing.TB, router http.Handler, roomCode, playerName string) *Client {
+	t.Helper()
+
+	c := NewClient(t, router)
+	c.RoomCode = roomCode
+
+	w := c.Post("/join-room", url.Values{"room_code": {roomCode}, "player_name": {playerName}, components.BotElapsedField: {formElapsed}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("join room %s as %s: expected 303, got %d: %s", roomCode, playerName, w.Code, w.Body.String())
+	}
+	if c.PlayerCookie() == nil {
+		t.Fatalf("join room %s as %s: no player cookie received", roomCode, playerName)
+	}
+	return c
+}
+
+// StartGame posts the start action for the client's room
+func (c *Client) StartGame() int {
+	return c.Post("/room/"+c.RoomCode+"/start", nil).Code
+}
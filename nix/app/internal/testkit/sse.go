@@ -0,0 +1,203 @@
+package testkit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream is a live SSE connection served by the router in a background goroutine.
+// It implements http.ResponseWriter and http.Flusher so the handler can stream into it.
+type Stream struct {
+	header http.Header
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	status int
+	data   bytes.Buffer
+}
+
+// OpenSSE connects the client to an SSE endpoint. The connection stays open
+// until Close is called or the handler returns on its own.
+func (c *Client) OpenSSE(path string) *Stream {
+	return c.ResumeSSE(path, "")
+}
+
+// ResumeSSE connects like OpenSSE, the way a browser reconnects: with the
+// Last-Event-ID of the last event it saw, when there was one.
+func (c *Client) ResumeSSE(path, lastEventID string) *Stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx)
+	c.addCookies(req)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	s := &Stream{
+		header: make(http.Header),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		c.router.ServeHTTP(s, req)
+	}()
+	return s
+}
+
+// Header implements http.ResponseWriter
+func (s *Stream) Header() http.Header {
+	return s.header
+}
+
+// WriteHeader implements http.ResponseWriter
+func (s *Stream) WriteHeader(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == 0 {
+		s.status = status
+	}
+}
+
+// Write implements http.ResponseWriter
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.data.Write(p)
+}
+
+// Flush implements http.Flusher; data is visible to readers as soon as it is written
+func (s *Stream) Flush() {}
+
+// Status returns the response status, or 0 if nothing has been written yet
+func (s *Stream) Status() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Data returns everything streamed so far
+func (s *Stream) Data() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data.String()
+}
+
+// LastEventID returns the last event ID streamed so far, the one a browser
+// would send back on reconnecting
+func (s *Stream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := ""
+	for _, line := range strings.Split(s.data.String(), "\n") {
+		if rest, ok := strings.CutPrefix(line, "id: "); ok {
+			id = rest
+		}
+	}
+	return id
+}
+
+// WaitFor polls until the streamed data contains substr or the timeout
+// elapses, reporting whether it was seen
+func (s *Stream) WaitFor(substr string, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if strings.Contains(s.Data(), substr) {
+			return true
+		}
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return strings.Contains(s.Data(), substr)
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// Fragments returns the elements of every Datastar element patch streamed so
+// far that targets selector, either by name or, for a patch without one, by
+// its top-level element's id
+func (s *Stream) Fragments(selector string) []string {
+	var fragments []string
+	for _, event := range strings.Split(s.Data(), "\n\n") {
+		if !strings.Contains(event, "event: datastar-patch-elements") {
+			continue
+		}
+		var target string
+		var elements []string
+		for _, line := range strings.Split(event, "\n") {
+			if value, ok := strings.CutPrefix(line, "data: selector "); ok {
+				target = value
+			} else if value, ok := strings.CutPrefix(line, "data: elements "); ok {
+				elements = append(elements, value)
+			}
+		}
+		html := strings.Join(elements, "\n")
+		if target == selector || target == "" && strings.HasPrefix(selector, "#") && strings.Contains(html, `id="`+selector[1:]+`"`) {
+			fragments = append(fragments, html)
+		}
+	}
+	return fragments
+}
+
+// WaitForFragment polls until an element patch targeting selector contains
+// substr or the timeout elapses, reporting whether one was seen
+func (s *Stream) WaitForFragment(selector, substr string, timeout time.Duration) bool {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	seen := func() bool {
+		for _, fragment := range s.Fragments(selector) {
+			if strings.Contains(fragment, substr) {
+				return true
+			}
+		}
+		return false
+	}
+	for {
+		if seen() {
+			return true
+		}
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return seen()
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// Done is closed once the handler has returned
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Closed reports whether the handler has returned, e.g. after a redirect
+func (s *Stream) Closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close disconnects the client and waits for the handler to return
+func (s *Stream) Close() {
+	s.cancel()
+	<-s.done
+}
@@ -0,0 +1,104 @@
+package testkit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/handlers"
+	"treacherest/internal/store"
+	"treacherest/internal/testkit"
+)
+
+func newRouter(t *testing.T) http.Handler {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	s := store.NewMemoryStore(cfg)
+	cards := &game.CardService{
+		Leaders:   []*game.Card{{ID: 1, Name: "Leader", Types: game.CardTypes{Subtype: "Leader"}}},
+		Guardians: []*game.Card{{ID: 2, Name: "Guardian", Types: game.CardTypes{Subtype: "Guardian"}}},
+		Assassins: []*game.Card{{ID: 3, Name: "Assassin", Types: game.CardTypes{Subtype: "Assassin"}}},
+		Traitors:  []*game.Card{{ID: 4, Name: "Traitor", Types: game.CardTypes{Subtype: "Traitor"}}},
+	}
+	s.SetCardService(cards)
+	h := handlers.New(s, cards, cfg, nil)
+	return handlers.SetupRouter(h, cfg, &handlers.RouterOptions{DisableRateLimiting: true, DisableRequestLogger: true})
+}
+
+func TestCreateAndJoinRoomKeepCookies(t *testing.T) {
+	router := newRouter(t)
+
+	host := testkit.CreateRoom(t, router, "Alice", false)
+	if host.RoomCode == "" || host.PlayerID() == "" || host.SessionCookie() == nil {
+		t.Fatalf("expected room code, player and session cookies, got %q %q %v", host.RoomCode, host.PlayerID(), host.SessionCookie())
+	}
+
+	guest := testkit.JoinRoom(t, router, host.RoomCode, "Bob")
+	if guest.PlayerID() == "" || guest.PlayerID() == host.PlayerID() {
+		t.Fatalf("expected a distinct player for the guest, got %q", guest.PlayerID())
+	}
+
+	// The jar carries the player cookie, so the lobby renders for the guest
+	if w := guest.Get("/room/" + host.RoomCode); w.Code != http.StatusOK {
+		t.Fatalf("expected lobby page, got %d", w.Code)
+	}
+}
+
+func TestHostOnlyCreateSetsHostCookie(t *testing.T) {
+	router := newRouter(t)
+
+	host := testkit.CreateRoom(t, router, "Operator", true)
+	if host.Cookie("host_"+host.RoomCode) == nil {
+		t.Fatal("expected host cookie for a host-only room")
+	}
+}
+
+func TestStreamCapturesLiveEvents(t *testing.T) {
+	router := newRouter(t)
+	host := testkit.CreateRoom(t, router, "Alice", false)
+
+	stream := host.OpenSSE("/sse/lobby/" + host.RoomCode)
+	time.Sleep(100 * time.Millisecond)
+
+	testkit.JoinRoom(t, router, host.RoomCode, "Bob")
+	if !stream.WaitFor("Bob", 2*time.Second) {
+		t.Fatalf("expected lobby stream to announce Bob, got %q", stream.Data())
+	}
+	if stream.Status() != http.StatusOK {
+		t.Errorf("expected 200, got %d", stream.Status())
+	}
+
+	stream.Close()
+	if !stream.Closed() {
+		t.Error("expected stream to be closed after Close")
+	}
+}
+
+func TestStreamWaitForTimesOut(t *testing.T) {
+	router := newRouter(t)
+	host := testkit.CreateRoom(t, router, "Alice", false)
+
+	stream := host.OpenSSE("/sse/lobby/" + host.RoomCode)
+	defer stream.Close()
+
+	if stream.WaitFor("never-sent", 50*time.Millisecond) {
+		t.Fatal("expected WaitFor to time out")
+	}
+}
+
+func TestScenarioRunsAFlowStepByStep(t *testing.T) {
+	router := newRouter(t)
+
+	s := testkit.NewScenario(t, router).
+		Join("Alice").Join("Bob").
+		Only("Alice").ExpectFragmentContains("#player-list-card", "Bob").
+		Everyone().Start().
+		ExpectFragmentContains("#page-body", "game-container")
+	if s.Client("Alice").PlayerID() == "" || s.Client(testkit.OperatorName).Cookie("host_"+s.RoomCode) == nil {
+		t.Fatal("expected the scenario's browsers to keep their cookies")
+	}
+
+	s.Leave("Bob").Only("Alice").ExpectStreamLacks("open_elsewhere")
+}
@@ -0,0 +1,28 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"treacherest/internal/testhelpers"
+)
+
+func TestConnectionBanner(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	html := renderer.Render(ConnectionBanner("ABCDE", "")).GetHTML()
+	if !strings.Contains(html, `id="connection-banner"`) || strings.Contains(html, "alert-error") {
+		t.Fatalf("expected an empty banner placeholder, got %s", html)
+	}
+
+	html = renderer.Render(ConnectionBanner("ABCDE", ConnectionLostKeepalive)).GetHTML()
+	if !strings.Contains(html, "/room/ABCDE/resync") || !strings.Contains(html, ConnectionLostKeepalive) {
+		t.Fatalf("expected a retry button and the reason code, got %s", html)
+	}
+
+	for _, reason := range []string{ConnectionLostRoomGone, ConnectionLostPlayerRemoved} {
+		html = renderer.Render(ConnectionBanner("ABCDE", reason)).GetHTML()
+		if strings.Contains(html, "/resync") || !strings.Contains(html, `href="/"`) {
+			t.Errorf("%s: expected a way home instead of a retry, got %s", reason, html)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package components
 import (
 	"strings"
 	"testing"
+	"treacherest/internal/game"
 	"treacherest/internal/testhelpers"
 )
 
@@ -22,3 +23,26 @@ func TestCountdownDisplayWithMessage_RedesignTimerSemantics(t *testing.T) {
 		}
 	}
 }
+
+func TestCountdownForPlayer_Styles(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	numeric := renderer.Render(CountdownForPlayer(game.CountdownNumeric, 3, RevealCountdownMessage)).GetHTML()
+	if !strings.Contains(numeric, `role="timer"`) {
+		t.Errorf("expected the numeric style to keep the timer, got %s", numeric)
+	}
+
+	progress := renderer.Render(CountdownForPlayer(game.CountdownProgress, 3, RevealCountdownMessage)).GetHTML()
+	for _, expected := range []string{"<progress", `max="5"`, `value="2"`, `data-attr:value="5 - $countdown"`} {
+		if !strings.Contains(progress, expected) {
+			t.Errorf("expected progress style to contain %q in %s", expected, progress)
+		}
+	}
+
+	text := renderer.Render(CountdownForPlayer(game.CountdownText, 1, RevealCountdownMessage)).GetHTML()
+	for _, expected := range []string{`id="countdown-announcement"`, `aria-live="assertive"`, "Roles are revealed in 1 second."} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected text style to contain %q in %s", expected, text)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package components
 
 import (
+	"fmt"
 	"html"
 	"regexp"
 	"strings"
+	"treacherest/internal/game"
 	"unicode/utf8"
 )
 
@@ -85,3 +87,45 @@ func formatRoleCardLine(line string) roleCardTextLine {
 func escapeRoleCardHTML(text string) string {
 	return html.EscapeString(text)
 }
+
+// roleCardImageSrc is viewer's role image URL when the room deters
+// screenshots, and the inline card art otherwise. A deterred card with no
+// viewer gets no image. The card ID in the URL makes a changed role load its
+// new image.
+func roleCardImageSrc(card *game.Card, room *game.Room, viewer *game.Player) string {
+	if card.GetImageBase64() == "" {
+		return ""
+	}
+	if room == nil || !room.ScreenshotDeterrence {
+		return card.GetImageBase64()
+	}
+	if viewer == nil {
+		return ""
+	}
+	token := room.RoleImageToken(viewer.ID)
+	if token == "" {
+		return ""
+	}
+	return fmt.Sprintf("/room/%s/role-image/%s?card=%d", room.Code, token, card.ID)
+}
+
+// roleImagePreloadSrc is the URL player's role card will load its art from,
+// or "" when there is nothing to fetch ahead of the reveal
+func roleImagePreloadSrc(room *game.Room, player *game.Player) string {
+	if player == nil || player.Role == nil {
+		return ""
+	}
+	src := roleCardImageSrc(player.Role, room, player)
+	if strings.HasPrefix(src, "data:") {
+		return ""
+	}
+	return src
+}
+
+// roleCardDeterrenceClass keeps a deterred role card out of text selection and printouts
+func roleCardDeterrenceClass(room *game.Room) string {
+	if room != nil && room.ScreenshotDeterrence {
+		return "select-none print:hidden"
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package components
+
+import (
+	"net/url"
+	"strconv"
+	"treacherest/internal/game"
+)
+
+// RoleCardPageSize is how many cards one page of a role type's card list shows
+const RoleCardPageSize = 10
+
+// RoleCardPage is one page of a role type's card list
+type RoleCardPage struct {
+	Cards []*game.Card
+	Page  int // 1-based, clamped to Pages
+	Pages int // at least 1
+	Total int // cards across all pages
+	Query string
+
+	ShowPacks bool // the card data has more than one pack, so each card names its own
+}
+
+// NewRoleCardPage picks the page of cards view asks for. A query keeps only
+// the cards matching it, best match first.
+func NewRoleCardPage(cardService *game.CardService, cards []*game.Card, view game.CardListView) RoleCardPage {
+	if view.Query != "" {
+		inType := make(map[*game.Card]bool, len(cards))
+		for _, card := range cards {
+			inType[card] = true
+		}
+		matches := make([]*game.Card, 0)
+		if cardService != nil {
+			for _, result := range cardService.Search(view.Query, 0) {
+				if inType[result.Card] {
+					matches = append(matches, result.Card)
+				}
+			}
+		}
+		cards = matches
+	}
+
+	pages := max(1, (len(cards)+RoleCardPageSize-1)/RoleCardPageSize)
+	page := min(max(view.Page, 1), pages)
+	start := (page - 1) * RoleCardPageSize
+	end := min(start+RoleCardPageSize, len(cards))
+	return RoleCardPage{
+		Cards: cards[start:end],
+		Page:  page,
+		Pages: pages,
+		Total: len(cards),
+		Query: view.Query,
+
+		ShowPacks: cardService != nil && len(cardService.Packs()) > 1,
+	}
+}
+
+// CardPageURL is the address of a card's detail page, in locale when it is
+// not English
+func CardPageURL(cardID int, locale string) string {
+	path := "/cards/" + strconv.Itoa(cardID)
+	if locale == "" || locale == game.DefaultCardLocale {
+		return path
+	}
+	return path + "?lang=" + url.QueryEscape(locale)
+}
@@ -0,0 +1,34 @@
+package components
+
+import (
+	"testing"
+	"treacherest/internal/game"
+)
+
+func TestNewRoleCardPage(t *testing.T) {
+	service := game.NewSandboxCardService(RoleCardPageSize + 3)
+	traitors := service.Traitors
+
+	page := NewRoleCardPage(service, traitors, game.CardListView{Page: 2})
+	if page.Pages != 2 || page.Page != 2 || page.Total != RoleCardPageSize+3 || len(page.Cards) != 3 {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+	if page.Cards[0] != traitors[RoleCardPageSize] {
+		t.Errorf("expected the second page to start at card %d, got %s", RoleCardPageSize+1, page.Cards[0].Name)
+	}
+
+	if page := NewRoleCardPage(service, traitors, game.CardListView{Page: 9}); page.Page != 2 {
+		t.Errorf("expected a page past the end to clamp to 2, got %d", page.Page)
+	}
+	if page := NewRoleCardPage(service, traitors, game.CardListView{}); page.Page != 1 || len(page.Cards) != RoleCardPageSize {
+		t.Errorf("expected page 0 to show the first full page, got page %d with %d cards", page.Page, len(page.Cards))
+	}
+
+	page = NewRoleCardPage(service, traitors, game.CardListView{Page: 1, Query: "Sandbox Traitor 12"})
+	if page.Total != 1 || page.Pages != 1 || page.Cards[0].Name != "Sandbox Traitor 12" {
+		t.Errorf("expected only Sandbox Traitor 12 to match, got %+v", page)
+	}
+	if page := NewRoleCardPage(service, traitors, game.CardListView{Page: 1, Query: "Sandbox Leader"}); page.Total != 0 || len(page.Cards) != 0 || page.Pages != 1 {
+		t.Errorf("expected no Traitors to match a Leader search, got %+v", page)
+	}
+}
@@ -0,0 +1,21 @@
+package components
+
+import "treacherest/internal/game"
+
+// ViewerContext is who a fragment is rendered for. Components holding Room
+// Operator controls take one and render nothing unless CanControl is set, so
+// every render path hides those controls from players the same way.
+type ViewerContext struct {
+	Player     *game.Player // nil for viewers without a seat
+	CanControl bool
+}
+
+// NewViewerContext returns the context for player viewing room. Only the Room
+// Operator's session controls the room; a debug "view as" render passes the
+// viewed player and so gets that player's view.
+func NewViewerContext(room *game.Room, player *game.Player) ViewerContext {
+	return ViewerContext{
+		Player:     player,
+		CanControl: room != nil && player != nil && room.IsOperatorSession(player.SessionID),
+	}
+}
@@ -0,0 +1,62 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"treacherest/internal/config"
+	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
+
+	"github.com/a-h/templ"
+)
+
+func TestNewViewerContext(t *testing.T) {
+	operator := game.NewPlayer("op", "Operator", "session-operator")
+	player := game.NewPlayer("p1", "Player", "session-player")
+	room := &game.Room{Code: "VIEW1", Players: map[string]*game.Player{operator.ID: operator, player.ID: player}}
+	room.OperatorSessionID = operator.SessionID
+
+	if viewer := NewViewerContext(room, operator); !viewer.CanControl || viewer.Player != operator {
+		t.Errorf("expected the Room Operator to control the room, got %+v", viewer)
+	}
+	if viewer := NewViewerContext(room, player); viewer.CanControl {
+		t.Error("expected a player to be unable to control the room")
+	}
+	if viewer := NewViewerContext(room, nil); viewer.CanControl {
+		t.Error("expected a viewer without a seat to be unable to control the room")
+	}
+}
+
+func TestRoleConfigurationHiddenFromNonControllers(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+	cfg := config.DefaultConfig()
+	roleConfig, err := game.NewRoleConfigService(cfg).CreateFromPreset("standard", 5)
+	if err != nil {
+		t.Fatalf("create role config: %v", err)
+	}
+	cardService := game.NewSandboxCardService(2)
+	room := &game.Room{
+		Code:          "VIEW2",
+		RoleConfig:    roleConfig,
+		Players:       make(map[string]*game.Player),
+		CardListViews: map[string]game.CardListView{"Leader": {Page: 1}},
+	}
+	player := game.NewPlayer("p1", "Player", "session-player")
+	room.Players[player.ID] = player
+
+	viewer := NewViewerContext(room, player)
+	for name, component := range map[string]templ.Component{
+		"role config": RoleConfigurationNew(viewer, room, cfg, cardService, PlayerCountDisplay{}),
+		"card list":   RoleTypeCards(viewer, room, "Leader", roleConfig.RoleTypes["Leader"], cardService, cardService.Leaders),
+		"card modals": RoleTypeCardModals(viewer, room, "Leader", cardService, cardService.Leaders),
+	} {
+		if html := strings.TrimSpace(renderer.Render(component).GetHTML()); html != "" {
+			t.Errorf("expected no %s markup for a player, got %s", name, html)
+		}
+	}
+
+	controller := ViewerContext{Player: player, CanControl: true}
+	renderer.Render(RoleConfigurationNew(controller, room, cfg, cardService, PlayerCountDisplay{})).
+		AssertContains(`id="role-config"`).
+		AssertContains("card-Leader-sandbox-leader-1")
+}
@@ -0,0 +1,14 @@
+package pages
+
+import "strings"
+
+// attributionParagraphs splits the attribution notice on blank lines
+func attributionParagraphs(text string) []string {
+	paragraphs := make([]string, 0)
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
@@ -100,7 +100,7 @@ func TestGamePage(t *testing.T) {
 			AssertValid().
 			AssertContains("Test Guardian").
 			AssertHasElementWithID("game-container").
-			AssertContains(`data-init="@get(&#39;/sse/game/GAME1&#39;)"`)
+			AssertContains(`data-init="window.roomSocket ? roomSocket.subscribe(el, &#39;GAME1&#39;, &#39;game&#39;) : @get(&#39;/sse/room/GAME1?view=game&#39;)"`)
 	})
 
 	t.Run("shows player role", func(t *testing.T) {
@@ -1225,3 +1225,67 @@ func TestGameBody_CoupPrivateInformationScopedToRecipient(t *testing.T) {
 		AssertNotContains("Blue Knights:").
 		AssertNotContains("Known: Blue Knight")
 }
+
+func TestGameContent_KnownInfoOnlyForOwner(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	room := &game.Room{
+		Code:    "KNOW1",
+		State:   game.StatePlaying,
+		Players: make(map[string]*game.Player),
+	}
+	gus := game.NewPlayer("g1", "Gus", "s1")
+	gus.Role = mockGuardianCard()
+	gia := game.NewPlayer("g2", "Gia", "s2")
+	gia.Role = mockGuardianCard()
+	ann := game.NewPlayer("a1", "Ann", "s3")
+	ann.Role = mockAssassinCard()
+	gus.KnownInfo = []game.KnownInfo{{PlayerID: gia.ID, PlayerName: gia.Name, RoleType: game.RoleGuardian}}
+	for _, p := range []*game.Player{gus, gia, ann} {
+		room.Players[p.ID] = p
+	}
+
+	html := renderer.Render(GameContent(room, gus)).GetHTML()
+	if !strings.Contains(html, `id="known-info"`) || !strings.Contains(html, "is a Guardian") {
+		t.Fatalf("expected Gus to see his ally knowledge, got %s", html)
+	}
+
+	html = renderer.Render(GameContent(room, ann)).GetHTML()
+	if strings.Contains(html, `id="known-info"`) {
+		t.Fatalf("Ann should not see another player's knowledge: %s", html)
+	}
+}
+
+func TestGameContent_CountdownPreloadsOnlyOwnRoleImage(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	room := &game.Room{
+		Code:                 "PRE01",
+		State:                game.StateCountdown,
+		CountdownRemaining:   3,
+		Players:              make(map[string]*game.Player),
+		ScreenshotDeterrence: true,
+	}
+	room.EnsureRoleImageSecret()
+	gus := game.NewPlayer("g1", "Gus", "s1")
+	gus.Role = mockGuardianCard()
+	ann := game.NewPlayer("a1", "Ann", "s2")
+	ann.Role = mockAssassinCard()
+	for _, p := range []*game.Player{gus, ann} {
+		p.FaceUp = false
+		room.Players[p.ID] = p
+	}
+
+	html := renderer.Render(GameContent(room, gus)).GetHTML()
+	if !strings.Contains(html, `<link rel="preload" as="image" href="/room/PRE01/role-image/`+room.RoleImageToken(gus.ID)) {
+		t.Fatalf("expected Gus's page to preload his role image, got %s", html)
+	}
+	if strings.Contains(html, room.RoleImageToken(ann.ID)) || strings.Contains(html, "Test Guardian") {
+		t.Fatalf("the preload should not show the role or another player's image: %s", html)
+	}
+
+	room.ScreenshotDeterrence = false
+	if html := renderer.Render(GameContent(room, gus)).GetHTML(); strings.Contains(html, `rel="preload"`) {
+		t.Fatalf("inline art needs no preload: %s", html)
+	}
+}
@@ -32,6 +32,25 @@ func TestHomePage(t *testing.T) {
 			AssertContains(`placeholder="Enter your name (optional)"`)
 	})
 
+	t.Run("has bot check fields in both forms", func(t *testing.T) {
+		html := renderer.Render(Home()).GetHTML()
+
+		if got := strings.Count(html, `name="website"`); got != 2 {
+			t.Errorf("expected a honeypot in both forms, got %d", got)
+		}
+		if got := strings.Count(html, `name="form_elapsed"`); got != 2 {
+			t.Errorf("expected submit timing in both forms, got %d", got)
+		}
+	})
+
+	t.Run("labels the seat PIN input", func(t *testing.T) {
+		component := Home()
+
+		renderer.Render(component).
+			AssertHasElementWithID("create-seat-pin").
+			AssertContains(`for="create-seat-pin"`)
+	})
+
 	t.Run("has room code input", func(t *testing.T) {
 		component := Home()
 
@@ -67,13 +86,14 @@ func TestHomePage(t *testing.T) {
 			AssertContains(`evt.preventDefault()`)
 	})
 
-	t.Run("has two forms", func(t *testing.T) {
+	t.Run("has join, create and pass-the-phone forms", func(t *testing.T) {
 		component := Home()
 
 		renderer.Render(component).
-			AssertElementCount("form", 2).
+			AssertElementCount("form", 3).
 			AssertContains("Create New Game").
-			AssertContains("Join Existing Game")
+			AssertContains("Join Existing Game").
+			AssertContains(`action="/table/new"`)
 	})
 
 	t.Run("has non-playing operator checkbox", func(t *testing.T) {
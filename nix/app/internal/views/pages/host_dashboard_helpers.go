@@ -18,6 +18,14 @@ func HostDashboardStartMessage(room *game.Room, cfg *config.ServerConfig) string
 	return hostDashboardStartStateFor(room, cfg).Message
 }
 
+// startChecklistIssues is the start checklist for a Treachery room
+func startChecklistIssues(room *game.Room, cfg *config.ServerConfig) []game.ValidationIssue {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+	return room.GetValidationState(game.NewRoleConfigService(room.Config(cfg))).Issues
+}
+
 func hostDashboardStartStateFor(room *game.Room, cfg *config.ServerConfig) hostDashboardStartState {
 	if room == nil {
 		return hostDashboardStartState{CanStart: false, Message: "Room is unavailable"}
@@ -33,7 +41,7 @@ func hostDashboardStartStateFor(room *game.Room, cfg *config.ServerConfig) hostD
 	if cfg == nil {
 		cfg = config.DefaultConfig()
 	}
-	state := room.GetValidationState(game.NewRoleConfigService(cfg))
+	state := room.GetValidationState(game.NewRoleConfigService(room.Config(cfg)))
 	if state.CanStart {
 		if state.ValidationMessage != "" {
 			return hostDashboardStartState{CanStart: true, Message: state.ValidationMessage}
@@ -45,3 +53,15 @@ func hostDashboardStartStateFor(room *game.Room, cfg *config.ServerConfig) hostD
 	}
 	return hostDashboardStartState{CanStart: false, Message: "Room is not ready to start"}
 }
+
+// hostCanTransferTo reports whether the dashboard offers to hand Room
+// Operator authority to player: a real, playing seat other than the operator's
+func hostCanTransferTo(room *game.Room, player *game.Player) bool {
+	return !player.IsDebug && !player.IsHost && !room.IsOperatorSession(player.SessionID)
+}
+
+// hostCanRemove reports whether the dashboard offers to kick or ban player:
+// anyone in the lobby but the operator
+func hostCanRemove(room *game.Room, player *game.Player) bool {
+	return room.State == game.StateLobby && !room.IsOperatorSession(player.SessionID)
+}
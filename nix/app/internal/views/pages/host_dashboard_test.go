@@ -580,11 +580,13 @@ func TestHostDashboardLobby_TreacheryModeUsesUnifiedRoleCountConfiguration(t *te
 		Players:    make(map[string]*game.Player),
 	}
 	host := &game.Player{
-		ID:     "host",
-		Name:   "Host",
-		IsHost: true,
+		ID:        "host",
+		Name:      "Host",
+		IsHost:    true,
+		SessionID: "session-host",
 	}
 	room.Players[host.ID] = host
+	room.OperatorSessionID = host.SessionID
 
 	renderer.Render(HostDashboardLobby(room, host, cfg, &game.CardService{})).
 		AssertContains("Role Count Configuration").
@@ -614,6 +616,41 @@ func TestHostDashboardLobby_TreacheryModeUsesUnifiedRoleCountConfiguration(t *te
 		AssertNotContains("Advanced Options")
 }
 
+func TestHostDashboardLobby_TreacheryStartChecklist(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+	cfg := config.DefaultConfig()
+	room := &game.Room{
+		Code:      "CHECK",
+		State:     game.StateLobby,
+		RulesMode: game.RulesModeTreachery,
+		RoleConfig: &game.RoleConfiguration{
+			PresetName: "custom",
+			RoleTypes: map[string]*game.RoleTypeConfig{
+				"Leader":   {Count: 0},
+				"Guardian": {Count: 1},
+			},
+		},
+		Players: make(map[string]*game.Player),
+	}
+	host := &game.Player{ID: "host", Name: "Host", IsHost: true, SessionID: "session-host"}
+	room.Players[host.ID] = host
+	room.Players["p1"] = &game.Player{ID: "p1", Name: "Player One"}
+	room.Players["p2"] = &game.Player{ID: "p2", Name: "Player Two"}
+	room.OperatorSessionID = host.SessionID
+
+	renderer.Render(HostDashboardStartControls(room, cfg)).
+		AssertContains(`aria-describedby="role-count-validation"`).
+		AssertContains(`id="role-count-validation"`).
+		AssertContains(`data-check="players" data-severity="ok"`).
+		AssertContains(`href="#player-list"`).
+		AssertContains("2 players joined").
+		AssertContains(`data-check="roles" data-severity="blocking"`).
+		AssertContains(`href="#treachery-role-counts"`).
+		AssertContains(`data-check="leader" data-severity="blocking"`).
+		AssertContains(`href="#allow-leaderless"`).
+		AssertContains("Blocking:")
+}
+
 func TestHostDashboardLobby_DebugControlSurfaceRequiresRoomOperator(t *testing.T) {
 	renderer := testhelpers.NewTemplateRenderer(t)
 	room := &game.Room{
@@ -11,7 +11,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("renders join page structure", func(t *testing.T) {
 		roomCode := "ABC12"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertNotEmpty().
@@ -23,7 +23,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("has join form with correct structure", func(t *testing.T) {
 		roomCode := "XYZ99"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertHasElement("form").
@@ -36,7 +36,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("displays error message when provided", func(t *testing.T) {
 		roomCode := "ABC12"
 		errorMsg := "Room is full"
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertContains(errorMsg).
@@ -46,7 +46,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("does not show error section when no error", func(t *testing.T) {
 		roomCode := "ABC12"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertNotContains("alert-error")
@@ -55,7 +55,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("has submit button", func(t *testing.T) {
 		roomCode := "TEST1"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertHasElement("button").
@@ -66,7 +66,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("input field has proper attributes", func(t *testing.T) {
 		roomCode := "ROOM1"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertContains(`type="text"`).
@@ -76,7 +76,7 @@ func TestJoinPage(t *testing.T) {
 	t.Run("has datastar attributes for real-time updates", func(t *testing.T) {
 		roomCode := "LIVE1"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		// Data-store attributes were removed from the template
 		renderer.Render(component).
@@ -86,10 +86,18 @@ func TestJoinPage(t *testing.T) {
 	t.Run("room code is properly displayed", func(t *testing.T) {
 		roomCode := "GAME7"
 		errorMsg := ""
-		component := Join(roomCode, errorMsg)
+		component := Join(roomCode, errorMsg, false)
 
 		renderer.Render(component).
 			AssertContains("Join Game Room").
 			AssertContains(roomCode)
 	})
+
+	t.Run("asks for the password of a private room", func(t *testing.T) {
+		renderer.Render(Join("PRIV1", "", true)).
+			AssertHasElementWithID("join-room-password").
+			AssertContains(`name="join_password"`)
+		renderer.Render(Join("OPEN1", "", false)).
+			AssertNotContains(`name="join_password"`)
+	})
 }
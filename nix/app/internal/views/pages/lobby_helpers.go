@@ -1,8 +1,10 @@
 package pages
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"treacherest/internal/config"
 	"treacherest/internal/game"
 )
 
@@ -36,6 +38,89 @@ func LobbyWaitingStatus(room *game.Room) string {
 	return fmt.Sprintf("Waiting for Room Operator - %d of %d seats filled", room.GetActivePlayerCount(), lobbySeatCount(room))
 }
 
+// LobbyLargeRoom reports whether the lobby roster runs in large-room mode:
+// names and counts only, kept current by patching LobbyRosterSignals rather
+// than re-rendering the roster for every player on every join
+func LobbyLargeRoom(cfg *config.ServerConfig, room *game.Room) bool {
+	if cfg == nil || room == nil || cfg.Server.LargeRoomThreshold <= 0 {
+		return false
+	}
+	return room.GetActivePlayerCount() > cfg.Server.LargeRoomThreshold
+}
+
+// LobbyRosterSignals is the signal state behind the large-room roster
+func LobbyRosterSignals(room *game.Room) map[string]interface{} {
+	names := []string{}
+	for _, player := range room.GetActivePlayers() {
+		names = append(names, player.Name)
+	}
+	return map[string]interface{}{
+		"lobbyRoster":      names,
+		"lobbyPlayerCount": room.GetActivePlayerCount(),
+		"lobbySeatCount":   lobbySeatCount(room),
+	}
+}
+
+func lobbyRosterSignalsJSON(room *game.Room) string {
+	data, err := json.Marshal(LobbyRosterSignals(room))
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// RoleDistributionSlice is one role's share of the table in the lobby's role
+// distribution summary
+type RoleDistributionSlice struct {
+	Role  game.RoleType
+	Count int
+}
+
+// RoleDistribution returns the configured role counts players may see, in
+// table order; nil when the Room Operator hides the distribution, roles are
+// fully random, or the room plays Coup
+func RoleDistribution(room *game.Room) []RoleDistributionSlice {
+	if room == nil || room.RulesMode == game.RulesModeCoup || room.RoleConfig == nil {
+		return nil
+	}
+	if room.RoleConfig.HideRoleDistribution || room.RoleConfig.FullyRandomRoles {
+		return nil
+	}
+	var slices []RoleDistributionSlice
+	for _, role := range game.ConfiguredRoleTypes(room.RoleConfig) {
+		if typeConfig := room.RoleConfig.RoleTypes[string(role)]; typeConfig != nil && typeConfig.Count > 0 {
+			slices = append(slices, RoleDistributionSlice{Role: role, Count: typeConfig.Count})
+		}
+	}
+	return slices
+}
+
+// RoleDistributionSummary reads like "1 Leader · 3 Guardians · 2 Assassins"
+func RoleDistributionSummary(slices []RoleDistributionSlice) string {
+	parts := make([]string, 0, len(slices))
+	for _, slice := range slices {
+		label := string(slice.Role)
+		if slice.Count != 1 {
+			label += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", slice.Count, label))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func roleDistributionBarClass(role game.RoleType) string {
+	switch role {
+	case game.RoleLeader:
+		return "bg-warning"
+	case game.RoleGuardian:
+		return "bg-info"
+	case game.RoleAssassin:
+		return "bg-error"
+	default:
+		return "bg-neutral"
+	}
+}
+
 func lobbySeatCount(room *game.Room) int {
 	if room == nil {
 		return 0
@@ -92,3 +177,22 @@ func coupKingKnowledgeSummary(room *game.Room) string {
 		return "Full King knowledge"
 	}
 }
+
+// rulesReferencePath links to the rules reference's section for mode
+func rulesReferencePath(mode game.RulesMode) string {
+	if mode != game.RulesModeCoup {
+		mode = game.RulesModeTreachery
+	}
+	return "/rules#rules-" + string(mode)
+}
+
+// RoomQRCodeSrc is the room's join QR code image. A private room's code
+// carries a one-time token, so the address changes each time one is used
+// and the code on screen is fetched again.
+func RoomQRCodeSrc(room *game.Room) string {
+	src := "/room/" + room.Code + "/qr.png"
+	if room.HasJoinPassword() {
+		src += fmt.Sprintf("?v=%d", room.UsedJoinTokens())
+	}
+	return src
+}
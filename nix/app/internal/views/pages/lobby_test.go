@@ -42,6 +42,7 @@ func TestLobbyPage(t *testing.T) {
 
 	// Create config and card service
 	cfg := config.DefaultConfig()
+	testhelpers.RequireCardAssets(t)
 	cardService, err := game.NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create card service: %v", err)
@@ -63,7 +64,7 @@ func TestLobbyPage(t *testing.T) {
 		component := LobbyPage(room, player1, cfg, cardService)
 
 		renderer.Render(component).
-			AssertContains(`data-init="@get(&#39;/sse/lobby/TEST1&#39;)"`)
+			AssertContains(`data-init="window.roomSocket ? roomSocket.subscribe(el, &#39;TEST1&#39;, &#39;lobby&#39;) : @get(&#39;/sse/room/TEST1?view=lobby&#39;)"`)
 	})
 
 	t.Run("renders player list", func(t *testing.T) {
@@ -242,7 +243,7 @@ func TestLobbyPage(t *testing.T) {
 
 		renderer.Render(component).
 			AssertNotContains("coup-preset-form").
-			AssertNotContains(`@post(&#39;/room/COUP3/start&#39;)`).
+			AssertNotContains(`@post(&#39;/room/COUP3/start?view=lobby&#39;)`).
 			AssertContains("Leave Room")
 	})
 
@@ -466,6 +467,87 @@ func TestLobbySettingsSummary(t *testing.T) {
 	}
 }
 
+func TestRoleDistributionCard(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+	room := &game.Room{
+		Code:    "DIST1",
+		State:   game.StateLobby,
+		Players: make(map[string]*game.Player),
+		RoleConfig: &game.RoleConfiguration{
+			PresetName: "custom",
+			RoleTypes: map[string]*game.RoleTypeConfig{
+				"Leader":   {Count: 1},
+				"Guardian": {Count: 3},
+				"Assassin": {Count: 2},
+				"Traitor":  {Count: 1},
+			},
+		},
+	}
+
+	renderer.Render(RoleDistributionCard(room)).
+		AssertHasElementWithID("role-distribution").
+		AssertContains("1 Leader · 3 Guardians · 2 Assassins · 1 Traitor").
+		AssertContains("flex-grow: 3")
+
+	room.RoleConfig.HideRoleDistribution = true
+	renderer.Render(RoleDistributionCard(room)).
+		AssertHasElementWithID("role-distribution").
+		AssertNotContains("role-distribution-summary")
+
+	room.RoleConfig.HideRoleDistribution = false
+	room.RoleConfig.FullyRandomRoles = true
+	renderer.Render(RoleDistributionCard(room)).
+		AssertNotContains("role-distribution-summary").
+		AssertContains("Fully random roles")
+}
+
+func TestLargeLobbyRoster(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+	cfg := config.DefaultConfig()
+	cfg.Server.LargeRoomThreshold = 2
+
+	room := &game.Room{
+		Code:       "LARGE",
+		State:      game.StateLobby,
+		Players:    make(map[string]*game.Player),
+		MaxPlayers: 20,
+	}
+	for _, name := range []string{"Alice", "Bob"} {
+		player := game.NewPlayer("p-"+name, name, "session-"+name)
+		room.Players[player.ID] = player
+	}
+	viewer := room.Players["p-Alice"]
+
+	if LobbyLargeRoom(cfg, room) {
+		t.Fatal("expected a room at the threshold to keep the full roster")
+	}
+	renderer.Render(LobbyPlayerList(room, viewer, cfg)).
+		AssertContains("open-seat-3").
+		AssertNotContains("large-roster")
+
+	room.Players["p-Carol"] = game.NewPlayer("p-Carol", "Carol", "session-Carol")
+	if !LobbyLargeRoom(cfg, room) {
+		t.Fatal("expected a room above the threshold to switch to large-room mode")
+	}
+	renderer.Render(LobbyPlayerList(room, viewer, cfg)).
+		AssertHasElementWithID("player-list-card").
+		AssertHasElementWithID("large-roster").
+		AssertContains("3 of 20 seats filled").
+		AssertContains("Carol").
+		AssertContains("&#34;lobbyPlayerCount&#34;:3").
+		AssertNotContains("open-seat-")
+
+	signals := LobbyRosterSignals(room)
+	if names, _ := signals["lobbyRoster"].([]string); len(names) != 3 {
+		t.Errorf("expected three names in the roster signal, got %v", signals["lobbyRoster"])
+	}
+
+	cfg.Server.LargeRoomThreshold = 0
+	if LobbyLargeRoom(cfg, room) {
+		t.Error("expected a zero threshold to disable large-room mode")
+	}
+}
+
 func TestLobbyBody(t *testing.T) {
 	renderer := testhelpers.NewTemplateRenderer(t)
 
@@ -487,6 +569,7 @@ func TestLobbyBody(t *testing.T) {
 
 	// Create config and card service
 	cfg := config.DefaultConfig()
+	testhelpers.RequireCardAssets(t)
 	cardService, err := game.NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create card service: %v", err)
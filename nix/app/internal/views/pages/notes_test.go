@@ -0,0 +1,28 @@
+package pages
+
+import (
+	"strings"
+	"testing"
+	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
+)
+
+func TestPlayerNotesPanel_OnlyRendersOwnNotes(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	alice := &game.Player{ID: "p1", Name: "Alice", Notes: "Bob is the Assassin"}
+	bob := &game.Player{ID: "p2", Name: "Bob"}
+	room := &game.Room{Code: "NOTE1", State: game.StatePlaying, Players: map[string]*game.Player{"p1": alice, "p2": bob}}
+
+	html := renderer.Render(PlayerNotesPanel(room, alice)).GetHTML()
+	for _, expected := range []string{"Bob is the Assassin", "/room/NOTE1/notes", "data-ignore-morph"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected notes panel to contain %q in %s", expected, html)
+		}
+	}
+
+	html = renderer.Render(GameContent(room, bob)).GetHTML()
+	if strings.Contains(html, "Bob is the Assassin") {
+		t.Errorf("another player's notes leaked into Bob's render: %s", html)
+	}
+}
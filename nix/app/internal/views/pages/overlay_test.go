@@ -0,0 +1,59 @@
+package pages
+
+import (
+	"strings"
+	"testing"
+	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
+)
+
+func TestOverlayContent_ShowsOnlyPublicState(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	room := &game.Room{
+		Code:    "OVR01",
+		State:   game.StatePlaying,
+		Players: make(map[string]*game.Player),
+	}
+	leader := &game.Player{ID: "p1", Name: "Alice", Role: &game.Card{Name: "The Blood Empress", Types: game.CardTypes{Subtype: "Leader"}}}
+	traitor := &game.Player{ID: "p2", Name: "Bob", Role: &game.Card{Name: "The Puppet Master", Types: game.CardTypes{Subtype: "Traitor"}}}
+	host := &game.Player{ID: "h", Name: "Hosty", IsHost: true}
+	room.Players[leader.ID] = leader
+	room.Players[traitor.ID] = traitor
+	room.Players[host.ID] = host
+
+	html := renderer.Render(OverlayContent(room)).GetHTML()
+	if strings.Contains(html, `id="overlay-leader"`) {
+		t.Fatalf("leader should stay hidden until revealed: %s", html)
+	}
+
+	room.LeaderRevealed = true
+	html = renderer.Render(OverlayContent(room)).GetHTML()
+	for _, expected := range []string{"Alice", "Bob", "The Blood Empress", `id="overlay-players"`} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected overlay to contain %q in %s", expected, html)
+		}
+	}
+	for _, forbidden := range []string{"The Puppet Master", "Hosty"} {
+		if strings.Contains(html, forbidden) {
+			t.Errorf("overlay leaked %q in %s", forbidden, html)
+		}
+	}
+}
+
+func TestOverlayPage_StreamsWithToken(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	room := &game.Room{Code: "OVR02", State: game.StateCountdown, CountdownRemaining: 3, Players: make(map[string]*game.Player)}
+	html := renderer.Render(OverlayPage(room, "abc123")).GetHTML()
+
+	for _, expected := range []string{
+		"/sse/overlay/OVR02?token=abc123",
+		`id="overlay-countdown"`,
+		"background: transparent",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected overlay page to contain %q in %s", expected, html)
+		}
+	}
+}
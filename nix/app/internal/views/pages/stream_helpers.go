@@ -0,0 +1,10 @@
+package pages
+
+import "fmt"
+
+// roomStreamInit is the data-init that opens a room page's stream: the
+// /sse/room/{code} stream, or the same view over /ws/room/{code} when the
+// browser has chosen the WebSocket transport (see the base layout)
+func roomStreamInit(roomCode, view string) string {
+	return fmt.Sprintf(`window.roomSocket ? roomSocket.subscribe(el, '%[1]s', '%[2]s') : @get('/sse/room/%[1]s?view=%[2]s')`, roomCode, view)
+}
@@ -0,0 +1,89 @@
+package pages
+
+import (
+	"strings"
+	"testing"
+	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
+)
+
+func TestVotePanel_HidesTallyUnlessPublic(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	alice := &game.Player{ID: "p1", Name: "Alice"}
+	bob := &game.Player{ID: "p2", Name: "Bob"}
+	room := &game.Room{Code: "VOTE1", State: game.StatePlaying, Players: map[string]*game.Player{"p1": alice, "p2": bob}}
+	if err := room.OpenVote("Exile a player", game.PlayerVoteOptions([]*game.Player{alice, bob}), false); err != nil {
+		t.Fatalf("open vote: %v", err)
+	}
+	room.CastVote("p1", "p2")
+
+	html := renderer.Render(VotePanel(room, alice)).GetHTML()
+	for _, expected := range []string{"Exile a player", "/room/VOTE1/vote/cast/p2", "You can change it once"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected vote panel to contain %q in %s", expected, html)
+		}
+	}
+	if strings.Contains(html, "badge") {
+		t.Errorf("private vote should not show tallies: %s", html)
+	}
+
+	room.Vote.PublicTally = true
+	html = renderer.Render(VotePanel(room, alice)).GetHTML()
+	if !strings.Contains(html, "badge") {
+		t.Errorf("public vote should show tallies: %s", html)
+	}
+
+	alice.IsEliminated = true
+	if html := renderer.Render(VotePanel(room, alice)).GetHTML(); strings.Contains(html, "vote-panel") {
+		t.Errorf("eliminated players should not see the ballot: %s", html)
+	}
+}
+
+func TestHostVotePanel_ShowsTallyAndLog(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	room := &game.Room{Code: "VOTE2", State: game.StatePlaying, Players: make(map[string]*game.Player)}
+	html := renderer.Render(HostVotePanel(room)).GetHTML()
+	if !strings.Contains(html, `id="operator-open-vote"`) {
+		t.Fatalf("expected the open-vote form, got %s", html)
+	}
+
+	room.OpenVote("Skip lunch?", []game.VoteOption{{ID: "o1", Label: "Yes"}, {ID: "o2", Label: "No"}}, false)
+	room.CastVote("p1", "o1")
+	html = renderer.Render(HostVotePanel(room)).GetHTML()
+	for _, expected := range []string{`id="operator-vote-tally"`, "1 ballots cast", "/room/VOTE2/vote/close"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected operator panel to contain %q in %s", expected, html)
+		}
+	}
+
+	room.CloseVote()
+	html = renderer.Render(GameLogPanel(room)).GetHTML()
+	if !strings.Contains(html, "Skip lunch?") || !strings.Contains(html, "Yes") {
+		t.Errorf("expected the closed vote in the game log, got %s", html)
+	}
+}
+
+func TestLobbyPoll_AlwaysRendersPatchTarget(t *testing.T) {
+	renderer := testhelpers.NewTemplateRenderer(t)
+
+	alice := &game.Player{ID: "p1", Name: "Alice"}
+	room := &game.Room{Code: "POLL3", State: game.StateLobby, Players: map[string]*game.Player{"p1": alice}}
+	html := renderer.Render(LobbyPoll(room, alice)).GetHTML()
+	if !strings.Contains(html, `id="lobby-poll"`) || strings.Contains(html, "<button") {
+		t.Fatalf("expected an empty poll target, got %s", html)
+	}
+
+	room.OpenPoll("Which preset tonight?", []game.VoteOption{{ID: "standard", Label: "Standard"}, {ID: "chaos", Label: "Chaos"}})
+	room.CastPollVote("p1", "chaos")
+	html = renderer.Render(LobbyPoll(room, alice)).GetHTML()
+	for _, expected := range []string{"Which preset tonight?", "/room/POLL3/poll/vote/standard", "anonymous"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected lobby poll to contain %q in %s", expected, html)
+		}
+	}
+	if strings.Contains(html, "Alice") {
+		t.Errorf("poll should not name voters: %s", html)
+	}
+}
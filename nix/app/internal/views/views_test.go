@@ -7,6 +7,7 @@ import (
 	"treacherest"
 	"treacherest/internal/config"
 	"treacherest/internal/game"
+	"treacherest/internal/testhelpers"
 	"treacherest/internal/views/layouts"
 	"treacherest/internal/views/pages"
 )
@@ -41,6 +42,7 @@ func TestTemplateRendering(t *testing.T) {
 
 	// Create config and card service
 	cfg := config.DefaultConfig()
+	testhelpers.RequireCardAssets(t)
 	cardService, err := game.NewCardService(treacherest.TreacheryCardsJSON, treacherest.CardImagesFS)
 	if err != nil {
 		t.Fatalf("Failed to create card service: %v", err)
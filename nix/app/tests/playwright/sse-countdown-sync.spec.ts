@@ -186,27 +186,27 @@ test.describe('SSE Countdown Synchronization', () => {
     // Take screenshot to see what page we're on
     await page.screenshot({ path: 'test-results/debug-lobby-page.png' });
     
-    // Should have one SSE connection to lobby
+    // Should have one SSE connection to the room
     // Wait longer for Datastar to load and SSE connection to establish
     await page.waitForTimeout(3000);
     
     console.log(`All SSE connections: ${JSON.stringify(sseConnections)}`);
-    const lobbyConnections = sseConnections.filter(url => url.includes('/sse/lobby/'));
-    console.log(`Lobby SSE connections: ${JSON.stringify(lobbyConnections)}`);
+    const lobbyConnections = sseConnections.filter(url => url.includes('/sse/room/'));
+    console.log(`Room SSE connections: ${JSON.stringify(lobbyConnections)}`);
     
     expect(lobbyConnections).toHaveLength(1);
     
     // Start game
     await page.click('button:has-text("Start Game")');
     
-    // Should redirect and create one SSE connection to game
+    // The game body is swapped in and the address moves to /game on the
+    // same room stream, without opening another
     await page.waitForURL(/\/game\//);
     await page.waitForTimeout(1000);
     
-    const gameConnections = sseConnections.filter(url => url.includes('/sse/game/'));
-    console.log(`Game SSE connections: ${gameConnections.length}`);
+    const roomConnections = sseConnections.filter(url => url.includes('/sse/room/'));
+    console.log(`Room SSE connections: ${roomConnections.length}`);
     
-    // Should only have ONE game SSE connection (not multiple)
-    expect(gameConnections).toHaveLength(1);
+    expect(roomConnections).toHaveLength(1);
   });
 });